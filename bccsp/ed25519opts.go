@@ -0,0 +1,72 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bccsp
+
+// ED25519KeyGenOpts contains options for ED25519 key generation.
+type ED25519KeyGenOpts struct {
+	Temporary bool
+}
+
+// Algorithm returns the key generation algorithm identifier (to be used).
+func (opts *ED25519KeyGenOpts) Algorithm() string {
+	return ED25519
+}
+
+// Ephemeral returns true if the key to generate has to be ephemeral,
+// false otherwise.
+func (opts *ED25519KeyGenOpts) Ephemeral() bool {
+	return opts.Temporary
+}
+
+// ED25519PKIXPublicKeyImportOpts contains options for ED25519 public key importation in PKIX format
+type ED25519PKIXPublicKeyImportOpts struct {
+	Temporary bool
+}
+
+// Algorithm returns the key importation algorithm identifier (to be used).
+func (opts *ED25519PKIXPublicKeyImportOpts) Algorithm() string {
+	return ED25519
+}
+
+// Ephemeral returns true if the key to generate has to be ephemeral,
+// false otherwise.
+func (opts *ED25519PKIXPublicKeyImportOpts) Ephemeral() bool {
+	return opts.Temporary
+}
+
+// ED25519PrivateKeyImportOpts contains options for ED25519 secret key importation in DER format
+// or PKCS#8 format.
+type ED25519PrivateKeyImportOpts struct {
+	Temporary bool
+}
+
+// Algorithm returns the key importation algorithm identifier (to be used).
+func (opts *ED25519PrivateKeyImportOpts) Algorithm() string {
+	return ED25519
+}
+
+// Ephemeral returns true if the key to generate has to be ephemeral,
+// false otherwise.
+func (opts *ED25519PrivateKeyImportOpts) Ephemeral() bool {
+	return opts.Temporary
+}
+
+// ED25519GoPublicKeyImportOpts contains options for ED25519 key importation from ed25519.PublicKey
+type ED25519GoPublicKeyImportOpts struct {
+	Temporary bool
+}
+
+// Algorithm returns the key importation algorithm identifier (to be used).
+func (opts *ED25519GoPublicKeyImportOpts) Algorithm() string {
+	return ED25519
+}
+
+// Ephemeral returns true if the key to generate has to be ephemeral,
+// false otherwise.
+func (opts *ED25519GoPublicKeyImportOpts) Ephemeral() bool {
+	return opts.Temporary
+}
@@ -0,0 +1,38 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package factory
+
+import (
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/kms"
+	"github.com/hyperledger/fabric/bccsp/sw"
+	"github.com/pkg/errors"
+)
+
+const (
+	// KMSBasedFactoryName is the name of the factory of the BCCSP backed by
+	// a remote signing daemon
+	KMSBasedFactoryName = "KMS"
+)
+
+// KMSFactory is the factory of the BCCSP backed by a remote signing daemon.
+type KMSFactory struct{}
+
+// Name returns the name of this factory
+func (f *KMSFactory) Name() string {
+	return KMSBasedFactoryName
+}
+
+// Get returns an instance of BCCSP using Opts.
+func (f *KMSFactory) Get(config *FactoryOpts) (bccsp.BCCSP, error) {
+	// Validate arguments
+	if config == nil || config.KMS == nil {
+		return nil, errors.New("Invalid config. It must not be nil.")
+	}
+
+	return kms.New(*config.KMS, sw.NewDummyKeyStore())
+}
@@ -0,0 +1,64 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package factory
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/kms"
+	"github.com/hyperledger/fabric/bccsp/kms/kmstest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKMSFactoryName(t *testing.T) {
+	f := &KMSFactory{}
+	require.Equal(t, f.Name(), KMSBasedFactoryName)
+}
+
+func TestKMSFactoryGetInvalidArgs(t *testing.T) {
+	f := &KMSFactory{}
+
+	_, err := f.Get(nil)
+	require.EqualError(t, err, "Invalid config. It must not be nil.")
+
+	_, err = f.Get(&FactoryOpts{})
+	require.EqualError(t, err, "Invalid config. It must not be nil.")
+
+	_, err = f.Get(&FactoryOpts{KMS: &kms.KMSOpts{}})
+	require.EqualError(t, err, "Failed initializing configuration: Security level not supported [0]")
+}
+
+func TestKMSFactoryGet(t *testing.T) {
+	server := kmstest.NewServer()
+	address, err := server.Start()
+	require.NoError(t, err)
+	defer server.Stop()
+
+	opts := &FactoryOpts{
+		Default: "KMS",
+		KMS: &kms.KMSOpts{
+			Security: 256,
+			Hash:     "SHA2",
+			Address:  address,
+		},
+	}
+	csp, err := GetBCCSPFromOpts(opts)
+	require.NoError(t, err)
+	require.IsType(t, &kms.Provider{}, csp)
+
+	k, err := csp.KeyGen(&bccsp.ECDSAKeyGenOpts{})
+	require.NoError(t, err)
+	digest, err := csp.Hash([]byte("hello"), &bccsp.SHA256Opts{})
+	require.NoError(t, err)
+	signature, err := csp.Sign(k, digest, nil)
+	require.NoError(t, err)
+	valid, err := csp.Verify(k, signature, digest, nil)
+	require.NoError(t, err)
+	require.True(t, valid)
+	require.Equal(t, 1, server.SignCount())
+}
@@ -0,0 +1,208 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package factory
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"sort"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/sw"
+	"github.com/pkg/errors"
+)
+
+// Outcomes of the migration of a key.
+const (
+	// KeyMigrated means the key has been imported into the target BCCSP.
+	KeyMigrated = "migrated"
+	// KeyPresent means the key was already available in the target BCCSP.
+	KeyPresent = "present"
+	// KeyFailed means the key could not be imported or failed verification.
+	KeyFailed = "failed"
+)
+
+// KeyMigrationResult reports the outcome of the migration of one key.
+type KeyMigrationResult struct {
+	SKI       string `json:"ski"`
+	Algorithm string `json:"algorithm"`
+	Status    string `json:"status"`
+	Error     string `json:"error,omitempty"`
+}
+
+// KeyMigrationReport reports the outcome of a key store migration.
+type KeyMigrationReport struct {
+	Source string               `json:"source"`
+	Target string               `json:"target"`
+	Keys   []KeyMigrationResult `json:"keys"`
+}
+
+// Failed returns the number of keys that could not be migrated.
+func (r *KeyMigrationReport) Failed() int {
+	failed := 0
+	for _, k := range r.Keys {
+		if k.Status == KeyFailed {
+			failed++
+		}
+	}
+	return failed
+}
+
+// MigrateKeys copies the private keys of the file based key store configured
+// in source to the BCCSP configured in target. When skis is not empty, only
+// the keys with the given hex encoded SKIs are migrated.
+//
+// Keys imported into a PKCS11 BCCSP are stored under the CKA_ID the target
+// options associate to their SKI. Every key is verified with a sign/verify
+// round trip through the target BCCSP, and the outcome of each migration is
+// collected in the report. An error is returned only when the migration
+// cannot be attempted at all.
+func MigrateKeys(source, target *FactoryOpts, skis ...string) (*KeyMigrationReport, error) {
+	if source == nil || target == nil {
+		return nil, errors.New("Invalid config. It must not be nil.")
+	}
+	if source.Default != SoftwareBasedFactoryName || source.SW == nil || source.SW.FileKeystore == nil {
+		return nil, errors.New("the source BCCSP must be SW with a file based key store")
+	}
+
+	pwd, err := source.SW.FileKeystore.password()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed reading source key store password")
+	}
+	keys, err := sw.ExportPrivateKeys(source.SW.FileKeystore.KeyStorePath, pwd)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed exporting source keys")
+	}
+
+	if len(skis) == 0 {
+		for ski := range keys {
+			skis = append(skis, ski)
+		}
+		sort.Strings(skis)
+	}
+
+	csp, err := GetBCCSPFromOpts(target)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &KeyMigrationReport{
+		Source: source.SW.FileKeystore.KeyStorePath,
+		Target: target.Default,
+	}
+	for _, ski := range skis {
+		result := KeyMigrationResult{SKI: ski}
+		der, ok := keys[ski]
+		if !ok {
+			result.Status = KeyFailed
+			result.Error = "key not found in source key store"
+			report.Keys = append(report.Keys, result)
+			continue
+		}
+
+		result.Algorithm, result.Status, err = migrateKey(csp, ski, der)
+		if err != nil {
+			result.Status = KeyFailed
+			result.Error = err.Error()
+		}
+		report.Keys = append(report.Keys, result)
+	}
+
+	return report, nil
+}
+
+func migrateKey(csp bccsp.BCCSP, ski string, der []byte) (algorithm, status string, err error) {
+	key, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return "", "", errors.Wrap(err, "failed parsing private key")
+	}
+
+	var opts bccsp.KeyImportOpts
+	var pub crypto.PublicKey
+	switch key := key.(type) {
+	case *ecdsa.PrivateKey:
+		algorithm = bccsp.ECDSA
+		opts = &bccsp.ECDSAPrivateKeyImportOpts{Temporary: false}
+		pub = &key.PublicKey
+	case ed25519.PrivateKey:
+		algorithm = bccsp.ED25519
+		opts = &bccsp.ED25519PrivateKeyImportOpts{Temporary: false}
+		pub = key.Public()
+	default:
+		return "", "", errors.Errorf("unsupported private key type %T", key)
+	}
+
+	rawSKI, err := hex.DecodeString(ski)
+	if err != nil {
+		return algorithm, "", errors.Wrap(err, "invalid SKI")
+	}
+
+	status = KeyPresent
+	k, err := csp.GetKey(rawSKI)
+	if err != nil || !k.Private() {
+		status = KeyMigrated
+		k, err = csp.KeyImport(der, opts)
+		if err != nil {
+			return algorithm, "", errors.WithMessage(err, "failed importing private key")
+		}
+		// Look the key up again to make sure it can be found by SKI
+		k, err = csp.GetKey(rawSKI)
+		if err != nil {
+			return algorithm, "", errors.WithMessage(err, "imported key not found")
+		}
+	}
+
+	if err := verifyKey(csp, k, pub); err != nil {
+		return algorithm, "", err
+	}
+	return algorithm, status, nil
+}
+
+// verifyKey signs a random message with k and verifies the signature both
+// with the BCCSP and with the public key of the source key.
+func verifyKey(csp bccsp.BCCSP, k bccsp.Key, pub crypto.PublicKey) error {
+	msg := make([]byte, 32)
+	if _, err := rand.Read(msg); err != nil {
+		return errors.Wrap(err, "failed generating message")
+	}
+
+	digest := msg
+	if _, ok := pub.(*ecdsa.PublicKey); ok {
+		hash := sha256.Sum256(msg)
+		digest = hash[:]
+	}
+
+	signature, err := csp.Sign(k, digest, nil)
+	if err != nil {
+		return errors.WithMessage(err, "failed signing with migrated key")
+	}
+
+	valid, err := csp.Verify(k, signature, digest, nil)
+	if err != nil {
+		return errors.WithMessage(err, "failed verifying with migrated key")
+	}
+	if !valid {
+		return errors.New("signature of migrated key is not valid")
+	}
+
+	switch pub := pub.(type) {
+	case *ecdsa.PublicKey:
+		valid = ecdsa.VerifyASN1(pub, digest, signature)
+	case ed25519.PublicKey:
+		valid = ed25519.Verify(pub, digest, signature)
+	}
+	if !valid {
+		return errors.New("signature of migrated key does not match the source public key")
+	}
+
+	return nil
+}
@@ -0,0 +1,143 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package factory
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/sw"
+	"github.com/stretchr/testify/require"
+)
+
+// newSourceKeyStore creates a file based key store holding an ECDSA and an
+// ED25519 private key and returns its options and the SKIs of the keys.
+func newSourceKeyStore(t *testing.T) (*FactoryOpts, []string) {
+	opts := &FactoryOpts{
+		Default: "SW",
+		SW: &SwOpts{
+			Security:     256,
+			Hash:         "SHA2",
+			FileKeystore: &FileKeystoreOpts{KeyStorePath: t.TempDir()},
+		},
+	}
+	csp, err := (&SWFactory{}).Get(opts)
+	require.NoError(t, err)
+
+	var skis []string
+	for _, keyGenOpts := range []bccsp.KeyGenOpts{&bccsp.ECDSAP256KeyGenOpts{}, &bccsp.ED25519KeyGenOpts{}} {
+		k, err := csp.KeyGen(keyGenOpts)
+		require.NoError(t, err)
+		skis = append(skis, hex.EncodeToString(k.SKI()))
+	}
+	sort.Strings(skis)
+
+	return opts, skis
+}
+
+func TestMigrateKeys(t *testing.T) {
+	source, skis := newSourceKeyStore(t)
+
+	passwordFile := filepath.Join(t.TempDir(), "password")
+	require.NoError(t, os.WriteFile(passwordFile, []byte("passwd\n"), 0o600))
+	target := &FactoryOpts{
+		Default: "SW",
+		SW: &SwOpts{
+			Security: 256,
+			Hash:     "SHA2",
+			FileKeystore: &FileKeystoreOpts{
+				KeyStorePath: t.TempDir(),
+				PasswordFile: passwordFile,
+			},
+		},
+	}
+
+	report, err := MigrateKeys(source, target)
+	require.NoError(t, err)
+	require.Equal(t, source.SW.FileKeystore.KeyStorePath, report.Source)
+	require.Equal(t, "SW", report.Target)
+	require.Len(t, report.Keys, 2)
+	require.Zero(t, report.Failed())
+	for i, k := range report.Keys {
+		require.Equal(t, skis[i], k.SKI)
+		require.Equal(t, KeyMigrated, k.Status)
+		require.Empty(t, k.Error)
+	}
+	require.ElementsMatch(t, []string{bccsp.ECDSA, bccsp.ED25519}, []string{report.Keys[0].Algorithm, report.Keys[1].Algorithm})
+
+	// The target key store encrypts the migrated keys
+	migrated, err := sw.ExportPrivateKeys(target.SW.FileKeystore.KeyStorePath, []byte("passwd"))
+	require.NoError(t, err)
+	require.Len(t, migrated, 2)
+	_, err = sw.ExportPrivateKeys(target.SW.FileKeystore.KeyStorePath, nil)
+	require.Error(t, err)
+
+	// Running the migration again finds the keys in the target
+	report, err = MigrateKeys(source, target, skis[0], "0102")
+	require.NoError(t, err)
+	require.Equal(t, []KeyMigrationResult{
+		{SKI: skis[0], Algorithm: report.Keys[0].Algorithm, Status: KeyPresent},
+		{SKI: "0102", Status: KeyFailed, Error: "key not found in source key store"},
+	}, report.Keys)
+	require.Equal(t, 1, report.Failed())
+}
+
+func TestMigrateKeysInvalidSource(t *testing.T) {
+	target := &FactoryOpts{Default: "SW", SW: GetDefaultOpts().SW}
+
+	_, err := MigrateKeys(nil, target)
+	require.EqualError(t, err, "Invalid config. It must not be nil.")
+
+	_, err = MigrateKeys(&FactoryOpts{Default: "SW", SW: GetDefaultOpts().SW}, target)
+	require.EqualError(t, err, "the source BCCSP must be SW with a file based key store")
+
+	source, _ := newSourceKeyStore(t)
+	source.SW.FileKeystore.Password = "passwd"
+	source.SW.FileKeystore.PasswordFile = "password"
+	_, err = MigrateKeys(source, target)
+	require.EqualError(t, err, "failed reading source key store password: only one of Password and PasswordFile can be set")
+
+	source.SW.FileKeystore.PasswordFile = ""
+	require.NoError(t, os.WriteFile(filepath.Join(source.SW.FileKeystore.KeyStorePath, "bogus_sk"), []byte("bogus"), 0o600))
+	_, err = MigrateKeys(source, target)
+	require.ErrorContains(t, err, "failed exporting source keys: failed parsing private key bogus_sk")
+
+	source, _ = newSourceKeyStore(t)
+	_, err = MigrateKeys(source, &FactoryOpts{Default: "BOGUS"})
+	require.EqualError(t, err, "Could not find BCCSP, no 'BOGUS' provider")
+}
+
+func TestVerifyKey(t *testing.T) {
+	csp, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewDummyKeyStore())
+	require.NoError(t, err)
+
+	k, err := csp.KeyGen(&bccsp.ECDSAP256KeyGenOpts{Temporary: true})
+	require.NoError(t, err)
+	other, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	err = verifyKey(csp, k, &other.PublicKey)
+	require.EqualError(t, err, "signature of migrated key does not match the source public key")
+
+	k, err = csp.KeyGen(&bccsp.ED25519KeyGenOpts{Temporary: true})
+	require.NoError(t, err)
+	pk, err := k.PublicKey()
+	require.NoError(t, err)
+	raw, err := pk.Bytes()
+	require.NoError(t, err)
+	pub, err := x509.ParsePKIXPublicKey(raw)
+	require.NoError(t, err)
+	require.NoError(t, verifyKey(csp, k, pub.(ed25519.PublicKey)))
+}
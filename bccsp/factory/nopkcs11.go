@@ -13,6 +13,7 @@ import (
 	"reflect"
 
 	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/kms"
 	"github.com/mitchellh/mapstructure"
 	"github.com/pkg/errors"
 )
@@ -21,8 +22,9 @@ const pkcs11Enabled = false
 
 // FactoryOpts holds configuration information used to initialize factory implementations
 type FactoryOpts struct {
-	Default string  `json:"default" yaml:"Default"`
-	SW      *SwOpts `json:"SW,omitempty" yaml:"SW,omitempty"`
+	Default string       `json:"default" yaml:"Default"`
+	SW      *SwOpts      `json:"SW,omitempty" yaml:"SW,omitempty"`
+	KMS     *kms.KMSOpts `json:"KMS,omitempty" yaml:"KMS,omitempty"`
 }
 
 // InitFactories must be called before using factory interfaces
@@ -61,6 +63,16 @@ func initFactories(config *FactoryOpts) error {
 		}
 	}
 
+	// KMS-Based BCCSP
+	if config.Default == "KMS" && config.KMS != nil {
+		f := &KMSFactory{}
+		var err error
+		defaultBCCSP, err = initBCCSP(f, config)
+		if err != nil {
+			return errors.Wrapf(err, "Failed initializing KMS.BCCSP")
+		}
+	}
+
 	if defaultBCCSP == nil {
 		return errors.Errorf("Could not find default `%s` BCCSP", config.Default)
 	}
@@ -74,6 +86,8 @@ func GetBCCSPFromOpts(config *FactoryOpts) (bccsp.BCCSP, error) {
 	switch config.Default {
 	case "SW":
 		f = &SWFactory{}
+	case "KMS":
+		f = &KMSFactory{}
 	default:
 		return nil, errors.Errorf("Could not find BCCSP, no '%s' provider", config.Default)
 	}
@@ -14,6 +14,7 @@ import (
 	"strings"
 
 	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/kms"
 	"github.com/hyperledger/fabric/bccsp/pkcs11"
 	"github.com/mitchellh/mapstructure"
 	"github.com/pkg/errors"
@@ -26,6 +27,7 @@ type FactoryOpts struct {
 	Default string             `json:"default" yaml:"Default"`
 	SW      *SwOpts            `json:"SW,omitempty" yaml:"SW,omitempty"`
 	PKCS11  *pkcs11.PKCS11Opts `json:"PKCS11,omitempty" yaml:"PKCS11"`
+	KMS     *kms.KMSOpts       `json:"KMS,omitempty" yaml:"KMS,omitempty"`
 }
 
 // InitFactories must be called before using factory interfaces
@@ -74,6 +76,16 @@ func initFactories(config *FactoryOpts) error {
 		}
 	}
 
+	// KMS-Based BCCSP
+	if config.Default == "KMS" && config.KMS != nil {
+		f := &KMSFactory{}
+		var err error
+		defaultBCCSP, err = initBCCSP(f, config)
+		if err != nil {
+			return errors.Wrapf(err, "Failed initializing KMS.BCCSP")
+		}
+	}
+
 	if defaultBCCSP == nil {
 		return errors.Errorf("Could not find default `%s` BCCSP", config.Default)
 	}
@@ -89,6 +101,8 @@ func GetBCCSPFromOpts(config *FactoryOpts) (bccsp.BCCSP, error) {
 		f = &SWFactory{}
 	case "PKCS11":
 		f = &PKCS11Factory{}
+	case "KMS":
+		f = &KMSFactory{}
 	default:
 		return nil, errors.Errorf("Could not find BCCSP, no '%s' provider", config.Default)
 	}
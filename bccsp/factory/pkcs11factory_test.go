@@ -14,6 +14,7 @@ import (
 	"encoding/hex"
 	"testing"
 
+	"github.com/hyperledger/fabric/bccsp"
 	"github.com/hyperledger/fabric/bccsp/pkcs11"
 	"github.com/stretchr/testify/require"
 )
@@ -119,3 +120,46 @@ func defaultOptions() *pkcs11.PKCS11Opts {
 		Label:    label,
 	}
 }
+
+func TestMigrateKeysToPKCS11(t *testing.T) {
+	source, _ := newSourceKeyStore(t)
+	csp, err := (&SWFactory{}).Get(source)
+	require.NoError(t, err)
+	k, err := csp.KeyGen(&bccsp.ECDSAP256KeyGenOpts{})
+	require.NoError(t, err)
+	ski := hex.EncodeToString(k.SKI())
+
+	p11Opts := defaultOptions()
+	p11Opts.KeyIDs = []pkcs11.KeyIDMapping{{SKI: ski, ID: "migrated-" + ski[:8]}}
+	target := &FactoryOpts{Default: "PKCS11", PKCS11: p11Opts}
+
+	report, err := MigrateKeys(source, target)
+	require.NoError(t, err)
+	require.Len(t, report.Keys, 3)
+	for _, result := range report.Keys {
+		switch result.Algorithm {
+		case bccsp.ECDSA:
+			require.Equal(t, KeyMigrated, result.Status, result.Error)
+		case bccsp.ED25519:
+			// The PKCS11 BCCSP does not support ED25519 keys
+			require.Equal(t, KeyFailed, result.Status)
+		}
+	}
+
+	// The key is found using the mapped identifier
+	p11CSP, err := GetBCCSPFromOpts(target)
+	require.NoError(t, err)
+	migrated, err := p11CSP.GetKey(k.SKI())
+	require.NoError(t, err)
+	require.True(t, migrated.Private())
+
+	// Without the mapping the key cannot be found on the token
+	p11CSP, err = GetBCCSPFromOpts(&FactoryOpts{Default: "PKCS11", PKCS11: defaultOptions()})
+	require.NoError(t, err)
+	_, err = p11CSP.GetKey(k.SKI())
+	require.Error(t, err)
+
+	report, err = MigrateKeys(source, target, ski)
+	require.NoError(t, err)
+	require.Equal(t, KeyPresent, report.Keys[0].Status)
+}
@@ -16,6 +16,9 @@ limitations under the License.
 package factory
 
 import (
+	"bytes"
+	"os"
+
 	"github.com/hyperledger/fabric/bccsp"
 	"github.com/hyperledger/fabric/bccsp/sw"
 	"github.com/pkg/errors"
@@ -46,7 +49,11 @@ func (f *SWFactory) Get(config *FactoryOpts) (bccsp.BCCSP, error) {
 	var ks bccsp.KeyStore
 	switch {
 	case swOpts.FileKeystore != nil:
-		fks, err := sw.NewFileBasedKeyStore(nil, swOpts.FileKeystore.KeyStorePath, false)
+		pwd, err := swOpts.FileKeystore.password()
+		if err != nil {
+			return nil, errors.Wrapf(err, "Failed to initialize software key store")
+		}
+		fks, err := sw.NewFileBasedKeyStoreWithKDF(pwd, swOpts.FileKeystore.KDF, swOpts.FileKeystore.KeyStorePath, false)
 		if err != nil {
 			return nil, errors.Wrapf(err, "Failed to initialize software key store")
 		}
@@ -70,4 +77,37 @@ type SwOpts struct {
 // Pluggable Keystores, could add JKS, P12, etc..
 type FileKeystoreOpts struct {
 	KeyStorePath string `yaml:"KeyStore"`
+	// Password, when set, encrypts the private keys written to the key
+	// store as PKCS#8 and decrypts them when loaded. It is usually supplied
+	// through the environment rather than the configuration file.
+	Password string `json:"-" yaml:"Password,omitempty"`
+	// PasswordFile is the path of a file holding the key store password.
+	// It is mutually exclusive with Password.
+	PasswordFile string `json:"passwordfile,omitempty" yaml:"PasswordFile,omitempty"`
+	// KDF is the key derivation function used to encrypt private keys,
+	// either PBKDF2 (default) or SCRYPT.
+	KDF string `json:"kdf,omitempty" yaml:"KDF,omitempty"`
+}
+
+// password returns the key store password, reading it from PasswordFile
+// when needed. Trailing newlines in the file are ignored.
+func (o *FileKeystoreOpts) password() ([]byte, error) {
+	switch {
+	case o.Password != "" && o.PasswordFile != "":
+		return nil, errors.New("only one of Password and PasswordFile can be set")
+	case o.PasswordFile != "":
+		pwd, err := os.ReadFile(o.PasswordFile)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed reading key store password file")
+		}
+		pwd = bytes.TrimRight(pwd, "\r\n")
+		if len(pwd) == 0 {
+			return nil, errors.Errorf("key store password file %s is empty", o.PasswordFile)
+		}
+		return pwd, nil
+	case o.Password != "":
+		return []byte(o.Password), nil
+	default:
+		return nil, nil
+	}
 }
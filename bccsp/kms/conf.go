@@ -0,0 +1,39 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kms
+
+import "time"
+
+const defaultTimeout = 10 * time.Second
+
+// KMSOpts contains options for the KMSFactory
+type KMSOpts struct {
+	// Default algorithms when not specified (Deprecated?)
+	Security int    `json:"security"`
+	Hash     string `json:"hash"`
+
+	// Address is the host:port of the signing daemon
+	Address string `json:"address"`
+	// Timeout bounds every call to the signing daemon
+	Timeout time.Duration `json:"timeout,omitempty"`
+	// TLS configures the connection to the signing daemon
+	TLS TLSOpts `json:"tls,omitempty"`
+}
+
+// TLSOpts holds the TLS settings used to reach the signing daemon.
+// Files are PEM encoded.
+type TLSOpts struct {
+	Enabled bool `json:"enabled,omitempty"`
+	// RootCert is the CA certificate file used to verify the daemon
+	RootCert string `json:"rootcert,omitempty"`
+	// ClientCert and ClientKey enable mutual TLS when both are set
+	ClientCert string `json:"clientcert,omitempty"`
+	ClientKey  string `json:"clientkey,omitempty"`
+	// ServerNameOverride overrides the host name used to verify the
+	// daemon certificate
+	ServerNameOverride string `json:"servernameoverride,omitempty"`
+}
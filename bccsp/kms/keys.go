@@ -0,0 +1,52 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kms
+
+import (
+	"crypto"
+	"errors"
+
+	"github.com/hyperledger/fabric/bccsp"
+)
+
+// privateKey is a handle to a private key held by the signing daemon.
+// The public part is kept locally as a software key so that verification
+// does not need a round trip.
+type privateKey struct {
+	ski    []byte
+	pubKey crypto.PublicKey
+	pub    bccsp.Key
+}
+
+// Bytes converts this key to its byte representation,
+// if this operation is allowed.
+func (k *privateKey) Bytes() ([]byte, error) {
+	return nil, errors.New("Not supported.")
+}
+
+// SKI returns the subject key identifier of this key.
+func (k *privateKey) SKI() []byte {
+	return k.ski
+}
+
+// Symmetric returns true if this key is a symmetric key,
+// false if this key is asymmetric
+func (k *privateKey) Symmetric() bool {
+	return false
+}
+
+// Private returns true if this key is a private key,
+// false otherwise.
+func (k *privateKey) Private() bool {
+	return true
+}
+
+// PublicKey returns the corresponding public key part of an asymmetric public/private key pair.
+// This method returns an error in symmetric key schemes.
+func (k *privateKey) PublicKey() (bccsp.Key, error) {
+	return k.pub, nil
+}
@@ -0,0 +1,332 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kms
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/sw"
+	"github.com/hyperledger/fabric/bccsp/utils"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+var logger = flogging.MustGetLogger("bccsp_kms")
+
+// Provider is a BCCSP that delegates key generation, key retrieval and
+// signing to an external signing daemon reached over gRPC. Private keys
+// never leave the daemon. Hashing, verification and key import are
+// performed locally by a software based BCCSP.
+type Provider struct {
+	bccsp.BCCSP
+
+	client    KeyManagementServiceClient
+	conn      *grpc.ClientConn
+	timeout   time.Duration
+	algorithm string
+
+	cacheLock sync.RWMutex
+	keyCache  map[string]bccsp.Key
+}
+
+// Ensure we satisfy the BCCSP interfaces.
+var _ bccsp.BCCSP = (*Provider)(nil)
+
+// An Option is used to configure the Provider.
+type Option func(p *Provider) error
+
+// WithClient returns an option that configures the Provider to use the
+// given client instead of dialing the address found in the options.
+func WithClient(client KeyManagementServiceClient) Option {
+	return func(p *Provider) error {
+		p.client = client
+		return nil
+	}
+}
+
+// New returns a new instance of a BCCSP that uses the signing daemon at
+// opts.Address to generate and use key pairs. The default ECDSA curve is
+// selected according to the security level in opts.
+//
+// All other cryptographic functions are delegated to a software based BCCSP
+// implementation that is configured to use the security level and hashing
+// family from opts and the key store that is provided.
+func New(opts KMSOpts, keyStore bccsp.KeyStore, options ...Option) (*Provider, error) {
+	algorithm, err := algorithmForSecurityLevel(opts.Security)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed initializing configuration")
+	}
+
+	swCSP, err := sw.NewWithParams(opts.Security, opts.Hash, keyStore)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed initializing fallback SW BCCSP")
+	}
+
+	csp := &Provider{
+		BCCSP:     swCSP,
+		timeout:   opts.Timeout,
+		algorithm: algorithm,
+		keyCache:  map[string]bccsp.Key{},
+	}
+	if csp.timeout <= 0 {
+		csp.timeout = defaultTimeout
+	}
+
+	for _, o := range options {
+		if err := o(csp); err != nil {
+			return nil, err
+		}
+	}
+
+	if csp.client == nil {
+		if opts.Address == "" {
+			return nil, errors.New("kms: signing daemon address not provided")
+		}
+		dialOpt, err := transportCredentials(opts.TLS)
+		if err != nil {
+			return nil, errors.WithMessage(err, "kms: failed loading TLS configuration")
+		}
+		csp.conn, err = grpc.Dial(opts.Address, dialOpt)
+		if err != nil {
+			return nil, errors.Wrapf(err, "kms: failed connecting to %s", opts.Address)
+		}
+		csp.client = NewKeyManagementServiceClient(csp.conn)
+	}
+
+	return csp, nil
+}
+
+func algorithmForSecurityLevel(securityLevel int) (string, error) {
+	switch securityLevel {
+	case 256:
+		return bccsp.ECDSAP256, nil
+	case 384:
+		return bccsp.ECDSAP384, nil
+	default:
+		return "", errors.Errorf("Security level not supported [%d]", securityLevel)
+	}
+}
+
+func transportCredentials(opts TLSOpts) (grpc.DialOption, error) {
+	if !opts.Enabled {
+		return grpc.WithTransportCredentials(insecure.NewCredentials()), nil
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: opts.ServerNameOverride,
+	}
+	if opts.RootCert != "" {
+		pem, err := os.ReadFile(opts.RootCert)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed reading root certificate")
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, errors.Errorf("no certificates found in %s", opts.RootCert)
+		}
+	}
+	if opts.ClientCert != "" || opts.ClientKey != "" {
+		cert, err := tls.LoadX509KeyPair(opts.ClientCert, opts.ClientKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed loading client key pair")
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)), nil
+}
+
+// Close releases the connection to the signing daemon.
+func (csp *Provider) Close() error {
+	if csp.conn == nil {
+		return nil
+	}
+	return csp.conn.Close()
+}
+
+// KeyGen generates a key using opts.
+func (csp *Provider) KeyGen(opts bccsp.KeyGenOpts) (bccsp.Key, error) {
+	// Validate arguments
+	if opts == nil {
+		return nil, errors.New("Invalid Opts parameter. It must not be nil")
+	}
+
+	var algorithm string
+	switch opts.(type) {
+	case *bccsp.ECDSAKeyGenOpts:
+		algorithm = csp.algorithm
+	case *bccsp.ECDSAP256KeyGenOpts:
+		algorithm = bccsp.ECDSAP256
+	case *bccsp.ECDSAP384KeyGenOpts:
+		algorithm = bccsp.ECDSAP384
+	case *bccsp.ED25519KeyGenOpts:
+		algorithm = bccsp.ED25519
+	default:
+		return csp.BCCSP.KeyGen(opts)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), csp.timeout)
+	defer cancel()
+	resp, err := csp.client.KeyGen(ctx, &KeyGenRequest{
+		Algorithm: algorithm,
+		Ephemeral: opts.Ephemeral(),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed generating %s key", algorithm)
+	}
+
+	k, err := csp.privateKeyFromResponse(resp)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "Failed generating %s key", algorithm)
+	}
+	csp.cacheKey(k.SKI(), k)
+
+	return k, nil
+}
+
+// GetKey returns the key this CSP associates to
+// the Subject Key Identifier ski.
+func (csp *Provider) GetKey(ski []byte) (bccsp.Key, error) {
+	if key, ok := csp.cachedKey(ski); ok {
+		return key, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), csp.timeout)
+	defer cancel()
+	resp, err := csp.client.GetKey(ctx, &GetKeyRequest{Ski: ski})
+	if err != nil {
+		logger.Debugf("Key not found using KMS: %v", err)
+		return csp.BCCSP.GetKey(ski)
+	}
+
+	k, err := csp.privateKeyFromResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(k.SKI(), ski) {
+		return nil, errors.Errorf("signing daemon returned key %x when %x was requested", k.SKI(), ski)
+	}
+	csp.cacheKey(ski, k)
+
+	return k, nil
+}
+
+// privateKeyFromResponse builds a handle to the remote private key,
+// checking that the identifier reported by the daemon matches the one
+// computed locally from the public key.
+func (csp *Provider) privateKeyFromResponse(resp *KeyResponse) (*privateKey, error) {
+	pubKey, err := x509.ParsePKIXPublicKey(resp.PublicKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid public key returned by signing daemon")
+	}
+
+	var opts bccsp.KeyImportOpts
+	switch pubKey.(type) {
+	case *ecdsa.PublicKey:
+		opts = &bccsp.ECDSAGoPublicKeyImportOpts{Temporary: true}
+	case ed25519.PublicKey:
+		opts = &bccsp.ED25519GoPublicKeyImportOpts{Temporary: true}
+	default:
+		return nil, errors.Errorf("unsupported public key type %T returned by signing daemon", pubKey)
+	}
+	pub, err := csp.BCCSP.KeyImport(pubKey, opts)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed importing public key")
+	}
+
+	if !bytes.Equal(pub.SKI(), resp.Ski) {
+		return nil, errors.Errorf("signing daemon returned SKI %x for a public key with SKI %x", resp.Ski, pub.SKI())
+	}
+
+	return &privateKey{ski: pub.SKI(), pubKey: pubKey, pub: pub}, nil
+}
+
+func (csp *Provider) cacheKey(ski []byte, key bccsp.Key) {
+	csp.cacheLock.Lock()
+	csp.keyCache[hex.EncodeToString(ski)] = key
+	csp.cacheLock.Unlock()
+}
+
+func (csp *Provider) cachedKey(ski []byte) (bccsp.Key, bool) {
+	csp.cacheLock.RLock()
+	defer csp.cacheLock.RUnlock()
+	key, ok := csp.keyCache[hex.EncodeToString(ski)]
+	return key, ok
+}
+
+// Sign signs digest using key k.
+// The opts argument should be appropriate for the primitive used.
+//
+// Note that when a signature of a hash of a larger message is needed,
+// the caller is responsible for hashing the larger message and passing
+// the hash (as digest).
+func (csp *Provider) Sign(k bccsp.Key, digest []byte, opts bccsp.SignerOpts) ([]byte, error) {
+	// Validate arguments
+	if k == nil {
+		return nil, errors.New("Invalid Key. It must not be nil")
+	}
+	if len(digest) == 0 {
+		return nil, errors.New("Invalid digest. Cannot be empty")
+	}
+
+	key, ok := k.(*privateKey)
+	if !ok {
+		return csp.BCCSP.Sign(k, digest, opts)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), csp.timeout)
+	defer cancel()
+	resp, err := csp.client.Sign(ctx, &SignRequest{Ski: key.ski, Digest: digest})
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed signing with KMS")
+	}
+
+	switch pub := key.pubKey.(type) {
+	case *ecdsa.PublicKey:
+		// Make sure the signature is in the canonical low-S form whatever
+		// the daemon returned
+		r, s, err := utils.UnmarshalECDSASignature(resp.Signature)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid signature returned by signing daemon")
+		}
+		s, err = utils.ToLowS(pub, s)
+		if err != nil {
+			return nil, err
+		}
+		return utils.MarshalECDSASignature(r, s)
+	default:
+		return resp.Signature, nil
+	}
+}
+
+// Verify verifies signature against key k and digest
+func (csp *Provider) Verify(k bccsp.Key, signature, digest []byte, opts bccsp.SignerOpts) (bool, error) {
+	// Validate arguments
+	if k == nil {
+		return false, errors.New("Invalid Key. It must not be nil")
+	}
+
+	// Verification is always local, using the public part of remote keys
+	if key, ok := k.(*privateKey); ok {
+		k = key.pub
+	}
+	return csp.BCCSP.Verify(k, signature, digest, opts)
+}
@@ -0,0 +1,216 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kms_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/kms"
+	"github.com/hyperledger/fabric/bccsp/kms/kmstest"
+	"github.com/hyperledger/fabric/bccsp/signer"
+	"github.com/hyperledger/fabric/bccsp/sw"
+	"github.com/hyperledger/fabric/bccsp/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func newProvider(t *testing.T) (*kms.Provider, *kmstest.Server) {
+	server := kmstest.NewServer()
+	address, err := server.Start()
+	require.NoError(t, err)
+	t.Cleanup(server.Stop)
+
+	csp, err := kms.New(kms.KMSOpts{Security: 256, Hash: "SHA2", Address: address}, sw.NewDummyKeyStore())
+	require.NoError(t, err)
+	t.Cleanup(func() { csp.Close() })
+
+	return csp, server
+}
+
+func TestNew(t *testing.T) {
+	_, err := kms.New(kms.KMSOpts{Security: 128, Hash: "SHA2", Address: "localhost:1"}, sw.NewDummyKeyStore())
+	require.EqualError(t, err, "Failed initializing configuration: Security level not supported [128]")
+
+	_, err = kms.New(kms.KMSOpts{Security: 256, Hash: "SHA1", Address: "localhost:1"}, sw.NewDummyKeyStore())
+	require.ErrorContains(t, err, "Failed initializing fallback SW BCCSP")
+
+	_, err = kms.New(kms.KMSOpts{Security: 256, Hash: "SHA2"}, sw.NewDummyKeyStore())
+	require.EqualError(t, err, "kms: signing daemon address not provided")
+
+	_, err = kms.New(kms.KMSOpts{
+		Security: 256,
+		Hash:     "SHA2",
+		Address:  "localhost:1",
+		TLS:      kms.TLSOpts{Enabled: true, RootCert: "testdata/missing.pem"},
+	}, sw.NewDummyKeyStore())
+	require.ErrorContains(t, err, "kms: failed loading TLS configuration: failed reading root certificate")
+}
+
+func TestKeyGenSignVerify(t *testing.T) {
+	csp, server := newProvider(t)
+
+	for _, tc := range []struct {
+		opts  bccsp.KeyGenOpts
+		curve elliptic.Curve
+	}{
+		{opts: &bccsp.ECDSAKeyGenOpts{Temporary: true}, curve: elliptic.P256()},
+		{opts: &bccsp.ECDSAP256KeyGenOpts{}, curve: elliptic.P256()},
+		{opts: &bccsp.ECDSAP384KeyGenOpts{}, curve: elliptic.P384()},
+		{opts: &bccsp.ED25519KeyGenOpts{}},
+	} {
+		k, err := csp.KeyGen(tc.opts)
+		require.NoError(t, err)
+		require.True(t, k.Private())
+		require.False(t, k.Symmetric())
+		_, err = k.Bytes()
+		require.EqualError(t, err, "Not supported.")
+
+		pk, err := k.PublicKey()
+		require.NoError(t, err)
+		require.Equal(t, k.SKI(), pk.SKI())
+		raw, err := pk.Bytes()
+		require.NoError(t, err)
+		pub, err := x509.ParsePKIXPublicKey(raw)
+		require.NoError(t, err)
+
+		msg := []byte("hello world")
+		digest, err := csp.Hash(msg, &bccsp.SHA256Opts{})
+		require.NoError(t, err)
+		if tc.curve == nil {
+			// Ed25519 signs the message itself
+			digest = msg
+		}
+
+		signature, err := csp.Sign(k, digest, nil)
+		require.NoError(t, err)
+
+		valid, err := csp.Verify(k, signature, digest, nil)
+		require.NoError(t, err)
+		require.True(t, valid)
+		valid, err = csp.Verify(pk, signature, digest, nil)
+		require.NoError(t, err)
+		require.True(t, valid)
+
+		switch pub := pub.(type) {
+		case *ecdsa.PublicKey:
+			require.Equal(t, tc.curve, pub.Curve)
+			r, s, err := utils.UnmarshalECDSASignature(signature)
+			require.NoError(t, err)
+			lowS, err := utils.IsLowS(pub, s)
+			require.NoError(t, err)
+			require.True(t, lowS)
+			require.True(t, ecdsa.Verify(pub, digest, r, s))
+		case ed25519.PublicKey:
+			require.True(t, ed25519.Verify(pub, msg, signature))
+		}
+	}
+	require.Equal(t, 4, server.SignCount())
+
+	// Other key types are generated locally
+	k, err := csp.KeyGen(&bccsp.AESKeyGenOpts{Temporary: true})
+	require.NoError(t, err)
+	require.True(t, k.Symmetric())
+
+	_, err = csp.KeyGen(nil)
+	require.EqualError(t, err, "Invalid Opts parameter. It must not be nil")
+}
+
+func TestGetKey(t *testing.T) {
+	csp, server := newProvider(t)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	ski, err := server.AddKey(key)
+	require.NoError(t, err)
+
+	k, err := csp.GetKey(ski)
+	require.NoError(t, err)
+	require.True(t, k.Private())
+	require.Equal(t, ski, k.SKI())
+
+	// The SKI matches the one computed by the software BCCSP
+	swCSP, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewDummyKeyStore())
+	require.NoError(t, err)
+	pk, err := swCSP.KeyImport(&key.PublicKey, &bccsp.ECDSAGoPublicKeyImportOpts{Temporary: true})
+	require.NoError(t, err)
+	require.Equal(t, pk.SKI(), ski)
+
+	// Unknown keys are looked up in the local key store
+	_, err = csp.GetKey([]byte("unknown"))
+	require.EqualError(t, err, "Failed getting key for SKI [[117 110 107 110 111 119 110]]: Key not found. This is a dummy KeyStore")
+}
+
+func TestSignErrors(t *testing.T) {
+	csp, server := newProvider(t)
+
+	k, err := csp.KeyGen(&bccsp.ECDSAP256KeyGenOpts{})
+	require.NoError(t, err)
+
+	_, err = csp.Sign(nil, []byte("digest"), nil)
+	require.EqualError(t, err, "Invalid Key. It must not be nil")
+	_, err = csp.Sign(k, nil, nil)
+	require.EqualError(t, err, "Invalid digest. Cannot be empty")
+
+	server.Stop()
+	_, err = csp.Sign(k, []byte("digest"), nil)
+	require.ErrorContains(t, err, "Failed signing with KMS")
+}
+
+func TestTimeout(t *testing.T) {
+	csp, err := kms.New(kms.KMSOpts{Security: 256, Hash: "SHA2", Address: "127.0.0.1:1", Timeout: 100 * time.Millisecond}, sw.NewDummyKeyStore())
+	require.NoError(t, err)
+	defer csp.Close()
+
+	start := time.Now()
+	_, err = csp.KeyGen(&bccsp.ECDSAP256KeyGenOpts{})
+	require.ErrorContains(t, err, "Failed generating ECDSAP256 key")
+	require.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestX509Certificate(t *testing.T) {
+	csp, _ := newProvider(t)
+
+	k, err := csp.KeyGen(&bccsp.ECDSAP256KeyGenOpts{})
+	require.NoError(t, err)
+	cryptoSigner, err := signer.New(csp, k)
+	require.NoError(t, err)
+
+	template := x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "kms.example.com"},
+		NotBefore:             time.Now().Add(-1 * time.Hour),
+		NotAfter:              time.Now().Add(1 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	certRaw, err := x509.CreateCertificate(rand.Reader, &template, &template, cryptoSigner.Public(), cryptoSigner)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(certRaw)
+	require.NoError(t, err)
+	require.NoError(t, cert.CheckSignatureFrom(cert))
+
+	pk, err := csp.KeyImport(cert, &bccsp.X509PublicKeyImportOpts{Temporary: true})
+	require.NoError(t, err)
+	require.Equal(t, k.SKI(), pk.SKI())
+
+	digest := sha256.Sum256([]byte("msg"))
+	signature, err := csp.Sign(k, digest[:], nil)
+	require.NoError(t, err)
+	valid, err := csp.Verify(pk, signature, digest[:], nil)
+	require.NoError(t, err)
+	require.True(t, valid)
+}
@@ -0,0 +1,185 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package kmstest provides an in-process reference implementation of the
+// signing daemon protocol used by the KMS BCCSP. Keys are held in memory.
+package kmstest
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"net"
+	"sync"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/kms"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Server is an in-memory signing daemon.
+type Server struct {
+	kms.UnimplementedKeyManagementServiceServer
+
+	mutex sync.Mutex
+	keys  map[string]crypto.Signer
+	// signCount counts the Sign requests served
+	signCount int
+
+	grpcServer *grpc.Server
+}
+
+// NewServer creates a signing daemon with no keys.
+func NewServer() *Server {
+	return &Server{keys: map[string]crypto.Signer{}}
+}
+
+// Start serves the signing daemon on a loopback port and returns its address.
+func (s *Server) Start() (string, error) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", err
+	}
+	s.grpcServer = grpc.NewServer()
+	kms.RegisterKeyManagementServiceServer(s.grpcServer, s)
+	go s.grpcServer.Serve(lis)
+
+	return lis.Addr().String(), nil
+}
+
+// Stop stops serving.
+func (s *Server) Stop() {
+	if s.grpcServer != nil {
+		s.grpcServer.Stop()
+	}
+}
+
+// AddKey makes the signing daemon hold the given private key and returns
+// its SKI. Only ECDSA and Ed25519 keys are supported.
+func (s *Server) AddKey(key crypto.Signer) ([]byte, error) {
+	ski, err := computeSKI(key.Public())
+	if err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.keys[hex.EncodeToString(ski)] = key
+
+	return ski, nil
+}
+
+// SignCount returns the number of Sign requests served.
+func (s *Server) SignCount() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.signCount
+}
+
+// KeyGen generates a new key pair.
+func (s *Server) KeyGen(_ context.Context, req *kms.KeyGenRequest) (*kms.KeyResponse, error) {
+	var key crypto.Signer
+	var err error
+	switch req.Algorithm {
+	case bccsp.ECDSAP256:
+		key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case bccsp.ECDSAP384:
+		key, err = ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	case bccsp.ED25519:
+		_, key, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unsupported algorithm %s", req.Algorithm)
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	ski, err := s.AddKey(key)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return keyResponse(ski, key)
+}
+
+// GetKey returns the public key of the key pair with the requested SKI.
+func (s *Server) GetKey(_ context.Context, req *kms.GetKeyRequest) (*kms.KeyResponse, error) {
+	key, err := s.key(req.Ski)
+	if err != nil {
+		return nil, err
+	}
+
+	return keyResponse(req.Ski, key)
+}
+
+// Sign signs the digest with the key pair with the requested SKI.
+func (s *Server) Sign(_ context.Context, req *kms.SignRequest) (*kms.SignResponse, error) {
+	key, err := s.key(req.Ski)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	s.signCount++
+	s.mutex.Unlock()
+
+	var signature []byte
+	switch key := key.(type) {
+	case *ecdsa.PrivateKey:
+		signature, err = ecdsa.SignASN1(rand.Reader, key, req.Digest)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	case ed25519.PrivateKey:
+		// The digest is the message itself for Ed25519
+		signature = ed25519.Sign(key, req.Digest)
+	}
+
+	return &kms.SignResponse{Signature: signature}, nil
+}
+
+func (s *Server) key(ski []byte) (crypto.Signer, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	key, ok := s.keys[hex.EncodeToString(ski)]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "key %x not found", ski)
+	}
+	return key, nil
+}
+
+func keyResponse(ski []byte, key crypto.Signer) (*kms.KeyResponse, error) {
+	pub, err := x509.MarshalPKIXPublicKey(key.Public())
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &kms.KeyResponse{Ski: ski, PublicKey: pub}, nil
+}
+
+func computeSKI(pub crypto.PublicKey) ([]byte, error) {
+	var raw []byte
+	switch pub := pub.(type) {
+	case *ecdsa.PublicKey:
+		raw = elliptic.Marshal(pub.Curve, pub.X, pub.Y)
+	case ed25519.PublicKey:
+		raw = pub
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unsupported key type %T", pub)
+	}
+
+	hash := sha256.Sum256(raw)
+	return hash[:], nil
+}
@@ -10,6 +10,7 @@ import (
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/sha256"
+	"crypto/x509"
 	"encoding/asn1"
 	"encoding/hex"
 	"fmt"
@@ -206,6 +207,50 @@ func (csp *Provider) KeyGen(opts bccsp.KeyGenOpts) (k bccsp.Key, err error) {
 	return k, nil
 }
 
+// KeyImport imports a key from its raw representation using opts.
+// Non ephemeral ECDSA private keys are imported into the token, using the
+// CKA_ID the key mapper associates to their SKI. All other keys are
+// imported by the software based BCCSP.
+func (csp *Provider) KeyImport(raw interface{}, opts bccsp.KeyImportOpts) (bccsp.Key, error) {
+	// Validate arguments
+	if opts == nil {
+		return nil, errors.New("Invalid opts. It must not be nil")
+	}
+
+	if _, ok := opts.(*bccsp.ECDSAPrivateKeyImportOpts); !ok || opts.Ephemeral() {
+		return csp.BCCSP.KeyImport(raw, opts)
+	}
+
+	der, ok := raw.([]byte)
+	if !ok || len(der) == 0 {
+		return nil, errors.New("Invalid raw material. Expected non-empty byte array.")
+	}
+	priv, err := derToECDSAPrivateKey(der)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed converting to ECDSA private key")
+	}
+
+	ski, err := csp.importECKey(priv)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed importing ECDSA private key")
+	}
+
+	k := &ecdsaPrivateKey{ski, ecdsaPublicKey{ski, &priv.PublicKey}}
+	csp.cacheKey(ski, k)
+	return k, nil
+}
+
+func derToECDSAPrivateKey(der []byte) (*ecdsa.PrivateKey, error) {
+	if key, err := x509.ParsePKCS8PrivateKey(der); err == nil {
+		ecKey, ok := key.(*ecdsa.PrivateKey)
+		if !ok {
+			return nil, errors.Errorf("unsupported private key type %T", key)
+		}
+		return ecKey, nil
+	}
+	return x509.ParseECPrivateKey(der)
+}
+
 func (csp *Provider) cacheKey(ski []byte, key bccsp.Key) {
 	csp.cacheLock.Lock()
 	csp.keyCache[hex.EncodeToString(ski)] = key
@@ -457,6 +502,20 @@ var (
 	oidNamedCurveP521 = asn1.ObjectIdentifier{1, 3, 132, 0, 35}
 )
 
+func oidFromNamedCurve(curve elliptic.Curve) (asn1.ObjectIdentifier, bool) {
+	switch curve {
+	case elliptic.P224():
+		return oidNamedCurveP224, true
+	case elliptic.P256():
+		return oidNamedCurveP256, true
+	case elliptic.P384():
+		return oidNamedCurveP384, true
+	case elliptic.P521():
+		return oidNamedCurveP521, true
+	}
+	return nil, false
+}
+
 func namedCurveFromOID(oid asn1.ObjectIdentifier) elliptic.Curve {
 	switch {
 	case oid.Equal(oidNamedCurveP224):
@@ -601,6 +660,91 @@ func (csp *Provider) generateECKey(curve asn1.ObjectIdentifier, ephemeral bool)
 	return ski, pubGoKey, nil
 }
 
+// importECKey creates token objects for the private key and its public
+// part. Both use the CKA_ID the key mapper associates to the SKI.
+func (csp *Provider) importECKey(priv *ecdsa.PrivateKey) (ski []byte, err error) {
+	curve, ok := oidFromNamedCurve(priv.Curve)
+	if !ok {
+		return nil, fmt.Errorf("unsupported curve %s", priv.Curve.Params().Name)
+	}
+	marshaledOID, err := asn1.Marshal(curve)
+	if err != nil {
+		return nil, fmt.Errorf("Could not marshal OID [%s]", err.Error())
+	}
+
+	ecpt := elliptic.Marshal(priv.Curve, priv.X, priv.Y)
+	hash := sha256.Sum256(ecpt)
+	ski = hash[:]
+
+	// CKA_EC_POINT holds the DER encoding of the point as an OCTET STRING
+	marshaledPoint, err := asn1.Marshal(ecpt)
+	if err != nil {
+		return nil, fmt.Errorf("Could not marshal EC point [%s]", err.Error())
+	}
+	value := make([]byte, (priv.Curve.Params().N.BitLen()+7)/8)
+	priv.D.FillBytes(value)
+
+	session, err := csp.getSession()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { csp.handleSessionReturn(err, session) }()
+
+	if _, findErr := csp.findKeyPairFromSKI(session, ski, privateKeyType); findErr == nil {
+		return nil, fmt.Errorf("P11: private key with SKI %x already exists", ski)
+	}
+
+	id := csp.getKeyIDForSKI(ski)
+	label := hex.EncodeToString(ski)
+
+	pubkeyT := []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, pkcs11.CKK_EC),
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, pkcs11.CKO_PUBLIC_KEY),
+		pkcs11.NewAttribute(pkcs11.CKA_TOKEN, true),
+		pkcs11.NewAttribute(pkcs11.CKA_VERIFY, true),
+		pkcs11.NewAttribute(pkcs11.CKA_EC_PARAMS, marshaledOID),
+		pkcs11.NewAttribute(pkcs11.CKA_EC_POINT, marshaledPoint),
+
+		pkcs11.NewAttribute(pkcs11.CKA_ID, id),
+		pkcs11.NewAttribute(pkcs11.CKA_LABEL, label),
+	}
+
+	prvkeyT := []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, pkcs11.CKK_EC),
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, pkcs11.CKO_PRIVATE_KEY),
+		pkcs11.NewAttribute(pkcs11.CKA_TOKEN, true),
+		pkcs11.NewAttribute(pkcs11.CKA_PRIVATE, true),
+		pkcs11.NewAttribute(pkcs11.CKA_SIGN, true),
+		pkcs11.NewAttribute(pkcs11.CKA_EC_PARAMS, marshaledOID),
+		pkcs11.NewAttribute(pkcs11.CKA_VALUE, value),
+
+		pkcs11.NewAttribute(pkcs11.CKA_ID, id),
+		pkcs11.NewAttribute(pkcs11.CKA_LABEL, label),
+
+		pkcs11.NewAttribute(pkcs11.CKA_EXTRACTABLE, false),
+		pkcs11.NewAttribute(pkcs11.CKA_SENSITIVE, true),
+	}
+
+	if csp.immutable {
+		pubkeyT = append(pubkeyT, pkcs11.NewAttribute(pkcs11.CKA_MODIFIABLE, false))
+		prvkeyT = append(prvkeyT, pkcs11.NewAttribute(pkcs11.CKA_MODIFIABLE, false))
+	}
+
+	pub, err := csp.ctx.CreateObject(session, pubkeyT)
+	if err != nil {
+		return nil, fmt.Errorf("P11: public key import failed [%s]", err)
+	}
+	if _, err = csp.ctx.CreateObject(session, prvkeyT); err != nil {
+		if destroyErr := csp.ctx.DestroyObject(session, pub); destroyErr != nil {
+			logger.Warningf("Failed removing public key %x after failed import: %s", ski, destroyErr)
+		}
+		return nil, fmt.Errorf("P11: private key import failed [%s]", err)
+	}
+
+	logger.Infof("Imported P11 key, SKI %x", ski)
+	return ski, nil
+}
+
 func (csp *Provider) signP11ECDSA(ski []byte, msg []byte) (R, S *big.Int, err error) {
 	session, err := csp.getSession()
 	if err != nil {
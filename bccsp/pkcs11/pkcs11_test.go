@@ -10,9 +10,11 @@ SPDX-License-Identifier: Apache-2.0
 package pkcs11
 
 import (
+	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/sha256"
+	"crypto/x509"
 	"encoding/asn1"
 	"strconv"
 	"strings"
@@ -373,6 +375,79 @@ func TestKeyMapper(t *testing.T) {
 	})
 }
 
+func TestECDSAKeyImport(t *testing.T) {
+	newID := []byte("imported-id")
+	csp, cleanup := newProvider(t, defaultOptions(), WithKeyMapper(func([]byte) []byte { return newID }))
+	defer cleanup()
+
+	for _, curve := range []elliptic.Curve{elliptic.P256(), elliptic.P384()} {
+		key, err := ecdsa.GenerateKey(curve, rand.Reader)
+		require.NoError(t, err)
+		der, err := x509.MarshalPKCS8PrivateKey(key)
+		require.NoError(t, err)
+
+		k, err := csp.KeyImport(der, &bccsp.ECDSAPrivateKeyImportOpts{Temporary: false})
+		require.NoError(t, err)
+		require.True(t, k.Private())
+		ski := sha256.Sum256(elliptic.Marshal(curve, key.X, key.Y))
+		require.Equal(t, ski[:], k.SKI())
+
+		// The key is looked up on the token using the mapped identifier
+		csp.clearCaches()
+		k, err = csp.GetKey(k.SKI())
+		require.NoError(t, err)
+		require.True(t, k.Private())
+
+		digest := sha256.Sum256([]byte("Hello World"))
+		signature, err := csp.Sign(k, digest[:], nil)
+		require.NoError(t, err)
+		require.True(t, ecdsa.VerifyASN1(&key.PublicKey, digest[:], signature))
+
+		_, err = csp.KeyImport(der, &bccsp.ECDSAPrivateKeyImportOpts{Temporary: false})
+		require.ErrorContains(t, err, "already exists")
+
+		// Remove the key so that the next one can use the same identifier
+		sess, err := csp.getSession()
+		require.NoError(t, err)
+		for _, class := range []uint{pkcs11.CKO_PUBLIC_KEY, pkcs11.CKO_PRIVATE_KEY} {
+			err = csp.ctx.FindObjectsInit(sess, []*pkcs11.Attribute{
+				pkcs11.NewAttribute(pkcs11.CKA_CLASS, class),
+				pkcs11.NewAttribute(pkcs11.CKA_ID, newID),
+			})
+			require.NoError(t, err)
+			objs, _, err := csp.ctx.FindObjects(sess, 1)
+			require.NoError(t, err)
+			require.NoError(t, csp.ctx.FindObjectsFinal(sess))
+			require.Len(t, objs, 1)
+			require.NoError(t, csp.ctx.DestroyObject(sess, objs[0]))
+		}
+		csp.returnSession(sess)
+		csp.clearCaches()
+	}
+
+	t.Run("Ephemeral", func(t *testing.T) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		der, err := x509.MarshalPKCS8PrivateKey(key)
+		require.NoError(t, err)
+
+		k, err := csp.KeyImport(der, &bccsp.ECDSAPrivateKeyImportOpts{Temporary: true})
+		require.NoError(t, err)
+		require.True(t, k.Private())
+		_, onToken := k.(*ecdsaPrivateKey)
+		require.False(t, onToken, "ephemeral keys must be imported by the software BCCSP")
+	})
+
+	t.Run("InvalidRaw", func(t *testing.T) {
+		_, err := csp.KeyImport(nil, &bccsp.ECDSAPrivateKeyImportOpts{})
+		require.EqualError(t, err, "Invalid raw material. Expected non-empty byte array.")
+		_, err = csp.KeyImport([]byte{1, 2, 3}, &bccsp.ECDSAPrivateKeyImportOpts{})
+		require.ErrorContains(t, err, "Failed converting to ECDSA private key")
+		_, err = csp.KeyImport([]byte{1, 2, 3}, nil)
+		require.EqualError(t, err, "Invalid opts. It must not be nil")
+	})
+}
+
 func updateKeyIdentifier(t *testing.T, pctx *pkcs11.Ctx, sess pkcs11.SessionHandle, class uint, currentID, newID []byte) {
 	pkt := []*pkcs11.Attribute{
 		pkcs11.NewAttribute(pkcs11.CKA_CLASS, class),
@@ -0,0 +1,242 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sw
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/signer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyED25519(t *testing.T) {
+	t.Parallel()
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	msg := []byte("hello world")
+	sigma, err := signED25519(&priv, msg, nil)
+	require.NoError(t, err)
+
+	valid, err := verifyED25519(&pub, sigma, msg, nil)
+	require.NoError(t, err)
+	require.True(t, valid)
+
+	valid, err = verifyED25519(&pub, sigma, []byte("goodbye world"), nil)
+	require.NoError(t, err)
+	require.False(t, valid)
+
+	valid, err = verifyED25519(&pub, nil, msg, nil)
+	require.NoError(t, err)
+	require.False(t, valid)
+}
+
+func TestED25519SignerSign(t *testing.T) {
+	t.Parallel()
+
+	signer := &ed25519Signer{}
+	verifierPrivateKey := &ed25519PrivateKeyVerifier{}
+	verifierPublicKey := &ed25519PublicKeyKeyVerifier{}
+
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	k := &ed25519PrivateKey{&priv}
+	pk, err := k.PublicKey()
+	require.NoError(t, err)
+
+	msg := []byte("Hello World")
+	sigma, err := signer.Sign(k, msg, nil)
+	require.NoError(t, err)
+	require.NotNil(t, sigma)
+
+	valid, err := verifierPrivateKey.Verify(k, sigma, msg, nil)
+	require.NoError(t, err)
+	require.True(t, valid)
+
+	valid, err = verifierPublicKey.Verify(pk, sigma, msg, nil)
+	require.NoError(t, err)
+	require.True(t, valid)
+}
+
+func TestED25519Keys(t *testing.T) {
+	t.Parallel()
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	// Private Key PEM format
+	rawPEM, err := privateKeyToPEM(priv, nil)
+	require.NoError(t, err)
+	pemKey, err := pemToPrivateKey(rawPEM, nil)
+	require.NoError(t, err)
+	require.Equal(t, priv, pemKey)
+
+	// Encrypted Private Key PEM format
+	encPEM, err := privateKeyToPEM(priv, []byte("passwd"))
+	require.NoError(t, err)
+	_, err = pemToPrivateKey(encPEM, nil)
+	require.EqualError(t, err, "encrypted Key. Need a password")
+	encKey, err := pemToPrivateKey(encPEM, []byte("passwd"))
+	require.NoError(t, err)
+	require.Equal(t, priv, encKey)
+
+	// Public Key PEM format
+	rawPEM, err = publicKeyToPEM(pub, nil)
+	require.NoError(t, err)
+	pemPub, err := pemToPublicKey(rawPEM, nil)
+	require.NoError(t, err)
+	require.Equal(t, pub, pemPub)
+
+	// Encrypted Public Key PEM format
+	encPEM, err = publicKeyToPEM(pub, []byte("passwd"))
+	require.NoError(t, err)
+	encPub, err := pemToPublicKey(encPEM, []byte("passwd"))
+	require.NoError(t, err)
+	require.Equal(t, pub, encPub)
+}
+
+func TestED25519KeyGenAndGetKeyBySKI(t *testing.T) {
+	t.Parallel()
+	provider, _, cleanup := currentTestConfig.Provider(t)
+	defer cleanup()
+
+	k, err := provider.KeyGen(&bccsp.ED25519KeyGenOpts{Temporary: false})
+	require.NoError(t, err)
+	require.True(t, k.Private())
+	require.False(t, k.Symmetric())
+
+	_, err = k.Bytes()
+	require.EqualError(t, err, "Not supported.")
+
+	pk, err := k.PublicKey()
+	require.NoError(t, err)
+	require.Equal(t, k.SKI(), pk.SKI())
+	require.False(t, pk.Private())
+
+	k2, err := provider.GetKey(k.SKI())
+	require.NoError(t, err)
+	require.IsType(t, &ed25519PrivateKey{}, k2)
+	require.Equal(t, k.SKI(), k2.SKI())
+}
+
+func TestED25519SignVerify(t *testing.T) {
+	t.Parallel()
+	provider, _, cleanup := currentTestConfig.Provider(t)
+	defer cleanup()
+
+	k, err := provider.KeyGen(&bccsp.ED25519KeyGenOpts{Temporary: true})
+	require.NoError(t, err)
+
+	msg := []byte("Hello World")
+	signature, err := provider.Sign(k, msg, nil)
+	require.NoError(t, err)
+
+	valid, err := provider.Verify(k, signature, msg, nil)
+	require.NoError(t, err)
+	require.True(t, valid)
+
+	pk, err := k.PublicKey()
+	require.NoError(t, err)
+	valid, err = provider.Verify(pk, signature, msg, nil)
+	require.NoError(t, err)
+	require.True(t, valid)
+
+	valid, err = provider.Verify(pk, signature, []byte("Goodbye World"), nil)
+	require.NoError(t, err)
+	require.False(t, valid)
+}
+
+func TestED25519KeyImport(t *testing.T) {
+	t.Parallel()
+	provider, _, cleanup := currentTestConfig.Provider(t)
+	defer cleanup()
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	// PKIX public key
+	pkRaw, err := x509.MarshalPKIXPublicKey(pub)
+	require.NoError(t, err)
+	pk, err := provider.KeyImport(pkRaw, &bccsp.ED25519PKIXPublicKeyImportOpts{Temporary: false})
+	require.NoError(t, err)
+	raw, err := pk.Bytes()
+	require.NoError(t, err)
+	require.Equal(t, pkRaw, raw)
+
+	// The public key was stored, retrieve it
+	pk2, err := provider.GetKey(pk.SKI())
+	require.NoError(t, err)
+	require.IsType(t, &ed25519PublicKey{}, pk2)
+
+	// Go public key
+	goPK, err := provider.KeyImport(pub, &bccsp.ED25519GoPublicKeyImportOpts{Temporary: true})
+	require.NoError(t, err)
+	require.Equal(t, pk.SKI(), goPK.SKI())
+
+	// PKCS#8 private key
+	skRaw, err := x509.MarshalPKCS8PrivateKey(priv)
+	require.NoError(t, err)
+	sk, err := provider.KeyImport(skRaw, &bccsp.ED25519PrivateKeyImportOpts{Temporary: true})
+	require.NoError(t, err)
+	require.Equal(t, pk.SKI(), sk.SKI())
+
+	msg := []byte("Hello World")
+	signature, err := provider.Sign(sk, msg, nil)
+	require.NoError(t, err)
+	valid, err := provider.Verify(pk, signature, msg, nil)
+	require.NoError(t, err)
+	require.True(t, valid)
+
+	// Invalid raw material
+	_, err = provider.KeyImport("not bytes", &bccsp.ED25519PKIXPublicKeyImportOpts{})
+	require.Error(t, err)
+	_, err = provider.KeyImport([]byte{}, &bccsp.ED25519PrivateKeyImportOpts{})
+	require.Error(t, err)
+	_, err = provider.KeyImport(priv, &bccsp.ED25519GoPublicKeyImportOpts{})
+	require.Error(t, err)
+}
+
+func TestKeyImportFromX509ED25519PublicKey(t *testing.T) {
+	t.Parallel()
+	provider, _, cleanup := currentTestConfig.Provider(t)
+	defer cleanup()
+
+	k, err := provider.KeyGen(&bccsp.ED25519KeyGenOpts{Temporary: true})
+	require.NoError(t, err)
+
+	cryptoSigner, err := signer.New(provider, k)
+	require.NoError(t, err)
+
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test.example.com"},
+		NotBefore:    time.Now().Add(-1 * time.Hour),
+		NotAfter:     time.Now().Add(1 * time.Hour),
+
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	certRaw, err := x509.CreateCertificate(rand.Reader, &template, &template, cryptoSigner.Public(), cryptoSigner)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(certRaw)
+	require.NoError(t, err)
+	require.NoError(t, cert.CheckSignatureFrom(cert))
+
+	pk, err := provider.KeyImport(cert, &bccsp.X509PublicKeyImportOpts{Temporary: true})
+	require.NoError(t, err)
+	require.IsType(t, &ed25519PublicKey{}, pk)
+	require.Equal(t, k.SKI(), pk.SKI())
+}
@@ -9,6 +9,8 @@ package sw
 import (
 	"bytes"
 	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/x509"
 	"encoding/hex"
 	"errors"
 	"fmt"
@@ -19,6 +21,7 @@ import (
 	"sync"
 
 	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/utils"
 )
 
 // NewFileBasedKeyStore instantiated a file-based key store at a given position.
@@ -30,6 +33,22 @@ func NewFileBasedKeyStore(pwd []byte, path string, readOnly bool) (bccsp.KeyStor
 	return ks, ks.Init(pwd, path, readOnly)
 }
 
+// NewFileBasedKeyStoreWithKDF instantiates a file-based key store like
+// NewFileBasedKeyStore, additionally selecting the key derivation function
+// (utils.PBKDF2 or utils.SCRYPT) used to encrypt private keys when a
+// non-empty password is specified. Private keys are then stored as
+// OpenSSL compatible encrypted PKCS#8.
+func NewFileBasedKeyStoreWithKDF(pwd []byte, kdf, path string, readOnly bool) (bccsp.KeyStore, error) {
+	switch strings.ToUpper(kdf) {
+	case "", utils.PBKDF2, utils.SCRYPT:
+	default:
+		return nil, fmt.Errorf("unsupported key derivation function [%s]", kdf)
+	}
+
+	ks := &fileBasedKeyStore{kdf: strings.ToUpper(kdf)}
+	return ks, ks.Init(pwd, path, readOnly)
+}
+
 // fileBasedKeyStore is a folder-based KeyStore.
 // Each key is stored in a separated file whose name contains the key's SKI
 // and flags to identity the key's type. All the keys are stored in
@@ -44,6 +63,8 @@ type fileBasedKeyStore struct {
 	isOpen   bool
 
 	pwd []byte
+	// kdf is the key derivation function used to encrypt private keys
+	kdf string
 
 	// Sync
 	m sync.Mutex
@@ -141,6 +162,8 @@ func (ks *fileBasedKeyStore) GetKey(ski []byte) (bccsp.Key, error) {
 		switch k := key.(type) {
 		case *ecdsa.PrivateKey:
 			return &ecdsaPrivateKey{k}, nil
+		case ed25519.PrivateKey:
+			return &ed25519PrivateKey{&k}, nil
 		default:
 			return nil, errors.New("secret key type not recognized")
 		}
@@ -154,6 +177,8 @@ func (ks *fileBasedKeyStore) GetKey(ski []byte) (bccsp.Key, error) {
 		switch k := key.(type) {
 		case *ecdsa.PublicKey:
 			return &ecdsaPublicKey{k}, nil
+		case ed25519.PublicKey:
+			return &ed25519PublicKey{&k}, nil
 		default:
 			return nil, errors.New("public key type not recognized")
 		}
@@ -185,6 +210,18 @@ func (ks *fileBasedKeyStore) StoreKey(k bccsp.Key) (err error) {
 			return fmt.Errorf("failed storing ECDSA public key [%s]", err)
 		}
 
+	case *ed25519PrivateKey:
+		err = ks.storePrivateKey(hex.EncodeToString(k.SKI()), *kk.privKey)
+		if err != nil {
+			return fmt.Errorf("failed storing ED25519 private key [%s]", err)
+		}
+
+	case *ed25519PublicKey:
+		err = ks.storePublicKey(hex.EncodeToString(k.SKI()), *kk.pubKey)
+		if err != nil {
+			return fmt.Errorf("failed storing ED25519 public key [%s]", err)
+		}
+
 	case *aesPrivateKey:
 		err = ks.storeKey(hex.EncodeToString(k.SKI()), kk.privKey)
 		if err != nil {
@@ -227,6 +264,8 @@ func (ks *fileBasedKeyStore) searchKeystoreForSKI(ski []byte) (k bccsp.Key, err
 		switch kk := key.(type) {
 		case *ecdsa.PrivateKey:
 			k = &ecdsaPrivateKey{kk}
+		case ed25519.PrivateKey:
+			k = &ed25519PrivateKey{&kk}
 		default:
 			continue
 		}
@@ -240,6 +279,51 @@ func (ks *fileBasedKeyStore) searchKeystoreForSKI(ski []byte) (k bccsp.Key, err
 	return nil, fmt.Errorf("key with SKI %x not found in %s", ski, ks.path)
 }
 
+// ExportPrivateKeys returns the PKCS#8 encoding of the ECDSA and ED25519
+// private keys found in the file based key store at path, indexed by the
+// hex encoding of their SKI. Encrypted keys are decrypted using pwd.
+// It is meant to be used to move keys to a different BCCSP.
+func ExportPrivateKeys(path string, pwd []byte) (map[string][]byte, error) {
+	files, err := os.ReadDir(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading key store %s [%s]", path, err)
+	}
+
+	keys := map[string][]byte{}
+	for _, f := range files {
+		if f.IsDir() || !strings.HasSuffix(f.Name(), "sk") {
+			continue
+		}
+
+		raw, err := os.ReadFile(filepath.Join(path, f.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed reading private key %s [%s]", f.Name(), err)
+		}
+		key, err := pemToPrivateKey(raw, pwd)
+		if err != nil {
+			return nil, fmt.Errorf("failed parsing private key %s [%s]", f.Name(), err)
+		}
+
+		var k bccsp.Key
+		switch kk := key.(type) {
+		case *ecdsa.PrivateKey:
+			k = &ecdsaPrivateKey{kk}
+		case ed25519.PrivateKey:
+			k = &ed25519PrivateKey{&kk}
+		default:
+			return nil, fmt.Errorf("private key %s has unsupported type %T", f.Name(), key)
+		}
+
+		der, err := x509.MarshalPKCS8PrivateKey(key)
+		if err != nil {
+			return nil, fmt.Errorf("failed marshaling private key %s [%s]", f.Name(), err)
+		}
+		keys[hex.EncodeToString(k.SKI())] = der
+	}
+
+	return keys, nil
+}
+
 func (ks *fileBasedKeyStore) getSuffix(alias string) string {
 	files, _ := os.ReadDir(ks.path)
 	for _, f := range files {
@@ -260,7 +344,13 @@ func (ks *fileBasedKeyStore) getSuffix(alias string) string {
 }
 
 func (ks *fileBasedKeyStore) storePrivateKey(alias string, privateKey interface{}) error {
-	rawKey, err := privateKeyToPEM(privateKey, ks.pwd)
+	var rawKey []byte
+	var err error
+	if len(ks.pwd) != 0 {
+		rawKey, err = privateKeyToEncryptedPKCS8PEM(privateKey, ks.pwd, ks.kdf)
+	} else {
+		rawKey, err = privateKeyToPEM(privateKey, nil)
+	}
 	if err != nil {
 		logger.Errorf("Failed converting private key to PEM [%s]: [%s]", alias, err)
 		return err
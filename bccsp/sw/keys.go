@@ -8,6 +8,7 @@ package sw
 
 import (
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/x509"
@@ -15,6 +16,8 @@ import (
 	"encoding/pem"
 	"errors"
 	"fmt"
+
+	"github.com/hyperledger/fabric/bccsp/utils"
 )
 
 type pkcs8Info struct {
@@ -113,42 +116,70 @@ func privateKeyToPEM(privateKey interface{}, pwd []byte) ([]byte, error) {
 				Bytes: pkcs8Bytes,
 			},
 		), nil
+	case ed25519.PrivateKey:
+		if k == nil {
+			return nil, errors.New("invalid ed25519 private key. It must be different from nil")
+		}
+
+		pkcs8Bytes, err := x509.MarshalPKCS8PrivateKey(k)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling ED25519 key to PKCS#8: [%s]", err)
+		}
+		return pem.EncodeToMemory(
+			&pem.Block{
+				Type:  "PRIVATE KEY",
+				Bytes: pkcs8Bytes,
+			},
+		), nil
 
 	default:
-		return nil, errors.New("invalid key type. It must be *ecdsa.PrivateKey")
+		return nil, errors.New("invalid key type. It must be *ecdsa.PrivateKey or ed25519.PrivateKey")
 	}
 }
 
 func privateKeyToEncryptedPEM(privateKey interface{}, pwd []byte) ([]byte, error) {
+	return privateKeyToEncryptedPKCS8PEM(privateKey, pwd, utils.PBKDF2)
+}
+
+// privateKeyToEncryptedPKCS8PEM encodes the private key as an OpenSSL
+// compatible PKCS#8 EncryptedPrivateKeyInfo (PBES2, AES-256-CBC) whose
+// encryption key is derived from pwd using kdf.
+func privateKeyToEncryptedPKCS8PEM(privateKey interface{}, pwd []byte, kdf string) ([]byte, error) {
 	if privateKey == nil {
 		return nil, errors.New("invalid private key. It must be different from nil")
 	}
 
+	var raw []byte
+	var err error
 	switch k := privateKey.(type) {
 	case *ecdsa.PrivateKey:
 		if k == nil {
 			return nil, errors.New("invalid ecdsa private key. It must be different from nil")
 		}
-		raw, err := x509.MarshalECPrivateKey(k)
-		if err != nil {
-			return nil, err
-		}
-
-		block, err := x509.EncryptPEMBlock(
-			rand.Reader,
-			"PRIVATE KEY",
-			raw,
-			pwd,
-			x509.PEMCipherAES256)
-		if err != nil {
-			return nil, err
+		raw, err = x509.MarshalPKCS8PrivateKey(k)
+	case ed25519.PrivateKey:
+		if k == nil {
+			return nil, errors.New("invalid ed25519 private key. It must be different from nil")
 		}
-
-		return pem.EncodeToMemory(block), nil
-
+		raw, err = x509.MarshalPKCS8PrivateKey(k)
 	default:
-		return nil, errors.New("invalid key type. It must be *ecdsa.PrivateKey")
+		return nil, errors.New("invalid key type. It must be *ecdsa.PrivateKey or ed25519.PrivateKey")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	encrypted, err := utils.EncryptPKCS8PrivateKey(raw, pwd, kdf)
+	if err != nil {
+		return nil, err
 	}
+
+	return pem.EncodeToMemory(
+		&pem.Block{
+			Type:  utils.EncryptedPrivateKeyPEMType,
+			Bytes: encrypted,
+		},
+	), nil
 }
 
 func derToPrivateKey(der []byte) (key interface{}, err error) {
@@ -158,7 +189,7 @@ func derToPrivateKey(der []byte) (key interface{}, err error) {
 
 	if key, err = x509.ParsePKCS8PrivateKey(der); err == nil {
 		switch key.(type) {
-		case *ecdsa.PrivateKey:
+		case *ecdsa.PrivateKey, ed25519.PrivateKey:
 			return
 		default:
 			return nil, errors.New("found unknown private key type in PKCS#8 wrapping")
@@ -169,7 +200,7 @@ func derToPrivateKey(der []byte) (key interface{}, err error) {
 		return
 	}
 
-	return nil, errors.New("invalid key type. The DER must contain an ecdsa.PrivateKey")
+	return nil, errors.New("invalid key type. The DER must contain an ecdsa.PrivateKey or ed25519.PrivateKey")
 }
 
 func pemToPrivateKey(raw []byte, pwd []byte) (interface{}, error) {
@@ -180,6 +211,15 @@ func pemToPrivateKey(raw []byte, pwd []byte) (interface{}, error) {
 
 	// TODO: derive from header the type of the key
 
+	if block.Type == utils.EncryptedPrivateKeyPEMType {
+		decrypted, err := utils.DecryptPKCS8PrivateKey(block.Bytes, pwd)
+		if err != nil {
+			return nil, err
+		}
+		return derToPrivateKey(decrypted)
+	}
+
+	// Legacy RFC 1423 encryption, as written by earlier versions
 	if x509.IsEncryptedPEMBlock(block) {
 		if len(pwd) == 0 {
 			return nil, errors.New("encrypted Key. Need a password")
@@ -272,6 +312,21 @@ func publicKeyToPEM(publicKey interface{}, pwd []byte) ([]byte, error) {
 			return nil, err
 		}
 
+		return pem.EncodeToMemory(
+			&pem.Block{
+				Type:  "PUBLIC KEY",
+				Bytes: PubASN1,
+			},
+		), nil
+	case ed25519.PublicKey:
+		if k == nil {
+			return nil, errors.New("invalid ed25519 public key. It must be different from nil")
+		}
+		PubASN1, err := x509.MarshalPKIXPublicKey(k)
+		if err != nil {
+			return nil, err
+		}
+
 		return pem.EncodeToMemory(
 			&pem.Block{
 				Type:  "PUBLIC KEY",
@@ -280,35 +335,43 @@ func publicKeyToPEM(publicKey interface{}, pwd []byte) ([]byte, error) {
 		), nil
 
 	default:
-		return nil, errors.New("invalid key type. It must be *ecdsa.PublicKey")
+		return nil, errors.New("invalid key type. It must be *ecdsa.PublicKey or ed25519.PublicKey")
 	}
 }
 
 func publicKeyToEncryptedPEM(publicKey interface{}, pwd []byte) ([]byte, error) {
+	var raw []byte
+	var err error
+
 	switch k := publicKey.(type) {
 	case *ecdsa.PublicKey:
 		if k == nil {
 			return nil, errors.New("invalid ecdsa public key. It must be different from nil")
 		}
-		raw, err := x509.MarshalPKIXPublicKey(k)
-		if err != nil {
-			return nil, err
-		}
-
-		block, err := x509.EncryptPEMBlock(
-			rand.Reader,
-			"PUBLIC KEY",
-			raw,
-			pwd,
-			x509.PEMCipherAES256)
-		if err != nil {
-			return nil, err
+		raw, err = x509.MarshalPKIXPublicKey(k)
+	case ed25519.PublicKey:
+		if k == nil {
+			return nil, errors.New("invalid ed25519 public key. It must be different from nil")
 		}
-
-		return pem.EncodeToMemory(block), nil
+		raw, err = x509.MarshalPKIXPublicKey(k)
 	default:
-		return nil, errors.New("invalid key type. It must be *ecdsa.PublicKey")
+		return nil, errors.New("invalid key type. It must be *ecdsa.PublicKey or ed25519.PublicKey")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := x509.EncryptPEMBlock(
+		rand.Reader,
+		"PUBLIC KEY",
+		raw,
+		pwd,
+		x509.PEMCipherAES256)
+	if err != nil {
+		return nil, err
 	}
+
+	return pem.EncodeToMemory(block), nil
 }
 
 func pemToPublicKey(raw []byte, pwd []byte) (interface{}, error) {
@@ -0,0 +1,306 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package utils
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"hash"
+	"strings"
+
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	// PBKDF2 selects PBKDF2 with HMAC-SHA256 as the key derivation
+	// function for encrypted PKCS#8 private keys.
+	PBKDF2 = "PBKDF2"
+	// SCRYPT selects scrypt as the key derivation function for
+	// encrypted PKCS#8 private keys.
+	SCRYPT = "SCRYPT"
+
+	// EncryptedPrivateKeyPEMType is the PEM block type used by OpenSSL
+	// for PKCS#8 EncryptedPrivateKeyInfo structures.
+	EncryptedPrivateKeyPEMType = "ENCRYPTED PRIVATE KEY"
+)
+
+// Parameters used when encrypting. They match or exceed the defaults
+// of `openssl pkcs8 -topk8 -v2 aes-256-cbc`.
+const (
+	pbkdf2Iterations = 100000
+	scryptN          = 16384
+	scryptR          = 8
+	scryptP          = 1
+	saltSize         = 16
+)
+
+var (
+	oidPBES2  = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 13}
+	oidPBKDF2 = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 12}
+	oidScrypt = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 11591, 4, 11}
+
+	oidHMACWithSHA1   = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 7}
+	oidHMACWithSHA256 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 9}
+	oidHMACWithSHA384 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 10}
+	oidHMACWithSHA512 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 11}
+
+	oidAES128CBC = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 2}
+	oidAES192CBC = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 22}
+	oidAES256CBC = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 42}
+)
+
+// encryptedPrivateKeyInfo is defined in RFC 5958, section 3.
+type encryptedPrivateKeyInfo struct {
+	EncryptionAlgorithm pkix.AlgorithmIdentifier
+	EncryptedData       []byte
+}
+
+// pbes2Params is defined in RFC 8018, appendix A.4.
+type pbes2Params struct {
+	KeyDerivationFunc pkix.AlgorithmIdentifier
+	EncryptionScheme  pkix.AlgorithmIdentifier
+}
+
+// pbkdf2Params is defined in RFC 8018, appendix A.2.
+type pbkdf2Params struct {
+	Salt           []byte
+	IterationCount int
+	KeyLength      int                      `asn1:"optional"`
+	PRF            pkix.AlgorithmIdentifier `asn1:"optional"`
+}
+
+// scryptParams is defined in RFC 7914, section 7.1.
+type scryptParams struct {
+	Salt                     []byte
+	CostParameter            int
+	BlockSize                int
+	ParallelizationParameter int
+	KeyLength                int `asn1:"optional"`
+}
+
+// EncryptPKCS8PrivateKey encrypts the given PKCS#8 PrivateKeyInfo DER
+// with the password and returns the DER encoding of an RFC 5958
+// EncryptedPrivateKeyInfo using PBES2 with AES-256-CBC. The key is
+// derived with the given kdf, PBKDF2 or SCRYPT. An empty kdf selects
+// PBKDF2. The result can be read with `openssl pkcs8`.
+func EncryptPKCS8PrivateKey(der, pwd []byte, kdf string) ([]byte, error) {
+	if len(der) == 0 {
+		return nil, errors.New("invalid private key. It must be different from nil")
+	}
+	if len(pwd) == 0 {
+		return nil, errors.New("invalid password. It must be different from nil")
+	}
+
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("failed generating salt: [%s]", err)
+	}
+	iv := make([]byte, aes.BlockSize)
+	if _, err := rand.Read(iv); err != nil {
+		return nil, fmt.Errorf("failed generating IV: [%s]", err)
+	}
+
+	const keyLen = 32
+	var key []byte
+	var kdfAlg pkix.AlgorithmIdentifier
+	switch strings.ToUpper(kdf) {
+	case "", PBKDF2:
+		key = pbkdf2.Key(pwd, salt, pbkdf2Iterations, keyLen, sha256.New)
+		params, err := asn1.Marshal(pbkdf2Params{
+			Salt:           salt,
+			IterationCount: pbkdf2Iterations,
+			PRF: pkix.AlgorithmIdentifier{
+				Algorithm:  oidHMACWithSHA256,
+				Parameters: asn1.NullRawValue,
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		kdfAlg = pkix.AlgorithmIdentifier{Algorithm: oidPBKDF2, Parameters: asn1.RawValue{FullBytes: params}}
+	case SCRYPT:
+		var err error
+		key, err = scrypt.Key(pwd, salt, scryptN, scryptR, scryptP, keyLen)
+		if err != nil {
+			return nil, fmt.Errorf("failed deriving key: [%s]", err)
+		}
+		params, err := asn1.Marshal(scryptParams{
+			Salt:                     salt,
+			CostParameter:            scryptN,
+			BlockSize:                scryptR,
+			ParallelizationParameter: scryptP,
+			KeyLength:                keyLen,
+		})
+		if err != nil {
+			return nil, err
+		}
+		kdfAlg = pkix.AlgorithmIdentifier{Algorithm: oidScrypt, Parameters: asn1.RawValue{FullBytes: params}}
+	default:
+		return nil, fmt.Errorf("unsupported key derivation function [%s]", kdf)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	padding := aes.BlockSize - len(der)%aes.BlockSize
+	encrypted := make([]byte, len(der), len(der)+padding)
+	copy(encrypted, der)
+	encrypted = append(encrypted, bytes.Repeat([]byte{byte(padding)}, padding)...)
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(encrypted, encrypted)
+
+	ivParam, err := asn1.Marshal(iv)
+	if err != nil {
+		return nil, err
+	}
+	pbes2, err := asn1.Marshal(pbes2Params{
+		KeyDerivationFunc: kdfAlg,
+		EncryptionScheme: pkix.AlgorithmIdentifier{
+			Algorithm:  oidAES256CBC,
+			Parameters: asn1.RawValue{FullBytes: ivParam},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return asn1.Marshal(encryptedPrivateKeyInfo{
+		EncryptionAlgorithm: pkix.AlgorithmIdentifier{
+			Algorithm:  oidPBES2,
+			Parameters: asn1.RawValue{FullBytes: pbes2},
+		},
+		EncryptedData: encrypted,
+	})
+}
+
+// DecryptPKCS8PrivateKey decrypts the DER encoding of an RFC 5958
+// EncryptedPrivateKeyInfo protected with PBES2 and returns the inner
+// PKCS#8 PrivateKeyInfo DER. PBKDF2 (HMAC-SHA1/SHA256/SHA384/SHA512) and
+// scrypt key derivation are supported, together with AES-CBC ciphers.
+func DecryptPKCS8PrivateKey(der, pwd []byte) ([]byte, error) {
+	if len(pwd) == 0 {
+		return nil, errors.New("encrypted Key. Need a password")
+	}
+
+	var info encryptedPrivateKeyInfo
+	if rest, err := asn1.Unmarshal(der, &info); err != nil {
+		return nil, fmt.Errorf("failed parsing encrypted private key: [%s]", err)
+	} else if len(rest) != 0 {
+		return nil, errors.New("failed parsing encrypted private key: trailing data")
+	}
+	if !info.EncryptionAlgorithm.Algorithm.Equal(oidPBES2) {
+		return nil, fmt.Errorf("unsupported encryption scheme [%s]. Only PBES2 is supported", info.EncryptionAlgorithm.Algorithm)
+	}
+
+	var params pbes2Params
+	if _, err := asn1.Unmarshal(info.EncryptionAlgorithm.Parameters.FullBytes, &params); err != nil {
+		return nil, fmt.Errorf("failed parsing PBES2 parameters: [%s]", err)
+	}
+
+	var keyLen int
+	switch alg := params.EncryptionScheme.Algorithm; {
+	case alg.Equal(oidAES128CBC):
+		keyLen = 16
+	case alg.Equal(oidAES192CBC):
+		keyLen = 24
+	case alg.Equal(oidAES256CBC):
+		keyLen = 32
+	default:
+		return nil, fmt.Errorf("unsupported cipher [%s]", alg)
+	}
+	var iv []byte
+	if _, err := asn1.Unmarshal(params.EncryptionScheme.Parameters.FullBytes, &iv); err != nil {
+		return nil, fmt.Errorf("failed parsing cipher parameters: [%s]", err)
+	}
+	if len(iv) != aes.BlockSize {
+		return nil, errors.New("invalid IV length")
+	}
+
+	key, err := deriveKey(params.KeyDerivationFunc, pwd, keyLen)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	encrypted := info.EncryptedData
+	if len(encrypted) == 0 || len(encrypted)%aes.BlockSize != 0 {
+		return nil, errors.New("invalid encrypted data length")
+	}
+	decrypted := make([]byte, len(encrypted))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(decrypted, encrypted)
+
+	// A wrong password almost always results in invalid padding
+	padding := int(decrypted[len(decrypted)-1])
+	if padding == 0 || padding > aes.BlockSize || padding > len(decrypted) {
+		return nil, errors.New("failed decrypting private key: invalid password or corrupted data")
+	}
+	for _, b := range decrypted[len(decrypted)-padding:] {
+		if int(b) != padding {
+			return nil, errors.New("failed decrypting private key: invalid password or corrupted data")
+		}
+	}
+
+	return decrypted[:len(decrypted)-padding], nil
+}
+
+func deriveKey(kdf pkix.AlgorithmIdentifier, pwd []byte, keyLen int) ([]byte, error) {
+	switch {
+	case kdf.Algorithm.Equal(oidPBKDF2):
+		var params pbkdf2Params
+		if _, err := asn1.Unmarshal(kdf.Parameters.FullBytes, &params); err != nil {
+			return nil, fmt.Errorf("failed parsing PBKDF2 parameters: [%s]", err)
+		}
+		if params.KeyLength != 0 && params.KeyLength != keyLen {
+			return nil, errors.New("PBKDF2 key length does not match the cipher")
+		}
+
+		var h func() hash.Hash
+		switch prf := params.PRF.Algorithm; {
+		case len(prf) == 0, prf.Equal(oidHMACWithSHA1):
+			h = sha1.New
+		case prf.Equal(oidHMACWithSHA256):
+			h = sha256.New
+		case prf.Equal(oidHMACWithSHA384):
+			h = sha512.New384
+		case prf.Equal(oidHMACWithSHA512):
+			h = sha512.New
+		default:
+			return nil, fmt.Errorf("unsupported PBKDF2 PRF [%s]", prf)
+		}
+		return pbkdf2.Key(pwd, params.Salt, params.IterationCount, keyLen, h), nil
+
+	case kdf.Algorithm.Equal(oidScrypt):
+		var params scryptParams
+		if _, err := asn1.Unmarshal(kdf.Parameters.FullBytes, &params); err != nil {
+			return nil, fmt.Errorf("failed parsing scrypt parameters: [%s]", err)
+		}
+		if params.KeyLength != 0 && params.KeyLength != keyLen {
+			return nil, errors.New("scrypt key length does not match the cipher")
+		}
+		key, err := scrypt.Key(pwd, params.Salt, params.CostParameter, params.BlockSize, params.ParallelizationParameter, keyLen)
+		if err != nil {
+			return nil, fmt.Errorf("failed deriving key: [%s]", err)
+		}
+		return key, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported key derivation function [%s]", kdf.Algorithm)
+	}
+}
@@ -0,0 +1,117 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package utils
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptDecryptPKCS8PrivateKey(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	for _, key := range []interface{}{ecKey, edKey} {
+		der, err := x509.MarshalPKCS8PrivateKey(key)
+		require.NoError(t, err)
+
+		for _, kdf := range []string{"", PBKDF2, SCRYPT, "scrypt"} {
+			encrypted, err := EncryptPKCS8PrivateKey(der, []byte("passwd"), kdf)
+			require.NoError(t, err)
+			require.NotContains(t, string(encrypted), string(der))
+
+			decrypted, err := DecryptPKCS8PrivateKey(encrypted, []byte("passwd"))
+			require.NoError(t, err)
+			require.Equal(t, der, decrypted)
+
+			_, err = DecryptPKCS8PrivateKey(encrypted, []byte("wrong"))
+			require.Error(t, err)
+
+			_, err = DecryptPKCS8PrivateKey(encrypted, nil)
+			require.EqualError(t, err, "encrypted Key. Need a password")
+		}
+	}
+}
+
+func TestEncryptPKCS8PrivateKeyInvalidInputs(t *testing.T) {
+	_, err := EncryptPKCS8PrivateKey(nil, []byte("passwd"), PBKDF2)
+	require.EqualError(t, err, "invalid private key. It must be different from nil")
+
+	_, err = EncryptPKCS8PrivateKey([]byte{1, 2, 3}, nil, PBKDF2)
+	require.EqualError(t, err, "invalid password. It must be different from nil")
+
+	_, err = EncryptPKCS8PrivateKey([]byte{1, 2, 3}, []byte("passwd"), "bcrypt")
+	require.EqualError(t, err, "unsupported key derivation function [bcrypt]")
+
+	_, err = DecryptPKCS8PrivateKey([]byte{1, 2, 3}, []byte("passwd"))
+	require.ErrorContains(t, err, "failed parsing encrypted private key")
+}
+
+func TestPKCS8OpenSSLInterop(t *testing.T) {
+	openssl, err := exec.LookPath("openssl")
+	if err != nil {
+		t.Skip("openssl not found")
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	dir := t.TempDir()
+
+	t.Run("OpenSSLDecrypts", func(t *testing.T) {
+		for _, kdf := range []string{PBKDF2, SCRYPT} {
+			encrypted, err := EncryptPKCS8PrivateKey(der, []byte("passwd"), kdf)
+			require.NoError(t, err)
+			in := filepath.Join(dir, kdf+".pem")
+			err = os.WriteFile(in, pem.EncodeToMemory(&pem.Block{Type: EncryptedPrivateKeyPEMType, Bytes: encrypted}), 0o600)
+			require.NoError(t, err)
+
+			out, err := exec.Command(openssl, "pkcs8", "-in", in, "-passin", "pass:passwd").Output()
+			require.NoError(t, err, "openssl failed for %s", kdf)
+			block, _ := pem.Decode(out)
+			require.NotNil(t, block)
+			require.Equal(t, der, block.Bytes)
+		}
+	})
+
+	t.Run("OpenSSLEncrypts", func(t *testing.T) {
+		in := filepath.Join(dir, "plain.pem")
+		err := os.WriteFile(in, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600)
+		require.NoError(t, err)
+
+		for _, args := range [][]string{
+			{"-v2", "aes-256-cbc"},
+			{"-v2", "aes-128-cbc", "-v2prf", "hmacWithSHA512"},
+			{"-v2", "aes-192-cbc", "-v2prf", "hmacWithSHA1"},
+			{"-v2", "aes-256-cbc", "-scrypt"},
+		} {
+			cmd := append([]string{"pkcs8", "-topk8", "-in", in, "-passout", "pass:passwd"}, args...)
+			out, err := exec.Command(openssl, cmd...).Output()
+			require.NoError(t, err, "openssl failed for %v", args)
+			block, _ := pem.Decode(out)
+			require.NotNil(t, block)
+			require.Equal(t, EncryptedPrivateKeyPEMType, block.Type)
+
+			decrypted, err := DecryptPKCS8PrivateKey(block.Bytes, []byte("passwd"))
+			require.NoError(t, err, "failed decrypting %v", args)
+			require.Equal(t, der, decrypted)
+		}
+	})
+}
@@ -28,9 +28,9 @@ var (
 	outWriter io.Writer = os.Stderr
 
 	// CLI arguments
-	mspID                                     *string
-	tlsCA, tlsCert, tlsKey, userKey, userCert **os.File
-	configFile                                *string
+	mspID                                                          *string
+	tlsCA, tlsCert, tlsKey, userKey, userKeyPasswordFile, userCert **os.File
+	configFile                                                     *string
 )
 
 // CLICommand defines a command that is added to the CLI
@@ -102,6 +102,7 @@ func configureFlags(persistCommand *kingpin.Application) {
 	tlsKey = persistCommand.Flag("tlsKey", "(Optional) Sets the client TLS key file path that is used when the peer enforces client authentication").File()
 	// Enrollment flags
 	userKey = persistCommand.Flag("userKey", "Sets the user's key file path that is used to sign messages sent to the peer").File()
+	userKeyPasswordFile = persistCommand.Flag("userKeyPasswordFile", "(Optional) Sets the file path of the password protecting an encrypted user's key").File()
 	userCert = persistCommand.Flag("userCert", "Sets the user's certificate file path that is used to authenticate the messages sent to the peer").File()
 	mspID = persistCommand.Flag("MSP", "Sets the MSP ID of the user, which represents the CA(s) that issued its user certificate").String()
 }
@@ -126,9 +127,10 @@ func loadConfig(file string) Config {
 func parseFlagsToConfig() Config {
 	conf := Config{
 		SignerConfig: signer.Config{
-			MSPID:        *mspID,
-			IdentityPath: evaluateFileFlag(userCert),
-			KeyPath:      evaluateFileFlag(userKey),
+			MSPID:           *mspID,
+			IdentityPath:    evaluateFileFlag(userCert),
+			KeyPath:         evaluateFileFlag(userKey),
+			KeyPasswordFile: evaluateFileFlag(userKeyPasswordFile),
 		},
 		TLSConfig: comm.Config{
 			KeyPath:        evaluateFileFlag(tlsKey),
@@ -7,8 +7,10 @@ SPDX-License-Identifier: Apache-2.0
 package signer
 
 import (
+	"bytes"
 	"crypto"
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/rand"
 	"crypto/x509"
 	"encoding/asn1"
@@ -30,6 +32,9 @@ type Config struct {
 	MSPID        string
 	IdentityPath string
 	KeyPath      string
+	// KeyPasswordFile is the path of a file holding the password of an
+	// encrypted PKCS#8 private key. It is only needed for encrypted keys.
+	KeyPasswordFile string `yaml:"keypasswordfile,omitempty"`
 }
 
 // Signer signs messages.
@@ -37,7 +42,7 @@ type Config struct {
 // initialize an MSP without a CA cert that signs the signing identity,
 // this will do for now.
 type Signer struct {
-	key     *ecdsa.PrivateKey
+	key     crypto.PrivateKey
 	Creator []byte
 }
 
@@ -51,7 +56,15 @@ func NewSigner(conf Config) (*Signer, error) {
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	key, err := loadPrivateKey(conf.KeyPath)
+	var pwd []byte
+	if conf.KeyPasswordFile != "" {
+		pwd, err = os.ReadFile(conf.KeyPasswordFile)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		pwd = bytes.TrimRight(pwd, "\r\n")
+	}
+	key, err := loadPrivateKey(conf.KeyPath, pwd)
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
@@ -93,11 +106,19 @@ func validateEnrollmentCertificate(b []byte) error {
 }
 
 func (si *Signer) Sign(msg []byte) ([]byte, error) {
-	digest := util.ComputeSHA256(msg)
-	return signECDSA(si.key, digest)
+	switch key := si.key.(type) {
+	case ed25519.PrivateKey:
+		// Ed25519 signs the message itself rather than its digest
+		return ed25519.Sign(key, msg), nil
+	case *ecdsa.PrivateKey:
+		digest := util.ComputeSHA256(msg)
+		return signECDSA(key, digest)
+	default:
+		return nil, errors.Errorf("unsupported private key type %T", key)
+	}
 }
 
-func loadPrivateKey(file string) (*ecdsa.PrivateKey, error) {
+func loadPrivateKey(file string, pwd []byte) (crypto.PrivateKey, error) {
 	b, err := os.ReadFile(file)
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -106,11 +127,14 @@ func loadPrivateKey(file string) (*ecdsa.PrivateKey, error) {
 	if bl == nil {
 		return nil, errors.Errorf("failed to decode PEM block from %s", file)
 	}
-	key, err := parsePrivateKey(bl.Bytes)
-	if err != nil {
-		return nil, err
+	if bl.Type == utils.EncryptedPrivateKeyPEMType {
+		der, err := utils.DecryptPKCS8PrivateKey(bl.Bytes, pwd)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to decrypt private key from %s", file)
+		}
+		return parsePrivateKey(der)
 	}
-	return key.(*ecdsa.PrivateKey), nil
+	return parsePrivateKey(bl.Bytes)
 }
 
 // Based on crypto/tls/tls.go but modified for Fabric:
@@ -118,8 +142,8 @@ func parsePrivateKey(der []byte) (crypto.PrivateKey, error) {
 	// OpenSSL 1.0.0 generates PKCS#8 keys.
 	if key, err := x509.ParsePKCS8PrivateKey(der); err == nil {
 		switch key := key.(type) {
-		// Fabric only supports ECDSA at the moment.
-		case *ecdsa.PrivateKey:
+		// Fabric only supports ECDSA and Ed25519 at the moment.
+		case *ecdsa.PrivateKey, ed25519.PrivateKey:
 			return key, nil
 		default:
 			return nil, errors.Errorf("found unknown private key type (%T) in PKCS#8 wrapping", key)
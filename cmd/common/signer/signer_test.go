@@ -8,6 +8,8 @@ package signer
 
 import (
 	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
 	"crypto/x509"
 	"encoding/pem"
 	"io/ioutil"
@@ -36,7 +38,7 @@ func TestSigner(t *testing.T) {
 
 	r, s, err := utils.UnmarshalECDSASignature(sig)
 	require.NoError(t, err)
-	ecdsa.Verify(&signer.key.PublicKey, util.ComputeSHA256(msg), r, s)
+	require.True(t, ecdsa.Verify(&signer.key.(*ecdsa.PrivateKey).PublicKey, util.ComputeSHA256(msg), r, s))
 }
 
 func TestSignerDifferentFormats(t *testing.T) {
@@ -92,6 +94,73 @@ ZsQXrlIqlmNalfYPX+NDDELqlpXQBeEqnA==
 	}
 }
 
+func TestSignerED25519(t *testing.T) {
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(priv)
+	require.NoError(t, err)
+
+	keyFile := filepath.Join(t.TempDir(), "key")
+	err = os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8}), 0o600)
+	require.NoError(t, err)
+
+	signer, err := NewSigner(Config{
+		MSPID:        "MSPID",
+		IdentityPath: filepath.Join("testdata", "signer", "cert.pem"),
+		KeyPath:      keyFile,
+	})
+	require.NoError(t, err)
+
+	msg := []byte("foo")
+	sig, err := signer.Sign(msg)
+	require.NoError(t, err)
+	require.True(t, ed25519.Verify(priv.Public().(ed25519.PublicKey), msg, sig))
+}
+
+func TestSignerEncryptedPKCS8(t *testing.T) {
+	keyPath := filepath.Join("testdata", "signer", "8150cb2d09628ccc89727611ebb736189f6482747eff9b8aaaa27e9a382d2e93_sk")
+	raw, err := os.ReadFile(keyPath)
+	require.NoError(t, err)
+	block, _ := pem.Decode(raw)
+	require.NotNil(t, block)
+
+	encrypted, err := utils.EncryptPKCS8PrivateKey(block.Bytes, []byte("passwd"), utils.SCRYPT)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	keyFile := filepath.Join(dir, "key")
+	err = os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: utils.EncryptedPrivateKeyPEMType, Bytes: encrypted}), 0o600)
+	require.NoError(t, err)
+	pwdFile := filepath.Join(dir, "password")
+	err = os.WriteFile(pwdFile, []byte("passwd\n"), 0o600)
+	require.NoError(t, err)
+
+	conf := Config{
+		MSPID:           "SampleOrg",
+		IdentityPath:    filepath.Join("testdata", "signer", "cert.pem"),
+		KeyPath:         keyFile,
+		KeyPasswordFile: pwdFile,
+	}
+	signer, err := NewSigner(conf)
+	require.NoError(t, err)
+
+	msg := []byte("foo")
+	sig, err := signer.Sign(msg)
+	require.NoError(t, err)
+	r, s, err := utils.UnmarshalECDSASignature(sig)
+	require.NoError(t, err)
+	require.True(t, ecdsa.Verify(&signer.key.(*ecdsa.PrivateKey).PublicKey, util.ComputeSHA256(msg), r, s))
+
+	conf.KeyPasswordFile = ""
+	_, err = NewSigner(conf)
+	require.ErrorContains(t, err, "encrypted Key. Need a password")
+
+	conf.KeyPasswordFile = filepath.Join(dir, "missing")
+	_, err = NewSigner(conf)
+	require.ErrorContains(t, err, "no such file or directory")
+}
+
 func TestSignerBadConfig(t *testing.T) {
 	conf := Config{
 		MSPID:        "SampleOrg",
@@ -13,18 +13,27 @@ import (
 	"net/http"
 	"os"
 	"reflect"
+	"strings"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric-config/protolator"
 	cb "github.com/hyperledger/fabric-protos-go/common"
-	_ "github.com/hyperledger/fabric-protos-go/msp"
+	mb "github.com/hyperledger/fabric-protos-go/msp"
 	_ "github.com/hyperledger/fabric-protos-go/orderer"
 	_ "github.com/hyperledger/fabric-protos-go/orderer/etcdraft"
 	_ "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/bccsp/factory"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/policydsl"
+	"github.com/hyperledger/fabric/internal/configtxgen/encoder"
+	"github.com/hyperledger/fabric/internal/configtxgen/genesisconfig"
+	"github.com/hyperledger/fabric/internal/configtxlator/builder"
+	"github.com/hyperledger/fabric/internal/configtxlator/diff"
 	"github.com/hyperledger/fabric/internal/configtxlator/metadata"
+	"github.com/hyperledger/fabric/internal/configtxlator/policyeval"
 	"github.com/hyperledger/fabric/internal/configtxlator/rest"
 	"github.com/hyperledger/fabric/internal/configtxlator/update"
+	"github.com/hyperledger/fabric/protoutil"
 
 	"github.com/gorilla/handlers"
 	"github.com/pkg/errors"
@@ -56,6 +65,54 @@ var (
 	computeUpdateChannelID = computeUpdate.Flag("channel_id", "The name of the channel for this update.").Required().String()
 	computeUpdateDest      = computeUpdate.Flag("output", "A file to write the JSON document to.").Default(os.Stdout.Name()).OpenFile(os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o600)
 
+	addOrg            = app.Command("add_org", "Builds the config update which adds an organization defined in configtx.yaml to the application group of a channel.")
+	addOrgConfigBlock = addOrg.Flag("config_block", "The latest config block of the channel.").Required().File()
+	addOrgConfigPath  = addOrg.Flag("config_path", "The path containing the configtx.yaml which defines the organization. Defaults to FABRIC_CFG_PATH.").String()
+	addOrgName        = addOrg.Flag("org", "The name of the organization in configtx.yaml.").Required().String()
+	addOrgDest        = addOrg.Flag("output", "A file to write the config update envelope to.").Default(os.Stdout.Name()).OpenFile(os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o600)
+
+	removeOrg            = app.Command("remove_org", "Builds the config update which removes an organization from the application group of a channel.")
+	removeOrgConfigBlock = removeOrg.Flag("config_block", "The latest config block of the channel.").Required().File()
+	removeOrgName        = removeOrg.Flag("org", "The name of the organization to remove.").Required().String()
+	removeOrgDest        = removeOrg.Flag("output", "A file to write the config update envelope to.").Default(os.Stdout.Name()).OpenFile(os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o600)
+
+	setBatchTimeout            = app.Command("set_batch_timeout", "Builds the config update which changes the batch timeout of a channel.")
+	setBatchTimeoutConfigBlock = setBatchTimeout.Flag("config_block", "The latest config block of the channel.").Required().File()
+	setBatchTimeoutTimeout     = setBatchTimeout.Flag("timeout", "The amount of time to wait before cutting a block, e.g. '2s'.").Required().Duration()
+	setBatchTimeoutDest        = setBatchTimeout.Flag("output", "A file to write the config update envelope to.").Default(os.Stdout.Name()).OpenFile(os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o600)
+
+	setBatchSize                  = app.Command("set_batch_size", "Builds the config update which changes the batch size of a channel. Limits which are not set are left unchanged.")
+	setBatchSizeConfigBlock       = setBatchSize.Flag("config_block", "The latest config block of the channel.").Required().File()
+	setBatchSizeMaxMessageCount   = setBatchSize.Flag("max_message_count", "The maximum number of messages in a block.").Uint32()
+	setBatchSizeAbsoluteMaxBytes  = setBatchSize.Flag("absolute_max_bytes", "The absolute maximum number of bytes of the messages in a block.").Uint32()
+	setBatchSizePreferredMaxBytes = setBatchSize.Flag("preferred_max_bytes", "The preferred maximum number of bytes of the messages in a block.").Uint32()
+	setBatchSizeDest              = setBatchSize.Flag("output", "A file to write the config update envelope to.").Default(os.Stdout.Name()).OpenFile(os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o600)
+
+	updateConsenter              = app.Command("update_consenter", "Builds the config update which replaces the TLS certificates of a consenter of a channel. Certificates which are not set are left unchanged.")
+	updateConsenterConfigBlock   = updateConsenter.Flag("config_block", "The latest config block of the channel.").Required().File()
+	updateConsenterHost          = updateConsenter.Flag("host", "The host of the consenter.").Required().String()
+	updateConsenterPort          = updateConsenter.Flag("port", "The cluster port of the consenter.").Required().Uint32()
+	updateConsenterClientTLSCert = updateConsenter.Flag("client_tls_cert", "A file containing the new PEM encoded client TLS certificate of the consenter.").ExistingFile()
+	updateConsenterServerTLSCert = updateConsenter.Flag("server_tls_cert", "A file containing the new PEM encoded server TLS certificate of the consenter.").ExistingFile()
+	updateConsenterDest          = updateConsenter.Flag("output", "A file to write the config update envelope to.").Default(os.Stdout.Name()).OpenFile(os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o600)
+
+	configDiff         = app.Command("diff", "Prints the differences between the configs of two config blocks, or between the config of a config block and the config which results from applying a config update envelope to it.")
+	configDiffOriginal = configDiff.Flag("original", "The original config block.").Required().File()
+	configDiffUpdated  = configDiff.Flag("updated", "The updated config block.").File()
+	configDiffUpdate   = configDiff.Flag("update", "A config update envelope to apply to the original config block, in place of an updated config block.").File()
+	configDiffFormat   = configDiff.Flag("format", "The output format, either 'text' or 'json'.").Default("text").Enum("text", "json")
+	configDiffDest     = configDiff.Flag("output", "A file to write the differences to.").Default(os.Stdout.Name()).OpenFile(os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o600)
+
+	policy                = app.Command("policy", "Works with the policies of a channel config.")
+	policyEval            = policy.Command("eval", "Evaluates a policy expression against the policies and MSPs of the config of a config block, and reports whether a set of identities satisfies it and why. Exits with a non-zero status if the policy is not satisfied.")
+	policyEvalConfigBlock = policyEval.Flag("config_block", "The config block of the channel.").Required().File()
+	policyEvalPolicy      = policyEval.Flag("policy", "The policy expression, e.g. \"OR('Org1MSP.admin', MAJORITY Admins)\".").Required().String()
+	policyEvalPath        = policyEval.Flag("path", "The config group whose sub-groups the implicit meta expressions refer to.").Default("/Channel/Application").String()
+	policyEvalEnvelope    = policyEval.Flag("envelope", "An envelope whose signatures are evaluated, e.g. a config update envelope signed with 'peer channel signconfigtx'.").File()
+	policyEvalIdentities  = policyEval.Flag("identity", "An identity which is evaluated without a signature, in the form <MSP ID>:<PEM encoded certificate file> (may be repeated).").Strings()
+	policyEvalFormat      = policyEval.Flag("format", "The output format, either 'text' or 'json'.").Default("text").Enum("text", "json")
+	policyEvalDest        = policyEval.Flag("output", "A file to write the evaluation report to.").Default(os.Stdout.Name()).OpenFile(os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o600)
+
 	version = app.Command("version", "Show version information")
 )
 
@@ -90,6 +147,58 @@ func main() {
 		if err != nil {
 			app.Fatalf("Error computing update: %s", err)
 		}
+	case addOrg.FullCommand():
+		defer (*addOrgConfigBlock).Close()
+		defer (*addOrgDest).Close()
+		err := addOrgUpdate(*addOrgConfigBlock, *addOrgDest, *addOrgConfigPath, *addOrgName)
+		if err != nil {
+			app.Fatalf("Error building update: %s", err)
+		}
+	case removeOrg.FullCommand():
+		defer (*removeOrgConfigBlock).Close()
+		defer (*removeOrgDest).Close()
+		err := buildUpdate(*removeOrgConfigBlock, *removeOrgDest, builder.RemoveApplicationOrg(*removeOrgName))
+		if err != nil {
+			app.Fatalf("Error building update: %s", err)
+		}
+	case setBatchTimeout.FullCommand():
+		defer (*setBatchTimeoutConfigBlock).Close()
+		defer (*setBatchTimeoutDest).Close()
+		err := buildUpdate(*setBatchTimeoutConfigBlock, *setBatchTimeoutDest, builder.SetBatchTimeout(*setBatchTimeoutTimeout))
+		if err != nil {
+			app.Fatalf("Error building update: %s", err)
+		}
+	case setBatchSize.FullCommand():
+		defer (*setBatchSizeConfigBlock).Close()
+		defer (*setBatchSizeDest).Close()
+		err := buildUpdate(*setBatchSizeConfigBlock, *setBatchSizeDest, builder.SetBatchSize(*setBatchSizeMaxMessageCount, *setBatchSizeAbsoluteMaxBytes, *setBatchSizePreferredMaxBytes))
+		if err != nil {
+			app.Fatalf("Error building update: %s", err)
+		}
+	case updateConsenter.FullCommand():
+		defer (*updateConsenterConfigBlock).Close()
+		defer (*updateConsenterDest).Close()
+		err := updateConsenterUpdate(*updateConsenterConfigBlock, *updateConsenterDest, *updateConsenterHost, *updateConsenterPort, *updateConsenterClientTLSCert, *updateConsenterServerTLSCert)
+		if err != nil {
+			app.Fatalf("Error building update: %s", err)
+		}
+	case configDiff.FullCommand():
+		defer (*configDiffOriginal).Close()
+		defer (*configDiffDest).Close()
+		err := diffConfigs(*configDiffOriginal, *configDiffUpdated, *configDiffUpdate, *configDiffFormat, *configDiffDest)
+		if err != nil {
+			app.Fatalf("Error computing diff: %s", err)
+		}
+	case policyEval.FullCommand():
+		defer (*policyEvalConfigBlock).Close()
+		defer (*policyEvalDest).Close()
+		satisfied, err := evalPolicy(*policyEvalConfigBlock, *policyEvalEnvelope, *policyEvalIdentities, *policyEvalPolicy, *policyEvalPath, *policyEvalFormat, *policyEvalDest)
+		if err != nil {
+			app.Fatalf("Error evaluating policy: %s", err)
+		}
+		if !satisfied {
+			app.Fatalf("Policy is not satisfied")
+		}
 	// "version" command
 	case version.FullCommand():
 		printVersion()
@@ -110,8 +219,9 @@ func startServer(address string, cors []string) {
 		// list will need to be expanded if new non-POST APIs are added
 		methods := handlers.AllowedMethods([]string{http.MethodPost})
 		headers := handlers.AllowedHeaders([]string{"Content-Type"})
+		exposedHeaders := handlers.ExposedHeaders([]string{rest.ModPoliciesHeader})
 		logger.Infof("Serving HTTP requests on %s with CORS %v", listener.Addr(), cors)
-		err = http.Serve(listener, handlers.CORS(origins, methods, headers)(rest.NewRouter()))
+		err = http.Serve(listener, handlers.CORS(origins, methods, headers, exposedHeaders)(rest.NewRouter()))
 	} else {
 		logger.Infof("Serving HTTP requests on %s", listener.Addr())
 		err = http.Serve(listener, rest.NewRouter())
@@ -216,3 +326,250 @@ func computeUpdt(original, updated, output *os.File, channelID string) error {
 
 	return nil
 }
+
+// buildUpdate applies the modifiers to the config carried by the config
+// block, and writes the resulting config update envelope to the output. The
+// modification policies the signatures of the update must satisfy are
+// reported on stderr.
+func buildUpdate(configBlock, output *os.File, modifiers ...builder.Modifier) error {
+	channelID, config, err := readConfigBlock(configBlock)
+	if err != nil {
+		return err
+	}
+
+	env, configUpdate, err := builder.Build(channelID, config, modifiers...)
+	if err != nil {
+		return err
+	}
+
+	outBytes, err := proto.Marshal(env)
+	if err != nil {
+		return errors.Wrapf(err, "error marshaling config update envelope")
+	}
+
+	_, err = output.Write(outBytes)
+	if err != nil {
+		return errors.Wrapf(err, "error writing config update envelope to output")
+	}
+
+	fmt.Fprintf(os.Stderr, "Config update for channel %s must satisfy the policies: %s\n", channelID, strings.Join(builder.ModPolicies(config, configUpdate), ", "))
+
+	return nil
+}
+
+func addOrgUpdate(configBlock, output *os.File, configPath, orgName string) error {
+	var topLevelConfig *genesisconfig.TopLevel
+	if configPath != "" {
+		topLevelConfig = genesisconfig.LoadTopLevel(configPath)
+	} else {
+		topLevelConfig = genesisconfig.LoadTopLevel()
+	}
+
+	for _, org := range topLevelConfig.Organizations {
+		if org.Name != orgName {
+			continue
+		}
+
+		orgGroup, err := encoder.NewApplicationOrgGroup(org)
+		if err != nil {
+			return errors.Wrapf(err, "bad org definition for org %s", org.Name)
+		}
+
+		return buildUpdate(configBlock, output, builder.AddApplicationOrg(org.Name, orgGroup))
+	}
+
+	return errors.Errorf("organization %s not found", orgName)
+}
+
+func updateConsenterUpdate(configBlock, output *os.File, host string, port uint32, clientTLSCertPath, serverTLSCertPath string) error {
+	if clientTLSCertPath == "" && serverTLSCertPath == "" {
+		return errors.New("at least one of --client_tls_cert and --server_tls_cert must be set")
+	}
+
+	var clientTLSCert, serverTLSCert []byte
+	var err error
+	if clientTLSCertPath != "" {
+		clientTLSCert, err = os.ReadFile(clientTLSCertPath)
+		if err != nil {
+			return errors.Wrapf(err, "error reading client TLS certificate")
+		}
+	}
+	if serverTLSCertPath != "" {
+		serverTLSCert, err = os.ReadFile(serverTLSCertPath)
+		if err != nil {
+			return errors.Wrapf(err, "error reading server TLS certificate")
+		}
+	}
+
+	return buildUpdate(configBlock, output, builder.UpdateConsenterTLSCerts(host, port, clientTLSCert, serverTLSCert))
+}
+
+func readConfigBlock(configBlock *os.File) (string, *cb.Config, error) {
+	blockIn, err := io.ReadAll(configBlock)
+	if err != nil {
+		return "", nil, errors.Wrapf(err, "error reading config block")
+	}
+
+	block := &cb.Block{}
+	err = proto.Unmarshal(blockIn, block)
+	if err != nil {
+		return "", nil, errors.Wrapf(err, "error unmarshalling config block")
+	}
+
+	return builder.ConfigFromBlock(block)
+}
+
+func diffConfigs(original, updated, update *os.File, format string, output *os.File) error {
+	if (updated == nil) == (update == nil) {
+		return errors.New("exactly one of --updated and --update must be set")
+	}
+
+	channelID, origConf, err := readConfigBlock(original)
+	if err != nil {
+		return errors.WithMessage(err, "error reading original config block")
+	}
+
+	var updtConf *cb.Config
+	if updated != nil {
+		defer updated.Close()
+		_, updtConf, err = readConfigBlock(updated)
+		if err != nil {
+			return errors.WithMessage(err, "error reading updated config block")
+		}
+	} else {
+		defer update.Close()
+		updtConf, err = applyUpdate(channelID, origConf, update)
+		if err != nil {
+			return err
+		}
+	}
+
+	changes, err := diff.Configs(origConf, updtConf)
+	if err != nil {
+		return err
+	}
+
+	if format == "json" {
+		return diff.WriteJSON(output, changes)
+	}
+	return diff.WriteText(output, changes)
+}
+
+func applyUpdate(channelID string, config *cb.Config, update *os.File) (*cb.Config, error) {
+	updateIn, err := io.ReadAll(update)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error reading config update envelope")
+	}
+
+	env := &cb.Envelope{}
+	err = proto.Unmarshal(updateIn, env)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error unmarshalling config update envelope")
+	}
+
+	configUpdate, err := diff.ConfigUpdateFromEnvelope(env)
+	if err != nil {
+		return nil, err
+	}
+
+	if configUpdate.ChannelId != channelID {
+		return nil, errors.Errorf("config update is for channel %s, but the config block is for channel %s", configUpdate.ChannelId, channelID)
+	}
+
+	return diff.ApplyUpdate(config, configUpdate)
+}
+
+// evalPolicy evaluates the policy expression against the signatures of the
+// envelope or the given identities, writes the report to the output, and
+// returns whether the policy is satisfied.
+func evalPolicy(configBlock, envelope *os.File, identities []string, policy, path, format string, output *os.File) (bool, error) {
+	if (envelope == nil) == (len(identities) == 0) {
+		return false, errors.New("exactly one of --envelope and --identity must be set")
+	}
+
+	expr, err := policydsl.ParseExpression(policy)
+	if err != nil {
+		return false, errors.WithMessage(err, "error parsing policy")
+	}
+
+	channelID, config, err := readConfigBlock(configBlock)
+	if err != nil {
+		return false, err
+	}
+
+	evaluator, err := policyeval.New(channelID, config, factory.GetDefault())
+	if err != nil {
+		return false, err
+	}
+
+	var report *policyeval.Report
+	if envelope != nil {
+		defer envelope.Close()
+		signedData, err := readSignedData(envelope)
+		if err != nil {
+			return false, err
+		}
+		report, err = evaluator.EvaluateSignedData(expr, path, signedData)
+		if err != nil {
+			return false, err
+		}
+	} else {
+		serializedIdentities, err := readIdentities(identities)
+		if err != nil {
+			return false, err
+		}
+		report, err = evaluator.EvaluateIdentities(expr, path, serializedIdentities)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	if format == "json" {
+		err = policyeval.WriteJSON(output, report)
+	} else {
+		err = policyeval.WriteText(output, report)
+	}
+	if err != nil {
+		return false, errors.Wrapf(err, "error writing evaluation report to output")
+	}
+
+	return report.Satisfied, nil
+}
+
+func readSignedData(envelope *os.File) ([]*protoutil.SignedData, error) {
+	envIn, err := io.ReadAll(envelope)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error reading envelope")
+	}
+
+	env := &cb.Envelope{}
+	err = proto.Unmarshal(envIn, env)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error unmarshalling envelope")
+	}
+
+	return policyeval.SignedDataFromEnvelope(env)
+}
+
+func readIdentities(identities []string) ([][]byte, error) {
+	var serializedIdentities [][]byte
+	for _, identity := range identities {
+		i := strings.Index(identity, ":")
+		if i <= 0 || i == len(identity)-1 {
+			return nil, errors.Errorf("identity %s is not in the form <MSP ID>:<certificate file>", identity)
+		}
+
+		cert, err := os.ReadFile(identity[i+1:])
+		if err != nil {
+			return nil, errors.Wrapf(err, "error reading certificate")
+		}
+
+		serializedIdentity, err := proto.Marshal(&mb.SerializedIdentity{Mspid: identity[:i], IdBytes: cert})
+		if err != nil {
+			return nil, errors.Wrapf(err, "error marshalling identity")
+		}
+		serializedIdentities = append(serializedIdentities, serializedIdentity)
+	}
+
+	return serializedIdentities, nil
+}
@@ -7,6 +7,7 @@ package main
 
 import (
 	"bytes"
+	"crypto/x509"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -14,10 +15,12 @@ import (
 	"path/filepath"
 	"text/template"
 
+	"github.com/hyperledger/fabric-config/protolator"
 	"github.com/hyperledger/fabric/internal/cryptogen/ca"
 	"github.com/hyperledger/fabric/internal/cryptogen/csp"
 	"github.com/hyperledger/fabric/internal/cryptogen/metadata"
 	"github.com/hyperledger/fabric/internal/cryptogen/msp"
+	fabricmsp "github.com/hyperledger/fabric/msp"
 
 	kingpin "gopkg.in/alecthomas/kingpin.v2"
 	yaml "gopkg.in/yaml.v2"
@@ -43,10 +46,11 @@ type SpecData struct {
 }
 
 type NodeTemplate struct {
-	Count    int      `yaml:"Count"`
-	Start    int      `yaml:"Start"`
-	Hostname string   `yaml:"Hostname"`
-	SANS     []string `yaml:"SANS"`
+	Count              int      `yaml:"Count"`
+	Start              int      `yaml:"Start"`
+	Hostname           string   `yaml:"Hostname"`
+	SANS               []string `yaml:"SANS"`
+	PublicKeyAlgorithm string   `yaml:"PublicKeyAlgorithm"`
 }
 
 type NodeSpec struct {
@@ -60,10 +64,12 @@ type NodeSpec struct {
 	StreetAddress      string   `yaml:"StreetAddress"`
 	PostalCode         string   `yaml:"PostalCode"`
 	SANS               []string `yaml:"SANS"`
+	PublicKeyAlgorithm string   `yaml:"PublicKeyAlgorithm"`
 }
 
 type UsersSpec struct {
-	Count int `yaml:"Count"`
+	Count              int    `yaml:"Count"`
+	PublicKeyAlgorithm string `yaml:"PublicKeyAlgorithm"`
 }
 
 type OrgSpec struct {
@@ -124,6 +130,7 @@ PeerOrgs:
     #    OrganizationalUnit: Hyperledger Fabric
     #    StreetAddress: address for org # default nil
     #    PostalCode: postalCode for org # default nil
+    #    PublicKeyAlgorithm: ecdsa # ecdsa (default) or ed25519
 
     # ---------------------------------------------------------------------------
     # "Specs"
@@ -148,6 +155,8 @@ PeerOrgs:
     #                 NOTE: Two implicit entries are created for you:
     #                     - {{ .CommonName }}
     #                     - {{ .Hostname }}
+    #   - PublicKeyAlgorithm: (Optional) The algorithm of the node's keys,
+    #                 either "ecdsa" (default) or "ed25519".
     # ---------------------------------------------------------------------------
     # Specs:
     #   - Hostname: foo # implicitly "foo.org1.example.com"
@@ -157,6 +166,7 @@ PeerOrgs:
     #       - "altfoo.{{.Domain}}"
     #       - "{{.Hostname}}.org6.net"
     #       - 172.16.10.31
+    #     PublicKeyAlgorithm: ed25519
     #   - Hostname: bar
     #   - Hostname: baz
 
@@ -178,14 +188,18 @@ PeerOrgs:
       # Hostname: {{.Prefix}}{{.Index}} # default
       # SANS:
       #   - "{{.Hostname}}.alt.{{.Domain}}"
+      # PublicKeyAlgorithm: ecdsa # ecdsa (default) or ed25519
 
     # ---------------------------------------------------------------------------
     # "Users"
     # ---------------------------------------------------------------------------
     # Count: The number of user accounts _in addition_ to Admin
+    # PublicKeyAlgorithm: The algorithm of the users' keys, including Admin,
+    #                     either "ecdsa" (default) or "ed25519".
     # ---------------------------------------------------------------------------
     Users:
       Count: 1
+      # PublicKeyAlgorithm: ecdsa
 
   # ---------------------------------------------------------------------------
   # Org2: See "Org1" for full specification
@@ -213,6 +227,13 @@ var (
 	ext           = app.Command("extend", "Extend existing network")
 	inputDir      = ext.Flag("input", "The input directory in which existing network place").Default("crypto-config").String()
 	extConfigFile = ext.Flag("config", "The configuration template to use").File()
+
+	rev          = app.Command("revoke", "Revoke the certificates of nodes and users of an organization")
+	revInputDir  = rev.Flag("input", "The input directory in which existing network place").Default("crypto-config").String()
+	revOrg       = rev.Flag("org", "The domain of the organization, e.g. 'org1.example.com'").Required().String()
+	revNames     = rev.Flag("name", "The name of a node or user to revoke, e.g. 'peer0.org1.example.com' or 'User1@org1.example.com' (may be repeated)").Required().Strings()
+	revMSPID     = rev.Flag("mspid", "The MSP ID of the organization, required to write the MSP config").String()
+	revMSPConfig = rev.Flag("mspconfig", "The file to write the MSP config of the organization to as JSON, for use in a channel config update").String()
 )
 
 func main() {
@@ -226,6 +247,13 @@ func main() {
 	case ext.FullCommand():
 		extend()
 
+	case rev.FullCommand():
+		err := revoke(*revInputDir, *revOrg, *revNames, *revMSPID, *revMSPConfig)
+		if err != nil {
+			fmt.Printf("Error revoking certificates: %s\n", err)
+			os.Exit(1)
+		}
+
 		// "showtemplate" command
 	case showtemplate.FullCommand():
 		fmt.Print(defaultConfig)
@@ -312,8 +340,9 @@ func extendPeerOrg(orgSpec OrgSpec) {
 	generateNodes(peersDir, orgSpec.Specs, signCA, tlsCA, msp.PEER, orgSpec.EnableNodeOUs)
 
 	adminUser := NodeSpec{
-		isAdmin:    true,
-		CommonName: fmt.Sprintf("%s@%s", adminBaseName, orgName),
+		isAdmin:            true,
+		CommonName:         fmt.Sprintf("%s@%s", adminBaseName, orgName),
+		PublicKeyAlgorithm: orgSpec.Users.PublicKeyAlgorithm,
 	}
 	// copy the admin cert to each of the org's peer's MSP admincerts
 	for _, spec := range orgSpec.Specs {
@@ -333,7 +362,8 @@ func extendPeerOrg(orgSpec OrgSpec) {
 	users := []NodeSpec{}
 	for j := 1; j <= orgSpec.Users.Count; j++ {
 		user := NodeSpec{
-			CommonName: fmt.Sprintf("%s%d@%s", userBaseName, j, orgName),
+			CommonName:         fmt.Sprintf("%s%d@%s", userBaseName, j, orgName),
+			PublicKeyAlgorithm: orgSpec.Users.PublicKeyAlgorithm,
 		}
 
 		users = append(users, user)
@@ -361,8 +391,9 @@ func extendOrdererOrg(orgSpec OrgSpec) {
 	generateNodes(orderersDir, orgSpec.Specs, signCA, tlsCA, msp.ORDERER, orgSpec.EnableNodeOUs)
 
 	adminUser := NodeSpec{
-		isAdmin:    true,
-		CommonName: fmt.Sprintf("%s@%s", adminBaseName, orgName),
+		isAdmin:            true,
+		CommonName:         fmt.Sprintf("%s@%s", adminBaseName, orgName),
+		PublicKeyAlgorithm: orgSpec.Users.PublicKeyAlgorithm,
 	}
 
 	for _, spec := range orgSpec.Specs {
@@ -429,7 +460,24 @@ func parseTemplateWithDefault(input, defaultInput string, data interface{}) (str
 	return parseTemplate(input, data)
 }
 
+func renderPublicKeyAlgorithm(keyAlg *string) error {
+	switch *keyAlg {
+	case "":
+		*keyAlg = csp.ECDSA
+	case csp.ECDSA, csp.ED25519:
+	default:
+		return fmt.Errorf("unsupported public key algorithm: %s", *keyAlg)
+	}
+
+	return nil
+}
+
 func renderNodeSpec(domain string, spec *NodeSpec) error {
+	err := renderPublicKeyAlgorithm(&spec.PublicKeyAlgorithm)
+	if err != nil {
+		return err
+	}
+
 	data := SpecData{
 		Hostname: spec.Hostname,
 		Domain:   domain,
@@ -478,8 +526,9 @@ func renderOrgSpec(orgSpec *OrgSpec, prefix string) error {
 		}
 
 		spec := NodeSpec{
-			Hostname: hostname,
-			SANS:     orgSpec.Template.SANS,
+			Hostname:           hostname,
+			SANS:               orgSpec.Template.SANS,
+			PublicKeyAlgorithm: orgSpec.Template.PublicKeyAlgorithm,
 		}
 		orgSpec.Specs = append(orgSpec.Specs, spec)
 	}
@@ -503,7 +552,8 @@ func renderOrgSpec(orgSpec *OrgSpec, prefix string) error {
 		return err
 	}
 
-	return nil
+	// Users, including the admin, share the same key algorithm
+	return renderPublicKeyAlgorithm(&orgSpec.Users.PublicKeyAlgorithm)
 }
 
 func generatePeerOrg(baseDir string, orgSpec OrgSpec) {
@@ -519,19 +569,19 @@ func generatePeerOrg(baseDir string, orgSpec OrgSpec) {
 	usersDir := filepath.Join(orgDir, "users")
 	adminCertsDir := filepath.Join(mspDir, "admincerts")
 	// generate signing CA
-	signCA, err := ca.NewCA(caDir, orgName, orgSpec.CA.CommonName, orgSpec.CA.Country, orgSpec.CA.Province, orgSpec.CA.Locality, orgSpec.CA.OrganizationalUnit, orgSpec.CA.StreetAddress, orgSpec.CA.PostalCode)
+	signCA, err := ca.NewCA(caDir, orgName, orgSpec.CA.CommonName, orgSpec.CA.Country, orgSpec.CA.Province, orgSpec.CA.Locality, orgSpec.CA.OrganizationalUnit, orgSpec.CA.StreetAddress, orgSpec.CA.PostalCode, orgSpec.CA.PublicKeyAlgorithm)
 	if err != nil {
 		fmt.Printf("Error generating signCA for org %s:\n%v\n", orgName, err)
 		os.Exit(1)
 	}
 	// generate TLS CA
-	tlsCA, err := ca.NewCA(tlsCADir, orgName, "tls"+orgSpec.CA.CommonName, orgSpec.CA.Country, orgSpec.CA.Province, orgSpec.CA.Locality, orgSpec.CA.OrganizationalUnit, orgSpec.CA.StreetAddress, orgSpec.CA.PostalCode)
+	tlsCA, err := ca.NewCA(tlsCADir, orgName, "tls"+orgSpec.CA.CommonName, orgSpec.CA.Country, orgSpec.CA.Province, orgSpec.CA.Locality, orgSpec.CA.OrganizationalUnit, orgSpec.CA.StreetAddress, orgSpec.CA.PostalCode, orgSpec.CA.PublicKeyAlgorithm)
 	if err != nil {
 		fmt.Printf("Error generating tlsCA for org %s:\n%v\n", orgName, err)
 		os.Exit(1)
 	}
 
-	err = msp.GenerateVerifyingMSP(mspDir, signCA, tlsCA, orgSpec.EnableNodeOUs)
+	err = msp.GenerateVerifyingMSP(mspDir, signCA, tlsCA, orgSpec.EnableNodeOUs, orgSpec.CA.PublicKeyAlgorithm)
 	if err != nil {
 		fmt.Printf("Error generating MSP for org %s:\n%v\n", orgName, err)
 		os.Exit(1)
@@ -543,15 +593,17 @@ func generatePeerOrg(baseDir string, orgSpec OrgSpec) {
 	users := []NodeSpec{}
 	for j := 1; j <= orgSpec.Users.Count; j++ {
 		user := NodeSpec{
-			CommonName: fmt.Sprintf("%s%d@%s", userBaseName, j, orgName),
+			CommonName:         fmt.Sprintf("%s%d@%s", userBaseName, j, orgName),
+			PublicKeyAlgorithm: orgSpec.Users.PublicKeyAlgorithm,
 		}
 
 		users = append(users, user)
 	}
 	// add an admin user
 	adminUser := NodeSpec{
-		isAdmin:    true,
-		CommonName: fmt.Sprintf("%s@%s", adminBaseName, orgName),
+		isAdmin:            true,
+		CommonName:         fmt.Sprintf("%s@%s", adminBaseName, orgName),
+		PublicKeyAlgorithm: orgSpec.Users.PublicKeyAlgorithm,
 	}
 
 	users = append(users, adminUser)
@@ -614,7 +666,7 @@ func generateNodes(baseDir string, nodes []NodeSpec, signCA *ca.CA, tlsCA *ca.CA
 			if node.isAdmin && nodeOUs {
 				currentNodeType = msp.ADMIN
 			}
-			err := msp.GenerateLocalMSP(nodeDir, node.CommonName, node.SANS, signCA, tlsCA, currentNodeType, nodeOUs)
+			err := msp.GenerateLocalMSP(nodeDir, node.CommonName, node.SANS, signCA, tlsCA, currentNodeType, nodeOUs, node.PublicKeyAlgorithm)
 			if err != nil {
 				fmt.Printf("Error generating local MSP for %v:\n%v\n", node, err)
 				os.Exit(1)
@@ -635,19 +687,19 @@ func generateOrdererOrg(baseDir string, orgSpec OrgSpec) {
 	usersDir := filepath.Join(orgDir, "users")
 	adminCertsDir := filepath.Join(mspDir, "admincerts")
 	// generate signing CA
-	signCA, err := ca.NewCA(caDir, orgName, orgSpec.CA.CommonName, orgSpec.CA.Country, orgSpec.CA.Province, orgSpec.CA.Locality, orgSpec.CA.OrganizationalUnit, orgSpec.CA.StreetAddress, orgSpec.CA.PostalCode)
+	signCA, err := ca.NewCA(caDir, orgName, orgSpec.CA.CommonName, orgSpec.CA.Country, orgSpec.CA.Province, orgSpec.CA.Locality, orgSpec.CA.OrganizationalUnit, orgSpec.CA.StreetAddress, orgSpec.CA.PostalCode, orgSpec.CA.PublicKeyAlgorithm)
 	if err != nil {
 		fmt.Printf("Error generating signCA for org %s:\n%v\n", orgName, err)
 		os.Exit(1)
 	}
 	// generate TLS CA
-	tlsCA, err := ca.NewCA(tlsCADir, orgName, "tls"+orgSpec.CA.CommonName, orgSpec.CA.Country, orgSpec.CA.Province, orgSpec.CA.Locality, orgSpec.CA.OrganizationalUnit, orgSpec.CA.StreetAddress, orgSpec.CA.PostalCode)
+	tlsCA, err := ca.NewCA(tlsCADir, orgName, "tls"+orgSpec.CA.CommonName, orgSpec.CA.Country, orgSpec.CA.Province, orgSpec.CA.Locality, orgSpec.CA.OrganizationalUnit, orgSpec.CA.StreetAddress, orgSpec.CA.PostalCode, orgSpec.CA.PublicKeyAlgorithm)
 	if err != nil {
 		fmt.Printf("Error generating tlsCA for org %s:\n%v\n", orgName, err)
 		os.Exit(1)
 	}
 
-	err = msp.GenerateVerifyingMSP(mspDir, signCA, tlsCA, orgSpec.EnableNodeOUs)
+	err = msp.GenerateVerifyingMSP(mspDir, signCA, tlsCA, orgSpec.EnableNodeOUs, orgSpec.CA.PublicKeyAlgorithm)
 	if err != nil {
 		fmt.Printf("Error generating MSP for org %s:\n%v\n", orgName, err)
 		os.Exit(1)
@@ -656,8 +708,9 @@ func generateOrdererOrg(baseDir string, orgSpec OrgSpec) {
 	generateNodes(orderersDir, orgSpec.Specs, signCA, tlsCA, msp.ORDERER, orgSpec.EnableNodeOUs)
 
 	adminUser := NodeSpec{
-		isAdmin:    true,
-		CommonName: fmt.Sprintf("%s@%s", adminBaseName, orgName),
+		isAdmin:            true,
+		CommonName:         fmt.Sprintf("%s@%s", adminBaseName, orgName),
+		PublicKeyAlgorithm: orgSpec.Users.PublicKeyAlgorithm,
 	}
 
 	// generate an admin for the orderer org
@@ -716,11 +769,12 @@ func printVersion() {
 
 func getCA(caDir string, spec OrgSpec, name string) *ca.CA {
 	priv, _ := csp.LoadPrivateKey(caDir)
+	signer, _ := csp.NewSigner(priv)
 	cert, _ := ca.LoadCertificateECDSA(caDir)
 
 	return &ca.CA{
 		Name:               name,
-		Signer:             priv,
+		Signer:             signer,
 		SignCert:           cert,
 		Country:            spec.CA.Country,
 		Province:           spec.CA.Province,
@@ -730,3 +784,120 @@ func getCA(caDir string, spec OrgSpec, name string) *ca.CA {
 		PostalCode:         spec.CA.PostalCode,
 	}
 }
+
+// revoke adds the signing certificates of the named nodes and users of an
+// organization to the CRL of its signing CA, in the crls folder of the
+// organization's MSP.
+func revoke(baseDir, orgName string, names []string, mspID, mspConfigPath string) error {
+	if mspConfigPath != "" && mspID == "" {
+		return fmt.Errorf("the MSP ID of the organization is required to write its MSP config")
+	}
+
+	orgDir, nodeDirs, err := findOrg(baseDir, orgName)
+	if err != nil {
+		return err
+	}
+
+	signCA, err := loadCA(filepath.Join(orgDir, "ca"))
+	if err != nil {
+		return fmt.Errorf("Error loading signCA for org %s: %s", orgName, err)
+	}
+
+	var certs []*x509.Certificate
+	for _, name := range names {
+		cert, err := findSignCert(orgDir, nodeDirs, name)
+		if err != nil {
+			return err
+		}
+		certs = append(certs, cert)
+	}
+
+	mspDir := filepath.Join(orgDir, "msp")
+	crl, err := msp.RevokeCertificates(mspDir, signCA, certs)
+	if err != nil {
+		return fmt.Errorf("Error generating CRL for org %s: %s", orgName, err)
+	}
+
+	for i, cert := range certs {
+		fmt.Printf("Revoked %s (serial number %s)\n", names[i], cert.SerialNumber)
+	}
+	fmt.Printf("CRL number %s of %s revokes %d certificate(s)\n", crl.Number, signCA.Name, len(crl.RevokedCertificates))
+
+	if mspConfigPath == "" {
+		return nil
+	}
+
+	mspConfig, err := fabricmsp.GetVerifyingMspConfig(mspDir, mspID, "bccsp")
+	if err != nil {
+		return fmt.Errorf("Error loading MSP config for org %s: %s", orgName, err)
+	}
+
+	buf := &bytes.Buffer{}
+	err = protolator.DeepMarshalJSON(buf, mspConfig)
+	if err != nil {
+		return fmt.Errorf("Error encoding MSP config for org %s: %s", orgName, err)
+	}
+
+	return ioutil.WriteFile(mspConfigPath, buf.Bytes(), 0o644)
+}
+
+// findOrg returns the directory of a peer or orderer organization, along with
+// the names of the folders holding the local MSPs of its nodes and users.
+func findOrg(baseDir, orgName string) (string, []string, error) {
+	orgDir := filepath.Join(baseDir, "peerOrganizations", orgName)
+	if _, err := os.Stat(orgDir); err == nil {
+		return orgDir, []string{"peers", "users"}, nil
+	}
+
+	orgDir = filepath.Join(baseDir, "ordererOrganizations", orgName)
+	if _, err := os.Stat(orgDir); err == nil {
+		return orgDir, []string{"orderers", "users"}, nil
+	}
+
+	return "", nil, fmt.Errorf("organization %s not found in %s", orgName, baseDir)
+}
+
+func findSignCert(orgDir string, nodeDirs []string, name string) (*x509.Certificate, error) {
+	for _, nodeDir := range nodeDirs {
+		signCertsDir := filepath.Join(orgDir, nodeDir, name, "msp", "signcerts")
+		if _, err := os.Stat(signCertsDir); err != nil {
+			continue
+		}
+
+		cert, err := ca.LoadCertificateECDSA(signCertsDir)
+		if err != nil {
+			return nil, fmt.Errorf("Error loading certificate of %s: %s", name, err)
+		}
+		if cert == nil {
+			return nil, fmt.Errorf("no certificate found for %s in %s", name, signCertsDir)
+		}
+
+		return cert, nil
+	}
+
+	return nil, fmt.Errorf("no node or user named %s in %s", name, orgDir)
+}
+
+func loadCA(caDir string) (*ca.CA, error) {
+	priv, err := csp.LoadPrivateKey(caDir)
+	if err != nil {
+		return nil, err
+	}
+	signer, err := csp.NewSigner(priv)
+	if err != nil {
+		return nil, err
+	}
+	cert, err := ca.LoadCertificateECDSA(caDir)
+	if err != nil {
+		return nil, err
+	}
+	if cert == nil {
+		return nil, fmt.Errorf("no CA certificate found in %s", caDir)
+	}
+
+	return &ca.CA{
+		Name:     cert.Subject.CommonName,
+		Signer:   signer,
+		SignCert: cert,
+	}, nil
+}
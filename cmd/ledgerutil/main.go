@@ -9,9 +9,12 @@ package main
 import (
 	"fmt"
 	"os"
+	"strconv"
 
 	"github.com/hyperledger/fabric/internal/ledgerutil/compare"
+	"github.com/hyperledger/fabric/internal/ledgerutil/export"
 	"github.com/hyperledger/fabric/internal/ledgerutil/identifytxs"
+	"github.com/hyperledger/fabric/internal/ledgerutil/inspect"
 	"github.com/hyperledger/fabric/internal/ledgerutil/verify"
 	"gopkg.in/alecthomas/kingpin.v2"
 )
@@ -31,6 +34,26 @@ const (
 	outputDirIdDesc       = "Location for identified transactions json results output directory. Default is the current directory."
 	verifyErrorMessage    = "Verify Ledger Error:"
 	outputDirVerifyDesc   = "Location for verification result output directory. Default is the current directory."
+	exportErrorMessage    = "Ledger Export Error: "
+	exportSnapshotDesc    = "Ledger snapshot directory to export the world state from. Either a snapshot or a peer file system path must be provided."
+	exportFSPathDesc      = "Path to file system of a stopped peer to export the world state from, used to access the state LevelDB. " +
+		"Requires the channel to be provided."
+	exportChannelDesc   = "Channel whose world state is exported from the peer file system."
+	exportNamespaceDesc = "Namespace of the records to export. Defaults to all the namespaces."
+	exportKeyPrefixDesc = "Prefix of the keys of the records to export. Does not apply to the hashes of private data."
+	exportFormatDesc    = "Format of the output file, one json object per line (json) or comma separated values (csv)."
+	exportPvtHashesDesc = "Include the hashes of the private data keys and values."
+	outputDirExportDesc = "Location for the exported state output file. Default is the current directory."
+	inspectErrorMessage = "Ledger Inspect Error: "
+	inspectFSPathDesc   = "Path to file system of a stopped peer, or ledger location of a stopped orderer, used to read the block files. " +
+		"Defaults to '/var/hyperledger/production'."
+	inspectChannelDesc = "Channel whose blocks are inspected."
+	startBlockDesc     = "First block to print."
+	endBlockDesc       = "Last block to print. Defaults to the last block in the block store."
+	txIDFilterDesc     = "Print only the transactions with this transaction ID, and the blocks that contain them."
+	chaincodeDesc      = "Print only the transactions that invoke this chaincode, and the blocks that contain them."
+	validationCodeDesc = "Print only the transactions with this validation code, for example VALID or MVCC_READ_CONFLICT, and the blocks that contain them."
+	txIDDesc           = "Transaction ID of the transaction to print."
 )
 
 var (
@@ -51,6 +74,30 @@ var (
 	blockStorePathVerify = verifyApp.Arg("blockStorePath", blockStorePathDesc).Default(blockStorePathDefault).String()
 	outputDirVerify      = verifyApp.Flag("outputDir", outputDirVerifyDesc).Short('o').String()
 
+	exportApp              = app.Command("export", "Export the world state of a channel from a snapshot or a stopped peer.")
+	exportSnapshotPath     = exportApp.Flag("snapshotPath", exportSnapshotDesc).Short('s').String()
+	exportFSPath           = exportApp.Flag("fileSystemPath", exportFSPathDesc).Short('p').String()
+	exportChannel          = exportApp.Flag("channelID", exportChannelDesc).Short('c').String()
+	exportNamespace        = exportApp.Flag("namespace", exportNamespaceDesc).Short('n').String()
+	exportKeyPrefix        = exportApp.Flag("keyPrefix", exportKeyPrefixDesc).Short('k').String()
+	exportFormat           = exportApp.Flag("format", exportFormatDesc).Short('f').Default(export.JSONFormat).Enum(export.JSONFormat, export.CSVFormat)
+	exportIncludePvtHashes = exportApp.Flag("includePvtHashes", exportPvtHashesDesc).Bool()
+	outputDirExport        = exportApp.Flag("outputDir", outputDirExportDesc).Short('o').String()
+
+	blocksApp            = app.Command("blocks", "Print the decoded blocks of a channel from the block store of a stopped peer or orderer.")
+	blocksFSPath         = blocksApp.Arg("fileSystemPath", inspectFSPathDesc).Default(blockStorePathDefault).String()
+	blocksChannel        = blocksApp.Flag("channelID", inspectChannelDesc).Short('c').Required().String()
+	blocksStart          = blocksApp.Flag("startBlock", startBlockDesc).Short('s').Default("0").Uint64()
+	blocksEnd            = blocksApp.Flag("endBlock", endBlockDesc).Short('e').String()
+	blocksTxID           = blocksApp.Flag("txID", txIDFilterDesc).Short('t').String()
+	blocksChaincode      = blocksApp.Flag("chaincode", chaincodeDesc).Short('n').String()
+	blocksValidationCode = blocksApp.Flag("validationCode", validationCodeDesc).Short('v').String()
+
+	txApp     = app.Command("tx", "Print a decoded transaction of a channel from the block store of a stopped peer or orderer.")
+	txID      = txApp.Arg("txID", txIDDesc).Required().String()
+	txFSPath  = txApp.Arg("fileSystemPath", inspectFSPathDesc).Default(blockStorePathDefault).String()
+	txChannel = txApp.Flag("channelID", inspectChannelDesc).Short('c').Required().String()
+
 	args = os.Args[1:]
 )
 
@@ -135,5 +182,75 @@ func main() {
 			fmt.Printf("\nSuccessfully executed verify tool. Some error(s) are found.\n")
 			os.Exit(1)
 		}
+
+	case exportApp.FullCommand():
+
+		if (*exportSnapshotPath == "") == (*exportFSPath == "") {
+			fmt.Printf("%sexactly one of --snapshotPath and --fileSystemPath must be provided\n", exportErrorMessage)
+			os.Exit(1)
+		}
+		if *exportFSPath != "" && *exportChannel == "" {
+			fmt.Printf("%s--channelID must be provided when exporting from a peer file system\n", exportErrorMessage)
+			os.Exit(1)
+		}
+
+		// Determine result file location
+		if *outputDirExport == "" {
+			*outputDirExport, err = os.Getwd()
+			if err != nil {
+				fmt.Printf("%s%s\n", exportErrorMessage, err)
+				os.Exit(1)
+			}
+		}
+
+		filter := &export.Filter{
+			Namespace:        *exportNamespace,
+			KeyPrefix:        *exportKeyPrefix,
+			IncludePvtHashes: *exportIncludePvtHashes,
+		}
+		var count int
+		var outputFilePath string
+		if *exportSnapshotPath != "" {
+			count, outputFilePath, err = export.ExportSnapshot(*exportSnapshotPath, *outputDirExport, *exportFormat, filter)
+		} else {
+			count, outputFilePath, err = export.ExportPeer(*exportFSPath, *exportChannel, *outputDirExport, *exportFormat, filter)
+		}
+		if err != nil {
+			fmt.Printf("%s%s\n", exportErrorMessage, err)
+			os.Exit(1)
+		}
+		fmt.Printf("\nSuccessfully exported world state. Results saved to %s. Total records exported: %d\n", outputFilePath, count)
+
+	case blocksApp.FullCommand():
+
+		filter := inspect.NewFilter()
+		filter.StartBlock = *blocksStart
+		if *blocksEnd != "" {
+			filter.EndBlock, err = strconv.ParseUint(*blocksEnd, 10, 64)
+			if err != nil {
+				fmt.Printf("%sinvalid end block %s\n", inspectErrorMessage, *blocksEnd)
+				os.Exit(1)
+			}
+		}
+		filter.TxID = *blocksTxID
+		filter.Chaincode = *blocksChaincode
+		filter.ValidationCode = *blocksValidationCode
+
+		if _, err := inspect.PrintBlocks(*blocksFSPath, *blocksChannel, filter, os.Stdout); err != nil {
+			fmt.Printf("%s%s\n", inspectErrorMessage, err)
+			os.Exit(1)
+		}
+
+	case txApp.FullCommand():
+
+		count, err := inspect.PrintTx(*txFSPath, *txChannel, *txID, os.Stdout)
+		if err != nil {
+			fmt.Printf("%s%s\n", inspectErrorMessage, err)
+			os.Exit(1)
+		}
+		if count == 0 {
+			fmt.Printf("%sTransaction %s was not found in the block store\n", inspectErrorMessage, *txID)
+			os.Exit(1)
+		}
 	}
 }
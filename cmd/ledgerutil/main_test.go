@@ -60,6 +60,54 @@ func TestArguments(t *testing.T) {
 			exitCode: 1,
 			args:     []string{"verify"},
 		},
+		"export-help": {
+			exitCode: 0,
+			args:     []string{"export", "--help"},
+		},
+		"export": {
+			exitCode: 1,
+			args:     []string{"export"},
+		},
+		"export-snapshot-and-peer": {
+			exitCode: 1,
+			args:     []string{"export", "--snapshotPath", "snapshotDir", "--fileSystemPath", "fsPath"},
+		},
+		"export-peer-without-channel": {
+			exitCode: 1,
+			args:     []string{"export", "--fileSystemPath", "fsPath"},
+		},
+		"blocks-help": {
+			exitCode: 0,
+			args:     []string{"blocks", "--help"},
+		},
+		"blocks-without-channel": {
+			exitCode: 1,
+			args:     []string{"blocks", "../../internal/ledgerutil/testdata/sample_prod"},
+		},
+		"blocks": {
+			exitCode: 0,
+			args:     []string{"blocks", "../../internal/ledgerutil/testdata/sample_prod", "-c", "mychannel", "-s", "3", "-e", "4"},
+		},
+		"blocks-invalid-end-block": {
+			exitCode: 1,
+			args:     []string{"blocks", "../../internal/ledgerutil/testdata/sample_prod", "-c", "mychannel", "-e", "last"},
+		},
+		"tx-help": {
+			exitCode: 0,
+			args:     []string{"tx", "--help"},
+		},
+		"tx": {
+			exitCode: 0,
+			args:     []string{"tx", "ff86affc63812d2d7dda806a298ee8493f3b09c6a5941f64faff8d667609effb", "../../internal/ledgerutil/testdata/sample_prod", "-c", "mychannel"},
+		},
+		"tx-not-found": {
+			exitCode: 1,
+			args:     []string{"tx", "unknown", "../../internal/ledgerutil/testdata/sample_prod", "-c", "mychannel"},
+		},
+		"export-invalid-format": {
+			exitCode: 1,
+			args:     []string{"export", "--snapshotPath", "snapshotDir", "--format", "xml"},
+		},
 	}
 
 	// Build ledger binary
@@ -19,6 +19,7 @@ import (
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric-protos-go/common"
 	"github.com/hyperledger/fabric/internal/osnadmin"
+	"github.com/hyperledger/fabric/orderer/common/types"
 	"github.com/hyperledger/fabric/protoutil"
 	"gopkg.in/alecthomas/kingpin.v2"
 )
@@ -57,6 +58,20 @@ func executeForArgs(args []string) (output string, exit int, err error) {
 	remove := channel.Command("remove", "Remove an Ordering Service Node (OSN) from a channel.")
 	removeChannelID := remove.Flag("channelID", "Channel ID").Short('c').Required().String()
 
+	configBlock := channel.Command("config-block", "Fetch the latest config block of a channel from an Ordering Service Node (OSN).")
+	configBlockChannelID := configBlock.Flag("channelID", "Channel ID").Short('c').Required().String()
+	outputBlockPath := configBlock.Flag("output-block", "Path to the file the fetched config block is written to").Short('f').String()
+
+	clusterStatus := channel.Command("cluster-status", "Report the consenters of a channel and the catch-up progress of an Ordering Service Node (OSN).")
+	clusterStatusChannelID := clusterStatus.Flag("channelID", "Channel ID").Short('c').Required().String()
+
+	raftStatus := channel.Command("raft-status", "Report the Raft term, leader, commit index and, on the leader, the replication progress of the followers of a channel on an Ordering Service Node (OSN).")
+	raftStatusChannelID := raftStatus.Flag("channelID", "Channel ID").Short('c').Required().String()
+
+	transferLeadership := channel.Command("transfer-leadership", "Transfer the leadership of a channel from an Ordering Service Node (OSN) to another consenter. The OSN must be the leader of the channel.")
+	transferLeadershipChannelID := transferLeadership.Flag("channelID", "Channel ID").Short('c').Required().String()
+	transferLeadershipTo := transferLeadership.Flag("to", "Raft ID of the consenter to transfer the leadership to; if not set, the OSN picks a recently active consenter").Default("0").Uint64()
+
 	command, err := app.Parse(args)
 	if err != nil {
 		return "", 1, err
@@ -120,6 +135,14 @@ func executeForArgs(args []string) (output string, exit int, err error) {
 		resp, err = osnadmin.ListAllChannels(osnURL, caCertPool, tlsClientCert)
 	case remove.FullCommand():
 		resp, err = osnadmin.Remove(osnURL, *removeChannelID, caCertPool, tlsClientCert)
+	case configBlock.FullCommand():
+		resp, err = osnadmin.ConfigBlock(osnURL, *configBlockChannelID, caCertPool, tlsClientCert)
+	case clusterStatus.FullCommand():
+		resp, err = osnadmin.ClusterStatus(osnURL, *clusterStatusChannelID, caCertPool, tlsClientCert)
+	case raftStatus.FullCommand():
+		resp, err = osnadmin.RaftStatus(osnURL, *raftStatusChannelID, caCertPool, tlsClientCert)
+	case transferLeadership.FullCommand():
+		resp, err = osnadmin.TransferLeadership(osnURL, *transferLeadershipChannelID, *transferLeadershipTo, caCertPool, tlsClientCert)
 	}
 	if err != nil {
 		return errorOutput(err), 1, nil
@@ -130,6 +153,13 @@ func executeForArgs(args []string) (output string, exit int, err error) {
 		return errorOutput(err), 1, nil
 	}
 
+	if command == configBlock.FullCommand() && *outputBlockPath != "" && resp.StatusCode == http.StatusOK {
+		bodyBytes, err = writeConfigBlock(bodyBytes, *outputBlockPath)
+		if err != nil {
+			return errorOutput(err), 1, nil
+		}
+	}
+
 	output, err = responseOutput(!*noStatus, resp.StatusCode, bodyBytes)
 	if err != nil {
 		return errorOutput(err), 1, nil
@@ -161,6 +191,26 @@ func readBodyBytes(body io.ReadCloser) ([]byte, error) {
 	return bodyBytes, nil
 }
 
+// writeConfigBlock writes the block carried by a config-block response to
+// the given path and returns the response without the block bytes.
+func writeConfigBlock(responseBody []byte, path string) ([]byte, error) {
+	configBlock := &types.ConfigBlock{}
+	if err := json.Unmarshal(responseBody, configBlock); err != nil {
+		return nil, fmt.Errorf("unmarshalling config block response: %s", err)
+	}
+
+	if err := os.WriteFile(path, configBlock.Block, 0o644); err != nil {
+		return nil, fmt.Errorf("writing config block: %s", err)
+	}
+
+	configBlock.Block = nil
+	var buffer bytes.Buffer
+	if err := json.NewEncoder(&buffer).Encode(configBlock); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
 func errorOutput(err error) string {
 	return fmt.Sprintf("Error: %s\n", err)
 }
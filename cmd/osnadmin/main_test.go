@@ -335,6 +335,329 @@ var _ = Describe("osnadmin", func() {
 		})
 	})
 
+	Describe("ConfigBlock", func() {
+		var configBlock *cb.Block
+
+		BeforeEach(func() {
+			configBlock = blockWithGroups(
+				map[string]*cb.ConfigGroup{
+					"Application": {},
+				},
+				"testing123",
+			)
+			mockChannelManagement.ConfigBlockReturns(configBlock, nil)
+		})
+
+		It("uses the channel participation API to fetch the config block of a channel", func() {
+			args := []string{
+				"channel",
+				"config-block",
+				"--orderer-address", ordererURL,
+				"--channelID", channelID,
+				"--ca-file", ordererCACert,
+				"--client-cert", clientCert,
+				"--client-key", clientKey,
+			}
+			output, exit, err := executeForArgs(args)
+			expectedOutput := types.ConfigBlock{
+				Name:   "testing123",
+				Number: 0,
+				Block:  protoutil.MarshalOrPanic(configBlock),
+			}
+			checkStatusOutput(output, exit, err, 200, expectedOutput)
+			Expect(mockChannelManagement.ConfigBlockArgsForCall(0)).To(Equal(channelID))
+		})
+
+		It("writes the config block to the --output-block file", func() {
+			outputPath := filepath.Join(tempDir, "fetched.block")
+			args := []string{
+				"channel",
+				"config-block",
+				"--orderer-address", ordererURL,
+				"--channelID", channelID,
+				"--output-block", outputPath,
+				"--ca-file", ordererCACert,
+				"--client-cert", clientCert,
+				"--client-key", clientKey,
+			}
+			output, exit, err := executeForArgs(args)
+			expectedOutput := types.ConfigBlock{
+				Name:   "testing123",
+				Number: 0,
+			}
+			checkStatusOutput(output, exit, err, 200, expectedOutput)
+
+			blockBytes, err := os.ReadFile(outputPath)
+			Expect(err).NotTo(HaveOccurred())
+			fetchedBlock := &cb.Block{}
+			Expect(proto.Unmarshal(blockBytes, fetchedBlock)).To(Succeed())
+			Expect(proto.Equal(fetchedBlock, configBlock)).To(BeTrue())
+		})
+
+		Context("when the channel does not exist", func() {
+			BeforeEach(func() {
+				mockChannelManagement.ConfigBlockReturns(nil, types.ErrChannelNotExist)
+			})
+
+			It("returns 404 not found and does not write the --output-block file", func() {
+				outputPath := filepath.Join(tempDir, "fetched.block")
+				args := []string{
+					"channel",
+					"config-block",
+					"--orderer-address", ordererURL,
+					"--channelID", channelID,
+					"--output-block", outputPath,
+					"--ca-file", ordererCACert,
+					"--client-cert", clientCert,
+					"--client-key", clientKey,
+				}
+				output, exit, err := executeForArgs(args)
+				expectedOutput := types.ErrorResponse{
+					Error: "channel does not exist",
+				}
+				checkStatusOutput(output, exit, err, 404, expectedOutput)
+				Expect(outputPath).NotTo(BeAnExistingFile())
+			})
+		})
+
+		Context("when the --output-block file cannot be written", func() {
+			It("returns with exit code 1 and prints the error", func() {
+				outputPath := filepath.Join(tempDir, "not-a-dir", "fetched.block")
+				args := []string{
+					"channel",
+					"config-block",
+					"--orderer-address", ordererURL,
+					"--channelID", channelID,
+					"--output-block", outputPath,
+					"--ca-file", ordererCACert,
+					"--client-cert", clientCert,
+					"--client-key", clientKey,
+				}
+				output, exit, err := executeForArgs(args)
+				checkCLIError(output, exit, err, fmt.Sprintf("writing config block: open %s: no such file or directory", outputPath))
+			})
+		})
+	})
+
+	Describe("ClusterStatus", func() {
+		BeforeEach(func() {
+			mockChannelManagement.ClusterStatusReturns(types.ClusterStatus{
+				Name:              "testing123",
+				ConsensusRelation: "consenter",
+				Status:            "active",
+				Height:            5,
+				Consenters: []types.ConsenterInfo{
+					{ID: 1, Host: "orderer1", Port: 7050, Leader: true},
+					{ID: 2, Host: "orderer2", Port: 7050},
+				},
+			}, nil)
+		})
+
+		It("uses the channel participation API to report the cluster status of a channel", func() {
+			args := []string{
+				"channel",
+				"cluster-status",
+				"--orderer-address", ordererURL,
+				"--channelID", channelID,
+				"--ca-file", ordererCACert,
+				"--client-cert", clientCert,
+				"--client-key", clientKey,
+			}
+			output, exit, err := executeForArgs(args)
+			expectedOutput := types.ClusterStatus{
+				Name:              "testing123",
+				URL:               "/participation/v1/channels/testing123/cluster-status",
+				ConsensusRelation: "consenter",
+				Status:            "active",
+				Height:            5,
+				Consenters: []types.ConsenterInfo{
+					{ID: 1, Host: "orderer1", Port: 7050, Leader: true},
+					{ID: 2, Host: "orderer2", Port: 7050},
+				},
+			}
+			checkStatusOutput(output, exit, err, 200, expectedOutput)
+			Expect(mockChannelManagement.ClusterStatusArgsForCall(0)).To(Equal(channelID))
+		})
+
+		Context("when the channel does not exist", func() {
+			BeforeEach(func() {
+				mockChannelManagement.ClusterStatusReturns(types.ClusterStatus{}, types.ErrChannelNotExist)
+			})
+
+			It("returns 404 not found", func() {
+				args := []string{
+					"channel",
+					"cluster-status",
+					"--orderer-address", ordererURL,
+					"--channelID", channelID,
+					"--ca-file", ordererCACert,
+					"--client-cert", clientCert,
+					"--client-key", clientKey,
+				}
+				output, exit, err := executeForArgs(args)
+				expectedOutput := types.ErrorResponse{
+					Error: "channel does not exist",
+				}
+				checkStatusOutput(output, exit, err, 404, expectedOutput)
+			})
+		})
+	})
+
+	Describe("RaftStatus", func() {
+		BeforeEach(func() {
+			mockChannelManagement.RaftStatusReturns(types.RaftStatus{
+				Name:         "testing123",
+				ID:           1,
+				State:        "StateLeader",
+				Term:         2,
+				Leader:       1,
+				CommitIndex:  9,
+				AppliedIndex: 9,
+				Followers: []types.RaftFollowerProgress{
+					{ID: 2, MatchIndex: 9, NextIndex: 10, State: "StateReplicate", RecentActive: true},
+				},
+			}, nil)
+		})
+
+		It("uses the channel participation API to report the Raft status of a channel", func() {
+			args := []string{
+				"channel",
+				"raft-status",
+				"--orderer-address", ordererURL,
+				"--channelID", channelID,
+				"--ca-file", ordererCACert,
+				"--client-cert", clientCert,
+				"--client-key", clientKey,
+			}
+			output, exit, err := executeForArgs(args)
+			expectedOutput := types.RaftStatus{
+				Name:         "testing123",
+				URL:          "/participation/v1/channels/testing123/raft-status",
+				ID:           1,
+				State:        "StateLeader",
+				Term:         2,
+				Leader:       1,
+				CommitIndex:  9,
+				AppliedIndex: 9,
+				Followers: []types.RaftFollowerProgress{
+					{ID: 2, MatchIndex: 9, NextIndex: 10, State: "StateReplicate", RecentActive: true},
+				},
+			}
+			checkStatusOutput(output, exit, err, 200, expectedOutput)
+			Expect(mockChannelManagement.RaftStatusArgsForCall(0)).To(Equal(channelID))
+		})
+
+		Context("when the orderer is not a Raft consenter of the channel", func() {
+			BeforeEach(func() {
+				mockChannelManagement.RaftStatusReturns(types.RaftStatus{}, types.ErrNotRaftConsenter)
+			})
+
+			It("returns 409 conflict", func() {
+				args := []string{
+					"channel",
+					"raft-status",
+					"--orderer-address", ordererURL,
+					"--channelID", channelID,
+					"--ca-file", ordererCACert,
+					"--client-cert", clientCert,
+					"--client-key", clientKey,
+				}
+				output, exit, err := executeForArgs(args)
+				expectedOutput := types.ErrorResponse{
+					Error: "orderer is not a Raft consenter of the channel",
+				}
+				checkStatusOutput(output, exit, err, 409, expectedOutput)
+			})
+		})
+	})
+
+	Describe("TransferLeadership", func() {
+		It("uses the channel participation API to transfer the leadership to a consenter", func() {
+			args := []string{
+				"channel",
+				"transfer-leadership",
+				"--orderer-address", ordererURL,
+				"--channelID", channelID,
+				"--to", "3",
+				"--ca-file", ordererCACert,
+				"--client-cert", clientCert,
+				"--client-key", clientKey,
+			}
+			output, exit, err := executeForArgs(args)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(exit).To(Equal(0))
+			Expect(output).To(Equal("Status: 204\n"))
+
+			Expect(mockChannelManagement.TransferLeadershipCallCount()).To(Equal(1))
+			transferChannelID, to := mockChannelManagement.TransferLeadershipArgsForCall(0)
+			Expect(transferChannelID).To(Equal(channelID))
+			Expect(to).To(Equal(uint64(3)))
+		})
+
+		It("lets the orderer pick the consenter when --to is not set", func() {
+			args := []string{
+				"channel",
+				"transfer-leadership",
+				"--orderer-address", ordererURL,
+				"--channelID", channelID,
+				"--ca-file", ordererCACert,
+				"--client-cert", clientCert,
+				"--client-key", clientKey,
+			}
+			output, exit, err := executeForArgs(args)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(exit).To(Equal(0))
+			Expect(output).To(Equal("Status: 204\n"))
+
+			_, to := mockChannelManagement.TransferLeadershipArgsForCall(0)
+			Expect(to).To(BeZero())
+		})
+
+		Context("when the orderer is not the leader", func() {
+			BeforeEach(func() {
+				mockChannelManagement.TransferLeadershipReturns(types.ErrNotLeader)
+			})
+
+			It("returns 409 conflict", func() {
+				args := []string{
+					"channel",
+					"transfer-leadership",
+					"--orderer-address", ordererURL,
+					"--channelID", channelID,
+					"--to", "3",
+					"--ca-file", ordererCACert,
+					"--client-cert", clientCert,
+					"--client-key", clientKey,
+				}
+				output, exit, err := executeForArgs(args)
+				expectedOutput := types.ErrorResponse{
+					Error: "cannot transfer leadership: orderer is not the leader of the channel",
+				}
+				checkStatusOutput(output, exit, err, 409, expectedOutput)
+			})
+		})
+
+		Context("when TLS is disabled", func() {
+			BeforeEach(func() {
+				tlsConfig = nil
+			})
+
+			It("uses the channel participation API to transfer the leadership", func() {
+				args := []string{
+					"channel",
+					"transfer-leadership",
+					"--orderer-address", ordererURL,
+					"--channelID", channelID,
+					"--to", "2",
+				}
+				output, exit, err := executeForArgs(args)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(exit).To(Equal(0))
+				Expect(output).To(Equal("Status: 204\n"))
+			})
+		})
+	})
+
 	Describe("Join", func() {
 		var blockPath string
 
@@ -32,6 +32,32 @@ type ChannelManagement struct {
 	channelListReturnsOnCall map[int]struct {
 		result1 types.ChannelList
 	}
+	ClusterStatusStub        func(string) (types.ClusterStatus, error)
+	clusterStatusMutex       sync.RWMutex
+	clusterStatusArgsForCall []struct {
+		arg1 string
+	}
+	clusterStatusReturns struct {
+		result1 types.ClusterStatus
+		result2 error
+	}
+	clusterStatusReturnsOnCall map[int]struct {
+		result1 types.ClusterStatus
+		result2 error
+	}
+	ConfigBlockStub        func(string) (*common.Block, error)
+	configBlockMutex       sync.RWMutex
+	configBlockArgsForCall []struct {
+		arg1 string
+	}
+	configBlockReturns struct {
+		result1 *common.Block
+		result2 error
+	}
+	configBlockReturnsOnCall map[int]struct {
+		result1 *common.Block
+		result2 error
+	}
 	JoinChannelStub        func(string, *common.Block) (types.ChannelInfo, error)
 	joinChannelMutex       sync.RWMutex
 	joinChannelArgsForCall []struct {
@@ -46,6 +72,19 @@ type ChannelManagement struct {
 		result1 types.ChannelInfo
 		result2 error
 	}
+	RaftStatusStub        func(string) (types.RaftStatus, error)
+	raftStatusMutex       sync.RWMutex
+	raftStatusArgsForCall []struct {
+		arg1 string
+	}
+	raftStatusReturns struct {
+		result1 types.RaftStatus
+		result2 error
+	}
+	raftStatusReturnsOnCall map[int]struct {
+		result1 types.RaftStatus
+		result2 error
+	}
 	RemoveChannelStub        func(string) error
 	removeChannelMutex       sync.RWMutex
 	removeChannelArgsForCall []struct {
@@ -57,6 +96,18 @@ type ChannelManagement struct {
 	removeChannelReturnsOnCall map[int]struct {
 		result1 error
 	}
+	TransferLeadershipStub        func(string, uint64) error
+	transferLeadershipMutex       sync.RWMutex
+	transferLeadershipArgsForCall []struct {
+		arg1 string
+		arg2 uint64
+	}
+	transferLeadershipReturns struct {
+		result1 error
+	}
+	transferLeadershipReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -144,6 +195,10 @@ func (fake *ChannelManagement) ChannelList() types.ChannelList {
 func (fake *ChannelManagement) ChannelListCallCount() int {
 	fake.channelListMutex.RLock()
 	defer fake.channelListMutex.RUnlock()
+	fake.clusterStatusMutex.RLock()
+	defer fake.clusterStatusMutex.RUnlock()
+	fake.configBlockMutex.RLock()
+	defer fake.configBlockMutex.RUnlock()
 	return len(fake.channelListArgsForCall)
 }
 
@@ -176,6 +231,132 @@ func (fake *ChannelManagement) ChannelListReturnsOnCall(i int, result1 types.Cha
 	}{result1}
 }
 
+func (fake *ChannelManagement) ClusterStatus(arg1 string) (types.ClusterStatus, error) {
+	fake.clusterStatusMutex.Lock()
+	ret, specificReturn := fake.clusterStatusReturnsOnCall[len(fake.clusterStatusArgsForCall)]
+	fake.clusterStatusArgsForCall = append(fake.clusterStatusArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("ClusterStatus", []interface{}{arg1})
+	fake.clusterStatusMutex.Unlock()
+	if fake.ClusterStatusStub != nil {
+		return fake.ClusterStatusStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.clusterStatusReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ChannelManagement) ClusterStatusCallCount() int {
+	fake.clusterStatusMutex.RLock()
+	defer fake.clusterStatusMutex.RUnlock()
+	return len(fake.clusterStatusArgsForCall)
+}
+
+func (fake *ChannelManagement) ClusterStatusCalls(stub func(string) (types.ClusterStatus, error)) {
+	fake.clusterStatusMutex.Lock()
+	defer fake.clusterStatusMutex.Unlock()
+	fake.ClusterStatusStub = stub
+}
+
+func (fake *ChannelManagement) ClusterStatusArgsForCall(i int) string {
+	fake.clusterStatusMutex.RLock()
+	defer fake.clusterStatusMutex.RUnlock()
+	argsForCall := fake.clusterStatusArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *ChannelManagement) ClusterStatusReturns(result1 types.ClusterStatus, result2 error) {
+	fake.clusterStatusMutex.Lock()
+	defer fake.clusterStatusMutex.Unlock()
+	fake.ClusterStatusStub = nil
+	fake.clusterStatusReturns = struct {
+		result1 types.ClusterStatus
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ChannelManagement) ClusterStatusReturnsOnCall(i int, result1 types.ClusterStatus, result2 error) {
+	fake.clusterStatusMutex.Lock()
+	defer fake.clusterStatusMutex.Unlock()
+	fake.ClusterStatusStub = nil
+	if fake.clusterStatusReturnsOnCall == nil {
+		fake.clusterStatusReturnsOnCall = make(map[int]struct {
+			result1 types.ClusterStatus
+			result2 error
+		})
+	}
+	fake.clusterStatusReturnsOnCall[i] = struct {
+		result1 types.ClusterStatus
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ChannelManagement) ConfigBlock(arg1 string) (*common.Block, error) {
+	fake.configBlockMutex.Lock()
+	ret, specificReturn := fake.configBlockReturnsOnCall[len(fake.configBlockArgsForCall)]
+	fake.configBlockArgsForCall = append(fake.configBlockArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("ConfigBlock", []interface{}{arg1})
+	fake.configBlockMutex.Unlock()
+	if fake.ConfigBlockStub != nil {
+		return fake.ConfigBlockStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.configBlockReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ChannelManagement) ConfigBlockCallCount() int {
+	fake.configBlockMutex.RLock()
+	defer fake.configBlockMutex.RUnlock()
+	return len(fake.configBlockArgsForCall)
+}
+
+func (fake *ChannelManagement) ConfigBlockCalls(stub func(string) (*common.Block, error)) {
+	fake.configBlockMutex.Lock()
+	defer fake.configBlockMutex.Unlock()
+	fake.ConfigBlockStub = stub
+}
+
+func (fake *ChannelManagement) ConfigBlockArgsForCall(i int) string {
+	fake.configBlockMutex.RLock()
+	defer fake.configBlockMutex.RUnlock()
+	argsForCall := fake.configBlockArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *ChannelManagement) ConfigBlockReturns(result1 *common.Block, result2 error) {
+	fake.configBlockMutex.Lock()
+	defer fake.configBlockMutex.Unlock()
+	fake.ConfigBlockStub = nil
+	fake.configBlockReturns = struct {
+		result1 *common.Block
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ChannelManagement) ConfigBlockReturnsOnCall(i int, result1 *common.Block, result2 error) {
+	fake.configBlockMutex.Lock()
+	defer fake.configBlockMutex.Unlock()
+	fake.ConfigBlockStub = nil
+	if fake.configBlockReturnsOnCall == nil {
+		fake.configBlockReturnsOnCall = make(map[int]struct {
+			result1 *common.Block
+			result2 error
+		})
+	}
+	fake.configBlockReturnsOnCall[i] = struct {
+		result1 *common.Block
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *ChannelManagement) JoinChannel(arg1 string, arg2 *common.Block) (types.ChannelInfo, error) {
 	fake.joinChannelMutex.Lock()
 	ret, specificReturn := fake.joinChannelReturnsOnCall[len(fake.joinChannelArgsForCall)]
@@ -240,6 +421,69 @@ func (fake *ChannelManagement) JoinChannelReturnsOnCall(i int, result1 types.Cha
 	}{result1, result2}
 }
 
+func (fake *ChannelManagement) RaftStatus(arg1 string) (types.RaftStatus, error) {
+	fake.raftStatusMutex.Lock()
+	ret, specificReturn := fake.raftStatusReturnsOnCall[len(fake.raftStatusArgsForCall)]
+	fake.raftStatusArgsForCall = append(fake.raftStatusArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("RaftStatus", []interface{}{arg1})
+	fake.raftStatusMutex.Unlock()
+	if fake.RaftStatusStub != nil {
+		return fake.RaftStatusStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.raftStatusReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ChannelManagement) RaftStatusCallCount() int {
+	fake.raftStatusMutex.RLock()
+	defer fake.raftStatusMutex.RUnlock()
+	return len(fake.raftStatusArgsForCall)
+}
+
+func (fake *ChannelManagement) RaftStatusCalls(stub func(string) (types.RaftStatus, error)) {
+	fake.raftStatusMutex.Lock()
+	defer fake.raftStatusMutex.Unlock()
+	fake.RaftStatusStub = stub
+}
+
+func (fake *ChannelManagement) RaftStatusArgsForCall(i int) string {
+	fake.raftStatusMutex.RLock()
+	defer fake.raftStatusMutex.RUnlock()
+	argsForCall := fake.raftStatusArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *ChannelManagement) RaftStatusReturns(result1 types.RaftStatus, result2 error) {
+	fake.raftStatusMutex.Lock()
+	defer fake.raftStatusMutex.Unlock()
+	fake.RaftStatusStub = nil
+	fake.raftStatusReturns = struct {
+		result1 types.RaftStatus
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ChannelManagement) RaftStatusReturnsOnCall(i int, result1 types.RaftStatus, result2 error) {
+	fake.raftStatusMutex.Lock()
+	defer fake.raftStatusMutex.Unlock()
+	fake.RaftStatusStub = nil
+	if fake.raftStatusReturnsOnCall == nil {
+		fake.raftStatusReturnsOnCall = make(map[int]struct {
+			result1 types.RaftStatus
+			result2 error
+		})
+	}
+	fake.raftStatusReturnsOnCall[i] = struct {
+		result1 types.RaftStatus
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *ChannelManagement) RemoveChannel(arg1 string) error {
 	fake.removeChannelMutex.Lock()
 	ret, specificReturn := fake.removeChannelReturnsOnCall[len(fake.removeChannelArgsForCall)]
@@ -300,6 +544,67 @@ func (fake *ChannelManagement) RemoveChannelReturnsOnCall(i int, result1 error)
 	}{result1}
 }
 
+func (fake *ChannelManagement) TransferLeadership(arg1 string, arg2 uint64) error {
+	fake.transferLeadershipMutex.Lock()
+	ret, specificReturn := fake.transferLeadershipReturnsOnCall[len(fake.transferLeadershipArgsForCall)]
+	fake.transferLeadershipArgsForCall = append(fake.transferLeadershipArgsForCall, struct {
+		arg1 string
+		arg2 uint64
+	}{arg1, arg2})
+	fake.recordInvocation("TransferLeadership", []interface{}{arg1, arg2})
+	fake.transferLeadershipMutex.Unlock()
+	if fake.TransferLeadershipStub != nil {
+		return fake.TransferLeadershipStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.transferLeadershipReturns
+	return fakeReturns.result1
+}
+
+func (fake *ChannelManagement) TransferLeadershipCallCount() int {
+	fake.transferLeadershipMutex.RLock()
+	defer fake.transferLeadershipMutex.RUnlock()
+	return len(fake.transferLeadershipArgsForCall)
+}
+
+func (fake *ChannelManagement) TransferLeadershipCalls(stub func(string, uint64) error) {
+	fake.transferLeadershipMutex.Lock()
+	defer fake.transferLeadershipMutex.Unlock()
+	fake.TransferLeadershipStub = stub
+}
+
+func (fake *ChannelManagement) TransferLeadershipArgsForCall(i int) (string, uint64) {
+	fake.transferLeadershipMutex.RLock()
+	defer fake.transferLeadershipMutex.RUnlock()
+	argsForCall := fake.transferLeadershipArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *ChannelManagement) TransferLeadershipReturns(result1 error) {
+	fake.transferLeadershipMutex.Lock()
+	defer fake.transferLeadershipMutex.Unlock()
+	fake.TransferLeadershipStub = nil
+	fake.transferLeadershipReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ChannelManagement) TransferLeadershipReturnsOnCall(i int, result1 error) {
+	fake.transferLeadershipMutex.Lock()
+	defer fake.transferLeadershipMutex.Unlock()
+	fake.TransferLeadershipStub = nil
+	if fake.transferLeadershipReturnsOnCall == nil {
+		fake.transferLeadershipReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.transferLeadershipReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *ChannelManagement) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -307,10 +612,18 @@ func (fake *ChannelManagement) Invocations() map[string][][]interface{} {
 	defer fake.channelInfoMutex.RUnlock()
 	fake.channelListMutex.RLock()
 	defer fake.channelListMutex.RUnlock()
+	fake.clusterStatusMutex.RLock()
+	defer fake.clusterStatusMutex.RUnlock()
+	fake.configBlockMutex.RLock()
+	defer fake.configBlockMutex.RUnlock()
 	fake.joinChannelMutex.RLock()
 	defer fake.joinChannelMutex.RUnlock()
+	fake.raftStatusMutex.RLock()
+	defer fake.raftStatusMutex.RUnlock()
 	fake.removeChannelMutex.RLock()
 	defer fake.removeChannelMutex.RUnlock()
+	fake.transferLeadershipMutex.RLock()
+	defer fake.transferLeadershipMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
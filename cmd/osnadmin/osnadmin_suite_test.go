@@ -22,6 +22,10 @@ type channelManagement interface {
 	ChannelInfo(channelID string) (types.ChannelInfo, error)
 	JoinChannel(channelID string, configBlock *cb.Block) (types.ChannelInfo, error)
 	RemoveChannel(channelID string) error
+	ConfigBlock(channelID string) (*cb.Block, error)
+	ClusterStatus(channelID string) (types.ClusterStatus, error)
+	RaftStatus(channelID string) (types.RaftStatus, error)
+	TransferLeadership(channelID string, to uint64) error
 }
 
 func TestOsnadmin(t *testing.T) {
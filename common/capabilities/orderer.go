@@ -21,6 +21,9 @@ const (
 
 	// OrdererV2_0 is the capabilities string that defines new Fabric v2.0 orderer capabilities.
 	OrdererV2_0 = "V2_0"
+
+	// OrdererV3_0 is the capabilities string that defines new Fabric v3.0 orderer capabilities.
+	OrdererV3_0 = "V3_0"
 )
 
 // OrdererProvider provides capabilities information for orderer level config.
@@ -29,6 +32,7 @@ type OrdererProvider struct {
 	v11BugFixes bool
 	v142        bool
 	V20         bool
+	V30         bool
 }
 
 // NewOrdererProvider creates an orderer capabilities provider.
@@ -38,6 +42,7 @@ func NewOrdererProvider(capabilities map[string]*cb.Capability) *OrdererProvider
 	_, cp.v11BugFixes = capabilities[OrdererV1_1]
 	_, cp.v142 = capabilities[OrdererV1_4_2]
 	_, cp.V20 = capabilities[OrdererV2_0]
+	_, cp.V30 = capabilities[OrdererV3_0]
 	return cp
 }
 
@@ -56,6 +61,8 @@ func (cp *OrdererProvider) HasCapability(capability string) bool {
 		return true
 	case OrdererV2_0:
 		return true
+	case OrdererV3_0:
+		return true
 	default:
 		return false
 	}
@@ -64,19 +71,19 @@ func (cp *OrdererProvider) HasCapability(capability string) bool {
 // PredictableChannelTemplate specifies whether the v1.0 undesirable behavior of setting the /Channel
 // group's mod_policy to "" and copying versions from the channel config should be fixed or not.
 func (cp *OrdererProvider) PredictableChannelTemplate() bool {
-	return cp.v11BugFixes || cp.v142 || cp.V20
+	return cp.v11BugFixes || cp.v142 || cp.V20 || cp.V30
 }
 
 // Resubmission specifies whether the v1.0 non-deterministic commitment of tx should be fixed by re-submitting
 // the re-validated tx.
 func (cp *OrdererProvider) Resubmission() bool {
-	return cp.v11BugFixes || cp.v142 || cp.V20
+	return cp.v11BugFixes || cp.v142 || cp.V20 || cp.V30
 }
 
 // ExpirationCheck specifies whether the orderer checks for identity expiration checks
 // when validating messages
 func (cp *OrdererProvider) ExpirationCheck() bool {
-	return cp.v11BugFixes || cp.v142 || cp.V20
+	return cp.v11BugFixes || cp.v142 || cp.V20 || cp.V30
 }
 
 // ConsensusTypeMigration checks whether the orderer permits a consensus-type migration.
@@ -85,11 +92,17 @@ func (cp *OrdererProvider) ExpirationCheck() bool {
 // with consensus-type migration change. Migration is supported from Kafka to Raft only.
 // If not present, these config updates will be rejected.
 func (cp *OrdererProvider) ConsensusTypeMigration() bool {
-	return cp.v142 || cp.V20
+	return cp.v142 || cp.V20 || cp.V30
 }
 
 // UseChannelCreationPolicyAsAdmins determines whether the orderer should use the name
 // "Admins" instead of "ChannelCreationPolicy" in the new channel config template.
 func (cp *OrdererProvider) UseChannelCreationPolicyAsAdmins() bool {
-	return cp.V20
+	return cp.V20 || cp.V30
+}
+
+// AdaptiveBatchSize specifies whether the orderer permits the AdaptiveBatchSize value in the
+// orderer group, which adjusts the number of messages at which batches are cut to the load.
+func (cp *OrdererProvider) AdaptiveBatchSize() bool {
+	return cp.V30
 }
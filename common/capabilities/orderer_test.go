@@ -22,6 +22,7 @@ func TestOrdererV10(t *testing.T) {
 	require.False(t, op.ExpirationCheck())
 	require.False(t, op.ConsensusTypeMigration())
 	require.False(t, op.UseChannelCreationPolicyAsAdmins())
+	require.False(t, op.AdaptiveBatchSize())
 }
 
 func TestOrdererV11(t *testing.T) {
@@ -34,6 +35,7 @@ func TestOrdererV11(t *testing.T) {
 	require.True(t, op.ExpirationCheck())
 	require.False(t, op.ConsensusTypeMigration())
 	require.False(t, op.UseChannelCreationPolicyAsAdmins())
+	require.False(t, op.AdaptiveBatchSize())
 }
 
 func TestOrdererV142(t *testing.T) {
@@ -46,6 +48,7 @@ func TestOrdererV142(t *testing.T) {
 	require.True(t, op.ExpirationCheck())
 	require.True(t, op.ConsensusTypeMigration())
 	require.False(t, op.UseChannelCreationPolicyAsAdmins())
+	require.False(t, op.AdaptiveBatchSize())
 }
 
 func TestOrdererV20(t *testing.T) {
@@ -58,6 +61,20 @@ func TestOrdererV20(t *testing.T) {
 	require.True(t, op.Resubmission())
 	require.True(t, op.ExpirationCheck())
 	require.True(t, op.ConsensusTypeMigration())
+	require.False(t, op.AdaptiveBatchSize())
+}
+
+func TestOrdererV30(t *testing.T) {
+	op := NewOrdererProvider(map[string]*cb.Capability{
+		OrdererV3_0: {},
+	})
+	require.NoError(t, op.Supported())
+	require.True(t, op.PredictableChannelTemplate())
+	require.True(t, op.UseChannelCreationPolicyAsAdmins())
+	require.True(t, op.Resubmission())
+	require.True(t, op.ExpirationCheck())
+	require.True(t, op.ConsensusTypeMigration())
+	require.True(t, op.AdaptiveBatchSize())
 }
 
 func TestNotSupported(t *testing.T) {
@@ -15,6 +15,7 @@ import (
 	"github.com/hyperledger/fabric/common/configtx"
 	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/orderer/common/ordererext"
 )
 
 // Org stores the common organizational config
@@ -106,6 +107,10 @@ type Orderer interface {
 	// BatchTimeout returns the amount of time to wait before creating a batch
 	BatchTimeout() time.Duration
 
+	// AdaptiveBatchSize returns the bounds and the target latency of the adaptive cutting of batches,
+	// or nil if the batches are cut on the static batch size
+	AdaptiveBatchSize() *ordererext.AdaptiveBatchSize
+
 	// MaxChannelsCount returns the maximum count of channels to allow for an ordering network
 	MaxChannelsCount() uint64
 
@@ -224,6 +229,10 @@ type OrdererCapabilities interface {
 	// channel creation logic using channel creation policy as the Admins policy if
 	// the creation transaction appears to support it.
 	UseChannelCreationPolicyAsAdmins() bool
+
+	// AdaptiveBatchSize specifies whether the orderer permits the AdaptiveBatchSize value,
+	// which adjusts the number of messages at which batches are cut to the load.
+	AdaptiveBatchSize() bool
 }
 
 // PolicyMapper is an interface for
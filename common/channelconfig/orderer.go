@@ -16,6 +16,7 @@ import (
 	cb "github.com/hyperledger/fabric-protos-go/common"
 	ab "github.com/hyperledger/fabric-protos-go/orderer"
 	"github.com/hyperledger/fabric/common/capabilities"
+	"github.com/hyperledger/fabric/orderer/common/ordererext"
 	"github.com/pkg/errors"
 )
 
@@ -37,6 +38,9 @@ const (
 	// ChannelRestrictionsKey is the key name for the ChannelRestrictions message.
 	ChannelRestrictionsKey = "ChannelRestrictions"
 
+	// AdaptiveBatchSizeKey is the cb.ConfigItem type key name for the AdaptiveBatchSize message.
+	AdaptiveBatchSizeKey = "AdaptiveBatchSize"
+
 	// EndpointsKey is the cb.COnfigValue key name for the Endpoints message in the OrdererOrgGroup.
 	EndpointsKey = "Endpoints"
 )
@@ -50,6 +54,7 @@ type OrdererProtos struct {
 	// and was later migrated to etcdraft.
 	KafkaBrokers        *ab.KafkaBrokers
 	ChannelRestrictions *ab.ChannelRestrictions
+	AdaptiveBatchSize   *ordererext.AdaptiveBatchSize
 	Orderers            *cb.Orderers
 	Capabilities        *cb.Capabilities
 }
@@ -59,7 +64,8 @@ type OrdererConfig struct {
 	protos *OrdererProtos
 	orgs   map[string]OrdererOrg
 
-	batchTimeout time.Duration
+	batchTimeout      time.Duration
+	adaptiveBatchSize *ordererext.AdaptiveBatchSize
 }
 
 // OrdererOrgProtos are deserialized from the Orderer org config values
@@ -168,6 +174,12 @@ func (oc *OrdererConfig) BatchTimeout() time.Duration {
 	return oc.batchTimeout
 }
 
+// AdaptiveBatchSize returns the bounds and the target latency of the adaptive cutting of batches,
+// or nil if the batches are cut on the static batch size.
+func (oc *OrdererConfig) AdaptiveBatchSize() *ordererext.AdaptiveBatchSize {
+	return oc.adaptiveBatchSize
+}
+
 // MaxChannelsCount returns the maximum count of channels this orderer supports.
 func (oc *OrdererConfig) MaxChannelsCount() uint64 {
 	return oc.protos.ChannelRestrictions.MaxCount
@@ -191,6 +203,7 @@ func (oc *OrdererConfig) Validate() error {
 	for _, validator := range []func() error{
 		oc.validateBatchSize,
 		oc.validateBatchTimeout,
+		oc.validateAdaptiveBatchSize,
 	} {
 		if err := validator(); err != nil {
 			return err
@@ -228,6 +241,35 @@ func (oc *OrdererConfig) validateBatchTimeout() error {
 	return nil
 }
 
+func (oc *OrdererConfig) validateAdaptiveBatchSize() error {
+	abs := oc.protos.AdaptiveBatchSize
+	if abs == nil || (abs.MinMessageCount == 0 && abs.MaxMessageCount == 0 && abs.TargetLatency == "") {
+		// the value is absent, the batches are cut on the static batch size
+		return nil
+	}
+	if !oc.Capabilities().AdaptiveBatchSize() {
+		return fmt.Errorf("Attempted to set the adaptive batch size without the V3_0 orderer capability")
+	}
+	if abs.MinMessageCount == 0 {
+		return fmt.Errorf("Attempted to set the adaptive batch size min message count to an invalid value: 0")
+	}
+	if abs.MinMessageCount > abs.MaxMessageCount {
+		return fmt.Errorf("Attempted to set the adaptive batch size min message count (%v) greater than the max message count (%v).", abs.MinMessageCount, abs.MaxMessageCount)
+	}
+	if abs.MaxMessageCount > oc.protos.BatchSize.MaxMessageCount {
+		return fmt.Errorf("Attempted to set the adaptive batch size max message count (%v) greater than the batch size max message count (%v).", abs.MaxMessageCount, oc.protos.BatchSize.MaxMessageCount)
+	}
+	targetLatency, err := time.ParseDuration(abs.TargetLatency)
+	if err != nil {
+		return fmt.Errorf("Attempted to set the adaptive batch size target latency to a invalid value: %s", err)
+	}
+	if targetLatency <= 0 {
+		return fmt.Errorf("Attempted to set the adaptive batch size target latency to a non-positive value: %s", targetLatency)
+	}
+	oc.adaptiveBatchSize = abs
+	return nil
+}
+
 // This does just a barebones sanity check.
 func brokerEntrySeemsValid(broker string) bool {
 	if !strings.Contains(broker, ":") {
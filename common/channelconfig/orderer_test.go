@@ -9,7 +9,10 @@ package channelconfig
 import (
 	"testing"
 
+	cb "github.com/hyperledger/fabric-protos-go/common"
 	ab "github.com/hyperledger/fabric-protos-go/orderer"
+	"github.com/hyperledger/fabric/common/capabilities"
+	"github.com/hyperledger/fabric/orderer/common/ordererext"
 	"github.com/stretchr/testify/require"
 )
 
@@ -31,6 +34,38 @@ func TestBatchSize(t *testing.T) {
 	require.Error(t, oc.validateBatchSize(), "PreferredMaxBytes larger to AbsoluteMaxBytes")
 }
 
+func TestAdaptiveBatchSize(t *testing.T) {
+	batchSize := &ab.BatchSize{MaxMessageCount: 100}
+	v30 := &cb.Capabilities{Capabilities: map[string]*cb.Capability{capabilities.OrdererV3_0: {}}}
+
+	oc := &OrdererConfig{protos: &OrdererProtos{BatchSize: batchSize, Capabilities: v30, AdaptiveBatchSize: &ordererext.AdaptiveBatchSize{}}}
+	require.NoError(t, oc.validateAdaptiveBatchSize(), "Absent adaptive batch size")
+	require.Nil(t, oc.AdaptiveBatchSize())
+
+	abs := &ordererext.AdaptiveBatchSize{MinMessageCount: 10, MaxMessageCount: 100, TargetLatency: "500ms"}
+	oc = &OrdererConfig{protos: &OrdererProtos{BatchSize: batchSize, Capabilities: v30, AdaptiveBatchSize: abs}}
+	require.NoError(t, oc.validateAdaptiveBatchSize(), "Valid adaptive batch size")
+	require.Equal(t, abs, oc.AdaptiveBatchSize())
+
+	oc = &OrdererConfig{protos: &OrdererProtos{BatchSize: batchSize, Capabilities: v30, AdaptiveBatchSize: &ordererext.AdaptiveBatchSize{MinMessageCount: 0, MaxMessageCount: 100, TargetLatency: "500ms"}}}
+	require.Error(t, oc.validateAdaptiveBatchSize(), "MinMessageCount was zero")
+
+	oc = &OrdererConfig{protos: &OrdererProtos{BatchSize: batchSize, Capabilities: v30, AdaptiveBatchSize: &ordererext.AdaptiveBatchSize{MinMessageCount: 50, MaxMessageCount: 10, TargetLatency: "500ms"}}}
+	require.Error(t, oc.validateAdaptiveBatchSize(), "MinMessageCount larger than MaxMessageCount")
+
+	oc = &OrdererConfig{protos: &OrdererProtos{BatchSize: batchSize, Capabilities: v30, AdaptiveBatchSize: &ordererext.AdaptiveBatchSize{MinMessageCount: 10, MaxMessageCount: 101, TargetLatency: "500ms"}}}
+	require.Error(t, oc.validateAdaptiveBatchSize(), "MaxMessageCount larger than the batch size MaxMessageCount")
+
+	oc = &OrdererConfig{protos: &OrdererProtos{BatchSize: batchSize, Capabilities: v30, AdaptiveBatchSize: &ordererext.AdaptiveBatchSize{MinMessageCount: 10, MaxMessageCount: 100, TargetLatency: "soon"}}}
+	require.Error(t, oc.validateAdaptiveBatchSize(), "Invalid target latency")
+
+	oc = &OrdererConfig{protos: &OrdererProtos{BatchSize: batchSize, Capabilities: v30, AdaptiveBatchSize: &ordererext.AdaptiveBatchSize{MinMessageCount: 10, MaxMessageCount: 100, TargetLatency: "0s"}}}
+	require.Error(t, oc.validateAdaptiveBatchSize(), "Zero target latency")
+
+	oc = &OrdererConfig{protos: &OrdererProtos{BatchSize: batchSize, Capabilities: &cb.Capabilities{}, AdaptiveBatchSize: abs}}
+	require.EqualError(t, oc.validateAdaptiveBatchSize(), "Attempted to set the adaptive batch size without the V3_0 orderer capability")
+}
+
 func TestBatchTimeout(t *testing.T) {
 	oc := &OrdererConfig{protos: &OrdererProtos{BatchTimeout: &ab.BatchTimeout{Timeout: "1s"}}}
 	require.NoError(t, oc.validateBatchTimeout(), "Valid batch timeout")
@@ -19,6 +19,7 @@ import (
 	"github.com/hyperledger/fabric-protos-go/orderer/smartbft"
 	pb "github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/orderer/common/ordererext"
 	"github.com/hyperledger/fabric/protoutil"
 	"github.com/pkg/errors"
 )
@@ -143,6 +144,19 @@ func BatchTimeoutValue(timeout string) *StandardConfigValue {
 	}
 }
 
+// AdaptiveBatchSizeValue returns the config definition for the adaptive cutting of batches.
+// It is a value for the /Channel/Orderer group.
+func AdaptiveBatchSizeValue(minMessages, maxMessages uint32, targetLatency string) *StandardConfigValue {
+	return &StandardConfigValue{
+		key: AdaptiveBatchSizeKey,
+		value: &ordererext.AdaptiveBatchSize{
+			MinMessageCount: minMessages,
+			MaxMessageCount: maxMessages,
+			TargetLatency:   targetLatency,
+		},
+	}
+}
+
 // ChannelRestrictionsValue returns the config definition for the orderer channel restrictions.
 // It is a value for the /Channel/Orderer group.
 func ChannelRestrictionsValue(maxChannelCount uint64) *StandardConfigValue {
@@ -45,6 +45,7 @@ func TestUtilsBasic(t *testing.T) {
 	basicTest(t, ConsensusTypeValue("foo", []byte("bar")))
 	basicTest(t, BatchSizeValue(1, 2, 3))
 	basicTest(t, BatchTimeoutValue("1s"))
+	basicTest(t, AdaptiveBatchSizeValue(1, 2, "500ms"))
 	basicTest(t, ChannelRestrictionsValue(7))
 	basicTest(t, MSPValue(&mspprotos.MSPConfig{}))
 	basicTest(t, CapabilitiesValue(map[string]bool{"foo": true, "bar": false}))
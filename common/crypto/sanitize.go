@@ -48,6 +48,11 @@ func SanitizeX509Cert(initialPEM []byte) ([]byte, error) {
 		return nil, errors.Wrapf(err, "failed parsing certificate %s", string(initialPEM))
 	}
 
+	// Only ECDSA signatures are malleable, anything else is left untouched.
+	if _, isECDSA := cert.PublicKey.(*ecdsa.PublicKey); !isECDSA {
+		return initialPEM, nil
+	}
+
 	r, s, err := utils.UnmarshalECDSASignature(cert.Signature)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed unmarshaling ECDSA signature on identity: %s", string(initialPEM))
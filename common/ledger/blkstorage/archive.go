@@ -0,0 +1,332 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package blkstorage
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/internal/fileutil"
+	"github.com/pkg/errors"
+)
+
+var archivedBlocksInfoKey = []byte("archivedBlocksInfo")
+
+// ErrBlocksArchived is returned when a block, or a transaction in a block, is requested
+// and the block file that contains the block has been archived
+type ErrBlocksArchived struct {
+	Requested              string
+	FirstAvailableBlockNum uint64
+}
+
+func (e *ErrBlocksArchived) Error() string {
+	return fmt.Sprintf("cannot serve %s. The blocks below block [%d] have been archived",
+		e.Requested, e.FirstAvailableBlockNum,
+	)
+}
+
+// IsBlocksArchived returns true if err is, or wraps, an ErrBlocksArchived
+func IsBlocksArchived(err error) bool {
+	var e *ErrBlocksArchived
+	return errors.As(err, &e)
+}
+
+// archivedBlocksInfo records the first block file that remains in the block store after
+// archiving, along with the number of the first block in that file
+type archivedBlocksInfo struct {
+	firstFileNum  int
+	firstBlockNum uint64
+}
+
+func (i *archivedBlocksInfo) marshal() ([]byte, error) {
+	buffer := proto.NewBuffer([]byte{})
+	if err := buffer.EncodeVarint(uint64(i.firstFileNum)); err != nil {
+		return nil, errors.Wrapf(err, "error encoding the firstFileNum [%d]", i.firstFileNum)
+	}
+	if err := buffer.EncodeVarint(i.firstBlockNum); err != nil {
+		return nil, errors.Wrapf(err, "error encoding the firstBlockNum [%d]", i.firstBlockNum)
+	}
+	return buffer.Bytes(), nil
+}
+
+func (i *archivedBlocksInfo) unmarshal(b []byte) error {
+	buffer := proto.NewBuffer(b)
+	val, err := buffer.DecodeVarint()
+	if err != nil {
+		return err
+	}
+	i.firstFileNum = int(val)
+	if i.firstBlockNum, err = buffer.DecodeVarint(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (i *archivedBlocksInfo) String() string {
+	return fmt.Sprintf("firstFileNum=[%d], firstBlockNum=[%d]", i.firstFileNum, i.firstBlockNum)
+}
+
+// initArchivedBlocksInfo loads the archivedBlocksInfo from the index db and reconciles it with the
+// block files present on the file system, which may have been removed outside of the peer
+func (mgr *blockfileMgr) initArchivedBlocksInfo() error {
+	info, err := mgr.loadArchivedBlocksInfo()
+	if err != nil {
+		return err
+	}
+	firstFileNum, err := retrieveFirstFileSuffix(mgr.rootDir)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case firstFileNum <= 0 && info == nil:
+		mgr.archivedBlocksInfo.Store(&archivedBlocksInfo{})
+		return nil
+	case info != nil && firstFileNum < info.firstFileNum:
+		// a crash happened while archiving, after the archivedBlocksInfo was saved.
+		// The remaining files are no longer served and can be removed by the administrator
+		logger.Warnf("Block files below file number [%d] have been archived but are still present in [%s]",
+			info.firstFileNum, mgr.rootDir)
+		mgr.archivedBlocksInfo.Store(info)
+		return nil
+	case info != nil && firstFileNum == info.firstFileNum:
+		mgr.archivedBlocksInfo.Store(info)
+		return nil
+	}
+
+	// the block files below firstFileNum have been removed without archiving them via the block store
+	firstBlockNum, err := retrieveFirstBlockNumFromFile(mgr.rootDir, firstFileNum)
+	if err != nil {
+		return err
+	}
+	info = &archivedBlocksInfo{firstFileNum: firstFileNum, firstBlockNum: firstBlockNum}
+	logger.Infof("Block files below file number [%d] are missing, marking them as archived: %s", firstFileNum, info)
+	if err := mgr.saveArchivedBlocksInfo(info); err != nil {
+		return err
+	}
+	mgr.archivedBlocksInfo.Store(info)
+	return nil
+}
+
+func (mgr *blockfileMgr) loadArchivedBlocksInfo() (*archivedBlocksInfo, error) {
+	b, err := mgr.db.Get(archivedBlocksInfoKey)
+	if b == nil || err != nil {
+		return nil, err
+	}
+	info := &archivedBlocksInfo{}
+	if err := info.unmarshal(b); err != nil {
+		return nil, errors.Wrap(err, "error unmarshalling archivedBlocksInfo")
+	}
+	return info, nil
+}
+
+func (mgr *blockfileMgr) saveArchivedBlocksInfo(info *archivedBlocksInfo) error {
+	b, err := info.marshal()
+	if err != nil {
+		return err
+	}
+	return mgr.db.Put(archivedBlocksInfoKey, b, true)
+}
+
+func (mgr *blockfileMgr) getArchivedBlocksInfo() *archivedBlocksInfo {
+	return mgr.archivedBlocksInfo.Load().(*archivedBlocksInfo)
+}
+
+// firstAvailableBlockNum returns the number of the first block that can be served
+// from the block files, which is above the bootstrapping snapshot and the archived blocks
+func (mgr *blockfileMgr) firstAvailableBlockNum() uint64 {
+	first := mgr.firstPossibleBlockNumberInBlockFiles()
+	if archived := mgr.getArchivedBlocksInfo().firstBlockNum; archived > first {
+		first = archived
+	}
+	return first
+}
+
+// checkBlockAvailable returns an error if the block precedes the first block available in the block files
+func (mgr *blockfileMgr) checkBlockAvailable(blockNum uint64) error {
+	if archived := mgr.getArchivedBlocksInfo(); blockNum < archived.firstBlockNum {
+		return &ErrBlocksArchived{
+			Requested:              fmt.Sprintf("block [%d]", blockNum),
+			FirstAvailableBlockNum: archived.firstBlockNum,
+		}
+	}
+	if blockNum < mgr.firstPossibleBlockNumberInBlockFiles() {
+		return errors.Errorf(
+			"cannot serve block [%d]. The ledger is bootstrapped from a snapshot. First available block = [%d]",
+			blockNum, mgr.firstPossibleBlockNumberInBlockFiles(),
+		)
+	}
+	return nil
+}
+
+// checkLocationAvailable returns an ErrBlocksArchived if the location points to an archived block file
+func (mgr *blockfileMgr) checkLocationAvailable(lp *fileLocPointer, requested string) error {
+	if archived := mgr.getArchivedBlocksInfo(); lp.fileSuffixNum < archived.firstFileNum {
+		return &ErrBlocksArchived{
+			Requested:              requested,
+			FirstAvailableBlockNum: archived.firstBlockNum,
+		}
+	}
+	return nil
+}
+
+// archiveBlockFiles archives the block files that contain only blocks with a number lower than blockNum.
+// The files are moved to a sub-directory named after the ledger in the archiveDir or, if archiveDir is
+// empty, deleted. The archivedBlocksInfo is saved before touching the files, so that a crash in between
+// leaves the block files in place but marked as archived. The file that contains blockNum, and the file
+// that is currently being appended to, are never archived.
+func (mgr *blockfileMgr) archiveBlockFiles(ledgerID string, blockNum uint64, archiveDir string) (uint64, error) {
+	mgr.archiveLock.Lock()
+	defer mgr.archiveLock.Unlock()
+
+	current := mgr.getArchivedBlocksInfo()
+	if blockNum <= mgr.firstAvailableBlockNum() {
+		return mgr.firstAvailableBlockNum(), nil
+	}
+	if height := mgr.getBlockchainInfo().Height; blockNum >= height {
+		return 0, errors.Errorf("cannot archive blocks below block [%d], the ledger height is [%d]",
+			blockNum, height)
+	}
+
+	loc, err := mgr.index.getBlockLocByBlockNum(blockNum)
+	if err != nil {
+		return 0, errors.WithMessagef(err, "error locating block [%d]", blockNum)
+	}
+	if loc.fileSuffixNum <= current.firstFileNum {
+		logger.Infof("No block file to archive for ledger [%s], block [%d] is in the first block file", ledgerID, blockNum)
+		return mgr.firstAvailableBlockNum(), nil
+	}
+	firstBlockNum, err := retrieveFirstBlockNumFromFile(mgr.rootDir, loc.fileSuffixNum)
+	if err != nil {
+		return 0, err
+	}
+
+	var targetDir string
+	if archiveDir != "" {
+		targetDir = filepath.Join(archiveDir, ledgerID)
+		if _, err := fileutil.CreateDirIfMissing(targetDir); err != nil {
+			return 0, errors.WithMessagef(err, "error creating archive dir [%s]", targetDir)
+		}
+	}
+
+	// the files left over by a crash during a previous archiving are archived as well
+	firstFileNum, err := retrieveFirstFileSuffix(mgr.rootDir)
+	if err != nil {
+		return 0, err
+	}
+
+	info := &archivedBlocksInfo{firstFileNum: loc.fileSuffixNum, firstBlockNum: firstBlockNum}
+	if err := mgr.saveArchivedBlocksInfo(info); err != nil {
+		return 0, err
+	}
+	mgr.archivedBlocksInfo.Store(info)
+
+	for fileNum := firstFileNum; fileNum < info.firstFileNum; fileNum++ {
+		filePath := deriveBlockfilePath(mgr.rootDir, fileNum)
+		if targetDir == "" {
+			err = os.Remove(filePath)
+		} else {
+			err = moveFile(filePath, filepath.Join(targetDir, filepath.Base(filePath)))
+		}
+		if err != nil && !os.IsNotExist(err) {
+			return 0, errors.Wrapf(err, "error archiving block file [%s]", filePath)
+		}
+	}
+	if err := fileutil.SyncDir(mgr.rootDir); err != nil {
+		return 0, err
+	}
+	logger.Infof("Archived block files [%d] to [%d] of ledger [%s]. First available block = [%d]",
+		firstFileNum, info.firstFileNum-1, ledgerID, info.firstBlockNum)
+	return info.firstBlockNum, nil
+}
+
+// moveFile renames the file, and falls back to copying it and removing the
+// source when the destination is on a different file system
+func moveFile(src, dst string) error {
+	if err := os.Rename(src, dst); err == nil {
+		return nil
+	}
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	if err := out.Sync(); err != nil {
+		out.Close()
+		return err
+	}
+	if err := out.Close(); err != nil {
+		return err
+	}
+	if err := fileutil.SyncParentDir(dst); err != nil {
+		return err
+	}
+	return os.Remove(src)
+}
+
+func retrieveFirstFileSuffix(rootDir string) (int, error) {
+	smallestFileNum := -1
+	filesInfo, err := os.ReadDir(rootDir)
+	if err != nil {
+		return -1, errors.Wrapf(err, "error reading dir %s", rootDir)
+	}
+	for _, fileInfo := range filesInfo {
+		name := fileInfo.Name()
+		if fileInfo.IsDir() || !isBlockFileName(name) {
+			continue
+		}
+		fileNum, err := strconv.Atoi(strings.TrimPrefix(name, blockfilePrefix))
+		if err != nil {
+			return -1, err
+		}
+		if smallestFileNum == -1 || fileNum < smallestFileNum {
+			smallestFileNum = fileNum
+		}
+	}
+	return smallestFileNum, nil
+}
+
+// HasArchivedBlocks returns true if the block files at the beginning of the ledger have been archived
+func HasArchivedBlocks(blockStorageDir, ledgerID string) (bool, error) {
+	firstFileNum, err := retrieveFirstFileSuffix(filepath.Join(blockStorageDir, ChainsDir, ledgerID))
+	if err != nil {
+		return false, err
+	}
+	return firstFileNum > 0, nil
+}
+
+// GetLedgersWithArchivedBlocks returns the IDs of the ledgers for which some block files have been archived
+func GetLedgersWithArchivedBlocks(blockStorageDir string) ([]string, error) {
+	ledgerIDs, err := fileutil.ListSubdirs(filepath.Join(blockStorageDir, ChainsDir))
+	if err != nil {
+		return nil, err
+	}
+	ledgersWithArchivedBlocks := []string{}
+	for _, ledgerID := range ledgerIDs {
+		archived, err := HasArchivedBlocks(blockStorageDir, ledgerID)
+		if err != nil {
+			return nil, err
+		}
+		if archived {
+			ledgersWithArchivedBlocks = append(ledgersWithArchivedBlocks, ledgerID)
+		}
+	}
+	return ledgersWithArchivedBlocks, nil
+}
@@ -0,0 +1,222 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package blkstorage
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchiveBlocks(t *testing.T) {
+	ledgerid := "testLedger"
+	blocks := testutil.ConstructTestBlocks(t, 30)
+	maxFileSize := int(0.1 * float64(testutilEstimateTotalSizeOnDisk(t, blocks)))
+	conf := NewConf(t.TempDir(), maxFileSize)
+	archiveDir := t.TempDir()
+
+	env := newTestEnv(t, conf)
+	store, err := env.provider.Open(ledgerid)
+	require.NoError(t, err)
+	w := &testBlockfileMgrWrapper{t, store.fileMgr}
+	w.addBlocks(blocks[:25])
+	require.Equal(t, uint64(0), store.FirstAvailableBlockNum())
+
+	// an iterator opened before archiving fails when it reaches the archived blocks
+	itr, err := w.blockfileMgr.retrieveBlocks(0)
+	require.NoError(t, err)
+	defer itr.Close()
+
+	_, err = store.ArchiveBlocks(25, archiveDir)
+	require.EqualError(t, err, "cannot archive blocks below block [25], the ledger height is [25]")
+
+	firstAvailable, err := store.ArchiveBlocks(15, archiveDir)
+	require.NoError(t, err)
+	require.LessOrEqual(t, firstAvailable, uint64(15))
+	require.Greater(t, firstAvailable, uint64(0))
+	require.Equal(t, firstAvailable, store.FirstAvailableBlockNum())
+
+	archivedInfo := w.blockfileMgr.getArchivedBlocksInfo()
+	for fileNum := 0; fileNum < archivedInfo.firstFileNum; fileNum++ {
+		require.NoFileExists(t, deriveBlockfilePath(conf.getLedgerBlockDir(ledgerid), fileNum))
+		require.FileExists(t, deriveBlockfilePath(filepath.Join(archiveDir, ledgerid), fileNum))
+	}
+	require.FileExists(t, deriveBlockfilePath(conf.getLedgerBlockDir(ledgerid), archivedInfo.firstFileNum))
+	hasArchivedBlocks, err := HasArchivedBlocks(conf.blockStorageDir, ledgerid)
+	require.NoError(t, err)
+	require.True(t, hasArchivedBlocks)
+
+	_, err = itr.Next()
+	require.EqualError(t, err, fmt.Sprintf("cannot serve block [0]. The blocks below block [%d] have been archived", firstAvailable))
+
+	// archiving again below the first available block is a no-op
+	again, err := store.ArchiveBlocks(firstAvailable, "")
+	require.NoError(t, err)
+	require.Equal(t, firstAvailable, again)
+
+	verifyArchived(t, w.blockfileMgr, blocks[:25], firstAvailable)
+
+	// the blocks can be appended after archiving and the archived info survives a restart
+	w.addBlocks(blocks[25:])
+	w.close()
+	env.Cleanup()
+
+	env = newTestEnv(t, conf)
+	defer env.Cleanup()
+	w = newTestBlockfileWrapper(env, ledgerid)
+	defer w.close()
+	verifyArchived(t, w.blockfileMgr, blocks, firstAvailable)
+
+	// the block files are deleted if no archive dir is supplied
+	firstAvailable, err = w.blockfileMgr.archiveBlockFiles(ledgerid, 28, "")
+	require.NoError(t, err)
+	archivedInfo = w.blockfileMgr.getArchivedBlocksInfo()
+	require.Equal(t, firstAvailable, archivedInfo.firstBlockNum)
+	for fileNum := 0; fileNum < archivedInfo.firstFileNum; fileNum++ {
+		require.NoFileExists(t, deriveBlockfilePath(conf.getLedgerBlockDir(ledgerid), fileNum))
+	}
+	verifyArchived(t, w.blockfileMgr, blocks, firstAvailable)
+}
+
+func TestArchiveBlocksReconcileWithFileSystem(t *testing.T) {
+	ledgerid := "testLedger"
+	blocks := testutil.ConstructTestBlocks(t, 20)
+	maxFileSize := int(0.2 * float64(testutilEstimateTotalSizeOnDisk(t, blocks)))
+	conf := NewConf(t.TempDir(), maxFileSize)
+	rootDir := conf.getLedgerBlockDir(ledgerid)
+
+	env := newTestEnv(t, conf)
+	w := newTestBlockfileWrapper(env, ledgerid)
+	w.addBlocks(blocks)
+	w.close()
+	env.Cleanup()
+
+	t.Run("files removed outside of the block store", func(t *testing.T) {
+		require.NoError(t, os.Remove(deriveBlockfilePath(rootDir, 0)))
+		firstBlockNum, err := retrieveFirstBlockNumFromFile(rootDir, 1)
+		require.NoError(t, err)
+
+		env := newTestEnv(t, conf)
+		defer env.Cleanup()
+		w := newTestBlockfileWrapper(env, ledgerid)
+		defer w.close()
+		require.Equal(t, &archivedBlocksInfo{firstFileNum: 1, firstBlockNum: firstBlockNum}, w.blockfileMgr.getArchivedBlocksInfo())
+		verifyArchived(t, w.blockfileMgr, blocks, firstBlockNum)
+	})
+
+	t.Run("crash after saving the archived info", func(t *testing.T) {
+		env := newTestEnv(t, conf)
+		w := newTestBlockfileWrapper(env, ledgerid)
+		firstBlockNum, err := retrieveFirstBlockNumFromFile(rootDir, 3)
+		require.NoError(t, err)
+		require.NoError(t, w.blockfileMgr.saveArchivedBlocksInfo(&archivedBlocksInfo{firstFileNum: 3, firstBlockNum: firstBlockNum}))
+		w.close()
+		env.Cleanup()
+
+		env = newTestEnv(t, conf)
+		defer env.Cleanup()
+		w = newTestBlockfileWrapper(env, ledgerid)
+		defer w.close()
+		require.FileExists(t, deriveBlockfilePath(rootDir, 1))
+		verifyArchived(t, w.blockfileMgr, blocks, firstBlockNum)
+
+		// the leftover files are archived along with the next archiving
+		firstAvailable, err := w.blockfileMgr.archiveBlockFiles(ledgerid, 19, "")
+		require.NoError(t, err)
+		firstFileNum, err := retrieveFirstFileSuffix(rootDir)
+		require.NoError(t, err)
+		require.Equal(t, w.blockfileMgr.getArchivedBlocksInfo().firstFileNum, firstFileNum)
+		verifyArchived(t, w.blockfileMgr, blocks, firstAvailable)
+	})
+}
+
+func TestGetLedgersWithArchivedBlocks(t *testing.T) {
+	blocks := testutil.ConstructTestBlocks(t, 20)
+	maxFileSize := int(0.2 * float64(testutilEstimateTotalSizeOnDisk(t, blocks)))
+	conf := NewConf(t.TempDir(), maxFileSize)
+	env := newTestEnv(t, conf)
+	defer env.Cleanup()
+
+	for _, ledgerid := range []string{"ledger1", "ledger2", "ledger3"} {
+		w := newTestBlockfileWrapper(env, ledgerid)
+		w.addBlocks(blocks)
+		if ledgerid != "ledger2" {
+			_, err := w.blockfileMgr.archiveBlockFiles(ledgerid, 15, "")
+			require.NoError(t, err)
+		}
+		w.close()
+	}
+	_, err := env.provider.Open("emptyLedger")
+	require.NoError(t, err)
+
+	ledgerIDs, err := GetLedgersWithArchivedBlocks(conf.blockStorageDir)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"ledger1", "ledger3"}, ledgerIDs)
+}
+
+func TestArchivedBlocksInfoMarshal(t *testing.T) {
+	info := &archivedBlocksInfo{firstFileNum: 12, firstBlockNum: 3456}
+	b, err := info.marshal()
+	require.NoError(t, err)
+	unmarshalled := &archivedBlocksInfo{}
+	require.NoError(t, unmarshalled.unmarshal(b))
+	require.Equal(t, info, unmarshalled)
+	require.Equal(t, "firstFileNum=[12], firstBlockNum=[3456]", info.String())
+	require.Error(t, unmarshalled.unmarshal([]byte{0x80}))
+}
+
+func verifyArchived(t *testing.T, mgr *blockfileMgr, blocks []*common.Block, firstAvailable uint64) {
+	for _, block := range blocks {
+		blockNum := block.Header.Number
+		txID, err := protoutil.GetOrComputeTxIDFromEnvelope(block.Data.Data[0])
+		require.NoError(t, err)
+
+		// the txIDs of the archived blocks remain in the index
+		exists, err := mgr.txIDExists(txID)
+		require.NoError(t, err)
+		require.True(t, exists)
+		_, txBlockNum, err := mgr.retrieveTxValidationCodeByTxID(txID)
+		require.NoError(t, err)
+		require.Equal(t, blockNum, txBlockNum)
+
+		if blockNum >= firstAvailable {
+			b, err := mgr.retrieveBlockByNumber(blockNum)
+			require.NoError(t, err)
+			require.True(t, proto.Equal(block, b))
+			_, err = mgr.retrieveBlockByHash(protoutil.BlockHeaderHash(block.Header))
+			require.NoError(t, err)
+			_, err = mgr.retrieveBlockByTxID(txID)
+			require.NoError(t, err)
+			_, err = mgr.retrieveTransactionByID(txID)
+			require.NoError(t, err)
+			continue
+		}
+
+		_, err = mgr.retrieveBlockByNumber(blockNum)
+		require.True(t, IsBlocksArchived(err), "block [%d]: %v", blockNum, err)
+		require.EqualError(t, err, fmt.Sprintf("cannot serve block [%d]. The blocks below block [%d] have been archived", blockNum, firstAvailable))
+		_, err = mgr.retrieveBlockHeaderByNumber(blockNum)
+		require.True(t, IsBlocksArchived(err))
+		_, err = mgr.retrieveBlocks(blockNum)
+		require.True(t, IsBlocksArchived(err))
+		_, err = mgr.retrieveBlockByHash(protoutil.BlockHeaderHash(block.Header))
+		require.True(t, IsBlocksArchived(err))
+		_, err = mgr.retrieveBlockByTxID(txID)
+		require.True(t, IsBlocksArchived(err))
+		_, err = mgr.retrieveTransactionByID(txID)
+		require.EqualError(t, err, fmt.Sprintf("cannot serve transaction for the TXID [%s]. The blocks below block [%d] have been archived", txID, firstAvailable))
+		_, err = mgr.retrieveTransactionByBlockNumTranNum(blockNum, 0)
+		require.True(t, IsBlocksArchived(err))
+	}
+}
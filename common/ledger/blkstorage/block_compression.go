@@ -0,0 +1,84 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package blkstorage
+
+import (
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/snappy"
+	"github.com/pkg/errors"
+)
+
+// Compression identifies the codec used for compressing the blocks appended to the block files.
+//
+// An uncompressed block is stored in a block file as the varint encoded length of the serialized
+// block followed by the serialized block. A compressed block is stored as a zero length (which is
+// never the length of a serialized block), followed by the codec byte, the varint encoded length
+// of the compressed bytes and the compressed bytes. This allows the block files to mix compressed
+// and uncompressed blocks, so that the compression can be turned on and off for an existing ledger.
+type Compression byte
+
+const (
+	// CompressionNone stores the blocks uncompressed
+	CompressionNone Compression = iota
+	// CompressionSnappy compresses the blocks with snappy
+	CompressionSnappy
+)
+
+// compressedBlockMarker is the first byte of a compressed block in a block file
+const compressedBlockMarker = 0x00
+
+var compressionNames = map[Compression]string{
+	CompressionNone:   "none",
+	CompressionSnappy: "snappy",
+}
+
+// ParseCompression returns the Compression for the given name, which is "none" or "snappy"
+// (case insensitive). An empty name is equivalent to "none".
+func ParseCompression(name string) (Compression, error) {
+	if name == "" {
+		return CompressionNone, nil
+	}
+	for c, n := range compressionNames {
+		if strings.EqualFold(name, n) {
+			return c, nil
+		}
+	}
+	return CompressionNone, errors.Errorf("unsupported block compression [%s]", name)
+}
+
+func (c Compression) String() string {
+	if name, ok := compressionNames[c]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// encodeBlock returns the header and the bytes to be appended to a block file for the
+// serialized block, along with a flag indicating whether the bytes are compressed.
+func (c Compression) encodeBlock(blockBytes []byte) ([]byte, []byte, bool) {
+	switch c {
+	case CompressionSnappy:
+		compressed := snappy.Encode(nil, blockBytes)
+		header := append([]byte{compressedBlockMarker, byte(c)}, proto.EncodeVarint(uint64(len(compressed)))...)
+		return header, compressed, true
+	default:
+		return proto.EncodeVarint(uint64(len(blockBytes))), blockBytes, false
+	}
+}
+
+// decompressBlock returns the serialized block from the bytes compressed with the given codec
+func decompressBlock(codec byte, compressed []byte) ([]byte, error) {
+	switch Compression(codec) {
+	case CompressionSnappy:
+		blockBytes, err := snappy.Decode(nil, compressed)
+		return blockBytes, errors.Wrap(err, "error decompressing block")
+	default:
+		return nil, errors.Errorf("unsupported block compression codec [%d]", codec)
+	}
+}
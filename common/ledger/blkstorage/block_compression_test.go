@@ -0,0 +1,208 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package blkstorage
+
+import (
+	"os"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCompression(t *testing.T) {
+	for name, expected := range map[string]Compression{
+		"":       CompressionNone,
+		"none":   CompressionNone,
+		"snappy": CompressionSnappy,
+		"Snappy": CompressionSnappy,
+	} {
+		c, err := ParseCompression(name)
+		require.NoError(t, err)
+		require.Equal(t, expected, c)
+	}
+	_, err := ParseCompression("zip")
+	require.EqualError(t, err, "unsupported block compression [zip]")
+
+	require.Equal(t, "snappy", CompressionSnappy.String())
+	require.Equal(t, "unknown", Compression(42).String())
+}
+
+func TestCompressedBlockReadWrite(t *testing.T) {
+	blocks := testutil.ConstructTestBlocks(t, 10)
+	sizes := map[Compression]int{}
+	for _, compression := range []Compression{CompressionNone, CompressionSnappy} {
+		env := newTestEnv(t, NewConfWithCompression(t.TempDir(), 0, compression))
+		w := newTestBlockfileWrapper(env, "testLedger")
+		w.addBlocks(blocks)
+		w.testGetBlockByHash(blocks)
+		w.testGetBlockByNumber(blocks)
+		w.testGetBlockByTxID(blocks)
+		testGetTransactions(t, w.blockfileMgr, blocks)
+		testIterateBlocks(t, w.blockfileMgr, blocks)
+		sizes[compression] = w.blockfileMgr.blockfilesInfo.latestFileSize
+		w.close()
+		env.Cleanup()
+	}
+	require.Less(t, sizes[CompressionSnappy], sizes[CompressionNone])
+}
+
+func TestMixedCompressionBlockfiles(t *testing.T) {
+	ledgerid := "testLedger"
+	blocks := testutil.ConstructTestBlocks(t, 30)
+	maxFileSize := int(0.2 * float64(testutilEstimateTotalSizeOnDisk(t, blocks)))
+	rootDir := t.TempDir()
+
+	// the compression is switched on and off for an existing ledger, and the blocks span several files
+	for i, compression := range []Compression{CompressionNone, CompressionSnappy, CompressionNone} {
+		env := newTestEnv(t, NewConfWithCompression(rootDir, maxFileSize, compression))
+		w := newTestBlockfileWrapper(env, ledgerid)
+		w.addBlocks(blocks[i*10 : (i+1)*10])
+		w.close()
+		env.Cleanup()
+	}
+
+	conf := NewConfWithCompression(rootDir, maxFileSize, CompressionSnappy)
+	verify := func() {
+		env := newTestEnv(t, conf)
+		defer env.Cleanup()
+		w := newTestBlockfileWrapper(env, ledgerid)
+		defer w.close()
+		require.Greater(t, w.blockfileMgr.blockfilesInfo.latestFileNumber, 2)
+		w.testGetBlockByHash(blocks)
+		w.testGetBlockByNumber(blocks)
+		w.testGetBlockByTxID(blocks)
+		testGetTransactions(t, w.blockfileMgr, blocks)
+		testIterateBlocks(t, w.blockfileMgr, blocks)
+	}
+	verify()
+
+	// the index is rebuilt from the block files, as done after `peer node rebuild-dbs`
+	require.NoError(t, os.RemoveAll(conf.getIndexDir()))
+	verify()
+
+	// the blockfiles info is rebuilt from the block files
+	blkfilesInfo, err := constructBlockfilesInfo(conf.getLedgerBlockDir(ledgerid))
+	require.NoError(t, err)
+	require.Equal(t, uint64(29), blkfilesInfo.lastPersistedBlock)
+}
+
+func TestCompressedBlockCrashDuringWriting(t *testing.T) {
+	ledgerid := "testLedger"
+	conf := NewConfWithCompression(t.TempDir(), 0, CompressionSnappy)
+	env := newTestEnv(t, conf)
+	defer env.Cleanup()
+	w := newTestBlockfileWrapper(env, ledgerid)
+	blocks := testutil.ConstructTestBlocks(t, 6)
+	w.addBlocks(blocks[:5])
+	blkfilesInfo := w.blockfileMgr.blockfilesInfo
+
+	// write a partial compressed block after saving the blockfiles info, for each possible length of the partial block
+	blockBytes, _, err := serializeBlock(blocks[5])
+	require.NoError(t, err)
+	header, compressed, _ := CompressionSnappy.encodeBlock(blockBytes)
+	record := append(header, compressed...)
+	w.close()
+	for _, partialLen := range []int{1, 2, len(header), len(record) - 1} {
+		require.NoError(t, w.blockfileMgr.currentFileWriter.open())
+		require.NoError(t, w.blockfileMgr.currentFileWriter.truncateFile(blkfilesInfo.latestFileSize))
+		require.NoError(t, w.blockfileMgr.currentFileWriter.append(record[:partialLen], true))
+		w.close()
+
+		lastBlockBytes, endOffset, numBlocks, err := scanForLastCompleteBlock(
+			conf.getLedgerBlockDir(ledgerid), 0, 0)
+		require.NoError(t, err)
+		require.Equal(t, 5, numBlocks)
+		require.Equal(t, int64(blkfilesInfo.latestFileSize), endOffset)
+		lastBlock, err := deserializeBlock(lastBlockBytes)
+		require.NoError(t, err)
+		require.True(t, proto.Equal(blocks[4], lastBlock))
+	}
+
+	// the partial block is discarded on restart
+	env.provider.Close()
+	require.NoError(t, os.RemoveAll(conf.getIndexDir()))
+	env = newTestEnv(t, conf)
+	w = newTestBlockfileWrapper(env, ledgerid)
+	defer w.close()
+	require.Equal(t, blkfilesInfo, w.blockfileMgr.blockfilesInfo)
+	w.addBlocks(blocks[5:])
+	w.testGetBlockByNumber(blocks)
+}
+
+func TestCompressedBlockUnsupportedCodec(t *testing.T) {
+	rootDir := t.TempDir()
+	f, err := os.Create(deriveBlockfilePath(rootDir, 0))
+	require.NoError(t, err)
+	_, err = f.Write([]byte{compressedBlockMarker, 42, 1, 0})
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	stream, err := newBlockfileStream(rootDir, 0, 0)
+	require.NoError(t, err)
+	defer stream.close()
+	_, err = stream.nextBlockBytes()
+	require.EqualError(t, err, "error reading block at offset [0] in file number [0]: unsupported block compression codec [42]")
+}
+
+func TestFileLocPointerInCompressedBlock(t *testing.T) {
+	flp := newTxFileLocationPointer(
+		&fileLocPointer{fileSuffixNum: 3, locPointer: locPointer{offset: 1000}},
+		&locPointer{offset: 20, bytesLength: 300},
+		true,
+	)
+	require.Equal(t, "fileSuffixNum=3, blockOffset=1000, offset=20, bytesLength=300", flp.String())
+	b, err := flp.marshal()
+	require.NoError(t, err)
+	unmarshalled := &fileLocPointer{}
+	require.NoError(t, unmarshalled.unmarshal(b))
+	require.Equal(t, flp, unmarshalled)
+
+	flp = newTxFileLocationPointer(
+		&fileLocPointer{fileSuffixNum: 3, locPointer: locPointer{offset: 1000}},
+		&locPointer{offset: 20, bytesLength: 300},
+		false,
+	)
+	require.Equal(t, &fileLocPointer{fileSuffixNum: 3, locPointer: locPointer{offset: 1020, bytesLength: 300}}, flp)
+	b, err = flp.marshal()
+	require.NoError(t, err)
+	unmarshalled = &fileLocPointer{}
+	require.NoError(t, unmarshalled.unmarshal(b))
+	require.Equal(t, flp, unmarshalled)
+}
+
+func testGetTransactions(t *testing.T, mgr *blockfileMgr, blocks []*common.Block) {
+	for _, blk := range blocks {
+		for j, txEnvelopeBytes := range blk.Data.Data {
+			txEnvelope, err := protoutil.GetEnvelopeFromBlock(txEnvelopeBytes)
+			require.NoError(t, err)
+			txID, err := protoutil.GetOrComputeTxIDFromEnvelope(txEnvelopeBytes)
+			require.NoError(t, err)
+
+			env, err := mgr.retrieveTransactionByID(txID)
+			require.NoError(t, err)
+			require.True(t, proto.Equal(txEnvelope, env))
+			env, err = mgr.retrieveTransactionByBlockNumTranNum(blk.Header.Number, uint64(j))
+			require.NoError(t, err)
+			require.True(t, proto.Equal(txEnvelope, env))
+		}
+	}
+}
+
+func testIterateBlocks(t *testing.T, mgr *blockfileMgr, blocks []*common.Block) {
+	itr, err := mgr.retrieveBlocks(0)
+	require.NoError(t, err)
+	defer itr.Close()
+	for _, expected := range blocks {
+		next, err := itr.Next()
+		require.NoError(t, err)
+		require.True(t, proto.Equal(expected, next.(*common.Block)))
+	}
+}
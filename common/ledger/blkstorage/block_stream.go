@@ -46,6 +46,9 @@ type blockPlacementInfo struct {
 	fileNum          int
 	blockStartOffset int64
 	blockBytesOffset int64
+	// compressed indicates that the bytes starting at blockBytesOffset are
+	// the compressed bytes of the block
+	compressed bool
 }
 
 // /////////////////////////////////
@@ -94,9 +97,10 @@ func (s *blockfileStream) nextBlockBytesAndPlacementInfo() ([]byte, *blockPlacem
 		return nil, nil, nil
 	}
 	remainingBytes := fileInfo.Size() - s.currentOffset
-	// Peek 8 or smaller number of bytes (if remaining bytes are less than 8)
-	// Assumption is that a block size would be small enough to be represented in 8 bytes varint
-	peekBytes := 8
+	// Peek 10 or smaller number of bytes (if remaining bytes are less than 10)
+	// Assumption is that a block size would be small enough to be represented in 8 bytes varint,
+	// which may be preceded by the two bytes marking a compressed block
+	peekBytes := 10
 	if remainingBytes < int64(peekBytes) {
 		peekBytes = int(remainingBytes)
 		moreContentAvailable = false
@@ -114,6 +118,23 @@ func (s *blockfileStream) nextBlockBytesAndPlacementInfo() ([]byte, *blockPlacem
 		}
 		panic(errors.Errorf("Error in decoding varint bytes [%#v]", lenBytes))
 	}
+	compressed := length == 0 && lenBytes[0] == compressedBlockMarker
+	var codec byte
+	if compressed {
+		// a compressed block - the marker is followed by the codec and the size of the compressed bytes
+		if len(lenBytes) < 2 {
+			return nil, nil, ErrUnexpectedEndOfBlockfile
+		}
+		codec = lenBytes[1]
+		var m int
+		if length, m = proto.DecodeVarint(lenBytes[2:]); m == 0 {
+			if !moreContentAvailable {
+				return nil, nil, ErrUnexpectedEndOfBlockfile
+			}
+			panic(errors.Errorf("Error in decoding varint bytes [%#v]", lenBytes))
+		}
+		n += 1 + m
+	}
 	bytesExpected := int64(n) + int64(length)
 	if bytesExpected > remainingBytes {
 		logger.Debugf("At least [%d] bytes expected. Remaining bytes = [%d]. Returning with error [%s]",
@@ -129,10 +150,16 @@ func (s *blockfileStream) nextBlockBytesAndPlacementInfo() ([]byte, *blockPlacem
 		logger.Errorf("Error reading [%d] bytes from file number [%d], error: %s", length, s.fileNum, err)
 		return nil, nil, errors.Wrapf(err, "error reading [%d] bytes from file number [%d]", length, s.fileNum)
 	}
+	if compressed {
+		if blockBytes, err = decompressBlock(codec, blockBytes); err != nil {
+			return nil, nil, errors.WithMessagef(err, "error reading block at offset [%d] in file number [%d]", s.currentOffset, s.fileNum)
+		}
+	}
 	blockPlacementInfo := &blockPlacementInfo{
 		fileNum:          s.fileNum,
 		blockStartOffset: s.currentOffset,
 		blockBytesOffset: s.currentOffset + int64(n),
+		compressed:       compressed,
 	}
 	s.currentOffset += int64(n) + int64(length)
 	logger.Debugf("Returning blockbytes - length=[%d], placementInfo={%s}", len(blockBytes), blockPlacementInfo)
@@ -195,6 +222,6 @@ func (s *blockStream) close() error {
 }
 
 func (i *blockPlacementInfo) String() string {
-	return fmt.Sprintf("fileNum=[%d], startOffset=[%d], bytesOffset=[%d]",
-		i.fileNum, i.blockStartOffset, i.blockBytesOffset)
+	return fmt.Sprintf("fileNum=[%d], startOffset=[%d], bytesOffset=[%d], compressed=[%t]",
+		i.fileNum, i.blockStartOffset, i.blockBytesOffset, i.compressed)
 }
@@ -41,6 +41,8 @@ type blockfileMgr struct {
 	blkfilesInfoCond          *sync.Cond
 	currentFileWriter         *blockfileWriter
 	bcInfo                    atomic.Value
+	archivedBlocksInfo        atomic.Value
+	archiveLock               sync.Mutex
 }
 
 /*
@@ -137,6 +139,9 @@ func newBlockfileMgr(id string, conf *Conf, indexConfig *IndexConfig, indexStore
 	mgr.bootstrappingSnapshotInfo = bsi
 	mgr.currentFileWriter = currentFileWriter
 	mgr.blkfilesInfoCond = sync.NewCond(&sync.Mutex{})
+	if err := mgr.initArchivedBlocksInfo(); err != nil {
+		return nil, err
+	}
 
 	if err := mgr.syncIndex(); err != nil {
 		return nil, err
@@ -304,9 +309,9 @@ func (mgr *blockfileMgr) addBlock(block *common.Block) error {
 	txOffsets := info.txOffsets
 	currentOffset := mgr.blockfilesInfo.latestFileSize
 
-	blockBytesLen := len(blockBytes)
-	blockBytesEncodedLen := proto.EncodeVarint(uint64(blockBytesLen))
-	totalBytesToAppend := blockBytesLen + len(blockBytesEncodedLen)
+	// the header holds the length of the block bytes, preceded by the codec for a compressed block
+	blockHeaderBytes, blockBytesToAppend, compressed := mgr.conf.compression.encodeBlock(blockBytes)
+	totalBytesToAppend := len(blockHeaderBytes) + len(blockBytesToAppend)
 
 	// Determine if we need to start a new file since the size of this block
 	// exceeds the amount of space left in the current file
@@ -314,11 +319,11 @@ func (mgr *blockfileMgr) addBlock(block *common.Block) error {
 		mgr.moveToNextFile()
 		currentOffset = 0
 	}
-	// append blockBytesEncodedLen to the file
-	err = mgr.currentFileWriter.append(blockBytesEncodedLen, false)
+	// append blockHeaderBytes to the file
+	err = mgr.currentFileWriter.append(blockHeaderBytes, false)
 	if err == nil {
 		// append the actual block bytes to the file
-		err = mgr.currentFileWriter.append(blockBytes, true)
+		err = mgr.currentFileWriter.append(blockBytesToAppend, true)
 	}
 	if err != nil {
 		truncateErr := mgr.currentFileWriter.truncateFile(mgr.blockfilesInfo.latestFileSize)
@@ -348,14 +353,18 @@ func (mgr *blockfileMgr) addBlock(block *common.Block) error {
 	// Index block file location pointer updated with file suffex and offset for the new block
 	blockFLP := &fileLocPointer{fileSuffixNum: newBlkfilesInfo.latestFileNumber}
 	blockFLP.offset = currentOffset
-	// shift the txoffset because we prepend length of bytes before block bytes
-	for _, txOffset := range txOffsets {
-		txOffset.loc.offset += len(blockBytesEncodedLen)
+	// shift the txoffset because we prepend length of bytes before block bytes.
+	// The txoffsets of a compressed block remain relative to the uncompressed block bytes
+	if !compressed {
+		for _, txOffset := range txOffsets {
+			txOffset.loc.offset += len(blockHeaderBytes)
+		}
 	}
 	// save the index in the database
 	if err = mgr.index.indexBlock(&blockIdxInfo{
 		blockNum: block.Header.Number, blockHash: blockHash,
 		flp: blockFLP, txOffsets: txOffsets, metadata: block.Metadata,
+		compressed: compressed,
 	}); err != nil {
 		return err
 	}
@@ -396,12 +405,12 @@ func (mgr *blockfileMgr) syncIndex() error {
 		return nil
 	}
 
-	startFileNum := 0
+	startFileNum := mgr.getArchivedBlocksInfo().firstFileNum
 	startOffset := 0
 	skipFirstBlock := false
 	endFileNum := mgr.blockfilesInfo.latestFileNumber
 
-	firstAvailableBlkNum, err := retrieveFirstBlockNumFromFile(mgr.rootDir, 0)
+	firstAvailableBlkNum, err := retrieveFirstBlockNumFromFile(mgr.rootDir, startFileNum)
 	if err != nil {
 		return err
 	}
@@ -455,9 +464,11 @@ func (mgr *blockfileMgr) syncIndex() error {
 
 		// The blockStartOffset will get applied to the txOffsets prior to indexing within indexBlock(),
 		// therefore just shift by the difference between blockBytesOffset and blockStartOffset
-		numBytesToShift := int(blockPlacementInfo.blockBytesOffset - blockPlacementInfo.blockStartOffset)
-		for _, offset := range info.txOffsets {
-			offset.loc.offset += numBytesToShift
+		if !blockPlacementInfo.compressed {
+			numBytesToShift := int(blockPlacementInfo.blockBytesOffset - blockPlacementInfo.blockStartOffset)
+			for _, offset := range info.txOffsets {
+				offset.loc.offset += numBytesToShift
+			}
 		}
 
 		// Update the blockIndexInfo with what was actually stored in file system
@@ -469,6 +480,7 @@ func (mgr *blockfileMgr) syncIndex() error {
 		}
 		blockIdxInfo.txOffsets = info.txOffsets
 		blockIdxInfo.metadata = info.metadata
+		blockIdxInfo.compressed = blockPlacementInfo.compressed
 
 		logger.Debugf("syncIndex() indexing block [%d]", blockIdxInfo.blockNum)
 		if err = mgr.index.indexBlock(blockIdxInfo); err != nil {
@@ -512,6 +524,9 @@ func (mgr *blockfileMgr) retrieveBlockByHash(blockHash []byte) (*common.Block, e
 	if err != nil {
 		return nil, err
 	}
+	if err := mgr.checkLocationAvailable(loc, fmt.Sprintf("block with hash [%x]", blockHash)); err != nil {
+		return nil, err
+	}
 	return mgr.fetchBlock(loc)
 }
 
@@ -522,11 +537,8 @@ func (mgr *blockfileMgr) retrieveBlockByNumber(blockNum uint64) (*common.Block,
 	if blockNum == math.MaxUint64 {
 		blockNum = mgr.getBlockchainInfo().Height - 1
 	}
-	if blockNum < mgr.firstPossibleBlockNumberInBlockFiles() {
-		return nil, errors.Errorf(
-			"cannot serve block [%d]. The ledger is bootstrapped from a snapshot. First available block = [%d]",
-			blockNum, mgr.firstPossibleBlockNumberInBlockFiles(),
-		)
+	if err := mgr.checkBlockAvailable(blockNum); err != nil {
+		return nil, err
 	}
 	loc, err := mgr.index.getBlockLocByBlockNum(blockNum)
 	if err != nil {
@@ -546,6 +558,9 @@ func (mgr *blockfileMgr) retrieveBlockByTxID(txID string) (*common.Block, error)
 	if err != nil {
 		return nil, err
 	}
+	if err := mgr.checkLocationAvailable(loc, fmt.Sprintf("block for the TXID [%s]", txID)); err != nil {
+		return nil, err
+	}
 	return mgr.fetchBlock(loc)
 }
 
@@ -562,11 +577,8 @@ func (mgr *blockfileMgr) retrieveTxValidationCodeByTxID(txID string) (peer.TxVal
 
 func (mgr *blockfileMgr) retrieveBlockHeaderByNumber(blockNum uint64) (*common.BlockHeader, error) {
 	logger.Debugf("retrieveBlockHeaderByNumber() - blockNum = [%d]", blockNum)
-	if blockNum < mgr.firstPossibleBlockNumberInBlockFiles() {
-		return nil, errors.Errorf(
-			"cannot serve block [%d]. The ledger is bootstrapped from a snapshot. First available block = [%d]",
-			blockNum, mgr.firstPossibleBlockNumberInBlockFiles(),
-		)
+	if err := mgr.checkBlockAvailable(blockNum); err != nil {
+		return nil, err
 	}
 	loc, err := mgr.index.getBlockLocByBlockNum(blockNum)
 	if err != nil {
@@ -584,11 +596,8 @@ func (mgr *blockfileMgr) retrieveBlockHeaderByNumber(blockNum uint64) (*common.B
 }
 
 func (mgr *blockfileMgr) retrieveBlocks(startNum uint64) (*blocksItr, error) {
-	if startNum < mgr.firstPossibleBlockNumberInBlockFiles() {
-		return nil, errors.Errorf(
-			"cannot serve block [%d]. The ledger is bootstrapped from a snapshot. First available block = [%d]",
-			startNum, mgr.firstPossibleBlockNumberInBlockFiles(),
-		)
+	if err := mgr.checkBlockAvailable(startNum); err != nil {
+		return nil, err
 	}
 	return newBlockItr(mgr, startNum), nil
 }
@@ -608,16 +617,16 @@ func (mgr *blockfileMgr) retrieveTransactionByID(txID string) (*common.Envelope,
 	if err != nil {
 		return nil, err
 	}
+	if err := mgr.checkLocationAvailable(loc, fmt.Sprintf("transaction for the TXID [%s]", txID)); err != nil {
+		return nil, err
+	}
 	return mgr.fetchTransactionEnvelope(loc)
 }
 
 func (mgr *blockfileMgr) retrieveTransactionByBlockNumTranNum(blockNum uint64, tranNum uint64) (*common.Envelope, error) {
 	logger.Debugf("retrieveTransactionByBlockNumTranNum() - blockNum = [%d], tranNum = [%d]", blockNum, tranNum)
-	if blockNum < mgr.firstPossibleBlockNumberInBlockFiles() {
-		return nil, errors.Errorf(
-			"cannot serve block [%d]. The ledger is bootstrapped from a snapshot. First available block = [%d]",
-			blockNum, mgr.firstPossibleBlockNumberInBlockFiles(),
-		)
+	if err := mgr.checkBlockAvailable(blockNum); err != nil {
+		return nil, err
 	}
 	loc, err := mgr.index.getTXLocByBlockNumTranNum(blockNum, tranNum)
 	if err != nil {
@@ -642,7 +651,12 @@ func (mgr *blockfileMgr) fetchTransactionEnvelope(lp *fileLocPointer) (*common.E
 	logger.Debugf("Entering fetchTransactionEnvelope() %v\n", lp)
 	var err error
 	var txEnvelopeBytes []byte
-	if txEnvelopeBytes, err = mgr.fetchRawBytes(lp); err != nil {
+	if lp.inCompressedBlock {
+		txEnvelopeBytes, err = mgr.fetchBytesFromCompressedBlock(lp)
+	} else {
+		txEnvelopeBytes, err = mgr.fetchRawBytes(lp)
+	}
+	if err != nil {
 		return nil, err
 	}
 	_, n := proto.DecodeVarint(txEnvelopeBytes)
@@ -676,6 +690,22 @@ func (mgr *blockfileMgr) fetchRawBytes(lp *fileLocPointer) ([]byte, error) {
 	return b, nil
 }
 
+// fetchBytesFromCompressedBlock returns the bytes pointed by a location relative to
+// the uncompressed bytes of a compressed block
+func (mgr *blockfileMgr) fetchBytesFromCompressedBlock(lp *fileLocPointer) ([]byte, error) {
+	blockBytes, err := mgr.fetchBlockBytes(&fileLocPointer{
+		fileSuffixNum: lp.fileSuffixNum,
+		locPointer:    locPointer{offset: lp.blockOffset},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if lp.offset+lp.bytesLength > len(blockBytes) {
+		return nil, errors.Errorf("location [%s] is beyond the end of the block", lp)
+	}
+	return blockBytes[lp.offset : lp.offset+lp.bytesLength], nil
+}
+
 // Get the current blockfilesInfo information that is stored in the database
 func (mgr *blockfileMgr) loadBlkfilesInfo() (*blockfilesInfo, error) {
 	var b []byte
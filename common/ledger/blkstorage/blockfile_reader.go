@@ -0,0 +1,99 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package blkstorage
+
+import (
+	"os"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/pkg/errors"
+)
+
+// BlockfileReader reads the blocks of a ledger sequentially from its block files, without opening
+// the block index. Unlike the BlockStore, it never writes to the block storage directory, which makes it
+// suitable for inspecting the block store of a stopped peer or orderer.
+type BlockfileReader struct {
+	stream *blockStream
+}
+
+// NewBlockfileReader returns a BlockfileReader over the block files of the ledger under blockStorageDir,
+// starting at the block file that contains startBlockNum. The blocks below startBlockNum that
+// share this block file are returned as well, so the caller is expected to skip them.
+func NewBlockfileReader(blockStorageDir, ledgerID string, startBlockNum uint64) (*BlockfileReader, error) {
+	rootDir := NewConf(blockStorageDir, 0).getLedgerBlockDir(ledgerID)
+	if _, err := os.Stat(rootDir); err != nil {
+		if os.IsNotExist(err) {
+			return nil, errors.Errorf("ledger [%s] does not exist under [%s]", ledgerID, blockStorageDir)
+		}
+		return nil, errors.WithStack(err)
+	}
+	firstFileNum, err := retrieveFirstFileSuffix(rootDir)
+	if err != nil {
+		return nil, err
+	}
+	lastFileNum, err := retrieveLastFileSuffix(rootDir)
+	if err != nil {
+		return nil, err
+	}
+	if lastFileNum < 0 {
+		return nil, errors.Errorf("no block files found for ledger [%s]", ledgerID)
+	}
+
+	startFileNum := firstFileNum
+	for fileNum := lastFileNum; fileNum > firstFileNum; fileNum-- {
+		firstBlockNum, found, err := firstBlockNumInFile(rootDir, fileNum)
+		if err != nil {
+			return nil, err
+		}
+		if found && firstBlockNum <= startBlockNum {
+			startFileNum = fileNum
+			break
+		}
+	}
+
+	stream, err := newBlockStream(rootDir, startFileNum, 0, lastFileNum)
+	if err != nil {
+		return nil, err
+	}
+	return &BlockfileReader{stream: stream}, nil
+}
+
+// Next returns the next block, or nil once all the blocks have been read. A block partially
+// written at the end of the last block file, as may be left by a crash, is ignored.
+func (r *BlockfileReader) Next() (*common.Block, error) {
+	blockBytes, err := r.stream.nextBlockBytes()
+	if err == ErrUnexpectedEndOfBlockfile && r.stream.currentFileNum == r.stream.endFileNum {
+		return nil, nil
+	}
+	if err != nil || blockBytes == nil {
+		return nil, err
+	}
+	return deserializeBlock(blockBytes)
+}
+
+// Close releases the block file held open by the reader
+func (r *BlockfileReader) Close() error {
+	return r.stream.close()
+}
+
+func firstBlockNumInFile(rootDir string, fileNum int) (uint64, bool, error) {
+	info, err := os.Stat(deriveBlockfilePath(rootDir, fileNum))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, false, nil
+		}
+		return 0, false, errors.WithStack(err)
+	}
+	if info.Size() == 0 {
+		return 0, false, nil
+	}
+	blockNum, err := retrieveFirstBlockNumFromFile(rootDir, fileNum)
+	if err != nil {
+		return 0, false, err
+	}
+	return blockNum, true, nil
+}
@@ -0,0 +1,74 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package blkstorage
+
+import (
+	"os"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockfileReader(t *testing.T) {
+	ledgerid := "testLedger"
+	blocks := testutil.ConstructTestBlocks(t, 20)
+	maxFileSize := int(0.2 * float64(testutilEstimateTotalSizeOnDisk(t, blocks)))
+	conf := NewConf(t.TempDir(), maxFileSize)
+
+	env := newTestEnv(t, conf)
+	w := newTestBlockfileWrapper(env, ledgerid)
+	w.addBlocks(blocks)
+	w.close()
+	env.Cleanup()
+
+	for _, startBlockNum := range []uint64{0, 7, 19, 25} {
+		r, err := NewBlockfileReader(conf.blockStorageDir, ledgerid, startBlockNum)
+		require.NoError(t, err)
+		first, err := r.Next()
+		require.NoError(t, err)
+		require.LessOrEqual(t, first.Header.Number, startBlockNum)
+		for expected := first.Header.Number; expected < 20; expected++ {
+			if expected > first.Header.Number {
+				block, err := r.Next()
+				require.NoError(t, err)
+				require.True(t, proto.Equal(blocks[expected], block), "block %d", expected)
+			}
+		}
+		block, err := r.Next()
+		require.NoError(t, err)
+		require.Nil(t, block)
+		require.NoError(t, r.Close())
+	}
+
+	// a block partially written at the end of the last file is ignored
+	lastFileNum, err := retrieveLastFileSuffix(conf.getLedgerBlockDir(ledgerid))
+	require.NoError(t, err)
+	f, err := os.OpenFile(deriveBlockfilePath(conf.getLedgerBlockDir(ledgerid), lastFileNum), os.O_APPEND|os.O_WRONLY, 0o600)
+	require.NoError(t, err)
+	_, err = f.Write([]byte{0x80, 0x01, 0x05})
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	r, err := NewBlockfileReader(conf.blockStorageDir, ledgerid, 19)
+	require.NoError(t, err)
+	defer r.Close()
+	var last uint64
+	for {
+		block, err := r.Next()
+		require.NoError(t, err)
+		if block == nil {
+			break
+		}
+		last = block.Header.Number
+	}
+	require.Equal(t, uint64(19), last)
+
+	_, err = NewBlockfileReader(conf.blockStorageDir, "nonExistingLedger", 0)
+	require.EqualError(t, err, "ledger [nonExistingLedger] does not exist under ["+conf.blockStorageDir+"]")
+}
@@ -47,6 +47,9 @@ type blockIdxInfo struct {
 	flp       *fileLocPointer
 	txOffsets []*txindexInfo
 	metadata  *common.BlockMetadata
+	// compressed indicates that the block is stored compressed, in which case
+	// the txOffsets are relative to the uncompressed block bytes
+	compressed bool
 }
 
 type blockIndex struct {
@@ -110,7 +113,7 @@ func (index *blockIndex) indexBlock(blockIdxInfo *blockIdxInfo) error {
 	// Index3 Used to find a transaction by its transaction id
 	if index.isAttributeIndexed(IndexableAttrTxID) {
 		for i, txoffset := range txOffsets {
-			txFlp := newFileLocationPointer(flp.fileSuffixNum, flp.offset, txoffset.loc)
+			txFlp := newTxFileLocationPointer(flp, txoffset.loc, blockIdxInfo.compressed)
 			logger.Debugf("Adding txLoc [%s] for tx ID: [%s] to txid-index", txFlp, txoffset.txID)
 			txFlpBytes, marshalErr := txFlp.marshal()
 			if marshalErr != nil {
@@ -136,7 +139,7 @@ func (index *blockIndex) indexBlock(blockIdxInfo *blockIdxInfo) error {
 	// Index4 - Store BlockNumTranNum will be used to query history data
 	if index.isAttributeIndexed(IndexableAttrBlockNumTranNum) {
 		for i, txoffset := range txOffsets {
-			txFlp := newFileLocationPointer(flp.fileSuffixNum, flp.offset, txoffset.loc)
+			txFlp := newTxFileLocationPointer(flp, txoffset.loc, blockIdxInfo.compressed)
 			logger.Debugf("Adding txLoc [%s] for tx number:[%d] ID: [%s] to blockNumTranNum index", txFlp, i, txoffset.txID)
 			txFlpBytes, marshalErr := txFlp.marshal()
 			if marshalErr != nil {
@@ -500,6 +503,10 @@ func (lp *locPointer) String() string {
 type fileLocPointer struct {
 	fileSuffixNum int
 	locPointer
+	// inCompressedBlock indicates that the locPointer is relative to the
+	// uncompressed bytes of the compressed block stored at blockOffset
+	inCompressedBlock bool
+	blockOffset       int
 }
 
 func newFileLocationPointer(fileSuffixNum int, beginningOffset int, relativeLP *locPointer) *fileLocPointer {
@@ -509,6 +516,19 @@ func newFileLocationPointer(fileSuffixNum int, beginningOffset int, relativeLP *
 	return flp
 }
 
+// newTxFileLocationPointer returns the location of a transaction in the block stored at blockFLP
+func newTxFileLocationPointer(blockFLP *fileLocPointer, txLP *locPointer, compressedBlock bool) *fileLocPointer {
+	if !compressedBlock {
+		return newFileLocationPointer(blockFLP.fileSuffixNum, blockFLP.offset, txLP)
+	}
+	return &fileLocPointer{
+		fileSuffixNum:     blockFLP.fileSuffixNum,
+		locPointer:        *txLP,
+		inCompressedBlock: true,
+		blockOffset:       blockFLP.offset,
+	}
+}
+
 func (flp *fileLocPointer) marshal() ([]byte, error) {
 	buffer := proto.NewBuffer([]byte{})
 	e := buffer.EncodeVarint(uint64(flp.fileSuffixNum))
@@ -523,6 +543,13 @@ func (flp *fileLocPointer) marshal() ([]byte, error) {
 	if e != nil {
 		return nil, errors.Wrapf(e, "unexpected error while marshaling fileLocPointer [%s]", flp)
 	}
+	// the block offset is only present for the locations in compressed blocks
+	if flp.inCompressedBlock {
+		e = buffer.EncodeVarint(uint64(flp.blockOffset))
+		if e != nil {
+			return nil, errors.Wrapf(e, "unexpected error while marshaling fileLocPointer [%s]", flp)
+		}
+	}
 	return buffer.Bytes(), nil
 }
 
@@ -544,10 +571,22 @@ func (flp *fileLocPointer) unmarshal(b []byte) error {
 		return errors.Wrapf(e, "unexpected error while unmarshalling bytes [%#v] into fileLocPointer", b)
 	}
 	flp.bytesLength = int(i)
+	if len(buffer.Unread()) == 0 {
+		return nil
+	}
+	i, e = buffer.DecodeVarint()
+	if e != nil {
+		return errors.Wrapf(e, "unexpected error while unmarshalling bytes [%#v] into fileLocPointer", b)
+	}
+	flp.inCompressedBlock = true
+	flp.blockOffset = int(i)
 	return nil
 }
 
 func (flp *fileLocPointer) String() string {
+	if flp.inCompressedBlock {
+		return fmt.Sprintf("fileSuffixNum=%d, blockOffset=%d, %s", flp.fileSuffixNum, flp.blockOffset, flp.locPointer.String())
+	}
 	return fmt.Sprintf("fileSuffixNum=%d, %s", flp.fileSuffixNum, flp.locPointer.String())
 }
 
@@ -43,6 +43,10 @@ func (itr *blocksItr) waitForBlock(blockNum uint64) uint64 {
 func (itr *blocksItr) initStream() error {
 	var lp *fileLocPointer
 	var err error
+	// the block files may have been archived after the iterator was created
+	if err = itr.mgr.checkBlockAvailable(itr.blockNumToRetrieve); err != nil {
+		return err
+	}
 	if lp, err = itr.mgr.index.getBlockLocByBlockNum(itr.blockNumToRetrieve); err != nil {
 		return err
 	}
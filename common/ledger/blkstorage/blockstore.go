@@ -105,6 +105,21 @@ func (store *BlockStore) ExportTxIds(dir string, newHashFunc snapshot.NewHashFun
 	return store.fileMgr.index.exportUniqueTxIDs(dir, newHashFunc)
 }
 
+// ArchiveBlocks archives the block files that contain only blocks with a number lower than blockNum.
+// The block files are moved to a sub-directory named after the ledger in archiveDir, or deleted if
+// archiveDir is empty. The block file that is currently being appended to is never archived.
+// Retrieving an archived block, or a transaction in an archived block, returns an ErrBlocksArchived.
+// It returns the number of the first block that remains available in the block store.
+func (store *BlockStore) ArchiveBlocks(blockNum uint64, archiveDir string) (uint64, error) {
+	return store.fileMgr.archiveBlockFiles(store.id, blockNum, archiveDir)
+}
+
+// FirstAvailableBlockNum returns the number of the first block that can be retrieved from the block store,
+// which is greater than zero if the ledger is bootstrapped from a snapshot or some blocks have been archived
+func (store *BlockStore) FirstAvailableBlockNum() uint64 {
+	return store.fileMgr.firstAvailableBlockNum()
+}
+
 // Shutdown shuts down the block store
 func (store *BlockStore) Shutdown() {
 	logger.Debugf("closing fs blockStore:%s", store.id)
@@ -20,6 +20,7 @@ const (
 type Conf struct {
 	blockStorageDir  string
 	maxBlockfileSize int
+	compression      Compression
 }
 
 // NewConf constructs new `Conf`.
@@ -28,7 +29,16 @@ func NewConf(blockStorageDir string, maxBlockfileSize int) *Conf {
 	if maxBlockfileSize <= 0 {
 		maxBlockfileSize = defaultMaxBlockfileSize
 	}
-	return &Conf{blockStorageDir, maxBlockfileSize}
+	return &Conf{blockStorageDir: blockStorageDir, maxBlockfileSize: maxBlockfileSize}
+}
+
+// NewConfWithCompression constructs new `Conf` for a `BlockStore` that compresses
+// the blocks it appends with the given codec. The blocks already present in the
+// block files remain readable, irrespective of the compression they were stored with.
+func NewConfWithCompression(blockStorageDir string, maxBlockfileSize int, compression Compression) *Conf {
+	conf := NewConf(blockStorageDir, maxBlockfileSize)
+	conf.compression = compression
+	return conf
 }
 
 func (conf *Conf) getIndexDir() string {
@@ -11,6 +11,7 @@ import (
 	ab "github.com/hyperledger/fabric-protos-go/orderer"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/ledger/blockledger"
 )
 
@@ -47,6 +48,10 @@ type fileLedgerIterator struct {
 // It returns an error if the next block is no longer retrievable.
 func (i *fileLedgerIterator) Next() (*cb.Block, cb.Status) {
 	result, err := i.commonIterator.Next()
+	if blkstorage.IsBlocksArchived(err) {
+		logger.Warning(err)
+		return nil, cb.Status_NOT_FOUND
+	}
 	if err != nil {
 		logger.Error(err)
 		return nil, cb.Status_SERVICE_UNAVAILABLE
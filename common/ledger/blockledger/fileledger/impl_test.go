@@ -16,6 +16,7 @@ import (
 	"github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/common/flogging"
 	cl "github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/ledger/blkstorage/blkstoragetest"
 	"github.com/hyperledger/fabric/common/ledger/blockledger"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
@@ -367,6 +368,23 @@ func TestBlockstoreError(t *testing.T) {
 		_, status := it.Next()
 		require.Equal(t, cb.Status_SERVICE_UNAVAILABLE, status, "Expected service unavailable error")
 	}
+
+	{
+		resultsIterator := &mockBlockStoreIterator{}
+		resultsIterator.On("Next").Return(nil, &blkstorage.ErrBlocksArchived{Requested: "block [0]", FirstAvailableBlockNum: 10})
+		resultsIterator.On("Close").Return()
+		fl := &FileLedger{
+			blockStore: &mockBlockStore{
+				blockchainInfo:  &cb.BlockchainInfo{Height: uint64(20)},
+				resultsIterator: resultsIterator,
+			},
+			signal: make(chan struct{}),
+		}
+		it, _ := fl.Iterator(&ab.SeekPosition{Type: &ab.SeekPosition_Oldest{}})
+		defer it.Close()
+		_, status := it.Next()
+		require.Equal(t, cb.Status_NOT_FOUND, status, "Expected not found error for archived blocks")
+	}
 }
 
 func getSampleEnvelopeWithSignatureHeader() *cb.Envelope {
@@ -0,0 +1,434 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package policydsl
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	mb "github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/pkg/errors"
+)
+
+// Expression is a node of a parsed policy expression. It is one of
+// *Principal, *Gate or *ImplicitMeta.
+type Expression interface {
+	// String returns the expression in the policy expression language.
+	String() string
+
+	isExpression()
+}
+
+// Principal is an expression which requires a signature from an identity
+// with the given role in the given MSP, e.g. 'Org1MSP.admin'.
+type Principal struct {
+	MSPID string
+	// Role is one of the RoleXXX constants.
+	Role string
+}
+
+// Gate is an expression which requires N of its operands to be satisfied.
+// AND and OR are gates which require all or one of their operands.
+type Gate struct {
+	N        int
+	Operands []Expression
+}
+
+// ImplicitMeta is an expression which requires ANY, ALL or a MAJORITY of the
+// sub-policies with the given name of the sub-groups of the config group the
+// expression is evaluated at, e.g. MAJORITY Admins.
+type ImplicitMeta struct {
+	Rule      cb.ImplicitMetaPolicy_Rule
+	SubPolicy string
+}
+
+func (*Principal) isExpression()    {}
+func (*Gate) isExpression()         {}
+func (*ImplicitMeta) isExpression() {}
+
+func (p *Principal) String() string {
+	return "'" + p.MSPID + "." + p.Role + "'"
+}
+
+func (g *Gate) String() string {
+	operands := make([]string, len(g.Operands))
+	for i, operand := range g.Operands {
+		operands[i] = operand.String()
+	}
+
+	switch {
+	case g.N == len(g.Operands):
+		return "AND(" + strings.Join(operands, ", ") + ")"
+	case g.N == 1:
+		return "OR(" + strings.Join(operands, ", ") + ")"
+	default:
+		return fmt.Sprintf("OutOf(%d, %s)", g.N, strings.Join(operands, ", "))
+	}
+}
+
+func (im *ImplicitMeta) String() string {
+	return im.Rule.String() + " " + im.SubPolicy
+}
+
+// ParseExpression parses a policy expression, which unifies the signature
+// policy language of FromString and the implicit meta policy rules of the
+// channel configuration. The supported language is as follows:
+//
+// E = GATE(E[, E]) | OutOf(N, E[, E]) | RULE SUBPOLICY | 'ORG.ROLE'
+//
+// where:
+//   - GATE is either "and" or "or", in lower, upper or title case
+//   - N is the number of expressions out of the following ones which must be
+//     satisfied
+//   - RULE is either "ANY", "ALL" or "MAJORITY"
+//   - SUBPOLICY is the name of the sub-policy, which may also be quoted or
+//     enclosed in parentheses, e.g. MAJORITY('Admins')
+//   - ORG is the MSP identifier and ROLE takes the value of any of the
+//     RoleXXX constants representing the required role
+//
+// For example: OR('Org1MSP.admin', AND(MAJORITY Admins, 'Org2MSP.peer'))
+func ParseExpression(policy string) (Expression, error) {
+	p := &expressionParser{input: policy}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+
+	expr, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+	if p.token.kind != tokenEOF {
+		return nil, errors.Errorf("unexpected token '%s' at position %d in policy string", p.token.text, p.token.pos)
+	}
+
+	return expr, nil
+}
+
+// IsSignatureExpression returns whether the expression only consists of
+// principals and gates, and so may be encoded as a signature policy.
+func IsSignatureExpression(expr Expression) bool {
+	switch e := expr.(type) {
+	case *Principal:
+		return true
+	case *Gate:
+		for _, operand := range e.Operands {
+			if !IsSignatureExpression(operand) {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+// SignaturePolicyFromExpression encodes an expression consisting of principals
+// and gates as a SignaturePolicyEnvelope. The resulting envelope is the same as
+// the one FromString returns for the same policy string.
+func SignaturePolicyFromExpression(expr Expression) (*cb.SignaturePolicyEnvelope, error) {
+	ctx := newContext()
+	rule, err := signaturePolicy(expr, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cb.SignaturePolicyEnvelope{
+		Identities: ctx.principals,
+		Version:    0,
+		Rule:       rule,
+	}, nil
+}
+
+func signaturePolicy(expr Expression, ctx *context) (*cb.SignaturePolicy, error) {
+	switch e := expr.(type) {
+	case *Principal:
+		principal, err := e.MSPPrincipal()
+		if err != nil {
+			return nil, err
+		}
+		ctx.principals = append(ctx.principals, principal)
+		policy := SignedBy(int32(ctx.IDNum))
+		ctx.IDNum++
+		return policy, nil
+	case *Gate:
+		policies := make([]*cb.SignaturePolicy, len(e.Operands))
+		for i, operand := range e.Operands {
+			policy, err := signaturePolicy(operand, ctx)
+			if err != nil {
+				return nil, err
+			}
+			policies[i] = policy
+		}
+		return NOutOf(int32(e.N), policies), nil
+	default:
+		return nil, errors.Errorf("%s cannot be encoded as a signature policy", expr)
+	}
+}
+
+// PolicyFromString parses a policy expression and encodes it as a policy of
+// the channel configuration. An expression consisting of principals and gates
+// is encoded as a signature policy and an implicit meta expression is encoded
+// as an implicit meta policy. Expressions which nest implicit meta expressions
+// within gates cannot be encoded as a single policy.
+func PolicyFromString(policy string) (*cb.Policy, error) {
+	expr, err := ParseExpression(policy)
+	if err != nil {
+		return nil, err
+	}
+
+	if im, ok := expr.(*ImplicitMeta); ok {
+		value, err := proto.Marshal(&cb.ImplicitMetaPolicy{
+			Rule:      im.Rule,
+			SubPolicy: im.SubPolicy,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "error marshalling implicit meta policy")
+		}
+		return &cb.Policy{Type: int32(cb.Policy_IMPLICIT_META), Value: value}, nil
+	}
+
+	if !IsSignatureExpression(expr) {
+		return nil, errors.Errorf("policy '%s' nests implicit meta expressions within gates and cannot be encoded as a single policy", policy)
+	}
+
+	sigPolicy, err := SignaturePolicyFromExpression(expr)
+	if err != nil {
+		return nil, err
+	}
+	value, err := proto.Marshal(sigPolicy)
+	if err != nil {
+		return nil, errors.Wrap(err, "error marshalling signature policy")
+	}
+	return &cb.Policy{Type: int32(cb.Policy_SIGNATURE), Value: value}, nil
+}
+
+// MSPPrincipal returns the MSP principal which the principal expression
+// requires a signature from.
+func (p *Principal) MSPPrincipal() (*mb.MSPPrincipal, error) {
+	var role mb.MSPRole_MSPRoleType
+	switch p.Role {
+	case RoleMember:
+		role = mb.MSPRole_MEMBER
+	case RoleAdmin:
+		role = mb.MSPRole_ADMIN
+	case RoleClient:
+		role = mb.MSPRole_CLIENT
+	case RolePeer:
+		role = mb.MSPRole_PEER
+	case RoleOrderer:
+		role = mb.MSPRole_ORDERER
+	default:
+		return nil, errors.Errorf("error parsing role %s", p.Role)
+	}
+
+	mspRole, err := proto.Marshal(&mb.MSPRole{MspIdentifier: p.MSPID, Role: role})
+	if err != nil {
+		return nil, errors.Wrap(err, "error marshalling msp role")
+	}
+
+	return &mb.MSPPrincipal{
+		PrincipalClassification: mb.MSPPrincipal_ROLE,
+		Principal:               mspRole,
+	}, nil
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenString
+	tokenLParen
+	tokenRParen
+	tokenComma
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+type expressionParser struct {
+	input string
+	pos   int
+	token token
+}
+
+// next reads the next token of the input into p.token.
+func (p *expressionParser) next() error {
+	for p.pos < len(p.input) && unicode.IsSpace(rune(p.input[p.pos])) {
+		p.pos++
+	}
+
+	start := p.pos
+	if p.pos == len(p.input) {
+		p.token = token{kind: tokenEOF, text: "end of policy string", pos: start}
+		return nil
+	}
+
+	switch c := p.input[p.pos]; {
+	case c == '(':
+		p.pos++
+		p.token = token{kind: tokenLParen, text: "(", pos: start}
+	case c == ')':
+		p.pos++
+		p.token = token{kind: tokenRParen, text: ")", pos: start}
+	case c == ',':
+		p.pos++
+		p.token = token{kind: tokenComma, text: ",", pos: start}
+	case c == '\'' || c == '"':
+		end := strings.IndexByte(p.input[p.pos+1:], c)
+		if end < 0 {
+			return errors.Errorf("unterminated string at position %d in policy string", start)
+		}
+		p.pos += end + 2
+		p.token = token{kind: tokenString, text: p.input[start+1 : p.pos-1], pos: start}
+	case isIdentChar(c):
+		for p.pos < len(p.input) && isIdentChar(p.input[p.pos]) {
+			p.pos++
+		}
+		p.token = token{kind: tokenIdent, text: p.input[start:p.pos], pos: start}
+	default:
+		return errors.Errorf("unexpected character '%c' at position %d in policy string", c, start)
+	}
+
+	return nil
+}
+
+func isIdentChar(c byte) bool {
+	return c == '_' || c == '-' || c == '.' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func (p *expressionParser) expect(kind tokenKind, what string) (token, error) {
+	t := p.token
+	if t.kind != kind {
+		return t, errors.Errorf("expected %s at position %d in policy string, got '%s'", what, t.pos, t.text)
+	}
+	return t, p.next()
+}
+
+func (p *expressionParser) parseExpression() (Expression, error) {
+	t := p.token
+	switch t.kind {
+	case tokenString:
+		subm := regex.FindStringSubmatch(t.text)
+		if subm == nil {
+			return nil, errors.Errorf("error parsing principal %s", t.text)
+		}
+		return &Principal{MSPID: subm[1], Role: subm[3]}, p.next()
+	case tokenIdent:
+	default:
+		return nil, errors.Errorf("expected a principal, a gate or an implicit meta rule at position %d in policy string, got '%s'", t.pos, t.text)
+	}
+
+	if rule, ok := cb.ImplicitMetaPolicy_Rule_value[t.text]; ok {
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		subPolicy, err := p.parseSubPolicy()
+		if err != nil {
+			return nil, err
+		}
+		return &ImplicitMeta{Rule: cb.ImplicitMetaPolicy_Rule(rule), SubPolicy: subPolicy}, nil
+	}
+
+	var gate string
+	for _, g := range []string{GateAnd, GateOr, GateOutOf} {
+		if t.text == g || t.text == strings.ToLower(g) || t.text == strings.ToUpper(g) {
+			gate = g
+		}
+	}
+	if gate == "" {
+		return nil, errors.Errorf("unrecognized token '%s' in policy string", t.text)
+	}
+
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	if _, err := p.expect(tokenLParen, "'('"); err != nil {
+		return nil, err
+	}
+
+	n := -1
+	if gate == GateOutOf {
+		nt, err := p.expect(tokenIdent, "the number of expressions")
+		if err != nil {
+			return nil, err
+		}
+		n, err = strconv.Atoi(nt.text)
+		if err != nil {
+			return nil, errors.Errorf("expected the number of expressions at position %d in policy string, got '%s'", nt.pos, nt.text)
+		}
+		if _, err := p.expect(tokenComma, "','"); err != nil {
+			return nil, err
+		}
+	}
+
+	var operands []Expression
+	for {
+		operand, err := p.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, operand)
+
+		if p.token.kind != tokenComma {
+			break
+		}
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := p.expect(tokenRParen, "')'"); err != nil {
+		return nil, err
+	}
+
+	switch gate {
+	case GateAnd:
+		n = len(operands)
+	case GateOr:
+		n = 1
+	}
+	// as with FromString, permit n+1 (which can never be satisfied) but nothing more
+	if n < 0 || n > len(operands)+1 {
+		return nil, errors.Errorf("invalid t-out-of-n predicate, t %d, n %d", n, len(operands))
+	}
+
+	return &Gate{N: n, Operands: operands}, nil
+}
+
+// parseSubPolicy parses the sub-policy name of an implicit meta rule, which
+// may be bare, quoted or enclosed in parentheses.
+func (p *expressionParser) parseSubPolicy() (string, error) {
+	parens := p.token.kind == tokenLParen
+	if parens {
+		if err := p.next(); err != nil {
+			return "", err
+		}
+	}
+
+	t := p.token
+	if t.kind != tokenIdent && t.kind != tokenString || t.text == "" {
+		return "", errors.Errorf("expected a sub-policy name at position %d in policy string, got '%s'", t.pos, t.text)
+	}
+	if err := p.next(); err != nil {
+		return "", err
+	}
+
+	if parens {
+		if _, err := p.expect(tokenRParen, "')'"); err != nil {
+			return "", err
+		}
+	}
+
+	return t.text, nil
+}
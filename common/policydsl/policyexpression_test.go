@@ -0,0 +1,157 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package policydsl
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseExpression(t *testing.T) {
+	tests := []struct {
+		policy   string
+		expected Expression
+		str      string
+	}{
+		{
+			policy:   "'A.member'",
+			expected: &Principal{MSPID: "A", Role: RoleMember},
+			str:      "'A.member'",
+		},
+		{
+			policy: `OR("A.admin", 'B.peer')`,
+			expected: &Gate{N: 1, Operands: []Expression{
+				&Principal{MSPID: "A", Role: RoleAdmin},
+				&Principal{MSPID: "B", Role: RolePeer},
+			}},
+			str: "OR('A.admin', 'B.peer')",
+		},
+		{
+			policy: "outof(2, 'A.client', 'B.orderer', 'C.member')",
+			expected: &Gate{N: 2, Operands: []Expression{
+				&Principal{MSPID: "A", Role: RoleClient},
+				&Principal{MSPID: "B", Role: RoleOrderer},
+				&Principal{MSPID: "C", Role: RoleMember},
+			}},
+			str: "OutOf(2, 'A.client', 'B.orderer', 'C.member')",
+		},
+		{
+			policy:   "MAJORITY Admins",
+			expected: &ImplicitMeta{Rule: common.ImplicitMetaPolicy_MAJORITY, SubPolicy: "Admins"},
+			str:      "MAJORITY Admins",
+		},
+		{
+			policy: "Or('Org1.admin', AND(ANY('Readers'), ALL Writers), MAJORITY(Endorsement))",
+			expected: &Gate{N: 1, Operands: []Expression{
+				&Principal{MSPID: "Org1", Role: RoleAdmin},
+				&Gate{N: 2, Operands: []Expression{
+					&ImplicitMeta{Rule: common.ImplicitMetaPolicy_ANY, SubPolicy: "Readers"},
+					&ImplicitMeta{Rule: common.ImplicitMetaPolicy_ALL, SubPolicy: "Writers"},
+				}},
+				&ImplicitMeta{Rule: common.ImplicitMetaPolicy_MAJORITY, SubPolicy: "Endorsement"},
+			}},
+			str: "OR('Org1.admin', AND(ANY Readers, ALL Writers), MAJORITY Endorsement)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.policy, func(t *testing.T) {
+			expr, err := ParseExpression(tt.policy)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, expr)
+			require.Equal(t, tt.str, expr.String())
+
+			reparsed, err := ParseExpression(expr.String())
+			require.NoError(t, err)
+			require.Equal(t, expr, reparsed)
+		})
+	}
+}
+
+func TestParseExpressionErrors(t *testing.T) {
+	tests := []struct {
+		policy string
+		errMsg string
+	}{
+		{policy: "", errMsg: "expected a principal, a gate or an implicit meta rule at position 0 in policy string, got 'end of policy string'"},
+		{policy: "'A.foo'", errMsg: "error parsing principal A.foo"},
+		{policy: "A.member", errMsg: "unrecognized token 'A.member' in policy string"},
+		{policy: "NOR('A.member')", errMsg: "unrecognized token 'NOR' in policy string"},
+		{policy: "AND('A.member'", errMsg: "expected ')' at position 14 in policy string, got 'end of policy string'"},
+		{policy: "AND()", errMsg: "expected a principal, a gate or an implicit meta rule at position 4 in policy string, got ')'"},
+		{policy: "OR('A.member') 'B.member'", errMsg: "unexpected token 'B.member' at position 15 in policy string"},
+		{policy: "OutOf(x, 'A.member')", errMsg: "expected the number of expressions at position 6 in policy string, got 'x'"},
+		{policy: "OutOf(3, 'A.member')", errMsg: "invalid t-out-of-n predicate, t 3, n 1"},
+		{policy: "OR('A.member)", errMsg: "unterminated string at position 3 in policy string"},
+		{policy: "OR('A.member'; 'B.member')", errMsg: "unexpected character ';' at position 13 in policy string"},
+		{policy: "ANY", errMsg: "expected a sub-policy name at position 3 in policy string, got 'end of policy string'"},
+		{policy: "ANY('Admins'", errMsg: "expected ')' at position 12 in policy string, got 'end of policy string'"},
+		{policy: "any Admins", errMsg: "unrecognized token 'any' in policy string"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.policy, func(t *testing.T) {
+			_, err := ParseExpression(tt.policy)
+			require.EqualError(t, err, tt.errMsg)
+		})
+	}
+}
+
+func TestSignaturePolicyFromExpression(t *testing.T) {
+	for _, policy := range []string{
+		"OR('A.member', 'B.admin')",
+		"AND('A.member', 'B.client', 'C.peer')",
+		"OutOf(2, 'A.member', 'B.member', 'C.member')",
+		"OR(AND('A.member', 'B.member'), OutOf(1, 'C.orderer', 'D.admin'), 'A.member')",
+		"OutOf(3, 'A.member', 'B.member')",
+	} {
+		t.Run(policy, func(t *testing.T) {
+			expected, err := FromString(policy)
+			require.NoError(t, err)
+
+			expr, err := ParseExpression(policy)
+			require.NoError(t, err)
+			require.True(t, IsSignatureExpression(expr))
+			actual, err := SignaturePolicyFromExpression(expr)
+			require.NoError(t, err)
+			require.True(t, proto.Equal(expected, actual))
+		})
+	}
+
+	expr, err := ParseExpression("OR('A.member', ANY Readers)")
+	require.NoError(t, err)
+	require.False(t, IsSignatureExpression(expr))
+	_, err = SignaturePolicyFromExpression(expr)
+	require.EqualError(t, err, "ANY Readers cannot be encoded as a signature policy")
+}
+
+func TestPolicyFromString(t *testing.T) {
+	policy, err := PolicyFromString("OR('A.member', 'B.member')")
+	require.NoError(t, err)
+	require.Equal(t, int32(common.Policy_SIGNATURE), policy.Type)
+	sigPolicy := &common.SignaturePolicyEnvelope{}
+	require.NoError(t, proto.Unmarshal(policy.Value, sigPolicy))
+	expected, err := FromString("OR('A.member', 'B.member')")
+	require.NoError(t, err)
+	require.True(t, proto.Equal(expected, sigPolicy))
+
+	policy, err = PolicyFromString("MAJORITY Admins")
+	require.NoError(t, err)
+	require.Equal(t, int32(common.Policy_IMPLICIT_META), policy.Type)
+	imPolicy := &common.ImplicitMetaPolicy{}
+	require.NoError(t, proto.Unmarshal(policy.Value, imPolicy))
+	require.True(t, proto.Equal(&common.ImplicitMetaPolicy{Rule: common.ImplicitMetaPolicy_MAJORITY, SubPolicy: "Admins"}, imPolicy))
+
+	_, err = PolicyFromString("OR('A.member', ANY Readers)")
+	require.EqualError(t, err, "policy 'OR('A.member', ANY Readers)' nests implicit meta expressions within gates and cannot be encoded as a single policy")
+
+	_, err = PolicyFromString("OR(")
+	require.EqualError(t, err, "expected a principal, a gate or an implicit meta rule at position 3 in policy string, got 'end of policy string'")
+}
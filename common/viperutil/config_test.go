@@ -308,6 +308,26 @@ func TestBCCSPDecodeHookOverride(t *testing.T) {
 	require.Equal(t, 1111, tc.BCCSP.SW.Security)
 }
 
+func TestBCCSPDecodeHookKMS(t *testing.T) {
+	yaml := "---\nBCCSP:\n  Default: KMS\n  KMS:\n    Security: 256\n    Hash: SHA2\n    Address: localhost:7070\n    Timeout: 5s\n    TLS:\n      Enabled: true\n"
+
+	config := New()
+	config.SetConfigName(testConfigName)
+	err := config.ReadConfig(strings.NewReader(yaml))
+	require.NoError(t, err, "error reading config")
+
+	var tc struct {
+		BCCSP *factory.FactoryOpts
+	}
+	err = config.EnhancedExactUnmarshal(&tc)
+	require.NoError(t, err, "failed to unmarshal")
+	require.NotNil(t, tc.BCCSP)
+	require.NotNil(t, tc.BCCSP.KMS)
+	require.Equal(t, "localhost:7070", tc.BCCSP.KMS.Address)
+	require.Equal(t, 5*time.Second, tc.BCCSP.KMS.Timeout)
+	require.True(t, tc.BCCSP.KMS.TLS.Enabled)
+}
+
 func TestDurationDecode(t *testing.T) {
 	tests := []struct {
 		input    string
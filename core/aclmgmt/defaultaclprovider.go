@@ -48,6 +48,7 @@ func newDefaultACLProvider(policyChecker policy.PolicyChecker) defaultACLProvide
 
 	//-------------- _lifecycle --------------
 	d.pResourcePolicyMap[resources.Lifecycle_InstallChaincode] = policy.Admins
+	d.pResourcePolicyMap[resources.Lifecycle_UninstallChaincode] = policy.Admins
 	d.pResourcePolicyMap[resources.Lifecycle_QueryInstalledChaincode] = policy.Admins
 	d.pResourcePolicyMap[resources.Lifecycle_GetInstalledChaincodePackage] = policy.Admins
 	d.pResourcePolicyMap[resources.Lifecycle_QueryInstalledChaincodes] = policy.Admins
@@ -63,6 +64,7 @@ func newDefaultACLProvider(policyChecker policy.PolicyChecker) defaultACLProvide
 	d.pResourcePolicyMap[resources.Snapshot_submitrequest] = policy.Admins
 	d.pResourcePolicyMap[resources.Snapshot_cancelrequest] = policy.Admins
 	d.pResourcePolicyMap[resources.Snapshot_listpending] = policy.Admins
+	d.pResourcePolicyMap[resources.Snapshot_fetch] = policy.Peers
 
 	//-------------- LSCC --------------
 	//p resources (implemented by the chaincode currently)
@@ -87,6 +89,8 @@ func newDefaultACLProvider(policyChecker policy.PolicyChecker) defaultACLProvide
 	d.cResourcePolicyMap[resources.Qscc_GetBlockByHash] = CHANNELREADERS
 	d.cResourcePolicyMap[resources.Qscc_GetTransactionByID] = CHANNELREADERS
 	d.cResourcePolicyMap[resources.Qscc_GetBlockByTxID] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.Qscc_GetHistoryForKey] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.Qscc_GetTransactionsByBlockRange] = CHANNELREADERS
 
 	//--------------- CSCC resources -----------
 	//p resources (implemented by the chaincode currently)
@@ -111,6 +115,7 @@ func newDefaultACLProvider(policyChecker policy.PolicyChecker) defaultACLProvide
 	// Gateway resources
 	d.cResourcePolicyMap[resources.Gateway_CommitStatus] = CHANNELREADERS
 	d.cResourcePolicyMap[resources.Gateway_ChaincodeEvents] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.Gateway_SubmitAndWait] = CHANNELWRITERS
 
 	return d
 }
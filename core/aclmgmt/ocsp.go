@@ -0,0 +1,82 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package aclmgmt
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/protoutil"
+)
+
+// DeserializerGetter gets the identity deserializer of a channel given its
+// ID, or nil if the peer has not joined the channel
+type DeserializerGetter func(channelID string) msp.IdentityDeserializer
+
+// ocspACLProvider decorates an ACLProvider to also check the revocation
+// status of the identities granted access to channel resources
+type ocspACLProvider struct {
+	ACLProvider
+	checker      *msp.OCSPChecker
+	deserializer DeserializerGetter
+}
+
+// NewOCSPACLProvider returns an ACLProvider which, once the given provider
+// grants access to a channel resource, checks the revocation status of the
+// requesting identities against the OCSP responders of the checker. The
+// revocation status depends on the time of the check and on the responders
+// reached, so the returned provider must only guard the entry points of the
+// peer, such as the endorser, the gateway and the deliver service, and never
+// the validation of transactions. Channelless resources are checked against
+// the local MSP, which is expected to have OCSP enabled itself.
+func NewOCSPACLProvider(provider ACLProvider, checker *msp.OCSPChecker, dg DeserializerGetter) ACLProvider {
+	return &ocspACLProvider{
+		ACLProvider:  provider,
+		checker:      checker,
+		deserializer: dg,
+	}
+}
+
+// CheckACL implements the ACLProvider interface function
+func (p *ocspACLProvider) CheckACL(resName string, channelID string, idinfo interface{}) error {
+	if err := p.ACLProvider.CheckACL(resName, channelID, idinfo); err != nil {
+		return err
+	}
+	if channelID == "" {
+		// checked against the local MSP
+		return nil
+	}
+
+	var sd []*protoutil.SignedData
+	if signedData, ok := idinfo.([]*protoutil.SignedData); ok {
+		sd = signedData
+	} else {
+		var err error
+		sd, err = signedDataFor(resName, idinfo)
+		if err != nil {
+			return err
+		}
+	}
+
+	deserializer := p.deserializer(channelID)
+	if deserializer == nil {
+		return fmt.Errorf("no identity deserializer for channel [%s] during check of revocation status for resource [%s]", channelID, resName)
+	}
+
+	for _, d := range sd {
+		id, err := deserializer.DeserializeIdentity(d.Identity)
+		if err != nil {
+			return fmt.Errorf("failed deserializing identity during check of revocation status for resource [%s]: [%s]", resName, err)
+		}
+		if err := p.checker.CheckIdentity(id); err != nil {
+			aclLogger.Warnw("OCSP check failed", "error", err, "resource", resName, "channel", channelID)
+			return fmt.Errorf("failed checking revocation status for resource [%s]: [%s]", resName, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,100 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package aclmgmt
+
+import (
+	"crypto/x509"
+	"errors"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/aclmgmt/mocks"
+	"github.com/hyperledger/fabric/msp"
+	mspmocks "github.com/hyperledger/fabric/msp/mocks"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/require"
+)
+
+// ocspIdentity is an identity whose certificates cannot be obtained
+type ocspIdentity struct {
+	*mspmocks.MockIdentity
+}
+
+func (*ocspIdentity) OCSPCertificates() (*x509.Certificate, *x509.Certificate, error) {
+	return nil, nil, errors.New("could not validate identity")
+}
+
+func TestOCSPACLProvider(t *testing.T) {
+	signedData := []*protoutil.SignedData{{
+		Data:      []byte("DATA"),
+		Identity:  []byte("IDENTITY"),
+		Signature: []byte("SIGNATURE"),
+	}}
+
+	setup := func(identity msp.Identity, err error) (*mocks.DefaultACLProvider, ACLProvider) {
+		inner := &mocks.DefaultACLProvider{}
+		deserializer := &mspmocks.MockMSP{}
+		deserializer.On("DeserializeIdentity", []byte("IDENTITY")).Return(identity, err)
+		provider := NewOCSPACLProvider(inner, msp.NewOCSPChecker(msp.OCSPConfig{}), func(channelID string) msp.IdentityDeserializer {
+			if channelID != "mychannel" {
+				return nil
+			}
+			return deserializer
+		})
+		return inner, provider
+	}
+
+	t.Run("Granted", func(t *testing.T) {
+		inner, provider := setup(&mspmocks.MockIdentity{}, nil)
+		err := provider.CheckACL("res", "mychannel", signedData)
+		require.NoError(t, err)
+		require.Equal(t, 1, inner.CheckACLCallCount())
+
+		err = provider.CheckACL("res", "mychannel", signedData[0])
+		require.NoError(t, err)
+	})
+
+	t.Run("Denied", func(t *testing.T) {
+		inner, provider := setup(&mspmocks.MockIdentity{}, nil)
+		inner.CheckACLReturns(errors.New("access denied"))
+		err := provider.CheckACL("res", "mychannel", signedData)
+		require.EqualError(t, err, "access denied")
+	})
+
+	t.Run("Revoked", func(t *testing.T) {
+		_, provider := setup(&ocspIdentity{MockIdentity: &mspmocks.MockIdentity{}}, nil)
+		err := provider.CheckACL("res", "mychannel", signedData)
+		require.EqualError(t, err, "failed checking revocation status for resource [res]: [could not validate identity]")
+	})
+
+	t.Run("DeserializationFailure", func(t *testing.T) {
+		_, provider := setup(&mspmocks.MockIdentity{}, errors.New("bad identity"))
+		err := provider.CheckACL("res", "mychannel", signedData)
+		require.EqualError(t, err, "failed deserializing identity during check of revocation status for resource [res]: [bad identity]")
+	})
+
+	t.Run("UnknownChannel", func(t *testing.T) {
+		_, provider := setup(&mspmocks.MockIdentity{}, nil)
+		err := provider.CheckACL("res", "otherchannel", signedData)
+		require.EqualError(t, err, "no identity deserializer for channel [otherchannel] during check of revocation status for resource [res]")
+	})
+
+	t.Run("InvalidIdInfo", func(t *testing.T) {
+		_, provider := setup(&mspmocks.MockIdentity{}, nil)
+		err := provider.CheckACL("res", "mychannel", struct{}{})
+		require.EqualError(t, err, InvalidIdInfo("res").Error())
+	})
+
+	t.Run("NoChannel", func(t *testing.T) {
+		inner, provider := setup(&ocspIdentity{MockIdentity: &mspmocks.MockIdentity{}}, nil)
+		err := provider.CheckACL("res", "", signedData)
+		require.NoError(t, err)
+
+		err = provider.CheckACLNoChannel("res", signedData)
+		require.NoError(t, err)
+		require.Equal(t, 1, inner.CheckACLNoChannelCallCount())
+	})
+}
@@ -97,52 +97,56 @@ func (rp *aclmgmtPolicyProviderImpl) GetPolicyName(resName string) string {
 func (rp *aclmgmtPolicyProviderImpl) CheckACL(polName string, idinfo interface{}) error {
 	aclLogger.Debugf("acl check(%s)", polName)
 
+	sd, err := signedDataFor(polName, idinfo)
+	if err != nil {
+		return err
+	}
+
+	err = rp.pEvaluator.Evaluate(polName, sd)
+	if err != nil {
+		return fmt.Errorf("failed evaluating policy on signed data during check policy [%s]: [%s]", polName, err)
+	}
+
+	return nil
+}
+
+// signedDataFor extracts the signed data from the identity information
+// passed to CheckACL
+func signedDataFor(polName string, idinfo interface{}) ([]*protoutil.SignedData, error) {
 	// we will implement other identifiers. In the end we just need a SignedData
-	var sd []*protoutil.SignedData
 	switch idinfo := idinfo.(type) {
 	case *pb.SignedProposal:
 		signedProp := idinfo
 		proposal, err := protoutil.UnmarshalProposal(signedProp.ProposalBytes)
 		if err != nil {
-			return fmt.Errorf("Failing extracting proposal during check policy with policy [%s]: [%s]", polName, err)
+			return nil, fmt.Errorf("Failing extracting proposal during check policy with policy [%s]: [%s]", polName, err)
 		}
 
 		header, err := protoutil.UnmarshalHeader(proposal.Header)
 		if err != nil {
-			return fmt.Errorf("Failing extracting header during check policy [%s]: [%s]", polName, err)
+			return nil, fmt.Errorf("Failing extracting header during check policy [%s]: [%s]", polName, err)
 		}
 
 		shdr, err := protoutil.UnmarshalSignatureHeader(header.SignatureHeader)
 		if err != nil {
-			return fmt.Errorf("Invalid Proposal's SignatureHeader during check policy [%s]: [%s]", polName, err)
+			return nil, fmt.Errorf("Invalid Proposal's SignatureHeader during check policy [%s]: [%s]", polName, err)
 		}
 
-		sd = []*protoutil.SignedData{{
+		return []*protoutil.SignedData{{
 			Data:      signedProp.ProposalBytes,
 			Identity:  shdr.Creator,
 			Signature: signedProp.Signature,
-		}}
+		}}, nil
 
 	case *common.Envelope:
-		var err error
-		sd, err = protoutil.EnvelopeAsSignedData(idinfo)
-		if err != nil {
-			return err
-		}
+		return protoutil.EnvelopeAsSignedData(idinfo)
 
 	case *protoutil.SignedData:
-		sd = []*protoutil.SignedData{idinfo}
+		return []*protoutil.SignedData{idinfo}, nil
 
 	default:
-		return InvalidIdInfo(polName)
+		return nil, InvalidIdInfo(polName)
 	}
-
-	err := rp.pEvaluator.Evaluate(polName, sd)
-	if err != nil {
-		return fmt.Errorf("failed evaluating policy on signed data during check policy [%s]: [%s]", polName, err)
-	}
-
-	return nil
 }
 
 //-------- resource provider - entry point API used by aclmgmtimpl for doing resource based ACL ----------
@@ -13,6 +13,7 @@ package resources
 const (
 	// _lifecycle resources
 	Lifecycle_InstallChaincode                   = "_lifecycle/InstallChaincode"
+	Lifecycle_UninstallChaincode                 = "_lifecycle/UninstallChaincode"
 	Lifecycle_QueryInstalledChaincode            = "_lifecycle/QueryInstalledChaincode"
 	Lifecycle_GetInstalledChaincodePackage       = "_lifecycle/GetInstalledChaincodePackage"
 	Lifecycle_QueryInstalledChaincodes           = "_lifecycle/QueryInstalledChaincodes"
@@ -27,6 +28,7 @@ const (
 	Snapshot_submitrequest = "snapshot/submitrequest"
 	Snapshot_cancelrequest = "snapshot/cancelrequest"
 	Snapshot_listpending   = "snapshot/listpending"
+	Snapshot_fetch         = "snapshot/fetch"
 
 	// Lscc resources
 	Lscc_Install                   = "lscc/Install"
@@ -40,11 +42,13 @@ const (
 	Lscc_GetCollectionsConfig      = "lscc/GetCollectionsConfig"
 
 	// Qscc resources
-	Qscc_GetChainInfo       = "qscc/GetChainInfo"
-	Qscc_GetBlockByNumber   = "qscc/GetBlockByNumber"
-	Qscc_GetBlockByHash     = "qscc/GetBlockByHash"
-	Qscc_GetTransactionByID = "qscc/GetTransactionByID"
-	Qscc_GetBlockByTxID     = "qscc/GetBlockByTxID"
+	Qscc_GetChainInfo                = "qscc/GetChainInfo"
+	Qscc_GetBlockByNumber            = "qscc/GetBlockByNumber"
+	Qscc_GetBlockByHash              = "qscc/GetBlockByHash"
+	Qscc_GetTransactionByID          = "qscc/GetTransactionByID"
+	Qscc_GetBlockByTxID              = "qscc/GetBlockByTxID"
+	Qscc_GetHistoryForKey            = "qscc/GetHistoryForKey"
+	Qscc_GetTransactionsByBlockRange = "qscc/GetTransactionsByBlockRange"
 
 	// Cscc resources
 	Cscc_JoinChain            = "cscc/JoinChain"
@@ -65,4 +69,5 @@ const (
 	// Gateway resources
 	Gateway_CommitStatus    = "gateway/CommitStatus"
 	Gateway_ChaincodeEvents = "gateway/ChaincodeEvents"
+	Gateway_SubmitAndWait   = "gateway/SubmitAndWait"
 )
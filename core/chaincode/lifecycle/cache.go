@@ -259,6 +259,34 @@ func (c *Cache) handleChaincodeInstalledWhileLocked(initializing bool, md *persi
 	}
 }
 
+// HandleChaincodeUninstalled should be invoked whenever a chaincode is uninstalled
+func (c *Cache) HandleChaincodeUninstalled(packageID string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for hashOfCCHash, localChaincode := range c.localChaincodes {
+		if localChaincode.Info == nil || localChaincode.Info.PackageID != packageID {
+			continue
+		}
+
+		localChaincode.Info = nil
+		if len(localChaincode.References) == 0 {
+			delete(c.localChaincodes, hashOfCCHash)
+			return
+		}
+
+		for channelID, channelCache := range localChaincode.References {
+			for chaincodeName, cachedChaincode := range channelCache {
+				cachedChaincode.InstallInfo = nil
+				logger.Infof("Uninstalled chaincode with package ID '%s' no longer available on channel %s for chaincode definition %s:%s", packageID, channelID, chaincodeName, cachedChaincode.Definition.EndorsementInfo.Version)
+			}
+		}
+		c.handleMetadataUpdates(localChaincode)
+
+		return
+	}
+}
+
 // HandleStateUpdates is required to implement the ledger state listener interface.  It applies
 // any state updates to the cache.
 func (c *Cache) HandleStateUpdates(trigger *ledger.StateUpdateTrigger) error {
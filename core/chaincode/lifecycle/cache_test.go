@@ -337,6 +337,69 @@ var _ = Describe("Cache", func() {
 		})
 	})
 
+	Describe("HandleChaincodeUninstalled", func() {
+		var installedHash string
+
+		BeforeEach(func() {
+			installedHash = string(util.ComputeSHA256(protoutil.MarshalOrPanic(&lb.StateData{
+				Type: &lb.StateData_String_{String_: "packageID"},
+			})))
+			channelCache.Chaincodes["chaincode-name"].InstallInfo = localChaincodes[installedHash].Info
+		})
+
+		It("no longer lists the chaincode as installed", func() {
+			c.HandleChaincodeUninstalled("packageID")
+			Expect(c.ListInstalledChaincodes()).To(BeEmpty())
+			_, err := c.GetInstalledChaincode("packageID")
+			Expect(err).To(MatchError("could not find chaincode with package id 'packageID'"))
+		})
+
+		It("removes the install info from the referencing chaincode definitions", func() {
+			c.HandleChaincodeUninstalled("packageID")
+			Expect(localChaincodes).To(HaveKey(installedHash))
+			Expect(channelCache.Chaincodes["chaincode-name"].InstallInfo).To(BeNil())
+
+			localInfo, err := c.ChaincodeInfo("channel-id", "chaincode-name")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(localInfo.InstallInfo).To(BeNil())
+		})
+
+		It("updates the metadata of the channels referencing the chaincode", func() {
+			c.HandleChaincodeUninstalled("packageID")
+			Expect(fakeMetadataHandler.UpdateMetadataCallCount()).To(Equal(1))
+			channel, metadata := fakeMetadataHandler.UpdateMetadataArgsForCall(0)
+			Expect(channel).To(Equal("channel-id"))
+			Expect(metadata[0].Name).To(Equal("chaincode-name"))
+			Expect(metadata[0].Installed).To(BeFalse())
+		})
+
+		Context("when the chaincode is not referenced", func() {
+			BeforeEach(func() {
+				c.HandleChaincodeInstalled(&persistence.ChaincodePackageMetadata{
+					Type:  "cc-type",
+					Path:  "cc-path",
+					Label: "other-label",
+				}, "other-packageID")
+				Expect(c.ListInstalledChaincodes()).To(HaveLen(2))
+			})
+
+			It("forgets the chaincode", func() {
+				c.HandleChaincodeUninstalled("other-packageID")
+				Expect(localChaincodes).To(HaveLen(2))
+				Expect(c.ListInstalledChaincodes()).To(HaveLen(1))
+			})
+		})
+
+		Context("when the chaincode is not installed", func() {
+			It("leaves the cache untouched", func() {
+				c.HandleChaincodeUninstalled("notinstalled-packageID")
+				Expect(localChaincodes).To(HaveLen(2))
+				Expect(c.ListInstalledChaincodes()).To(HaveLen(1))
+				Expect(fakeMetadataHandler.UpdateMetadataCallCount()).To(Equal(0))
+			})
+		})
+	})
+
 	Describe("InitializeLocalChaincodes", func() {
 		It("loads the already installed chaincodes into the cache", func() {
 			Expect(channelCache.Chaincodes["chaincode-name"].InstallInfo).To(BeNil())
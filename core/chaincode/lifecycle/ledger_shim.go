@@ -180,6 +180,26 @@ func (pqes *PrivateQueryExecutorShim) CollectionName() string {
 	return pqes.Collection
 }
 
+// CollectionQueryExecutorShim implements the ReadableState and RangeableState interfaces
+// for the keys in a collection based on an underlying ledger.QueryExecutor
+type CollectionQueryExecutorShim struct {
+	Namespace     string
+	Collection    string
+	QueryExecutor ledger.QueryExecutor
+}
+
+func (cqes *CollectionQueryExecutorShim) GetState(key string) ([]byte, error) {
+	return cqes.QueryExecutor.GetPrivateData(cqes.Namespace, cqes.Collection, key)
+}
+
+func (cqes *CollectionQueryExecutorShim) GetStateRange(prefix string) (map[string][]byte, error) {
+	itr, err := cqes.QueryExecutor.GetPrivateDataRangeScanIterator(cqes.Namespace, cqes.Collection, prefix, prefix+"\x7f")
+	if err != nil {
+		return nil, errors.WithMessage(err, "could not get state iterator")
+	}
+	return StateIteratorToMap(&ResultsIteratorShim{ResultsIterator: itr})
+}
+
 // DummyQueryExecutorShim implements the ReadableState interface. It is
 // used to ensure channel-less system chaincode calls don't panic and return
 // and error when an invalid operation is attempted (i.e. an InstallChaincode
@@ -9,6 +9,9 @@ package lifecycle
 import (
 	"bytes"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
 
 	cb "github.com/hyperledger/fabric-protos-go/common"
@@ -21,6 +24,7 @@ import (
 	"github.com/hyperledger/fabric/core/chaincode/implicitcollection"
 	"github.com/hyperledger/fabric/core/chaincode/persistence"
 	"github.com/hyperledger/fabric/core/container"
+	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/protoutil"
 
 	"github.com/golang/protobuf/proto"
@@ -197,6 +201,7 @@ func (cd *ChaincodeDefinition) String() string {
 
 type ChaincodeBuilder interface {
 	Build(ccid string) error
+	Purge(ccid string) error
 }
 
 // ChaincodeStore provides a way to persist chaincodes
@@ -214,6 +219,7 @@ type PackageParser interface {
 //go:generate counterfeiter -o mock/install_listener.go --fake-name InstallListener . InstallListener
 type InstallListener interface {
 	HandleChaincodeInstalled(md *persistence.ChaincodePackageMetadata, packageID string)
+	HandleChaincodeUninstalled(packageID string)
 }
 
 //go:generate counterfeiter -o mock/installed_chaincodes_lister.go --fake-name InstalledChaincodesLister . InstalledChaincodesLister
@@ -222,6 +228,15 @@ type InstalledChaincodesLister interface {
 	GetInstalledChaincode(packageID string) (*chaincode.InstalledChaincode, error)
 }
 
+//go:generate counterfeiter -o mock/channel_ledgers.go --fake-name ChannelLedgers . ChannelLedgers
+
+// ChannelLedgers provides read access to the ledgers of the channels the peer
+// has joined.
+type ChannelLedgers interface {
+	ChannelIDs() []string
+	NewQueryExecutor(channelID string) (ledger.QueryExecutor, error)
+}
+
 // Resources stores the common functions needed by all components of the lifecycle
 // by the SCC as well as internally.  It also has some utility methods attached to it
 // for querying the lifecycle definitions.
@@ -308,6 +323,8 @@ type ExternalFunctions struct {
 	InstalledChaincodesLister InstalledChaincodesLister
 	ChaincodeBuilder          ChaincodeBuilder
 	BuildRegistry             *container.BuildRegistry
+	ChannelLedgers            ChannelLedgers
+	OrgMSPID                  string
 	mutex                     sync.Mutex
 	BuildLocks                map[string]*sync.Mutex
 	concurrentInstalls        uint32
@@ -735,6 +752,169 @@ func (ef *ExternalFunctions) InstallChaincode(chaincodeInstallPackage []byte) (*
 	}, nil
 }
 
+// UninstallChaincode removes the chaincode with the given package ID from the
+// peer's chaincode store, along with the artifacts built for it.  Unless
+// forced, it refuses to remove a package which is referenced by the chaincode
+// definitions of the channels the peer has joined, or by the definitions our
+// org approved for a sequence which is not committed yet.
+func (ef *ExternalFunctions) UninstallChaincode(packageID string, force bool) (*chaincode.InstalledChaincode, error) {
+	// the references are checked under the build lock so that the package
+	// cannot be installed again concurrently
+	buildLock, cleanupBuildLocks := ef.getBuildLock(packageID)
+	defer cleanupBuildLocks()
+
+	buildLock.Lock()
+	defer buildLock.Unlock()
+
+	installedCC, err := ef.InstalledChaincodesLister.GetInstalledChaincode(packageID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(installedCC.References) != 0 {
+		if !force {
+			return nil, errors.Errorf("chaincode package '%s' is referenced by chaincode definitions on channels: %s", packageID, formatReferences(installedCC.References))
+		}
+		logger.Warningf("Uninstalling chaincode package '%s' which is referenced by chaincode definitions on channels: %s", packageID, formatReferences(installedCC.References))
+	}
+
+	approvals, err := ef.uncommittedApprovals(packageID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(approvals) != 0 {
+		if !force {
+			return nil, errors.Errorf("chaincode package '%s' is referenced by uncommitted chaincode definitions approved by org '%s' on channels: %s", packageID, ef.OrgMSPID, formatReferences(approvals))
+		}
+		logger.Warningf("Uninstalling chaincode package '%s' which is referenced by uncommitted chaincode definitions approved by org '%s' on channels: %s", packageID, ef.OrgMSPID, formatReferences(approvals))
+	}
+
+	if err := ef.ChaincodeBuilder.Purge(packageID); err != nil {
+		return nil, errors.WithMessage(err, "could not purge chaincode build artifacts")
+	}
+
+	if err := ef.Resources.ChaincodeStore.Delete(packageID); err != nil {
+		return nil, errors.WithMessage(err, "could not delete cc install package")
+	}
+
+	ef.BuildRegistry.RemoveBuildStatus(packageID)
+
+	if ef.InstallListener != nil {
+		ef.InstallListener.HandleChaincodeUninstalled(packageID)
+	}
+
+	logger.Infof("Successfully uninstalled chaincode with package ID '%s'", packageID)
+
+	return installedCC, nil
+}
+
+// uncommittedApprovals returns, for each channel the peer has joined, the
+// chaincode definitions our org approved with the given package ID for a
+// sequence which is not committed yet.  The approvals are read from the
+// implicit collection of our org.
+func (ef *ExternalFunctions) uncommittedApprovals(packageID string) (map[string][]*chaincode.Metadata, error) {
+	approvals := map[string][]*chaincode.Metadata{}
+	for _, channelID := range ef.ChannelLedgers.ChannelIDs() {
+		metadata, err := ef.uncommittedApprovalsOnChannel(channelID, packageID)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "could not query the chaincode definitions approved by org '%s' on channel '%s'", ef.OrgMSPID, channelID)
+		}
+		if len(metadata) != 0 {
+			approvals[channelID] = metadata
+		}
+	}
+
+	return approvals, nil
+}
+
+func (ef *ExternalFunctions) uncommittedApprovalsOnChannel(channelID, packageID string) ([]*chaincode.Metadata, error) {
+	qe, err := ef.ChannelLedgers.NewQueryExecutor(channelID)
+	if err != nil {
+		return nil, err
+	}
+	defer qe.Done()
+
+	publicState := &SimpleQueryExecutorShim{
+		Namespace:           LifecycleNamespace,
+		SimpleQueryExecutor: qe,
+	}
+
+	orgState := &CollectionQueryExecutorShim{
+		Namespace:     LifecycleNamespace,
+		Collection:    implicitcollection.NameForOrg(ef.OrgMSPID),
+		QueryExecutor: qe,
+	}
+
+	sources, err := ef.Resources.Serializer.DeserializeAllMetadata(ChaincodeSourcesName, orgState)
+	if err != nil {
+		return nil, err
+	}
+
+	var approvals []*chaincode.Metadata
+	for privateName, metadata := range sources {
+		if metadata.Datatype != ChaincodeLocalPackageType {
+			continue
+		}
+
+		i := strings.LastIndex(privateName, "#")
+		if i == -1 {
+			continue
+		}
+		name := privateName[:i]
+		sequence, err := strconv.ParseInt(privateName[i+1:], 10, 64)
+		if err != nil {
+			continue
+		}
+
+		currentSequence, err := ef.Resources.Serializer.DeserializeFieldAsInt64(NamespacesName, name, "Sequence", publicState)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "could not get current sequence for chaincode '%s'", name)
+		}
+		if sequence <= currentSequence {
+			continue
+		}
+
+		ccLocalPackage := &ChaincodeLocalPackage{}
+		if err := ef.Resources.Serializer.Deserialize(ChaincodeSourcesName, privateName, metadata, ccLocalPackage, orgState); err != nil {
+			return nil, errors.WithMessagef(err, "could not deserialize chaincode package for %s", privateName)
+		}
+		if ccLocalPackage.PackageID != packageID {
+			continue
+		}
+
+		approved, err := ef.QueryApprovedChaincodeDefinition(channelID, name, sequence, publicState, orgState)
+		if err != nil {
+			return nil, err
+		}
+
+		approvals = append(approvals, &chaincode.Metadata{
+			Name:    name,
+			Version: approved.EndorsementInfo.Version,
+		})
+	}
+
+	return approvals, nil
+}
+
+// formatReferences renders the chaincode definitions referencing a package as
+// a list of channels, each followed by the name and version of its chaincodes,
+// in lexical order.
+func formatReferences(references map[string][]*chaincode.Metadata) string {
+	channels := make([]string, 0, len(references))
+	for channel, metadata := range references {
+		chaincodes := make([]string, len(metadata))
+		for i, md := range metadata {
+			chaincodes[i] = fmt.Sprintf("%s:%s", md.Name, md.Version)
+		}
+		sort.Strings(chaincodes)
+		channels = append(channels, fmt.Sprintf("%s (%s)", channel, strings.Join(chaincodes, ", ")))
+	}
+	sort.Strings(channels)
+
+	return strings.Join(channels, ", ")
+}
+
 func (ef *ExternalFunctions) getBuildLock(packageID string) (*sync.Mutex, func()) {
 	ef.mutex.Lock()
 	defer ef.mutex.Unlock()
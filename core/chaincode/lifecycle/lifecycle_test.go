@@ -11,14 +11,18 @@ import (
 
 	"github.com/golang/protobuf/proto"
 	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/ledger/queryresult"
 	pb "github.com/hyperledger/fabric-protos-go/peer"
 	lb "github.com/hyperledger/fabric-protos-go/peer/lifecycle"
 	"github.com/hyperledger/fabric/common/chaincode"
 	"github.com/hyperledger/fabric/common/channelconfig"
+	commonledger "github.com/hyperledger/fabric/common/ledger"
 	"github.com/hyperledger/fabric/core/chaincode/lifecycle"
 	"github.com/hyperledger/fabric/core/chaincode/lifecycle/mock"
 	"github.com/hyperledger/fabric/core/chaincode/persistence"
 	"github.com/hyperledger/fabric/core/container"
+	"github.com/hyperledger/fabric/core/ledger"
+	ledgermock "github.com/hyperledger/fabric/core/ledger/mock"
 	"github.com/hyperledger/fabric/protoutil"
 	"github.com/pkg/errors"
 
@@ -273,6 +277,7 @@ var _ = Describe("ExternalFunctions", func() {
 		fakeParser              *mock.PackageParser
 		fakeListener            *mock.InstallListener
 		fakeLister              *mock.InstalledChaincodesLister
+		fakeChannelLedgers      *mock.ChannelLedgers
 		fakeChannelConfigSource *mock.ChannelConfigSource
 		fakeChannelConfig       *mock.ChannelConfig
 		fakeApplicationConfig   *mock.ApplicationConfig
@@ -286,6 +291,7 @@ var _ = Describe("ExternalFunctions", func() {
 		fakeParser = &mock.PackageParser{}
 		fakeListener = &mock.InstallListener{}
 		fakeLister = &mock.InstalledChaincodesLister{}
+		fakeChannelLedgers = &mock.ChannelLedgers{}
 		fakeChannelConfigSource = &mock.ChannelConfigSource{}
 		fakeChannelConfig = &mock.ChannelConfig{}
 		fakeChannelConfigSource.GetStableChannelConfigReturns(fakeChannelConfig)
@@ -316,6 +322,8 @@ var _ = Describe("ExternalFunctions", func() {
 			InstalledChaincodesLister: fakeLister,
 			ChaincodeBuilder:          fakeChaincodeBuilder,
 			BuildRegistry:             &container.BuildRegistry{},
+			ChannelLedgers:            fakeChannelLedgers,
+			OrgMSPID:                  "fake-mspid",
 		}
 	})
 
@@ -448,6 +456,245 @@ var _ = Describe("ExternalFunctions", func() {
 		})
 	})
 
+	Describe("UninstallChaincode", func() {
+		BeforeEach(func() {
+			fakeLister.GetInstalledChaincodeReturns(&chaincode.InstalledChaincode{
+				Label:     "cc-label",
+				PackageID: "fake-hash",
+			}, nil)
+		})
+
+		It("removes the chaincode and its build artifacts", func() {
+			cc, err := ef.UninstallChaincode("fake-hash", false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cc).To(Equal(&chaincode.InstalledChaincode{
+				PackageID: "fake-hash",
+				Label:     "cc-label",
+			}))
+
+			Expect(fakeLister.GetInstalledChaincodeCallCount()).To(Equal(1))
+			Expect(fakeLister.GetInstalledChaincodeArgsForCall(0)).To(Equal("fake-hash"))
+
+			Expect(fakeChaincodeBuilder.PurgeCallCount()).To(Equal(1))
+			Expect(fakeChaincodeBuilder.PurgeArgsForCall(0)).To(Equal("fake-hash"))
+
+			Expect(fakeCCStore.DeleteCallCount()).To(Equal(1))
+			Expect(fakeCCStore.DeleteArgsForCall(0)).To(Equal("fake-hash"))
+
+			Expect(fakeListener.HandleChaincodeUninstalledCallCount()).To(Equal(1))
+			Expect(fakeListener.HandleChaincodeUninstalledArgsForCall(0)).To(Equal("fake-hash"))
+		})
+
+		When("the chaincode was built", func() {
+			BeforeEach(func() {
+				bs, ok := ef.BuildRegistry.BuildStatus("fake-hash")
+				Expect(ok).To(BeFalse())
+				bs.Notify(nil)
+			})
+
+			It("allows the chaincode to be installed and built again", func() {
+				_, err := ef.UninstallChaincode("fake-hash", false)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, ok := ef.BuildRegistry.BuildStatus("fake-hash")
+				Expect(ok).To(BeFalse())
+			})
+		})
+
+		When("the chaincode is referenced by chaincode definitions", func() {
+			BeforeEach(func() {
+				fakeLister.GetInstalledChaincodeReturns(&chaincode.InstalledChaincode{
+					Label:     "cc-label",
+					PackageID: "fake-hash",
+					References: map[string][]*chaincode.Metadata{
+						"test-channel": {
+							{Name: "test-chaincode", Version: "test-version"},
+							{Name: "hello-chaincode", Version: "hello-version"},
+						},
+						"another-channel": {
+							{Name: "another-chaincode", Version: "another-version"},
+						},
+					},
+				}, nil)
+			})
+
+			It("refuses to remove the chaincode", func() {
+				cc, err := ef.UninstallChaincode("fake-hash", false)
+				Expect(cc).To(BeNil())
+				Expect(err).To(MatchError("chaincode package 'fake-hash' is referenced by chaincode definitions on channels: another-channel (another-chaincode:another-version), test-channel (hello-chaincode:hello-version, test-chaincode:test-version)"))
+
+				Expect(fakeChaincodeBuilder.PurgeCallCount()).To(Equal(0))
+				Expect(fakeCCStore.DeleteCallCount()).To(Equal(0))
+				Expect(fakeListener.HandleChaincodeUninstalledCallCount()).To(Equal(0))
+			})
+
+			Context("when the removal is forced", func() {
+				It("removes the chaincode", func() {
+					cc, err := ef.UninstallChaincode("fake-hash", true)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(cc.PackageID).To(Equal("fake-hash"))
+
+					Expect(fakeChaincodeBuilder.PurgeCallCount()).To(Equal(1))
+					Expect(fakeCCStore.DeleteCallCount()).To(Equal(1))
+					Expect(fakeListener.HandleChaincodeUninstalledCallCount()).To(Equal(1))
+				})
+			})
+		})
+
+		When("the chaincode is referenced by uncommitted definitions approved by the org", func() {
+			var (
+				fakeQueryExecutor *ledgermock.QueryExecutor
+				fakePublicState   MapLedgerShim
+				fakeOrgState      MapLedgerShim
+			)
+
+			BeforeEach(func() {
+				fakePublicState = MapLedgerShim(map[string][]byte{})
+				fakeOrgState = MapLedgerShim(map[string][]byte{})
+
+				fakeQueryExecutor = &ledgermock.QueryExecutor{}
+				fakeQueryExecutor.GetStateStub = func(namespace, key string) ([]byte, error) {
+					return fakePublicState.GetState(key)
+				}
+				fakeQueryExecutor.GetPrivateDataStub = func(namespace, collection, key string) ([]byte, error) {
+					Expect(collection).To(Equal("_implicit_org_fake-mspid"))
+					return fakeOrgState.GetState(key)
+				}
+				fakeQueryExecutor.GetPrivateDataRangeScanIteratorStub = func(namespace, collection, begin, end string) (commonledger.ResultsIterator, error) {
+					Expect(collection).To(Equal("_implicit_org_fake-mspid"))
+					fakeResultsIterator := &mock.ResultsIterator{}
+					i := 0
+					for key, value := range fakeOrgState {
+						if key >= begin && key < end {
+							fakeResultsIterator.NextReturnsOnCall(i, &queryresult.KV{
+								Key:   key,
+								Value: value,
+							}, nil)
+							i++
+						}
+					}
+					return fakeResultsIterator, nil
+				}
+
+				fakeChannelLedgers.ChannelIDsReturns([]string{"test-channel", "another-channel"})
+				fakeChannelLedgers.NewQueryExecutorStub = func(channelID string) (ledger.QueryExecutor, error) {
+					if channelID == "test-channel" {
+						return fakeQueryExecutor, nil
+					}
+					emptyQueryExecutor := &ledgermock.QueryExecutor{}
+					emptyQueryExecutor.GetPrivateDataRangeScanIteratorReturns(&mock.ResultsIterator{}, nil)
+					return emptyQueryExecutor, nil
+				}
+
+				err := resources.Serializer.Serialize(lifecycle.NamespacesName, "cc-name", &lifecycle.ChaincodeDefinition{
+					Sequence: 4,
+				}, fakePublicState)
+				Expect(err).NotTo(HaveOccurred())
+
+				for sequence, packageID := range map[int64]string{4: "fake-hash", 5: "fake-hash", 6: "other-hash"} {
+					privateName := fmt.Sprintf("cc-name#%d", sequence)
+					err = resources.Serializer.Serialize(lifecycle.NamespacesName, privateName, &lifecycle.ChaincodeParameters{
+						EndorsementInfo: &lb.ChaincodeEndorsementInfo{Version: fmt.Sprintf("version-%d", sequence)},
+						ValidationInfo:  &lb.ChaincodeValidationInfo{},
+						Collections:     &pb.CollectionConfigPackage{},
+					}, fakeOrgState)
+					Expect(err).NotTo(HaveOccurred())
+					err = resources.Serializer.Serialize(lifecycle.ChaincodeSourcesName, privateName, &lifecycle.ChaincodeLocalPackage{PackageID: packageID}, fakeOrgState)
+					Expect(err).NotTo(HaveOccurred())
+				}
+			})
+
+			It("refuses to remove the chaincode", func() {
+				cc, err := ef.UninstallChaincode("fake-hash", false)
+				Expect(cc).To(BeNil())
+				Expect(err).To(MatchError("chaincode package 'fake-hash' is referenced by uncommitted chaincode definitions approved by org 'fake-mspid' on channels: test-channel (cc-name:version-5)"))
+
+				Expect(fakeChannelLedgers.NewQueryExecutorCallCount()).To(Equal(2))
+				Expect(fakeQueryExecutor.DoneCallCount()).To(Equal(1))
+				Expect(fakeChaincodeBuilder.PurgeCallCount()).To(Equal(0))
+				Expect(fakeCCStore.DeleteCallCount()).To(Equal(0))
+			})
+
+			Context("when the removal is forced", func() {
+				It("removes the chaincode", func() {
+					cc, err := ef.UninstallChaincode("fake-hash", true)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(cc.PackageID).To(Equal("fake-hash"))
+
+					Expect(fakeChaincodeBuilder.PurgeCallCount()).To(Equal(1))
+					Expect(fakeCCStore.DeleteCallCount()).To(Equal(1))
+				})
+			})
+
+			Context("when the approved definitions reference other packages", func() {
+				It("only reports the definitions referencing the package", func() {
+					_, err := ef.UninstallChaincode("other-hash", false)
+					Expect(err).To(MatchError("chaincode package 'other-hash' is referenced by uncommitted chaincode definitions approved by org 'fake-mspid' on channels: test-channel (cc-name:version-6)"))
+				})
+
+				It("removes a package which is not referenced", func() {
+					_, err := ef.UninstallChaincode("unused-hash", false)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(fakeCCStore.DeleteArgsForCall(0)).To(Equal("unused-hash"))
+				})
+			})
+
+			Context("when the ledger cannot be queried", func() {
+				BeforeEach(func() {
+					fakeChannelLedgers.NewQueryExecutorStub = nil
+					fakeChannelLedgers.NewQueryExecutorReturns(nil, fmt.Errorf("fake-ledger-error"))
+				})
+
+				It("wraps and returns the error", func() {
+					cc, err := ef.UninstallChaincode("fake-hash", true)
+					Expect(cc).To(BeNil())
+					Expect(err).To(MatchError("could not query the chaincode definitions approved by org 'fake-mspid' on channel 'test-channel': fake-ledger-error"))
+					Expect(fakeCCStore.DeleteCallCount()).To(Equal(0))
+				})
+			})
+		})
+
+		Context("when the chaincode is not installed", func() {
+			BeforeEach(func() {
+				fakeLister.GetInstalledChaincodeReturns(nil, fmt.Errorf("could not find chaincode with package id 'fake-hash'"))
+			})
+
+			It("returns the error", func() {
+				cc, err := ef.UninstallChaincode("fake-hash", false)
+				Expect(cc).To(BeNil())
+				Expect(err).To(MatchError("could not find chaincode with package id 'fake-hash'"))
+				Expect(fakeCCStore.DeleteCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when purging the build artifacts fails", func() {
+			BeforeEach(func() {
+				fakeChaincodeBuilder.PurgeReturns(fmt.Errorf("fake-purge-error"))
+			})
+
+			It("keeps the chaincode package and returns the wrapped error", func() {
+				cc, err := ef.UninstallChaincode("fake-hash", false)
+				Expect(cc).To(BeNil())
+				Expect(err).To(MatchError("could not purge chaincode build artifacts: fake-purge-error"))
+				Expect(fakeCCStore.DeleteCallCount()).To(Equal(0))
+				Expect(fakeListener.HandleChaincodeUninstalledCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when deleting the chaincode package fails", func() {
+			BeforeEach(func() {
+				fakeCCStore.DeleteReturns(fmt.Errorf("fake-delete-error"))
+			})
+
+			It("wraps and returns the error", func() {
+				cc, err := ef.UninstallChaincode("fake-hash", false)
+				Expect(cc).To(BeNil())
+				Expect(err).To(MatchError("could not delete cc install package: fake-delete-error"))
+				Expect(fakeListener.HandleChaincodeUninstalledCallCount()).To(Equal(0))
+			})
+		})
+	})
+
 	Describe("GetInstalledChaincodePackage", func() {
 		BeforeEach(func() {
 			fakeCCStore.LoadReturns([]byte("code-package"), nil)
@@ -15,6 +15,11 @@ type InstallListener struct {
 		arg1 *persistence.ChaincodePackageMetadata
 		arg2 string
 	}
+	HandleChaincodeUninstalledStub        func(string)
+	handleChaincodeUninstalledMutex       sync.RWMutex
+	handleChaincodeUninstalledArgsForCall []struct {
+		arg1 string
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -51,11 +56,44 @@ func (fake *InstallListener) HandleChaincodeInstalledArgsForCall(i int) (*persis
 	return argsForCall.arg1, argsForCall.arg2
 }
 
+func (fake *InstallListener) HandleChaincodeUninstalled(arg1 string) {
+	fake.handleChaincodeUninstalledMutex.Lock()
+	fake.handleChaincodeUninstalledArgsForCall = append(fake.handleChaincodeUninstalledArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("HandleChaincodeUninstalled", []interface{}{arg1})
+	fake.handleChaincodeUninstalledMutex.Unlock()
+	if fake.HandleChaincodeUninstalledStub != nil {
+		fake.HandleChaincodeUninstalledStub(arg1)
+	}
+}
+
+func (fake *InstallListener) HandleChaincodeUninstalledCallCount() int {
+	fake.handleChaincodeUninstalledMutex.RLock()
+	defer fake.handleChaincodeUninstalledMutex.RUnlock()
+	return len(fake.handleChaincodeUninstalledArgsForCall)
+}
+
+func (fake *InstallListener) HandleChaincodeUninstalledCalls(stub func(string)) {
+	fake.handleChaincodeUninstalledMutex.Lock()
+	defer fake.handleChaincodeUninstalledMutex.Unlock()
+	fake.HandleChaincodeUninstalledStub = stub
+}
+
+func (fake *InstallListener) HandleChaincodeUninstalledArgsForCall(i int) string {
+	fake.handleChaincodeUninstalledMutex.RLock()
+	defer fake.handleChaincodeUninstalledMutex.RUnlock()
+	argsForCall := fake.handleChaincodeUninstalledArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *InstallListener) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.handleChaincodeInstalledMutex.RLock()
 	defer fake.handleChaincodeInstalledMutex.RUnlock()
+	fake.handleChaincodeUninstalledMutex.RLock()
+	defer fake.handleChaincodeUninstalledMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -167,6 +167,20 @@ type SCCFunctions struct {
 		result1 map[string]bool
 		result2 error
 	}
+	UninstallChaincodeStub        func(string, bool) (*chaincode.InstalledChaincode, error)
+	uninstallChaincodeMutex       sync.RWMutex
+	uninstallChaincodeArgsForCall []struct {
+		arg1 string
+		arg2 bool
+	}
+	uninstallChaincodeReturns struct {
+		result1 *chaincode.InstalledChaincode
+		result2 error
+	}
+	uninstallChaincodeReturnsOnCall map[int]struct {
+		result1 *chaincode.InstalledChaincode
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -890,6 +904,70 @@ func (fake *SCCFunctions) QueryOrgApprovalsReturnsOnCall(i int, result1 map[stri
 	}{result1, result2}
 }
 
+func (fake *SCCFunctions) UninstallChaincode(arg1 string, arg2 bool) (*chaincode.InstalledChaincode, error) {
+	fake.uninstallChaincodeMutex.Lock()
+	ret, specificReturn := fake.uninstallChaincodeReturnsOnCall[len(fake.uninstallChaincodeArgsForCall)]
+	fake.uninstallChaincodeArgsForCall = append(fake.uninstallChaincodeArgsForCall, struct {
+		arg1 string
+		arg2 bool
+	}{arg1, arg2})
+	fake.recordInvocation("UninstallChaincode", []interface{}{arg1, arg2})
+	fake.uninstallChaincodeMutex.Unlock()
+	if fake.UninstallChaincodeStub != nil {
+		return fake.UninstallChaincodeStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.uninstallChaincodeReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *SCCFunctions) UninstallChaincodeCallCount() int {
+	fake.uninstallChaincodeMutex.RLock()
+	defer fake.uninstallChaincodeMutex.RUnlock()
+	return len(fake.uninstallChaincodeArgsForCall)
+}
+
+func (fake *SCCFunctions) UninstallChaincodeCalls(stub func(string, bool) (*chaincode.InstalledChaincode, error)) {
+	fake.uninstallChaincodeMutex.Lock()
+	defer fake.uninstallChaincodeMutex.Unlock()
+	fake.UninstallChaincodeStub = stub
+}
+
+func (fake *SCCFunctions) UninstallChaincodeArgsForCall(i int) (string, bool) {
+	fake.uninstallChaincodeMutex.RLock()
+	defer fake.uninstallChaincodeMutex.RUnlock()
+	argsForCall := fake.uninstallChaincodeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *SCCFunctions) UninstallChaincodeReturns(result1 *chaincode.InstalledChaincode, result2 error) {
+	fake.uninstallChaincodeMutex.Lock()
+	defer fake.uninstallChaincodeMutex.Unlock()
+	fake.UninstallChaincodeStub = nil
+	fake.uninstallChaincodeReturns = struct {
+		result1 *chaincode.InstalledChaincode
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *SCCFunctions) UninstallChaincodeReturnsOnCall(i int, result1 *chaincode.InstalledChaincode, result2 error) {
+	fake.uninstallChaincodeMutex.Lock()
+	defer fake.uninstallChaincodeMutex.Unlock()
+	fake.UninstallChaincodeStub = nil
+	if fake.uninstallChaincodeReturnsOnCall == nil {
+		fake.uninstallChaincodeReturnsOnCall = make(map[int]struct {
+			result1 *chaincode.InstalledChaincode
+			result2 error
+		})
+	}
+	fake.uninstallChaincodeReturnsOnCall[i] = struct {
+		result1 *chaincode.InstalledChaincode
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *SCCFunctions) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -915,6 +993,8 @@ func (fake *SCCFunctions) Invocations() map[string][][]interface{} {
 	defer fake.queryNamespaceDefinitionsMutex.RUnlock()
 	fake.queryOrgApprovalsMutex.RLock()
 	defer fake.queryOrgApprovalsMutex.RUnlock()
+	fake.uninstallChaincodeMutex.RLock()
+	defer fake.uninstallChaincodeMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -20,6 +20,7 @@ import (
 	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/core/aclmgmt"
 	"github.com/hyperledger/fabric/core/chaincode/implicitcollection"
+	"github.com/hyperledger/fabric/core/chaincode/lifecycle/lifecycleext"
 	"github.com/hyperledger/fabric/core/chaincode/persistence"
 	"github.com/hyperledger/fabric/core/dispatcher"
 	"github.com/hyperledger/fabric/core/ledger"
@@ -39,6 +40,10 @@ const (
 	// a chaincode
 	InstallChaincodeFuncName = "InstallChaincode"
 
+	// UninstallChaincodeFuncName is the chaincode function name used to
+	// uninstall a chaincode
+	UninstallChaincodeFuncName = "UninstallChaincode"
+
 	// QueryInstalledChaincodeFuncName is the chaincode function name used to
 	// query an installed chaincode
 	QueryInstalledChaincodeFuncName = "QueryInstalledChaincode"
@@ -79,6 +84,9 @@ type SCCFunctions interface {
 	// InstallChaincode persists a chaincode definition to disk
 	InstallChaincode([]byte) (*chaincode.InstalledChaincode, error)
 
+	// UninstallChaincode removes a chaincode package from disk
+	UninstallChaincode(packageID string, force bool) (*chaincode.InstalledChaincode, error)
+
 	// QueryInstalledChaincode returns metadata for the chaincode with the supplied package ID.
 	QueryInstalledChaincode(packageID string) (*chaincode.InstalledChaincode, error)
 
@@ -274,6 +282,25 @@ func (i *Invocation) InstallChaincode(input *lb.InstallChaincodeArgs) (proto.Mes
 	}, nil
 }
 
+// UninstallChaincode is a SCC function that may be dispatched to which routes
+// to the underlying lifecycle implementation.
+func (i *Invocation) UninstallChaincode(input *lifecycleext.UninstallChaincodeArgs) (proto.Message, error) {
+	logger.Debugf("received invocation of UninstallChaincode for install package ID '%s' (force: %t)",
+		input.PackageId,
+		input.Force,
+	)
+
+	uninstalledCC, err := i.SCC.Functions.UninstallChaincode(input.PackageId, input.Force)
+	if err != nil {
+		return nil, err
+	}
+
+	return &lifecycleext.UninstallChaincodeResult{
+		Label:     uninstalledCC.Label,
+		PackageId: uninstalledCC.PackageID,
+	}, nil
+}
+
 // QueryInstalledChaincode is a SCC function that may be dispatched to which
 // routes to the underlying lifecycle implementation.
 func (i *Invocation) QueryInstalledChaincode(input *lb.QueryInstalledChaincodeArgs) (proto.Message, error) {
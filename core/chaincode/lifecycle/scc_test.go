@@ -22,6 +22,7 @@ import (
 	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/policydsl"
 	"github.com/hyperledger/fabric/core/chaincode/lifecycle"
+	"github.com/hyperledger/fabric/core/chaincode/lifecycle/lifecycleext"
 	"github.com/hyperledger/fabric/core/chaincode/lifecycle/mock"
 	"github.com/hyperledger/fabric/core/chaincode/persistence"
 	"github.com/hyperledger/fabric/core/dispatcher"
@@ -199,6 +200,58 @@ var _ = Describe("SCC", func() {
 			})
 		})
 
+		Describe("UninstallChaincode", func() {
+			var (
+				arg          *lifecycleext.UninstallChaincodeArgs
+				marshaledArg []byte
+			)
+
+			BeforeEach(func() {
+				arg = &lifecycleext.UninstallChaincodeArgs{
+					PackageId: "package-id",
+					Force:     true,
+				}
+
+				var err error
+				marshaledArg, err = proto.Marshal(arg)
+				Expect(err).NotTo(HaveOccurred())
+
+				fakeStub.GetArgsReturns([][]byte{[]byte("UninstallChaincode"), marshaledArg})
+
+				fakeSCCFuncs.UninstallChaincodeReturns(&chaincode.InstalledChaincode{
+					Label:     "label",
+					PackageID: "package-id",
+				}, nil)
+			})
+
+			It("passes the arguments to and returns the results from the backing scc function implementation", func() {
+				res := scc.Invoke(fakeStub)
+				Expect(res.Status).To(Equal(int32(200)))
+				payload := &lifecycleext.UninstallChaincodeResult{}
+				err := proto.Unmarshal(res.Payload, payload)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(payload.PackageId).To(Equal("package-id"))
+				Expect(payload.Label).To(Equal("label"))
+
+				Expect(fakeSCCFuncs.UninstallChaincodeCallCount()).To(Equal(1))
+				packageID, force := fakeSCCFuncs.UninstallChaincodeArgsForCall(0)
+				Expect(packageID).To(Equal("package-id"))
+				Expect(force).To(BeTrue())
+			})
+
+			Context("when the underlying function implementation fails", func() {
+				BeforeEach(func() {
+					fakeSCCFuncs.UninstallChaincodeReturns(nil, fmt.Errorf("underlying-error"))
+				})
+
+				It("wraps and returns the error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("failed to invoke backing implementation of 'UninstallChaincode': underlying-error"))
+				})
+			})
+		})
+
 		Describe("QueryInstalledChaincode", func() {
 			var (
 				arg          *lb.QueryInstalledChaincodeArgs
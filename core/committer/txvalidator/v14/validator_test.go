@@ -1539,10 +1539,18 @@ func (m *mockLedger) PendingSnapshotRequests() ([]uint64, error) {
 	return nil, nil
 }
 
+func (m *mockLedger) SnapshotSchedule() (*ledger.SnapshotScheduleInfo, error) {
+	return nil, nil
+}
+
 func (m *mockLedger) CancelSnapshotRequest(height uint64) error {
 	return nil
 }
 
+func (m *mockLedger) ArchiveBlocks(blockNum uint64, archiveDir string) (uint64, error) {
+	return 0, nil
+}
+
 func (m *mockLedger) CommitNotificationsChannel(done <-chan struct{}) (<-chan *ledger.CommitNotification, error) {
 	return nil, nil
 }
@@ -49,6 +49,17 @@ func (br *BuildRegistry) ResetBuildStatus(ccid string) *BuildStatus {
 	return bs
 }
 
+// RemoveBuildStatus forgets the build status of the ccid, so that the next
+// caller of BuildStatus becomes responsible for building it again.  The
+// caller must use external locking to ensure the build status is not removed
+// while a build is in progress.
+func (br *BuildRegistry) RemoveBuildStatus(ccid string) {
+	br.mutex.Lock()
+	defer br.mutex.Unlock()
+
+	delete(br.builds, ccid)
+}
+
 type BuildStatus struct {
 	mutex sync.Mutex
 	doneC chan struct{}
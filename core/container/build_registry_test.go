@@ -45,6 +45,26 @@ var _ = Describe("BuildRegistry", func() {
 		})
 	})
 
+	When("the build status is removed", func() {
+		var initialBS *container.BuildStatus
+
+		BeforeEach(func() {
+			var ok bool
+			initialBS, ok = br.BuildStatus("ccid")
+			Expect(ok).To(BeFalse())
+			initialBS.Notify(nil)
+
+			br.RemoveBuildStatus("ccid")
+		})
+
+		It("returns a new build status", func() {
+			bs, ok := br.BuildStatus("ccid")
+			Expect(ok).To(BeFalse())
+			Expect(bs).NotTo(BeIdenticalTo(initialBS))
+			Expect(bs.Done()).NotTo(BeClosed())
+		})
+	})
+
 	When("a previous build status had an error", func() {
 		BeforeEach(func() {
 			bs, ok := br.BuildStatus("ccid")
@@ -25,6 +25,7 @@ var vmLogger = flogging.MustGetLogger("container")
 // DockerBuilder is what is exposed by the dockercontroller
 type DockerBuilder interface {
 	Build(ccid string, metadata *persistence.ChaincodePackageMetadata, codePackageStream io.Reader) (Instance, error)
+	Purge(ccid string) error
 }
 
 //go:generate counterfeiter -o mock/external_builder.go --fake-name ExternalBuilder . ExternalBuilder
@@ -32,6 +33,7 @@ type DockerBuilder interface {
 // ExternalBuilder is what is exposed by the dockercontroller
 type ExternalBuilder interface {
 	Build(ccid string, metadata []byte, codePackageStream io.Reader) (Instance, error)
+	Purge(ccid string) error
 }
 
 //go:generate counterfeiter -o mock/instance.go --fake-name Instance . Instance
@@ -83,9 +85,9 @@ func (r *Router) getInstance(ccid string) Instance {
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
 
-	// Note, to resolve the locking problem which existed in the previous code, we never delete
-	// references from the map.  In this way, it is safe to release the lock and operate
-	// on the returned reference
+	// Note, to resolve the locking problem which existed in the previous code, we only delete
+	// references from the map when the chaincode is purged.  In this way, it is safe to release
+	// the lock and operate on the returned reference
 	vm, ok := r.containers[ccid]
 	if !ok {
 		return UninitializedInstance{}
@@ -138,6 +140,36 @@ func (r *Router) Build(ccid string) error {
 	return nil
 }
 
+// Purge stops the instance built for the chaincode, if any, and removes the
+// artifacts the builders produced for it, so that a subsequent build starts
+// from scratch.
+func (r *Router) Purge(ccid string) error {
+	r.mutex.Lock()
+	instance, ok := r.containers[ccid]
+	delete(r.containers, ccid)
+	r.mutex.Unlock()
+
+	if ok {
+		if err := instance.Stop(); err != nil {
+			vmLogger.Debugf("stopping chaincode '%s' before purging it: %s", ccid, err)
+		}
+	}
+
+	if r.ExternalBuilder != nil {
+		if err := r.ExternalBuilder.Purge(ccid); err != nil {
+			return errors.WithMessage(err, "external builder failed to purge")
+		}
+	}
+
+	if r.DockerBuilder != nil {
+		if err := r.DockerBuilder.Purge(ccid); err != nil {
+			return errors.WithMessage(err, "docker purge failed")
+		}
+	}
+
+	return nil
+}
+
 func (r *Router) ChaincodeServerInfo(ccid string) (*ccintf.ChaincodeServerInfo, error) {
 	return r.getInstance(ccid).ChaincodeServerInfo()
 }
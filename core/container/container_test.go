@@ -256,5 +256,71 @@ var _ = Describe("Router", func() {
 				})
 			})
 		})
+
+		Describe("Purge", func() {
+			It("stops the instance and forgets it", func() {
+				err := router.Purge("fake-id")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeInstance.StopCallCount()).To(Equal(1))
+
+				err = router.Stop("fake-id")
+				Expect(err).To(MatchError("instance has not yet been built, cannot be stopped"))
+			})
+
+			It("passes through to the external and docker impls", func() {
+				err := router.Purge("fake-id")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeExternalBuilder.PurgeCallCount()).To(Equal(1))
+				Expect(fakeExternalBuilder.PurgeArgsForCall(0)).To(Equal("fake-id"))
+				Expect(fakeDockerBuilder.PurgeCallCount()).To(Equal(1))
+				Expect(fakeDockerBuilder.PurgeArgsForCall(0)).To(Equal("fake-id"))
+			})
+
+			Context("when stopping the instance fails", func() {
+				BeforeEach(func() {
+					fakeInstance.StopReturns(errors.New("fake-stop-error"))
+				})
+
+				It("purges the chaincode anyway", func() {
+					err := router.Purge("fake-id")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(fakeExternalBuilder.PurgeCallCount()).To(Equal(1))
+					Expect(fakeDockerBuilder.PurgeCallCount()).To(Equal(1))
+				})
+			})
+
+			Context("when the chaincode has not yet been built", func() {
+				It("purges the artifacts of a previous build", func() {
+					err := router.Purge("missing-name")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(fakeInstance.StopCallCount()).To(Equal(0))
+					Expect(fakeExternalBuilder.PurgeArgsForCall(0)).To(Equal("missing-name"))
+					Expect(fakeDockerBuilder.PurgeArgsForCall(0)).To(Equal("missing-name"))
+				})
+			})
+
+			Context("when the external builder returns an error", func() {
+				BeforeEach(func() {
+					fakeExternalBuilder.PurgeReturns(errors.New("fake-external-error"))
+				})
+
+				It("wraps and returns the error", func() {
+					err := router.Purge("fake-id")
+					Expect(err).To(MatchError("external builder failed to purge: fake-external-error"))
+					Expect(fakeDockerBuilder.PurgeCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the docker builder returns an error", func() {
+				BeforeEach(func() {
+					fakeDockerBuilder.PurgeReturns(errors.New("fake-docker-error"))
+				})
+
+				It("wraps and returns the error", func() {
+					err := router.Purge("fake-id")
+					Expect(err).To(MatchError("docker purge failed: fake-docker-error"))
+				})
+			})
+		})
 	})
 })
@@ -498,6 +498,39 @@ func TestBuild(t *testing.T) {
 	})
 }
 
+func TestPurge(t *testing.T) {
+	t.Run("when the image exists", func(t *testing.T) {
+		client := &mock.DockerClient{}
+
+		dvm := &DockerVM{Client: client, PeerID: "peer", NetworkID: "dev"}
+		err := dvm.Purge("chaincode-name:chaincode-version")
+		require.NoError(t, err)
+
+		imageName, err := dvm.GetVMNameForDocker("chaincode-name:chaincode-version")
+		require.NoError(t, err)
+		require.Equal(t, 1, client.RemoveImageCallCount())
+		require.Equal(t, imageName, client.RemoveImageArgsForCall(0))
+	})
+
+	t.Run("when the image does not exist", func(t *testing.T) {
+		client := &mock.DockerClient{}
+		client.RemoveImageReturns(docker.ErrNoSuchImage)
+
+		dvm := &DockerVM{Client: client}
+		err := dvm.Purge("chaincode-name:chaincode-version")
+		require.NoError(t, err)
+	})
+
+	t.Run("when removing the image fails", func(t *testing.T) {
+		client := &mock.DockerClient{}
+		client.RemoveImageReturns(errors.New("image-in-use"))
+
+		dvm := &DockerVM{Client: client}
+		err := dvm.Purge("chaincode-name:chaincode-version")
+		require.EqualError(t, err, "docker image removal failed: image-in-use")
+	})
+}
+
 type InMemBuilder struct{}
 
 func (imb InMemBuilder) Build() (io.Reader, error) {
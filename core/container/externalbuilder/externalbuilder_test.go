@@ -211,6 +211,31 @@ var _ = Describe("externalbuilder", func() {
 				})
 			})
 		})
+
+		Describe("Purge", func() {
+			BeforeEach(func() {
+				_, err := detector.Build("fake-package-id", md, codePackage)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("removes the persisted build output", func() {
+				err := detector.Purge("fake-package-id")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(filepath.Join(durablePath, "fake-package-id")).NotTo(BeAnExistingFile())
+
+				i, err := detector.CachedBuild("fake-package-id")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(i).To(BeNil())
+			})
+
+			When("the chaincode was never built", func() {
+				It("succeeds", func() {
+					err := detector.Purge("other-package-id")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(filepath.Join(durablePath, "fake-package-id")).To(BeADirectory())
+				})
+			})
+		})
 	})
 
 	Describe("Builders", func() {
@@ -25,6 +25,17 @@ type DockerBuilder struct {
 		result1 container.Instance
 		result2 error
 	}
+	PurgeStub        func(string) error
+	purgeMutex       sync.RWMutex
+	purgeArgsForCall []struct {
+		arg1 string
+	}
+	purgeReturns struct {
+		result1 error
+	}
+	purgeReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -94,11 +105,73 @@ func (fake *DockerBuilder) BuildReturnsOnCall(i int, result1 container.Instance,
 	}{result1, result2}
 }
 
+func (fake *DockerBuilder) Purge(arg1 string) error {
+	fake.purgeMutex.Lock()
+	ret, specificReturn := fake.purgeReturnsOnCall[len(fake.purgeArgsForCall)]
+	fake.purgeArgsForCall = append(fake.purgeArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("Purge", []interface{}{arg1})
+	fake.purgeMutex.Unlock()
+	if fake.PurgeStub != nil {
+		return fake.PurgeStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.purgeReturns
+	return fakeReturns.result1
+}
+
+func (fake *DockerBuilder) PurgeCallCount() int {
+	fake.purgeMutex.RLock()
+	defer fake.purgeMutex.RUnlock()
+	return len(fake.purgeArgsForCall)
+}
+
+func (fake *DockerBuilder) PurgeCalls(stub func(string) error) {
+	fake.purgeMutex.Lock()
+	defer fake.purgeMutex.Unlock()
+	fake.PurgeStub = stub
+}
+
+func (fake *DockerBuilder) PurgeArgsForCall(i int) string {
+	fake.purgeMutex.RLock()
+	defer fake.purgeMutex.RUnlock()
+	argsForCall := fake.purgeArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *DockerBuilder) PurgeReturns(result1 error) {
+	fake.purgeMutex.Lock()
+	defer fake.purgeMutex.Unlock()
+	fake.PurgeStub = nil
+	fake.purgeReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *DockerBuilder) PurgeReturnsOnCall(i int, result1 error) {
+	fake.purgeMutex.Lock()
+	defer fake.purgeMutex.Unlock()
+	fake.PurgeStub = nil
+	if fake.purgeReturnsOnCall == nil {
+		fake.purgeReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.purgeReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *DockerBuilder) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.buildMutex.RLock()
 	defer fake.buildMutex.RUnlock()
+	fake.purgeMutex.RLock()
+	defer fake.purgeMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -0,0 +1,97 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/pkg/errors"
+)
+
+// ArchiveBlocks archives the block files of the ledger that contain only blocks below the specified block number.
+// The block files are moved under the archiveDir or, if archiveDir is empty, deleted. The ledger must have a
+// completed snapshot at the specified block number or above. It returns the first block available in the ledger.
+func (l *kvLedger) ArchiveBlocks(blockNum uint64, archiveDir string) (uint64, error) {
+	if err := checkSnapshotCoversArchiving(l.config.SnapshotsConfig.RootDir, l.ledgerID, blockNum); err != nil {
+		return 0, err
+	}
+	return l.blockStore.ArchiveBlocks(blockNum, archiveDir)
+}
+
+// ArchiveBlocks archives the block files of a ledger, as kvLedger.ArchiveBlocks does, while the peer is shut down.
+func ArchiveBlocks(config *ledger.Config, ledgerID string, blockNum uint64, archiveDir string) (uint64, error) {
+	fileLock := leveldbhelper.NewFileLock(FileLockPath(config.RootFSPath))
+	if err := fileLock.Lock(); err != nil {
+		return 0, errors.Wrap(err, "as another peer node command is executing,"+
+			" wait for that command to complete its execution or terminate it before retrying")
+	}
+	defer fileLock.Unlock()
+
+	if err := checkSnapshotCoversArchiving(config.SnapshotsConfig.RootDir, ledgerID, blockNum); err != nil {
+		return 0, err
+	}
+
+	blkStoreProvider, err := blkstorage.NewProvider(
+		blkstorage.NewConf(
+			BlockStorePath(config.RootFSPath),
+			maxBlockFileSize,
+		),
+		&blkstorage.IndexConfig{AttrsToIndex: attrsToIndex},
+		&disabled.Provider{},
+	)
+	if err != nil {
+		return 0, err
+	}
+	defer blkStoreProvider.Close()
+
+	exists, err := blkStoreProvider.Exists(ledgerID)
+	if err != nil {
+		return 0, err
+	}
+	if !exists {
+		return 0, errors.Errorf("ledger [%s] does not exist", ledgerID)
+	}
+	blockStore, err := blkStoreProvider.Open(ledgerID)
+	if err != nil {
+		return 0, err
+	}
+	defer blockStore.Shutdown()
+
+	firstAvailableBlockNum, err := blockStore.ArchiveBlocks(blockNum, archiveDir)
+	if err != nil {
+		return 0, err
+	}
+	logger.Infof("The blocks of channel [%s] have been archived, first available block = [%d]", ledgerID, firstAvailableBlockNum)
+	return firstAvailableBlockNum, nil
+}
+
+// checkSnapshotCoversArchiving returns an error unless the ledger has a completed snapshot at the block number
+// or above, so that the ledger can still be reconstructed from the snapshot once the blocks are archived
+func checkSnapshotCoversArchiving(snapshotRootDir, ledgerID string, blockNum uint64) error {
+	lastSnapshotBlockNum, found, err := lastCompletedSnapshotBlockNum(snapshotRootDir, ledgerID)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return errors.Errorf("cannot archive blocks of ledger [%s], no completed snapshot found", ledgerID)
+	}
+	if blockNum > lastSnapshotBlockNum {
+		return errors.Errorf("cannot archive blocks below block [%d] of ledger [%s], the last snapshot is at block [%d]",
+			blockNum, ledgerID, lastSnapshotBlockNum)
+	}
+	return nil
+}
+
+func lastCompletedSnapshotBlockNum(snapshotRootDir, ledgerID string) (uint64, bool, error) {
+	blockNums, err := CompletedSnapshotBlockNums(snapshotRootDir, ledgerID)
+	if err != nil || len(blockNums) == 0 {
+		return 0, false, err
+	}
+	return blockNums[len(blockNums)-1], true, nil
+}
@@ -0,0 +1,92 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchiveBlocks(t *testing.T) {
+	conf := testConfig(t)
+	provider := testutilNewProvider(conf, t, &mock.DeployedChaincodeInfoProvider{})
+
+	blkGenerator, genesisBlk := testutil.NewBlockGenerator(t, "testLedger", false)
+	lgr, err := provider.CreateFromGenesisBlock(genesisBlk)
+	require.NoError(t, err)
+	kvlgr := lgr.(*kvLedger)
+	for i := 1; i <= 3; i++ {
+		blockAndPvtdata := prepareNextBlockForTest(t, kvlgr, blkGenerator, fmt.Sprintf("SimulateForBlk%d", i), map[string]string{"key": "value"}, nil)
+		require.NoError(t, kvlgr.CommitLegacy(blockAndPvtdata, &ledger.CommitOptions{}))
+	}
+
+	_, err = lgr.ArchiveBlocks(2, "")
+	require.EqualError(t, err, "cannot archive blocks of ledger [testLedger], no completed snapshot found")
+
+	require.NoError(t, kvlgr.generateSnapshot())
+	_, err = lgr.ArchiveBlocks(4, "")
+	require.EqualError(t, err, "cannot archive blocks below block [4] of ledger [testLedger], the last snapshot is at block [3]")
+
+	// all the blocks are in the first block file, which is not archived
+	firstAvailableBlockNum, err := lgr.ArchiveBlocks(3, t.TempDir())
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), firstAvailableBlockNum)
+
+	// the offline archiving requires the peer to be shut down
+	_, err = ArchiveBlocks(conf, "testLedger", 3, "")
+	require.ErrorContains(t, err, "as another peer node command is executing")
+	provider.Close()
+
+	firstAvailableBlockNum, err = ArchiveBlocks(conf, "testLedger", 3, "")
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), firstAvailableBlockNum)
+
+	_, err = ArchiveBlocks(conf, "nonExistingLedger", 3, "")
+	require.EqualError(t, err, "cannot archive blocks of ledger [nonExistingLedger], no completed snapshot found")
+
+	require.NoError(t, os.MkdirAll(SnapshotDirForLedgerBlockNum(conf.SnapshotsConfig.RootDir, "nonExistingLedger", 5), 0o755))
+	_, err = ArchiveBlocks(conf, "nonExistingLedger", 3, "")
+	require.EqualError(t, err, "ledger [nonExistingLedger] does not exist")
+}
+
+func TestLastCompletedSnapshotBlockNum(t *testing.T) {
+	snapshotRootDir := t.TempDir()
+	_, found, err := lastCompletedSnapshotBlockNum(snapshotRootDir, "testLedger")
+	require.NoError(t, err)
+	require.False(t, found)
+
+	for _, dir := range []string{"5", "100", "20", "not-a-snapshot"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(SnapshotsDirForLedger(snapshotRootDir, "testLedger"), dir), 0o755))
+	}
+	blockNum, found, err := lastCompletedSnapshotBlockNum(snapshotRootDir, "testLedger")
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, uint64(100), blockNum)
+}
+
+func TestOfflineCommandsWithArchivedBlocks(t *testing.T) {
+	conf := testConfig(t)
+	// a ledger without the first block file has archived blocks
+	ledgerDir := filepath.Join(BlockStorePath(conf.RootFSPath), blkstorage.ChainsDir, "archivedLedger")
+	require.NoError(t, os.MkdirAll(ledgerDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(ledgerDir, "blockfile_000003"), nil, 0o644))
+
+	err := RebuildDBs(conf)
+	require.EqualError(t, err, "cannot rebuild databases because the peer contains channel(s) [archivedLedger] with archived blocks")
+	err = RollbackKVLedger(conf.RootFSPath, "archivedLedger", 1)
+	require.EqualError(t, err, "cannot rollback any channel because the peer contains channel(s) [archivedLedger] with archived blocks")
+	err = ResetAllKVLedgers(conf.RootFSPath)
+	require.EqualError(t, err, "cannot reset channels because the peer contains channel(s) [archivedLedger] with archived blocks")
+}
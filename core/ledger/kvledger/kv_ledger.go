@@ -150,8 +150,10 @@ func newKVLedger(initializer *lgrInitializer) (*kvLedger, error) {
 	}
 	l.isPvtstoreAheadOfBlkstore.Store(isAhead)
 
+	// the chaincode lifecycle events are not available when the ledger is opened
+	// outside of the peer, in which case the statedb indexes are not maintained
 	statedbIndexCreator := initializer.stateDB.GetChaincodeEventListener()
-	if statedbIndexCreator != nil {
+	if statedbIndexCreator != nil && initializer.ccLifecycleEventProvider != nil && cceventmgmt.GetMgr() != nil {
 		logger.Debugf("Register state db for chaincode lifecycle events")
 		err := l.registerStateDBIndexCreatorForChaincodeLifecycleEvents(
 			statedbIndexCreator,
@@ -175,11 +177,10 @@ func newKVLedger(initializer *lgrInitializer) (*kvLedger, error) {
 		ledger:                        l,
 	}
 
+	l.stats = initializer.stats
 	if err := l.initSnapshotMgr(initializer); err != nil {
 		return nil, err
 	}
-
-	l.stats = initializer.stats
 	return l, nil
 }
 
@@ -285,6 +286,7 @@ func (l *kvLedger) initSnapshotMgr(initializer *lgrInitializer) error {
 
 	l.snapshotMgr = &snapshotMgr{
 		snapshotRequestBookkeeper: bookkeeper,
+		schedule:                  l.config.SnapshotsConfig.ScheduleForChannel(l.ledgerID),
 		events:                    make(chan *event),
 		commitProceed:             make(chan struct{}),
 		requestResponses:          make(chan *requestResponse),
@@ -296,6 +298,13 @@ func (l *kvLedger) initSnapshotMgr(initializer *lgrInitializer) error {
 	}
 	lastCommittedBlock := bcInfo.Height - 1
 
+	if schedule := l.snapshotMgr.schedule; schedule != nil && bcInfo.Height != 0 {
+		if err := bookkeeper.scheduleByBlockInterval(schedule.BlockInterval, lastCommittedBlock); err != nil {
+			return err
+		}
+		l.updateSnapshotScheduleStats()
+	}
+
 	// start a goroutine to synchronize commit, snapshot generation, and snapshot submission/cancellation,
 	go l.processSnapshotMgmtEvents(lastCommittedBlock)
 
@@ -89,7 +89,7 @@ func NewProvider(initializer *ledger.Initializer) (pr *Provider, e error) {
 		}
 	}()
 
-	fileLockPath := fileLockPath(initializer.Config.RootFSPath)
+	fileLockPath := FileLockPath(initializer.Config.RootFSPath)
 	fileLock := leveldbhelper.NewFileLock(fileLockPath)
 	if err := fileLock.Lock(); err != nil {
 		return nil, errors.Wrap(err, "as another peer node command is executing,"+
@@ -139,10 +139,18 @@ func (p *Provider) initLedgerIDInventory() error {
 
 func (p *Provider) initBlockStoreProvider() error {
 	indexConfig := &blkstorage.IndexConfig{AttrsToIndex: attrsToIndex}
+	compression := blkstorage.CompressionNone
+	if blockStoreConfig := p.initializer.Config.BlockStoreConfig; blockStoreConfig != nil {
+		var err error
+		if compression, err = blkstorage.ParseCompression(blockStoreConfig.Compression); err != nil {
+			return err
+		}
+	}
 	blkStoreProvider, err := blkstorage.NewProvider(
-		blkstorage.NewConf(
+		blkstorage.NewConfWithCompression(
 			BlockStorePath(p.initializer.Config.RootFSPath),
 			maxBlockFileSize,
+			compression,
 		),
 		indexConfig,
 		p.initializer.MetricsProvider,
@@ -11,7 +11,8 @@ import (
 	"strconv"
 )
 
-func fileLockPath(rootFSPath string) string {
+// FileLockPath returns the absolute path of the file lock held while the ledgers are in use
+func FileLockPath(rootFSPath string) string {
 	return filepath.Join(rootFSPath, "fileLock")
 }
 
@@ -60,6 +61,11 @@ func CompletedSnapshotsPath(snapshotRootDir string) string {
 	return filepath.Join(snapshotRootDir, "completed")
 }
 
+// FetchedSnapshotsPath returns the absolute path that is used for persisting the snapshots fetched from other peers
+func FetchedSnapshotsPath(snapshotRootDir string) string {
+	return filepath.Join(snapshotRootDir, "fetched")
+}
+
 // SnapshotsDirForLedger returns the absolute path of the dir for the snapshots for a specified ledger
 func SnapshotsDirForLedger(snapshotRootDir, ledgerID string) string {
 	return filepath.Join(CompletedSnapshotsPath(snapshotRootDir), ledgerID)
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/validation"
 )
 
@@ -18,6 +19,9 @@ type stats struct {
 	blockAndPvtdataStoreCommitTime metrics.Histogram
 	statedbCommitTime              metrics.Histogram
 	transactionsCount              metrics.Counter
+	snapshotScheduleNextBlock      metrics.Gauge
+	snapshotScheduleNextTime       metrics.Gauge
+	snapshotsPruned                metrics.Counter
 }
 
 func newStats(metricsProvider metrics.Provider) *stats {
@@ -26,6 +30,9 @@ func newStats(metricsProvider metrics.Provider) *stats {
 	stats.blockAndPvtdataStoreCommitTime = metricsProvider.NewHistogram(blockAndPvtdataStoreCommitTimeOpts)
 	stats.statedbCommitTime = metricsProvider.NewHistogram(statedbCommitTimeOpts)
 	stats.transactionsCount = metricsProvider.NewCounter(transactionCountOpts)
+	stats.snapshotScheduleNextBlock = metricsProvider.NewGauge(snapshotScheduleNextBlockOpts)
+	stats.snapshotScheduleNextTime = metricsProvider.NewGauge(snapshotScheduleNextTimeOpts)
+	stats.snapshotsPruned = metricsProvider.NewCounter(snapshotsPrunedOpts)
 	return stats
 }
 
@@ -75,6 +82,19 @@ func (s *ledgerStats) updateTransactionsStats(
 	}
 }
 
+func (s *ledgerStats) updateSnapshotSchedule(info *ledger.SnapshotScheduleInfo) {
+	s.stats.snapshotScheduleNextBlock.With("channel", s.ledgerid).Set(float64(info.NextBlockNumber))
+	var nextTime float64
+	if !info.NextTime.IsZero() {
+		nextTime = float64(info.NextTime.Unix())
+	}
+	s.stats.snapshotScheduleNextTime.With("channel", s.ledgerid).Set(nextTime)
+}
+
+func (s *ledgerStats) updateSnapshotsPruned() {
+	s.stats.snapshotsPruned.With("channel", s.ledgerid).Add(1)
+}
+
 var (
 	blockProcessingTimeOpts = metrics.HistogramOpts{
 		Namespace:    "ledger",
@@ -114,4 +134,31 @@ var (
 		LabelNames:   []string{"channel", "transaction_type", "chaincode", "validation_code"},
 		StatsdFormat: "%{#fqname}.%{channel}.%{transaction_type}.%{chaincode}.%{validation_code}",
 	}
+
+	snapshotScheduleNextBlockOpts = metrics.GaugeOpts{
+		Namespace:    "ledger",
+		Subsystem:    "",
+		Name:         "snapshot_schedule_next_block",
+		Help:         "Block number of the next snapshot scheduled by the block interval.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+
+	snapshotScheduleNextTimeOpts = metrics.GaugeOpts{
+		Namespace:    "ledger",
+		Subsystem:    "",
+		Name:         "snapshot_schedule_next_time",
+		Help:         "Unix time in seconds after which the next snapshot is scheduled by the time interval.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+
+	snapshotsPrunedOpts = metrics.CounterOpts{
+		Namespace:    "ledger",
+		Subsystem:    "",
+		Name:         "snapshots_pruned",
+		Help:         "Number of snapshots removed to retain the number of snapshots set by the snapshot schedule.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
 )
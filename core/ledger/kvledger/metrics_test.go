@@ -141,6 +141,47 @@ func TestStatsBlockCommit(t *testing.T) {
 	)
 }
 
+func TestStatsSnapshotSchedule(t *testing.T) {
+	fakeNextBlockGauge := testutilConstructGauge()
+	fakeNextTimeGauge := testutilConstructGauge()
+	fakeSnapshotsPruned := testutilConstructCounter()
+	fakeProvider := &metricsfakes.Provider{}
+	fakeProvider.NewGaugeStub = func(opts metrics.GaugeOpts) metrics.Gauge {
+		switch opts.Name {
+		case snapshotScheduleNextBlockOpts.Name:
+			return fakeNextBlockGauge
+		case snapshotScheduleNextTimeOpts.Name:
+			return fakeNextTimeGauge
+		default:
+			return testutilConstructGauge()
+		}
+	}
+	fakeProvider.NewHistogramStub = func(opts metrics.HistogramOpts) metrics.Histogram {
+		return testutilConstructHist()
+	}
+	fakeProvider.NewCounterStub = func(opts metrics.CounterOpts) metrics.Counter {
+		if opts.Name == snapshotsPrunedOpts.Name {
+			return fakeSnapshotsPruned
+		}
+		return testutilConstructCounter()
+	}
+
+	ledgerStats := newStats(fakeProvider).ledgerStats("ledger1")
+	ledgerStats.updateSnapshotSchedule(&lgr.SnapshotScheduleInfo{
+		Schedule:        &lgr.SnapshotSchedule{BlockInterval: 10, TimeInterval: time.Hour},
+		NextBlockNumber: 20,
+		NextTime:        time.Unix(5000, 0),
+	})
+	require.Equal(t, []string{"channel", "ledger1"}, fakeNextBlockGauge.WithArgsForCall(0))
+	require.Equal(t, float64(20), fakeNextBlockGauge.SetArgsForCall(0))
+	require.Equal(t, []string{"channel", "ledger1"}, fakeNextTimeGauge.WithArgsForCall(0))
+	require.Equal(t, float64(5000), fakeNextTimeGauge.SetArgsForCall(0))
+
+	ledgerStats.updateSnapshotsPruned()
+	require.Equal(t, []string{"channel", "ledger1"}, fakeSnapshotsPruned.WithArgsForCall(0))
+	require.Equal(t, float64(1), fakeSnapshotsPruned.AddArgsForCall(0))
+}
+
 type testMetricProvider struct {
 	fakeProvider                              *metricsfakes.Provider
 	fakeBlockProcessingTimeHist               *metricsfakes.Histogram
@@ -31,7 +31,7 @@ func ResumeChannel(rootFSPath, ledgerID string) error {
 }
 
 func pauseOrResumeChannel(rootFSPath, ledgerID string, status msgs.Status) error {
-	fileLock := leveldbhelper.NewFileLock(fileLockPath(rootFSPath))
+	fileLock := leveldbhelper.NewFileLock(FileLockPath(rootFSPath))
 	if err := fileLock.Lock(); err != nil {
 		return errors.Wrap(err, "as another peer node command is executing,"+
 			" wait for that command to complete its execution or terminate it before retrying")
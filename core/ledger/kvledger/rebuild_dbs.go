@@ -18,7 +18,7 @@ import (
 // Dropped database will be rebuilt upon server restart
 func RebuildDBs(config *ledger.Config) error {
 	rootFSPath := config.RootFSPath
-	fileLockPath := fileLockPath(rootFSPath)
+	fileLockPath := FileLockPath(rootFSPath)
 	fileLock := leveldbhelper.NewFileLock(fileLockPath)
 	if err := fileLock.Lock(); err != nil {
 		return errors.Wrap(err, "as another peer node command is executing,"+
@@ -34,6 +34,12 @@ func RebuildDBs(config *ledger.Config) error {
 	if len(ledgerIDs) > 0 {
 		return errors.Errorf("cannot rebuild databases because the peer contains channel(s) %s that were bootstrapped from snapshot", ledgerIDs)
 	}
+	if ledgerIDs, err = blkstorage.GetLedgersWithArchivedBlocks(blockstorePath); err != nil {
+		return errors.WithMessage(err, "error while checking if any ledger has archived blocks")
+	}
+	if len(ledgerIDs) > 0 {
+		return errors.Errorf("cannot rebuild databases because the peer contains channel(s) %s with archived blocks", ledgerIDs)
+	}
 
 	if config.StateDBConfig.StateDatabase == ledger.CouchDB {
 		if err := statecouchdb.DropApplicationDBs(config.StateDBConfig.CouchDB); err != nil {
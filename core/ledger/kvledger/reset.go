@@ -14,7 +14,7 @@ import (
 
 // ResetAllKVLedgers resets all ledger to the genesis block.
 func ResetAllKVLedgers(rootFSPath string) error {
-	fileLockPath := fileLockPath(rootFSPath)
+	fileLockPath := FileLockPath(rootFSPath)
 	fileLock := leveldbhelper.NewFileLock(fileLockPath)
 	if err := fileLock.Lock(); err != nil {
 		return errors.Wrap(err, "as another peer node command is executing,"+
@@ -30,6 +30,12 @@ func ResetAllKVLedgers(rootFSPath string) error {
 	if len(ledgerIDs) > 0 {
 		return errors.Errorf("cannot reset channels because the peer contains channel(s) %s that were bootstrapped from snapshot", ledgerIDs)
 	}
+	if ledgerIDs, err = blkstorage.GetLedgersWithArchivedBlocks(blockstorePath); err != nil {
+		return err
+	}
+	if len(ledgerIDs) > 0 {
+		return errors.Errorf("cannot reset channels because the peer contains channel(s) %s with archived blocks", ledgerIDs)
+	}
 
 	logger.Info("Resetting all channel ledgers to genesis block")
 	logger.Infof("Ledger data folder from config = [%s]", rootFSPath)
@@ -14,7 +14,7 @@ import (
 
 // RollbackKVLedger rollbacks a ledger to a specified block number
 func RollbackKVLedger(rootFSPath, ledgerID string, blockNum uint64) error {
-	fileLockPath := fileLockPath(rootFSPath)
+	fileLockPath := FileLockPath(rootFSPath)
 	fileLock := leveldbhelper.NewFileLock(fileLockPath)
 	if err := fileLock.Lock(); err != nil {
 		return errors.Wrap(err, "as another peer node command is executing,"+
@@ -30,6 +30,12 @@ func RollbackKVLedger(rootFSPath, ledgerID string, blockNum uint64) error {
 	if len(ledgerIDs) > 0 {
 		return errors.Errorf("cannot rollback any channel because the peer contains channel(s) %s that were bootstrapped from snapshot", ledgerIDs)
 	}
+	if ledgerIDs, err = blkstorage.GetLedgersWithArchivedBlocks(blockstorePath); err != nil {
+		return errors.WithMessage(err, "error while checking if any ledger has archived blocks")
+	}
+	if len(ledgerIDs) > 0 {
+		return errors.Errorf("cannot rollback any channel because the peer contains channel(s) %s with archived blocks", ledgerIDs)
+	}
 
 	if err := blkstorage.ValidateRollbackParams(blockstorePath, ledgerID, blockNum); err != nil {
 		return err
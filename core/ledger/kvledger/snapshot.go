@@ -367,7 +367,7 @@ func loadSnapshotMetadataJSONs(snapshotDir string) (*SnapshotMetadataJSONs, erro
 }
 
 func verifySnapshot(snapshotDir string, snapshotMetadata *SnapshotMetadata, hashProvider ledger.HashProvider) error {
-	if err := verifyFileHash(
+	if err := VerifySnapshotFileHash(
 		snapshotDir,
 		SnapshotSignableMetadataFileName,
 		snapshotMetadata.SnapshotHashInHex,
@@ -378,14 +378,16 @@ func verifySnapshot(snapshotDir string, snapshotMetadata *SnapshotMetadata, hash
 
 	filesAndHashes := snapshotMetadata.FilesAndHashes
 	for f, h := range filesAndHashes {
-		if err := verifyFileHash(snapshotDir, f, h, hashProvider); err != nil {
+		if err := VerifySnapshotFileHash(snapshotDir, f, h, hashProvider); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func verifyFileHash(dir, file string, expectedHashInHex string, hashProvider ledger.HashProvider) error {
+// VerifySnapshotFileHash verifies that the hash of a snapshot file matches the expected hash,
+// as recorded in the snapshot signable metadata
+func VerifySnapshotFileHash(dir, file string, expectedHashInHex string, hashProvider ledger.HashProvider) error {
 	hashImpl, err := hashProvider.GetHash(snapshotHashOpts)
 	if err != nil {
 		return err
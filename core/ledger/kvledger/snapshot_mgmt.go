@@ -10,10 +10,15 @@ import (
 	"fmt"
 	"math"
 	"os"
+	"sort"
+	"strconv"
 	"sync"
+	"time"
 
 	"github.com/hyperledger/fabric/common/ledger/util"
 	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/internal/fileutil"
 	"github.com/pkg/errors"
 )
 
@@ -38,6 +43,7 @@ const (
 
 type snapshotMgr struct {
 	snapshotRequestBookkeeper *snapshotRequestBookkeeper
+	schedule                  *ledger.SnapshotSchedule
 	events                    chan *event
 	commitProceed             chan struct{}
 	requestResponses          chan *requestResponse
@@ -83,6 +89,35 @@ func (l *kvLedger) PendingSnapshotRequests() ([]uint64, error) {
 	return l.snapshotMgr.snapshotRequestBookkeeper.list()
 }
 
+// SnapshotSchedule returns the periodic snapshot schedule of the ledger, along with the block number and the time
+// of the next snapshots scheduled by the block interval and the time interval respectively.
+func (l *kvLedger) SnapshotSchedule() (*ledger.SnapshotScheduleInfo, error) {
+	schedule := l.snapshotMgr.schedule
+	info := &ledger.SnapshotScheduleInfo{Schedule: schedule}
+	if schedule == nil {
+		return info, nil
+	}
+
+	bookkeeper := l.snapshotMgr.snapshotRequestBookkeeper
+	if schedule.BlockInterval != 0 {
+		nextBlockNum, err := bookkeeper.lastScheduledBlockNum()
+		if err != nil {
+			return nil, err
+		}
+		info.NextBlockNumber = nextBlockNum
+	}
+	if schedule.TimeInterval != 0 {
+		lastScheduledTime, err := bookkeeper.lastScheduledTime()
+		if err != nil {
+			return nil, err
+		}
+		if !lastScheduledTime.IsZero() {
+			info.NextTime = lastScheduledTime.Add(schedule.TimeInterval)
+		}
+	}
+	return info, nil
+}
+
 // processSnapshotMgmtEvents handles each event in the events channel and performs synchronization acorss
 // block commits, snapshot generation, and snapshot request submission/cancellation.
 // It should be started in a separate goroutine when the ledger is created/opened.
@@ -93,7 +128,7 @@ func (l *kvLedger) PendingSnapshotRequests() ([]uint64, error) {
 // - requestResponses: a channel returning the response for snapshot request submission/cancellation.
 // The 5 events are:
 // - commitStart: sent before committing a block
-// - commitDone: sent after a block is committed
+// - commitDone: sent after a block is committed, which also adds the requests due by the periodic snapshot schedule
 // - snapshotDone: sent when a snapshot generation is finished, regardless of success or failure
 // - requestAdd: sent when a snapshot request is submitted
 // - requestCancel: sent when a snapshot request is cancelled
@@ -130,6 +165,7 @@ func (l *kvLedger) processSnapshotMgmtEvents(lastCommittedBlockNumber uint64) {
 		case commitDone:
 			lastCommittedBlockNumber = e.blockNumber
 			committerStatus = idle
+			l.scheduleSnapshots(lastCommittedBlockNumber)
 			if lastCommittedBlockNumber != l.snapshotMgr.snapshotRequestBookkeeper.smallestRequestBlockNum {
 				continue
 			}
@@ -140,6 +176,9 @@ func (l *kvLedger) processSnapshotMgmtEvents(lastCommittedBlockNumber uint64) {
 					logger.Errorw("Failed to generate snapshot", "channelID", l.ledgerID, "lastCommittedBlockNumber", lastCommittedBlockNumber, "error", err)
 				} else {
 					logger.Infow("Generated snapshot", "channelID", l.ledgerID, "lastCommittedBlockNumber", lastCommittedBlockNumber)
+					if err := l.pruneSnapshots(); err != nil {
+						logger.Errorw("Failed to remove snapshots beyond the retained number", "channelID", l.ledgerID, "error", err)
+					}
 				}
 				events <- &event{snapshotDone, lastCommittedBlockNumber}
 			}()
@@ -187,7 +226,7 @@ func (l *kvLedger) processSnapshotMgmtEvents(lastCommittedBlockNumber uint64) {
 				}
 			}
 
-			if err := l.snapshotMgr.snapshotRequestBookkeeper.add(requestedBlockNum); err != nil {
+			if err := l.snapshotMgr.snapshotRequestBookkeeper.add(requestedBlockNum, false); err != nil {
 				requestResponses <- &requestResponse{err}
 				continue
 			}
@@ -200,6 +239,9 @@ func (l *kvLedger) processSnapshotMgmtEvents(lastCommittedBlockNumber uint64) {
 						logger.Errorw("Failed to generate snapshot", "channelID", l.ledgerID, "lastCommittedBlockNumber", lastCommittedBlockNumber, "error", err)
 					} else {
 						logger.Infow("Generated snapshot", "channelID", l.ledgerID, "lastCommittedBlockNumber", lastCommittedBlockNumber)
+						if err := l.pruneSnapshots(); err != nil {
+							logger.Errorw("Failed to remove snapshots beyond the retained number", "channelID", l.ledgerID, "error", err)
+						}
 					}
 					events <- &event{snapshotDone, requestedBlockNum}
 				}()
@@ -212,7 +254,7 @@ func (l *kvLedger) processSnapshotMgmtEvents(lastCommittedBlockNumber uint64) {
 				requestResponses <- &requestResponse{errors.Errorf("cannot cancel the snapshot request because it is under processing")}
 				continue
 			}
-			requestResponses <- &requestResponse{l.snapshotMgr.snapshotRequestBookkeeper.delete(requestedBlockNum)}
+			requestResponses <- &requestResponse{l.snapshotMgr.snapshotRequestBookkeeper.cancel(requestedBlockNum)}
 
 		case snapshotMgrShutdown:
 			return
@@ -235,6 +277,99 @@ func (l *kvLedger) regenrateMissedSnapshot(blockNumber uint64) error {
 	return nil
 }
 
+// scheduleSnapshots adds the snapshot requests due by the periodic snapshot schedule once the given block
+// is committed. A failure is only logged so that it does not hold up the commits and the other snapshot requests.
+func (l *kvLedger) scheduleSnapshots(lastCommittedBlockNum uint64) {
+	schedule := l.snapshotMgr.schedule
+	if schedule == nil {
+		return
+	}
+
+	bookkeeper := l.snapshotMgr.snapshotRequestBookkeeper
+	if err := bookkeeper.scheduleByBlockInterval(schedule.BlockInterval, lastCommittedBlockNum); err != nil {
+		logger.Errorw("Failed to schedule snapshot by block interval", "channelID", l.ledgerID, "lastCommittedBlockNumber", lastCommittedBlockNum, "error", err)
+	}
+	if err := bookkeeper.scheduleByTimeInterval(schedule.TimeInterval, lastCommittedBlockNum, time.Now()); err != nil {
+		logger.Errorw("Failed to schedule snapshot by time interval", "channelID", l.ledgerID, "lastCommittedBlockNumber", lastCommittedBlockNum, "error", err)
+	}
+	l.updateSnapshotScheduleStats()
+}
+
+func (l *kvLedger) updateSnapshotScheduleStats() {
+	info, err := l.SnapshotSchedule()
+	if err != nil {
+		logger.Warnw("Failed to retrieve snapshot schedule", "channelID", l.ledgerID, "error", err)
+		return
+	}
+	l.stats.updateSnapshotSchedule(info)
+}
+
+// pruneSnapshots removes the oldest completed snapshots created by the periodic snapshot schedule beyond the
+// number of snapshots it retains. The snapshots requested by an admin are never removed. As the retained number
+// is at least one, neither is the newest snapshot, which covers the blocks archived by ArchiveBlocks.
+func (l *kvLedger) pruneSnapshots() error {
+	schedule := l.snapshotMgr.schedule
+	if schedule == nil || schedule.Retain == 0 {
+		return nil
+	}
+
+	blockNums, err := CompletedSnapshotBlockNums(l.config.SnapshotsConfig.RootDir, l.ledgerID)
+	if err != nil {
+		return err
+	}
+
+	bookkeeper := l.snapshotMgr.snapshotRequestBookkeeper
+	var scheduledBlockNums []uint64
+	for _, blockNum := range blockNums {
+		scheduled, err := bookkeeper.isScheduledSnapshot(blockNum)
+		if err != nil {
+			return err
+		}
+		if scheduled {
+			scheduledBlockNums = append(scheduledBlockNums, blockNum)
+		}
+	}
+	if uint(len(scheduledBlockNums)) <= schedule.Retain {
+		return nil
+	}
+
+	for _, blockNum := range scheduledBlockNums[:uint(len(scheduledBlockNums))-schedule.Retain] {
+		snapshotDir := SnapshotDirForLedgerBlockNum(l.config.SnapshotsConfig.RootDir, l.ledgerID, blockNum)
+		if err := os.RemoveAll(snapshotDir); err != nil {
+			return errors.Wrapf(err, "error while removing snapshot dir [%s]", snapshotDir)
+		}
+		if err := bookkeeper.untrackScheduledSnapshot(blockNum); err != nil {
+			return err
+		}
+		logger.Infow("Removed snapshot beyond the retained number", "channelID", l.ledgerID, "blockNumber", blockNum, "retain", schedule.Retain)
+		l.stats.updateSnapshotsPruned()
+	}
+	return nil
+}
+
+// CompletedSnapshotBlockNums returns the block numbers of the completed snapshots of a ledger in ascending order
+func CompletedSnapshotBlockNums(snapshotRootDir, ledgerID string) ([]uint64, error) {
+	snapshotsDir := SnapshotsDirForLedger(snapshotRootDir, ledgerID)
+	exists, err := fileutil.DirExists(snapshotsDir)
+	if err != nil || !exists {
+		return nil, err
+	}
+	subdirs, err := fileutil.ListSubdirs(snapshotsDir)
+	if err != nil {
+		return nil, err
+	}
+	var blockNums []uint64
+	for _, subdir := range subdirs {
+		blockNum, err := strconv.ParseUint(subdir, 10, 64)
+		if err != nil {
+			continue
+		}
+		blockNums = append(blockNums, blockNum)
+	}
+	sort.Slice(blockNums, func(i, j int) bool { return blockNums[i] < blockNums[j] })
+	return blockNums, nil
+}
+
 // snapshotExists checks if the snapshot for the given block number exists
 func (l *kvLedger) snapshotExists(blockNum uint64) (bool, error) {
 	snapshotDir := SnapshotDirForLedgerBlockNum(l.config.SnapshotsConfig.RootDir, l.ledgerID, blockNum)
@@ -288,8 +423,9 @@ func newSnapshotRequestBookkeeper(ledgerID string, dbHandle *leveldbhelper.DBHan
 	return bk, nil
 }
 
-// add adds the given block number to the bookkeeper db and returns an error if the block number already exists
-func (k *snapshotRequestBookkeeper) add(blockNumber uint64) error {
+// add adds the given block number to the bookkeeper db and returns an error if the block number already exists.
+// The requests added by the periodic snapshot schedule are tracked, so that only the snapshots they create are pruned.
+func (k *snapshotRequestBookkeeper) add(blockNumber uint64, scheduled bool) error {
 	logger.Infow("Adding new request for snapshot", "channelID", k.ledgerID, "blockNumber", blockNumber)
 	key := encodeSnapshotRequestKey(blockNumber)
 
@@ -301,7 +437,14 @@ func (k *snapshotRequestBookkeeper) add(blockNumber uint64) error {
 		return errors.Errorf("duplicate snapshot request for block number %d", blockNumber)
 	}
 
-	if err := k.dbHandle.Put(key, []byte{}, true); err != nil {
+	batch := k.dbHandle.NewUpdateBatch()
+	batch.Put(key, []byte{})
+	if scheduled {
+		batch.Put(encodeScheduledSnapshotKey(blockNumber), []byte{})
+	} else {
+		batch.Delete(encodeScheduledSnapshotKey(blockNumber))
+	}
+	if err := k.dbHandle.WriteBatch(batch, true); err != nil {
 		return err
 	}
 
@@ -338,9 +481,115 @@ func (k *snapshotRequestBookkeeper) delete(blockNumber uint64) error {
 	return nil
 }
 
+// cancel deletes the given block number from the bookkeeper db, along with the tracking of a scheduled request
+func (k *snapshotRequestBookkeeper) cancel(blockNumber uint64) error {
+	if err := k.delete(blockNumber); err != nil {
+		return err
+	}
+	return k.untrackScheduledSnapshot(blockNumber)
+}
+
+// isScheduledSnapshot returns true if the snapshot for the given block number was requested by the periodic
+// snapshot schedule
+func (k *snapshotRequestBookkeeper) isScheduledSnapshot(blockNumber uint64) (bool, error) {
+	val, err := k.dbHandle.Get(encodeScheduledSnapshotKey(blockNumber))
+	if err != nil {
+		return false, err
+	}
+	return val != nil, nil
+}
+
+// untrackScheduledSnapshot stops tracking the snapshot for the given block number as requested by the periodic
+// snapshot schedule
+func (k *snapshotRequestBookkeeper) untrackScheduledSnapshot(blockNumber uint64) error {
+	return k.dbHandle.Delete(encodeScheduledSnapshotKey(blockNumber), true)
+}
+
+// scheduleByBlockInterval adds a request for the first block after the last committed block whose number is a
+// multiple of the block interval, unless that block number was the last one scheduled, so that a scheduled request
+// which is cancelled is not added again
+func (k *snapshotRequestBookkeeper) scheduleByBlockInterval(blockInterval, lastCommittedBlockNum uint64) error {
+	if blockInterval == 0 {
+		return nil
+	}
+
+	nextBlockNum := (lastCommittedBlockNum/blockInterval + 1) * blockInterval
+	lastScheduledBlockNum, err := k.lastScheduledBlockNum()
+	if err != nil {
+		return err
+	}
+	if nextBlockNum == lastScheduledBlockNum {
+		return nil
+	}
+
+	exists, err := k.exist(nextBlockNum)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		if err := k.add(nextBlockNum, true); err != nil {
+			return err
+		}
+	}
+	return k.dbHandle.Put(lastScheduledBlockNumKey, util.EncodeOrderPreservingVarUint64(nextBlockNum), true)
+}
+
+// scheduleByTimeInterval adds a request for the last committed block if the time interval has elapsed since the
+// last request scheduled by it. The interval starts when it is first evaluated.
+func (k *snapshotRequestBookkeeper) scheduleByTimeInterval(timeInterval time.Duration, lastCommittedBlockNum uint64, now time.Time) error {
+	if timeInterval == 0 {
+		return nil
+	}
+
+	lastScheduledTime, err := k.lastScheduledTime()
+	if err != nil {
+		return err
+	}
+	if !lastScheduledTime.IsZero() {
+		if now.Sub(lastScheduledTime) < timeInterval {
+			return nil
+		}
+
+		exists, err := k.exist(lastCommittedBlockNum)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			if err := k.add(lastCommittedBlockNum, true); err != nil {
+				return err
+			}
+		}
+	}
+	return k.dbHandle.Put(lastScheduledTimeKey, util.EncodeOrderPreservingVarUint64(uint64(now.UnixNano())), true)
+}
+
+// lastScheduledBlockNum returns the block number last scheduled by the block interval, or 0 if none
+func (k *snapshotRequestBookkeeper) lastScheduledBlockNum() (uint64, error) {
+	val, err := k.dbHandle.Get(lastScheduledBlockNumKey)
+	if err != nil || val == nil {
+		return 0, err
+	}
+	blockNum, _, err := util.DecodeOrderPreservingVarUint64(val)
+	return blockNum, err
+}
+
+// lastScheduledTime returns the time at which the time interval last scheduled a request, or the zero time if
+// the time interval has not started
+func (k *snapshotRequestBookkeeper) lastScheduledTime() (time.Time, error) {
+	val, err := k.dbHandle.Get(lastScheduledTimeKey)
+	if err != nil || val == nil {
+		return time.Time{}, err
+	}
+	unixNano, _, err := util.DecodeOrderPreservingVarUint64(val)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(0, int64(unixNano)), nil
+}
+
 func (k *snapshotRequestBookkeeper) list() ([]uint64, error) {
 	requestedBlockNumbers := []uint64{}
-	itr, err := k.dbHandle.GetIterator(nil, nil)
+	itr, err := k.dbHandle.GetIterator(snapshotRequestKeyPrefix, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -376,7 +625,7 @@ func (k *snapshotRequestBookkeeper) exist(blockNumber uint64) (bool, error) {
 const defaultSmallestBlockNumber uint64 = math.MaxUint64
 
 func (k *snapshotRequestBookkeeper) smallestRequest() (uint64, error) {
-	itr, err := k.dbHandle.GetIterator(nil, nil)
+	itr, err := k.dbHandle.GetIterator(snapshotRequestKeyPrefix, nil)
 	if err != nil {
 		return 0, err
 	}
@@ -396,12 +645,24 @@ func (k *snapshotRequestBookkeeper) smallestRequest() (uint64, error) {
 	return smallestBlockNumber, nil
 }
 
-var snapshotRequestKeyPrefix = []byte("s")
+var (
+	snapshotRequestKeyPrefix = []byte("s")
+	// the keys holding the state of the periodic snapshot schedule sort before the snapshot request keys
+	lastScheduledBlockNumKey   = []byte("pb")
+	lastScheduledTimeKey       = []byte("pt")
+	scheduledSnapshotKeyPrefix = []byte("ps")
+)
 
 func encodeSnapshotRequestKey(blockNumber uint64) []byte {
 	return append(snapshotRequestKeyPrefix, util.EncodeOrderPreservingVarUint64(blockNumber)...)
 }
 
+func encodeScheduledSnapshotKey(blockNumber uint64) []byte {
+	// the prefix is copied, as pruneSnapshots encodes the keys concurrently with the processing of the events
+	key := append([]byte{}, scheduledSnapshotKeyPrefix...)
+	return append(key, util.EncodeOrderPreservingVarUint64(blockNumber)...)
+}
+
 func decodeSnapshotRequestKey(key []byte) (uint64, int, error) {
 	return util.DecodeOrderPreservingVarUint64(key[len(snapshotRequestKeyPrefix):])
 }
@@ -32,13 +32,13 @@ func TestSnapshotRequestBookKeeper(t *testing.T) {
 	require.NoError(t, err)
 
 	// add requests and verify smallestRequest
-	require.NoError(t, bookkeeper.add(100))
+	require.NoError(t, bookkeeper.add(100, false))
 	require.Equal(t, uint64(100), bookkeeper.smallestRequestBlockNum)
 
-	require.NoError(t, bookkeeper.add(15))
+	require.NoError(t, bookkeeper.add(15, false))
 	require.Equal(t, uint64(15), bookkeeper.smallestRequestBlockNum)
 
-	require.NoError(t, bookkeeper.add(50))
+	require.NoError(t, bookkeeper.add(50, false))
 	require.Equal(t, uint64(15), bookkeeper.smallestRequestBlockNum)
 
 	requestBlockNums, err := bookkeeper.list()
@@ -94,8 +94,8 @@ func TestSnapshotRequestBookKeeperErrorPaths(t *testing.T) {
 	bookkeeper2, err := newSnapshotRequestBookkeeper("test-ledger", dbHandle)
 	require.NoError(t, err)
 
-	require.NoError(t, bookkeeper2.add(20))
-	require.EqualError(t, bookkeeper2.add(20), "duplicate snapshot request for block number 20")
+	require.NoError(t, bookkeeper2.add(20, false))
+	require.EqualError(t, bookkeeper2.add(20, false), "duplicate snapshot request for block number 20")
 	require.EqualError(t, bookkeeper2.delete(100), "no snapshot request exists for block number 100")
 
 	provider.Close()
@@ -103,7 +103,7 @@ func TestSnapshotRequestBookKeeperErrorPaths(t *testing.T) {
 	_, err = newSnapshotRequestBookkeeper("test-ledger", dbHandle)
 	require.EqualError(t, err, "internal leveldb error while obtaining db iterator: leveldb: closed")
 
-	err = bookkeeper2.add(20)
+	err = bookkeeper2.add(20, false)
 	require.Contains(t, err.Error(), "leveldb: closed")
 
 	err = bookkeeper2.delete(1)
@@ -344,6 +344,202 @@ func TestSnapshotRequestsErrorPaths(t *testing.T) {
 	require.EqualError(t, err, "internal leveldb error while obtaining db iterator: leveldb: closed")
 }
 
+func TestSnapshotRequestBookKeeperSchedule(t *testing.T) {
+	conf := testConfig(t)
+	provider := testutilNewProvider(conf, t, &mock.DeployedChaincodeInfoProvider{})
+	defer provider.Close()
+
+	dbHandle := provider.bookkeepingProvider.GetDBHandle("testrequestbookkeeperschedule", bookkeeping.SnapshotRequest)
+	bookkeeper, err := newSnapshotRequestBookkeeper("test-ledger", dbHandle)
+	require.NoError(t, err)
+
+	t.Run("block interval", func(t *testing.T) {
+		// a zero interval schedules nothing
+		require.NoError(t, bookkeeper.scheduleByBlockInterval(0, 7))
+		requestBlockNums, err := bookkeeper.list()
+		require.NoError(t, err)
+		require.Empty(t, requestBlockNums)
+
+		require.NoError(t, bookkeeper.scheduleByBlockInterval(10, 7))
+		require.NoError(t, bookkeeper.scheduleByBlockInterval(10, 9))
+		requestBlockNums, err = bookkeeper.list()
+		require.NoError(t, err)
+		require.Equal(t, []uint64{10}, requestBlockNums)
+		require.Equal(t, uint64(10), bookkeeper.smallestRequestBlockNum)
+		scheduled, err := bookkeeper.isScheduledSnapshot(10)
+		require.NoError(t, err)
+		require.True(t, scheduled)
+
+		// the next request is scheduled once the scheduled block is committed
+		require.NoError(t, bookkeeper.scheduleByBlockInterval(10, 10))
+		requestBlockNums, err = bookkeeper.list()
+		require.NoError(t, err)
+		require.Equal(t, []uint64{10, 20}, requestBlockNums)
+		require.NoError(t, bookkeeper.delete(10))
+
+		// a cancelled request is not scheduled again, nor tracked as scheduled
+		require.NoError(t, bookkeeper.cancel(20))
+		require.NoError(t, bookkeeper.scheduleByBlockInterval(10, 15))
+		requestBlockNums, err = bookkeeper.list()
+		require.NoError(t, err)
+		require.Empty(t, requestBlockNums)
+		scheduled, err = bookkeeper.isScheduledSnapshot(20)
+		require.NoError(t, err)
+		require.False(t, scheduled)
+
+		// an existing request is not duplicated
+		require.NoError(t, bookkeeper.add(30, false))
+		require.NoError(t, bookkeeper.scheduleByBlockInterval(10, 21))
+		requestBlockNums, err = bookkeeper.list()
+		require.NoError(t, err)
+		require.Equal(t, []uint64{30}, requestBlockNums)
+		lastScheduledBlockNum, err := bookkeeper.lastScheduledBlockNum()
+		require.NoError(t, err)
+		require.Equal(t, uint64(30), lastScheduledBlockNum)
+		scheduled, err = bookkeeper.isScheduledSnapshot(30)
+		require.NoError(t, err)
+		require.False(t, scheduled)
+		require.NoError(t, bookkeeper.delete(30))
+	})
+
+	t.Run("time interval", func(t *testing.T) {
+		start := time.Unix(1000, 0)
+
+		// a zero interval schedules nothing
+		require.NoError(t, bookkeeper.scheduleByTimeInterval(0, 40, start))
+		lastScheduledTime, err := bookkeeper.lastScheduledTime()
+		require.NoError(t, err)
+		require.True(t, lastScheduledTime.IsZero())
+
+		// the interval starts when first evaluated
+		require.NoError(t, bookkeeper.scheduleByTimeInterval(time.Hour, 40, start))
+		require.NoError(t, bookkeeper.scheduleByTimeInterval(time.Hour, 41, start.Add(time.Minute)))
+		requestBlockNums, err := bookkeeper.list()
+		require.NoError(t, err)
+		require.Empty(t, requestBlockNums)
+		lastScheduledTime, err = bookkeeper.lastScheduledTime()
+		require.NoError(t, err)
+		require.True(t, start.Equal(lastScheduledTime))
+
+		// the last committed block is requested once the interval elapses
+		require.NoError(t, bookkeeper.scheduleByTimeInterval(time.Hour, 42, start.Add(time.Hour)))
+		requestBlockNums, err = bookkeeper.list()
+		require.NoError(t, err)
+		require.Equal(t, []uint64{42}, requestBlockNums)
+		lastScheduledTime, err = bookkeeper.lastScheduledTime()
+		require.NoError(t, err)
+		require.True(t, start.Add(time.Hour).Equal(lastScheduledTime))
+
+		require.NoError(t, bookkeeper.scheduleByTimeInterval(time.Hour, 43, start.Add(90*time.Minute)))
+		requestBlockNums, err = bookkeeper.list()
+		require.NoError(t, err)
+		require.Equal(t, []uint64{42}, requestBlockNums)
+	})
+
+	// the schedule is retained when the bookkeeper is created again
+	bookkeeper2, err := newSnapshotRequestBookkeeper("test-ledger", dbHandle)
+	require.NoError(t, err)
+	require.Equal(t, uint64(42), bookkeeper2.smallestRequestBlockNum)
+	lastScheduledBlockNum, err := bookkeeper2.lastScheduledBlockNum()
+	require.NoError(t, err)
+	require.Equal(t, uint64(30), lastScheduledBlockNum)
+	lastScheduledTime, err := bookkeeper2.lastScheduledTime()
+	require.NoError(t, err)
+	require.True(t, time.Unix(1000, 0).Add(time.Hour).Equal(lastScheduledTime))
+
+	provider.Close()
+	require.Contains(t, bookkeeper2.scheduleByBlockInterval(10, 50).Error(), "leveldb: closed")
+	require.Contains(t, bookkeeper2.scheduleByTimeInterval(time.Hour, 50, time.Now()).Error(), "leveldb: closed")
+}
+
+func TestPeriodicSnapshots(t *testing.T) {
+	conf := testConfig(t)
+	conf.SnapshotsConfig.ChannelSchedules = map[string]*ledger.SnapshotSchedule{
+		"testperiodicsnapshots": {
+			BlockInterval: 5,
+			Retain:        2,
+		},
+	}
+	provider := testutilNewProvider(conf, t, &mock.DeployedChaincodeInfoProvider{})
+	defer provider.Close()
+
+	ledgerID := "testperiodicsnapshots"
+	bg, gb := testutil.NewBlockGenerator(t, ledgerID, false)
+	gbHash := protoutil.BlockHeaderHash(gb.Header)
+	l, err := provider.CreateFromGenesisBlock(gb)
+	require.NoError(t, err)
+	defer l.Close()
+
+	// the first request is scheduled when the genesis block is committed
+	requestsUpdated := func(expected []uint64) func() bool {
+		return func() bool {
+			requests, err := l.PendingSnapshotRequests()
+			require.NoError(t, err)
+			return equal(requests, expected)
+		}
+	}
+	require.Eventually(t, requestsUpdated([]uint64{5}), time.Minute, 10*time.Millisecond)
+
+	// the commit of a block waits for the snapshot of the previous block, so that the snapshots
+	// for block numbers 5 and 10 are generated once block number 12 is committed
+	lastBlock := testutilCommitBlocks(t, l, bg, 12, gbHash)
+	blockNums, err := CompletedSnapshotBlockNums(conf.SnapshotsConfig.RootDir, ledgerID)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{5, 10}, blockNums)
+	requests, err := l.PendingSnapshotRequests()
+	require.NoError(t, err)
+	require.Equal(t, []uint64{15}, requests)
+
+	info, err := l.SnapshotSchedule()
+	require.NoError(t, err)
+	require.Equal(t, &ledger.SnapshotScheduleInfo{
+		Schedule:        &ledger.SnapshotSchedule{BlockInterval: 5, Retain: 2},
+		NextBlockNumber: 15,
+	}, info)
+
+	// the oldest snapshot is removed once the snapshot for block number 15 is generated, while the snapshot
+	// requested by an admin is not counted in the retained snapshots nor removed
+	require.NoError(t, l.SubmitSnapshotRequest(13))
+	testutilCommitBlocks(t, l, bg, 15, protoutil.BlockHeaderHash(lastBlock.Header))
+	snapshotsPruned := func() bool {
+		blockNums, err := CompletedSnapshotBlockNums(conf.SnapshotsConfig.RootDir, ledgerID)
+		require.NoError(t, err)
+		return equal(blockNums, []uint64{10, 13, 15})
+	}
+	require.Eventually(t, snapshotsPruned, time.Minute, 10*time.Millisecond)
+	require.Eventually(t, requestsUpdated([]uint64{20}), time.Minute, 10*time.Millisecond)
+
+	// the scheduled request survives the reopening of the ledger
+	l.Close()
+	provider.Close()
+	provider2 := testutilNewProvider(conf, t, &mock.DeployedChaincodeInfoProvider{})
+	defer provider2.Close()
+	l2, err := provider2.Open(ledgerID)
+	require.NoError(t, err)
+	defer l2.Close()
+	requests, err = l2.PendingSnapshotRequests()
+	require.NoError(t, err)
+	require.Equal(t, []uint64{20}, requests)
+
+	// the snapshots created by the schedule remain tracked once the ledger is reopened
+	kvledger2 := l2.(*kvLedger)
+	for blockNum, expected := range map[uint64]bool{5: false, 10: true, 13: false, 15: true} {
+		scheduled, err := kvledger2.snapshotMgr.snapshotRequestBookkeeper.isScheduledSnapshot(blockNum)
+		require.NoError(t, err)
+		require.Equal(t, expected, scheduled, "block number %d", blockNum)
+	}
+
+	// without a schedule, the snapshots are all retained
+	kvledger2.snapshotMgr.schedule = nil
+	require.NoError(t, kvledger2.pruneSnapshots())
+	blockNums, err = CompletedSnapshotBlockNums(conf.SnapshotsConfig.RootDir, ledgerID)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{10, 13, 15}, blockNums)
+	info, err = kvledger2.SnapshotSchedule()
+	require.NoError(t, err)
+	require.Equal(t, &ledger.SnapshotScheduleInfo{}, info)
+}
+
 func equal(slice1 []uint64, slice2 []uint64) bool {
 	if len(slice1) != len(slice2) {
 		return false
@@ -307,7 +307,7 @@ func (s *DB) HandleChaincodeDeploy(chaincodeDefinition *cceventmgmt.ChaincodeDef
 	if chaincodeDefinition == nil {
 		return errors.New("chaincode definition not found while creating couchdb index")
 	}
-	dbArtifacts, err := ccprovider.ExtractFileEntries(dbArtifactsTar, indexCapable.GetDBType())
+	dbArtifacts, err := ccprovider.ExtractFileEntries(dbArtifactsTar, indexArtifactsDBType(indexCapable.GetDBType()))
 	if err != nil {
 		logger.Errorf("Index creation: error extracting db artifacts from tar for chaincode [%s]: %s", chaincodeDefinition.Name, err)
 		return nil
@@ -428,6 +428,18 @@ const (
 	collectionIndexDirDepth = 5
 )
 
+// indexArtifactsDBType returns the database type whose index definitions,
+// packaged with chaincodes under "META-INF/statedb/<type>", are deployed to a
+// state database of the given type. The leveldb state database evaluates
+// queries expressed in the CouchDB Mango query language, hence it uses the
+// CouchDB index definitions rather than definitions of its own.
+func indexArtifactsDBType(dbType string) string {
+	if dbType == "leveldb" {
+		return "couchdb"
+	}
+	return dbType
+}
+
 // Note previous functions will have ensured that the path starts
 // with 'META-INF/statedb' and does not have leading or trailing
 // path deliminators.
@@ -48,6 +48,11 @@ func TestHealthCheckRegister(t *testing.T) {
 	require.NotNil(t, arg2)
 }
 
+func TestIndexArtifactsDBType(t *testing.T) {
+	require.Equal(t, "couchdb", indexArtifactsDBType("couchdb"))
+	require.Equal(t, "couchdb", indexArtifactsDBType("leveldb"))
+}
+
 func TestGetIndexInfo(t *testing.T) {
 	chaincodeIndexPath := "META-INF/statedb/couchdb/indexes"
 	actualIndexInfo := getIndexInfo(chaincodeIndexPath)
@@ -0,0 +1,425 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package stateleveldb
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"math"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/pkg/errors"
+)
+
+// Secondary indexes are declared with the CouchDB index definitions packaged
+// with chaincodes. An index entry is stored for each state that holds a JSON
+// object with all the indexed fields, under the key
+// <indexEntryPrefix><ns>0x00<index name>0x00<encoded field values>0x00<key>
+// and with the key of the state as value.
+var (
+	indexEntryPrefix      = []byte{'i'}
+	indexDefinitionPrefix = []byte{'x'}
+)
+
+// Type tags of encoded index values, in collation order
+const (
+	endTag byte = iota
+	nullTag
+	falseTag
+	trueTag
+	numberTag
+	stringTag
+	arrayTag
+	objectTag
+)
+
+// upperBoundSuffix is greater than any byte that can follow an encoded value
+var upperBoundSuffix = []byte{0xff}
+
+type indexDefinition struct {
+	Name   string   `json:"name"`
+	DDoc   string   `json:"ddoc,omitempty"`
+	Fields []string `json:"fields"`
+}
+
+// parseIndexDefinition parses a CouchDB index definition such as
+// {"index":{"fields":["owner",{"size":"desc"}]},"ddoc":"indexOwnerDoc","name":"indexOwner","type":"json"}
+// The sort direction of the fields is irrelevant for the leveldb indexes.
+// When the name is missing, the name of the file is used.
+func parseIndexDefinition(fileName string, raw []byte) (*indexDefinition, error) {
+	def := struct {
+		Index struct {
+			Fields                []interface{}          `json:"fields"`
+			PartialFilterSelector map[string]interface{} `json:"partial_filter_selector"`
+		} `json:"index"`
+		DDoc string `json:"ddoc"`
+		Name string `json:"name"`
+		Type string `json:"type"`
+	}{}
+	if err := json.Unmarshal(raw, &def); err != nil {
+		return nil, errors.Wrap(err, "invalid index definition")
+	}
+	if def.Type != "" && def.Type != "json" {
+		return nil, errors.Errorf("unsupported index type [%s]", def.Type)
+	}
+	if def.Index.PartialFilterSelector != nil {
+		return nil, errors.New("partial filter selectors are not supported")
+	}
+	if len(def.Index.Fields) == 0 {
+		return nil, errors.New("index fields are missing")
+	}
+
+	index := &indexDefinition{Name: def.Name, DDoc: def.DDoc}
+	if index.Name == "" {
+		index.Name = strings.TrimSuffix(filepath.Base(fileName), filepath.Ext(fileName))
+	}
+	for _, f := range def.Index.Fields {
+		switch f := f.(type) {
+		case string:
+			index.Fields = append(index.Fields, f)
+		case map[string]interface{}:
+			if len(f) != 1 {
+				return nil, errors.New("index fields must be strings or objects with exactly one field")
+			}
+			for name := range f {
+				index.Fields = append(index.Fields, name)
+			}
+		default:
+			return nil, errors.New("index fields must be strings or objects with exactly one field")
+		}
+	}
+	return index, nil
+}
+
+func indexDefinitionKey(ns, name string) []byte {
+	k := append([]byte{}, indexDefinitionPrefix...)
+	k = append(k, []byte(ns)...)
+	k = append(k, nsKeySep...)
+	return append(k, []byte(name)...)
+}
+
+func indexEntriesPrefix(ns, name string) []byte {
+	k := append([]byte{}, indexEntryPrefix...)
+	k = append(k, []byte(ns)...)
+	k = append(k, nsKeySep...)
+	k = append(k, []byte(name)...)
+	return append(k, nsKeySep...)
+}
+
+// indexEntryKey returns the key of the entry of the index for the state with
+// the given key, or false if the document does not have all the indexed fields
+func (def *indexDefinition) indexEntryKey(ns string, doc map[string]interface{}, key string) ([]byte, bool) {
+	k := indexEntriesPrefix(ns, def.Name)
+	for _, f := range def.Fields {
+		value, ok := lookupField(doc, key, splitPath(f))
+		if !ok {
+			return nil, false
+		}
+		k = appendIndexValue(k, value)
+	}
+	k = append(k, endTag)
+	return append(k, []byte(key)...), true
+}
+
+// appendIndexValue appends the encoding of the JSON value v to buf. The
+// encoding preserves the collation order implemented by collate.
+func appendIndexValue(buf []byte, v interface{}) []byte {
+	switch v := v.(type) {
+	case nil:
+		return append(buf, nullTag)
+	case bool:
+		if v {
+			return append(buf, trueTag)
+		}
+		return append(buf, falseTag)
+	case json.Number:
+		bits := math.Float64bits(toFloat(v))
+		if bits&(1<<63) != 0 {
+			bits = ^bits
+		} else {
+			bits |= 1 << 63
+		}
+		buf = append(buf, numberTag)
+		return binary.BigEndian.AppendUint64(buf, bits)
+	case string:
+		return appendIndexString(append(buf, stringTag), v)
+	case []interface{}:
+		buf = append(buf, arrayTag)
+		for _, e := range v {
+			buf = appendIndexValue(buf, e)
+		}
+		return append(buf, endTag)
+	case map[string]interface{}:
+		buf = append(buf, objectTag)
+		for _, k := range sortedKeys(v) {
+			buf = appendIndexString(append(buf, stringTag), k)
+			buf = appendIndexValue(buf, v[k])
+		}
+		return append(buf, endTag)
+	}
+	return buf
+}
+
+// appendIndexString escapes 0x00 as 0x00 0xff and terminates the string
+// with 0x00 0x01 so that shorter strings sort first
+func appendIndexString(buf []byte, s string) []byte {
+	for i := 0; i < len(s); i++ {
+		buf = append(buf, s[i])
+		if s[i] == 0x00 {
+			buf = append(buf, 0xff)
+		}
+	}
+	return append(buf, 0x00, 0x01)
+}
+
+// loadIndexDefinitions returns the indexes declared for the namespace
+func (vdb *versionedDB) loadIndexDefinitions(ns string) ([]*indexDefinition, error) {
+	start := indexDefinitionKey(ns, "")
+	end := append(start[:len(start)-1:len(start)-1], lastKeyIndicator)
+	itr, err := vdb.db.GetIterator(start, end)
+	if err != nil {
+		return nil, err
+	}
+	defer itr.Release()
+
+	var defs []*indexDefinition
+	for itr.Next() {
+		def := &indexDefinition{}
+		if err := json.Unmarshal(itr.Value(), def); err != nil {
+			return nil, errors.Wrapf(err, "invalid index definition stored for namespace [%s]", ns)
+		}
+		defs = append(defs, def)
+	}
+	return defs, errors.Wrap(itr.Error(), "internal leveldb error while retrieving index definitions")
+}
+
+// updateIndexEntries adds to the batch the changes to the index entries
+// required by the update of the state with the given key, from the
+// oldValue to the newValue. Either value may be nil.
+func updateIndexEntries(dbBatch *leveldbhelper.UpdateBatch, ns string, defs []*indexDefinition, key string, oldValue, newValue []byte) {
+	oldDoc, newDoc := jsonDocument(oldValue), jsonDocument(newValue)
+	for _, def := range defs {
+		oldEntry, hasOld := []byte(nil), false
+		if oldDoc != nil {
+			oldEntry, hasOld = def.indexEntryKey(ns, oldDoc, key)
+		}
+		newEntry, hasNew := []byte(nil), false
+		if newDoc != nil {
+			newEntry, hasNew = def.indexEntryKey(ns, newDoc, key)
+		}
+		if hasOld && (!hasNew || string(oldEntry) != string(newEntry)) {
+			dbBatch.Delete(oldEntry)
+		}
+		if hasNew {
+			dbBatch.Put(newEntry, []byte(key))
+		}
+	}
+}
+
+// jsonDocument returns the JSON object held by value, or nil if the value
+// is not a JSON object
+func jsonDocument(value []byte) map[string]interface{} {
+	if len(value) == 0 || value[0] != '{' {
+		return nil
+	}
+	doc, err := decodeJSONObject(value)
+	if err != nil {
+		return nil
+	}
+	return doc
+}
+
+// createIndex stores the definition of the index and builds its entries for
+// the states of the namespace, replacing any index with the same name
+func (vdb *versionedDB) createIndex(ns string, def *indexDefinition) error {
+	dbBatch := vdb.db.NewUpdateBatch()
+
+	// remove the entries of an existing index with the same name
+	prefix := indexEntriesPrefix(ns, def.Name)
+	end := append(prefix[:len(prefix)-1:len(prefix)-1], lastKeyIndicator)
+	itr, err := vdb.db.GetIterator(prefix, end)
+	if err != nil {
+		return err
+	}
+	for itr.Next() {
+		dbBatch.Delete(append([]byte{}, itr.Key()...))
+		if err := vdb.writeBatchIfFull(dbBatch); err != nil {
+			itr.Release()
+			return err
+		}
+	}
+	itr.Release()
+	if err := itr.Error(); err != nil {
+		return errors.Wrap(err, "internal leveldb error while removing index entries")
+	}
+
+	dataStartKey := encodeDataKey(ns, "")
+	dataEndKey := dataKeyStarterForNextNamespace(ns)
+	itr, err = vdb.db.GetIterator(dataStartKey, dataEndKey)
+	if err != nil {
+		return err
+	}
+	defer itr.Release()
+	for itr.Next() {
+		vv, err := decodeValue(itr.Value())
+		if err != nil {
+			return err
+		}
+		_, key := decodeDataKey(itr.Key())
+		updateIndexEntries(dbBatch, ns, []*indexDefinition{def}, key, nil, vv.Value)
+		if err := vdb.writeBatchIfFull(dbBatch); err != nil {
+			return err
+		}
+	}
+	if err := itr.Error(); err != nil {
+		return errors.Wrap(err, "internal leveldb error while building index entries")
+	}
+
+	// the definition is stored last so that an index is never used before being complete
+	raw, err := json.Marshal(def)
+	if err != nil {
+		return errors.Wrap(err, "failed marshaling index definition")
+	}
+	dbBatch.Put(indexDefinitionKey(ns, def.Name), raw)
+	return vdb.db.WriteBatch(dbBatch, true)
+}
+
+func (vdb *versionedDB) writeBatchIfFull(dbBatch *leveldbhelper.UpdateBatch) error {
+	if dbBatch.Size() < maxDataImportBatchSize {
+		return nil
+	}
+	if err := vdb.db.WriteBatch(dbBatch, true); err != nil {
+		return err
+	}
+	dbBatch.Reset()
+	return nil
+}
+
+// indexPlan is a range of entries of an index that holds all the states
+// matching a selector
+type indexPlan struct {
+	index      *indexDefinition
+	start, end []byte
+}
+
+// fieldBounds collects the conditions on a field that can be served by an index
+type fieldBounds struct {
+	eq           interface{}
+	hasEq        bool
+	lower, upper *fieldSelector
+}
+
+// planIndex selects the index that narrows the most the states to evaluate
+// for the selector, preferring the index named by useIndex. It uses the
+// equality conditions on the leading fields of an index, and range
+// conditions on the field that follows them. It returns nil when no index
+// is usable.
+func planIndex(ns string, defs []*indexDefinition, sel selector, useIndex string) *indexPlan {
+	bounds := map[string]*fieldBounds{}
+	collectBounds(sel, bounds)
+
+	var best *indexPlan
+	bestScore := 0
+	for _, def := range defs {
+		prefix := indexEntriesPrefix(ns, def.Name)
+		score := 0
+		var lower, upper *fieldSelector
+		for _, f := range def.Fields {
+			b, ok := bounds[f]
+			if !ok {
+				break
+			}
+			if b.hasEq {
+				prefix = appendIndexValue(prefix, b.eq)
+				score += 2
+				continue
+			}
+			lower, upper = b.lower, b.upper
+			if lower != nil || upper != nil {
+				score++
+			}
+			break
+		}
+		if score == 0 {
+			continue
+		}
+		if useIndex != "" && (def.Name == useIndex || def.DDoc == useIndex) {
+			score += len(def.Fields)*2 + 1
+		}
+		if score <= bestScore {
+			continue
+		}
+
+		plan := &indexPlan{index: def, start: prefix, end: append(append([]byte{}, prefix...), upperBoundSuffix...)}
+		if lower != nil {
+			plan.start = appendIndexValue(append([]byte{}, prefix...), lower.arg)
+			if lower.op == "$gt" {
+				plan.start = append(plan.start, upperBoundSuffix...)
+			}
+		}
+		if upper != nil {
+			plan.end = appendIndexValue(append([]byte{}, prefix...), upper.arg)
+			if upper.op == "$lte" {
+				plan.end = append(plan.end, upperBoundSuffix...)
+			}
+		}
+		best, bestScore = plan, score
+	}
+	return best
+}
+
+// collectBounds collects the conditions on fields that all the matching
+// documents must satisfy, i.e. the conditions of the top level conjunction
+func collectBounds(sel selector, bounds map[string]*fieldBounds) {
+	switch s := sel.(type) {
+	case andSelector:
+		for _, sub := range s {
+			collectBounds(sub, bounds)
+		}
+	case *fieldSelector:
+		name := strings.Join(s.path, ".")
+		b, ok := bounds[name]
+		if !ok {
+			b = &fieldBounds{}
+			bounds[name] = b
+		}
+		switch s.op {
+		case "$eq":
+			b.eq, b.hasEq = s.arg, true
+		case "$gt", "$gte":
+			if b.lower == nil {
+				b.lower = s
+			}
+		case "$lt", "$lte":
+			if b.upper == nil {
+				b.upper = s
+			}
+		}
+	}
+}
+
+// keysFromIndex returns, sorted, the keys of the index entries in the range of the plan
+func (vdb *versionedDB) keysFromIndex(plan *indexPlan) ([]string, error) {
+	itr, err := vdb.db.GetIterator(plan.start, plan.end)
+	if err != nil {
+		return nil, err
+	}
+	defer itr.Release()
+
+	var keys []string
+	for itr.Next() {
+		keys = append(keys, string(itr.Value()))
+	}
+	if err := itr.Error(); err != nil {
+		return nil, errors.Wrap(err, "internal leveldb error while reading index entries")
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
@@ -0,0 +1,130 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package stateleveldb
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/ledger/internal/version"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseIndexDefinition(t *testing.T) {
+	def, err := parseIndexDefinition("indexOwner.json",
+		[]byte(`{"index":{"fields":["owner",{"size":"desc"}]},"ddoc":"indexOwnerDoc","name":"indexOwner","type":"json"}`))
+	require.NoError(t, err)
+	require.Equal(t, &indexDefinition{Name: "indexOwner", DDoc: "indexOwnerDoc", Fields: []string{"owner", "size"}}, def)
+
+	def, err = parseIndexDefinition("META-INF/statedb/couchdb/indexes/indexColor.json", []byte(`{"index":{"fields":["color"]}}`))
+	require.NoError(t, err)
+	require.Equal(t, &indexDefinition{Name: "indexColor", Fields: []string{"color"}}, def)
+
+	tests := []struct {
+		raw string
+		err string
+	}{
+		{`[]`, "invalid index definition: json: cannot unmarshal array into Go value of type struct"},
+		{`{"index":{"fields":["a"]},"type":"text"}`, "unsupported index type [text]"},
+		{`{"index":{"fields":["a"],"partial_filter_selector":{"a":1}}}`, "partial filter selectors are not supported"},
+		{`{"index":{}}`, "index fields are missing"},
+		{`{"index":{"fields":[1]}}`, "index fields must be strings or objects with exactly one field"},
+		{`{"index":{"fields":[{"a":"asc","b":"asc"}]}}`, "index fields must be strings or objects with exactly one field"},
+	}
+	for _, test := range tests {
+		_, err := parseIndexDefinition("index.json", []byte(test.raw))
+		require.ErrorContains(t, err, test.err, "definition %s", test.raw)
+	}
+}
+
+func TestIndexes(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db, err := env.DBProvider.GetDBHandle("testindexes", nil)
+	require.NoError(t, err)
+	vdb := db.(*versionedDB)
+	populateMarbles(t, db)
+
+	queries := []string{
+		`{"selector":{"color":"blue"}}`,
+		`{"selector":{"color":"blue","size":{"$gt":1,"$lte":5}}}`,
+		`{"selector":{"color":"red","size":{"$gte":"a"}}}`,
+		`{"selector":{"color":{"$gte":"blue","$lt":"red"}},"sort":["size"]}`,
+		`{"selector":{"details.weight":{"$lt":30}},"use_index":"indexWeight"}`,
+		`{"selector":{"owner":"fred","color":"blue"},"use_index":["_design/indexOwnerDoc","indexOwner"]}`,
+	}
+	expected := map[string][]string{}
+	for _, q := range queries {
+		expected[q] = queryKeys(t, db, q)
+	}
+
+	// indexes are built on the existing states, and invalid definitions are skipped
+	require.NoError(t, db.(statedb.IndexCapable).ProcessIndexesForChaincodeDeploy("ns", map[string][]byte{
+		"indexColor.json":   []byte(`{"index":{"fields":["color","size"]},"ddoc":"indexColorDoc","name":"indexColor","type":"json"}`),
+		"indexOwner.json":   []byte(`{"index":{"fields":["owner"]},"ddoc":"indexOwnerDoc","name":"indexOwner","type":"json"}`),
+		"indexWeight.json":  []byte(`{"index":{"fields":["details.weight"]}}`),
+		"indexInvalid.json": []byte(`{"index":{"fields":["a"]},"type":"text"}`),
+	}))
+	defs, err := vdb.loadIndexDefinitions("ns")
+	require.NoError(t, err)
+	require.Len(t, defs, 3)
+	defs, err = vdb.loadIndexDefinitions("other")
+	require.NoError(t, err)
+	require.Empty(t, defs)
+
+	for _, q := range queries {
+		require.Equal(t, expected[q], queryKeys(t, db, q), "query %s", q)
+	}
+
+	// the indexes follow the updates
+	batch := statedb.NewUpdateBatch()
+	batch.Put("ns", "marble7", []byte(`{"color":"blue","size":2,"owner":"fred"}`), version.NewHeight(2, 1))
+	batch.Put("ns", "marble3", []byte(`{"color":"green","size":3,"owner":"fred"}`), version.NewHeight(2, 2))
+	batch.Put("ns", "marble4", []byte(`not json`), version.NewHeight(2, 3))
+	batch.Delete("ns", "marble5", version.NewHeight(2, 4))
+	require.NoError(t, db.ApplyUpdates(batch, version.NewHeight(2, 4)))
+
+	require.Equal(t, []string{"marble1", "marble7"}, queryKeys(t, db, `{"selector":{"color":"blue"}}`))
+	require.Equal(t, []string{"marble3"}, queryKeys(t, db, `{"selector":{"color":"green"}}`))
+	require.Equal(t, []string{"marble3", "marble7"}, queryKeys(t, db, `{"selector":{"owner":"fred"}}`))
+	require.Equal(t, []string{"marble1"}, queryKeys(t, db, `{"selector":{"details.weight":{"$lt":30}}}`))
+
+	// redeploying an index rebuilds it
+	require.NoError(t, db.(statedb.IndexCapable).ProcessIndexesForChaincodeDeploy("ns", map[string][]byte{
+		"indexColor.json": []byte(`{"index":{"fields":["color"]},"ddoc":"indexColorDoc","name":"indexColor","type":"json"}`),
+	}))
+	require.Equal(t, []string{"marble1", "marble7"}, queryKeys(t, db, `{"selector":{"color":"blue"}}`))
+}
+
+func TestPlanIndex(t *testing.T) {
+	defs := []*indexDefinition{
+		{Name: "indexColor", DDoc: "indexColorDoc", Fields: []string{"color"}},
+		{Name: "indexColorSize", Fields: []string{"color", "size"}},
+		{Name: "indexSize", Fields: []string{"size"}},
+	}
+	plan := func(selector, useIndex string) *indexPlan {
+		q, err := parseQuery(`{"selector":` + selector + `}`)
+		require.NoError(t, err)
+		return planIndex("ns", defs, q.selector, useIndex)
+	}
+
+	require.Nil(t, plan(`{"owner":"tom"}`, ""))
+	require.Nil(t, plan(`{"$or":[{"color":"blue"},{"size":1}]}`, ""))
+	require.Nil(t, plan(`{"size":{"$ne":1}}`, ""))
+	require.Equal(t, "indexColor", plan(`{"color":"blue"}`, "").index.Name)
+	require.Equal(t, "indexColorSize", plan(`{"color":"blue","size":{"$gt":1}}`, "").index.Name)
+	require.Equal(t, "indexColorSize", plan(`{"color":"blue","size":1}`, "").index.Name)
+	require.Equal(t, "indexSize", plan(`{"color":{"$in":["blue"]},"size":1}`, "").index.Name)
+	require.Equal(t, "indexColor", plan(`{"color":"blue","size":1}`, "indexColorDoc").index.Name)
+	require.Equal(t, "indexSize", plan(`{"color":"blue","size":1}`, "indexSize").index.Name)
+	require.Equal(t, "indexColorSize", plan(`{"color":"blue","size":1}`, "unknown").index.Name)
+
+	p := plan(`{"size":{"$gt":1,"$lte":3}}`, "")
+	require.Equal(t, append(appendIndexValue(indexEntriesPrefix("ns", "indexSize"), json.Number("1")), upperBoundSuffix...), p.start)
+	require.Equal(t, append(appendIndexValue(indexEntriesPrefix("ns", "indexSize"), json.Number("3")), upperBoundSuffix...), p.end)
+}
@@ -0,0 +1,536 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package stateleveldb
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// idField is the name used in selectors to refer to the key of a state,
+// as the _id of the documents holding the states in CouchDB
+const idField = "_id"
+
+// query is a parsed query expressed in the subset of the CouchDB Mango
+// query language supported by the leveldb state database
+type query struct {
+	selector selector
+	fields   [][]string
+	sort     []sortField
+	limit    int32
+	bookmark string
+	useIndex string
+}
+
+type sortField struct {
+	path []string
+	desc bool
+}
+
+// parseQuery parses a Mango query. The supported top level options are
+// selector, fields, sort, limit, bookmark and use_index.
+func parseQuery(queryString string) (*query, error) {
+	raw, err := decodeJSONObject([]byte(queryString))
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid query")
+	}
+
+	q := &query{}
+	selectorDef, ok := raw["selector"]
+	if !ok {
+		return nil, errors.New("invalid query: selector is missing")
+	}
+	selectorObj, ok := selectorDef.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("invalid query: selector must be an object")
+	}
+	if q.selector, err = parseSelector(selectorObj); err != nil {
+		return nil, errors.WithMessage(err, "invalid selector")
+	}
+
+	for _, option := range sortedKeys(raw) {
+		value := raw[option]
+		switch option {
+		case "selector":
+		case "fields":
+			list, ok := value.([]interface{})
+			if !ok {
+				return nil, errors.New("invalid query: fields must be an array")
+			}
+			for _, f := range list {
+				name, ok := f.(string)
+				if !ok {
+					return nil, errors.New("invalid query: fields must be an array of strings")
+				}
+				q.fields = append(q.fields, splitPath(name))
+			}
+		case "sort":
+			if q.sort, err = parseSort(value); err != nil {
+				return nil, err
+			}
+		case "limit":
+			n, ok := value.(json.Number)
+			if !ok {
+				return nil, errors.New("invalid query: limit must be a number")
+			}
+			limit, err := strconv.ParseInt(n.String(), 10, 32)
+			if err != nil || limit < 0 {
+				return nil, errors.Errorf("invalid query: invalid limit [%s]", n)
+			}
+			q.limit = int32(limit)
+		case "bookmark":
+			if q.bookmark, ok = value.(string); !ok {
+				return nil, errors.New("invalid query: bookmark must be a string")
+			}
+		case "use_index":
+			switch index := value.(type) {
+			case string:
+				q.useIndex = index
+			case []interface{}:
+				// ["design document", "index name"]
+				if len(index) > 0 {
+					q.useIndex, _ = index[len(index)-1].(string)
+				}
+			default:
+				return nil, errors.New("invalid query: use_index must be a string or an array")
+			}
+		default:
+			return nil, errors.Errorf("invalid query: unsupported option [%s]", option)
+		}
+	}
+
+	return q, nil
+}
+
+func parseSort(value interface{}) ([]sortField, error) {
+	list, ok := value.([]interface{})
+	if !ok {
+		return nil, errors.New("invalid query: sort must be an array")
+	}
+	var fields []sortField
+	for _, s := range list {
+		switch s := s.(type) {
+		case string:
+			fields = append(fields, sortField{path: splitPath(s)})
+		case map[string]interface{}:
+			if len(s) != 1 {
+				return nil, errors.New("invalid query: sort entries must have exactly one field")
+			}
+			for name, direction := range s {
+				switch direction {
+				case "asc":
+					fields = append(fields, sortField{path: splitPath(name)})
+				case "desc":
+					fields = append(fields, sortField{path: splitPath(name), desc: true})
+				default:
+					return nil, errors.Errorf("invalid query: invalid sort direction [%v] for field [%s]", direction, name)
+				}
+			}
+		default:
+			return nil, errors.New("invalid query: sort entries must be strings or objects")
+		}
+	}
+	return fields, nil
+}
+
+// selector matches JSON documents. key is the key of the state holding the document.
+type selector interface {
+	matches(doc map[string]interface{}, key string) bool
+}
+
+type andSelector []selector
+
+func (s andSelector) matches(doc map[string]interface{}, key string) bool {
+	for _, sub := range s {
+		if !sub.matches(doc, key) {
+			return false
+		}
+	}
+	return true
+}
+
+type orSelector []selector
+
+func (s orSelector) matches(doc map[string]interface{}, key string) bool {
+	for _, sub := range s {
+		if sub.matches(doc, key) {
+			return true
+		}
+	}
+	return false
+}
+
+type notSelector struct {
+	selector selector
+}
+
+func (s *notSelector) matches(doc map[string]interface{}, key string) bool {
+	return !s.selector.matches(doc, key)
+}
+
+// fieldSelector applies a condition operator to the value of a field
+type fieldSelector struct {
+	path []string
+	op   string
+	arg  interface{}
+	re   *regexp.Regexp
+}
+
+func (s *fieldSelector) matches(doc map[string]interface{}, key string) bool {
+	value, exists := lookupField(doc, key, s.path)
+	if s.op == "$exists" {
+		return exists == s.arg.(bool)
+	}
+	if !exists {
+		return false
+	}
+
+	switch s.op {
+	case "$eq":
+		return collate(value, s.arg) == 0
+	case "$ne":
+		return collate(value, s.arg) != 0
+	case "$gt":
+		return collate(value, s.arg) > 0
+	case "$gte":
+		return collate(value, s.arg) >= 0
+	case "$lt":
+		return collate(value, s.arg) < 0
+	case "$lte":
+		return collate(value, s.arg) <= 0
+	case "$in":
+		return in(value, s.arg.([]interface{}))
+	case "$nin":
+		return !in(value, s.arg.([]interface{}))
+	case "$regex":
+		str, ok := value.(string)
+		return ok && s.re.MatchString(str)
+	}
+	return false
+}
+
+// in returns true if value, or one of its elements when it is an array,
+// is equal to one of args
+func in(value interface{}, args []interface{}) bool {
+	for _, arg := range args {
+		if collate(value, arg) == 0 {
+			return true
+		}
+		if values, ok := value.([]interface{}); ok {
+			for _, v := range values {
+				if collate(v, arg) == 0 {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func parseSelector(raw map[string]interface{}) (selector, error) {
+	var conditions andSelector
+	for _, name := range sortedKeys(raw) {
+		value := raw[name]
+		switch name {
+		case "$and", "$or", "$nor":
+			list, ok := value.([]interface{})
+			if !ok {
+				return nil, errors.Errorf("%s requires an array of selectors", name)
+			}
+			var subs []selector
+			for _, item := range list {
+				obj, ok := item.(map[string]interface{})
+				if !ok {
+					return nil, errors.Errorf("%s requires an array of selectors", name)
+				}
+				sub, err := parseSelector(obj)
+				if err != nil {
+					return nil, err
+				}
+				subs = append(subs, sub)
+			}
+			switch name {
+			case "$and":
+				conditions = append(conditions, andSelector(subs))
+			case "$or":
+				conditions = append(conditions, orSelector(subs))
+			case "$nor":
+				conditions = append(conditions, &notSelector{orSelector(subs)})
+			}
+		case "$not":
+			obj, ok := value.(map[string]interface{})
+			if !ok {
+				return nil, errors.New("$not requires a selector")
+			}
+			sub, err := parseSelector(obj)
+			if err != nil {
+				return nil, err
+			}
+			conditions = append(conditions, &notSelector{sub})
+		default:
+			if strings.HasPrefix(name, "$") {
+				return nil, errors.Errorf("unsupported operator [%s]", name)
+			}
+			sub, err := parseFieldConditions(splitPath(name), value)
+			if err != nil {
+				return nil, err
+			}
+			conditions = append(conditions, sub...)
+		}
+	}
+
+	if len(conditions) == 1 {
+		return conditions[0], nil
+	}
+	return conditions, nil
+}
+
+// parseFieldConditions parses the conditions applying to the field at path.
+// A value that is not an object, or an object that contains no operator,
+// is an implicit $eq, unless the object is a nested selector for sub fields.
+func parseFieldConditions(path []string, value interface{}) ([]selector, error) {
+	obj, ok := value.(map[string]interface{})
+	if !ok || len(obj) == 0 {
+		return []selector{&fieldSelector{path: path, op: "$eq", arg: value}}, nil
+	}
+
+	operators := 0
+	for name := range obj {
+		if strings.HasPrefix(name, "$") {
+			operators++
+		}
+	}
+	if operators != 0 && operators != len(obj) {
+		return nil, errors.Errorf("field [%s] mixes operators and sub fields", strings.Join(path, "."))
+	}
+
+	var conditions []selector
+	for _, name := range sortedKeys(obj) {
+		arg := obj[name]
+		if operators == 0 {
+			sub, err := parseFieldConditions(append(append([]string{}, path...), name), arg)
+			if err != nil {
+				return nil, err
+			}
+			conditions = append(conditions, sub...)
+			continue
+		}
+
+		condition := &fieldSelector{path: path, op: name, arg: arg}
+		switch name {
+		case "$eq", "$ne", "$gt", "$gte", "$lt", "$lte":
+		case "$in", "$nin":
+			if _, ok := arg.([]interface{}); !ok {
+				return nil, errors.Errorf("%s requires an array", name)
+			}
+		case "$exists":
+			if _, ok := arg.(bool); !ok {
+				return nil, errors.New("$exists requires a boolean")
+			}
+		case "$regex":
+			pattern, ok := arg.(string)
+			if !ok {
+				return nil, errors.New("$regex requires a string")
+			}
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid regular expression [%s]", pattern)
+			}
+			condition.re = re
+		case "$not":
+			sub, err := parseFieldConditions(path, arg)
+			if err != nil {
+				return nil, err
+			}
+			conditions = append(conditions, &notSelector{andSelector(sub)})
+			continue
+		default:
+			return nil, errors.Errorf("unsupported operator [%s]", name)
+		}
+		conditions = append(conditions, condition)
+	}
+	return conditions, nil
+}
+
+// lookupField returns the value of the field at path in doc
+func lookupField(doc map[string]interface{}, key string, path []string) (interface{}, bool) {
+	if len(path) == 1 && path[0] == idField {
+		return key, true
+	}
+
+	var value interface{} = doc
+	for _, name := range path {
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if value, ok = obj[name]; !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// project returns the document holding only the given fields of doc
+func project(doc map[string]interface{}, key string, fields [][]string) map[string]interface{} {
+	result := map[string]interface{}{}
+	for _, path := range fields {
+		if len(path) == 1 && path[0] == idField {
+			continue
+		}
+		value, ok := lookupField(doc, key, path)
+		if !ok {
+			continue
+		}
+		obj := result
+		for _, name := range path[:len(path)-1] {
+			next, ok := obj[name].(map[string]interface{})
+			if !ok {
+				next = map[string]interface{}{}
+				obj[name] = next
+			}
+			obj = next
+		}
+		obj[path[len(path)-1]] = value
+	}
+	return result
+}
+
+// collate compares JSON values following the CouchDB collation order,
+// null < false < true < numbers < strings < arrays < objects. Strings are
+// compared by code point rather than with the Unicode collation algorithm.
+func collate(a, b interface{}) int {
+	ta, tb := typeRank(a), typeRank(b)
+	if ta != tb {
+		return ta - tb
+	}
+
+	switch a := a.(type) {
+	case json.Number:
+		fa, fb := toFloat(a), toFloat(b.(json.Number))
+		switch {
+		case fa < fb:
+			return -1
+		case fa > fb:
+			return 1
+		}
+		return 0
+	case string:
+		return strings.Compare(a, b.(string))
+	case []interface{}:
+		b := b.([]interface{})
+		for i := 0; i < len(a) && i < len(b); i++ {
+			if c := collate(a[i], b[i]); c != 0 {
+				return c
+			}
+		}
+		return len(a) - len(b)
+	case map[string]interface{}:
+		b := b.(map[string]interface{})
+		ka, kb := sortedKeys(a), sortedKeys(b)
+		for i := 0; i < len(ka) && i < len(kb); i++ {
+			if c := strings.Compare(ka[i], kb[i]); c != 0 {
+				return c
+			}
+			if c := collate(a[ka[i]], b[kb[i]]); c != 0 {
+				return c
+			}
+		}
+		return len(ka) - len(kb)
+	}
+	return 0
+}
+
+func typeRank(v interface{}) int {
+	switch v := v.(type) {
+	case nil:
+		return 0
+	case bool:
+		if v {
+			return 2
+		}
+		return 1
+	case json.Number:
+		return 3
+	case string:
+		return 4
+	case []interface{}:
+		return 5
+	default:
+		return 6
+	}
+}
+
+func toFloat(n json.Number) float64 {
+	f, _ := strconv.ParseFloat(n.String(), 64)
+	return f
+}
+
+// decodeJSONObject decodes a JSON object, keeping numbers as json.Number
+// so that they are written back unchanged
+func decodeJSONObject(raw []byte) (map[string]interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	obj := map[string]interface{}{}
+	if err := decoder.Decode(&obj); err != nil {
+		return nil, err
+	}
+	if decoder.More() {
+		return nil, errors.New("unexpected data after JSON object")
+	}
+	return obj, nil
+}
+
+func splitPath(name string) []string {
+	return strings.Split(name, ".")
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// queryBookmark identifies the first result of the next page of a query,
+// by its key and the values of the fields the results are sorted by
+type queryBookmark struct {
+	Key    string        `json:"k"`
+	Values []interface{} `json:"v,omitempty"`
+}
+
+func encodeBookmark(b *queryBookmark) string {
+	raw, err := json.Marshal(b)
+	if err != nil {
+		// values come from decoded JSON documents and can always be encoded
+		panic(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeBookmark(bookmark string) (*queryBookmark, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(bookmark)
+	if err != nil {
+		return nil, errors.Errorf("invalid bookmark [%s]", bookmark)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	b := &queryBookmark{}
+	if err := decoder.Decode(b); err != nil {
+		return nil, errors.Errorf("invalid bookmark [%s]", bookmark)
+	}
+	return b, nil
+}
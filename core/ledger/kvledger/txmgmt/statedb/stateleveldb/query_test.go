@@ -0,0 +1,274 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package stateleveldb
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/ledger/internal/version"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/stretchr/testify/require"
+)
+
+func populateMarbles(t *testing.T, db statedb.VersionedDB) {
+	batch := statedb.NewUpdateBatch()
+	marbles := []string{
+		`{"asset_name":"marble1","color":"blue","size":1,"owner":"tom","tags":["a","b"],"details":{"weight":10}}`,
+		`{"asset_name":"marble2","color":"red","size":2,"owner":"jerry","details":{"weight":30}}`,
+		`{"asset_name":"marble3","color":"blue","size":3,"owner":"fred","tags":["b"]}`,
+		`{"asset_name":"marble4","color":"green","size":4,"owner":"martha","details":{"weight":20}}`,
+		`{"asset_name":"marble5","color":"blue","size":5,"owner":"fred"}`,
+		`{"asset_name":"marble6","color":"red","size":"large","owner":null}`,
+	}
+	for i, m := range marbles {
+		batch.Put("ns", fmt.Sprintf("marble%d", i+1), []byte(m), version.NewHeight(1, uint64(i+1)))
+	}
+	// states that are not JSON objects never match
+	batch.Put("ns", "binary", []byte{0x00, 0x01}, version.NewHeight(1, 10))
+	batch.Put("ns", "array", []byte(`["blue"]`), version.NewHeight(1, 11))
+	batch.Put("ns", "empty", []byte{}, version.NewHeight(1, 12))
+	batch.Put("other", "marble1", []byte(marbles[0]), version.NewHeight(1, 13))
+	require.NoError(t, db.ApplyUpdates(batch, version.NewHeight(1, 13)))
+}
+
+func queryKeys(t *testing.T, db statedb.VersionedDB, query string) []string {
+	itr, err := db.ExecuteQuery("ns", query)
+	require.NoError(t, err)
+	defer itr.Close()
+
+	keys := []string{}
+	for {
+		kv, err := itr.Next()
+		require.NoError(t, err)
+		if kv == nil {
+			return keys
+		}
+		require.Equal(t, "ns", kv.Namespace)
+		keys = append(keys, kv.Key)
+	}
+}
+
+func TestExecuteQuerySelectors(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db, err := env.DBProvider.GetDBHandle("testqueryselectors", nil)
+	require.NoError(t, err)
+	populateMarbles(t, db)
+
+	tests := []struct {
+		query    string
+		expected []string
+	}{
+		{`{"selector":{}}`, []string{"marble1", "marble2", "marble3", "marble4", "marble5", "marble6"}},
+		{`{"selector":{"color":"blue"}}`, []string{"marble1", "marble3", "marble5"}},
+		{`{"selector":{"color":{"$eq":"blue"},"owner":"fred"}}`, []string{"marble3", "marble5"}},
+		{`{"selector":{"color":{"$ne":"blue"}}}`, []string{"marble2", "marble4", "marble6"}},
+		{`{"selector":{"size":{"$gt":2,"$lte":4}}}`, []string{"marble3", "marble4"}},
+		{`{"selector":{"size":{"$gte":5}}}`, []string{"marble5", "marble6"}}, // strings collate after numbers
+		{`{"selector":{"size":{"$lt":2}}}`, []string{"marble1"}},
+		{`{"selector":{"owner":null}}`, []string{"marble6"}},
+		{`{"selector":{"$or":[{"color":"green"},{"owner":"jerry"}]}}`, []string{"marble2", "marble4"}},
+		{`{"selector":{"$and":[{"color":"blue"},{"size":{"$gt":1}}]}}`, []string{"marble3", "marble5"}},
+		{`{"selector":{"$nor":[{"color":"blue"},{"color":"red"}]}}`, []string{"marble4"}},
+		{`{"selector":{"$not":{"color":"blue"}}}`, []string{"marble2", "marble4", "marble6"}},
+		{`{"selector":{"color":{"$not":{"$eq":"blue"}}}}`, []string{"marble2", "marble4", "marble6"}},
+		{`{"selector":{"owner":{"$in":["tom","jerry"]}}}`, []string{"marble1", "marble2"}},
+		{`{"selector":{"owner":{"$nin":["tom","jerry"]}}}`, []string{"marble3", "marble4", "marble5", "marble6"}},
+		{`{"selector":{"tags":{"$in":["b"]}}}`, []string{"marble1", "marble3"}},
+		{`{"selector":{"tags":["b"]}}`, []string{"marble3"}},
+		{`{"selector":{"tags":{"$exists":false}}}`, []string{"marble2", "marble4", "marble5", "marble6"}},
+		{`{"selector":{"asset_name":{"$regex":"^marble[1-3]$"}}}`, []string{"marble1", "marble2", "marble3"}},
+		{`{"selector":{"size":{"$regex":"^l"}}}`, []string{"marble6"}},
+		{`{"selector":{"details.weight":{"$gte":20}}}`, []string{"marble2", "marble4"}},
+		{`{"selector":{"details":{"weight":{"$lt":20}}}}`, []string{"marble1"}},
+		{`{"selector":{"_id":{"$gt":"marble4"}}}`, []string{"marble5", "marble6"}},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.expected, queryKeys(t, db, test.query), "query %s", test.query)
+	}
+
+	// namespaces are isolated
+	itr, err := db.ExecuteQuery("none", `{"selector":{}}`)
+	require.NoError(t, err)
+	kv, err := itr.Next()
+	require.NoError(t, err)
+	require.Nil(t, kv)
+	itr.Close()
+}
+
+func TestExecuteQueryOptions(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db, err := env.DBProvider.GetDBHandle("testqueryoptions", nil)
+	require.NoError(t, err)
+	populateMarbles(t, db)
+
+	t.Run("fields", func(t *testing.T) {
+		itr, err := db.ExecuteQuery("ns", `{"selector":{"owner":"tom"},"fields":["owner","details.weight","missing","_id"]}`)
+		require.NoError(t, err)
+		defer itr.Close()
+		kv, err := itr.Next()
+		require.NoError(t, err)
+		require.Equal(t, "marble1", kv.Key)
+		require.JSONEq(t, `{"owner":"tom","details":{"weight":10}}`, string(kv.Value))
+		require.Equal(t, version.NewHeight(1, 1), kv.Version)
+	})
+
+	t.Run("sort", func(t *testing.T) {
+		// marble6 lacks a numeric size but is sorted after the numbers, and the
+		// marbles without weight are excluded
+		require.Equal(t, []string{"marble6", "marble5", "marble4", "marble3", "marble2", "marble1"},
+			queryKeys(t, db, `{"selector":{},"sort":[{"size":"desc"}]}`))
+		require.Equal(t, []string{"marble1", "marble4", "marble2"},
+			queryKeys(t, db, `{"selector":{},"sort":["details.weight"]}`))
+		require.Equal(t, []string{"marble5", "marble3", "marble1", "marble4", "marble6", "marble2"},
+			queryKeys(t, db, `{"selector":{},"sort":["color",{"size":"desc"}]}`))
+	})
+
+	t.Run("limit", func(t *testing.T) {
+		require.Equal(t, []string{"marble1", "marble3"}, queryKeys(t, db, `{"selector":{"color":"blue"},"limit":2}`))
+	})
+
+	t.Run("pagination", func(t *testing.T) {
+		for _, query := range []string{
+			`{"selector":{"size":{"$gt":0}}}`,
+			`{"selector":{"size":{"$gt":0}},"sort":[{"size":"desc"}]}`,
+		} {
+			all := queryKeys(t, db, query)
+			var paged []string
+			bookmark := ""
+			for {
+				itr, err := db.ExecuteQueryWithPagination("ns", query, bookmark, 2)
+				require.NoError(t, err)
+				count := 0
+				for {
+					kv, err := itr.Next()
+					require.NoError(t, err)
+					if kv == nil {
+						break
+					}
+					count++
+					paged = append(paged, kv.Key)
+				}
+				require.LessOrEqual(t, count, 2)
+				bookmark = itr.GetBookmarkAndClose()
+				if bookmark == "" {
+					break
+				}
+			}
+			require.Equal(t, all, paged, "query %s", query)
+		}
+
+		// the bookmark can also be passed in the query
+		itr, err := db.ExecuteQueryWithPagination("ns", `{"selector":{"color":"blue"}}`, "", 1)
+		require.NoError(t, err)
+		_, err = itr.Next()
+		require.NoError(t, err)
+		bookmark := itr.GetBookmarkAndClose()
+		require.NotEmpty(t, bookmark)
+		require.Equal(t, []string{"marble3", "marble5"},
+			queryKeys(t, db, fmt.Sprintf(`{"selector":{"color":"blue"},"bookmark":%q}`, bookmark)))
+	})
+}
+
+func TestExecuteQueryErrors(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db, err := env.DBProvider.GetDBHandle("testqueryerrors", nil)
+	require.NoError(t, err)
+
+	tests := []struct {
+		query string
+		err   string
+	}{
+		{`not json`, "invalid query: invalid character 'o' in literal null (expecting 'u')"},
+		{`{"fields":["a"]}`, "invalid query: selector is missing"},
+		{`{"selector":[]}`, "invalid query: selector must be an object"},
+		{`{"selector":{},"skip":1}`, "invalid query: unsupported option [skip]"},
+		{`{"selector":{},"limit":-1}`, "invalid query: invalid limit [-1]"},
+		{`{"selector":{},"limit":"1"}`, "invalid query: limit must be a number"},
+		{`{"selector":{},"fields":"a"}`, "invalid query: fields must be an array"},
+		{`{"selector":{},"sort":[{"a":"up"}]}`, "invalid query: invalid sort direction [up] for field [a]"},
+		{`{"selector":{},"sort":[{"a":"asc","b":"asc"}]}`, "invalid query: sort entries must have exactly one field"},
+		{`{"selector":{"$where":"x"}}`, "invalid selector: unsupported operator [$where]"},
+		{`{"selector":{"a":{"$elemMatch":{}}}}`, "invalid selector: unsupported operator [$elemMatch]"},
+		{`{"selector":{"a":{"$in":"b"}}}`, "invalid selector: $in requires an array"},
+		{`{"selector":{"a":{"$exists":1}}}`, "invalid selector: $exists requires a boolean"},
+		{`{"selector":{"a":{"$regex":"("}}}`, "invalid selector: invalid regular expression [(]: error parsing regexp: missing closing ): `(`"},
+		{`{"selector":{"a":{"$gt":1,"b":2}}}`, "invalid selector: field [a] mixes operators and sub fields"},
+		{`{"selector":{"$or":{"a":1}}}`, "invalid selector: $or requires an array of selectors"},
+	}
+	for _, test := range tests {
+		_, err := db.ExecuteQuery("ns", test.query)
+		require.EqualError(t, err, test.err, "query %s", test.query)
+	}
+
+	_, err = db.ExecuteQueryWithPagination("ns", `{"selector":{}}`, "!invalid!", 1)
+	require.EqualError(t, err, "invalid bookmark [!invalid!]")
+}
+
+func TestCollate(t *testing.T) {
+	// values in collation order
+	values := []string{
+		`null`, `false`, `true`, `-10.5`, `-1`, `0`, `2`, `10`, `1e3`,
+		`""`, `"A"`, `"a"`, `"a\u0000"`, `"ab"`, `"b"`,
+		`[]`, `[1]`, `[1,2]`, `[2]`, `["a"]`,
+		`{}`, `{"a":1}`, `{"a":2}`, `{"a":2,"b":1}`, `{"b":0}`,
+	}
+	decoded := make([]interface{}, len(values))
+	for i, v := range values {
+		doc, err := decodeJSONObject([]byte(`{"v":` + v + `}`))
+		require.NoError(t, err)
+		decoded[i] = doc["v"]
+	}
+
+	for i := range decoded {
+		for j := range decoded {
+			expected := 0
+			switch {
+			case i < j:
+				expected = -1
+			case i > j:
+				expected = 1
+			}
+			c := collate(decoded[i], decoded[j])
+			require.Equal(t, expected, sign(c), "collate(%s, %s)", values[i], values[j])
+
+			// the index encoding preserves the collation order
+			ei, ej := appendIndexValue(nil, decoded[i]), appendIndexValue(nil, decoded[j])
+			require.Equal(t, expected, sign(compareBytes(ei, ej)), "encoding of %s and %s", values[i], values[j])
+		}
+	}
+}
+
+func sign(c int) int {
+	switch {
+	case c < 0:
+		return -1
+	case c > 0:
+		return 1
+	}
+	return 0
+}
+
+func compareBytes(a, b []byte) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			return int(a[i]) - int(b[i])
+		}
+	}
+	return len(a) - len(b)
+}
+
+func TestBookmarkEncoding(t *testing.T) {
+	b := &queryBookmark{Key: "key\x00", Values: []interface{}{json.Number("1000007"), "a", nil}}
+	decoded, err := decodeBookmark(encodeBookmark(b))
+	require.NoError(t, err)
+	require.Equal(t, b, decoded)
+}
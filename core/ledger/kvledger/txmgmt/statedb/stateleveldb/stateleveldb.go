@@ -8,6 +8,10 @@ package stateleveldb
 
 import (
 	"bytes"
+	"encoding/json"
+	"sort"
+	"strings"
+	"sync"
 
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/ledger/dataformat"
@@ -83,11 +87,13 @@ func (provider *VersionedDBProvider) Drop(dbName string) error {
 type versionedDB struct {
 	db     *leveldbhelper.DBHandle
 	dbName string
+	// indexLock serializes the creation of indexes with the updates of their entries
+	indexLock sync.Mutex
 }
 
 // newVersionedDB constructs an instance of VersionedDB
 func newVersionedDB(db *leveldbhelper.DBHandle, dbName string) *versionedDB {
-	return &versionedDB{db, dbName}
+	return &versionedDB{db: db, dbName: dbName}
 }
 
 // Open implements method in VersionedDB interface
@@ -173,24 +179,91 @@ func (vdb *versionedDB) GetStateRangeScanIteratorWithPagination(namespace string
 
 // ExecuteQuery implements method in VersionedDB interface
 func (vdb *versionedDB) ExecuteQuery(namespace, query string) (statedb.ResultsIterator, error) {
-	return nil, errors.New("ExecuteQuery not supported for leveldb")
+	return vdb.ExecuteQueryWithPagination(namespace, query, "", 0)
 }
 
-// ExecuteQueryWithPagination implements method in VersionedDB interface
+// ExecuteQueryWithPagination implements method in VersionedDB interface.
+// The query is expressed in a subset of the CouchDB Mango query language and is
+// evaluated over the states of the namespace that hold JSON objects. When an
+// index declared by the chaincode covers the selector, only the states found in
+// the index are evaluated. Results are returned in the order of their keys
+// unless the query has a sort option, in which case all the matching states
+// are sorted in memory.
 func (vdb *versionedDB) ExecuteQueryWithPagination(namespace, query, bookmark string, pageSize int32) (statedb.QueryResultsIterator, error) {
-	return nil, errors.New("ExecuteQueryWithMetadata not supported for leveldb")
+	logger.Debugf("ExecuteQueryWithPagination(). ns=%s, query=%s, bookmark=%s, pageSize=%d", namespace, query, bookmark, pageSize)
+	q, err := parseQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	if bookmark == "" {
+		bookmark = q.bookmark
+	}
+	var position *queryBookmark
+	if bookmark != "" {
+		if position, err = decodeBookmark(bookmark); err != nil {
+			return nil, err
+		}
+	}
+
+	indexes, err := vdb.loadIndexDefinitions(namespace)
+	if err != nil {
+		return nil, err
+	}
+	startKey := ""
+	if position != nil && len(q.sort) == 0 {
+		startKey = position.Key
+	}
+	var source resultSource
+	if plan := planIndex(namespace, indexes, q.selector, q.useIndex); plan != nil {
+		logger.Debugf("Using index [%s] for query on namespace [%s]", plan.index.Name, namespace)
+		keys, err := vdb.keysFromIndex(plan)
+		if err != nil {
+			return nil, err
+		}
+		source = &keysSource{vdb: vdb, namespace: namespace, selector: q.selector, keys: keys, startKey: startKey}
+	} else {
+		dbItr, err := vdb.db.GetIterator(encodeDataKey(namespace, startKey), dataKeyStarterForNextNamespace(namespace))
+		if err != nil {
+			return nil, err
+		}
+		source = &dataSource{dbItr: dbItr, selector: q.selector}
+	}
+	if len(q.sort) > 0 {
+		if source, err = newSortedSource(source, q.sort, position); err != nil {
+			return nil, err
+		}
+	}
+
+	limit := q.limit
+	if pageSize > 0 && (limit == 0 || pageSize < limit) {
+		limit = pageSize
+	}
+	return &queryScanner{namespace: namespace, query: q, source: source, requestedLimit: limit}, nil
 }
 
 // ApplyUpdates implements method in VersionedDB interface
 func (vdb *versionedDB) ApplyUpdates(batch *statedb.UpdateBatch, height *version.Height) error {
+	vdb.indexLock.Lock()
+	defer vdb.indexLock.Unlock()
+
 	dbBatch := vdb.db.NewUpdateBatch()
 	namespaces := batch.GetUpdatedNamespaces()
 	for _, ns := range namespaces {
+		indexes, err := vdb.loadIndexDefinitions(ns)
+		if err != nil {
+			return err
+		}
 		updates := batch.GetUpdates(ns)
 		for k, vv := range updates {
 			dataKey := encodeDataKey(ns, k)
 			logger.Debugf("Channel [%s]: Applying key(string)=[%s] key(bytes)=[%#v]", vdb.dbName, string(dataKey), dataKey)
 
+			if len(indexes) > 0 {
+				if err := vdb.updateIndexes(dbBatch, ns, indexes, k, vv.Value); err != nil {
+					return err
+				}
+			}
+
 			if vv.Value == nil {
 				dbBatch.Delete(dataKey)
 			} else {
@@ -213,6 +286,58 @@ func (vdb *versionedDB) ApplyUpdates(batch *statedb.UpdateBatch, height *version
 	return vdb.db.WriteBatch(dbBatch, true)
 }
 
+// updateIndexes adds to the batch the changes to the index entries of the
+// namespace caused by the update of the state with the given key
+func (vdb *versionedDB) updateIndexes(dbBatch *leveldbhelper.UpdateBatch, ns string, indexes []*indexDefinition, key string, newValue []byte) error {
+	var oldValue []byte
+	oldDBVal, err := vdb.db.Get(encodeDataKey(ns, key))
+	if err != nil {
+		return err
+	}
+	if oldDBVal != nil {
+		vv, err := decodeValue(oldDBVal)
+		if err != nil {
+			return err
+		}
+		oldValue = vv.Value
+	}
+	updateIndexEntries(dbBatch, ns, indexes, key, oldValue, newValue)
+	return nil
+}
+
+// GetDBType implements method in IndexCapable interface
+func (vdb *versionedDB) GetDBType() string {
+	return "leveldb"
+}
+
+// ProcessIndexesForChaincodeDeploy implements method in IndexCapable interface.
+// As for CouchDB, the index files are processed in the order of their names and
+// invalid ones are logged and skipped.
+func (vdb *versionedDB) ProcessIndexesForChaincodeDeploy(namespace string, indexFilesData map[string][]byte) error {
+	vdb.indexLock.Lock()
+	defer vdb.indexLock.Unlock()
+
+	var indexFilesName []string
+	for fileName := range indexFilesData {
+		indexFilesName = append(indexFilesName, fileName)
+	}
+	sort.Strings(indexFilesName)
+	for _, fileName := range indexFilesName {
+		def, err := parseIndexDefinition(fileName, indexFilesData[fileName])
+		if err == nil {
+			err = vdb.createIndex(namespace, def)
+		}
+		if err != nil {
+			logger.Errorf("error creating index from file [%s] for chaincode [%s] on channel [%s]: %+v",
+				fileName, namespace, vdb.dbName, err)
+			continue
+		}
+		logger.Infof("successfully created index present in the file [%s] for chaincode [%s] on channel [%s]",
+			fileName, namespace, vdb.dbName)
+	}
+	return nil
+}
+
 // GetLatestSavePoint implements method in VersionedDB interface
 func (vdb *versionedDB) GetLatestSavePoint() (*version.Height, error) {
 	versionBytes, err := vdb.db.Get(savePointKey)
@@ -349,6 +474,208 @@ func (scanner *kvScanner) GetBookmarkAndClose() string {
 	return retval
 }
 
+// resultSource yields the states matching a query
+type resultSource interface {
+	next() (*queryResult, error)
+	close()
+}
+
+type queryResult struct {
+	key string
+	vv  *statedb.VersionedValue
+	doc map[string]interface{}
+}
+
+// dataSource evaluates the selector over the states of a namespace, in key order
+type dataSource struct {
+	dbItr    iterator.Iterator
+	selector selector
+}
+
+func (s *dataSource) next() (*queryResult, error) {
+	for s.dbItr.Next() {
+		_, key := decodeDataKey(s.dbItr.Key())
+		vv, err := decodeValue(s.dbItr.Value())
+		if err != nil {
+			return nil, err
+		}
+		if doc := jsonDocument(vv.Value); doc != nil && s.selector.matches(doc, key) {
+			return &queryResult{key: key, vv: vv, doc: doc}, nil
+		}
+	}
+	return nil, errors.Wrap(s.dbItr.Error(), "internal leveldb error while retrieving data from db iterator")
+}
+
+func (s *dataSource) close() {
+	s.dbItr.Release()
+}
+
+// keysSource evaluates the selector over the states with the given sorted keys
+type keysSource struct {
+	vdb       *versionedDB
+	namespace string
+	selector  selector
+	keys      []string
+	startKey  string
+}
+
+func (s *keysSource) next() (*queryResult, error) {
+	for len(s.keys) > 0 {
+		key := s.keys[0]
+		s.keys = s.keys[1:]
+		if key < s.startKey {
+			continue
+		}
+		vv, err := s.vdb.GetState(s.namespace, key)
+		if err != nil {
+			return nil, err
+		}
+		if vv == nil {
+			continue
+		}
+		if doc := jsonDocument(vv.Value); doc != nil && s.selector.matches(doc, key) {
+			return &queryResult{key: key, vv: vv, doc: doc}, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *keysSource) close() {}
+
+// sortedSource returns the results of another source in the sort order of a
+// query. As CouchDB, it excludes the results that lack one of the sort fields.
+type sortedSource struct {
+	results []*queryResult
+}
+
+func newSortedSource(source resultSource, sortFields []sortField, position *queryBookmark) (*sortedSource, error) {
+	defer source.close()
+
+	var results []*queryResult
+	for {
+		r, err := source.next()
+		if err != nil {
+			return nil, err
+		}
+		if r == nil {
+			break
+		}
+		if sortValues(r, sortFields) != nil {
+			results = append(results, r)
+		}
+	}
+
+	compare := func(values []interface{}, key string, r *queryResult) int {
+		rValues := sortValues(r, sortFields)
+		for i, f := range sortFields {
+			if i >= len(values) {
+				break
+			}
+			c := collate(values[i], rValues[i])
+			if f.desc {
+				c = -c
+			}
+			if c != 0 {
+				return c
+			}
+		}
+		return strings.Compare(key, r.key)
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return compare(sortValues(results[i], sortFields), results[i].key, results[j]) < 0
+	})
+	if position != nil {
+		// skip the results before the position of the bookmark
+		first := sort.Search(len(results), func(i int) bool {
+			return compare(position.Values, position.Key, results[i]) <= 0
+		})
+		results = results[first:]
+	}
+	return &sortedSource{results: results}, nil
+}
+
+func sortValues(r *queryResult, sortFields []sortField) []interface{} {
+	values := make([]interface{}, len(sortFields))
+	for i, f := range sortFields {
+		v, ok := lookupField(r.doc, r.key, f.path)
+		if !ok {
+			return nil
+		}
+		values[i] = v
+	}
+	return values
+}
+
+func (s *sortedSource) next() (*queryResult, error) {
+	if len(s.results) == 0 {
+		return nil, nil
+	}
+	r := s.results[0]
+	s.results = s.results[1:]
+	return r, nil
+}
+
+func (s *sortedSource) close() {}
+
+type queryScanner struct {
+	namespace            string
+	query                *query
+	source               resultSource
+	requestedLimit       int32
+	totalRecordsReturned int32
+}
+
+func (scanner *queryScanner) Next() (*statedb.VersionedKV, error) {
+	if scanner.requestedLimit > 0 && scanner.totalRecordsReturned >= scanner.requestedLimit {
+		return nil, nil
+	}
+	r, err := scanner.source.next()
+	if err != nil || r == nil {
+		return nil, err
+	}
+
+	vv := r.vv
+	if len(scanner.query.fields) > 0 {
+		value, err := json.Marshal(project(r.doc, r.key, scanner.query.fields))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed marshaling query result")
+		}
+		vv = &statedb.VersionedValue{Value: value, Metadata: vv.Metadata, Version: vv.Version}
+	}
+
+	scanner.totalRecordsReturned++
+	return &statedb.VersionedKV{
+		CompositeKey: &statedb.CompositeKey{
+			Namespace: scanner.namespace,
+			Key:       r.key,
+		},
+		VersionedValue: vv,
+	}, nil
+}
+
+func (scanner *queryScanner) Close() {
+	scanner.source.close()
+}
+
+// GetBookmarkAndClose returns a bookmark identifying the next matching state,
+// or an empty string if there is none
+func (scanner *queryScanner) GetBookmarkAndClose() string {
+	defer scanner.Close()
+	r, err := scanner.source.next()
+	if err != nil {
+		logger.Warningf("Failed retrieving the next query result for the bookmark: %s", err)
+		return ""
+	}
+	if r == nil {
+		return ""
+	}
+	b := &queryBookmark{Key: r.key}
+	if len(scanner.query.sort) > 0 {
+		b.Values = sortValues(r, scanner.query.sort)
+	}
+	return encodeBookmark(b)
+}
+
 type fullDBScanner struct {
 	db     *leveldbhelper.DBHandle
 	dbItr  iterator.Iterator
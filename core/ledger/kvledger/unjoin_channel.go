@@ -27,7 +27,7 @@ import (
 // invoked while the peer is shut down.
 func UnjoinChannel(config *ledger.Config, ledgerID string) error {
 	// Ensure the routine is invoked while the peer is down.
-	fileLock := leveldbhelper.NewFileLock(fileLockPath(config.RootFSPath))
+	fileLock := leveldbhelper.NewFileLock(FileLockPath(config.RootFSPath))
 	if err := fileLock.Lock(); err != nil {
 		return errors.WithMessage(err, "as another peer node command is executing,"+
 			" wait for that command to complete its execution or terminate it before retrying")
@@ -20,7 +20,7 @@ import (
 // ledger databases and upgrades the idStore format.
 func UpgradeDBs(config *ledger.Config) error {
 	rootFSPath := config.RootFSPath
-	fileLockPath := fileLockPath(rootFSPath)
+	fileLockPath := FileLockPath(rootFSPath)
 	fileLock := leveldbhelper.NewFileLock(fileLockPath)
 	if err := fileLock.Lock(); err != nil {
 		return errors.Wrap(err, "as another peer node command is executing,"+
@@ -50,6 +50,8 @@ type Config struct {
 	PrivateDataConfig *PrivateDataConfig
 	// HistoryDBConfig holds the configuration parameters for the transaction history database.
 	HistoryDBConfig *HistoryDBConfig
+	// BlockStoreConfig holds the configuration parameters for the block store.
+	BlockStoreConfig *BlockStoreConfig
 	// SnapshotsConfig holds the configuration parameters for the snapshots.
 	SnapshotsConfig *SnapshotsConfig
 }
@@ -127,10 +129,60 @@ type HistoryDBConfig struct {
 	Enabled bool
 }
 
+// BlockStoreConfig is a structure used to configure the block store.
+type BlockStoreConfig struct {
+	// Compression is the codec used for compressing the blocks appended to the
+	// block files. The supported options are "none" (the default) and "snappy".
+	// The blocks already stored remain readable when the compression is changed.
+	Compression string
+	// ArchiveDir is the directory to which the archived block files are moved.
+	// The archived block files are deleted if it is empty.
+	ArchiveDir string
+}
+
 // SnapshotsConfig is a structure used to configure snapshot function
 type SnapshotsConfig struct {
 	// RootDir is the top-level directory for the snapshots.
 	RootDir string
+	// Schedule is the periodic snapshot schedule of the channels that have no entry
+	// in ChannelSchedules. Snapshots are only generated on request when it is nil.
+	Schedule *SnapshotSchedule
+	// ChannelSchedules holds the periodic snapshot schedules that replace Schedule
+	// for the given channels.
+	ChannelSchedules map[string]*SnapshotSchedule
+}
+
+// ScheduleForChannel returns the periodic snapshot schedule of the given channel,
+// or nil if snapshots are only generated on request.
+func (c *SnapshotsConfig) ScheduleForChannel(channelID string) *SnapshotSchedule {
+	if schedule, ok := c.ChannelSchedules[channelID]; ok {
+		return schedule
+	}
+	return c.Schedule
+}
+
+// SnapshotSchedule is a structure used to configure the periodic generation of snapshots
+type SnapshotSchedule struct {
+	// BlockInterval generates a snapshot at every block whose number is a multiple of it.
+	// Zero disables the block interval.
+	BlockInterval uint64
+	// TimeInterval generates a snapshot at the first block committed once the interval has
+	// elapsed since the last snapshot scheduled by it. Zero disables the time interval.
+	TimeInterval time.Duration
+	// Retain is the number of most recent snapshots generated by the schedule that are kept when a new
+	// snapshot is generated, the older ones are removed. The snapshots requested by an admin are never
+	// removed. Zero keeps all snapshots.
+	Retain uint
+}
+
+// SnapshotScheduleInfo describes the periodic snapshot schedule of a ledger and its progress
+type SnapshotScheduleInfo struct {
+	// Schedule is the configured schedule, nil if snapshots are only generated on request.
+	Schedule *SnapshotSchedule
+	// NextBlockNumber is the block number of the next snapshot scheduled by the block interval.
+	NextBlockNumber uint64
+	// NextTime is the time after which the next snapshot is scheduled by the time interval.
+	NextTime time.Time
 }
 
 // PeerLedgerProvider provides handle to ledger instances
@@ -217,6 +269,15 @@ type PeerLedger interface {
 	CancelSnapshotRequest(height uint64) error
 	// PendingSnapshotRequests returns a list of heights for the pending (or under processing) snapshot requests.
 	PendingSnapshotRequests() ([]uint64, error)
+	// SnapshotSchedule returns the periodic snapshot schedule of the ledger. The snapshot requests
+	// added by the schedule are included in the PendingSnapshotRequests.
+	SnapshotSchedule() (*SnapshotScheduleInfo, error)
+	// ArchiveBlocks archives the block files that contain only blocks below the specified block number.
+	// The block files are moved to the archiveDir or, if archiveDir is empty, deleted. It returns an error
+	// if the ledger does not have a completed snapshot at the specified block number or above.
+	// Once archived, retrieving the blocks, or the transactions in them, returns an error.
+	// It returns the number of the first block available in the ledger after archiving.
+	ArchiveBlocks(blockNum uint64, archiveDir string) (uint64, error)
 	// CommitNotificationsChannel returns a read-only channel on which ledger sends a `CommitNotification`
 	// when a block is committed. The CommitNotification contains entries for the transactions from the committed block,
 	// which are not malformed, carry a legitimate TxID, and in addition, are not marked as a duplicate transaction.
@@ -9,6 +9,7 @@ package ledgermgmt
 import (
 	"bytes"
 	"fmt"
+	"os"
 	"sync"
 
 	"github.com/hyperledger/fabric-protos-go/common"
@@ -160,6 +161,41 @@ func (m *LedgerMgr) CreateLedgerFromSnapshot(snapshotDir string, channelCallback
 	return nil
 }
 
+// CreateLedgerFromFetchedSnapshot creates a new ledger with a snapshot that is fetched by the fetch function,
+// such as from another peer, and executes the callback function after the ledger is created. Like
+// CreateLedgerFromSnapshot, this function launches a goroutine to fetch the snapshot, create the ledger and call
+// the callback func, and the snapshot source is reported as the bootstrapping snapshot dir while it is in progress.
+// The fetched snapshot dir is removed after the ledger is created from it. It returns an error if another ledger is
+// being created from a snapshot.
+func (m *LedgerMgr) CreateLedgerFromFetchedSnapshot(snapshotSource string, fetch func() (string, error), channelCallback func(ledger.PeerLedger, string)) error {
+	if err := m.setJoinBySnapshotStatus(snapshotSource); err != nil {
+		return err
+	}
+
+	go func() {
+		defer m.resetJoinBySnapshotStatus()
+
+		snapshotDir, err := fetch()
+		if err != nil {
+			logger.Errorw("Error fetching snapshot", "snapshotSource", snapshotSource, "error", err)
+			return
+		}
+
+		ledger, cid, err := m.createFromSnapshot(snapshotDir)
+		if err != nil {
+			logger.Errorw("Error creating ledger from snapshot", "snapshotDir", snapshotDir, "error", err)
+			return
+		}
+		if err := os.RemoveAll(snapshotDir); err != nil {
+			logger.Warnw("Error removing fetched snapshot", "snapshotDir", snapshotDir, "error", err)
+		}
+
+		channelCallback(ledger, cid)
+	}()
+
+	return nil
+}
+
 func (m *LedgerMgr) createFromSnapshot(snapshotDir string) (ledger.PeerLedger, string, error) {
 	m.lock.Lock()
 	defer m.lock.Unlock()
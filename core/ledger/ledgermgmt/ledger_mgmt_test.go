@@ -23,6 +23,7 @@ import (
 	"github.com/hyperledger/fabric/core/ledger/kvledger"
 	"github.com/hyperledger/fabric/core/ledger/mock"
 	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 )
 
@@ -167,6 +168,75 @@ func TestCreateLedgerFromSnapshot(t *testing.T) {
 	})
 }
 
+func TestCreateLedgerFromFetchedSnapshot(t *testing.T) {
+	initializer, lgrMgr, cleanup := setup(t)
+	defer cleanup()
+
+	channelID := "testcreatefromfetchedsnapshot"
+	snapshotDir, _ := generateSnapshot(t, lgrMgr, initializer, channelID)
+
+	t.Run("create_ledger_from_fetched_snapshot", func(t *testing.T) {
+		_, ledgerMgr, cleanup := setup(t)
+		defer cleanup()
+
+		fetchedSnapshotDir := filepath.Join(t.TempDir(), "fetched")
+		require.NoError(t, testutil.CopyDir(snapshotDir, fetchedSnapshotDir, true))
+
+		waitCh := make(chan struct{})
+		fetch := func() (string, error) {
+			<-waitCh
+			return fetchedSnapshotDir, nil
+		}
+		callbackCounter := 0
+		callback := func(l ledger.PeerLedger, cid string) { callbackCounter++ }
+
+		require.NoError(t, ledgerMgr.CreateLedgerFromFetchedSnapshot("peer0:7051", fetch, callback))
+		status := ledgerMgr.JoinBySnapshotStatus()
+		require.True(t, status.InProgress)
+		require.Equal(t, "peer0:7051", status.BootstrappingSnapshotDir)
+
+		// concurrent CreateLedgerFromSnapshot call should fail while the snapshot is being fetched
+		require.EqualError(t, ledgerMgr.CreateLedgerFromSnapshot(snapshotDir, callback),
+			"a ledger is being created from a snapshot at peer0:7051. Call ledger creation again after it is done.")
+
+		close(waitCh)
+		ledgerCreated := func() bool {
+			status := ledgerMgr.JoinBySnapshotStatus()
+			return !status.InProgress && status.BootstrappingSnapshotDir == ""
+		}
+		require.Eventually(t, ledgerCreated, time.Minute, 100*time.Millisecond)
+		require.Equal(t, 1, callbackCounter)
+
+		ledgerids, err := ledgerMgr.GetLedgerIDs()
+		require.NoError(t, err)
+		require.Equal(t, []string{channelID}, ledgerids)
+		require.NoDirExists(t, fetchedSnapshotDir)
+	})
+
+	t.Run("callback_func_is_not_called_if_fetch_failed", func(t *testing.T) {
+		_, ledgerMgr, cleanup := setup(t)
+		defer cleanup()
+
+		fetch := func() (string, error) {
+			return "", errors.New("fetch-error")
+		}
+		callbackCounter := 0
+		callback := func(l ledger.PeerLedger, cid string) { callbackCounter++ }
+
+		require.NoError(t, ledgerMgr.CreateLedgerFromFetchedSnapshot("peer0:7051", fetch, callback))
+		ledgerCreated := func() bool {
+			status := ledgerMgr.JoinBySnapshotStatus()
+			return !status.InProgress && status.BootstrappingSnapshotDir == ""
+		}
+		require.Eventually(t, ledgerCreated, time.Minute, 100*time.Millisecond)
+		require.Equal(t, 0, callbackCounter)
+
+		ids, err := ledgerMgr.GetLedgerIDs()
+		require.NoError(t, err)
+		require.Empty(t, ids)
+	})
+}
+
 func TestConcurrentCreateLedgerFromGB(t *testing.T) {
 	_, ledgerMgr, cleanup := setup(t)
 	defer cleanup()
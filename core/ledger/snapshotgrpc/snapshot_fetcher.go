@@ -0,0 +1,254 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package snapshotgrpc
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/common/configtx"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/hyperledger/fabric/core/ledger/snapshotgrpc/snapshotext"
+	"github.com/hyperledger/fabric/internal/pkg/identity"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+)
+
+var logger = flogging.MustGetLogger("snapshotgrpc")
+
+// Dialer connects to a remote peer.
+type Dialer interface {
+	Dial(address string) (*grpc.ClientConn, error)
+}
+
+// Fetcher fetches the completed snapshots of a channel from a peer of the same
+// organization.
+type Fetcher struct {
+	Dialer       Dialer
+	Signer       identity.SignerSerializer
+	HashProvider ledger.HashProvider
+	// FetchedSnapshotsDir is the dir into which the snapshots are fetched. A snapshot
+	// is fetched into the <channel ID>/<block number> sub dir.
+	FetchedSnapshotsDir string
+}
+
+// Fetch fetches the completed snapshot of a channel at the given block number, or the
+// most recent completed snapshot of the channel when the block number is 0, from the
+// peer at the given address and returns the dir of the fetched snapshot.
+// The files fetched completely by a previous call are not fetched again and the files
+// fetched partially are resumed from where they were left. The fetched files are verified
+// against the hashes in the snapshot signable metadata and a file that does not match its
+// hash is removed so that it is fetched again by a subsequent call.
+func (f *Fetcher) Fetch(address, channelID string, blockNumber uint64) (string, error) {
+	if err := configtx.ValidateChannelID(channelID); err != nil {
+		return "", err
+	}
+
+	conn, err := f.Dialer.Dial(address)
+	if err != nil {
+		return "", errors.WithMessagef(err, "failed to connect to peer %s", address)
+	}
+	defer conn.Close()
+	client := snapshotext.NewSnapshotExtensionsClient(conn)
+
+	signedRequest, err := f.signedFetchRequest(channelID, blockNumber, "", 0)
+	if err != nil {
+		return "", err
+	}
+	listResponse, err := client.ListSnapshotFiles(context.Background(), signedRequest)
+	if err != nil {
+		return "", errors.WithMessagef(err, "failed to list the snapshot files of channel %s from peer %s", channelID, address)
+	}
+	blockNumber = listResponse.BlockNumber
+
+	snapshotDir, err := f.prepareSnapshotDir(channelID, blockNumber)
+	if err != nil {
+		return "", err
+	}
+
+	logger.Infow("Fetching snapshot", "peer", address, "channelID", channelID, "blockNumber", blockNumber, "snapshotDir", snapshotDir)
+	for _, file := range listResponse.Files {
+		if err := f.fetchFile(client, channelID, blockNumber, snapshotDir, file); err != nil {
+			return "", errors.WithMessagef(err, "failed to fetch snapshot file %s of channel %s from peer %s", file.Name, channelID, address)
+		}
+	}
+
+	if err := f.verify(snapshotDir, channelID, blockNumber); err != nil {
+		return "", errors.WithMessagef(err, "failed to verify the snapshot of channel %s fetched from peer %s", channelID, address)
+	}
+	logger.Infow("Fetched snapshot", "peer", address, "channelID", channelID, "blockNumber", blockNumber, "snapshotDir", snapshotDir)
+
+	return snapshotDir, nil
+}
+
+// prepareSnapshotDir creates the dir for the snapshot of the channel at the given block
+// number, if it does not exist, and removes the snapshots of the channel at other block
+// numbers that were left by a previous fetch.
+func (f *Fetcher) prepareSnapshotDir(channelID string, blockNumber uint64) (string, error) {
+	channelDir := filepath.Join(f.FetchedSnapshotsDir, channelID)
+	snapshotDirName := strconv.FormatUint(blockNumber, 10)
+
+	entries, err := os.ReadDir(channelDir)
+	if err != nil && !os.IsNotExist(err) {
+		return "", errors.Wrapf(err, "failed to read dir %s", channelDir)
+	}
+	for _, entry := range entries {
+		if entry.Name() == snapshotDirName {
+			continue
+		}
+		if err := os.RemoveAll(filepath.Join(channelDir, entry.Name())); err != nil {
+			return "", errors.Wrapf(err, "failed to remove stale snapshot %s", filepath.Join(channelDir, entry.Name()))
+		}
+	}
+
+	snapshotDir := filepath.Join(channelDir, snapshotDirName)
+	if err := os.MkdirAll(snapshotDir, 0o755); err != nil {
+		return "", errors.Wrapf(err, "failed to create dir %s", snapshotDir)
+	}
+	return snapshotDir, nil
+}
+
+// fetchFile fetches a snapshot file into the snapshot dir, resuming from the size of the
+// file already fetched.
+func (f *Fetcher) fetchFile(client snapshotext.SnapshotExtensionsClient, channelID string, blockNumber uint64, snapshotDir string, file *snapshotext.SnapshotFile) error {
+	if file.Name == "" || filepath.Base(file.Name) != file.Name {
+		return errors.Errorf("invalid snapshot file name [%s]", file.Name)
+	}
+	filePath := filepath.Join(snapshotDir, file.Name)
+
+	var offset uint64
+	fileInfo, err := os.Stat(filePath)
+	switch {
+	case os.IsNotExist(err):
+	case err != nil:
+		return errors.Wrapf(err, "failed to stat file %s", filePath)
+	case uint64(fileInfo.Size()) == file.Size:
+		logger.Debugw("Snapshot file already fetched", "file", filePath)
+		return nil
+	case uint64(fileInfo.Size()) < file.Size:
+		offset = uint64(fileInfo.Size())
+	default:
+		if err := os.Remove(filePath); err != nil {
+			return errors.Wrapf(err, "failed to remove file %s", filePath)
+		}
+	}
+
+	signedRequest, err := f.signedFetchRequest(channelID, blockNumber, file.Name, offset)
+	if err != nil {
+		return err
+	}
+	stream, err := client.FetchSnapshotFile(context.Background(), signedRequest)
+	if err != nil {
+		return err
+	}
+
+	out, err := os.OpenFile(filePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open file %s", filePath)
+	}
+	defer out.Close()
+
+	if offset != 0 {
+		logger.Infow("Resuming fetch of snapshot file", "file", filePath, "offset", offset)
+	}
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if chunk.Offset != offset {
+			return errors.Errorf("unexpected chunk offset %d, expected offset %d", chunk.Offset, offset)
+		}
+		if _, err := out.Write(chunk.Data); err != nil {
+			return errors.Wrapf(err, "failed to write file %s", filePath)
+		}
+		offset += uint64(len(chunk.Data))
+	}
+
+	if offset != file.Size {
+		return errors.Errorf("fetched %d bytes, expected %d bytes", offset, file.Size)
+	}
+	return out.Sync()
+}
+
+// verify verifies the fetched snapshot files against the hashes in the snapshot
+// signable metadata.
+func (f *Fetcher) verify(snapshotDir, channelID string, blockNumber uint64) error {
+	metadataBytes, err := os.ReadFile(filepath.Join(snapshotDir, kvledger.SnapshotSignableMetadataFileName))
+	if err != nil {
+		return errors.Wrap(err, "failed to read snapshot signable metadata")
+	}
+	metadata := &kvledger.SnapshotSignableMetadata{}
+	if err := json.Unmarshal(metadataBytes, metadata); err != nil {
+		return errors.Wrap(err, "failed to unmarshal snapshot signable metadata")
+	}
+
+	if metadata.ChannelName != channelID {
+		return errors.Errorf("snapshot is for channel %s", metadata.ChannelName)
+	}
+	if metadata.LastBlockNumber != blockNumber {
+		return errors.Errorf("snapshot is at block number %d, expected block number %d", metadata.LastBlockNumber, blockNumber)
+	}
+
+	for file, hash := range metadata.FilesAndHashes {
+		if err := kvledger.VerifySnapshotFileHash(snapshotDir, file, hash, f.HashProvider); err != nil {
+			if rmErr := os.Remove(filepath.Join(snapshotDir, file)); rmErr != nil && !os.IsNotExist(rmErr) {
+				logger.Warnw("Failed to remove snapshot file", "file", file, "error", rmErr)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *Fetcher) signedFetchRequest(channelID string, blockNumber uint64, fileName string, offset uint64) (*pb.SignedSnapshotRequest, error) {
+	creator, err := f.Signer.Serialize()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to serialize identity")
+	}
+	nonce, err := protoutil.CreateNonce()
+	if err != nil {
+		return nil, err
+	}
+
+	request := &snapshotext.FetchSnapshotRequest{
+		SignatureHeader: &cb.SignatureHeader{
+			Creator: creator,
+			Nonce:   nonce,
+		},
+		ChannelId:   channelID,
+		BlockNumber: blockNumber,
+		FileName:    fileName,
+		Offset:      offset,
+	}
+	requestBytes, err := proto.Marshal(request)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal snapshot request")
+	}
+	signature, err := f.Signer.Sign(requestBytes)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to sign snapshot request")
+	}
+
+	return &pb.SignedSnapshotRequest{
+		Request:   requestBytes,
+		Signature: signature,
+	}, nil
+}
@@ -0,0 +1,375 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package snapshotgrpc
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/bccsp/sw"
+	"github.com/hyperledger/fabric/common/configtx/test"
+	"github.com/hyperledger/fabric/core/aclmgmt/resources"
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/hyperledger/fabric/core/ledger/ledgermgmt"
+	"github.com/hyperledger/fabric/core/ledger/ledgermgmt/ledgermgmttest"
+	"github.com/hyperledger/fabric/core/ledger/snapshotgrpc/mock"
+	"github.com/hyperledger/fabric/core/ledger/snapshotgrpc/snapshotext"
+	"github.com/hyperledger/fabric/internal/pkg/comm"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/hyperledger/fabric/protoutil/fakes"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestListSnapshotFiles(t *testing.T) {
+	snapshotsRootDir, ledgerID := generateTestSnapshot(t)
+	snapshotDir := kvledger.SnapshotDirForLedgerBlockNum(snapshotsRootDir, ledgerID, 0)
+
+	fakeACLProvider := &mock.ACLProvider{}
+	snapshotSvc := &SnapshotService{ACLProvider: fakeACLProvider, SnapshotsRootDir: snapshotsRootDir}
+
+	entries, err := os.ReadDir(snapshotDir)
+	require.NoError(t, err)
+	expectedResponse := &snapshotext.ListSnapshotFilesResponse{BlockNumber: 0}
+	for _, entry := range entries {
+		fileInfo, err := entry.Info()
+		require.NoError(t, err)
+		expectedResponse.Files = append(expectedResponse.Files, &snapshotext.SnapshotFile{
+			Name: fileInfo.Name(),
+			Size: uint64(fileInfo.Size()),
+		})
+	}
+	require.NotEmpty(t, expectedResponse.Files)
+
+	resp, err := snapshotSvc.ListSnapshotFiles(context.Background(), createSignedFetchRequest(ledgerID, 0, "", 0))
+	require.NoError(t, err)
+	require.True(t, proto.Equal(expectedResponse, resp))
+
+	resName, _ := fakeACLProvider.CheckACLNoChannelArgsForCall(0)
+	require.Equal(t, resources.Snapshot_fetch, resName)
+
+	// the most recent completed snapshot is listed when the block number is 0
+	require.NoError(t, os.Rename(snapshotDir, kvledger.SnapshotDirForLedgerBlockNum(snapshotsRootDir, ledgerID, 10)))
+	resp, err = snapshotSvc.ListSnapshotFiles(context.Background(), createSignedFetchRequest(ledgerID, 0, "", 0))
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), resp.BlockNumber)
+	resp, err = snapshotSvc.ListSnapshotFiles(context.Background(), createSignedFetchRequest(ledgerID, 10, "", 0))
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), resp.BlockNumber)
+}
+
+func TestFetchSnapshotFileErrors(t *testing.T) {
+	snapshotsRootDir, ledgerID := generateTestSnapshot(t)
+
+	fakeACLProvider := &mock.ACLProvider{}
+	snapshotSvc := &SnapshotService{ACLProvider: fakeACLProvider, SnapshotsRootDir: snapshotsRootDir}
+
+	tests := []struct {
+		name          string
+		signedRequest *pb.SignedSnapshotRequest
+		errMsg        string
+	}{
+		{
+			name:          "unmarshal error",
+			signedRequest: &pb.SignedSnapshotRequest{Request: []byte("dummy")},
+			errMsg:        "failed to unmarshal snapshot request",
+		},
+		{
+			name:          "missing signature header",
+			signedRequest: &pb.SignedSnapshotRequest{Request: protoutil.MarshalOrPanic(&snapshotext.FetchSnapshotRequest{})},
+			errMsg:        "missing signature header",
+		},
+		{
+			name:          "missing channel ID",
+			signedRequest: createSignedFetchRequest("", 0, kvledger.SnapshotSignableMetadataFileName, 0),
+			errMsg:        "missing channel ID",
+		},
+		{
+			name:          "invalid channel ID",
+			signedRequest: createSignedFetchRequest("../"+ledgerID, 0, kvledger.SnapshotSignableMetadataFileName, 0),
+			errMsg:        "'../" + ledgerID + "' contains illegal characters",
+		},
+		{
+			name:          "no completed snapshot",
+			signedRequest: createSignedFetchRequest("otherchannel", 0, kvledger.SnapshotSignableMetadataFileName, 0),
+			errMsg:        "no completed snapshot exists for channel otherchannel",
+		},
+		{
+			name:          "no completed snapshot at block number",
+			signedRequest: createSignedFetchRequest(ledgerID, 5, kvledger.SnapshotSignableMetadataFileName, 0),
+			errMsg:        "no completed snapshot exists for channel " + ledgerID + " at block number 5",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := snapshotSvc.ListSnapshotFiles(context.Background(), test.signedRequest)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), test.errMsg)
+			err = snapshotSvc.FetchSnapshotFile(test.signedRequest, &fakeFetchSnapshotFileServer{})
+			require.Error(t, err)
+			require.Contains(t, err.Error(), test.errMsg)
+		})
+	}
+
+	for _, fileName := range []string{"", "../" + kvledger.SnapshotSignableMetadataFileName} {
+		err := snapshotSvc.FetchSnapshotFile(createSignedFetchRequest(ledgerID, 0, fileName, 0), &fakeFetchSnapshotFileServer{})
+		require.EqualError(t, err, "invalid snapshot file name ["+fileName+"]")
+	}
+
+	err := snapshotSvc.FetchSnapshotFile(createSignedFetchRequest(ledgerID, 0, "nonexistent", 0), &fakeFetchSnapshotFileServer{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to open snapshot file nonexistent")
+
+	fakeACLProvider.CheckACLNoChannelReturns(errors.New("fake-check-acl-error"))
+	_, err = snapshotSvc.ListSnapshotFiles(context.Background(), createSignedFetchRequest(ledgerID, 0, "", 0))
+	require.EqualError(t, err, "fake-check-acl-error")
+	err = snapshotSvc.FetchSnapshotFile(createSignedFetchRequest(ledgerID, 0, kvledger.SnapshotSignableMetadataFileName, 0), &fakeFetchSnapshotFileServer{})
+	require.EqualError(t, err, "fake-check-acl-error")
+
+	fakeACLProvider.CheckACLNoChannelReturns(nil)
+	fakeServer := &fakeFetchSnapshotFileServer{sendErr: errors.New("fake-send-error")}
+	err = snapshotSvc.FetchSnapshotFile(createSignedFetchRequest(ledgerID, 0, kvledger.SnapshotSignableMetadataFileName, 0), fakeServer)
+	require.EqualError(t, err, "fake-send-error")
+}
+
+func TestFetcher(t *testing.T) {
+	defer func(chunkSize int) { snapshotFileChunkSize = chunkSize }(snapshotFileChunkSize)
+	snapshotFileChunkSize = 16
+
+	snapshotsRootDir, ledgerID := generateTestSnapshot(t)
+	snapshotDir := kvledger.SnapshotDirForLedgerBlockNum(snapshotsRootDir, ledgerID, 0)
+
+	fakeACLProvider := &mock.ACLProvider{}
+	snapshotSvc := &SnapshotService{ACLProvider: fakeACLProvider, SnapshotsRootDir: snapshotsRootDir}
+	server, err := comm.NewGRPCServer("127.0.0.1:0", comm.ServerConfig{})
+	require.NoError(t, err)
+	snapshotext.RegisterSnapshotExtensionsServer(server.Server(), snapshotSvc)
+	go server.Start()
+	defer server.Stop()
+
+	cryptoProvider, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewDummyKeyStore())
+	require.NoError(t, err)
+	fakeSigner := &fakes.SignerSerializer{}
+	fakeSigner.SerializeReturns([]byte("creator"), nil)
+	fakeSigner.SignReturns([]byte("signature"), nil)
+	fetchedSnapshotsDir := filepath.Join(t.TempDir(), "fetched")
+	fetcher := &Fetcher{
+		Dialer:              comm.ClientConfig{DialTimeout: 5 * time.Second},
+		Signer:              fakeSigner,
+		HashProvider:        cryptoProvider,
+		FetchedSnapshotsDir: fetchedSnapshotsDir,
+	}
+	expectedSnapshotDir := filepath.Join(fetchedSnapshotsDir, ledgerID, "0")
+
+	// a stale snapshot of the channel left by a previous fetch is removed
+	staleSnapshotDir := filepath.Join(fetchedSnapshotsDir, ledgerID, "100")
+	require.NoError(t, os.MkdirAll(staleSnapshotDir, 0o755))
+
+	fetchedDir, err := fetcher.Fetch(server.Address(), ledgerID, 0)
+	require.NoError(t, err)
+	require.Equal(t, expectedSnapshotDir, fetchedDir)
+	requireSameFiles(t, snapshotDir, fetchedDir)
+	require.NoDirExists(t, staleSnapshotDir)
+
+	resName, idinfo := fakeACLProvider.CheckACLNoChannelArgsForCall(0)
+	require.Equal(t, resources.Snapshot_fetch, resName)
+	signedData := idinfo.([]*protoutil.SignedData)
+	require.Equal(t, []byte("creator"), signedData[0].Identity)
+	require.Equal(t, []byte("signature"), signedData[0].Signature)
+
+	t.Run("resume", func(t *testing.T) {
+		// truncate a fetched data file and remove the metadata file
+		entries, err := os.ReadDir(fetchedDir)
+		require.NoError(t, err)
+		var partialFile *os.File
+		var partialFileSize int64
+		for _, entry := range entries {
+			fileInfo, err := entry.Info()
+			require.NoError(t, err)
+			if !strings.HasPrefix(fileInfo.Name(), "_") && fileInfo.Size() > int64(2*snapshotFileChunkSize) {
+				partialFileSize = fileInfo.Size() / 2
+				partialFile, err = os.OpenFile(filepath.Join(fetchedDir, fileInfo.Name()), os.O_WRONLY, 0o644)
+				require.NoError(t, err)
+				require.NoError(t, partialFile.Truncate(partialFileSize))
+				require.NoError(t, partialFile.Close())
+				break
+			}
+		}
+		require.NotNil(t, partialFile)
+		require.NoError(t, os.Remove(filepath.Join(fetchedDir, kvledger.SnapshotSignableMetadataFileName)))
+
+		numACLChecks := fakeACLProvider.CheckACLNoChannelCallCount()
+		fetchedDir, err = fetcher.Fetch(server.Address(), ledgerID, 0)
+		require.NoError(t, err)
+		requireSameFiles(t, snapshotDir, fetchedDir)
+
+		// only the file list, the partial file and the removed file are fetched
+		require.Equal(t, numACLChecks+3, fakeACLProvider.CheckACLNoChannelCallCount())
+		fetchedFiles := map[string]uint64{}
+		for i := numACLChecks + 1; i < numACLChecks+3; i++ {
+			_, idinfo := fakeACLProvider.CheckACLNoChannelArgsForCall(i)
+			request := &snapshotext.FetchSnapshotRequest{}
+			require.NoError(t, proto.Unmarshal(idinfo.([]*protoutil.SignedData)[0].Data, request))
+			fetchedFiles[request.FileName] = request.Offset
+		}
+		require.Equal(t, map[string]uint64{
+			filepath.Base(partialFile.Name()):         uint64(partialFileSize),
+			kvledger.SnapshotSignableMetadataFileName: 0,
+		}, fetchedFiles)
+	})
+
+	t.Run("hash mismatch", func(t *testing.T) {
+		// corrupt a fetched data file without changing its size so that it is not fetched again
+		entries, err := os.ReadDir(fetchedDir)
+		require.NoError(t, err)
+		var corruptedFile string
+		for _, entry := range entries {
+			fileInfo, err := entry.Info()
+			require.NoError(t, err)
+			if !strings.HasPrefix(fileInfo.Name(), "_") && fileInfo.Size() >= 4 {
+				corruptedFile = fileInfo.Name()
+				break
+			}
+		}
+		require.NotEmpty(t, corruptedFile)
+		f, err := os.OpenFile(filepath.Join(fetchedDir, corruptedFile), os.O_WRONLY, 0o644)
+		require.NoError(t, err)
+		_, err = f.WriteAt([]byte{0xff, 0xff, 0xff, 0xff}, 0)
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+
+		_, err = fetcher.Fetch(server.Address(), ledgerID, 0)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to verify the snapshot of channel "+ledgerID)
+		require.Contains(t, err.Error(), "hash mismatch for file ["+corruptedFile+"]")
+		require.NoFileExists(t, filepath.Join(fetchedDir, corruptedFile))
+
+		// the corrupted file is fetched again by a subsequent fetch
+		fetchedDir, err = fetcher.Fetch(server.Address(), ledgerID, 0)
+		require.NoError(t, err)
+		requireSameFiles(t, snapshotDir, fetchedDir)
+	})
+
+	t.Run("snapshot metadata mismatch", func(t *testing.T) {
+		// serve the snapshot of block 0 as the snapshot of block 10
+		require.NoError(t, os.Rename(snapshotDir, kvledger.SnapshotDirForLedgerBlockNum(snapshotsRootDir, ledgerID, 10)))
+		defer os.Rename(kvledger.SnapshotDirForLedgerBlockNum(snapshotsRootDir, ledgerID, 10), snapshotDir)
+
+		_, err := fetcher.Fetch(server.Address(), ledgerID, 10)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "snapshot is at block number 0, expected block number 10")
+		require.NoDirExists(t, expectedSnapshotDir)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := fetcher.Fetch(server.Address(), "../"+ledgerID, 0)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "contains illegal characters")
+
+		_, err = fetcher.Fetch(server.Address(), "otherchannel", 0)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to list the snapshot files of channel otherchannel from peer "+server.Address())
+		require.Contains(t, err.Error(), "no completed snapshot exists for channel otherchannel")
+
+		fakeSigner.SerializeReturns(nil, errors.New("fake-serialize-error"))
+		_, err = fetcher.Fetch(server.Address(), ledgerID, 0)
+		require.EqualError(t, err, "failed to serialize identity: fake-serialize-error")
+		fakeSigner.SerializeReturns([]byte("creator"), nil)
+
+		fakeSigner.SignReturns(nil, errors.New("fake-sign-error"))
+		_, err = fetcher.Fetch(server.Address(), ledgerID, 0)
+		require.EqualError(t, err, "failed to sign snapshot request: fake-sign-error")
+		fakeSigner.SignReturns([]byte("signature"), nil)
+
+		fetcher.Dialer = comm.ClientConfig{DialTimeout: 100 * time.Millisecond}
+		server.Stop()
+		_, err = fetcher.Fetch(server.Address(), ledgerID, 0)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to connect to peer "+server.Address())
+	})
+}
+
+func generateTestSnapshot(t *testing.T) (string, string) {
+	ledgerID := "testfetchsnapshot"
+	ledgermgmtInitializer := ledgermgmttest.NewInitializer(t.TempDir())
+	ledgerMgr := ledgermgmt.NewLedgerMgr(ledgermgmtInitializer)
+	defer ledgerMgr.Close()
+
+	gb, err := test.MakeGenesisBlock(ledgerID)
+	require.NoError(t, err)
+	lgr, err := ledgerMgr.CreateLedger(ledgerID, gb)
+	require.NoError(t, err)
+
+	require.NoError(t, lgr.SubmitSnapshotRequest(0))
+	require.Eventually(t, func() bool {
+		pendingRequests, err := lgr.PendingSnapshotRequests()
+		require.NoError(t, err)
+		return len(pendingRequests) == 0
+	}, time.Minute, 100*time.Millisecond)
+
+	return ledgermgmtInitializer.Config.SnapshotsConfig.RootDir, ledgerID
+}
+
+func requireSameFiles(t *testing.T, expectedDir, actualDir string) {
+	expectedEntries, err := os.ReadDir(expectedDir)
+	require.NoError(t, err)
+	actualEntries, err := os.ReadDir(actualDir)
+	require.NoError(t, err)
+	require.Len(t, actualEntries, len(expectedEntries))
+	for _, entry := range expectedEntries {
+		expected, err := os.ReadFile(filepath.Join(expectedDir, entry.Name()))
+		require.NoError(t, err)
+		actual, err := os.ReadFile(filepath.Join(actualDir, entry.Name()))
+		require.NoError(t, err)
+		require.Equal(t, expected, actual, "file %s differs", entry.Name())
+	}
+}
+
+func createSignedFetchRequest(channelID string, blockNumber uint64, fileName string, offset uint64) *pb.SignedSnapshotRequest {
+	request := &snapshotext.FetchSnapshotRequest{
+		SignatureHeader: &common.SignatureHeader{
+			Creator: []byte("creator"),
+			Nonce:   []byte("nonce-ignored"),
+		},
+		ChannelId:   channelID,
+		BlockNumber: blockNumber,
+		FileName:    fileName,
+		Offset:      offset,
+	}
+	return &pb.SignedSnapshotRequest{
+		Request:   protoutil.MarshalOrPanic(request),
+		Signature: []byte("dummy-signatures"),
+	}
+}
+
+type fakeFetchSnapshotFileServer struct {
+	grpc.ServerStream
+	sendErr error
+	chunks  []*snapshotext.SnapshotFileChunk
+}
+
+func (s *fakeFetchSnapshotFileServer) Send(chunk *snapshotext.SnapshotFileChunk) error {
+	if s.sendErr != nil {
+		return s.sendErr
+	}
+	s.chunks = append(s.chunks, chunk)
+	return nil
+}
+
+func (s *fakeFetchSnapshotFileServer) Context() context.Context { return context.Background() }
+
+func (s *fakeFetchSnapshotFileServer) SetHeader(metadata.MD) error { return nil }
@@ -8,23 +8,36 @@ package snapshotgrpc
 
 import (
 	"context"
+	"io"
+	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/golang/protobuf/ptypes/empty"
 	cb "github.com/hyperledger/fabric-protos-go/common"
 	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/common/configtx"
 	"github.com/hyperledger/fabric/common/crypto"
 	"github.com/hyperledger/fabric/core/aclmgmt/resources"
 	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/hyperledger/fabric/core/ledger/snapshotgrpc/snapshotext"
 	"github.com/hyperledger/fabric/protoutil"
 	"github.com/pkg/errors"
 )
 
+// snapshotFileChunkSize is the maximum size of the data sent in a SnapshotFileChunk
+var snapshotFileChunkSize = 1024 * 1024
+
 // Snapshot Service implements SnapshotServer grpc interface
 type SnapshotService struct {
 	LedgerGetter LedgerGetter
 	ACLProvider  ACLProvider
+	// SnapshotsRootDir is the root dir of the snapshots of the peer, from which
+	// the completed snapshots are served to other peers
+	SnapshotsRootDir string
 }
 
 // LedgerGetter gets the PeerLedger associated with a channel.
@@ -107,6 +120,174 @@ func (s *SnapshotService) QueryPendings(ctx context.Context, signedRequest *pb.S
 	return &pb.QueryPendingSnapshotsResponse{BlockNumbers: result}, nil
 }
 
+// QuerySchedule returns the periodic snapshot schedule of a channel.
+func (s *SnapshotService) QuerySchedule(ctx context.Context, signedRequest *pb.SignedSnapshotRequest) (*snapshotext.QueryScheduleResponse, error) {
+	query := &pb.SnapshotQuery{}
+	if err := proto.Unmarshal(signedRequest.Request, query); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal snapshot request")
+	}
+
+	if err := s.checkACL(resources.Snapshot_listpending, query.SignatureHeader, signedRequest); err != nil {
+		return nil, err
+	}
+
+	lgr, err := s.getLedger(query.ChannelId)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := lgr.SnapshotSchedule()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &snapshotext.QueryScheduleResponse{}
+	if info.Schedule == nil {
+		return response, nil
+	}
+	response.BlockInterval = info.Schedule.BlockInterval
+	response.Retain = uint32(info.Schedule.Retain)
+	response.NextBlockNumber = info.NextBlockNumber
+	if info.Schedule.TimeInterval != 0 {
+		response.TimeInterval = ptypes.DurationProto(info.Schedule.TimeInterval)
+	}
+	if !info.NextTime.IsZero() {
+		if response.NextTime, err = ptypes.TimestampProto(info.NextTime); err != nil {
+			return nil, errors.Wrap(err, "failed to convert next scheduled snapshot time")
+		}
+	}
+	return response, nil
+}
+
+// ListSnapshotFiles returns the files of a completed snapshot of a channel.
+func (s *SnapshotService) ListSnapshotFiles(ctx context.Context, signedRequest *pb.SignedSnapshotRequest) (*snapshotext.ListSnapshotFilesResponse, error) {
+	request, err := s.fetchRequest(signedRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshotDir, blockNumber, err := s.completedSnapshotDir(request.ChannelId, request.BlockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(snapshotDir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list the files of snapshot %s", snapshotDir)
+	}
+
+	response := &snapshotext.ListSnapshotFilesResponse{BlockNumber: blockNumber}
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+		fileInfo, err := entry.Info()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to stat the files of snapshot %s", snapshotDir)
+		}
+		response.Files = append(response.Files, &snapshotext.SnapshotFile{
+			Name: fileInfo.Name(),
+			Size: uint64(fileInfo.Size()),
+		})
+	}
+	return response, nil
+}
+
+// FetchSnapshotFile streams a file of a completed snapshot of a channel in chunks,
+// starting at the requested offset.
+func (s *SnapshotService) FetchSnapshotFile(signedRequest *pb.SignedSnapshotRequest, stream snapshotext.SnapshotExtensions_FetchSnapshotFileServer) error {
+	request, err := s.fetchRequest(signedRequest)
+	if err != nil {
+		return err
+	}
+
+	if request.FileName == "" || filepath.Base(request.FileName) != request.FileName {
+		return errors.Errorf("invalid snapshot file name [%s]", request.FileName)
+	}
+
+	snapshotDir, _, err := s.completedSnapshotDir(request.ChannelId, request.BlockNumber)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(filepath.Join(snapshotDir, request.FileName))
+	if err != nil {
+		return errors.Wrapf(err, "failed to open snapshot file %s", request.FileName)
+	}
+	defer f.Close()
+
+	if _, err := f.Seek(int64(request.Offset), io.SeekStart); err != nil {
+		return errors.Wrapf(err, "failed to seek snapshot file %s to offset %d", request.FileName, request.Offset)
+	}
+
+	offset := request.Offset
+	buf := make([]byte, snapshotFileChunkSize)
+	for {
+		n, err := f.Read(buf)
+		if n > 0 {
+			if err := stream.Send(&snapshotext.SnapshotFileChunk{Offset: offset, Data: buf[:n]}); err != nil {
+				return err
+			}
+			offset += uint64(n)
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrapf(err, "failed to read snapshot file %s", request.FileName)
+		}
+	}
+}
+
+func (s *SnapshotService) fetchRequest(signedRequest *pb.SignedSnapshotRequest) (*snapshotext.FetchSnapshotRequest, error) {
+	request := &snapshotext.FetchSnapshotRequest{}
+	if err := proto.Unmarshal(signedRequest.Request, request); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal snapshot request")
+	}
+
+	if err := s.checkACL(resources.Snapshot_fetch, request.SignatureHeader, signedRequest); err != nil {
+		return nil, err
+	}
+
+	return request, nil
+}
+
+// completedSnapshotDir returns the dir of the completed snapshot of a channel at the given
+// block number, or of the most recent completed snapshot when the block number is 0.
+func (s *SnapshotService) completedSnapshotDir(channelID string, blockNumber uint64) (string, uint64, error) {
+	if channelID == "" {
+		return "", 0, errors.New("missing channel ID")
+	}
+	if err := configtx.ValidateChannelID(channelID); err != nil {
+		return "", 0, err
+	}
+
+	blockNums, err := kvledger.CompletedSnapshotBlockNums(s.SnapshotsRootDir, channelID)
+	if err != nil {
+		return "", 0, errors.WithMessagef(err, "failed to list the completed snapshots of channel %s", channelID)
+	}
+	if len(blockNums) == 0 {
+		return "", 0, errors.Errorf("no completed snapshot exists for channel %s", channelID)
+	}
+
+	if blockNumber == 0 {
+		blockNumber = blockNums[len(blockNums)-1]
+	} else if !containsBlockNum(blockNums, blockNumber) {
+		return "", 0, errors.Errorf("no completed snapshot exists for channel %s at block number %d", channelID, blockNumber)
+	}
+
+	return kvledger.SnapshotDirForLedgerBlockNum(s.SnapshotsRootDir, channelID, blockNumber), blockNumber, nil
+}
+
+func containsBlockNum(blockNums []uint64, blockNum uint64) bool {
+	for _, n := range blockNums {
+		if n == blockNum {
+			return true
+		}
+	}
+	return false
+}
+
 func (s *SnapshotService) checkACL(resName string, signatureHdr *cb.SignatureHeader, signedRequest *pb.SignedSnapshotRequest) error {
 	if signatureHdr == nil {
 		return errors.New("missing signature header")
@@ -10,13 +10,18 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric-protos-go/common"
 	pb "github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/common/configtx/test"
+	"github.com/hyperledger/fabric/core/aclmgmt/resources"
+	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/ledgermgmt"
 	"github.com/hyperledger/fabric/core/ledger/ledgermgmt/ledgermgmttest"
 	"github.com/hyperledger/fabric/core/ledger/snapshotgrpc/mock"
+	"github.com/hyperledger/fabric/core/ledger/snapshotgrpc/snapshotext"
 	"github.com/hyperledger/fabric/protoutil"
 	"github.com/stretchr/testify/require"
 )
@@ -123,6 +128,9 @@ func TestSnapshot(t *testing.T) {
 			_, err = snapshotSvc.QueryPendings(context.Background(), test.signedRequest)
 			require.Error(t, err)
 			require.Contains(t, err.Error(), test.errMsg)
+			_, err = snapshotSvc.QuerySchedule(context.Background(), test.signedRequest)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), test.errMsg)
 		})
 	}
 
@@ -135,6 +143,8 @@ func TestSnapshot(t *testing.T) {
 	require.EqualError(t, err, "fake-check-acl-error")
 	_, err = snapshotSvc.QueryPendings(context.Background(), signedRequest)
 	require.EqualError(t, err, "fake-check-acl-error")
+	_, err = snapshotSvc.QuerySchedule(context.Background(), signedRequest)
+	require.EqualError(t, err, "fake-check-acl-error")
 
 	// verify panic from generate/cancel after ledger is closed
 	lgr.Close()
@@ -153,6 +163,54 @@ func TestSnapshot(t *testing.T) {
 	)
 }
 
+func TestQuerySchedule(t *testing.T) {
+	testDir := t.TempDir()
+
+	ledgermgmtInitializer := ledgermgmttest.NewInitializer(testDir)
+	ledgermgmtInitializer.Config.SnapshotsConfig.ChannelSchedules = map[string]*ledger.SnapshotSchedule{
+		"scheduled": {
+			BlockInterval: 100,
+			TimeInterval:  time.Hour,
+			Retain:        2,
+		},
+	}
+	ledgerMgr := ledgermgmt.NewLedgerMgr(ledgermgmtInitializer)
+	defer ledgerMgr.Close()
+
+	fakeLedgerGetter := &mock.LedgerGetter{}
+	fakeACLProvider := &mock.ACLProvider{}
+	snapshotSvc := &SnapshotService{LedgerGetter: fakeLedgerGetter, ACLProvider: fakeACLProvider}
+
+	ledgers := map[string]ledger.PeerLedger{}
+	for _, ledgerID := range []string{"scheduled", "unscheduled"} {
+		gb, err := test.MakeGenesisBlock(ledgerID)
+		require.NoError(t, err)
+		ledgers[ledgerID], err = ledgerMgr.CreateLedger(ledgerID, gb)
+		require.NoError(t, err)
+	}
+
+	fakeLedgerGetter.GetLedgerReturns(ledgers["unscheduled"])
+	resp, err := snapshotSvc.QuerySchedule(context.Background(), createSignedQuery("unscheduled"))
+	require.NoError(t, err)
+	require.True(t, proto.Equal(&snapshotext.QueryScheduleResponse{}, resp))
+
+	fakeLedgerGetter.GetLedgerReturns(ledgers["scheduled"])
+	// the schedule is updated asynchronously once the genesis block is committed
+	require.Eventually(t, func() bool {
+		resp, err = snapshotSvc.QuerySchedule(context.Background(), createSignedQuery("scheduled"))
+		require.NoError(t, err)
+		return resp.NextTime != nil
+	}, time.Minute, 10*time.Millisecond)
+	require.Equal(t, uint64(100), resp.BlockInterval)
+	require.Equal(t, time.Hour, resp.TimeInterval.AsDuration())
+	require.Equal(t, uint32(2), resp.Retain)
+	require.Equal(t, uint64(100), resp.NextBlockNumber)
+	require.WithinDuration(t, time.Now().Add(time.Hour), resp.NextTime.AsTime(), time.Minute)
+
+	resName, _ := fakeACLProvider.CheckACLNoChannelArgsForCall(1)
+	require.Equal(t, resources.Snapshot_listpending, resName)
+}
+
 func createSignedRequest(channelID string, blockNumber uint64) *pb.SignedSnapshotRequest {
 	sigHeader := &common.SignatureHeader{
 		Creator: []byte("creator"),
@@ -0,0 +1,106 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/ledger"
+)
+
+// LedgerGetter returns the ledger of a channel, or nil if the peer
+// has not joined the channel.
+type LedgerGetter interface {
+	GetLedger(cid string) ledger.PeerLedger
+}
+
+// ArchiveBlocksRequest is the body of a request to archive the blocks
+// of a channel below the block number.
+type ArchiveBlocksRequest struct {
+	ChannelID   string `json:"channelID"`
+	BlockNumber uint64 `json:"blockNumber"`
+}
+
+// ArchiveBlocksResponse is the body of the response to a successful
+// request to archive the blocks of a channel.
+type ArchiveBlocksResponse struct {
+	FirstAvailableBlockNumber uint64 `json:"firstAvailableBlockNumber"`
+}
+
+// ErrorResponse is the body of the response to a failed request.
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// ArchiveBlocksHandler serves the requests to archive the old blocks of
+// a channel on the operations endpoint.
+type ArchiveBlocksHandler struct {
+	LedgerGetter LedgerGetter
+	ArchiveDir   string
+	Logger       *flogging.FabricLogger
+}
+
+// NewArchiveBlocksHandler returns an ArchiveBlocksHandler that moves the
+// archived block files to archiveDir, or deletes them if archiveDir is empty.
+func NewArchiveBlocksHandler(ledgerGetter LedgerGetter, archiveDir string) *ArchiveBlocksHandler {
+	return &ArchiveBlocksHandler{
+		LedgerGetter: ledgerGetter,
+		ArchiveDir:   archiveDir,
+		Logger:       flogging.MustGetLogger("peer.archive"),
+	}
+}
+
+func (h *ArchiveBlocksHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		h.sendResponse(resp, http.StatusBadRequest, fmt.Errorf("invalid request method: %s", req.Method))
+		return
+	}
+
+	var archiveReq ArchiveBlocksRequest
+	decoder := json.NewDecoder(req.Body)
+	if err := decoder.Decode(&archiveReq); err != nil {
+		h.sendResponse(resp, http.StatusBadRequest, err)
+		return
+	}
+	req.Body.Close()
+
+	if archiveReq.ChannelID == "" {
+		h.sendResponse(resp, http.StatusBadRequest, fmt.Errorf("channelID is required"))
+		return
+	}
+	l := h.LedgerGetter.GetLedger(archiveReq.ChannelID)
+	if l == nil {
+		h.sendResponse(resp, http.StatusNotFound, fmt.Errorf("channel %s not found", archiveReq.ChannelID))
+		return
+	}
+
+	firstAvailableBlockNum, err := l.ArchiveBlocks(archiveReq.BlockNumber, h.ArchiveDir)
+	if err != nil {
+		h.sendResponse(resp, http.StatusBadRequest, err)
+		return
+	}
+	h.Logger.Infof("Archived the blocks of channel [%s] below block [%d], first available block = [%d]",
+		archiveReq.ChannelID, archiveReq.BlockNumber, firstAvailableBlockNum)
+	h.sendResponse(resp, http.StatusOK, &ArchiveBlocksResponse{FirstAvailableBlockNumber: firstAvailableBlockNum})
+}
+
+func (h *ArchiveBlocksHandler) sendResponse(resp http.ResponseWriter, code int, payload interface{}) {
+	encoder := json.NewEncoder(resp)
+	if err, ok := payload.(error); ok {
+		payload = &ErrorResponse{Error: err.Error()}
+	}
+
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(code)
+
+	if err := encoder.Encode(payload); err != nil {
+		h.Logger.Errorw("failed to encode payload", "error", err)
+	}
+}
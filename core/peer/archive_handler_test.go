@@ -0,0 +1,103 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/peer/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mapLedgerGetter map[string]ledger.PeerLedger
+
+func (m mapLedgerGetter) GetLedger(cid string) ledger.PeerLedger {
+	return m[cid]
+}
+
+func TestArchiveBlocksHandler(t *testing.T) {
+	fakeLedger := &mock.PeerLedger{}
+	handler := NewArchiveBlocksHandler(mapLedgerGetter{"mychannel": fakeLedger}, "/archive")
+
+	tests := []struct {
+		name         string
+		method       string
+		body         string
+		setup        func()
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:         "success",
+			method:       http.MethodPost,
+			body:         `{"channelID": "mychannel", "blockNumber": 100}`,
+			setup:        func() { fakeLedger.ArchiveBlocksReturns(90, nil) },
+			expectedCode: http.StatusOK,
+			expectedBody: `{"firstAvailableBlockNumber":90}`,
+		},
+		{
+			name:         "archiving fails",
+			method:       http.MethodPost,
+			body:         `{"channelID": "mychannel", "blockNumber": 100}`,
+			setup:        func() { fakeLedger.ArchiveBlocksReturns(0, errors.New("no completed snapshot found")) },
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"error":"no completed snapshot found"}`,
+		},
+		{
+			name:         "unknown channel",
+			method:       http.MethodPost,
+			body:         `{"channelID": "otherchannel", "blockNumber": 100}`,
+			expectedCode: http.StatusNotFound,
+			expectedBody: `{"error":"channel otherchannel not found"}`,
+		},
+		{
+			name:         "missing channel",
+			method:       http.MethodPost,
+			body:         `{"blockNumber": 100}`,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"error":"channelID is required"}`,
+		},
+		{
+			name:         "malformed body",
+			method:       http.MethodPost,
+			body:         `goo`,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"error":"invalid character 'g' looking for beginning of value"}`,
+		},
+		{
+			name:         "invalid method",
+			method:       http.MethodGet,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"error":"invalid request method: GET"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.setup != nil {
+				tt.setup()
+			}
+			req := httptest.NewRequest(tt.method, "/ledger/v1/archive", strings.NewReader(tt.body))
+			resp := httptest.NewRecorder()
+			handler.ServeHTTP(resp, req)
+
+			require.Equal(t, tt.expectedCode, resp.Result().StatusCode)
+			require.Equal(t, "application/json", resp.Result().Header.Get("Content-Type"))
+			require.JSONEq(t, tt.expectedBody, resp.Body.String())
+		})
+	}
+
+	require.Equal(t, 2, fakeLedger.ArchiveBlocksCallCount())
+	blockNum, archiveDir := fakeLedger.ArchiveBlocksArgsForCall(0)
+	require.Equal(t, uint64(100), blockNum)
+	require.Equal(t, "/archive", archiveDir)
+}
@@ -145,6 +145,27 @@ type Config struct {
 	// server time and client's time as specified in a client request message.
 	AuthenticationTimeWindow time.Duration
 
+	// ----- OCSP -----
+	// OCSP contains configuration parameters related to checking the revocation
+	// status of X.509 identities against OCSP responders, in addition to the
+	// CRLs in the MSP configuration of channels.
+
+	// OCSPEnabled enables/disables checking the revocation status of identities
+	// against OCSP responders.
+	OCSPEnabled bool
+	// OCSPResponderURL is the URL of the OCSP responder queried for all
+	// certificates. If empty, the OCSP servers listed in the certificates are
+	// queried.
+	OCSPResponderURL string
+	// OCSPCacheTTL sets how long the revocation status of a certificate is
+	// cached.
+	OCSPCacheTTL time.Duration
+	// OCSPTimeout sets the timeout of requests to the OCSP responders.
+	OCSPTimeout time.Duration
+	// OCSPFailOpen determines whether identities whose revocation status cannot
+	// be determined are considered valid.
+	OCSPFailOpen bool
+
 	// Endpoint of the vm management system. For docker can be one of the following in general
 	// unix:///var/run/docker.sock
 	// http://localhost:2375
@@ -251,6 +272,12 @@ func (c *Config) load() error {
 		c.AuthenticationTimeWindow = defaultTimeWindow
 	}
 
+	c.OCSPEnabled = viper.GetBool("peer.ocsp.enabled")
+	c.OCSPResponderURL = viper.GetString("peer.ocsp.responderURL")
+	c.OCSPCacheTTL = viper.GetDuration("peer.ocsp.cacheTTL")
+	c.OCSPTimeout = viper.GetDuration("peer.ocsp.timeout")
+	c.OCSPFailOpen = viper.GetBool("peer.ocsp.failOpen")
+
 	c.PeerTLSEnabled = viper.GetBool("peer.tls.enabled")
 	c.NetworkID = viper.GetString("peer.networkId")
 	c.LimitsConcurrencyEndorserService = viper.GetInt("peer.limits.concurrency.endorserService")
@@ -267,6 +267,11 @@ func TestGlobalConfig(t *testing.T) {
 	viper.Set("peer.listenAddress", "0.0.0.0:7051")
 	viper.Set("peer.authentication.timewindow", "15m")
 	viper.Set("peer.tls.enabled", "false")
+	viper.Set("peer.ocsp.enabled", true)
+	viper.Set("peer.ocsp.responderURL", "http://ocsp.example.com")
+	viper.Set("peer.ocsp.cacheTTL", "5m")
+	viper.Set("peer.ocsp.timeout", "5s")
+	viper.Set("peer.ocsp.failOpen", true)
 	viper.Set("peer.networkId", "testNetwork")
 	viper.Set("peer.limits.concurrency.endorserService", 2500)
 	viper.Set("peer.limits.concurrency.deliverService", 2500)
@@ -326,6 +331,11 @@ func TestGlobalConfig(t *testing.T) {
 		ListenAddress:                         "0.0.0.0:7051",
 		AuthenticationTimeWindow:              15 * time.Minute,
 		PeerTLSEnabled:                        false,
+		OCSPEnabled:                           true,
+		OCSPResponderURL:                      "http://ocsp.example.com",
+		OCSPCacheTTL:                          5 * time.Minute,
+		OCSPTimeout:                           5 * time.Second,
+		OCSPFailOpen:                          true,
 		PeerAddress:                           "localhost:8080",
 		PeerID:                                "testPeerID",
 		NetworkID:                             "testNetwork",
@@ -381,10 +391,14 @@ func TestGlobalConfig(t *testing.T) {
 		DockerCA:   filepath.Join(cwd, "test/vm/tls/ca/file"),
 
 		GatewayOptions: config.Options{
-			Enabled:            true,
-			EndorsementTimeout: 10 * time.Second,
-			BroadcastTimeout:   10 * time.Second,
-			DialTimeout:        60 * time.Second,
+			Enabled:                 true,
+			EndorsementTimeout:      10 * time.Second,
+			BroadcastTimeout:        10 * time.Second,
+			DialTimeout:             60 * time.Second,
+			MaxSubmitAttempts:       3,
+			EvaluateCacheMaxEntries: 1000,
+			EndorserSelection:       "height",
+			EndorserStatsWindow:     20,
 		},
 	}
 
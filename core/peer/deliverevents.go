@@ -183,7 +183,37 @@ func (bprs *blockAndPrivateDataResponseSender) getPrivateData(
 		return nil, errors.New("wrong chain type")
 	}
 
-	pvtData, err := channel.Ledger().GetPvtDataByNum(block.Header.Number, nil)
+	return eligiblePrivateData(block, channelID, channel.Ledger(), bprs.CollectionPolicyChecker, bprs.IdentityDeserializerManager, signedData)
+}
+
+// PrivateDataRetriever retrieves the private data of the blocks of a channel, and the collection configs that apply to
+// them.
+type PrivateDataRetriever interface {
+	GetPvtDataByNum(blockNum uint64, filter ledger.PvtNsCollFilter) ([]*ledger.TxPvtData, error)
+	GetConfigHistoryRetriever() (ledger.ConfigHistoryRetriever, error)
+}
+
+// EligiblePrivateData returns the private data of the block, by transaction sequence in the block, restricted to the
+// collections that the identity of the signed data is eligible to read. The eligibility is determined as for the
+// DeliverWithPrivateData service.
+func EligiblePrivateData(
+	block *common.Block,
+	channelID string,
+	retriever PrivateDataRetriever,
+	signedData *protoutil.SignedData,
+) (map[uint64]*rwset.TxPvtReadWriteSet, error) {
+	return eligiblePrivateData(block, channelID, retriever, &collPolicyChecker{}, &identityDeserializerMgr{}, signedData)
+}
+
+func eligiblePrivateData(
+	block *common.Block,
+	channelID string,
+	retriever PrivateDataRetriever,
+	checker CollectionPolicyChecker,
+	deserializerMgr IdentityDeserializerManager,
+	signedData *protoutil.SignedData,
+) (map[uint64]*rwset.TxPvtReadWriteSet, error) {
+	pvtData, err := retriever.GetPvtDataByNum(block.Header.Number, nil)
 	if err != nil {
 		logger.Errorf("Error getting private data by block number %d on channel %s", block.Header.Number, channelID)
 		return nil, errors.Wrapf(err, "error getting private data by block number %d", block.Header.Number)
@@ -191,12 +221,12 @@ func (bprs *blockAndPrivateDataResponseSender) getPrivateData(
 
 	seqs2Namespaces := aggregatedCollections(make(map[seqAndDataModel]map[string][]*rwset.CollectionPvtReadWriteSet))
 
-	configHistoryRetriever, err := channel.Ledger().GetConfigHistoryRetriever()
+	configHistoryRetriever, err := retriever.GetConfigHistoryRetriever()
 	if err != nil {
 		return nil, err
 	}
 
-	identityDeserializer, err := bprs.IdentityDeserializerManager.Deserializer(channelID)
+	identityDeserializer, err := deserializerMgr.Deserializer(channelID)
 	if err != nil {
 		return nil, err
 	}
@@ -211,7 +241,7 @@ func (bprs *blockAndPrivateDataResponseSender) getPrivateData(
 			for _, col := range ns.CollectionPvtRwset {
 				logger.Debugf("Checking policy for namespace %s, collection %s", ns.Namespace, col.CollectionName)
 
-				eligible, err := bprs.CollectionPolicyChecker.CheckCollectionPolicy(block.Header.Number,
+				eligible, err := checker.CheckCollectionPolicy(block.Header.Number,
 					ns.Namespace, col.CollectionName, configHistoryRetriever, identityDeserializer, signedData)
 				if err != nil {
 					return nil, err
@@ -289,6 +319,12 @@ func (s *DeliverServer) DeliverWithPrivateData(srv peer.Deliver_DeliverWithPriva
 	return err
 }
 
+// FilteredBlock returns the filtered block sent by the DeliverFiltered service for the block.
+func FilteredBlock(block *common.Block) (*peer.FilteredBlock, error) {
+	b := blockEvent(*block)
+	return b.toFilteredBlock()
+}
+
 func (block *blockEvent) toFilteredBlock() (*peer.FilteredBlock, error) {
 	filteredBlock := &peer.FilteredBlock{
 		Number: block.Header.Number,
@@ -205,14 +205,7 @@ func (p *Peer) CreateChannelFromSnapshot(
 	legacyLifecycleValidation plugindispatcher.LifecycleResources,
 	newLifecycleValidation plugindispatcher.CollectionAndLifecycleResources,
 ) error {
-	channelCallback := func(l ledger.PeerLedger, cid string) {
-		if err := p.createChannel(cid, l, deployedCCInfoProvider, legacyLifecycleValidation, newLifecycleValidation); err != nil {
-			logger.Errorf("error creating channel for %s", cid)
-			return
-		}
-		p.initChannel(cid)
-	}
-
+	channelCallback := p.snapshotChannelCallback(deployedCCInfoProvider, legacyLifecycleValidation, newLifecycleValidation)
 	err := p.LedgerMgr.CreateLedgerFromSnapshot(snapshotDir, channelCallback)
 	if err != nil {
 		return errors.WithMessagef(err, "cannot create ledger from snapshot %s", snapshotDir)
@@ -221,6 +214,38 @@ func (p *Peer) CreateChannelFromSnapshot(
 	return nil
 }
 
+// CreateChannelFromFetchedSnapshot creates a channel from a snapshot that is fetched by
+// the fetch function, such as from another peer of the organization.
+func (p *Peer) CreateChannelFromFetchedSnapshot(
+	snapshotSource string,
+	fetch func() (string, error),
+	deployedCCInfoProvider ledger.DeployedChaincodeInfoProvider,
+	legacyLifecycleValidation plugindispatcher.LifecycleResources,
+	newLifecycleValidation plugindispatcher.CollectionAndLifecycleResources,
+) error {
+	channelCallback := p.snapshotChannelCallback(deployedCCInfoProvider, legacyLifecycleValidation, newLifecycleValidation)
+	err := p.LedgerMgr.CreateLedgerFromFetchedSnapshot(snapshotSource, fetch, channelCallback)
+	if err != nil {
+		return errors.WithMessagef(err, "cannot create ledger from snapshot fetched from %s", snapshotSource)
+	}
+
+	return nil
+}
+
+func (p *Peer) snapshotChannelCallback(
+	deployedCCInfoProvider ledger.DeployedChaincodeInfoProvider,
+	legacyLifecycleValidation plugindispatcher.LifecycleResources,
+	newLifecycleValidation plugindispatcher.CollectionAndLifecycleResources,
+) func(ledger.PeerLedger, string) {
+	return func(l ledger.PeerLedger, cid string) {
+		if err := p.createChannel(cid, l, deployedCCInfoProvider, legacyLifecycleValidation, newLifecycleValidation); err != nil {
+			logger.Errorf("error creating channel for %s", cid)
+			return
+		}
+		p.initChannel(cid)
+	}
+}
+
 // RetrievePersistedChannelConfig retrieves the persisted channel config from statedb
 func RetrievePersistedChannelConfig(ledger ledger.PeerLedger) (*common.Config, error) {
 	qe, err := ledger.NewQueryExecutor()
@@ -28,6 +28,8 @@ const (
 	Admins = "Admins"
 	// Members is the label for the local MSP members
 	Members = "Members"
+	// Peers is the label for the local MSP peers
+	Peers = "Peers"
 )
 
 type MSPPrincipalGetter interface {
@@ -68,6 +70,16 @@ func (m *localMSPPrincipalGetter) Get(role string) (*protomsp.MSPPrincipal, erro
 			return nil, errors.Wrap(err, "marshalling failed")
 		}
 
+		return &protomsp.MSPPrincipal{
+			PrincipalClassification: protomsp.MSPPrincipal_ROLE,
+			Principal:               principalBytes,
+		}, nil
+	case Peers:
+		principalBytes, err := proto.Marshal(&protomsp.MSPRole{Role: protomsp.MSPRole_PEER, MspIdentifier: mspid})
+		if err != nil {
+			return nil, errors.Wrap(err, "marshalling failed")
+		}
+
 		return &protomsp.MSPPrincipal{
 			PrincipalClassification: protomsp.MSPPrincipal_ROLE,
 			Principal:               principalBytes,
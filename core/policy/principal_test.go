@@ -51,4 +51,13 @@ func TestLocalMSPPrincipalGetter_Get(t *testing.T) {
 	proto.Unmarshal(p.Principal, role)
 	require.Equal(t, localMSPID, role.MspIdentifier)
 	require.Equal(t, msp.MSPRole_MEMBER, role.Role)
+
+	p, err = g.Get(Peers)
+	require.NoError(t, err)
+	require.NotNil(t, p)
+	require.Equal(t, msp.MSPPrincipal_ROLE, p.PrincipalClassification)
+	role = &msp.MSPRole{}
+	proto.Unmarshal(p.Principal, role)
+	require.Equal(t, localMSPID, role.MspIdentifier)
+	require.Equal(t, msp.MSPRole_PEER, role.Role)
 }
@@ -13,6 +13,7 @@ package cscc
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric-chaincode-go/shim"
@@ -40,6 +41,7 @@ func New(
 	nr plugindispatcher.CollectionAndLifecycleResources,
 	p *peer.Peer,
 	bccsp bccsp.BCCSP,
+	snapshotFetcher SnapshotFetcher,
 ) *PeerConfiger {
 	return &PeerConfiger{
 		aclProvider:            aclProvider,
@@ -48,9 +50,17 @@ func New(
 		newLifecycle:           nr,
 		peer:                   p,
 		bccsp:                  bccsp,
+		snapshotFetcher:        snapshotFetcher,
 	}
 }
 
+// SnapshotFetcher fetches a completed snapshot of a channel from another peer of
+// the organization and returns the dir of the fetched snapshot. When the block
+// number is 0, the most recent completed snapshot of the channel is fetched.
+type SnapshotFetcher interface {
+	Fetch(address, channelID string, blockNumber uint64) (string, error)
+}
+
 func (e *PeerConfiger) Name() string              { return "cscc" }
 func (e *PeerConfiger) Chaincode() shim.Chaincode { return e }
 
@@ -64,6 +74,7 @@ type PeerConfiger struct {
 	newLifecycle           plugindispatcher.CollectionAndLifecycleResources
 	peer                   *peer.Peer
 	bccsp                  bccsp.BCCSP
+	snapshotFetcher        SnapshotFetcher
 }
 
 var cnflogger = flogging.MustGetLogger("cscc")
@@ -76,6 +87,8 @@ const (
 	GetConfigBlock       string = "GetConfigBlock"
 	GetChannelConfig     string = "GetChannelConfig"
 	GetChannels          string = "GetChannels"
+
+	JoinChainBySnapshotFromPeer string = "JoinChainBySnapshotFromPeer"
 )
 
 // Init is mostly useless from an SCC perspective
@@ -180,6 +193,23 @@ func (e *PeerConfiger) InvokeNoShim(args [][]byte, sp *pb.SignedProposal) pb.Res
 		}
 		snapshotDir := string(args[1])
 		return e.JoinChainBySnapshot(snapshotDir, e.deployedCCInfoProvider, e.legacyLifecycle, e.newLifecycle)
+	case JoinChainBySnapshotFromPeer:
+		// args[1] is the address of the peer, args[2] is the channel ID and the optional
+		// args[3] is the block number of the snapshot
+		if len(args) < 3 || len(args[1]) == 0 || len(args[2]) == 0 {
+			return shim.Error("Cannot join the channel, a peer address and a channel ID must be provided")
+		}
+		var blockNumber uint64
+		if len(args) > 3 && len(args[3]) != 0 {
+			if blockNumber, err = strconv.ParseUint(string(args[3]), 10, 64); err != nil {
+				return shim.Error(fmt.Sprintf("Cannot join the channel, invalid snapshot block number [%s]", args[3]))
+			}
+		}
+		// check policy
+		if err = e.aclProvider.CheckACL(resources.Cscc_JoinChainBySnapshot, "", sp); err != nil {
+			return shim.Error(fmt.Sprintf("access denied for [%s]: [%s]", fname, err))
+		}
+		return e.JoinChainBySnapshotFromPeer(string(args[1]), string(args[2]), blockNumber, e.deployedCCInfoProvider, e.legacyLifecycle, e.newLifecycle)
 	case JoinBySnapshotStatus:
 		if err = e.aclProvider.CheckACL(resources.Cscc_JoinBySnapshotStatus, "", sp); err != nil {
 			return shim.Error(fmt.Sprintf("access denied for [%s]: %s", fname, err))
@@ -282,6 +312,30 @@ func (e *PeerConfiger) JoinChainBySnapshot(
 	return shim.Success(nil)
 }
 
+// JoinChainBySnapshotFromPeer will join the channel by the snapshot fetched from the
+// peer at the specified address.
+func (e *PeerConfiger) JoinChainBySnapshotFromPeer(
+	address string,
+	channelID string,
+	blockNumber uint64,
+	deployedCCInfoProvider ledger.DeployedChaincodeInfoProvider,
+	lr plugindispatcher.LifecycleResources,
+	nr plugindispatcher.CollectionAndLifecycleResources,
+) pb.Response {
+	if e.snapshotFetcher == nil {
+		return shim.Error("fetching snapshots from other peers is not supported")
+	}
+
+	fetch := func() (string, error) {
+		return e.snapshotFetcher.Fetch(address, channelID, blockNumber)
+	}
+	if err := e.peer.CreateChannelFromFetchedSnapshot(address, fetch, deployedCCInfoProvider, lr, nr); err != nil {
+		return shim.Error(err.Error())
+	}
+
+	return shim.Success(nil)
+}
+
 // Return the current configuration block for the specified channelID. If the
 // peer doesn't belong to the channel, return error
 func (e *PeerConfiger) getConfigBlock(channelID []byte) pb.Response {
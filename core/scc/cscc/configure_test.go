@@ -23,6 +23,7 @@ import (
 	"github.com/hyperledger/fabric/common/metrics/disabled"
 	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/core/aclmgmt"
+	"github.com/hyperledger/fabric/core/aclmgmt/resources"
 	"github.com/hyperledger/fabric/core/chaincode"
 	"github.com/hyperledger/fabric/core/config/configtest"
 	"github.com/hyperledger/fabric/core/deliverservice"
@@ -71,6 +72,12 @@ type storeProvider interface {
 	transientstore.StoreProvider
 }
 
+//go:generate counterfeiter -o mocks/snapshot_fetcher.go --fake-name SnapshotFetcher . snapshotFetcher
+
+type snapshotFetcher interface {
+	SnapshotFetcher
+}
+
 func TestMain(m *testing.M) {
 	msptesttools.LoadMSPSetupForTesting()
 	rc := m.Run()
@@ -431,6 +438,100 @@ func TestConfigerInvokeJoinChainBySnapshot(t *testing.T) {
 	require.Contains(t, res.Message, "access denied for [JoinChainBySnapshot]")
 }
 
+func TestConfigerInvokeJoinChainBySnapshotFromPeer(t *testing.T) {
+	testDir := t.TempDir()
+
+	ledgerInitializer := ledgermgmttest.NewInitializer(testDir)
+	ledgerInitializer.CustomTxProcessors = map[cb.HeaderType]ledger.CustomTxProcessor{
+		cb.HeaderType_CONFIG: &peer.ConfigTxProcessor{},
+	}
+	ledgerMgr := ledgermgmt.NewLedgerMgr(ledgerInitializer)
+	defer ledgerMgr.Close()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:")
+	require.NoError(t, err)
+	grpcServer := grpc.NewServer()
+
+	cscc := newPeerConfiger(t, ledgerMgr, grpcServer, listener.Addr().String())
+	fakeSnapshotFetcher := &mocks.SnapshotFetcher{}
+	cscc.snapshotFetcher = fakeSnapshotFetcher
+
+	go grpcServer.Serve(listener)
+	defer grpcServer.Stop()
+
+	channelID := "testjoinchainbysnapshotfrompeer"
+	sProp := validSignedProposal()
+	sProp.Signature = sProp.ProposalBytes
+
+	mockACLProvider := cscc.aclProvider.(*mocks.ACLProvider)
+	mockStub := &mocks.ChaincodeStub{}
+	mockStub.GetSignedProposalReturns(sProp, nil)
+
+	snapshotDir := ledgermgmttest.CreateSnapshotWithGenesisBlock(t, testDir, channelID, &peer.ConfigTxProcessor{})
+	fakeSnapshotFetcher.FetchReturns(snapshotDir, nil)
+
+	waitForJoinBySnapshot := func() bool {
+		resp := cscc.joinBySnapshotStatus()
+		require.Equal(t, shim.OK, int(resp.Status))
+		status := &pb.JoinBySnapshotStatus{}
+		require.NoError(t, proto.Unmarshal(resp.Payload, status))
+		return !status.InProgress
+	}
+
+	// error paths due to invalid arguments
+	mockStub.GetArgsReturns([][]byte{[]byte(JoinChainBySnapshotFromPeer), []byte("peer0:7051")})
+	res := cscc.Invoke(mockStub)
+	require.Equal(t, int32(shim.ERROR), res.Status)
+	require.Equal(t, "Cannot join the channel, a peer address and a channel ID must be provided", res.Message)
+
+	mockStub.GetArgsReturns([][]byte{[]byte(JoinChainBySnapshotFromPeer), []byte("peer0:7051"), []byte(channelID), []byte("latest")})
+	res = cscc.Invoke(mockStub)
+	require.Equal(t, int32(shim.ERROR), res.Status)
+	require.Equal(t, "Cannot join the channel, invalid snapshot block number [latest]", res.Message)
+
+	// error path due to CheckACL error
+	mockACLProvider.CheckACLReturns(errors.New("Failed authorization"))
+	mockStub.GetArgsReturns([][]byte{[]byte(JoinChainBySnapshotFromPeer), []byte("peer0:7051"), []byte(channelID)})
+	res = cscc.Invoke(mockStub)
+	require.Equal(t, int32(shim.ERROR), res.Status)
+	require.Contains(t, res.Message, "access denied for [JoinChainBySnapshotFromPeer]")
+	resName, _, _ := mockACLProvider.CheckACLArgsForCall(0)
+	require.Equal(t, resources.Cscc_JoinChainBySnapshot, resName)
+
+	// the ledger is not created when the snapshot cannot be fetched
+	mockACLProvider.CheckACLReturns(nil)
+	fakeSnapshotFetcher.FetchReturnsOnCall(0, "", errors.New("fetch-error"))
+	res = cscc.Invoke(mockStub)
+	require.Equal(t, int32(shim.OK), res.Status)
+	require.Eventually(t, waitForJoinBySnapshot, time.Minute, 100*time.Millisecond)
+	require.Nil(t, cscc.peer.GetLedger(channelID))
+
+	// successful path
+	mockStub.GetArgsReturns([][]byte{[]byte(JoinChainBySnapshotFromPeer), []byte("peer0:7051"), []byte(channelID), []byte("0")})
+	res = cscc.Invoke(mockStub)
+	require.Equal(t, int32(shim.OK), res.Status)
+	require.Eventually(t, waitForJoinBySnapshot, time.Minute, 100*time.Millisecond)
+
+	require.Equal(t, 2, fakeSnapshotFetcher.FetchCallCount())
+	address, fetchedChannelID, blockNumber := fakeSnapshotFetcher.FetchArgsForCall(1)
+	require.Equal(t, "peer0:7051", address)
+	require.Equal(t, channelID, fetchedChannelID)
+	require.Equal(t, uint64(0), blockNumber)
+
+	lgr := cscc.peer.GetLedger(channelID)
+	require.NotNil(t, lgr)
+	bcInfo, err := lgr.GetBlockchainInfo()
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), bcInfo.Height)
+	require.NoDirExists(t, snapshotDir)
+
+	// error path when the peer does not support fetching snapshots
+	cscc.snapshotFetcher = nil
+	res = cscc.Invoke(mockStub)
+	require.Equal(t, int32(shim.ERROR), res.Status)
+	require.Equal(t, "fetching snapshots from other peers is not supported", res.Message)
+}
+
 func TestConfigerInvokeGetChannelConfig(t *testing.T) {
 	testDir := t.TempDir()
 
@@ -11,10 +11,13 @@ import (
 	"strconv"
 
 	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/ledger/queryresult"
 	pb "github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/core/aclmgmt"
 	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/internal/pkg/txflags"
 	"github.com/hyperledger/fabric/protoutil"
 )
 
@@ -40,6 +43,9 @@ func (e *LedgerQuerier) Chaincode() shim.Chaincode { return e }
 // - GetBlockByNumber returns a block
 // - GetBlockByHash returns a block
 // - GetTransactionByID returns a transaction
+// - GetBlockByTxID returns the block of a transaction
+// - GetHistoryForKey returns a page of the history of a key
+// - GetTransactionsByBlockRange returns the transactions of a range of blocks
 type LedgerQuerier struct {
 	aclProvider aclmgmt.ACLProvider
 	ledgers     LedgerGetter
@@ -54,6 +60,18 @@ const (
 	GetBlockByHash     string = "GetBlockByHash"
 	GetTransactionByID string = "GetTransactionByID"
 	GetBlockByTxID     string = "GetBlockByTxID"
+
+	GetHistoryForKey            string = "GetHistoryForKey"
+	GetTransactionsByBlockRange string = "GetTransactionsByBlockRange"
+)
+
+const (
+	// DefaultHistoryPageSize is the number of history records returned by GetHistoryForKey when no page size is given.
+	DefaultHistoryPageSize = 100
+	// MaxHistoryPageSize is the largest page size accepted by GetHistoryForKey.
+	MaxHistoryPageSize = 1000
+	// MaxBlockRange is the largest number of blocks accepted by GetTransactionsByBlockRange.
+	MaxBlockRange = 1000
 )
 
 // Init is called once per chain when the chain is created.
@@ -72,6 +90,14 @@ func (e *LedgerQuerier) Init(stub shim.ChaincodeStubInterface) pb.Response {
 // # GetBlockByNumber: Return the block specified by block number in args[2]
 // # GetBlockByHash: Return the block specified by block hash in args[2]
 // # GetTransactionByID: Return the transaction specified by ID in args[2]
+// # GetBlockByTxID: Return the block of the transaction specified by ID in args[2]
+// # GetHistoryForKey: Return a QueryResponse with a page of the history of the
+// key in args[3] of the namespace in args[2], from the newest to the oldest
+// modification. The optional args[4] is the page size and the optional args[5]
+// is the bookmark returned in the metadata of the previous page.
+// # GetTransactionsByBlockRange: Return a QueryResponse with a FilteredBlock,
+// listing the ID, type and validation code of each transaction, for each block
+// from the number in args[2] to the number in args[3] inclusive
 func (e *LedgerQuerier) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
 	args := stub.GetArgs()
 
@@ -124,6 +150,10 @@ func (e *LedgerQuerier) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
 		return getChainInfo(targetLedger)
 	case GetBlockByTxID:
 		return getBlockByTxID(targetLedger, args[2])
+	case GetHistoryForKey:
+		return getHistoryForKey(targetLedger, args[2:])
+	case GetTransactionsByBlockRange:
+		return getTransactionsByBlockRange(targetLedger, args[2:])
 	}
 
 	return shim.Error(fmt.Sprintf("Requested function %s not found.", fname))
@@ -221,6 +251,184 @@ func getBlockByTxID(vledger ledger.PeerLedger, rawTxID []byte) pb.Response {
 	return shim.Success(bytes)
 }
 
+func getHistoryForKey(vledger ledger.PeerLedger, args [][]byte) pb.Response {
+	if len(args) < 2 {
+		return shim.Error("Namespace and key must be provided.")
+	}
+	namespace, key := string(args[0]), string(args[1])
+	if namespace == "" || key == "" {
+		return shim.Error("Namespace and key must not be empty.")
+	}
+
+	pageSize := DefaultHistoryPageSize
+	if len(args) > 2 && len(args[2]) > 0 {
+		size, err := strconv.Atoi(string(args[2]))
+		if err != nil {
+			return shim.Error(fmt.Sprintf("Failed to parse page size with error %s", err))
+		}
+		if size < 1 || size > MaxHistoryPageSize {
+			return shim.Error(fmt.Sprintf("Page size must be between 1 and %d, got %d", MaxHistoryPageSize, size))
+		}
+		pageSize = size
+	}
+
+	var bookmark string
+	if len(args) > 3 {
+		bookmark = string(args[3])
+	}
+
+	hqe, err := vledger.NewHistoryQueryExecutor()
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to get history query executor, error %s", err))
+	}
+	if hqe == nil {
+		return shim.Error("History database is not enabled on this peer")
+	}
+
+	itr, err := hqe.GetHistoryForKey(namespace, key)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to get history for key %s of namespace %s, error %s", key, namespace, err))
+	}
+	defer itr.Close()
+
+	// The bookmark is the ID of the last transaction of the previous page. As the
+	// history is returned from the newest to the oldest modification, this holds
+	// even if the key is modified between the pages.
+	found := bookmark == ""
+	response := &pb.QueryResponse{}
+	var last string
+	for {
+		result, err := itr.Next()
+		if err != nil {
+			return shim.Error(fmt.Sprintf("Failed to get history for key %s of namespace %s, error %s", key, namespace, err))
+		}
+		if result == nil {
+			break
+		}
+		modification := result.(*queryresult.KeyModification)
+
+		if !found {
+			found = modification.TxId == bookmark
+			continue
+		}
+
+		if len(response.Results) == pageSize {
+			response.HasMore = true
+			break
+		}
+
+		bytes, err := protoutil.Marshal(modification)
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		response.Results = append(response.Results, &pb.QueryResultBytes{ResultBytes: bytes})
+		last = modification.TxId
+	}
+
+	if !found {
+		return shim.Error(fmt.Sprintf("Bookmark %s not found in the history of key %s of namespace %s", bookmark, key, namespace))
+	}
+
+	metadata := &pb.QueryResponseMetadata{FetchedRecordsCount: int32(len(response.Results))}
+	if response.HasMore {
+		metadata.Bookmark = last
+	}
+	if response.Metadata, err = protoutil.Marshal(metadata); err != nil {
+		return shim.Error(err.Error())
+	}
+
+	bytes, err := protoutil.Marshal(response)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	return shim.Success(bytes)
+}
+
+func getTransactionsByBlockRange(vledger ledger.PeerLedger, args [][]byte) pb.Response {
+	if len(args) < 2 {
+		return shim.Error("Start and end block numbers must be provided.")
+	}
+	start, err := strconv.ParseUint(string(args[0]), 10, 64)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to parse start block number with error %s", err))
+	}
+	end, err := strconv.ParseUint(string(args[1]), 10, 64)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to parse end block number with error %s", err))
+	}
+	if start > end {
+		return shim.Error(fmt.Sprintf("Start block number %d is greater than end block number %d", start, end))
+	}
+	if end-start >= MaxBlockRange {
+		return shim.Error(fmt.Sprintf("Block range must not exceed %d blocks", MaxBlockRange))
+	}
+
+	response := &pb.QueryResponse{}
+	for number := start; number <= end; number++ {
+		block, err := vledger.GetBlockByNumber(number)
+		if err != nil {
+			return shim.Error(fmt.Sprintf("Failed to get block number %d, error %s", number, err))
+		}
+
+		filteredBlock, err := blockTransactions(block)
+		if err != nil {
+			return shim.Error(fmt.Sprintf("Failed to get transactions of block number %d, error %s", number, err))
+		}
+
+		bytes, err := protoutil.Marshal(filteredBlock)
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		response.Results = append(response.Results, &pb.QueryResultBytes{ResultBytes: bytes})
+	}
+
+	metadata := &pb.QueryResponseMetadata{FetchedRecordsCount: int32(len(response.Results))}
+	if response.Metadata, err = protoutil.Marshal(metadata); err != nil {
+		return shim.Error(err.Error())
+	}
+
+	bytes, err := protoutil.Marshal(response)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	return shim.Success(bytes)
+}
+
+// blockTransactions returns a filtered block with the ID, type and validation
+// code of each transaction of the block.
+func blockTransactions(block *common.Block) (*pb.FilteredBlock, error) {
+	filteredBlock := &pb.FilteredBlock{
+		Number: block.GetHeader().GetNumber(),
+	}
+
+	txsFltr := txflags.ValidationFlags(block.GetMetadata().GetMetadata()[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	for txIndex, ebytes := range block.GetData().GetData() {
+		env, err := protoutil.GetEnvelopeFromBlock(ebytes)
+		if err != nil {
+			return nil, err
+		}
+		payload, err := protoutil.UnmarshalPayload(env.Payload)
+		if err != nil {
+			return nil, err
+		}
+		chdr, err := protoutil.UnmarshalChannelHeader(payload.GetHeader().GetChannelHeader())
+		if err != nil {
+			return nil, err
+		}
+
+		filteredBlock.ChannelId = chdr.ChannelId
+		filteredBlock.FilteredTransactions = append(filteredBlock.FilteredTransactions, &pb.FilteredTransaction{
+			Txid:             chdr.TxId,
+			Type:             common.HeaderType(chdr.Type),
+			TxValidationCode: txsFltr.Flag(txIndex),
+		})
+	}
+
+	return filteredBlock, nil
+}
+
 func getACLResource(fname string) string {
 	return "qscc/" + fname
 }
@@ -11,9 +11,11 @@ import (
 	"os"
 	"testing"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric-chaincode-go/shim"
 	"github.com/hyperledger/fabric-chaincode-go/shimtest"
 	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/ledger/queryresult"
 	peer2 "github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/bccsp/sw"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
@@ -31,6 +33,10 @@ import (
 )
 
 func setupTestLedger(t *testing.T, chainid string, path string) (*shimtest.MockStub, *peer.Peer, func(), error) {
+	return setupTestLedgerWithHistory(t, chainid, path, true)
+}
+
+func setupTestLedgerWithHistory(t *testing.T, chainid string, path string, enableHistory bool) (*shimtest.MockStub, *peer.Peer, func(), error) {
 	mockAclProvider.Reset()
 
 	viper.Set("peer.fileSystemPath", path)
@@ -42,6 +48,7 @@ func setupTestLedger(t *testing.T, chainid string, path string) (*shimtest.MockS
 	}
 
 	initializer := ledgermgmttest.NewInitializer(testDir)
+	initializer.Config.HistoryDBConfig.Enabled = enableHistory
 
 	ledgerMgr := ledgermgmt.NewLedgerMgr(initializer)
 
@@ -331,6 +338,48 @@ func TestFailingAccessControl(t *testing.T) {
 	// assert that the expectations were met
 	mockAclProvider.AssertExpectations(t)
 
+	// GetHistoryForKey
+	args = [][]byte{[]byte(GetHistoryForKey), []byte(chainid), []byte("ns1"), []byte("key1")}
+	sProp, _ = protoutil.MockSignedEndorserProposalOrPanic(
+		chainid,
+		&peer2.ChaincodeSpec{
+			ChaincodeId: &peer2.ChaincodeID{
+				Name: "qscc",
+			},
+		},
+		[]byte("Alice"),
+		[]byte("msg1"),
+	)
+	sProp.Signature = sProp.ProposalBytes
+	// Set the ACLProvider to have a failure
+	resetProvider(resources.Qscc_GetHistoryForKey, chainid, sProp, errors.New("Failed access control"))
+	res = stub.MockInvokeWithSignedProposal("2", args, sProp)
+	require.Equal(t, int32(shim.ERROR), res.Status, "GetHistoryForKey must fail: %s", res.Message)
+	require.Contains(t, res.Message, "Failed access control")
+	// assert that the expectations were met
+	mockAclProvider.AssertExpectations(t)
+
+	// GetTransactionsByBlockRange
+	args = [][]byte{[]byte(GetTransactionsByBlockRange), []byte(chainid), []byte("0"), []byte("0")}
+	sProp, _ = protoutil.MockSignedEndorserProposalOrPanic(
+		chainid,
+		&peer2.ChaincodeSpec{
+			ChaincodeId: &peer2.ChaincodeID{
+				Name: "qscc",
+			},
+		},
+		[]byte("Alice"),
+		[]byte("msg1"),
+	)
+	sProp.Signature = sProp.ProposalBytes
+	// Set the ACLProvider to have a failure
+	resetProvider(resources.Qscc_GetTransactionsByBlockRange, chainid, sProp, errors.New("Failed access control"))
+	res = stub.MockInvokeWithSignedProposal("2", args, sProp)
+	require.Equal(t, int32(shim.ERROR), res.Status, "GetTransactionsByBlockRange must fail: %s", res.Message)
+	require.Contains(t, res.Message, "Failed access control")
+	// assert that the expectations were met
+	mockAclProvider.AssertExpectations(t)
+
 	// GetTransactionByID
 	args = [][]byte{[]byte(GetTransactionByID), []byte(chainid), []byte("1")}
 	sProp, _ = protoutil.MockSignedEndorserProposalOrPanic(
@@ -427,6 +476,212 @@ func TestQueryGeneratedBlock(t *testing.T) {
 	}
 }
 
+func TestQueryGetHistoryForKey(t *testing.T) {
+	chainid := "mytestchainid9"
+	path := t.TempDir()
+
+	stub, p, cleanup, err := setupTestLedger(t, chainid, path)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	defer cleanup()
+
+	for i := 1; i <= 5; i++ {
+		addKeyUpdateForTesting(t, chainid, p, uint64(i), fmt.Sprintf("value%d", i))
+	}
+
+	query := func(args ...string) peer2.Response {
+		invokeArgs := [][]byte{[]byte(GetHistoryForKey), []byte(chainid)}
+		for _, arg := range args {
+			invokeArgs = append(invokeArgs, []byte(arg))
+		}
+		prop := resetProvider(resources.Qscc_GetHistoryForKey, chainid, nil, nil)
+		return stub.MockInvokeWithSignedProposal("1", invokeArgs, prop)
+	}
+
+	page := func(res peer2.Response) ([]string, *peer2.QueryResponseMetadata, bool) {
+		require.Equal(t, int32(shim.OK), res.Status, "GetHistoryForKey failed with err: %s", res.Message)
+		response := &peer2.QueryResponse{}
+		require.NoError(t, proto.Unmarshal(res.Payload, response))
+		var values []string
+		for _, result := range response.Results {
+			modification := &queryresult.KeyModification{}
+			require.NoError(t, proto.Unmarshal(result.ResultBytes, modification))
+			values = append(values, string(modification.Value))
+		}
+		metadata := &peer2.QueryResponseMetadata{}
+		require.NoError(t, proto.Unmarshal(response.Metadata, metadata))
+		return values, metadata, response.HasMore
+	}
+
+	t.Run("AllHistory", func(t *testing.T) {
+		values, metadata, hasMore := page(query("ns1", "key1"))
+		require.Equal(t, []string{"value5", "value4", "value3", "value2", "value1"}, values)
+		require.Equal(t, int32(5), metadata.FetchedRecordsCount)
+		require.Empty(t, metadata.Bookmark)
+		require.False(t, hasMore)
+	})
+
+	t.Run("Pages", func(t *testing.T) {
+		values, metadata, hasMore := page(query("ns1", "key1", "2"))
+		require.Equal(t, []string{"value5", "value4"}, values)
+		require.True(t, hasMore)
+		require.NotEmpty(t, metadata.Bookmark)
+
+		values, metadata, hasMore = page(query("ns1", "key1", "2", metadata.Bookmark))
+		require.Equal(t, []string{"value3", "value2"}, values)
+		require.True(t, hasMore)
+
+		values, metadata, hasMore = page(query("ns1", "key1", "2", metadata.Bookmark))
+		require.Equal(t, []string{"value1"}, values)
+		require.False(t, hasMore)
+		require.Empty(t, metadata.Bookmark)
+	})
+
+	t.Run("NoHistory", func(t *testing.T) {
+		values, metadata, hasMore := page(query("ns1", "missing"))
+		require.Empty(t, values)
+		require.Equal(t, int32(0), metadata.FetchedRecordsCount)
+		require.False(t, hasMore)
+	})
+
+	t.Run("UnknownBookmark", func(t *testing.T) {
+		res := query("ns1", "key1", "2", "unknown")
+		require.Equal(t, int32(shim.ERROR), res.Status)
+		require.Equal(t, "Bookmark unknown not found in the history of key key1 of namespace ns1", res.Message)
+	})
+
+	t.Run("HistoryDisabled", func(t *testing.T) {
+		stub, _, cleanup, err := setupTestLedgerWithHistory(t, "mytestchainid11", t.TempDir(), false)
+		require.NoError(t, err)
+		defer cleanup()
+
+		args := [][]byte{[]byte(GetHistoryForKey), []byte("mytestchainid11"), []byte("ns1"), []byte("key1")}
+		prop := resetProvider(resources.Qscc_GetHistoryForKey, "mytestchainid11", nil, nil)
+		res := stub.MockInvokeWithSignedProposal("1", args, prop)
+		require.Equal(t, int32(shim.ERROR), res.Status)
+		require.Equal(t, "History database is not enabled on this peer", res.Message)
+	})
+
+	t.Run("InvalidArguments", func(t *testing.T) {
+		res := query("ns1")
+		require.Equal(t, int32(shim.ERROR), res.Status)
+		require.Equal(t, "Namespace and key must be provided.", res.Message)
+
+		res = query("ns1", "")
+		require.Equal(t, int32(shim.ERROR), res.Status)
+		require.Equal(t, "Namespace and key must not be empty.", res.Message)
+
+		res = query("ns1", "key1", "ten")
+		require.Equal(t, int32(shim.ERROR), res.Status)
+		require.Contains(t, res.Message, "Failed to parse page size")
+
+		res = query("ns1", "key1", "0")
+		require.Equal(t, int32(shim.ERROR), res.Status)
+		require.Equal(t, "Page size must be between 1 and 1000, got 0", res.Message)
+
+		res = query("ns1", "key1", "1001")
+		require.Equal(t, int32(shim.ERROR), res.Status)
+		require.Equal(t, "Page size must be between 1 and 1000, got 1001", res.Message)
+	})
+}
+
+func TestQueryGetTransactionsByBlockRange(t *testing.T) {
+	chainid := "mytestchainid10"
+	path := t.TempDir()
+
+	stub, p, cleanup, err := setupTestLedger(t, chainid, path)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	defer cleanup()
+
+	block1 := addBlockForTesting(t, chainid, p)
+
+	query := func(start, end string) peer2.Response {
+		args := [][]byte{[]byte(GetTransactionsByBlockRange), []byte(chainid), []byte(start), []byte(end)}
+		prop := resetProvider(resources.Qscc_GetTransactionsByBlockRange, chainid, nil, nil)
+		return stub.MockInvokeWithSignedProposal("1", args, prop)
+	}
+
+	res := query("0", "1")
+	require.Equal(t, int32(shim.OK), res.Status, "GetTransactionsByBlockRange failed with err: %s", res.Message)
+	response := &peer2.QueryResponse{}
+	require.NoError(t, proto.Unmarshal(res.Payload, response))
+	require.Len(t, response.Results, 2)
+
+	var blocks []*peer2.FilteredBlock
+	for _, result := range response.Results {
+		filteredBlock := &peer2.FilteredBlock{}
+		require.NoError(t, proto.Unmarshal(result.ResultBytes, filteredBlock))
+		blocks = append(blocks, filteredBlock)
+	}
+	require.Equal(t, uint64(0), blocks[0].Number)
+	require.Len(t, blocks[0].FilteredTransactions, 1)
+	require.Equal(t, common.HeaderType_CONFIG, blocks[0].FilteredTransactions[0].Type)
+
+	require.Equal(t, uint64(1), blocks[1].Number)
+	require.Len(t, blocks[1].FilteredTransactions, len(block1.Data.Data))
+	for i, ebytes := range block1.Data.Data {
+		env, err := protoutil.GetEnvelopeFromBlock(ebytes)
+		require.NoError(t, err)
+		chdr, err := protoutil.ChannelHeader(env)
+		require.NoError(t, err)
+		tx := blocks[1].FilteredTransactions[i]
+		require.Equal(t, chdr.TxId, tx.Txid)
+		require.Equal(t, common.HeaderType_ENDORSER_TRANSACTION, tx.Type)
+		require.Equal(t, peer2.TxValidationCode_VALID, tx.TxValidationCode)
+	}
+
+	metadata := &peer2.QueryResponseMetadata{}
+	require.NoError(t, proto.Unmarshal(response.Metadata, metadata))
+	require.Equal(t, int32(2), metadata.FetchedRecordsCount)
+
+	res = query("2", "2")
+	require.Equal(t, int32(shim.ERROR), res.Status)
+	require.Contains(t, res.Message, "Failed to get block number 2")
+
+	res = query("1", "0")
+	require.Equal(t, int32(shim.ERROR), res.Status)
+	require.Equal(t, "Start block number 1 is greater than end block number 0", res.Message)
+
+	res = query("0", "1000")
+	require.Equal(t, int32(shim.ERROR), res.Status)
+	require.Equal(t, "Block range must not exceed 1000 blocks", res.Message)
+
+	res = query("first", "1")
+	require.Equal(t, int32(shim.ERROR), res.Status)
+	require.Contains(t, res.Message, "Failed to parse start block number")
+
+	res = query("0", "last")
+	require.Equal(t, int32(shim.ERROR), res.Status)
+	require.Contains(t, res.Message, "Failed to parse end block number")
+
+	args := [][]byte{[]byte(GetTransactionsByBlockRange), []byte(chainid), []byte("0")}
+	prop := resetProvider(resources.Qscc_GetTransactionsByBlockRange, chainid, nil, nil)
+	res = stub.MockInvokeWithSignedProposal("2", args, prop)
+	require.Equal(t, int32(shim.ERROR), res.Status)
+	require.Equal(t, "Start and end block numbers must be provided.", res.Message)
+}
+
+func addKeyUpdateForTesting(t *testing.T, chainid string, p *peer.Peer, blockNum uint64, value string) {
+	ledger := p.GetLedger(chainid)
+
+	simulator, err := ledger.NewTxSimulator(util.GenerateUUID())
+	require.NoError(t, err)
+	require.NoError(t, simulator.SetState("ns1", "key1", []byte(value)))
+	simulator.Done()
+	simRes, err := simulator.GetTxSimulationResults()
+	require.NoError(t, err)
+	pubSimResBytes, err := simRes.GetPubSimulationBytes()
+	require.NoError(t, err)
+
+	bcInfo, err := ledger.GetBlockchainInfo()
+	require.NoError(t, err)
+	block := testutil.ConstructBlock(t, blockNum, bcInfo.CurrentBlockHash, [][]byte{pubSimResBytes}, false)
+	require.NoError(t, ledger.CommitLegacy(&ledger2.BlockAndPvtData{Block: block}, &ledger2.CommitOptions{}))
+}
+
 func addBlockForTesting(t *testing.T, chainid string, p *peer.Peer) *common.Block {
 	ledger := p.GetLedger(chainid)
 	defer ledger.Close()
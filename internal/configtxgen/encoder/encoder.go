@@ -185,6 +185,9 @@ func NewOrdererGroup(conf *genesisconfig.Orderer, channelCapabilities map[string
 	if conf.OrdererType == "BFT" && !channelCapabilities["V3_0"] {
 		return nil, errors.New("orderer type BFT must be used with V3_0 capability")
 	}
+	if conf.AdaptiveBatchSize != nil && !conf.Capabilities["V3_0"] {
+		return nil, errors.New("adaptive batch size must be used with V3_0 orderer capability")
+	}
 	ordererGroup := protoutil.NewConfigGroup()
 	if err := AddOrdererPolicies(ordererGroup, conf.Policies, channelconfig.AdminsPolicyKey); err != nil {
 		return nil, errors.Wrapf(err, "error adding policies to orderer group")
@@ -195,6 +198,13 @@ func NewOrdererGroup(conf *genesisconfig.Orderer, channelCapabilities map[string
 		conf.BatchSize.PreferredMaxBytes,
 	), channelconfig.AdminsPolicyKey)
 	addValue(ordererGroup, channelconfig.BatchTimeoutValue(conf.BatchTimeout.String()), channelconfig.AdminsPolicyKey)
+	if conf.AdaptiveBatchSize != nil {
+		addValue(ordererGroup, channelconfig.AdaptiveBatchSizeValue(
+			conf.AdaptiveBatchSize.MinMessageCount,
+			conf.AdaptiveBatchSize.MaxMessageCount,
+			conf.AdaptiveBatchSize.TargetLatency.String(),
+		), channelconfig.AdminsPolicyKey)
+	}
 	addValue(ordererGroup, channelconfig.ChannelRestrictionsValue(conf.MaxChannels), channelconfig.AdminsPolicyKey)
 
 	if len(conf.Capabilities) > 0 {
@@ -23,6 +23,7 @@ import (
 	"github.com/hyperledger/fabric/internal/configtxgen/encoder/fakes"
 	"github.com/hyperledger/fabric/internal/configtxgen/genesisconfig"
 	"github.com/hyperledger/fabric/internal/pkg/identity"
+	"github.com/hyperledger/fabric/orderer/common/ordererext"
 	"github.com/hyperledger/fabric/protoutil"
 )
 
@@ -385,6 +386,42 @@ var _ = Describe("Encoder", func() {
 			Expect(cg.Values["Capabilities"]).NotTo(BeNil())
 		})
 
+		Context("when the adaptive batch size is set", func() {
+			BeforeEach(func() {
+				conf.AdaptiveBatchSize = &genesisconfig.AdaptiveBatchSize{
+					MinMessageCount: 10,
+					MaxMessageCount: 100,
+					TargetLatency:   500 * time.Millisecond,
+				}
+				conf.Capabilities["V3_0"] = true
+			})
+
+			It("adds the adaptive batch size value", func() {
+				cg, err := encoder.NewOrdererGroup(conf, channelCapabilities)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(cg.Values)).To(Equal(6))
+				abs := &ordererext.AdaptiveBatchSize{}
+				err = proto.Unmarshal(cg.Values["AdaptiveBatchSize"].Value, abs)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(proto.Equal(abs, &ordererext.AdaptiveBatchSize{
+					MinMessageCount: 10,
+					MaxMessageCount: 100,
+					TargetLatency:   "500ms",
+				})).To(BeTrue())
+			})
+
+			Context("when the V3_0 orderer capability is not enabled", func() {
+				BeforeEach(func() {
+					delete(conf.Capabilities, "V3_0")
+				})
+
+				It("returns an error", func() {
+					_, err := encoder.NewOrdererGroup(conf, channelCapabilities)
+					Expect(err).To(MatchError("adaptive batch size must be used with V3_0 orderer capability"))
+				})
+			})
+		})
+
 		Context("when the policy definition is bad", func() {
 			BeforeEach(func() {
 				conf.Policies["Admins"].Rule = "garbage"
@@ -149,17 +149,18 @@ type AnchorPeer struct {
 
 // Orderer contains configuration associated to a channel.
 type Orderer struct {
-	OrdererType      string                   `yaml:"OrdererType"`
-	Addresses        []string                 `yaml:"Addresses"`
-	BatchTimeout     time.Duration            `yaml:"BatchTimeout"`
-	BatchSize        BatchSize                `yaml:"BatchSize"`
-	ConsenterMapping []*Consenter             `yaml:"ConsenterMapping"`
-	EtcdRaft         *etcdraft.ConfigMetadata `yaml:"EtcdRaft"`
-	SmartBFT         *smartbft.Options        `yaml:"SmartBFT"`
-	Organizations    []*Organization          `yaml:"Organizations"`
-	MaxChannels      uint64                   `yaml:"MaxChannels"`
-	Capabilities     map[string]bool          `yaml:"Capabilities"`
-	Policies         map[string]*Policy       `yaml:"Policies"`
+	OrdererType       string                   `yaml:"OrdererType"`
+	Addresses         []string                 `yaml:"Addresses"`
+	BatchTimeout      time.Duration            `yaml:"BatchTimeout"`
+	BatchSize         BatchSize                `yaml:"BatchSize"`
+	AdaptiveBatchSize *AdaptiveBatchSize       `yaml:"AdaptiveBatchSize"`
+	ConsenterMapping  []*Consenter             `yaml:"ConsenterMapping"`
+	EtcdRaft          *etcdraft.ConfigMetadata `yaml:"EtcdRaft"`
+	SmartBFT          *smartbft.Options        `yaml:"SmartBFT"`
+	Organizations     []*Organization          `yaml:"Organizations"`
+	MaxChannels       uint64                   `yaml:"MaxChannels"`
+	Capabilities      map[string]bool          `yaml:"Capabilities"`
+	Policies          map[string]*Policy       `yaml:"Policies"`
 }
 
 // BatchSize contains configuration affecting the size of batches.
@@ -169,6 +170,13 @@ type BatchSize struct {
 	PreferredMaxBytes uint32 `yaml:"PreferredMaxBytes"`
 }
 
+// AdaptiveBatchSize contains configuration affecting the adaptive cutting of batches.
+type AdaptiveBatchSize struct {
+	MinMessageCount uint32        `yaml:"MinMessageCount"`
+	MaxMessageCount uint32        `yaml:"MaxMessageCount"`
+	TargetLatency   time.Duration `yaml:"TargetLatency"`
+}
+
 type Consenter struct {
 	ID            uint32 `yaml:"ID"`
 	Host          string `yaml:"Host"`
@@ -0,0 +1,149 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package builder
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/internal/configtxlator/update"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+// Modifier applies a change to a channel config.
+type Modifier func(config *cb.Config) error
+
+// ConfigFromBlock extracts the channel ID and the channel config from a
+// config block.
+func ConfigFromBlock(block *cb.Block) (string, *cb.Config, error) {
+	env, err := protoutil.ExtractEnvelope(block, 0)
+	if err != nil {
+		return "", nil, errors.WithMessage(err, "error extracting envelope from config block")
+	}
+
+	payload, err := protoutil.UnmarshalPayload(env.Payload)
+	if err != nil {
+		return "", nil, errors.WithMessage(err, "error unmarshalling payload of config block")
+	}
+
+	if payload.Header == nil {
+		return "", nil, errors.New("config block payload has no header")
+	}
+
+	chdr, err := protoutil.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		return "", nil, errors.WithMessage(err, "error unmarshalling channel header of config block")
+	}
+
+	if chdr.Type != int32(cb.HeaderType_CONFIG) {
+		return "", nil, errors.Errorf("block is not a config block, its header type is %s", cb.HeaderType(chdr.Type))
+	}
+
+	configEnv, err := protoutil.UnmarshalConfigEnvelope(payload.Data)
+	if err != nil {
+		return "", nil, errors.WithMessage(err, "error unmarshalling config envelope of config block")
+	}
+
+	if configEnv.Config == nil || configEnv.Config.ChannelGroup == nil {
+		return "", nil, errors.New("config block carries no channel config")
+	}
+
+	return chdr.ChannelId, configEnv.Config, nil
+}
+
+// Build applies the modifiers to a copy of the given config, and computes the
+// config update which transitions between the two. The config update is
+// returned wrapped in an unsigned CONFIG_UPDATE envelope, ready to be signed
+// by the admins required by the modification policies.
+func Build(channelID string, original *cb.Config, modifiers ...Modifier) (*cb.Envelope, *cb.ConfigUpdate, error) {
+	updated := proto.Clone(original).(*cb.Config)
+	for _, modify := range modifiers {
+		if err := modify(updated); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	configUpdate, err := update.Compute(original, updated)
+	if err != nil {
+		return nil, nil, errors.WithMessage(err, "error computing config update")
+	}
+	configUpdate.ChannelId = channelID
+
+	env, err := protoutil.CreateSignedEnvelope(
+		cb.HeaderType_CONFIG_UPDATE,
+		channelID,
+		nil,
+		&cb.ConfigUpdateEnvelope{ConfigUpdate: protoutil.MarshalOrPanic(configUpdate)},
+		0,
+		0,
+	)
+	if err != nil {
+		return nil, nil, errors.WithMessage(err, "error creating config update envelope")
+	}
+
+	return env, configUpdate, nil
+}
+
+// ModPolicies returns the fully qualified names of the modification policies
+// which the signatures of a config update must satisfy for the update to be
+// applied on top of the original config, in lexical order.
+//
+// An element of the config which is modified by the update is governed by the
+// mod_policy it carries in the original config. Adding or removing an element
+// modifies the group which contains it.
+func ModPolicies(original *cb.Config, configUpdate *cb.ConfigUpdate) []string {
+	policies := map[string]struct{}{}
+	modPolicies(original.GetChannelGroup(), configUpdate.GetWriteSet(), update.RootGroupPath, policies)
+
+	result := make([]string, 0, len(policies))
+	for policy := range policies {
+		result = append(result, policy)
+	}
+	sort.Strings(result)
+
+	return result
+}
+
+func modPolicies(original, written *cb.ConfigGroup, path string, policies map[string]struct{}) {
+	if original == nil || written == nil {
+		// The group is new, and its addition is governed by the parent group.
+		return
+	}
+
+	if written.Version > original.Version {
+		policies[qualifiedPolicy(path, original.ModPolicy)] = struct{}{}
+	}
+
+	for key, value := range written.Values {
+		if orig, ok := original.Values[key]; ok && value.Version > orig.Version {
+			policies[qualifiedPolicy(path, orig.ModPolicy)] = struct{}{}
+		}
+	}
+
+	for key, policy := range written.Policies {
+		if orig, ok := original.Policies[key]; ok && policy.Version > orig.Version {
+			policies[qualifiedPolicy(path, orig.ModPolicy)] = struct{}{}
+		}
+	}
+
+	for key, group := range written.Groups {
+		modPolicies(original.Groups[key], group, path+"/"+key, policies)
+	}
+}
+
+// qualifiedPolicy resolves a mod_policy relative to the path of the group
+// which contains the element it governs.
+func qualifiedPolicy(groupPath, modPolicy string) string {
+	if strings.HasPrefix(modPolicy, "/") {
+		return modPolicy
+	}
+
+	return groupPath + "/" + modPolicy
+}
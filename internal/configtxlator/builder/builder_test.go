@@ -0,0 +1,253 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package builder
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	ab "github.com/hyperledger/fabric-protos-go/orderer"
+	"github.com/hyperledger/fabric-protos-go/orderer/etcdraft"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/crypto/tlsgen"
+	"github.com/hyperledger/fabric/core/config/configtest"
+	"github.com/hyperledger/fabric/internal/configtxgen/encoder"
+	"github.com/hyperledger/fabric/internal/configtxgen/genesisconfig"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/require"
+)
+
+func loadProfile(t *testing.T, profileName string) *genesisconfig.Profile {
+	profile := genesisconfig.Load(profileName, configtest.GetDevConfigDir())
+
+	ca, err := tlsgen.NewCA()
+	require.NoError(t, err)
+	certPath := filepath.Join(t.TempDir(), "cert.pem")
+	require.NoError(t, os.WriteFile(certPath, ca.CertBytes(), 0o644))
+
+	for _, consenter := range profile.Orderer.EtcdRaft.GetConsenters() {
+		consenter.ClientTlsCert = []byte(certPath)
+		consenter.ServerTlsCert = []byte(certPath)
+	}
+	for _, consenter := range profile.Orderer.ConsenterMapping {
+		consenter.Identity = certPath
+		consenter.ClientTLSCert = certPath
+		consenter.ServerTLSCert = certPath
+	}
+
+	return profile
+}
+
+func configBlock(t *testing.T, profileName string) *cb.Block {
+	return encoder.New(loadProfile(t, profileName)).GenesisBlockForChannel("mychannel")
+}
+
+func configFromProfile(t *testing.T, profileName string) *cb.Config {
+	channelID, config, err := ConfigFromBlock(configBlock(t, profileName))
+	require.NoError(t, err)
+	require.Equal(t, "mychannel", channelID)
+	return config
+}
+
+// buildUpdate applies a modifier, checks the envelope is an unsigned config
+// update for mychannel, and returns the config update.
+func buildUpdate(t *testing.T, config *cb.Config, modifier Modifier) *cb.ConfigUpdate {
+	env, configUpdate, err := Build("mychannel", config, modifier)
+	require.NoError(t, err)
+
+	payload, err := protoutil.UnmarshalPayload(env.Payload)
+	require.NoError(t, err)
+	chdr, err := protoutil.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	require.NoError(t, err)
+	require.Equal(t, int32(cb.HeaderType_CONFIG_UPDATE), chdr.Type)
+	require.Equal(t, "mychannel", chdr.ChannelId)
+
+	configUpdateEnv := &cb.ConfigUpdateEnvelope{}
+	err = proto.Unmarshal(payload.Data, configUpdateEnv)
+	require.NoError(t, err)
+	require.Empty(t, configUpdateEnv.Signatures)
+	configUpdate2 := &cb.ConfigUpdate{}
+	err = proto.Unmarshal(configUpdateEnv.ConfigUpdate, configUpdate2)
+	require.NoError(t, err)
+	require.True(t, proto.Equal(configUpdate, configUpdate2))
+	require.Equal(t, "mychannel", configUpdate.ChannelId)
+
+	return configUpdate
+}
+
+func TestConfigFromBlock(t *testing.T) {
+	t.Run("config block", func(t *testing.T) {
+		channelID, config, err := ConfigFromBlock(configBlock(t, genesisconfig.SampleAppChannelEtcdRaftProfile))
+		require.NoError(t, err)
+		require.Equal(t, "mychannel", channelID)
+		require.Contains(t, config.ChannelGroup.Groups, channelconfig.ApplicationGroupKey)
+	})
+
+	t.Run("empty block", func(t *testing.T) {
+		_, _, err := ConfigFromBlock(protoutil.NewBlock(0, nil))
+		require.EqualError(t, err, "error extracting envelope from config block: envelope index out of bounds")
+	})
+
+	t.Run("not a config block", func(t *testing.T) {
+		block := protoutil.NewBlock(1, nil)
+		env, err := protoutil.CreateSignedEnvelope(cb.HeaderType_ENDORSER_TRANSACTION, "mychannel", nil, &cb.ConfigEnvelope{}, 0, 0)
+		require.NoError(t, err)
+		block.Data.Data = [][]byte{protoutil.MarshalOrPanic(env)}
+		_, _, err = ConfigFromBlock(block)
+		require.EqualError(t, err, "block is not a config block, its header type is ENDORSER_TRANSACTION")
+	})
+}
+
+func TestAddApplicationOrg(t *testing.T) {
+	profile := loadProfile(t, genesisconfig.SampleAppChannelEtcdRaftProfile)
+	config := configFromProfile(t, genesisconfig.SampleAppChannelEtcdRaftProfile)
+
+	org := *profile.Application.Organizations[0]
+	org.Name = "Org2"
+	orgGroup, err := encoder.NewApplicationOrgGroup(&org)
+	require.NoError(t, err)
+
+	configUpdate := buildUpdate(t, config, AddApplicationOrg("Org2", orgGroup))
+	written := configUpdate.WriteSet.Groups[channelconfig.ApplicationGroupKey].Groups["Org2"]
+	require.NotNil(t, written)
+	require.True(t, proto.Equal(orgGroup, written))
+	require.Equal(t, []string{"/Channel/Application/Admins"}, ModPolicies(config, configUpdate))
+
+	_, _, err = Build("mychannel", config, AddApplicationOrg("SampleOrg", orgGroup))
+	require.EqualError(t, err, "organization SampleOrg already exists in the application group")
+}
+
+func TestRemoveApplicationOrg(t *testing.T) {
+	config := configFromProfile(t, genesisconfig.SampleAppChannelEtcdRaftProfile)
+
+	configUpdate := buildUpdate(t, config, RemoveApplicationOrg("SampleOrg"))
+	require.Empty(t, configUpdate.WriteSet.Groups[channelconfig.ApplicationGroupKey].Groups)
+	require.Equal(t, []string{"/Channel/Application/Admins"}, ModPolicies(config, configUpdate))
+
+	_, _, err := Build("mychannel", config, RemoveApplicationOrg("Org2"))
+	require.EqualError(t, err, "organization Org2 does not exist in the application group")
+
+	delete(config.ChannelGroup.Groups, channelconfig.ApplicationGroupKey)
+	_, _, err = Build("mychannel", config, RemoveApplicationOrg("SampleOrg"))
+	require.EqualError(t, err, "channel config has no application group")
+}
+
+func TestSetBatchTimeout(t *testing.T) {
+	config := configFromProfile(t, genesisconfig.SampleAppChannelEtcdRaftProfile)
+
+	configUpdate := buildUpdate(t, config, SetBatchTimeout(5*time.Second))
+	batchTimeout := &ab.BatchTimeout{}
+	err := proto.Unmarshal(configUpdate.WriteSet.Groups[channelconfig.OrdererGroupKey].Values[channelconfig.BatchTimeoutKey].Value, batchTimeout)
+	require.NoError(t, err)
+	require.Equal(t, "5s", batchTimeout.Timeout)
+	require.Equal(t, []string{"/Channel/Orderer/Admins"}, ModPolicies(config, configUpdate))
+
+	_, _, err = Build("mychannel", config, SetBatchTimeout(0))
+	require.EqualError(t, err, "batch timeout must be positive, got 0s")
+
+	_, _, err = Build("mychannel", config, SetBatchTimeout(2*time.Second))
+	require.EqualError(t, err, "error computing config update: no differences detected between original and updated config")
+}
+
+func TestSetBatchSize(t *testing.T) {
+	config := configFromProfile(t, genesisconfig.SampleAppChannelEtcdRaftProfile)
+	original := &ab.BatchSize{}
+	err := proto.Unmarshal(config.ChannelGroup.Groups[channelconfig.OrdererGroupKey].Values[channelconfig.BatchSizeKey].Value, original)
+	require.NoError(t, err)
+
+	configUpdate := buildUpdate(t, config, SetBatchSize(100, 0, 1024))
+	batchSize := &ab.BatchSize{}
+	err = proto.Unmarshal(configUpdate.WriteSet.Groups[channelconfig.OrdererGroupKey].Values[channelconfig.BatchSizeKey].Value, batchSize)
+	require.NoError(t, err)
+	require.Equal(t, uint32(100), batchSize.MaxMessageCount)
+	require.Equal(t, original.AbsoluteMaxBytes, batchSize.AbsoluteMaxBytes)
+	require.Equal(t, uint32(1024), batchSize.PreferredMaxBytes)
+	require.Equal(t, []string{"/Channel/Orderer/Admins"}, ModPolicies(config, configUpdate))
+
+	_, _, err = Build("mychannel", config, SetBatchSize(0, 1024, 2048))
+	require.EqualError(t, err, "preferred max bytes (2048) must not exceed absolute max bytes (1024)")
+}
+
+func TestUpdateConsenterTLSCerts(t *testing.T) {
+	t.Run("etcdraft", func(t *testing.T) {
+		config := configFromProfile(t, genesisconfig.SampleAppChannelEtcdRaftProfile)
+
+		configUpdate := buildUpdate(t, config, UpdateConsenterTLSCerts("raft1.example.com", 7050, []byte("client"), []byte("server")))
+		consensusType := &ab.ConsensusType{}
+		err := proto.Unmarshal(configUpdate.WriteSet.Groups[channelconfig.OrdererGroupKey].Values[channelconfig.ConsensusTypeKey].Value, consensusType)
+		require.NoError(t, err)
+		metadata := &etcdraft.ConfigMetadata{}
+		err = proto.Unmarshal(consensusType.Metadata, metadata)
+		require.NoError(t, err)
+		require.Len(t, metadata.Consenters, 3)
+		require.Equal(t, []byte("client"), metadata.Consenters[1].ClientTlsCert)
+		require.Equal(t, []byte("server"), metadata.Consenters[1].ServerTlsCert)
+		require.NotEqual(t, []byte("client"), metadata.Consenters[0].ClientTlsCert)
+		require.Equal(t, []string{"/Channel/Orderer/Admins"}, ModPolicies(config, configUpdate))
+
+		_, _, err = Build("mychannel", config, UpdateConsenterTLSCerts("raft1.example.com", 7051, []byte("client"), nil))
+		require.EqualError(t, err, "consenter raft1.example.com:7051 does not exist")
+	})
+
+	t.Run("BFT", func(t *testing.T) {
+		config := configFromProfile(t, genesisconfig.SampleAppChannelSmartBftProfile)
+
+		configUpdate := buildUpdate(t, config, UpdateConsenterTLSCerts("bft2.example.com", 7050, nil, []byte("server")))
+		orderers := &cb.Orderers{}
+		err := proto.Unmarshal(configUpdate.WriteSet.Groups[channelconfig.OrdererGroupKey].Values[channelconfig.OrderersKey].Value, orderers)
+		require.NoError(t, err)
+		require.Len(t, orderers.ConsenterMapping, 4)
+		require.Equal(t, "bft2.example.com", orderers.ConsenterMapping[2].Host)
+		require.Equal(t, []byte("server"), orderers.ConsenterMapping[2].ServerTlsCert)
+		require.NotEqual(t, []byte("server"), orderers.ConsenterMapping[2].ClientTlsCert)
+		require.Equal(t, []string{"/Channel/Orderer/Admins"}, ModPolicies(config, configUpdate))
+	})
+}
+
+func TestModPolicies(t *testing.T) {
+	original := &cb.Config{
+		ChannelGroup: &cb.ConfigGroup{
+			Version:   1,
+			ModPolicy: "Admins",
+			Values: map[string]*cb.ConfigValue{
+				"Foo": {Version: 2, ModPolicy: "/Channel/Orderer/Admins"},
+			},
+			Policies: map[string]*cb.ConfigPolicy{
+				"Bar": {ModPolicy: "Admins"},
+			},
+			Groups: map[string]*cb.ConfigGroup{
+				"Orderer": {ModPolicy: "Admins"},
+			},
+		},
+	}
+
+	configUpdate := &cb.ConfigUpdate{
+		WriteSet: &cb.ConfigGroup{
+			Version: 1,
+			Values: map[string]*cb.ConfigValue{
+				"Foo": {Version: 3},
+			},
+			Policies: map[string]*cb.ConfigPolicy{
+				"Bar": {Version: 0},
+			},
+			Groups: map[string]*cb.ConfigGroup{
+				"Orderer": {
+					Version: 1,
+					Groups: map[string]*cb.ConfigGroup{
+						"NewOrg": {ModPolicy: "Admins"},
+					},
+				},
+			},
+		},
+	}
+
+	require.Equal(t, []string{"/Channel/Orderer/Admins"}, ModPolicies(original, configUpdate))
+}
@@ -0,0 +1,238 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package builder
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	ab "github.com/hyperledger/fabric-protos-go/orderer"
+	"github.com/hyperledger/fabric-protos-go/orderer/etcdraft"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/pkg/errors"
+)
+
+// etcdRaftConsensusType is the consensus type of channels ordered by etcdraft.
+const etcdRaftConsensusType = "etcdraft"
+
+// AddApplicationOrg adds an organization, such as the one built by
+// encoder.NewApplicationOrgGroup, to the application group of the channel.
+func AddApplicationOrg(name string, org *cb.ConfigGroup) Modifier {
+	return func(config *cb.Config) error {
+		application, err := applicationGroup(config)
+		if err != nil {
+			return err
+		}
+
+		if _, ok := application.Groups[name]; ok {
+			return errors.Errorf("organization %s already exists in the application group", name)
+		}
+
+		if application.Groups == nil {
+			application.Groups = map[string]*cb.ConfigGroup{}
+		}
+		application.Groups[name] = proto.Clone(org).(*cb.ConfigGroup)
+
+		return nil
+	}
+}
+
+// RemoveApplicationOrg removes an organization from the application group of
+// the channel.
+func RemoveApplicationOrg(name string) Modifier {
+	return func(config *cb.Config) error {
+		application, err := applicationGroup(config)
+		if err != nil {
+			return err
+		}
+
+		if _, ok := application.Groups[name]; !ok {
+			return errors.Errorf("organization %s does not exist in the application group", name)
+		}
+		delete(application.Groups, name)
+
+		return nil
+	}
+}
+
+// SetBatchTimeout sets the amount of time the orderer waits before cutting a
+// block.
+func SetBatchTimeout(timeout time.Duration) Modifier {
+	return func(config *cb.Config) error {
+		if timeout <= 0 {
+			return errors.Errorf("batch timeout must be positive, got %s", timeout)
+		}
+
+		orderer, err := ordererGroup(config)
+		if err != nil {
+			return err
+		}
+
+		batchTimeout := &ab.BatchTimeout{}
+		if err := unmarshalValue(orderer, channelconfig.BatchTimeoutKey, batchTimeout); err != nil {
+			return err
+		}
+		batchTimeout.Timeout = timeout.String()
+
+		return marshalValue(orderer, channelconfig.BatchTimeoutKey, batchTimeout)
+	}
+}
+
+// SetBatchSize sets the limits on the size of the blocks cut by the orderer.
+// A zero limit is left unchanged.
+func SetBatchSize(maxMessageCount, absoluteMaxBytes, preferredMaxBytes uint32) Modifier {
+	return func(config *cb.Config) error {
+		orderer, err := ordererGroup(config)
+		if err != nil {
+			return err
+		}
+
+		batchSize := &ab.BatchSize{}
+		if err := unmarshalValue(orderer, channelconfig.BatchSizeKey, batchSize); err != nil {
+			return err
+		}
+
+		if maxMessageCount != 0 {
+			batchSize.MaxMessageCount = maxMessageCount
+		}
+		if absoluteMaxBytes != 0 {
+			batchSize.AbsoluteMaxBytes = absoluteMaxBytes
+		}
+		if preferredMaxBytes != 0 {
+			batchSize.PreferredMaxBytes = preferredMaxBytes
+		}
+
+		if batchSize.PreferredMaxBytes > batchSize.AbsoluteMaxBytes {
+			return errors.Errorf("preferred max bytes (%d) must not exceed absolute max bytes (%d)", batchSize.PreferredMaxBytes, batchSize.AbsoluteMaxBytes)
+		}
+
+		return marshalValue(orderer, channelconfig.BatchSizeKey, batchSize)
+	}
+}
+
+// UpdateConsenterTLSCerts replaces the TLS certificates of the consenter
+// listening on the given host and port, e.g. when rotating them. A nil
+// certificate is left unchanged.
+//
+// The consenter is updated in the etcdraft metadata of the consensus type of
+// etcdraft channels, and in the consenters mapping of BFT channels.
+func UpdateConsenterTLSCerts(host string, port uint32, clientTLSCert, serverTLSCert []byte) Modifier {
+	return func(config *cb.Config) error {
+		orderer, err := ordererGroup(config)
+		if err != nil {
+			return err
+		}
+
+		endpoint := fmt.Sprintf("%s:%d", host, port)
+		found := false
+
+		consensusType := &ab.ConsensusType{}
+		if err := unmarshalValue(orderer, channelconfig.ConsensusTypeKey, consensusType); err != nil {
+			return err
+		}
+
+		if consensusType.Type == etcdRaftConsensusType {
+			metadata := &etcdraft.ConfigMetadata{}
+			if err := proto.Unmarshal(consensusType.Metadata, metadata); err != nil {
+				return errors.Wrap(err, "error unmarshalling etcdraft metadata")
+			}
+
+			for _, consenter := range metadata.Consenters {
+				if consenter.Host != host || consenter.Port != port {
+					continue
+				}
+				found = true
+				if clientTLSCert != nil {
+					consenter.ClientTlsCert = clientTLSCert
+				}
+				if serverTLSCert != nil {
+					consenter.ServerTlsCert = serverTLSCert
+				}
+			}
+
+			if consensusType.Metadata, err = proto.Marshal(metadata); err != nil {
+				return errors.Wrap(err, "error marshalling etcdraft metadata")
+			}
+			if err := marshalValue(orderer, channelconfig.ConsensusTypeKey, consensusType); err != nil {
+				return err
+			}
+		}
+
+		if _, ok := orderer.Values[channelconfig.OrderersKey]; ok {
+			orderers := &cb.Orderers{}
+			if err := unmarshalValue(orderer, channelconfig.OrderersKey, orderers); err != nil {
+				return err
+			}
+
+			for _, consenter := range orderers.ConsenterMapping {
+				if consenter.Host != host || consenter.Port != port {
+					continue
+				}
+				found = true
+				if clientTLSCert != nil {
+					consenter.ClientTlsCert = clientTLSCert
+				}
+				if serverTLSCert != nil {
+					consenter.ServerTlsCert = serverTLSCert
+				}
+			}
+
+			if err := marshalValue(orderer, channelconfig.OrderersKey, orderers); err != nil {
+				return err
+			}
+		}
+
+		if !found {
+			return errors.Errorf("consenter %s does not exist", endpoint)
+		}
+
+		return nil
+	}
+}
+
+func applicationGroup(config *cb.Config) (*cb.ConfigGroup, error) {
+	application, ok := config.GetChannelGroup().GetGroups()[channelconfig.ApplicationGroupKey]
+	if !ok {
+		return nil, errors.New("channel config has no application group")
+	}
+
+	return application, nil
+}
+
+func ordererGroup(config *cb.Config) (*cb.ConfigGroup, error) {
+	orderer, ok := config.GetChannelGroup().GetGroups()[channelconfig.OrdererGroupKey]
+	if !ok {
+		return nil, errors.New("channel config has no orderer group")
+	}
+
+	return orderer, nil
+}
+
+func unmarshalValue(group *cb.ConfigGroup, key string, msg proto.Message) error {
+	value, ok := group.Values[key]
+	if !ok {
+		return errors.Errorf("config value %s does not exist", key)
+	}
+
+	if err := proto.Unmarshal(value.Value, msg); err != nil {
+		return errors.Wrapf(err, "error unmarshalling config value %s", key)
+	}
+
+	return nil
+}
+
+func marshalValue(group *cb.ConfigGroup, key string, msg proto.Message) error {
+	bytes, err := proto.Marshal(msg)
+	if err != nil {
+		return errors.Wrapf(err, "error marshalling config value %s", key)
+	}
+	group.Values[key].Value = bytes
+
+	return nil
+}
@@ -0,0 +1,122 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package diff
+
+import (
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+// ConfigUpdateFromEnvelope extracts the config update from a CONFIG_UPDATE
+// envelope, such as the one produced by peer channel signconfigtx.
+func ConfigUpdateFromEnvelope(env *cb.Envelope) (*cb.ConfigUpdate, error) {
+	payload, err := protoutil.UnmarshalPayload(env.Payload)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error unmarshalling payload of envelope")
+	}
+
+	if payload.Header == nil {
+		return nil, errors.New("envelope payload has no header")
+	}
+
+	chdr, err := protoutil.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error unmarshalling channel header of envelope")
+	}
+
+	if chdr.Type != int32(cb.HeaderType_CONFIG_UPDATE) {
+		return nil, errors.Errorf("envelope is not a config update, its header type is %s", cb.HeaderType(chdr.Type))
+	}
+
+	configUpdateEnv := &cb.ConfigUpdateEnvelope{}
+	if err := proto.Unmarshal(payload.Data, configUpdateEnv); err != nil {
+		return nil, errors.Wrap(err, "error unmarshalling config update envelope")
+	}
+
+	configUpdate := &cb.ConfigUpdate{}
+	if err := proto.Unmarshal(configUpdateEnv.ConfigUpdate, configUpdate); err != nil {
+		return nil, errors.Wrap(err, "error unmarshalling config update")
+	}
+
+	return configUpdate, nil
+}
+
+// ApplyUpdate returns the config which results from applying the write set of
+// a config update on top of the original config. The update is neither
+// validated against the read set nor authorized.
+//
+// As computed by the update package, an element of the write set whose
+// version matches the original only references the original element, and a
+// group whose version is bumped lists all of its members, so that the members
+// it does not list are removed.
+func ApplyUpdate(original *cb.Config, configUpdate *cb.ConfigUpdate) (*cb.Config, error) {
+	if original.GetChannelGroup() == nil {
+		return nil, errors.New("original config has no channel group")
+	}
+	if configUpdate.GetWriteSet() == nil {
+		return nil, errors.New("config update has no write set")
+	}
+
+	return &cb.Config{
+		Sequence:     original.Sequence + 1,
+		ChannelGroup: applyGroup(original.ChannelGroup, configUpdate.WriteSet),
+	}, nil
+}
+
+func applyGroup(original, written *cb.ConfigGroup) *cb.ConfigGroup {
+	result := proto.Clone(original).(*cb.ConfigGroup)
+	replaceMembers := written.Version > original.Version
+	if replaceMembers {
+		result.Version = written.Version
+		result.ModPolicy = written.ModPolicy
+		result.Values = map[string]*cb.ConfigValue{}
+		result.Policies = map[string]*cb.ConfigPolicy{}
+		result.Groups = map[string]*cb.ConfigGroup{}
+	}
+	if result.Values == nil {
+		result.Values = map[string]*cb.ConfigValue{}
+	}
+	if result.Policies == nil {
+		result.Policies = map[string]*cb.ConfigPolicy{}
+	}
+	if result.Groups == nil {
+		result.Groups = map[string]*cb.ConfigGroup{}
+	}
+
+	for key, value := range written.Values {
+		orig, ok := original.Values[key]
+		switch {
+		case !ok || value.Version > orig.Version:
+			result.Values[key] = proto.Clone(value).(*cb.ConfigValue)
+		case replaceMembers:
+			result.Values[key] = proto.Clone(orig).(*cb.ConfigValue)
+		}
+	}
+
+	for key, policy := range written.Policies {
+		orig, ok := original.Policies[key]
+		switch {
+		case !ok || policy.Version > orig.Version:
+			result.Policies[key] = proto.Clone(policy).(*cb.ConfigPolicy)
+		case replaceMembers:
+			result.Policies[key] = proto.Clone(orig).(*cb.ConfigPolicy)
+		}
+	}
+
+	for key, group := range written.Groups {
+		orig, ok := original.Groups[key]
+		if !ok {
+			result.Groups[key] = proto.Clone(group).(*cb.ConfigGroup)
+			continue
+		}
+		result.Groups[key] = applyGroup(orig, group)
+	}
+
+	return result
+}
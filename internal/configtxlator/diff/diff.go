@@ -0,0 +1,413 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package diff
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hyperledger/fabric-config/protolator"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/internal/configtxlator/update"
+	"github.com/pkg/errors"
+)
+
+// ElementType is the kind of config element a change applies to.
+type ElementType string
+
+const (
+	Group  ElementType = "group"
+	Value  ElementType = "value"
+	Policy ElementType = "policy"
+)
+
+// ChangeType is the kind of change made to a config element, or to one of
+// its fields.
+type ChangeType string
+
+const (
+	Added    ChangeType = "added"
+	Removed  ChangeType = "removed"
+	Modified ChangeType = "modified"
+)
+
+// Change is a single difference between two channel configs.
+type Change struct {
+	// Path is the fully qualified path of the config element, e.g.
+	// /Channel/Orderer/BatchSize.
+	Path string `json:"path"`
+	// Element is the kind of the config element.
+	Element ElementType `json:"element"`
+	// Type is the kind of change.
+	Type ChangeType `json:"type"`
+	// Field is the path of the changed field within the JSON representation
+	// of the element, e.g. value.max_message_count. It is empty when the
+	// element as a whole was added or removed.
+	Field string `json:"field,omitempty"`
+	// Old is the value before the change, if any.
+	Old interface{} `json:"old,omitempty"`
+	// New is the value after the change, if any.
+	New interface{} `json:"new,omitempty"`
+}
+
+// Certificate is the summary of an X.509 certificate which replaces its PEM
+// encoding in the diff.
+type Certificate struct {
+	Subject      string    `json:"subject"`
+	Issuer       string    `json:"issuer"`
+	SerialNumber string    `json:"serial_number"`
+	NotBefore    time.Time `json:"not_before"`
+	NotAfter     time.Time `json:"not_after"`
+	Fingerprint  string    `json:"sha256_fingerprint"`
+}
+
+// Configs returns the differences between two channel configs, ordered by
+// path. Config values and policies are decoded as by protolator, and the
+// certificates they embed are summarized.
+func Configs(original, updated *cb.Config) ([]Change, error) {
+	originalGroup, err := decodedChannelGroup(original)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error decoding original config")
+	}
+
+	updatedGroup, err := decodedChannelGroup(updated)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error decoding updated config")
+	}
+
+	var changes []Change
+	diffGroup(update.RootGroupPath, originalGroup, updatedGroup, &changes)
+
+	return changes, nil
+}
+
+// WriteText writes the changes to w, one per line, as
+//
+//	<+|-|~> <element> <path>[ <field>][: <old> -> <new>]
+func WriteText(w io.Writer, changes []Change) error {
+	for _, change := range changes {
+		line := &strings.Builder{}
+
+		switch change.Type {
+		case Added:
+			line.WriteString("+ ")
+		case Removed:
+			line.WriteString("- ")
+		default:
+			line.WriteString("~ ")
+		}
+		fmt.Fprintf(line, "%-6s %s", change.Element, change.Path)
+		if change.Field != "" {
+			fmt.Fprintf(line, " %s", change.Field)
+		}
+
+		switch {
+		case change.Type == Modified:
+			fmt.Fprintf(line, ": %s -> %s", textValue(change.Old), textValue(change.New))
+		case change.New != nil:
+			fmt.Fprintf(line, ": %s", textValue(change.New))
+		case change.Old != nil:
+			fmt.Fprintf(line, ": %s", textValue(change.Old))
+		}
+
+		if _, err := fmt.Fprintln(w, line.String()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// WriteJSON writes the changes to w as an indented JSON array.
+func WriteJSON(w io.Writer, changes []Change) error {
+	if changes == nil {
+		changes = []Change{}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "\t")
+	return encoder.Encode(changes)
+}
+
+func textValue(value interface{}) string {
+	if cert, ok := value.(*Certificate); ok {
+		return fmt.Sprintf("certificate %q issued by %q, serial %s, expires %s", cert.Subject, cert.Issuer, cert.SerialNumber, cert.NotAfter.Format(time.RFC3339))
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(encoded)
+}
+
+// decodedChannelGroup returns the protolator JSON representation of the
+// channel group of the config as a generic tree.
+func decodedChannelGroup(config *cb.Config) (map[string]interface{}, error) {
+	if config.GetChannelGroup() == nil {
+		return nil, errors.New("config has no channel group")
+	}
+
+	// The types of the config values are only resolved when the channel
+	// group is marshaled as part of the config.
+	buf := &bytes.Buffer{}
+	if err := protolator.DeepMarshalJSON(buf, config); err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(buf)
+	decoder.UseNumber()
+	var decoded map[string]interface{}
+	if err := decoder.Decode(&decoded); err != nil {
+		return nil, err
+	}
+
+	return summarizeCertificates(asMap(decoded["channel_group"])).(map[string]interface{}), nil
+}
+
+// summarizeCertificates replaces the base64 encoded PEM certificates found
+// in the tree by their summary.
+func summarizeCertificates(node interface{}) interface{} {
+	switch n := node.(type) {
+	case map[string]interface{}:
+		for key, child := range n {
+			n[key] = summarizeCertificates(child)
+		}
+	case []interface{}:
+		for i, child := range n {
+			n[i] = summarizeCertificates(child)
+		}
+	case string:
+		if cert := parseCertificate(n); cert != nil {
+			return cert
+		}
+	}
+
+	return node
+}
+
+func parseCertificate(encoded string) *Certificate {
+	raw, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil
+	}
+
+	block, _ := pem.Decode(raw)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil
+	}
+
+	fingerprint := sha256.Sum256(cert.Raw)
+	return &Certificate{
+		Subject:      cert.Subject.String(),
+		Issuer:       cert.Issuer.String(),
+		SerialNumber: cert.SerialNumber.String(),
+		NotBefore:    cert.NotBefore.UTC(),
+		NotAfter:     cert.NotAfter.UTC(),
+		Fingerprint:  hex.EncodeToString(fingerprint[:]),
+	}
+}
+
+// diffGroup compares two groups. The original group is nil when the group
+// was added, in which case all its elements are reported as added.
+func diffGroup(path string, original, updated map[string]interface{}, changes *[]Change) {
+	for _, field := range []string{"mod_policy", "version"} {
+		if original != nil && !reflect.DeepEqual(original[field], updated[field]) {
+			*changes = append(*changes, Change{
+				Path:    path,
+				Element: Group,
+				Type:    Modified,
+				Field:   field,
+				Old:     original[field],
+				New:     updated[field],
+			})
+		}
+	}
+
+	diffElements(path, Value, asMap(original["values"]), asMap(updated["values"]), changes)
+	diffElements(path, Policy, asMap(original["policies"]), asMap(updated["policies"]), changes)
+
+	originalGroups, updatedGroups := asMap(original["groups"]), asMap(updated["groups"])
+	for _, key := range unionKeys(originalGroups, updatedGroups) {
+		originalGroup, inOriginal := originalGroups[key]
+		updatedGroup, inUpdated := updatedGroups[key]
+		groupPath := path + "/" + key
+
+		switch {
+		case !inOriginal:
+			*changes = append(*changes, Change{Path: groupPath, Element: Group, Type: Added, New: groupFields(updatedGroup)})
+			diffGroup(groupPath, nil, asMap(updatedGroup), changes)
+		case !inUpdated:
+			*changes = append(*changes, Change{Path: groupPath, Element: Group, Type: Removed, Old: groupFields(originalGroup)})
+		default:
+			diffGroup(groupPath, asMap(originalGroup), asMap(updatedGroup), changes)
+		}
+	}
+}
+
+// diffElements compares the values or the policies of a group.
+func diffElements(path string, element ElementType, original, updated map[string]interface{}, changes *[]Change) {
+	for _, key := range unionKeys(original, updated) {
+		originalElement, inOriginal := original[key]
+		updatedElement, inUpdated := updated[key]
+		elementPath := path + "/" + key
+
+		switch {
+		case !inOriginal:
+			*changes = append(*changes, Change{Path: elementPath, Element: element, Type: Added, New: updatedElement})
+		case !inUpdated:
+			*changes = append(*changes, Change{Path: elementPath, Element: element, Type: Removed, Old: originalElement})
+		default:
+			diffFields(elementPath, element, "", originalElement, updatedElement, changes)
+		}
+	}
+}
+
+// diffFields compares the JSON representations of an element field by field.
+func diffFields(path string, element ElementType, field string, original, updated interface{}, changes *[]Change) {
+	if reflect.DeepEqual(original, updated) {
+		return
+	}
+
+	change := func(changeType ChangeType, field string, original, updated interface{}) {
+		*changes = append(*changes, Change{
+			Path:    path,
+			Element: element,
+			Type:    changeType,
+			Field:   field,
+			Old:     original,
+			New:     updated,
+		})
+	}
+
+	switch o := original.(type) {
+	case map[string]interface{}:
+		u, ok := updated.(map[string]interface{})
+		if !ok {
+			break
+		}
+		for _, key := range unionKeys(o, u) {
+			originalField, inOriginal := o[key]
+			updatedField, inUpdated := u[key]
+			subField := joinField(field, key)
+
+			switch {
+			case !inOriginal:
+				change(Added, subField, nil, updatedField)
+			case !inUpdated:
+				change(Removed, subField, originalField, nil)
+			default:
+				diffFields(path, element, subField, originalField, updatedField, changes)
+			}
+		}
+		return
+	case []interface{}:
+		u, ok := updated.([]interface{})
+		if !ok {
+			break
+		}
+		if len(o) == len(u) {
+			for i := range o {
+				diffFields(path, element, fmt.Sprintf("%s[%d]", field, i), o[i], u[i], changes)
+			}
+			return
+		}
+		// When the length of a list changes, elements are matched by content
+		// rather than by position, so that e.g. adding a root certificate is
+		// not reported as changing all the certificates which follow it.
+		for _, i := range unmatched(o, u) {
+			change(Removed, fmt.Sprintf("%s[%d]", field, i), o[i], nil)
+		}
+		for _, i := range unmatched(u, o) {
+			change(Added, fmt.Sprintf("%s[%d]", field, i), nil, u[i])
+		}
+		return
+	}
+
+	change(Modified, field, original, updated)
+}
+
+// unmatched returns the indexes of the elements of a which have no
+// counterpart in b.
+func unmatched(a, b []interface{}) []int {
+	remaining := map[string]int{}
+	for _, element := range b {
+		remaining[canonical(element)]++
+	}
+
+	var result []int
+	for i, element := range a {
+		key := canonical(element)
+		if remaining[key] > 0 {
+			remaining[key]--
+			continue
+		}
+		result = append(result, i)
+	}
+
+	return result
+}
+
+func canonical(element interface{}) string {
+	encoded, err := json.Marshal(element)
+	if err != nil {
+		return fmt.Sprint(element)
+	}
+	return string(encoded)
+}
+
+func joinField(field, key string) string {
+	if field == "" {
+		return key
+	}
+	return field + "." + key
+}
+
+// groupFields returns the fields of a group itself, without its elements.
+func groupFields(group interface{}) map[string]interface{} {
+	g := asMap(group)
+	return map[string]interface{}{
+		"mod_policy": g["mod_policy"],
+		"version":    g["version"],
+	}
+}
+
+func asMap(node interface{}) map[string]interface{} {
+	m, _ := node.(map[string]interface{})
+	return m
+}
+
+func unionKeys(a, b map[string]interface{}) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for key := range a {
+		keys = append(keys, key)
+	}
+	for key := range b {
+		if _, ok := a[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	return keys
+}
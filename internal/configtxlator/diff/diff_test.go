@@ -0,0 +1,273 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package diff
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/common/crypto/tlsgen"
+	"github.com/hyperledger/fabric/core/config/configtest"
+	"github.com/hyperledger/fabric/internal/configtxgen/encoder"
+	"github.com/hyperledger/fabric/internal/configtxgen/genesisconfig"
+	"github.com/hyperledger/fabric/internal/configtxlator/builder"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/require"
+)
+
+type testConfig struct {
+	profile *genesisconfig.Profile
+	config  *cb.Config
+	ca      tlsgen.CA
+}
+
+func newTestConfig(t *testing.T) *testConfig {
+	profile := genesisconfig.Load(genesisconfig.SampleAppChannelEtcdRaftProfile, configtest.GetDevConfigDir())
+
+	ca, err := tlsgen.NewCA()
+	require.NoError(t, err)
+	certPath := filepath.Join(t.TempDir(), "cert.pem")
+	require.NoError(t, os.WriteFile(certPath, ca.CertBytes(), 0o644))
+
+	for _, consenter := range profile.Orderer.EtcdRaft.Consenters {
+		consenter.ClientTlsCert = []byte(certPath)
+		consenter.ServerTlsCert = []byte(certPath)
+	}
+
+	_, config, err := builder.ConfigFromBlock(encoder.New(profile).GenesisBlockForChannel("mychannel"))
+	require.NoError(t, err)
+
+	return &testConfig{profile: profile, config: config, ca: ca}
+}
+
+func (tc *testConfig) update(t *testing.T, modifiers ...builder.Modifier) (*cb.Envelope, *cb.ConfigUpdate) {
+	env, configUpdate, err := builder.Build("mychannel", tc.config, modifiers...)
+	require.NoError(t, err)
+	return env, configUpdate
+}
+
+func TestApplyUpdate(t *testing.T) {
+	tc := newTestConfig(t)
+
+	org := *tc.profile.Application.Organizations[0]
+	org.Name = "Org2"
+	orgGroup, err := encoder.NewApplicationOrgGroup(&org)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		modifier builder.Modifier
+	}{
+		{name: "add org", modifier: builder.AddApplicationOrg("Org2", orgGroup)},
+		{name: "remove org", modifier: builder.RemoveApplicationOrg("SampleOrg")},
+		{name: "batch timeout", modifier: builder.SetBatchTimeout(5 * time.Second)},
+		{name: "consenter", modifier: builder.UpdateConsenterTLSCerts("raft0.example.com", 7050, []byte("client"), nil)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, configUpdate := tc.update(t, tt.modifier)
+
+			applied, err := ApplyUpdate(tc.config, configUpdate)
+			require.NoError(t, err)
+			require.Equal(t, tc.config.Sequence+1, applied.Sequence)
+
+			// Applying the update yields a config which differs from the
+			// original by the very same update.
+			_, recomputed, err := builder.Build("mychannel", tc.config, func(config *cb.Config) error {
+				config.ChannelGroup = applied.ChannelGroup
+				return nil
+			})
+			require.NoError(t, err)
+			require.True(t, proto.Equal(configUpdate, recomputed))
+		})
+	}
+
+	t.Run("no write set", func(t *testing.T) {
+		_, err := ApplyUpdate(tc.config, &cb.ConfigUpdate{})
+		require.EqualError(t, err, "config update has no write set")
+	})
+
+	t.Run("no channel group", func(t *testing.T) {
+		_, err := ApplyUpdate(&cb.Config{}, &cb.ConfigUpdate{WriteSet: &cb.ConfigGroup{}})
+		require.EqualError(t, err, "original config has no channel group")
+	})
+}
+
+func TestConfigUpdateFromEnvelope(t *testing.T) {
+	tc := newTestConfig(t)
+
+	env, configUpdate := tc.update(t, builder.SetBatchTimeout(5*time.Second))
+	extracted, err := ConfigUpdateFromEnvelope(env)
+	require.NoError(t, err)
+	require.True(t, proto.Equal(configUpdate, extracted))
+
+	env, err = protoutil.CreateSignedEnvelope(cb.HeaderType_CONFIG, "mychannel", nil, &cb.ConfigEnvelope{}, 0, 0)
+	require.NoError(t, err)
+	_, err = ConfigUpdateFromEnvelope(env)
+	require.EqualError(t, err, "envelope is not a config update, its header type is CONFIG")
+}
+
+func TestConfigs(t *testing.T) {
+	tc := newTestConfig(t)
+
+	t.Run("no differences", func(t *testing.T) {
+		changes, err := Configs(tc.config, tc.config)
+		require.NoError(t, err)
+		require.Empty(t, changes)
+	})
+
+	t.Run("batch timeout", func(t *testing.T) {
+		_, configUpdate := tc.update(t, builder.SetBatchTimeout(5*time.Second))
+		applied, err := ApplyUpdate(tc.config, configUpdate)
+		require.NoError(t, err)
+
+		changes, err := Configs(tc.config, applied)
+		require.NoError(t, err)
+		require.Equal(t, []Change{
+			{
+				Path:    "/Channel/Orderer/BatchTimeout",
+				Element: Value,
+				Type:    Modified,
+				Field:   "value.timeout",
+				Old:     "2s",
+				New:     "5s",
+			},
+			{
+				Path:    "/Channel/Orderer/BatchTimeout",
+				Element: Value,
+				Type:    Modified,
+				Field:   "version",
+				Old:     "0",
+				New:     "1",
+			},
+		}, changes)
+
+		buf := &bytes.Buffer{}
+		require.NoError(t, WriteText(buf, changes))
+		require.Equal(t,
+			"~ value  /Channel/Orderer/BatchTimeout value.timeout: \"2s\" -> \"5s\"\n"+
+				"~ value  /Channel/Orderer/BatchTimeout version: \"0\" -> \"1\"\n",
+			buf.String(),
+		)
+
+		buf.Reset()
+		require.NoError(t, WriteJSON(buf, changes))
+		var decoded []Change
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+		require.Len(t, decoded, 2)
+		require.Equal(t, "value.timeout", decoded[0].Field)
+	})
+
+	t.Run("remove org", func(t *testing.T) {
+		_, configUpdate := tc.update(t, builder.RemoveApplicationOrg("SampleOrg"))
+		applied, err := ApplyUpdate(tc.config, configUpdate)
+		require.NoError(t, err)
+
+		changes, err := Configs(tc.config, applied)
+		require.NoError(t, err)
+		require.Len(t, changes, 2)
+		require.Equal(t, Change{Path: "/Channel/Application", Element: Group, Type: Modified, Field: "version", Old: "0", New: "1"}, changes[0])
+		require.Equal(t, Change{
+			Path:    "/Channel/Application/SampleOrg",
+			Element: Group,
+			Type:    Removed,
+			Old:     map[string]interface{}{"mod_policy": "Admins", "version": "0"},
+		}, changes[1])
+
+		buf := &bytes.Buffer{}
+		require.NoError(t, WriteText(buf, changes[1:]))
+		require.Equal(t, "- group  /Channel/Application/SampleOrg: {\"mod_policy\":\"Admins\",\"version\":\"0\"}\n", buf.String())
+	})
+
+	t.Run("add org", func(t *testing.T) {
+		org := *tc.profile.Application.Organizations[0]
+		org.Name = "Org2"
+		orgGroup, err := encoder.NewApplicationOrgGroup(&org)
+		require.NoError(t, err)
+
+		updated := proto.Clone(tc.config).(*cb.Config)
+		require.NoError(t, builder.AddApplicationOrg("Org2", orgGroup)(updated))
+
+		changes, err := Configs(tc.config, updated)
+		require.NoError(t, err)
+		require.Equal(t, Change{
+			Path:    "/Channel/Application/Org2",
+			Element: Group,
+			Type:    Added,
+			New:     map[string]interface{}{"mod_policy": "Admins", "version": "0"},
+		}, changes[0])
+
+		var msp *Change
+		for i, change := range changes {
+			require.Equal(t, Added, change.Type)
+			if change.Path == "/Channel/Application/Org2/MSP" {
+				msp = &changes[i]
+			}
+		}
+		require.NotNil(t, msp)
+		rootCerts := msp.New.(map[string]interface{})["value"].(map[string]interface{})["config"].(map[string]interface{})["root_certs"].([]interface{})
+		require.Len(t, rootCerts, 1)
+		require.IsType(t, &Certificate{}, rootCerts[0])
+	})
+
+	t.Run("certificate rotation", func(t *testing.T) {
+		keyPair, err := tc.ca.NewServerCertKeyPair("raft0.example.com")
+		require.NoError(t, err)
+
+		updated := proto.Clone(tc.config).(*cb.Config)
+		require.NoError(t, builder.UpdateConsenterTLSCerts("raft0.example.com", 7050, nil, keyPair.Cert)(updated))
+
+		changes, err := Configs(tc.config, updated)
+		require.NoError(t, err)
+		require.Len(t, changes, 1)
+		require.Equal(t, "/Channel/Orderer/ConsensusType", changes[0].Path)
+		require.Equal(t, "value.metadata.consenters[0].server_tls_cert", changes[0].Field)
+
+		oldCert, newCert := changes[0].Old.(*Certificate), changes[0].New.(*Certificate)
+		require.Equal(t, oldCert.Subject, newCert.Issuer)
+		require.NotEqual(t, oldCert.SerialNumber, newCert.SerialNumber)
+		require.NotEqual(t, oldCert.Fingerprint, newCert.Fingerprint)
+
+		buf := &bytes.Buffer{}
+		require.NoError(t, WriteText(buf, changes))
+		require.Contains(t, buf.String(), "~ value  /Channel/Orderer/ConsensusType value.metadata.consenters[0].server_tls_cert: certificate ")
+		require.Contains(t, buf.String(), "expires "+newCert.NotAfter.Format(time.RFC3339))
+	})
+
+	t.Run("list membership", func(t *testing.T) {
+		original := []interface{}{"a", "b", "c"}
+		updated := []interface{}{"a", "c", "d", "e"}
+
+		var changes []Change
+		diffFields("/Channel/Foo", Value, "value.list", original, updated, &changes)
+		require.Equal(t, []Change{
+			{Path: "/Channel/Foo", Element: Value, Type: Removed, Field: "value.list[1]", Old: "b"},
+			{Path: "/Channel/Foo", Element: Value, Type: Added, Field: "value.list[2]", New: "d"},
+			{Path: "/Channel/Foo", Element: Value, Type: Added, Field: "value.list[3]", New: "e"},
+		}, changes)
+	})
+}
+
+func TestParseCertificate(t *testing.T) {
+	ca, err := tlsgen.NewCA()
+	require.NoError(t, err)
+
+	cert := parseCertificate(base64.StdEncoding.EncodeToString(ca.CertBytes()))
+	require.NotNil(t, cert)
+	require.Equal(t, cert.Subject, cert.Issuer)
+
+	require.Nil(t, parseCertificate("not base64"))
+	require.Nil(t, parseCertificate(base64.StdEncoding.EncodeToString([]byte("not PEM"))))
+}
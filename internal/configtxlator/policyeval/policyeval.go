@@ -0,0 +1,481 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package policyeval
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	mb "github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/common/policydsl"
+	"github.com/hyperledger/fabric/internal/configtxlator/update"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+// Identity is an identity the policy was evaluated against.
+type Identity struct {
+	MSPID   string `json:"msp_id"`
+	Subject string `json:"subject,omitempty"`
+	// Valid is whether the identity could be deserialized and, if it was
+	// given along with a signature, whether the signature is valid. Invalid
+	// identities are not evaluated against the policy.
+	Valid bool   `json:"valid"`
+	Error string `json:"error,omitempty"`
+}
+
+// Result is the outcome of evaluating an expression, or one of the
+// sub-policies an implicit meta expression refers to.
+type Result struct {
+	Expression string    `json:"expression"`
+	Satisfied  bool      `json:"satisfied"`
+	Reason     string    `json:"reason"`
+	Operands   []*Result `json:"operands,omitempty"`
+}
+
+// Report is the outcome of evaluating a policy expression.
+type Report struct {
+	Policy string `json:"policy"`
+	// Path is the config group implicit meta expressions are evaluated at.
+	Path      string `json:"path"`
+	Satisfied bool   `json:"satisfied"`
+	// SignaturesVerified is whether the identities were given along with
+	// signatures which were verified.
+	SignaturesVerified bool        `json:"signatures_verified"`
+	Identities         []*Identity `json:"identities"`
+	Result             *Result     `json:"result"`
+}
+
+// Evaluator evaluates policy expressions against the policies and MSPs of a
+// channel config.
+type Evaluator struct {
+	channelGroup  *cb.ConfigGroup
+	policyManager policies.Manager
+	deserializer  msp.IdentityDeserializer
+}
+
+// New creates an Evaluator for the config of the channel.
+func New(channelID string, config *cb.Config, cryptoProvider bccsp.BCCSP) (*Evaluator, error) {
+	bundle, err := channelconfig.NewBundle(channelID, config, cryptoProvider)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error processing channel config")
+	}
+
+	return &Evaluator{
+		channelGroup:  config.ChannelGroup,
+		policyManager: bundle.PolicyManager(),
+		deserializer:  bundle.MSPManager(),
+	}, nil
+}
+
+// EvaluateSignedData evaluates the expression against the identities which
+// produced the signed data. As with the policies of the channel config, an
+// identity is only evaluated once and only if its signature is valid.
+// Implicit meta expressions refer to the sub-groups of the config group at
+// the given path, e.g. /Channel/Application.
+func (e *Evaluator) EvaluateSignedData(expr policydsl.Expression, path string, signedData []*protoutil.SignedData) (*Report, error) {
+	var validIdentities []msp.Identity
+	var identities []*Identity
+	seen := map[string]struct{}{}
+	for _, sd := range signedData {
+		identity, report := e.deserialize(sd.Identity)
+		identities = append(identities, report)
+		if identity == nil {
+			continue
+		}
+
+		key := identity.GetIdentifier().Mspid + identity.GetIdentifier().Id
+		if _, ok := seen[key]; ok {
+			report.Valid = false
+			report.Error = "duplicate identity"
+			continue
+		}
+		if err := identity.Verify(sd.Data, sd.Signature); err != nil {
+			report.Valid = false
+			report.Error = fmt.Sprintf("invalid signature: %s", err)
+			continue
+		}
+
+		seen[key] = struct{}{}
+		validIdentities = append(validIdentities, identity)
+	}
+
+	return e.evaluate(expr, path, validIdentities, identities, true)
+}
+
+// EvaluateIdentities evaluates the expression against the serialized
+// identities, without requiring signatures from them. Implicit meta
+// expressions refer to the sub-groups of the config group at the given path,
+// e.g. /Channel/Application.
+func (e *Evaluator) EvaluateIdentities(expr policydsl.Expression, path string, serializedIdentities [][]byte) (*Report, error) {
+	var validIdentities []msp.Identity
+	var identities []*Identity
+	for _, serializedIdentity := range serializedIdentities {
+		identity, report := e.deserialize(serializedIdentity)
+		identities = append(identities, report)
+		if identity != nil {
+			validIdentities = append(validIdentities, identity)
+		}
+	}
+
+	return e.evaluate(expr, path, validIdentities, identities, false)
+}
+
+func (e *Evaluator) evaluate(expr policydsl.Expression, path string, validIdentities []msp.Identity, identities []*Identity, signaturesVerified bool) (*Report, error) {
+	group, err := e.configGroup(path)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := &evaluation{
+		evaluator:  e,
+		path:       strings.TrimSuffix(path, policies.PathSeparator),
+		group:      group,
+		identities: validIdentities,
+	}
+	result, err := ctx.evaluate(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	if identities == nil {
+		identities = []*Identity{}
+	}
+	return &Report{
+		Policy:             expr.String(),
+		Path:               ctx.path,
+		Satisfied:          result.Satisfied,
+		SignaturesVerified: signaturesVerified,
+		Identities:         identities,
+		Result:             result,
+	}, nil
+}
+
+// configGroup returns the config group at the fully qualified path.
+func (e *Evaluator) configGroup(path string) (*cb.ConfigGroup, error) {
+	if path != update.RootGroupPath && !strings.HasPrefix(path, update.RootGroupPath+policies.PathSeparator) {
+		return nil, errors.Errorf("path %s is not within the channel group %s", path, update.RootGroupPath)
+	}
+
+	group := e.channelGroup
+	for _, name := range strings.Split(strings.Trim(strings.TrimPrefix(path, update.RootGroupPath), policies.PathSeparator), policies.PathSeparator) {
+		if name == "" {
+			continue
+		}
+		group = group.Groups[name]
+		if group == nil {
+			return nil, errors.Errorf("config group %s does not exist", path)
+		}
+	}
+
+	return group, nil
+}
+
+// deserialize deserializes an identity, returning nil and the reason in the
+// report if it is invalid.
+func (e *Evaluator) deserialize(serializedIdentity []byte) (msp.Identity, *Identity) {
+	report := &Identity{}
+
+	sID := &mb.SerializedIdentity{}
+	if err := proto.Unmarshal(serializedIdentity, sID); err != nil {
+		report.Error = fmt.Sprintf("invalid identity: %s", err)
+		return nil, report
+	}
+	report.MSPID = sID.Mspid
+	report.Subject = subject(sID.IdBytes)
+
+	identity, err := e.deserializer.DeserializeIdentity(serializedIdentity)
+	if err != nil {
+		report.Error = fmt.Sprintf("invalid identity: %s", err)
+		return nil, report
+	}
+
+	report.Valid = true
+	return identity, report
+}
+
+// evaluation holds the state of the evaluation of an expression.
+type evaluation struct {
+	evaluator  *Evaluator
+	path       string
+	group      *cb.ConfigGroup
+	identities []msp.Identity
+}
+
+func (ctx *evaluation) evaluate(expr policydsl.Expression) (*Result, error) {
+	switch e := expr.(type) {
+	case *policydsl.Principal:
+		return ctx.evaluatePrincipal(e)
+	case *policydsl.Gate:
+		return ctx.evaluateGate(e)
+	case *policydsl.ImplicitMeta:
+		return ctx.evaluateImplicitMeta(e), nil
+	default:
+		return nil, errors.Errorf("unknown expression type %T", expr)
+	}
+}
+
+// evaluateSignature evaluates an expression consisting of principals and
+// gates as a signature policy, so that, as with the signature policies of the
+// channel config, each identity satisfies at most one principal.
+func (ctx *evaluation) evaluateSignature(expr policydsl.Expression) (bool, error) {
+	sigPolicy, err := policydsl.SignaturePolicyFromExpression(expr)
+	if err != nil {
+		return false, err
+	}
+
+	provider := &cauthdsl.EnvelopeBasedPolicyProvider{Deserializer: ctx.evaluator.deserializer}
+	policy, err := provider.NewPolicy(sigPolicy)
+	if err != nil {
+		return false, errors.WithMessagef(err, "error compiling %s", expr)
+	}
+
+	return policy.EvaluateIdentities(ctx.identities) == nil, nil
+}
+
+func (ctx *evaluation) evaluatePrincipal(principal *policydsl.Principal) (*Result, error) {
+	satisfied, err := ctx.evaluateSignature(principal)
+	if err != nil {
+		return nil, err
+	}
+	result := &Result{Expression: principal.String(), Satisfied: satisfied}
+
+	if !satisfied {
+		result.Reason = fmt.Sprintf("not satisfied by any of the %d valid identities", len(ctx.identities))
+		return result, nil
+	}
+
+	mspPrincipal, err := principal.MSPPrincipal()
+	if err != nil {
+		return nil, err
+	}
+	var satisfiedBy []string
+	for _, identity := range ctx.identities {
+		if identity.SatisfiesPrincipal(mspPrincipal) == nil {
+			satisfiedBy = append(satisfiedBy, describe(identity))
+		}
+	}
+	result.Reason = "satisfied by " + strings.Join(satisfiedBy, ", ")
+
+	return result, nil
+}
+
+func (ctx *evaluation) evaluateGate(gate *policydsl.Gate) (*Result, error) {
+	result := &Result{Expression: gate.String()}
+
+	satisfiedOperands := 0
+	for _, operand := range gate.Operands {
+		operandResult, err := ctx.evaluate(operand)
+		if err != nil {
+			return nil, err
+		}
+		if operandResult.Satisfied {
+			satisfiedOperands++
+		}
+		result.Operands = append(result.Operands, operandResult)
+	}
+	result.Reason = fmt.Sprintf("%d of %d operands satisfied, %d required", satisfiedOperands, len(gate.Operands), gate.N)
+
+	// Gates which refer to the policies of the config cannot be evaluated as
+	// a signature policy, and their operands are evaluated independently.
+	if !policydsl.IsSignatureExpression(gate) {
+		result.Satisfied = satisfiedOperands >= gate.N
+		return result, nil
+	}
+
+	satisfied, err := ctx.evaluateSignature(gate)
+	if err != nil {
+		return nil, err
+	}
+	result.Satisfied = satisfied
+	if !satisfied && satisfiedOperands >= gate.N {
+		result.Reason += ", but the operands are not satisfied by distinct identities"
+	}
+
+	return result, nil
+}
+
+// evaluateImplicitMeta evaluates the sub-policies of the sub-groups of the
+// config group in the same way as the implicit meta policies of the config.
+func (ctx *evaluation) evaluateImplicitMeta(im *policydsl.ImplicitMeta) *Result {
+	result := &Result{Expression: im.String()}
+
+	var groups []string
+	for name := range ctx.group.Groups {
+		groups = append(groups, name)
+	}
+	sort.Strings(groups)
+
+	satisfiedSubPolicies := 0
+	for _, name := range groups {
+		subPolicyPath := ctx.path + policies.PathSeparator + name + policies.PathSeparator + im.SubPolicy
+		subResult := &Result{Expression: subPolicyPath}
+
+		policy, ok := ctx.evaluator.policyManager.GetPolicy(subPolicyPath)
+		if !ok {
+			subResult.Reason = "policy does not exist"
+		} else if err := policy.EvaluateIdentities(ctx.identities); err != nil {
+			subResult.Reason = err.Error()
+		} else {
+			subResult.Satisfied = true
+			subResult.Reason = "satisfied"
+			satisfiedSubPolicies++
+		}
+
+		result.Operands = append(result.Operands, subResult)
+	}
+
+	var threshold int
+	switch im.Rule {
+	case cb.ImplicitMetaPolicy_ANY:
+		threshold = 1
+	case cb.ImplicitMetaPolicy_ALL:
+		threshold = len(groups)
+	case cb.ImplicitMetaPolicy_MAJORITY:
+		threshold = len(groups)/2 + 1
+	}
+	// as with the implicit meta policies of the config, no sub-policies are
+	// required when there are none
+	if len(groups) == 0 {
+		threshold = 0
+	}
+
+	result.Satisfied = satisfiedSubPolicies >= threshold
+	result.Reason = fmt.Sprintf("%d of %d '%s' sub-policies of %s satisfied, %d required", satisfiedSubPolicies, len(groups), im.SubPolicy, ctx.path, threshold)
+
+	return result
+}
+
+// describe returns the MSP ID and, for X.509 identities, the subject of the
+// identity.
+func describe(identity msp.Identity) string {
+	mspID := identity.GetMSPIdentifier()
+	serializedIdentity, err := identity.Serialize()
+	if err != nil {
+		return mspID
+	}
+
+	sID := &mb.SerializedIdentity{}
+	if err := proto.Unmarshal(serializedIdentity, sID); err != nil {
+		return mspID
+	}
+	if s := subject(sID.IdBytes); s != "" {
+		return mspID + " " + s
+	}
+	return mspID
+}
+
+// subject returns the subject of a PEM encoded certificate, or the empty
+// string if the bytes are not a PEM encoded certificate.
+func subject(idBytes []byte) string {
+	block, _ := pem.Decode(idBytes)
+	if block == nil {
+		return ""
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return ""
+	}
+	return cert.Subject.String()
+}
+
+// SignedDataFromEnvelope returns the signatures of a config update envelope,
+// such as the one produced by peer channel signconfigtx, or the signature of
+// the creator of any other envelope.
+func SignedDataFromEnvelope(env *cb.Envelope) ([]*protoutil.SignedData, error) {
+	payload, err := protoutil.UnmarshalPayload(env.Payload)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error unmarshalling payload of envelope")
+	}
+	if payload.Header == nil {
+		return nil, errors.New("envelope payload has no header")
+	}
+
+	chdr, err := protoutil.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error unmarshalling channel header of envelope")
+	}
+	if chdr.Type != int32(cb.HeaderType_CONFIG_UPDATE) {
+		return protoutil.EnvelopeAsSignedData(env)
+	}
+
+	configUpdateEnv := &cb.ConfigUpdateEnvelope{}
+	if err := proto.Unmarshal(payload.Data, configUpdateEnv); err != nil {
+		return nil, errors.Wrap(err, "error unmarshalling config update envelope")
+	}
+	return protoutil.ConfigUpdateEnvelopeAsSignedData(configUpdateEnv)
+}
+
+// WriteText writes a human readable form of the report.
+func WriteText(w io.Writer, report *Report) error {
+	b := &strings.Builder{}
+
+	verdict := "is not satisfied"
+	if report.Satisfied {
+		verdict = "is satisfied"
+	}
+	fmt.Fprintf(b, "Policy %s at %s %s\n\n", report.Policy, report.Path, verdict)
+
+	if report.SignaturesVerified {
+		b.WriteString("Identities:\n")
+	} else {
+		b.WriteString("Identities (signatures not verified):\n")
+	}
+	if len(report.Identities) == 0 {
+		b.WriteString("  none\n")
+	}
+	for _, identity := range report.Identities {
+		status := "[valid]"
+		if !identity.Valid {
+			status = "[invalid]"
+		}
+		fmt.Fprintf(b, "  %-11s %s", status, identity.MSPID)
+		if identity.Subject != "" {
+			fmt.Fprintf(b, " %s", identity.Subject)
+		}
+		if identity.Error != "" {
+			fmt.Fprintf(b, ": %s", identity.Error)
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\nEvaluation:\n")
+	writeResult(b, report.Result, 1)
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeResult(b *strings.Builder, result *Result, depth int) {
+	status := "[satisfied]"
+	if !result.Satisfied {
+		status = "[not satisfied]"
+	}
+	fmt.Fprintf(b, "%s%-15s %s: %s\n", strings.Repeat("  ", depth), status, result.Expression, result.Reason)
+	for _, operand := range result.Operands {
+		writeResult(b, operand, depth+1)
+	}
+}
+
+// WriteJSON writes the report as JSON.
+func WriteJSON(w io.Writer, report *Report) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "\t")
+	return encoder.Encode(report)
+}
@@ -0,0 +1,325 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package policyeval
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	mb "github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric/bccsp/factory"
+	"github.com/hyperledger/fabric/common/crypto/tlsgen"
+	"github.com/hyperledger/fabric/common/policydsl"
+	"github.com/hyperledger/fabric/core/config/configtest"
+	"github.com/hyperledger/fabric/internal/configtxgen/encoder"
+	"github.com/hyperledger/fabric/internal/configtxgen/genesisconfig"
+	"github.com/hyperledger/fabric/internal/configtxlator/builder"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/require"
+)
+
+type testEnv struct {
+	evaluator *Evaluator
+	config    *cb.Config
+	signer    msp.SigningIdentity
+	ca        tlsgen.CA
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	ca, err := tlsgen.NewCA()
+	require.NoError(t, err)
+
+	certPath := filepath.Join(t.TempDir(), "cert.pem")
+	require.NoError(t, os.WriteFile(certPath, ca.CertBytes(), 0o644))
+
+	profile := genesisconfig.Load(genesisconfig.SampleAppChannelEtcdRaftProfile, configtest.GetDevConfigDir())
+	for _, consenter := range profile.Orderer.EtcdRaft.Consenters {
+		consenter.ClientTlsCert = []byte(certPath)
+		consenter.ServerTlsCert = []byte(certPath)
+	}
+	_, config, err := builder.ConfigFromBlock(encoder.New(profile).GenesisBlockForChannel("mychannel"))
+	require.NoError(t, err)
+
+	// loading the local MSP config initializes the BCCSP with its keystore
+	mspConfig, err := msp.GetLocalMspConfig(configtest.GetDevMspDir(), nil, "SampleOrg")
+	require.NoError(t, err)
+	cryptoProvider := factory.GetDefault()
+	evaluator, err := New("mychannel", config, cryptoProvider)
+	require.NoError(t, err)
+
+	localMSP, err := msp.New(msp.Options[msp.ProviderTypeToString(msp.FABRIC)], cryptoProvider)
+	require.NoError(t, err)
+	require.NoError(t, localMSP.Setup(mspConfig))
+	signer, err := localMSP.GetDefaultSigningIdentity()
+	require.NoError(t, err)
+
+	return &testEnv{evaluator: evaluator, config: config, signer: signer, ca: ca}
+}
+
+func (te *testEnv) signedData(t *testing.T, data []byte) *protoutil.SignedData {
+	identity, err := te.signer.Serialize()
+	require.NoError(t, err)
+	signature, err := te.signer.Sign(data)
+	require.NoError(t, err)
+	return &protoutil.SignedData{Data: data, Identity: identity, Signature: signature}
+}
+
+// foreignIdentity returns a serialized identity which claims to be of
+// SampleOrg but is not issued by its CA.
+func (te *testEnv) foreignIdentity(t *testing.T) []byte {
+	keyPair, err := te.ca.NewClientCertKeyPair()
+	require.NoError(t, err)
+	return protoutil.MarshalOrPanic(&mb.SerializedIdentity{Mspid: "SampleOrg", IdBytes: keyPair.Cert})
+}
+
+func parse(t *testing.T, policy string) policydsl.Expression {
+	expr, err := policydsl.ParseExpression(policy)
+	require.NoError(t, err)
+	return expr
+}
+
+func TestEvaluateSignedData(t *testing.T) {
+	te := newTestEnv(t)
+	signedData := te.signedData(t, []byte("data"))
+	subject := "CN=peer0.org1.example.com,OU=COP,L=San Francisco,ST=California,C=US"
+
+	t.Run("implicit meta", func(t *testing.T) {
+		report, err := te.evaluator.EvaluateSignedData(parse(t, "MAJORITY Admins"), "/Channel/Application", []*protoutil.SignedData{signedData})
+		require.NoError(t, err)
+		require.Equal(t, &Report{
+			Policy:             "MAJORITY Admins",
+			Path:               "/Channel/Application",
+			Satisfied:          true,
+			SignaturesVerified: true,
+			Identities: []*Identity{
+				{MSPID: "SampleOrg", Subject: subject, Valid: true},
+			},
+			Result: &Result{
+				Expression: "MAJORITY Admins",
+				Satisfied:  true,
+				Reason:     "1 of 1 'Admins' sub-policies of /Channel/Application satisfied, 1 required",
+				Operands: []*Result{
+					{Expression: "/Channel/Application/SampleOrg/Admins", Satisfied: true, Reason: "satisfied"},
+				},
+			},
+		}, report)
+	})
+
+	t.Run("nested implicit meta", func(t *testing.T) {
+		report, err := te.evaluator.EvaluateSignedData(parse(t, "OR('OtherOrg.member', AND(ALL Readers, ANY Nonexistent))"), "/Channel/", []*protoutil.SignedData{signedData})
+		require.NoError(t, err)
+		require.False(t, report.Satisfied)
+		require.Equal(t, "/Channel", report.Path)
+		require.Equal(t, &Result{
+			Expression: "OR('OtherOrg.member', AND(ALL Readers, ANY Nonexistent))",
+			Reason:     "0 of 2 operands satisfied, 1 required",
+			Operands: []*Result{
+				{Expression: "'OtherOrg.member'", Reason: "not satisfied by any of the 1 valid identities"},
+				{
+					Expression: "AND(ALL Readers, ANY Nonexistent)",
+					Reason:     "1 of 2 operands satisfied, 2 required",
+					Operands: []*Result{
+						{
+							Expression: "ALL Readers",
+							Satisfied:  true,
+							Reason:     "2 of 2 'Readers' sub-policies of /Channel satisfied, 2 required",
+							Operands: []*Result{
+								{Expression: "/Channel/Application/Readers", Satisfied: true, Reason: "satisfied"},
+								{Expression: "/Channel/Orderer/Readers", Satisfied: true, Reason: "satisfied"},
+							},
+						},
+						{
+							Expression: "ANY Nonexistent",
+							Reason:     "0 of 2 'Nonexistent' sub-policies of /Channel satisfied, 1 required",
+							Operands: []*Result{
+								{Expression: "/Channel/Application/Nonexistent", Reason: "policy does not exist"},
+								{Expression: "/Channel/Orderer/Nonexistent", Reason: "policy does not exist"},
+							},
+						},
+					},
+				},
+			},
+		}, report.Result)
+	})
+
+	t.Run("distinct identities", func(t *testing.T) {
+		report, err := te.evaluator.EvaluateSignedData(parse(t, "AND('SampleOrg.member', 'SampleOrg.admin')"), "/Channel", []*protoutil.SignedData{signedData})
+		require.NoError(t, err)
+		require.False(t, report.Satisfied)
+		require.Equal(t, &Result{
+			Expression: "AND('SampleOrg.member', 'SampleOrg.admin')",
+			Reason:     "2 of 2 operands satisfied, 2 required, but the operands are not satisfied by distinct identities",
+			Operands: []*Result{
+				{Expression: "'SampleOrg.member'", Satisfied: true, Reason: "satisfied by SampleOrg " + subject},
+				{Expression: "'SampleOrg.admin'", Satisfied: true, Reason: "satisfied by SampleOrg " + subject},
+			},
+		}, report.Result)
+
+		report, err = te.evaluator.EvaluateSignedData(parse(t, "OR('SampleOrg.member', 'SampleOrg.admin')"), "/Channel", []*protoutil.SignedData{signedData})
+		require.NoError(t, err)
+		require.True(t, report.Satisfied)
+		require.Equal(t, "2 of 2 operands satisfied, 1 required", report.Result.Reason)
+	})
+
+	t.Run("invalid signatures", func(t *testing.T) {
+		badSignature := te.signedData(t, []byte("data"))
+		badSignature.Signature = []byte("garbage")
+
+		report, err := te.evaluator.EvaluateSignedData(parse(t, "'SampleOrg.member'"), "/Channel", []*protoutil.SignedData{
+			badSignature,
+			{Identity: []byte("garbage")},
+		})
+		require.NoError(t, err)
+		require.False(t, report.Satisfied)
+		require.Len(t, report.Identities, 2)
+		require.False(t, report.Identities[0].Valid)
+		require.Equal(t, subject, report.Identities[0].Subject)
+		require.Contains(t, report.Identities[0].Error, "invalid signature: ")
+		require.False(t, report.Identities[1].Valid)
+		require.Contains(t, report.Identities[1].Error, "invalid identity: ")
+		require.Equal(t, "not satisfied by any of the 0 valid identities", report.Result.Reason)
+	})
+
+	t.Run("duplicate identities", func(t *testing.T) {
+		report, err := te.evaluator.EvaluateSignedData(parse(t, "OutOf(2, 'SampleOrg.member', 'SampleOrg.member')"), "/Channel", []*protoutil.SignedData{signedData, signedData})
+		require.NoError(t, err)
+		require.False(t, report.Satisfied)
+		require.True(t, report.Identities[0].Valid)
+		require.False(t, report.Identities[1].Valid)
+		require.Equal(t, "duplicate identity", report.Identities[1].Error)
+	})
+
+	t.Run("bad path", func(t *testing.T) {
+		_, err := te.evaluator.EvaluateSignedData(parse(t, "ANY Admins"), "/Channel/Consortiums", nil)
+		require.EqualError(t, err, "config group /Channel/Consortiums does not exist")
+
+		_, err = te.evaluator.EvaluateSignedData(parse(t, "ANY Admins"), "/Chan", nil)
+		require.EqualError(t, err, "path /Chan is not within the channel group /Channel")
+	})
+}
+
+func TestEvaluateIdentities(t *testing.T) {
+	te := newTestEnv(t)
+	identity, err := te.signer.Serialize()
+	require.NoError(t, err)
+
+	report, err := te.evaluator.EvaluateIdentities(parse(t, "OR('SampleOrg.member', MAJORITY Admins)"), "/Channel/Orderer", [][]byte{te.foreignIdentity(t)})
+	require.NoError(t, err)
+	require.False(t, report.Satisfied)
+	require.False(t, report.SignaturesVerified)
+	require.Len(t, report.Identities, 1)
+	require.False(t, report.Identities[0].Valid)
+	require.Equal(t, "SampleOrg", report.Identities[0].MSPID)
+	require.Contains(t, report.Identities[0].Error, "certificate signed by unknown authority")
+	require.Equal(t, "not satisfied by any of the 0 valid identities", report.Result.Operands[0].Reason)
+	require.Equal(t, "/Channel/Orderer/SampleOrg/Admins", report.Result.Operands[1].Operands[0].Expression)
+	require.Equal(t, "signature set did not satisfy policy", report.Result.Operands[1].Operands[0].Reason)
+
+	report, err = te.evaluator.EvaluateIdentities(parse(t, "OR('SampleOrg.member', MAJORITY Admins)"), "/Channel/Orderer", [][]byte{te.foreignIdentity(t), identity})
+	require.NoError(t, err)
+	require.True(t, report.Satisfied)
+	require.Equal(t, "2 of 2 operands satisfied, 1 required", report.Result.Reason)
+}
+
+func TestNewError(t *testing.T) {
+	_, err := New("mychannel", &cb.Config{ChannelGroup: &cb.ConfigGroup{
+		Policies: map[string]*cb.ConfigPolicy{"Admins": {Policy: &cb.Policy{Type: 42}}},
+	}}, factory.GetDefault())
+	require.ErrorContains(t, err, "error processing channel config")
+}
+
+func TestSignedDataFromEnvelope(t *testing.T) {
+	te := newTestEnv(t)
+
+	env, _, err := builder.Build("mychannel", te.config, builder.SetBatchTimeout(time.Second))
+	require.NoError(t, err)
+	configUpdateEnv, err := protoutil.EnvelopeToConfigUpdate(env)
+	require.NoError(t, err)
+	sigHeader, err := protoutil.NewSignatureHeader(te.signer)
+	require.NoError(t, err)
+	configSig := &cb.ConfigSignature{SignatureHeader: protoutil.MarshalOrPanic(sigHeader)}
+	configSig.Signature, err = te.signer.Sign(bytes.Join([][]byte{configSig.SignatureHeader, configUpdateEnv.ConfigUpdate}, nil))
+	require.NoError(t, err)
+	configUpdateEnv.Signatures = append(configUpdateEnv.Signatures, configSig)
+	signedEnv, err := protoutil.CreateSignedEnvelope(cb.HeaderType_CONFIG_UPDATE, "mychannel", nil, configUpdateEnv, 0, 0)
+	require.NoError(t, err)
+
+	signedData, err := SignedDataFromEnvelope(signedEnv)
+	require.NoError(t, err)
+	require.Len(t, signedData, 1)
+	report, err := te.evaluator.EvaluateSignedData(parse(t, "ANY Admins"), "/Channel/Orderer", signedData)
+	require.NoError(t, err)
+	require.True(t, report.Satisfied)
+
+	txEnv, err := protoutil.CreateSignedEnvelope(cb.HeaderType_MESSAGE, "mychannel", te.signer, &cb.Envelope{}, 0, 0)
+	require.NoError(t, err)
+	signedData, err = SignedDataFromEnvelope(txEnv)
+	require.NoError(t, err)
+	require.Len(t, signedData, 1)
+	report, err = te.evaluator.EvaluateSignedData(parse(t, "'SampleOrg.member'"), "/Channel", signedData)
+	require.NoError(t, err)
+	require.True(t, report.Satisfied)
+
+	_, err = SignedDataFromEnvelope(&cb.Envelope{Payload: []byte("garbage")})
+	require.ErrorContains(t, err, "error unmarshalling payload of envelope")
+	_, err = SignedDataFromEnvelope(&cb.Envelope{})
+	require.EqualError(t, err, "envelope payload has no header")
+}
+
+func TestWrite(t *testing.T) {
+	report := &Report{
+		Policy:    "OR('SampleOrg.member', ANY Admins)",
+		Path:      "/Channel/Application",
+		Satisfied: true,
+		Identities: []*Identity{
+			{MSPID: "SampleOrg", Subject: "CN=peer0", Valid: true},
+			{MSPID: "OtherOrg", Error: "invalid identity: unknown MSP"},
+		},
+		Result: &Result{
+			Expression: "OR('SampleOrg.member', ANY Admins)",
+			Satisfied:  true,
+			Reason:     "1 of 2 operands satisfied, 1 required",
+			Operands: []*Result{
+				{Expression: "'SampleOrg.member'", Satisfied: true, Reason: "satisfied by SampleOrg CN=peer0"},
+				{
+					Expression: "ANY Admins",
+					Reason:     "0 of 1 'Admins' sub-policies of /Channel/Application satisfied, 1 required",
+					Operands: []*Result{
+						{Expression: "/Channel/Application/SampleOrg/Admins", Reason: "signature set did not satisfy policy"},
+					},
+				},
+			},
+		},
+	}
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, WriteText(buf, report))
+	require.Equal(t, `Policy OR('SampleOrg.member', ANY Admins) at /Channel/Application is satisfied
+
+Identities (signatures not verified):
+  [valid]     SampleOrg CN=peer0
+  [invalid]   OtherOrg: invalid identity: unknown MSP
+
+Evaluation:
+  [satisfied]     OR('SampleOrg.member', ANY Admins): 1 of 2 operands satisfied, 1 required
+    [satisfied]     'SampleOrg.member': satisfied by SampleOrg CN=peer0
+    [not satisfied] ANY Admins: 0 of 1 'Admins' sub-policies of /Channel/Application satisfied, 1 required
+      [not satisfied] /Channel/Application/SampleOrg/Admins: signature set did not satisfy policy
+`, buf.String())
+
+	buf.Reset()
+	require.NoError(t, WriteJSON(buf, report))
+	decoded := &Report{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), decoded))
+	require.Equal(t, report, decoded)
+}
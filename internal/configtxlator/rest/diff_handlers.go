@@ -0,0 +1,124 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rest
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/internal/configtxlator/builder"
+	"github.com/hyperledger/fabric/internal/configtxlator/diff"
+)
+
+func fieldConfigBlock(fieldName string, r *http.Request) (string, *cb.Config, error) {
+	fieldBytes, err := fieldBytes(fieldName, r)
+	if err != nil {
+		return "", nil, fmt.Errorf("error reading field bytes: %s", err)
+	}
+
+	block := &cb.Block{}
+	err = proto.Unmarshal(fieldBytes, block)
+	if err != nil {
+		return "", nil, fmt.Errorf("error unmarshalling field bytes: %s", err)
+	}
+
+	return builder.ConfigFromBlock(block)
+}
+
+func fieldConfigUpdate(fieldName string, r *http.Request) (*cb.ConfigUpdate, error) {
+	fieldBytes, err := fieldBytes(fieldName, r)
+	if err != nil {
+		return nil, fmt.Errorf("error reading field bytes: %s", err)
+	}
+
+	env := &cb.Envelope{}
+	err = proto.Unmarshal(fieldBytes, env)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling field bytes: %s", err)
+	}
+
+	return diff.ConfigUpdateFromEnvelope(env)
+}
+
+// DiffConfigs responds with the differences between the config of the block
+// in the 'original' field and either the config of the block in the 'updated'
+// field, or the config which results from applying the config update envelope
+// in the 'update' field. The 'format' field selects between JSON, the default,
+// and text output.
+func DiffConfigs(w http.ResponseWriter, r *http.Request) {
+	format := r.FormValue("format")
+	if format != "" && format != "json" && format != "text" {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "Error with field 'format': unknown format %s\n", format)
+		return
+	}
+
+	channelID, originalConfig, err := fieldConfigBlock("original", r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "Error with field 'original': %s\n", err)
+		return
+	}
+
+	var updatedConfig *cb.Config
+	if _, _, err := r.FormFile("update"); err == http.ErrMissingFile {
+		_, updatedConfig, err = fieldConfigBlock("updated", r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "Error with field 'updated': %s\n", err)
+			return
+		}
+	} else {
+		configUpdate, err := fieldConfigUpdate("update", r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "Error with field 'update': %s\n", err)
+			return
+		}
+
+		if configUpdate.ChannelId != channelID {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "Error with field 'update': config update is for channel %s, but the original config block is for channel %s\n", configUpdate.ChannelId, channelID)
+			return
+		}
+
+		updatedConfig, err = diff.ApplyUpdate(originalConfig, configUpdate)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "Error with field 'update': %s\n", err)
+			return
+		}
+	}
+
+	changes, err := diff.Configs(originalConfig, updatedConfig)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "Error computing diff: %s\n", err)
+		return
+	}
+
+	buf := &bytes.Buffer{}
+	contentType := "application/json"
+	if format == "text" {
+		contentType = "text/plain"
+		err = diff.WriteText(buf, changes)
+	} else {
+		err = diff.WriteJSON(buf, changes)
+	}
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "Error encoding diff: %s\n", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+	w.Write(buf.Bytes())
+}
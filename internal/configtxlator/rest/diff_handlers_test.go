@@ -0,0 +1,93 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/hyperledger/fabric/internal/configtxgen/encoder"
+	"github.com/hyperledger/fabric/internal/configtxlator/diff"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffConfigs(t *testing.T) {
+	profile := etcdRaftProfile(t)
+	block := protoutil.MarshalOrPanic(encoder.New(profile).GenesisBlockForChannel("mychannel"))
+	original := formField{name: "original", value: block, file: true}
+
+	rec := postForm(t, "/configtxlator/update/batch-timeout",
+		formField{name: "config_block", value: block, file: true},
+		formField{name: "timeout", value: []byte("5s")},
+	)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	update := formField{name: "update", value: rec.Body.Bytes(), file: true}
+
+	t.Run("Update", func(t *testing.T) {
+		rec := postForm(t, "/configtxlator/compute/diff", original, update)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+		var changes []diff.Change
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &changes))
+		require.Len(t, changes, 2)
+		require.Equal(t, "/Channel/Orderer/BatchTimeout", changes[0].Path)
+		require.Equal(t, "value.timeout", changes[0].Field)
+		require.Equal(t, "2s", changes[0].Old)
+		require.Equal(t, "5s", changes[0].New)
+	})
+
+	t.Run("UpdateText", func(t *testing.T) {
+		rec := postForm(t, "/configtxlator/compute/diff", original, update, formField{name: "format", value: []byte("text")})
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		require.Equal(t, "text/plain", rec.Header().Get("Content-Type"))
+		require.Equal(t,
+			"~ value  /Channel/Orderer/BatchTimeout value.timeout: \"2s\" -> \"5s\"\n"+
+				"~ value  /Channel/Orderer/BatchTimeout version: \"0\" -> \"1\"\n",
+			rec.Body.String(),
+		)
+	})
+
+	t.Run("Updated", func(t *testing.T) {
+		rec := postForm(t, "/configtxlator/compute/diff", original, formField{name: "updated", value: block, file: true})
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		require.Equal(t, "[]\n", rec.Body.String())
+	})
+
+	t.Run("BadFormat", func(t *testing.T) {
+		rec := postForm(t, "/configtxlator/compute/diff", original, update, formField{name: "format", value: []byte("yaml")})
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+		require.Equal(t, "Error with field 'format': unknown format yaml\n", rec.Body.String())
+	})
+
+	t.Run("MissingOriginal", func(t *testing.T) {
+		rec := postForm(t, "/configtxlator/compute/diff", update)
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+		require.Contains(t, rec.Body.String(), "Error with field 'original'")
+	})
+
+	t.Run("MissingUpdated", func(t *testing.T) {
+		rec := postForm(t, "/configtxlator/compute/diff", original)
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+		require.Contains(t, rec.Body.String(), "Error with field 'updated'")
+	})
+
+	t.Run("BadUpdate", func(t *testing.T) {
+		rec := postForm(t, "/configtxlator/compute/diff", original, formField{name: "update", value: block, file: true})
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+		require.Contains(t, rec.Body.String(), "Error with field 'update'")
+	})
+
+	t.Run("OtherChannel", func(t *testing.T) {
+		otherBlock := protoutil.MarshalOrPanic(encoder.New(profile).GenesisBlockForChannel("otherchannel"))
+		rec := postForm(t, "/configtxlator/compute/diff", formField{name: "original", value: otherBlock, file: true}, update)
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+		require.Equal(t, "Error with field 'update': config update is for channel mychannel, but the original config block is for channel otherchannel\n", rec.Body.String())
+	})
+}
@@ -22,6 +22,25 @@ func NewRouter() *mux.Router {
 	router.
 		HandleFunc("/configtxlator/compute/update-from-configs", ComputeUpdateFromConfigs).
 		Methods("POST")
+	router.
+		HandleFunc("/configtxlator/compute/diff", DiffConfigs).
+		Methods("POST")
+
+	router.
+		HandleFunc("/configtxlator/update/add-org", AddOrg).
+		Methods("POST")
+	router.
+		HandleFunc("/configtxlator/update/remove-org", RemoveOrg).
+		Methods("POST")
+	router.
+		HandleFunc("/configtxlator/update/batch-timeout", SetBatchTimeout).
+		Methods("POST")
+	router.
+		HandleFunc("/configtxlator/update/batch-size", SetBatchSize).
+		Methods("POST")
+	router.
+		HandleFunc("/configtxlator/update/consenter", UpdateConsenter).
+		Methods("POST")
 
 	return router
 }
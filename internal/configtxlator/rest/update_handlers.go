@@ -0,0 +1,164 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/internal/configtxlator/builder"
+)
+
+// ModPoliciesHeader is the response header which lists the modification
+// policies the signatures of a built config update must satisfy.
+const ModPoliciesHeader = "X-Fabric-Mod-Policies"
+
+func AddOrg(w http.ResponseWriter, r *http.Request) {
+	orgBytes, err := fieldBytes("org", r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "Error with field 'org': error reading field bytes: %s\n", err)
+		return
+	}
+
+	org := &cb.ConfigGroup{}
+	err = proto.Unmarshal(orgBytes, org)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "Error with field 'org': error unmarshalling field bytes: %s\n", err)
+		return
+	}
+
+	orgName := r.FormValue("org_name")
+	if orgName == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "Error with field 'org_name': missing value\n")
+		return
+	}
+
+	buildUpdate(w, r, builder.AddApplicationOrg(orgName, org))
+}
+
+func RemoveOrg(w http.ResponseWriter, r *http.Request) {
+	orgName := r.FormValue("org_name")
+	if orgName == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "Error with field 'org_name': missing value\n")
+		return
+	}
+
+	buildUpdate(w, r, builder.RemoveApplicationOrg(orgName))
+}
+
+func SetBatchTimeout(w http.ResponseWriter, r *http.Request) {
+	timeout, err := time.ParseDuration(r.FormValue("timeout"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "Error with field 'timeout': %s\n", err)
+		return
+	}
+
+	buildUpdate(w, r, builder.SetBatchTimeout(timeout))
+}
+
+func SetBatchSize(w http.ResponseWriter, r *http.Request) {
+	var limits [3]uint32
+	for i, fieldName := range []string{"max_message_count", "absolute_max_bytes", "preferred_max_bytes"} {
+		value, err := fieldUint32(fieldName, r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "Error with field '%s': %s\n", fieldName, err)
+			return
+		}
+		limits[i] = value
+	}
+
+	buildUpdate(w, r, builder.SetBatchSize(limits[0], limits[1], limits[2]))
+}
+
+func UpdateConsenter(w http.ResponseWriter, r *http.Request) {
+	host := r.FormValue("host")
+	if host == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "Error with field 'host': missing value\n")
+		return
+	}
+
+	port, err := fieldUint32("port", r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "Error with field 'port': %s\n", err)
+		return
+	}
+
+	var certs [2][]byte
+	for i, fieldName := range []string{"client_tls_cert", "server_tls_cert"} {
+		cert, err := fieldBytes(fieldName, r)
+		if err == http.ErrMissingFile {
+			continue
+		}
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "Error with field '%s': error reading field bytes: %s\n", fieldName, err)
+			return
+		}
+		certs[i] = cert
+	}
+
+	buildUpdate(w, r, builder.UpdateConsenterTLSCerts(host, port, certs[0], certs[1]))
+}
+
+// fieldUint32 parses an optional numeric form value, which defaults to zero.
+func fieldUint32(fieldName string, r *http.Request) (uint32, error) {
+	value := r.FormValue(fieldName)
+	if value == "" {
+		return 0, nil
+	}
+
+	parsed, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return 0, err
+	}
+
+	return uint32(parsed), nil
+}
+
+// buildUpdate applies the modifiers to the config carried by the block in the
+// 'config_block' field, and responds with the marshaled config update
+// envelope.
+func buildUpdate(w http.ResponseWriter, r *http.Request, modifiers ...builder.Modifier) {
+	channelID, config, err := fieldConfigBlock("config_block", r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "Error with field 'config_block': %s\n", err)
+		return
+	}
+
+	env, configUpdate, err := builder.Build(channelID, config, modifiers...)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "Error building update: %s\n", err)
+		return
+	}
+
+	encoded, err := proto.Marshal(env)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "Error marshaling config update envelope: %s\n", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set(ModPoliciesHeader, strings.Join(builder.ModPolicies(config, configUpdate), ","))
+	w.WriteHeader(http.StatusOK)
+	w.Write(encoded)
+}
@@ -0,0 +1,216 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rest
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/common/crypto/tlsgen"
+	"github.com/hyperledger/fabric/core/config/configtest"
+	"github.com/hyperledger/fabric/internal/configtxgen/encoder"
+	"github.com/hyperledger/fabric/internal/configtxgen/genesisconfig"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/require"
+)
+
+func etcdRaftProfile(t *testing.T) *genesisconfig.Profile {
+	profile := genesisconfig.Load(genesisconfig.SampleAppChannelEtcdRaftProfile, configtest.GetDevConfigDir())
+
+	ca, err := tlsgen.NewCA()
+	require.NoError(t, err)
+	certPath := filepath.Join(t.TempDir(), "cert.pem")
+	require.NoError(t, os.WriteFile(certPath, ca.CertBytes(), 0o644))
+
+	for _, consenter := range profile.Orderer.EtcdRaft.Consenters {
+		consenter.ClientTlsCert = []byte(certPath)
+		consenter.ServerTlsCert = []byte(certPath)
+	}
+
+	return profile
+}
+
+type formField struct {
+	name  string
+	value []byte
+	file  bool
+}
+
+func postForm(t *testing.T, path string, fields ...formField) *httptest.ResponseRecorder {
+	buffer := &bytes.Buffer{}
+	mpw := multipart.NewWriter(buffer)
+
+	for _, field := range fields {
+		if field.file {
+			ffw, err := mpw.CreateFormFile(field.name, field.name)
+			require.NoError(t, err)
+			_, err = ffw.Write(field.value)
+			require.NoError(t, err)
+			continue
+		}
+		require.NoError(t, mpw.WriteField(field.name, string(field.value)))
+	}
+
+	require.NoError(t, mpw.Close())
+
+	req, err := http.NewRequest("POST", path, buffer)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", mpw.FormDataContentType())
+
+	rec := httptest.NewRecorder()
+	NewRouter().ServeHTTP(rec, req)
+
+	return rec
+}
+
+func requireConfigUpdate(t *testing.T, rec *httptest.ResponseRecorder, modPolicies string) *cb.ConfigUpdate {
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	require.Equal(t, "application/octet-stream", rec.Header().Get("Content-Type"))
+	require.Equal(t, modPolicies, rec.Header().Get(ModPoliciesHeader))
+
+	env := &cb.Envelope{}
+	require.NoError(t, proto.Unmarshal(rec.Body.Bytes(), env))
+	payload, err := protoutil.UnmarshalPayload(env.Payload)
+	require.NoError(t, err)
+	configUpdateEnv := &cb.ConfigUpdateEnvelope{}
+	require.NoError(t, proto.Unmarshal(payload.Data, configUpdateEnv))
+	configUpdate := &cb.ConfigUpdate{}
+	require.NoError(t, proto.Unmarshal(configUpdateEnv.ConfigUpdate, configUpdate))
+	require.Equal(t, "mychannel", configUpdate.ChannelId)
+
+	return configUpdate
+}
+
+func TestUpdateHandlers(t *testing.T) {
+	profile := etcdRaftProfile(t)
+	block := protoutil.MarshalOrPanic(encoder.New(profile).GenesisBlockForChannel("mychannel"))
+	configBlock := formField{name: "config_block", value: block, file: true}
+
+	t.Run("AddOrg", func(t *testing.T) {
+		org := *profile.Application.Organizations[0]
+		org.Name = "Org2"
+		orgGroup, err := encoder.NewApplicationOrgGroup(&org)
+		require.NoError(t, err)
+
+		rec := postForm(t, "/configtxlator/update/add-org",
+			configBlock,
+			formField{name: "org", value: protoutil.MarshalOrPanic(orgGroup), file: true},
+			formField{name: "org_name", value: []byte("Org2")},
+		)
+		configUpdate := requireConfigUpdate(t, rec, "/Channel/Application/Admins")
+		require.Contains(t, configUpdate.WriteSet.Groups["Application"].Groups, "Org2")
+	})
+
+	t.Run("AddOrgMissingOrg", func(t *testing.T) {
+		rec := postForm(t, "/configtxlator/update/add-org",
+			configBlock,
+			formField{name: "org_name", value: []byte("Org2")},
+		)
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+		require.Contains(t, rec.Body.String(), "Error with field 'org'")
+	})
+
+	t.Run("RemoveOrg", func(t *testing.T) {
+		rec := postForm(t, "/configtxlator/update/remove-org",
+			configBlock,
+			formField{name: "org_name", value: []byte("SampleOrg")},
+		)
+		configUpdate := requireConfigUpdate(t, rec, "/Channel/Application/Admins")
+		require.Empty(t, configUpdate.WriteSet.Groups["Application"].Groups)
+	})
+
+	t.Run("RemoveOrgUnknown", func(t *testing.T) {
+		rec := postForm(t, "/configtxlator/update/remove-org",
+			configBlock,
+			formField{name: "org_name", value: []byte("Org2")},
+		)
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+		require.Equal(t, "Error building update: organization Org2 does not exist in the application group\n", rec.Body.String())
+	})
+
+	t.Run("SetBatchTimeout", func(t *testing.T) {
+		rec := postForm(t, "/configtxlator/update/batch-timeout",
+			configBlock,
+			formField{name: "timeout", value: []byte("500ms")},
+		)
+		configUpdate := requireConfigUpdate(t, rec, "/Channel/Orderer/Admins")
+		require.Contains(t, configUpdate.WriteSet.Groups["Orderer"].Values, "BatchTimeout")
+	})
+
+	t.Run("SetBatchTimeoutBadTimeout", func(t *testing.T) {
+		rec := postForm(t, "/configtxlator/update/batch-timeout",
+			configBlock,
+			formField{name: "timeout", value: []byte("soon")},
+		)
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+		require.Contains(t, rec.Body.String(), "Error with field 'timeout'")
+	})
+
+	t.Run("SetBatchSize", func(t *testing.T) {
+		rec := postForm(t, "/configtxlator/update/batch-size",
+			configBlock,
+			formField{name: "max_message_count", value: []byte("42")},
+		)
+		configUpdate := requireConfigUpdate(t, rec, "/Channel/Orderer/Admins")
+		require.Contains(t, configUpdate.WriteSet.Groups["Orderer"].Values, "BatchSize")
+	})
+
+	t.Run("SetBatchSizeBadLimit", func(t *testing.T) {
+		rec := postForm(t, "/configtxlator/update/batch-size",
+			configBlock,
+			formField{name: "preferred_max_bytes", value: []byte("-1")},
+		)
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+		require.Contains(t, rec.Body.String(), "Error with field 'preferred_max_bytes'")
+	})
+
+	t.Run("UpdateConsenter", func(t *testing.T) {
+		rec := postForm(t, "/configtxlator/update/consenter",
+			configBlock,
+			formField{name: "host", value: []byte("raft0.example.com")},
+			formField{name: "port", value: []byte("7050")},
+			formField{name: "server_tls_cert", value: []byte("server"), file: true},
+		)
+		configUpdate := requireConfigUpdate(t, rec, "/Channel/Orderer/Admins")
+		require.Contains(t, configUpdate.WriteSet.Groups["Orderer"].Values, "ConsensusType")
+	})
+
+	t.Run("UpdateConsenterUnknown", func(t *testing.T) {
+		rec := postForm(t, "/configtxlator/update/consenter",
+			configBlock,
+			formField{name: "host", value: []byte("raft9.example.com")},
+			formField{name: "port", value: []byte("7050")},
+			formField{name: "client_tls_cert", value: []byte("client"), file: true},
+		)
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+		require.Equal(t, "Error building update: consenter raft9.example.com:7050 does not exist\n", rec.Body.String())
+	})
+
+	t.Run("MissingConfigBlock", func(t *testing.T) {
+		rec := postForm(t, "/configtxlator/update/remove-org",
+			formField{name: "org_name", value: []byte("SampleOrg")},
+		)
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+		require.Contains(t, rec.Body.String(), "Error with field 'config_block'")
+	})
+
+	t.Run("NotAConfigBlock", func(t *testing.T) {
+		rec := postForm(t, "/configtxlator/update/remove-org",
+			formField{name: "config_block", value: protoutil.MarshalOrPanic(protoutil.NewBlock(0, nil)), file: true},
+			formField{name: "org_name", value: []byte("SampleOrg")},
+		)
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+		require.Contains(t, rec.Body.String(), "Error with field 'config_block': error extracting envelope from config block")
+	})
+}
@@ -12,9 +12,14 @@ import (
 
 	"github.com/golang/protobuf/proto"
 	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/protoutil"
 )
 
+// RootGroupPath is the fully qualified path of the channel group, which is
+// the root of the config tree.
+const RootGroupPath = policies.PathSeparator + policies.ChannelPrefix
+
 func computePoliciesMapUpdate(original, updated map[string]*cb.ConfigPolicy) (readSet, writeSet, sameSet map[string]*cb.ConfigPolicy, updatedMembers bool) {
 	readSet = make(map[string]*cb.ConfigPolicy)
 	writeSet = make(map[string]*cb.ConfigPolicy)
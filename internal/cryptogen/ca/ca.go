@@ -8,6 +8,7 @@ package ca
 import (
 	"crypto"
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/sha256"
@@ -48,7 +49,8 @@ func NewCA(
 	locality,
 	orgUnit,
 	streetAddress,
-	postalCode string,
+	postalCode,
+	keyAlg string,
 ) (*CA, error) {
 	var ca *CA
 
@@ -57,7 +59,15 @@ func NewCA(
 		return nil, err
 	}
 
-	priv, err := csp.GeneratePrivateKey(baseDir)
+	priv, err := csp.GeneratePrivateKey(baseDir, keyAlg)
+	if err != nil {
+		return nil, err
+	}
+	pub, err := csp.GetPublicKey(priv)
+	if err != nil {
+		return nil, err
+	}
+	signer, err := csp.NewSigner(priv)
 	if err != nil {
 		return nil, err
 	}
@@ -81,22 +91,20 @@ func NewCA(
 	template.Subject = subject
 	template.SubjectKeyId = computeSKI(priv)
 
-	x509Cert, err := genCertificateECDSA(
+	x509Cert, err := genCertificate(
 		baseDir,
 		name,
 		&template,
 		&template,
-		&priv.PublicKey,
-		priv,
+		pub,
+		signer,
 	)
 	if err != nil {
 		return nil, err
 	}
 	ca = &CA{
-		Name: name,
-		Signer: &csp.ECDSASigner{
-			PrivateKey: priv,
-		},
+		Name:               name,
+		Signer:             signer,
 		SignCert:           x509Cert,
 		Country:            country,
 		Province:           province,
@@ -116,7 +124,7 @@ func (ca *CA) SignCertificate(
 	name string,
 	orgUnits,
 	alternateNames []string,
-	pub *ecdsa.PublicKey,
+	pub crypto.PublicKey,
 	ku x509.KeyUsage,
 	eku []x509.ExtKeyUsage,
 ) (*x509.Certificate, error) {
@@ -148,7 +156,7 @@ func (ca *CA) SignCertificate(
 		}
 	}
 
-	cert, err := genCertificateECDSA(
+	cert, err := genCertificate(
 		baseDir,
 		name,
 		&template,
@@ -163,10 +171,66 @@ func (ca *CA) SignCertificate(
 	return cert, nil
 }
 
+// GenerateCRL creates a certificate revocation list signed by the CA which
+// revokes the given certificates in addition to the ones revoked by the
+// previous CRL of the CA, if any
+func (ca *CA) GenerateCRL(
+	previous *x509.RevocationList,
+	certs []*x509.Certificate,
+) (*x509.RevocationList, error) {
+	now := time.Now().UTC()
+
+	template := &x509.RevocationList{
+		Number:     big.NewInt(1),
+		ThisUpdate: now,
+		NextUpdate: ca.SignCert.NotAfter,
+	}
+	revoked := map[string]bool{}
+
+	if previous != nil {
+		if err := previous.CheckSignatureFrom(ca.SignCert); err != nil {
+			return nil, errors.WithMessage(err, "previous CRL was not issued by the CA")
+		}
+		template.Number = new(big.Int).Add(previous.Number, big.NewInt(1))
+		for _, rc := range previous.RevokedCertificates {
+			revoked[rc.SerialNumber.String()] = true
+			template.RevokedCertificates = append(template.RevokedCertificates, rc)
+		}
+	}
+
+	for _, cert := range certs {
+		if err := cert.CheckSignatureFrom(ca.SignCert); err != nil {
+			return nil, errors.WithMessagef(err, "certificate %s was not issued by the CA", cert.Subject.CommonName)
+		}
+		if revoked[cert.SerialNumber.String()] {
+			continue
+		}
+		revoked[cert.SerialNumber.String()] = true
+		template.RevokedCertificates = append(template.RevokedCertificates, pkix.RevokedCertificate{
+			SerialNumber:   cert.SerialNumber,
+			RevocationTime: now,
+		})
+	}
+
+	crlBytes, err := x509.CreateRevocationList(rand.Reader, template, ca.SignCert, ca.Signer)
+	if err != nil {
+		return nil, err
+	}
+
+	return x509.ParseRevocationList(crlBytes)
+}
+
 // compute Subject Key Identifier using RFC 7093, Section 2, Method 4
-func computeSKI(privKey *ecdsa.PrivateKey) []byte {
+func computeSKI(privKey crypto.PrivateKey) []byte {
+	var raw []byte
+
 	// Marshall the public key
-	raw := elliptic.Marshal(privKey.Curve, privKey.PublicKey.X, privKey.PublicKey.Y)
+	switch kk := privKey.(type) {
+	case *ecdsa.PrivateKey:
+		raw = elliptic.Marshal(kk.Curve, kk.PublicKey.X, kk.PublicKey.Y)
+	case ed25519.PrivateKey:
+		raw = kk.Public().(ed25519.PublicKey)
+	}
 
 	// Hash it
 	hash := sha256.Sum256(raw)
@@ -235,13 +299,13 @@ func x509Template() x509.Certificate {
 	return x509
 }
 
-// generate a signed X509 certificate using ECDSA
-func genCertificateECDSA(
+// generate a signed X509 certificate
+func genCertificate(
 	baseDir,
 	name string,
 	template,
 	parent *x509.Certificate,
-	pub *ecdsa.PublicKey,
+	pub crypto.PublicKey,
 	priv interface{},
 ) (*x509.Certificate, error) {
 	// create the x509 public cert
@@ -270,7 +334,7 @@ func genCertificateECDSA(
 	return x509Cert, nil
 }
 
-// LoadCertificateECDSA load a ecdsa cert from a file in cert path
+// LoadCertificateECDSA load a ecdsa or ed25519 cert from a file in cert path
 func LoadCertificateECDSA(certPath string) (*x509.Certificate, error) {
 	var cert *x509.Certificate
 	var err error
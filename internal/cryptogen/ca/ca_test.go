@@ -43,8 +43,10 @@ func TestLoadCertificateECDSA(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Failed to create certs directory: %s", err)
 	}
-	priv, err := csp.GeneratePrivateKey(certDir)
+	priv, err := csp.GeneratePrivateKey(certDir, csp.ECDSA)
 	require.NoError(t, err, "Failed to generate signed certificate")
+	pub, err := csp.GetPublicKey(priv)
+	require.NoError(t, err)
 
 	// create our CA
 	caDir := filepath.Join(testDir, "ca")
@@ -58,6 +60,7 @@ func TestLoadCertificateECDSA(t *testing.T) {
 		testOrganizationalUnit,
 		testStreetAddress,
 		testPostalCode,
+		csp.ECDSA,
 	)
 	require.NoError(t, err, "Error generating CA")
 
@@ -66,7 +69,7 @@ func TestLoadCertificateECDSA(t *testing.T) {
 		testName3,
 		nil,
 		nil,
-		&priv.PublicKey,
+		pub,
 		x509.KeyUsageDigitalSignature|x509.KeyUsageKeyEncipherment,
 		[]x509.ExtKeyUsage{x509.ExtKeyUsageAny},
 	)
@@ -123,6 +126,7 @@ func TestNewCA(t *testing.T) {
 		testOrganizationalUnit,
 		testStreetAddress,
 		testPostalCode,
+		csp.ECDSA,
 	)
 	require.NoError(t, err, "Error generating CA")
 	require.NotNil(t, rootCA, "Failed to return CA")
@@ -158,8 +162,10 @@ func TestGenerateSignCertificate(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Failed to create certs directory: %s", err)
 	}
-	priv, err := csp.GeneratePrivateKey(certDir)
+	priv, err := csp.GeneratePrivateKey(certDir, csp.ECDSA)
 	require.NoError(t, err, "Failed to generate signed certificate")
+	pub, err := csp.GetPublicKey(priv)
+	require.NoError(t, err)
 
 	// create our CA
 	caDir := filepath.Join(testDir, "ca")
@@ -173,6 +179,7 @@ func TestGenerateSignCertificate(t *testing.T) {
 		testOrganizationalUnit,
 		testStreetAddress,
 		testPostalCode,
+		csp.ECDSA,
 	)
 	require.NoError(t, err, "Error generating CA")
 
@@ -181,7 +188,7 @@ func TestGenerateSignCertificate(t *testing.T) {
 		testName,
 		nil,
 		nil,
-		&priv.PublicKey,
+		pub,
 		x509.KeyUsageDigitalSignature|x509.KeyUsageKeyEncipherment,
 		[]x509.ExtKeyUsage{x509.ExtKeyUsageAny},
 	)
@@ -196,7 +203,7 @@ func TestGenerateSignCertificate(t *testing.T) {
 		testName,
 		nil,
 		nil,
-		&priv.PublicKey,
+		pub,
 		x509.KeyUsageDigitalSignature,
 		[]x509.ExtKeyUsage{},
 	)
@@ -205,7 +212,7 @@ func TestGenerateSignCertificate(t *testing.T) {
 
 	// make sure ous are correctly set
 	ous := []string{"TestOU", "PeerOU"}
-	cert, err = rootCA.SignCertificate(certDir, testName, ous, nil, &priv.PublicKey,
+	cert, err = rootCA.SignCertificate(certDir, testName, ous, nil, pub,
 		x509.KeyUsageDigitalSignature, []x509.ExtKeyUsage{})
 	require.NoError(t, err)
 	require.Contains(t, cert.Subject.OrganizationalUnit, ous[0])
@@ -213,7 +220,7 @@ func TestGenerateSignCertificate(t *testing.T) {
 
 	// make sure sans are correctly set
 	sans := []string{testName2, testName3, testIP}
-	cert, err = rootCA.SignCertificate(certDir, testName, nil, sans, &priv.PublicKey,
+	cert, err = rootCA.SignCertificate(certDir, testName, nil, sans, pub,
 		x509.KeyUsageDigitalSignature, []x509.ExtKeyUsage{})
 	require.NoError(t, err)
 	require.Contains(t, cert.DNSNames, testName2)
@@ -226,7 +233,7 @@ func TestGenerateSignCertificate(t *testing.T) {
 	require.Equal(t, true, checkForFile(pemFile),
 		"Expected to find file "+pemFile)
 
-	_, err = rootCA.SignCertificate(certDir, "empty/CA", nil, nil, &priv.PublicKey,
+	_, err = rootCA.SignCertificate(certDir, "empty/CA", nil, nil, pub,
 		x509.KeyUsageKeyEncipherment, []x509.ExtKeyUsage{x509.ExtKeyUsageAny})
 	require.Error(t, err, "Bad name should fail")
 
@@ -240,9 +247,108 @@ func TestGenerateSignCertificate(t *testing.T) {
 	require.Error(t, err, "Empty CA should not be able to sign")
 }
 
+func TestED25519CA(t *testing.T) {
+	testDir := t.TempDir()
+
+	certDir := filepath.Join(testDir, "certs")
+	err := os.MkdirAll(certDir, 0o755)
+	require.NoError(t, err)
+	priv, err := csp.GeneratePrivateKey(certDir, csp.ED25519)
+	require.NoError(t, err)
+	pub, err := csp.GetPublicKey(priv)
+	require.NoError(t, err)
+
+	rootCA, err := ca.NewCA(
+		filepath.Join(testDir, "ca"),
+		testCAName,
+		testCAName,
+		testCountry,
+		testProvince,
+		testLocality,
+		testOrganizationalUnit,
+		testStreetAddress,
+		testPostalCode,
+		csp.ED25519,
+	)
+	require.NoError(t, err, "Error generating CA")
+	require.Equal(t, x509.Ed25519, rootCA.SignCert.PublicKeyAlgorithm)
+	require.Equal(t, x509.PureEd25519, rootCA.SignCert.SignatureAlgorithm)
+
+	cert, err := rootCA.SignCertificate(
+		certDir,
+		testName,
+		nil,
+		nil,
+		pub,
+		x509.KeyUsageDigitalSignature,
+		[]x509.ExtKeyUsage{},
+	)
+	require.NoError(t, err, "Failed to generate signed certificate")
+	require.Equal(t, pub, cert.PublicKey)
+	require.NoError(t, cert.CheckSignatureFrom(rootCA.SignCert))
+
+	_, err = ca.NewCA(
+		filepath.Join(testDir, "ca2"),
+		testCAName,
+		testCAName,
+		testCountry,
+		testProvince,
+		testLocality,
+		testOrganizationalUnit,
+		testStreetAddress,
+		testPostalCode,
+		"rsa",
+	)
+	require.EqualError(t, err, "unsupported public key algorithm: rsa")
+}
+
 func checkForFile(file string) bool {
 	if _, err := os.Stat(file); os.IsNotExist(err) {
 		return false
 	}
 	return true
 }
+
+func TestGenerateCRL(t *testing.T) {
+	testDir := t.TempDir()
+
+	rootCA, err := ca.NewCA(filepath.Join(testDir, "ca"), testCAName, testCAName, testCountry, testProvince, testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode, csp.ECDSA)
+	require.NoError(t, err, "Error generating CA")
+	otherCA, err := ca.NewCA(filepath.Join(testDir, "otherca"), testCA2Name, testCA2Name, testCountry, testProvince, testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode, csp.ED25519)
+	require.NoError(t, err, "Error generating CA")
+
+	signCert := func(signCA *ca.CA, name string) *x509.Certificate {
+		priv, err := csp.GeneratePrivateKey(testDir, csp.ECDSA)
+		require.NoError(t, err)
+		cert, err := signCA.SignCertificate(testDir, name, nil, nil, &priv.(*ecdsa.PrivateKey).PublicKey, x509.KeyUsageDigitalSignature, nil)
+		require.NoError(t, err)
+		return cert
+	}
+	cert0 := signCert(rootCA, testName)
+	cert1 := signCert(rootCA, testName2)
+	otherCert := signCert(otherCA, testName3)
+
+	crl, err := rootCA.GenerateCRL(nil, []*x509.Certificate{cert0})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), crl.Number.Int64())
+	require.NoError(t, crl.CheckSignatureFrom(rootCA.SignCert))
+	require.Equal(t, rootCA.SignCert.SubjectKeyId, crl.AuthorityKeyId)
+	require.Len(t, crl.RevokedCertificates, 1)
+	require.Equal(t, cert0.SerialNumber, crl.RevokedCertificates[0].SerialNumber)
+
+	// certificates which are already revoked are not duplicated
+	crl, err = rootCA.GenerateCRL(crl, []*x509.Certificate{cert0, cert1})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), crl.Number.Int64())
+	require.Len(t, crl.RevokedCertificates, 2)
+	require.Equal(t, cert1.SerialNumber, crl.RevokedCertificates[1].SerialNumber)
+
+	_, err = rootCA.GenerateCRL(nil, []*x509.Certificate{otherCert})
+	require.ErrorContains(t, err, "certificate cert2 was not issued by the CA")
+
+	otherCRL, err := otherCA.GenerateCRL(nil, []*x509.Certificate{otherCert})
+	require.NoError(t, err)
+	require.NoError(t, otherCRL.CheckSignatureFrom(otherCA.SignCert))
+	_, err = rootCA.GenerateCRL(otherCRL, nil)
+	require.ErrorContains(t, err, "previous CRL was not issued by the CA")
+}
@@ -8,6 +8,7 @@ package csp
 import (
 	"crypto"
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/x509"
@@ -22,10 +23,17 @@ import (
 	"github.com/pkg/errors"
 )
 
+// Supported public key algorithms
+const (
+	ECDSA   = "ecdsa"
+	ED25519 = "ed25519"
+)
+
 // LoadPrivateKey loads a private key from a file in keystorePath.  It looks
-// for a file ending in "_sk" and expects a PEM-encoded PKCS8 EC private key.
-func LoadPrivateKey(keystorePath string) (*ecdsa.PrivateKey, error) {
-	var priv *ecdsa.PrivateKey
+// for a file ending in "_sk" and expects a PEM-encoded PKCS8 EC or Ed25519
+// private key.
+func LoadPrivateKey(keystorePath string) (crypto.PrivateKey, error) {
+	var priv crypto.PrivateKey
 
 	walkFunc := func(path string, info os.FileInfo, pathErr error) error {
 		if !strings.HasSuffix(path, "_sk") {
@@ -53,7 +61,7 @@ func LoadPrivateKey(keystorePath string) (*ecdsa.PrivateKey, error) {
 	return priv, err
 }
 
-func parsePrivateKeyPEM(rawKey []byte) (*ecdsa.PrivateKey, error) {
+func parsePrivateKeyPEM(rawKey []byte) (crypto.PrivateKey, error) {
 	block, _ := pem.Decode(rawKey)
 	if block == nil {
 		return nil, errors.New("bytes are not PEM encoded")
@@ -64,17 +72,30 @@ func parsePrivateKeyPEM(rawKey []byte) (*ecdsa.PrivateKey, error) {
 		return nil, errors.WithMessage(err, "pem bytes are not PKCS8 encoded ")
 	}
 
-	priv, ok := key.(*ecdsa.PrivateKey)
-	if !ok {
-		return nil, errors.New("pem bytes do not contain an EC private key")
+	switch priv := key.(type) {
+	case *ecdsa.PrivateKey:
+		return priv, nil
+	case ed25519.PrivateKey:
+		return priv, nil
+	default:
+		return nil, errors.New("pem bytes do not contain an EC or Ed25519 private key")
 	}
-	return priv, nil
 }
 
-// GeneratePrivateKey creates an EC private key using a P-256 curve and stores
-// it in keystorePath.
-func GeneratePrivateKey(keystorePath string) (*ecdsa.PrivateKey, error) {
-	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+// GeneratePrivateKey creates a private key for the given public key
+// algorithm and stores it in keystorePath. ECDSA keys use the P-256 curve.
+func GeneratePrivateKey(keystorePath string, keyAlg string) (crypto.PrivateKey, error) {
+	var priv crypto.PrivateKey
+	var err error
+
+	switch keyAlg {
+	case ECDSA:
+		priv, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case ED25519:
+		_, priv, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		return nil, errors.Errorf("unsupported public key algorithm: %s", keyAlg)
+	}
 	if err != nil {
 		return nil, errors.WithMessage(err, "failed to generate private key")
 	}
@@ -95,6 +116,31 @@ func GeneratePrivateKey(keystorePath string) (*ecdsa.PrivateKey, error) {
 	return priv, err
 }
 
+// GetPublicKey returns the public key associated with priv.
+func GetPublicKey(priv crypto.PrivateKey) (crypto.PublicKey, error) {
+	switch kk := priv.(type) {
+	case *ecdsa.PrivateKey:
+		return &kk.PublicKey, nil
+	case ed25519.PrivateKey:
+		return kk.Public(), nil
+	default:
+		return nil, errors.Errorf("unsupported private key type %T", priv)
+	}
+}
+
+// NewSigner returns a crypto.Signer for priv. ECDSA keys are wrapped
+// in an ECDSASigner so that the resulting signatures use Low S values.
+func NewSigner(priv crypto.PrivateKey) (crypto.Signer, error) {
+	switch kk := priv.(type) {
+	case *ecdsa.PrivateKey:
+		return &ECDSASigner{PrivateKey: kk}, nil
+	case ed25519.PrivateKey:
+		return kk, nil
+	default:
+		return nil, errors.Errorf("unsupported private key type %T", priv)
+	}
+}
+
 /*
 *
 ECDSA signer implements the crypto.Signer interface for ECDSA keys.  The
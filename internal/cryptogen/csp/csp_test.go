@@ -7,6 +7,7 @@ package csp_test
 
 import (
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
@@ -25,18 +26,22 @@ import (
 )
 
 func TestLoadPrivateKey(t *testing.T) {
-	testDir := t.TempDir()
-	priv, err := csp.GeneratePrivateKey(testDir)
-	if err != nil {
-		t.Fatalf("Failed to generate private key: %s", err)
+	for _, keyAlg := range []string{csp.ECDSA, csp.ED25519} {
+		t.Run(keyAlg, func(t *testing.T) {
+			testDir := t.TempDir()
+			priv, err := csp.GeneratePrivateKey(testDir, keyAlg)
+			if err != nil {
+				t.Fatalf("Failed to generate private key: %s", err)
+			}
+			pkFile := filepath.Join(testDir, "priv_sk")
+			require.Equal(t, true, checkForFile(pkFile),
+				"Expected to find private key file")
+			loadedPriv, err := csp.LoadPrivateKey(testDir)
+			require.NoError(t, err, "Failed to load private key")
+			require.NotNil(t, loadedPriv, "Should have returned a private key")
+			require.Equal(t, priv, loadedPriv, "Expected private keys to match")
+		})
 	}
-	pkFile := filepath.Join(testDir, "priv_sk")
-	require.Equal(t, true, checkForFile(pkFile),
-		"Expected to find private key file")
-	loadedPriv, err := csp.LoadPrivateKey(testDir)
-	require.NoError(t, err, "Failed to load private key")
-	require.NotNil(t, loadedPriv, "Should have returned an *ecdsa.PrivateKey")
-	require.Equal(t, priv, loadedPriv, "Expected private keys to match")
 }
 
 func TestLoadPrivateKey_BadPEM(t *testing.T) {
@@ -74,7 +79,7 @@ func TestLoadPrivateKey_BadPEM(t *testing.T) {
 		{
 			name:   "not EC key",
 			data:   pkcs8RSAPem,
-			errMsg: fmt.Sprintf("%s: pem bytes do not contain an EC private key", badPEMFile),
+			errMsg: fmt.Sprintf("%s: pem bytes do not contain an EC or Ed25519 private key", badPEMFile),
 		},
 		{
 			name:   "not PKCS8 encoded",
@@ -102,16 +107,50 @@ func TestGeneratePrivateKey(t *testing.T) {
 	testDir := t.TempDir()
 
 	expectedFile := filepath.Join(testDir, "priv_sk")
-	priv, err := csp.GeneratePrivateKey(testDir)
+	priv, err := csp.GeneratePrivateKey(testDir, csp.ECDSA)
 	require.NoError(t, err, "Failed to generate private key")
-	require.NotNil(t, priv, "Should have returned an *ecdsa.Key")
+	require.IsType(t, &ecdsa.PrivateKey{}, priv, "Should have returned an *ecdsa.PrivateKey")
 	require.Equal(t, true, checkForFile(expectedFile),
 		"Expected to find private key file")
 
-	_, err = csp.GeneratePrivateKey("notExist")
+	priv, err = csp.GeneratePrivateKey(testDir, csp.ED25519)
+	require.NoError(t, err, "Failed to generate private key")
+	require.IsType(t, ed25519.PrivateKey{}, priv, "Should have returned an ed25519.PrivateKey")
+
+	_, err = csp.GeneratePrivateKey(testDir, "rsa")
+	require.EqualError(t, err, "unsupported public key algorithm: rsa")
+
+	_, err = csp.GeneratePrivateKey("notExist", csp.ECDSA)
 	require.Contains(t, err.Error(), "no such file or directory")
 }
 
+func TestNewSigner(t *testing.T) {
+	ecPriv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	signer, err := csp.NewSigner(ecPriv)
+	require.NoError(t, err)
+	require.IsType(t, &csp.ECDSASigner{}, signer)
+
+	pub, err := csp.GetPublicKey(ecPriv)
+	require.NoError(t, err)
+	require.Equal(t, &ecPriv.PublicKey, pub)
+
+	_, edPriv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	signer, err = csp.NewSigner(edPriv)
+	require.NoError(t, err)
+	require.Equal(t, edPriv, signer)
+
+	pub, err = csp.GetPublicKey(edPriv)
+	require.NoError(t, err)
+	require.Equal(t, edPriv.Public(), pub)
+
+	_, err = csp.NewSigner(nil)
+	require.EqualError(t, err, "unsupported private key type <nil>")
+	_, err = csp.GetPublicKey(nil)
+	require.EqualError(t, err, "unsupported private key type <nil>")
+}
+
 func TestECDSASigner(t *testing.T) {
 	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
 	if err != nil {
@@ -47,6 +47,7 @@ func GenerateLocalMSP(
 	tlsCA *ca.CA,
 	nodeType int,
 	nodeOUs bool,
+	keyAlg string,
 ) error {
 	// create folder structure
 	mspDir := filepath.Join(baseDir, "msp")
@@ -69,7 +70,11 @@ func GenerateLocalMSP(
 	keystore := filepath.Join(mspDir, "keystore")
 
 	// generate private key
-	priv, err := csp.GeneratePrivateKey(keystore)
+	priv, err := csp.GeneratePrivateKey(keystore, keyAlg)
+	if err != nil {
+		return err
+	}
+	pub, err := csp.GetPublicKey(priv)
 	if err != nil {
 		return err
 	}
@@ -84,7 +89,7 @@ func GenerateLocalMSP(
 		name,
 		ous,
 		nil,
-		&priv.PublicKey,
+		pub,
 		x509.KeyUsageDigitalSignature,
 		[]x509.ExtKeyUsage{},
 	)
@@ -135,7 +140,11 @@ func GenerateLocalMSP(
 	*/
 
 	// generate private key
-	tlsPrivKey, err := csp.GeneratePrivateKey(tlsDir)
+	tlsPrivKey, err := csp.GeneratePrivateKey(tlsDir, keyAlg)
+	if err != nil {
+		return err
+	}
+	tlsPubKey, err := csp.GetPublicKey(tlsPrivKey)
 	if err != nil {
 		return err
 	}
@@ -146,7 +155,7 @@ func GenerateLocalMSP(
 		name,
 		nil,
 		sans,
-		&tlsPrivKey.PublicKey,
+		tlsPubKey,
 		x509.KeyUsageDigitalSignature|x509.KeyUsageKeyEncipherment,
 		[]x509.ExtKeyUsage{
 			x509.ExtKeyUsageServerAuth,
@@ -185,6 +194,7 @@ func GenerateVerifyingMSP(
 	signCA,
 	tlsCA *ca.CA,
 	nodeOUs bool,
+	keyAlg string,
 ) error {
 	// create folder structure and write artifacts to proper locations
 	err := createFolderStructure(baseDir, false)
@@ -228,7 +238,11 @@ func GenerateVerifyingMSP(
 	if err != nil {
 		return errors.WithMessage(err, "failed to create keystore directory")
 	}
-	priv, err := csp.GeneratePrivateKey(ksDir)
+	priv, err := csp.GeneratePrivateKey(ksDir, keyAlg)
+	if err != nil {
+		return err
+	}
+	pub, err := csp.GetPublicKey(priv)
 	if err != nil {
 		return err
 	}
@@ -237,7 +251,7 @@ func GenerateVerifyingMSP(
 		signCA.Name,
 		nil,
 		nil,
-		&priv.PublicKey,
+		pub,
 		x509.KeyUsageDigitalSignature,
 		[]x509.ExtKeyUsage{},
 	)
@@ -248,6 +262,44 @@ func GenerateVerifyingMSP(
 	return nil
 }
 
+// RevokeCertificates revokes certificates issued by the signing CA of an MSP
+// by adding them to the CRL of the CA in the crls folder of the MSP. The CRL
+// is created if it does not exist yet.
+func RevokeCertificates(mspDir string, signCA *ca.CA, certs []*x509.Certificate) (*x509.RevocationList, error) {
+	crlsDir := filepath.Join(mspDir, "crls")
+	err := os.MkdirAll(crlsDir, 0o755)
+	if err != nil {
+		return nil, err
+	}
+
+	crlPath := filepath.Join(crlsDir, crlFilename(signCA.Name))
+	var previous *x509.RevocationList
+	if raw, err := os.ReadFile(crlPath); err == nil {
+		block, _ := pem.Decode(raw)
+		if block == nil || block.Type != "X509 CRL" {
+			return nil, errors.Errorf("%s: wrong PEM encoding", crlPath)
+		}
+		previous, err = x509.ParseRevocationList(block.Bytes)
+		if err != nil {
+			return nil, errors.Wrapf(err, "%s: wrong DER encoding", crlPath)
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	crl, err := signCA.GenerateCRL(previous, certs)
+	if err != nil {
+		return nil, err
+	}
+
+	err = pemExport(crlPath, "X509 CRL", crl.Raw)
+	if err != nil {
+		return nil, err
+	}
+
+	return crl, nil
+}
+
 func createFolderStructure(rootDir string, local bool) error {
 	var folders []string
 	// create admincerts, cacerts, keystore and signcerts folders
@@ -275,6 +327,10 @@ func x509Filename(name string) string {
 	return name + "-cert.pem"
 }
 
+func crlFilename(name string) string {
+	return name + "-crl.pem"
+}
+
 func x509Export(path string, cert *x509.Certificate) error {
 	return pemExport(path, "CERTIFICATE", cert.Raw)
 }
@@ -6,14 +6,20 @@ SPDX-License-Identifier: Apache-2.0
 package msp_test
 
 import (
+	"crypto/x509"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 	"testing"
 
+	mspproto "github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric/bccsp/factory"
+	"github.com/hyperledger/fabric/bccsp/sw"
 	"github.com/hyperledger/fabric/internal/cryptogen/ca"
+	"github.com/hyperledger/fabric/internal/cryptogen/csp"
 	"github.com/hyperledger/fabric/internal/cryptogen/msp"
 	fabricmsp "github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/protoutil"
 	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v2"
 )
@@ -35,7 +41,7 @@ var testDir = filepath.Join(os.TempDir(), "msp-test")
 func testGenerateLocalMSP(t *testing.T, nodeOUs bool) {
 	cleanup(testDir)
 
-	err := msp.GenerateLocalMSP(testDir, testName, nil, &ca.CA{}, &ca.CA{}, msp.PEER, nodeOUs)
+	err := msp.GenerateLocalMSP(testDir, testName, nil, &ca.CA{}, &ca.CA{}, msp.PEER, nodeOUs, csp.ECDSA)
 	require.Error(t, err, "Empty CA should have failed")
 
 	caDir := filepath.Join(testDir, "ca")
@@ -44,10 +50,10 @@ func testGenerateLocalMSP(t *testing.T, nodeOUs bool) {
 	tlsDir := filepath.Join(testDir, "tls")
 
 	// generate signing CA
-	signCA, err := ca.NewCA(caDir, testCAOrg, testCAName, testCountry, testProvince, testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode)
+	signCA, err := ca.NewCA(caDir, testCAOrg, testCAName, testCountry, testProvince, testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode, csp.ECDSA)
 	require.NoError(t, err, "Error generating CA")
 	// generate TLS CA
-	tlsCA, err := ca.NewCA(tlsCADir, testCAOrg, testCAName, testCountry, testProvince, testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode)
+	tlsCA, err := ca.NewCA(tlsCADir, testCAOrg, testCAName, testCountry, testProvince, testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode, csp.ECDSA)
 	require.NoError(t, err, "Error generating CA")
 
 	require.NotEmpty(t, signCA.SignCert.Subject.Country, "country cannot be empty.")
@@ -64,7 +70,7 @@ func testGenerateLocalMSP(t *testing.T, nodeOUs bool) {
 	require.Equal(t, testPostalCode, signCA.SignCert.Subject.PostalCode[0], "Failed to match postalCode")
 
 	// generate local MSP for nodeType=PEER
-	err = msp.GenerateLocalMSP(testDir, testName, nil, signCA, tlsCA, msp.PEER, nodeOUs)
+	err = msp.GenerateLocalMSP(testDir, testName, nil, signCA, tlsCA, msp.PEER, nodeOUs, csp.ECDSA)
 	require.NoError(t, err, "Failed to generate local MSP")
 
 	// check to see that the right files were generated/saved
@@ -96,7 +102,7 @@ func testGenerateLocalMSP(t *testing.T, nodeOUs bool) {
 	}
 
 	// generate local MSP for nodeType=CLIENT
-	err = msp.GenerateLocalMSP(testDir, testName, nil, signCA, tlsCA, msp.CLIENT, nodeOUs)
+	err = msp.GenerateLocalMSP(testDir, testName, nil, signCA, tlsCA, msp.CLIENT, nodeOUs, csp.ECDSA)
 	require.NoError(t, err, "Failed to generate local MSP")
 	// check all
 	for _, file := range mspFiles {
@@ -110,10 +116,10 @@ func testGenerateLocalMSP(t *testing.T, nodeOUs bool) {
 	}
 
 	tlsCA.Name = "test/fail"
-	err = msp.GenerateLocalMSP(testDir, testName, nil, signCA, tlsCA, msp.CLIENT, nodeOUs)
+	err = msp.GenerateLocalMSP(testDir, testName, nil, signCA, tlsCA, msp.CLIENT, nodeOUs, csp.ECDSA)
 	require.Error(t, err, "Should have failed with CA name 'test/fail'")
 	signCA.Name = "test/fail"
-	err = msp.GenerateLocalMSP(testDir, testName, nil, signCA, tlsCA, msp.ORDERER, nodeOUs)
+	err = msp.GenerateLocalMSP(testDir, testName, nil, signCA, tlsCA, msp.ORDERER, nodeOUs, csp.ECDSA)
 	require.Error(t, err, "Should have failed with CA name 'test/fail'")
 	t.Log(err)
 	cleanup(testDir)
@@ -127,18 +133,60 @@ func TestGenerateLocalMSPWithoutNodeOU(t *testing.T) {
 	testGenerateLocalMSP(t, false)
 }
 
+func TestGenerateLocalMSPED25519(t *testing.T) {
+	testDir := t.TempDir()
+
+	signCA, err := ca.NewCA(filepath.Join(testDir, "ca"), testCAOrg, testCAName, testCountry, testProvince, testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode, csp.ED25519)
+	require.NoError(t, err, "Error generating CA")
+	tlsCA, err := ca.NewCA(filepath.Join(testDir, "tlsca"), testCAOrg, testCAName, testCountry, testProvince, testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode, csp.ED25519)
+	require.NoError(t, err, "Error generating CA")
+
+	nodeDir := filepath.Join(testDir, testName)
+	err = msp.GenerateLocalMSP(nodeDir, testName, nil, signCA, tlsCA, msp.PEER, true, csp.ED25519)
+	require.NoError(t, err, "Failed to generate local MSP")
+
+	// the generated MSP must be usable by the fabric MSP implementation
+	mspDir := filepath.Join(nodeDir, "msp")
+	conf, err := fabricmsp.GetLocalMspConfig(mspDir, nil, testCAName)
+	require.NoError(t, err)
+
+	cryptoProvider, err := factory.GetBCCSPFromOpts(&factory.FactoryOpts{
+		Default: "SW",
+		SW: &factory.SwOpts{
+			Hash:         "SHA2",
+			Security:     256,
+			FileKeystore: &factory.FileKeystoreOpts{KeyStorePath: filepath.Join(mspDir, "keystore")},
+		},
+	})
+	require.NoError(t, err)
+	localMSP, err := fabricmsp.New(&fabricmsp.BCCSPNewOpts{NewBaseOpts: fabricmsp.NewBaseOpts{Version: fabricmsp.MSPv1_4_3}}, cryptoProvider)
+	require.NoError(t, err)
+	err = localMSP.Setup(conf)
+	require.NoError(t, err)
+
+	signer, err := localMSP.GetDefaultSigningIdentity()
+	require.NoError(t, err)
+	require.NoError(t, signer.Validate())
+
+	msg := []byte("hello")
+	sig, err := signer.Sign(msg)
+	require.NoError(t, err)
+	require.NoError(t, signer.Verify(msg, sig))
+	require.Error(t, signer.Verify([]byte("bye"), sig))
+}
+
 func testGenerateVerifyingMSP(t *testing.T, nodeOUs bool) {
 	caDir := filepath.Join(testDir, "ca")
 	tlsCADir := filepath.Join(testDir, "tlsca")
 	mspDir := filepath.Join(testDir, "msp")
 	// generate signing CA
-	signCA, err := ca.NewCA(caDir, testCAOrg, testCAName, testCountry, testProvince, testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode)
+	signCA, err := ca.NewCA(caDir, testCAOrg, testCAName, testCountry, testProvince, testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode, csp.ECDSA)
 	require.NoError(t, err, "Error generating CA")
 	// generate TLS CA
-	tlsCA, err := ca.NewCA(tlsCADir, testCAOrg, testCAName, testCountry, testProvince, testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode)
+	tlsCA, err := ca.NewCA(tlsCADir, testCAOrg, testCAName, testCountry, testProvince, testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode, csp.ECDSA)
 	require.NoError(t, err, "Error generating CA")
 
-	err = msp.GenerateVerifyingMSP(mspDir, signCA, tlsCA, nodeOUs)
+	err = msp.GenerateVerifyingMSP(mspDir, signCA, tlsCA, nodeOUs, csp.ECDSA)
 	require.NoError(t, err, "Failed to generate verifying MSP")
 
 	// check to see that the right files were generated/saved
@@ -159,10 +207,10 @@ func testGenerateVerifyingMSP(t *testing.T, nodeOUs bool) {
 	}
 
 	tlsCA.Name = "test/fail"
-	err = msp.GenerateVerifyingMSP(mspDir, signCA, tlsCA, nodeOUs)
+	err = msp.GenerateVerifyingMSP(mspDir, signCA, tlsCA, nodeOUs, csp.ECDSA)
 	require.Error(t, err, "Should have failed with CA name 'test/fail'")
 	signCA.Name = "test/fail"
-	err = msp.GenerateVerifyingMSP(mspDir, signCA, tlsCA, nodeOUs)
+	err = msp.GenerateVerifyingMSP(mspDir, signCA, tlsCA, nodeOUs, csp.ECDSA)
 	require.Error(t, err, "Should have failed with CA name 'test/fail'")
 	t.Log(err)
 	cleanup(testDir)
@@ -176,6 +224,68 @@ func TestGenerateVerifyingMSPWithoutNodeOU(t *testing.T) {
 	testGenerateVerifyingMSP(t, true)
 }
 
+func TestRevokeCertificates(t *testing.T) {
+	testDir := t.TempDir()
+	mspDir := filepath.Join(testDir, "msp")
+
+	signCA, err := ca.NewCA(filepath.Join(testDir, "ca"), testCAOrg, testCAName, testCountry, testProvince, testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode, csp.ECDSA)
+	require.NoError(t, err, "Error generating CA")
+	tlsCA, err := ca.NewCA(filepath.Join(testDir, "tlsca"), testCAOrg, testCAName, testCountry, testProvince, testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode, csp.ECDSA)
+	require.NoError(t, err, "Error generating CA")
+	err = msp.GenerateVerifyingMSP(mspDir, signCA, tlsCA, true, csp.ECDSA)
+	require.NoError(t, err, "Failed to generate verifying MSP")
+
+	var certs [][]byte
+	for _, name := range []string{"user0", "user1"} {
+		nodeDir := filepath.Join(testDir, name)
+		err = msp.GenerateLocalMSP(nodeDir, name, nil, signCA, tlsCA, msp.CLIENT, true, csp.ECDSA)
+		require.NoError(t, err, "Failed to generate local MSP")
+		cert, err := ioutil.ReadFile(filepath.Join(nodeDir, "msp", "signcerts", name+"-cert.pem"))
+		require.NoError(t, err)
+		certs = append(certs, cert)
+	}
+
+	validate := func(cert []byte) error {
+		conf, err := fabricmsp.GetVerifyingMspConfig(mspDir, "SampleOrg", "bccsp")
+		require.NoError(t, err)
+		cryptoProvider, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewDummyKeyStore())
+		require.NoError(t, err)
+		verifyingMSP, err := fabricmsp.New(&fabricmsp.BCCSPNewOpts{NewBaseOpts: fabricmsp.NewBaseOpts{Version: fabricmsp.MSPv1_4_3}}, cryptoProvider)
+		require.NoError(t, err)
+		require.NoError(t, verifyingMSP.Setup(conf))
+
+		id, err := verifyingMSP.DeserializeIdentity(protoutil.MarshalOrPanic(&mspproto.SerializedIdentity{Mspid: "SampleOrg", IdBytes: cert}))
+		require.NoError(t, err)
+		return verifyingMSP.Validate(id)
+	}
+	require.NoError(t, validate(certs[0]))
+	require.NoError(t, validate(certs[1]))
+
+	user1Cert, err := ca.LoadCertificateECDSA(filepath.Join(testDir, "user1", "msp", "signcerts"))
+	require.NoError(t, err)
+	crl, err := msp.RevokeCertificates(mspDir, signCA, []*x509.Certificate{user1Cert})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), crl.Number.Int64())
+	require.True(t, checkForFile(filepath.Join(mspDir, "crls", testCAName+"-crl.pem")))
+
+	require.NoError(t, validate(certs[0]))
+	require.EqualError(t, validate(certs[1]), "could not validate identity against certification chain: The certificate has been revoked")
+
+	// revoking again updates the existing CRL
+	user0Cert, err := ca.LoadCertificateECDSA(filepath.Join(testDir, "user0", "msp", "signcerts"))
+	require.NoError(t, err)
+	crl, err = msp.RevokeCertificates(mspDir, signCA, []*x509.Certificate{user0Cert, user1Cert})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), crl.Number.Int64())
+	require.Len(t, crl.RevokedCertificates, 2)
+	require.Error(t, validate(certs[0]))
+
+	err = ioutil.WriteFile(filepath.Join(mspDir, "crls", testCAName+"-crl.pem"), []byte("garbage"), 0o644)
+	require.NoError(t, err)
+	_, err = msp.RevokeCertificates(mspDir, signCA, []*x509.Certificate{user0Cert})
+	require.EqualError(t, err, filepath.Join(mspDir, "crls", testCAName+"-crl.pem")+": wrong PEM encoding")
+}
+
 func TestExportConfig(t *testing.T) {
 	path := filepath.Join(testDir, "export-test")
 	configFile := filepath.Join(path, "config.yaml")
@@ -0,0 +1,414 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package export
+
+import (
+	"bufio"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/stateleveldb"
+	"github.com/hyperledger/fabric/internal/fileutil"
+	"github.com/pkg/errors"
+)
+
+const (
+	// JSONFormat - Output format with one json object per line for each exported record
+	JSONFormat = "json"
+	// CSVFormat - Output format with a header row followed by one row for each exported record
+	CSVFormat = "csv"
+
+	ledgersDataDirName = "ledgersData"
+	nsJoiner           = "$$"
+	pvtDataPrefix      = "p"
+	hashDataPrefix     = "h"
+
+	// base64Encoding - Encoding of the values which are not valid UTF-8
+	base64Encoding = "base64"
+)
+
+var csvHeader = []string{"namespace", "collection", "key", "value", "blockNum", "txNum", "hashed", "valueEncoding"}
+
+// Filter - Selects the world state records to export
+// An empty Namespace selects all the namespaces and an empty KeyPrefix selects all the keys.
+// The KeyPrefix does not apply to the hashed private data, as their keys are hashes.
+type Filter struct {
+	Namespace        string
+	KeyPrefix        string
+	IncludePvtHashes bool
+}
+
+// ExportSnapshot - Exports the world state records of a channel snapshot to a file in outputDirLoc
+// The public state is read from the snapshot's public state files and, if requested, the hashes of the
+// private data from the private state hashes files.
+// Returns the count of exported records and the path of the output file
+func ExportSnapshot(snapshotDir string, outputDirLoc string, format string, filter *Filter) (count int, outputFilePath string, err error) {
+	mdata, err := readMetadata(filepath.Join(snapshotDir, kvledger.SnapshotSignableMetadataFileName))
+	if err != nil {
+		return 0, "", err
+	}
+
+	writer, outputFilePath, err := newRecordWriter(outputDirLoc, mdata.ChannelName, mdata.LastBlockNumber, format)
+	if err != nil {
+		return 0, "", err
+	}
+	defer func() {
+		if err != nil {
+			writer.abort()
+		}
+	}()
+
+	count, err = exportSnapshotFile(snapshotDir, privacyenabledstate.PubStateDataFileName,
+		privacyenabledstate.PubStateMetadataFileName, filter, writer)
+	if err != nil {
+		return 0, "", err
+	}
+
+	if filter.IncludePvtHashes {
+		pvtCount, err := exportSnapshotFile(snapshotDir, privacyenabledstate.PvtStateHashesFileName,
+			privacyenabledstate.PvtStateHashesMetadataFileName, filter, writer)
+		if err != nil {
+			return 0, "", err
+		}
+		count += pvtCount
+	}
+
+	if err = writer.close(); err != nil {
+		return 0, "", err
+	}
+	return count, outputFilePath, nil
+}
+
+// ExportPeer - Exports the world state records of a channel from the state LevelDB of a peer to a file in outputDirLoc
+// The peer must be stopped, as the state database cannot be opened by two processes at once. The peers that use
+// CouchDB as state database are not supported.
+// Returns the count of exported records and the path of the output file
+func ExportPeer(fsPath string, channelID string, outputDirLoc string, format string, filter *Filter) (count int, outputFilePath string, err error) {
+	ledgersDataDir := filepath.Join(fsPath, ledgersDataDirName)
+	stateDBPath := kvledger.StateDBPath(ledgersDataDir)
+	exists, err := fileutil.DirExists(stateDBPath)
+	if err != nil {
+		return 0, "", err
+	}
+	if !exists {
+		return 0, "", errors.Errorf("state database not found in %s, only the peers that use goleveldb as state database are supported. Aborting export", fsPath)
+	}
+
+	fileLock := leveldbhelper.NewFileLock(kvledger.FileLockPath(ledgersDataDir))
+	if err := fileLock.Lock(); err != nil {
+		return 0, "", errors.WithMessage(err, "the peer must be stopped before exporting its state")
+	}
+	defer fileLock.Unlock()
+
+	dbProvider, err := stateleveldb.NewVersionedDBProvider(stateDBPath)
+	if err != nil {
+		return 0, "", err
+	}
+	defer dbProvider.Close()
+
+	vdb, err := dbProvider.GetDBHandle(channelID, nil)
+	if err != nil {
+		return 0, "", err
+	}
+	savepoint, err := vdb.GetLatestSavePoint()
+	if err != nil {
+		return 0, "", err
+	}
+	if savepoint == nil {
+		return 0, "", errors.Errorf("channel %s not found in the state database. Aborting export", channelID)
+	}
+
+	itr, err := vdb.GetFullScanIterator(func(ns string) bool {
+		return !filter.selectsNamespace(ns)
+	})
+	if err != nil {
+		return 0, "", err
+	}
+	defer itr.Close()
+
+	writer, outputFilePath, err := newRecordWriter(outputDirLoc, channelID, savepoint.BlockNum, format)
+	if err != nil {
+		return 0, "", err
+	}
+	defer func() {
+		if err != nil {
+			writer.abort()
+		}
+	}()
+
+	for {
+		kv, err := itr.Next()
+		if err != nil {
+			return 0, "", err
+		}
+		if kv == nil {
+			break
+		}
+		r := newStateRecord(kv.Namespace, []byte(kv.Key), kv.Value, kv.Version.BlockNum, kv.Version.TxNum)
+		if !filter.selectsRecord(r) {
+			continue
+		}
+		if err = writer.write(r); err != nil {
+			return 0, "", err
+		}
+		count++
+	}
+
+	if err = writer.close(); err != nil {
+		return 0, "", err
+	}
+	return count, outputFilePath, nil
+}
+
+// Exports the selected records of one of the snapshot data files
+func exportSnapshotFile(snapshotDir, dataFileName, metadataFileName string, filter *Filter, writer recordWriter) (int, error) {
+	snapshotReader, err := privacyenabledstate.NewSnapshotReader(snapshotDir, dataFileName, metadataFileName)
+	if err != nil {
+		return 0, err
+	}
+	// The data file is absent when the snapshot holds no records of this kind
+	if snapshotReader == nil {
+		return 0, nil
+	}
+	defer snapshotReader.Close()
+
+	count := 0
+	for {
+		namespace, snapshotRecord, err := snapshotReader.Next()
+		if err != nil {
+			return 0, err
+		}
+		if snapshotRecord == nil {
+			return count, nil
+		}
+		if !filter.selectsNamespace(namespace) {
+			continue
+		}
+		blockNum, txNum, err := heightFromBytes(snapshotRecord.Version)
+		if err != nil {
+			return 0, errors.WithMessage(err, "error while decoding version")
+		}
+		r := newStateRecord(namespace, snapshotRecord.Key, snapshotRecord.Value, blockNum, txNum)
+		if !filter.selectsRecord(r) {
+			continue
+		}
+		if err := writer.write(r); err != nil {
+			return 0, err
+		}
+		count++
+	}
+}
+
+// stateRecord represents an exported world state record
+type stateRecord struct {
+	Namespace     string `json:"namespace"`
+	Collection    string `json:"collection,omitempty"`
+	Key           string `json:"key"`
+	Value         string `json:"value"`
+	BlockNum      uint64 `json:"blockNum"`
+	TxNum         uint64 `json:"txNum"`
+	Hashed        bool   `json:"hashed"`
+	ValueEncoding string `json:"valueEncoding,omitempty"`
+}
+
+// Creates a new stateRecord from a namespace of the state database
+// The keys and values of the hashed private data are encoded in hexadecimal and
+// the other values are encoded in base64 unless they are valid UTF-8
+func newStateRecord(namespace string, key []byte, value []byte, blockNum uint64, txNum uint64) *stateRecord {
+	r := &stateRecord{
+		Namespace: namespace,
+		Key:       string(key),
+		Value:     string(value),
+		BlockNum:  blockNum,
+		TxNum:     txNum,
+	}
+	if ns, coll, ok := splitHashedDataNs(namespace); ok {
+		r.Namespace = ns
+		r.Collection = coll
+		r.Key = hex.EncodeToString(key)
+		r.Value = hex.EncodeToString(value)
+		r.Hashed = true
+		return r
+	}
+	if !utf8.Valid(value) {
+		r.Value = base64.StdEncoding.EncodeToString(value)
+		r.ValueEncoding = base64Encoding
+	}
+	return r
+}
+
+func (r *stateRecord) toCSV() []string {
+	return []string{
+		r.Namespace,
+		r.Collection,
+		r.Key,
+		r.Value,
+		strconv.FormatUint(r.BlockNum, 10),
+		strconv.FormatUint(r.TxNum, 10),
+		strconv.FormatBool(r.Hashed),
+		r.ValueEncoding,
+	}
+}
+
+// Returns true if the records of a namespace of the state database are exported
+// The private data itself is never exported, only its hashes if requested
+func (f *Filter) selectsNamespace(namespace string) bool {
+	if strings.Contains(namespace, nsJoiner+pvtDataPrefix) {
+		return false
+	}
+	if ns, _, ok := splitHashedDataNs(namespace); ok {
+		if !f.IncludePvtHashes {
+			return false
+		}
+		namespace = ns
+	}
+	return f.Namespace == "" || f.Namespace == namespace
+}
+
+// Returns true if the record is exported
+func (f *Filter) selectsRecord(r *stateRecord) bool {
+	return r.Hashed || strings.HasPrefix(r.Key, f.KeyPrefix)
+}
+
+// Splits a namespace of the hashed private data into the chaincode namespace and the collection
+func splitHashedDataNs(namespace string) (string, string, bool) {
+	strs := strings.SplitN(namespace, nsJoiner+hashDataPrefix, 2)
+	if len(strs) != 2 {
+		return "", "", false
+	}
+	return strs[0], strs[1], true
+}
+
+// recordWriter writes the exported records to the output file
+type recordWriter interface {
+	write(r *stateRecord) error
+	close() error
+	abort()
+}
+
+// Creates the output file in outputDirLoc, named after the channel and the block number of the exported state
+func newRecordWriter(outputDirLoc string, channelName string, blockNum uint64, format string) (recordWriter, string, error) {
+	if format != JSONFormat && format != CSVFormat {
+		return nil, "", errors.Errorf("unsupported output format %s, the supported formats are %s and %s", format, JSONFormat, CSVFormat)
+	}
+	if _, err := fileutil.CreateDirIfMissing(outputDirLoc); err != nil {
+		return nil, "", err
+	}
+
+	outputFileName := fmt.Sprintf("%s_%d_state.%s", channelName, blockNum, format)
+	outputFilePath := filepath.Join(outputDirLoc, outputFileName)
+	f, err := os.OpenFile(outputFilePath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		if os.IsExist(err) {
+			return nil, "", errors.Errorf("%s already exists in %s. Choose a different location or remove the existing results. Aborting export", outputFileName, outputDirLoc)
+		}
+		return nil, "", errors.Wrapf(err, "error while creating the output file %s", outputFilePath)
+	}
+
+	w := &fileWriter{file: f, buffer: bufio.NewWriter(f)}
+	if format == JSONFormat {
+		return &jsonLinesWriter{fileWriter: w, encoder: json.NewEncoder(w.buffer)}, outputFilePath, nil
+	}
+
+	cw := &csvWriter{fileWriter: w, writer: csv.NewWriter(w.buffer)}
+	if err := cw.writer.Write(csvHeader); err != nil {
+		w.abort()
+		return nil, "", err
+	}
+	return cw, outputFilePath, nil
+}
+
+type fileWriter struct {
+	file   *os.File
+	buffer *bufio.Writer
+}
+
+func (w *fileWriter) close() error {
+	if err := w.buffer.Flush(); err != nil {
+		w.file.Close()
+		return err
+	}
+	if err := w.file.Sync(); err != nil {
+		w.file.Close()
+		return err
+	}
+	return w.file.Close()
+}
+
+// Closes and removes a partially written output file
+func (w *fileWriter) abort() {
+	w.file.Close()
+	os.Remove(w.file.Name())
+}
+
+// jsonLinesWriter writes one json object per line for each record
+type jsonLinesWriter struct {
+	*fileWriter
+	encoder *json.Encoder
+}
+
+func (w *jsonLinesWriter) write(r *stateRecord) error {
+	return w.encoder.Encode(r)
+}
+
+// csvWriter writes one csv row for each record
+type csvWriter struct {
+	*fileWriter
+	writer *csv.Writer
+}
+
+func (w *csvWriter) write(r *stateRecord) error {
+	return w.writer.Write(r.toCSV())
+}
+
+func (w *csvWriter) close() error {
+	w.writer.Flush()
+	if err := w.writer.Error(); err != nil {
+		w.file.Close()
+		return err
+	}
+	return w.fileWriter.close()
+}
+
+// Obtain the block height and transaction height of a snapshot record from its version bytes
+func heightFromBytes(b []byte) (uint64, uint64, error) {
+	blockNum, n1, err := util.DecodeOrderPreservingVarUint64(b)
+	if err != nil {
+		return 0, 0, err
+	}
+	txNum, _, err := util.DecodeOrderPreservingVarUint64(b[n1:])
+	if err != nil {
+		return 0, 0, err
+	}
+	return blockNum, txNum, nil
+}
+
+// Extracts the snapshot metadata from the provided filepath
+func readMetadata(fpath string) (*kvledger.SnapshotSignableMetadata, error) {
+	var mdata kvledger.SnapshotSignableMetadata
+
+	f, err := os.ReadFile(fpath)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(f, &mdata); err != nil {
+		return nil, err
+	}
+	return &mdata, nil
+}
@@ -0,0 +1,308 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package export
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"hash"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/hyperledger/fabric/core/ledger/ledgermgmt"
+	"github.com/hyperledger/fabric/core/ledger/ledgermgmt/ledgermgmttest"
+	"github.com/hyperledger/fabric/internal/fileutil"
+	"github.com/stretchr/testify/require"
+)
+
+var testNewHashFunc = func() (hash.Hash, error) {
+	return sha256.New(), nil
+}
+
+type testRecord struct {
+	namespace string
+	key       string
+	value     string
+	blockNum  uint64
+	txNum     uint64
+}
+
+func TestExportSnapshot(t *testing.T) {
+	keyHash := sha256.Sum256([]byte("pvtkey"))
+	valueHash := sha256.Sum256([]byte("pvtvalue"))
+	pubRecords := []*testRecord{
+		{namespace: "marbles", key: "marble1", value: "blue", blockNum: 3, txNum: 1},
+		{namespace: "marbles", key: "marble2", value: "red", blockNum: 4, txNum: 0},
+		{namespace: "marbles", key: "owner1", value: "tom", blockNum: 4, txNum: 2},
+		{namespace: "tokens", key: "marble1", value: "100", blockNum: 5, txNum: 0},
+	}
+	pvtRecords := []*testRecord{
+		{namespace: "marbles$$hcollA", key: string(keyHash[:]), value: string(valueHash[:]), blockNum: 6, txNum: 0},
+	}
+	snapshotDir := t.TempDir()
+	require.NoError(t, createSnapshot(snapshotDir, pubRecords, pvtRecords))
+
+	marble1 := `{"namespace":"marbles","key":"marble1","value":"blue","blockNum":3,"txNum":1,"hashed":false}`
+	marble2 := `{"namespace":"marbles","key":"marble2","value":"red","blockNum":4,"txNum":0,"hashed":false}`
+	owner1 := `{"namespace":"marbles","key":"owner1","value":"tom","blockNum":4,"txNum":2,"hashed":false}`
+	token1 := `{"namespace":"tokens","key":"marble1","value":"100","blockNum":5,"txNum":0,"hashed":false}`
+	pvtHash := `{"namespace":"marbles","collection":"collA","key":"` + hex.EncodeToString(keyHash[:]) +
+		`","value":"` + hex.EncodeToString(valueHash[:]) + `","blockNum":6,"txNum":0,"hashed":true}`
+
+	testCases := map[string]struct {
+		filter          *Filter
+		expectedRecords []string
+	}{
+		"all": {
+			filter:          &Filter{},
+			expectedRecords: []string{marble1, marble2, owner1, token1},
+		},
+		"namespace": {
+			filter:          &Filter{Namespace: "marbles"},
+			expectedRecords: []string{marble1, marble2, owner1},
+		},
+		"namespace-and-key-prefix": {
+			filter:          &Filter{Namespace: "marbles", KeyPrefix: "marble"},
+			expectedRecords: []string{marble1, marble2},
+		},
+		"key-prefix-with-pvt-hashes": {
+			filter:          &Filter{KeyPrefix: "marble1", IncludePvtHashes: true},
+			expectedRecords: []string{marble1, token1, pvtHash},
+		},
+		"other-namespace-with-pvt-hashes": {
+			filter:          &Filter{Namespace: "tokens", IncludePvtHashes: true},
+			expectedRecords: []string{token1},
+		},
+		"unknown-namespace": {
+			filter: &Filter{Namespace: "unknown"},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			outputDir := t.TempDir()
+			count, outputFilePath, err := ExportSnapshot(snapshotDir, outputDir, JSONFormat, testCase.filter)
+			require.NoError(t, err)
+			require.Equal(t, len(testCase.expectedRecords), count)
+			require.Equal(t, filepath.Join(outputDir, "mychannel_10_state.json"), outputFilePath)
+
+			lines := readLines(t, outputFilePath)
+			require.Len(t, lines, len(testCase.expectedRecords))
+			for i, expected := range testCase.expectedRecords {
+				require.JSONEq(t, expected, lines[i])
+			}
+		})
+	}
+
+	t.Run("csv", func(t *testing.T) {
+		outputDir := t.TempDir()
+		count, outputFilePath, err := ExportSnapshot(snapshotDir, outputDir, CSVFormat, &Filter{Namespace: "marbles", IncludePvtHashes: true})
+		require.NoError(t, err)
+		require.Equal(t, 4, count)
+		require.Equal(t, filepath.Join(outputDir, "mychannel_10_state.csv"), outputFilePath)
+		require.Equal(t, []string{
+			"namespace,collection,key,value,blockNum,txNum,hashed,valueEncoding",
+			"marbles,,marble1,blue,3,1,false,",
+			"marbles,,marble2,red,4,0,false,",
+			"marbles,,owner1,tom,4,2,false,",
+			"marbles,collA," + hex.EncodeToString(keyHash[:]) + "," + hex.EncodeToString(valueHash[:]) + ",6,0,true,",
+		}, readLines(t, outputFilePath))
+	})
+
+	t.Run("output-file-exists", func(t *testing.T) {
+		outputDir := t.TempDir()
+		_, _, err := ExportSnapshot(snapshotDir, outputDir, JSONFormat, &Filter{})
+		require.NoError(t, err)
+		_, _, err = ExportSnapshot(snapshotDir, outputDir, JSONFormat, &Filter{})
+		require.EqualError(t, err, "mychannel_10_state.json already exists in "+outputDir+
+			". Choose a different location or remove the existing results. Aborting export")
+	})
+
+	t.Run("unsupported-format", func(t *testing.T) {
+		outputDir := t.TempDir()
+		_, _, err := ExportSnapshot(snapshotDir, outputDir, "xml", &Filter{})
+		require.EqualError(t, err, "unsupported output format xml, the supported formats are json and csv")
+	})
+
+	t.Run("missing-metadata", func(t *testing.T) {
+		_, _, err := ExportSnapshot(t.TempDir(), t.TempDir(), JSONFormat, &Filter{})
+		require.ErrorContains(t, err, "no such file or directory")
+	})
+}
+
+func TestExportPeer(t *testing.T) {
+	fsPath := t.TempDir()
+	initializer := ledgermgmttest.NewInitializer(filepath.Join(fsPath, ledgersDataDirName))
+	ledgerMgr := ledgermgmt.NewLedgerMgr(initializer)
+	blkGenerator, gb := testutil.NewBlockGenerator(t, "mychannel", false)
+	lgr, err := ledgerMgr.CreateLedger("mychannel", gb)
+	require.NoError(t, err)
+
+	simulator, err := lgr.NewTxSimulator("tx1")
+	require.NoError(t, err)
+	require.NoError(t, simulator.SetState("marbles", "marble1", []byte("blue")))
+	require.NoError(t, simulator.SetState("marbles", "owner1", []byte("tom")))
+	require.NoError(t, simulator.SetState("tokens", "marble1", []byte("100")))
+	simulator.Done()
+	simRes, err := simulator.GetTxSimulationResults()
+	require.NoError(t, err)
+	pubSimBytes, err := simRes.GetPubSimulationBytes()
+	require.NoError(t, err)
+	block := blkGenerator.NextBlock([][]byte{pubSimBytes})
+	require.NoError(t, lgr.CommitLegacy(&ledger.BlockAndPvtData{Block: block}, &ledger.CommitOptions{}))
+
+	// the state database cannot be opened while the peer is running
+	_, _, err = ExportPeer(fsPath, "mychannel", t.TempDir(), JSONFormat, &Filter{})
+	require.ErrorContains(t, err, "the peer must be stopped before exporting its state")
+	ledgerMgr.Close()
+
+	outputDir := t.TempDir()
+	count, outputFilePath, err := ExportPeer(fsPath, "mychannel", outputDir, JSONFormat, &Filter{Namespace: "marbles", KeyPrefix: "marble"})
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+	require.Equal(t, filepath.Join(outputDir, "mychannel_1_state.json"), outputFilePath)
+	lines := readLines(t, outputFilePath)
+	require.Len(t, lines, 1)
+	require.JSONEq(t, `{"namespace":"marbles","key":"marble1","value":"blue","blockNum":1,"txNum":0,"hashed":false}`, lines[0])
+
+	count, outputFilePath, err = ExportPeer(fsPath, "mychannel", outputDir, CSVFormat, &Filter{})
+	require.NoError(t, err)
+	require.Equal(t, 3, count)
+	require.Equal(t, []string{
+		"namespace,collection,key,value,blockNum,txNum,hashed,valueEncoding",
+		"marbles,,marble1,blue,1,0,false,",
+		"marbles,,owner1,tom,1,0,false,",
+		"tokens,,marble1,100,1,0,false,",
+	}, readLines(t, outputFilePath))
+
+	_, _, err = ExportPeer(fsPath, "otherchannel", outputDir, JSONFormat, &Filter{})
+	require.EqualError(t, err, "channel otherchannel not found in the state database. Aborting export")
+
+	emptyFSPath := t.TempDir()
+	_, _, err = ExportPeer(emptyFSPath, "mychannel", outputDir, JSONFormat, &Filter{})
+	require.EqualError(t, err, "state database not found in "+emptyFSPath+
+		", only the peers that use goleveldb as state database are supported. Aborting export")
+}
+
+func TestExportSnapshotNonUTF8Value(t *testing.T) {
+	value := []byte{0x0a, 0x05, 0xff, 0xfe, 0x00, 0x80}
+	require.False(t, utf8.Valid(value))
+	snapshotDir := t.TempDir()
+	require.NoError(t, createSnapshot(snapshotDir, []*testRecord{
+		{namespace: "_lifecycle", key: "namespaces/fields/mycc/Sequence", value: string(value), blockNum: 3, txNum: 0},
+	}, nil))
+
+	t.Run("json", func(t *testing.T) {
+		_, outputFilePath, err := ExportSnapshot(snapshotDir, t.TempDir(), JSONFormat, &Filter{})
+		require.NoError(t, err)
+		lines := readLines(t, outputFilePath)
+		require.Len(t, lines, 1)
+
+		r := &stateRecord{}
+		require.NoError(t, json.Unmarshal([]byte(lines[0]), r))
+		require.Equal(t, "base64", r.ValueEncoding)
+		decoded, err := base64.StdEncoding.DecodeString(r.Value)
+		require.NoError(t, err)
+		require.Equal(t, value, decoded)
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		_, outputFilePath, err := ExportSnapshot(snapshotDir, t.TempDir(), CSVFormat, &Filter{})
+		require.NoError(t, err)
+		f, err := os.Open(outputFilePath)
+		require.NoError(t, err)
+		defer f.Close()
+		rows, err := csv.NewReader(f).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, rows, 2)
+
+		require.Equal(t, "base64", rows[1][7])
+		decoded, err := base64.StdEncoding.DecodeString(rows[1][3])
+		require.NoError(t, err)
+		require.Equal(t, value, decoded)
+	})
+}
+
+func TestFilter(t *testing.T) {
+	filter := &Filter{Namespace: "marbles"}
+	require.True(t, filter.selectsNamespace("marbles"))
+	require.False(t, filter.selectsNamespace("tokens"))
+	require.False(t, filter.selectsNamespace("marbles$$pcollA"))
+	require.False(t, filter.selectsNamespace("marbles$$hcollA"))
+
+	filter.IncludePvtHashes = true
+	require.False(t, filter.selectsNamespace("marbles$$pcollA"))
+	require.True(t, filter.selectsNamespace("marbles$$hcollA"))
+	require.False(t, filter.selectsNamespace("tokens$$hcollA"))
+}
+
+// createSnapshot generates a sample snapshot of mychannel at block 10 with the passed in records
+func createSnapshot(dir string, pubStateRecords []*testRecord, pvtStateRecords []*testRecord) error {
+	filesAndHashes := map[string]string{}
+	for _, f := range []struct {
+		dataFileName, metadataFileName string
+		records                        []*testRecord
+	}{
+		{privacyenabledstate.PubStateDataFileName, privacyenabledstate.PubStateMetadataFileName, pubStateRecords},
+		{privacyenabledstate.PvtStateHashesFileName, privacyenabledstate.PvtStateHashesMetadataFileName, pvtStateRecords},
+	} {
+		writer, err := privacyenabledstate.NewSnapshotWriter(dir, f.dataFileName, f.metadataFileName, testNewHashFunc)
+		if err != nil {
+			return err
+		}
+		defer writer.Close()
+		for _, r := range f.records {
+			err := writer.AddData(r.namespace, &privacyenabledstate.SnapshotRecord{
+				Key:     []byte(r.key),
+				Value:   []byte(r.value),
+				Version: append(util.EncodeOrderPreservingVarUint64(r.blockNum), util.EncodeOrderPreservingVarUint64(r.txNum)...),
+			})
+			if err != nil {
+				return err
+			}
+		}
+		dataHash, metadataHash, err := writer.Done()
+		if err != nil {
+			return err
+		}
+		filesAndHashes[f.dataFileName] = hex.EncodeToString(dataHash)
+		filesAndHashes[f.metadataFileName] = hex.EncodeToString(metadataHash)
+	}
+
+	signableMetadata := &kvledger.SnapshotSignableMetadata{
+		ChannelName:     "mychannel",
+		LastBlockNumber: 10,
+		FilesAndHashes:  filesAndHashes,
+		StateDBType:     "goleveldb",
+	}
+	signableMetadataBytes, err := signableMetadata.ToJSON()
+	if err != nil {
+		return err
+	}
+	return fileutil.CreateAndSyncFile(filepath.Join(dir, kvledger.SnapshotSignableMetadataFileName), signableMetadataBytes, 0o444)
+}
+
+func readLines(t *testing.T, path string) []string {
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	s := strings.TrimSuffix(string(b), "\n")
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
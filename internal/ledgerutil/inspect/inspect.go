@@ -0,0 +1,314 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package inspect
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"math"
+	"path/filepath"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-config/protolator"
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/hyperledger/fabric/internal/fileutil"
+	"github.com/hyperledger/fabric/internal/pkg/txflags"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+const (
+	ledgersDataDirName = "ledgersData"
+	chainsDirName      = "chains"
+)
+
+// Filter - Selects the blocks and the transactions to print
+// EndBlock is inclusive. The transaction filters are ignored when empty; when any is set,
+// only the matching transactions, and the blocks that contain at least one of them, are printed.
+type Filter struct {
+	StartBlock     uint64
+	EndBlock       uint64
+	TxID           string
+	Chaincode      string
+	ValidationCode string
+}
+
+// NewFilter - Returns a Filter that selects all the blocks and the transactions
+func NewFilter() *Filter {
+	return &Filter{EndBlock: math.MaxUint64}
+}
+
+// PrintBlocks - Prints the decoded blocks of a channel selected by the filter as JSON
+// The blocks are read from the block files of a peer, whose file system path is fsPath, or of an orderer,
+// whose ledger location is fsPath. The block files are read directly, without opening the block index,
+// so the block store is never modified, however the peer or orderer should be stopped for consistent results.
+// Returns the count of printed blocks
+func PrintBlocks(fsPath string, channelID string, filter *Filter, w io.Writer) (int, error) {
+	if err := filter.validate(); err != nil {
+		return 0, err
+	}
+	count := 0
+	err := scanBlocks(fsPath, channelID, filter, func(block *common.Block, txs []*txRecord) error {
+		if len(txs) == 0 && filter.selectsTransactionsOnly() {
+			return nil
+		}
+		record, err := newBlockRecord(block, txs)
+		if err != nil {
+			return err
+		}
+		count++
+		return writeRecord(w, record)
+	})
+	return count, err
+}
+
+// PrintTx - Prints the decoded transactions of a channel with the given txID as JSON
+// A txID can appear in more than one block when a duplicate transaction was invalidated.
+// The block store is searched as by PrintBlocks, reading all the blocks, and the count of printed transactions is returned
+func PrintTx(fsPath string, channelID string, txID string, w io.Writer) (int, error) {
+	filter := NewFilter()
+	filter.TxID = txID
+	count := 0
+	err := scanBlocks(fsPath, channelID, filter, func(_ *common.Block, txs []*txRecord) error {
+		for _, tx := range txs {
+			count++
+			if err := writeRecord(w, tx); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return count, err
+}
+
+// Reads the blocks in the range of the filter and passes each block along with its selected transactions to process
+func scanBlocks(fsPath string, channelID string, filter *Filter, process func(*common.Block, []*txRecord) error) error {
+	blockStorageDir, err := findBlockStorageDir(fsPath)
+	if err != nil {
+		return err
+	}
+	reader, err := blkstorage.NewBlockfileReader(blockStorageDir, channelID, filter.StartBlock)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	for {
+		block, err := reader.Next()
+		if err != nil {
+			return err
+		}
+		if block == nil || block.Header.Number > filter.EndBlock {
+			return nil
+		}
+		if block.Header.Number < filter.StartBlock {
+			continue
+		}
+
+		var txs []*txRecord
+		flags := validationFlags(block)
+		for txNum, envBytes := range block.GetData().GetData() {
+			tx := newTxRecord(block.Header.Number, txNum, envBytes, flags)
+			if !filter.selectsTx(tx) {
+				continue
+			}
+			// the envelope is decoded in full only for the selected transactions
+			tx.decodeEnvelope()
+			txs = append(txs, tx)
+		}
+		if err := process(block, txs); err != nil {
+			return err
+		}
+	}
+}
+
+// Finds the block storage directory of a peer, under its file system path, or of an orderer, at its ledger location
+func findBlockStorageDir(fsPath string) (string, error) {
+	for _, dir := range []string{kvledger.BlockStorePath(filepath.Join(fsPath, ledgersDataDirName)), fsPath} {
+		exists, err := fileutil.DirExists(filepath.Join(dir, chainsDirName))
+		if err != nil {
+			return "", err
+		}
+		if exists {
+			return dir, nil
+		}
+	}
+	return "", errors.Errorf("no block store found in %s. Aborting inspection", fsPath)
+}
+
+// blockRecord represents a block in json, with its transactions decoded
+type blockRecord struct {
+	Number       uint64          `json:"number"`
+	Header       json.RawMessage `json:"header"`
+	Metadata     json.RawMessage `json:"metadata"`
+	Transactions []*txRecord     `json:"transactions"`
+}
+
+func newBlockRecord(block *common.Block, txs []*txRecord) (*blockRecord, error) {
+	header, err := deepMarshalJSON(block.Header)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "error decoding the header of block [%d]", block.Header.Number)
+	}
+	metadata, err := deepMarshalJSON(block.Metadata)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "error decoding the metadata of block [%d]", block.Header.Number)
+	}
+	if txs == nil {
+		txs = []*txRecord{}
+	}
+	return &blockRecord{
+		Number:       block.Header.Number,
+		Header:       header,
+		Metadata:     metadata,
+		Transactions: txs,
+	}, nil
+}
+
+// txRecord represents a transaction in json
+// The envelope holds the decoded payload, including the read-write sets and the endorsements of endorser
+// transactions and the config updates of config transactions. A transaction that cannot be decoded, as may
+// be the case of an invalid transaction, is reported with the decoding error instead of its envelope.
+type txRecord struct {
+	BlockNum       uint64          `json:"blockNum"`
+	TxNum          int             `json:"txNum"`
+	TxID           string          `json:"txID,omitempty"`
+	Type           string          `json:"type,omitempty"`
+	ValidationCode string          `json:"validationCode,omitempty"`
+	Chaincodes     []string        `json:"chaincodes,omitempty"`
+	Envelope       json.RawMessage `json:"envelope,omitempty"`
+	DecodeError    string          `json:"decodeError,omitempty"`
+
+	env *common.Envelope
+}
+
+func newTxRecord(blockNum uint64, txNum int, envBytes []byte, flags txflags.ValidationFlags) *txRecord {
+	tx := &txRecord{
+		BlockNum: blockNum,
+		TxNum:    txNum,
+	}
+	if txNum < len(flags) {
+		tx.ValidationCode = flags.Flag(txNum).String()
+	}
+	if err := tx.decode(envBytes); err != nil {
+		tx.DecodeError = err.Error()
+	}
+	return tx
+}
+
+func (tx *txRecord) decode(envBytes []byte) error {
+	env, err := protoutil.UnmarshalEnvelope(envBytes)
+	if err != nil {
+		return err
+	}
+	payload, err := protoutil.UnmarshalPayload(env.Payload)
+	if err != nil {
+		return err
+	}
+	if payload.Header == nil {
+		return errors.New("missing payload header")
+	}
+	chdr, err := protoutil.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		return err
+	}
+	tx.TxID = chdr.TxId
+	tx.Type = common.HeaderType(chdr.Type).String()
+
+	if common.HeaderType(chdr.Type) == common.HeaderType_ENDORSER_TRANSACTION {
+		transaction, err := protoutil.UnmarshalTransaction(payload.Data)
+		if err != nil {
+			return err
+		}
+		for _, action := range transaction.Actions {
+			_, ccAction, err := protoutil.GetPayloads(action)
+			if err != nil {
+				return err
+			}
+			if name := ccAction.GetChaincodeId().GetName(); name != "" {
+				tx.Chaincodes = append(tx.Chaincodes, name)
+			}
+		}
+	}
+
+	tx.env = env
+	return nil
+}
+
+func (tx *txRecord) decodeEnvelope() {
+	if tx.env == nil {
+		return
+	}
+	envelope, err := deepMarshalJSON(tx.env)
+	if err != nil {
+		tx.DecodeError = err.Error()
+		return
+	}
+	tx.Envelope = envelope
+}
+
+// Returns true if the filter selects the blocks that contain matching transactions only
+func (f *Filter) selectsTransactionsOnly() bool {
+	return f.TxID != "" || f.Chaincode != "" || f.ValidationCode != ""
+}
+
+func (f *Filter) selectsTx(tx *txRecord) bool {
+	if f.TxID != "" && tx.TxID != f.TxID {
+		return false
+	}
+	if f.ValidationCode != "" && tx.ValidationCode != f.ValidationCode {
+		return false
+	}
+	if f.Chaincode != "" {
+		for _, cc := range tx.Chaincodes {
+			if cc == f.Chaincode {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+func (f *Filter) validate() error {
+	if f.StartBlock > f.EndBlock {
+		return errors.Errorf("start block [%d] is greater than end block [%d]", f.StartBlock, f.EndBlock)
+	}
+	if _, ok := peer.TxValidationCode_value[f.ValidationCode]; f.ValidationCode != "" && !ok {
+		return errors.Errorf("unknown validation code %s", f.ValidationCode)
+	}
+	return nil
+}
+
+// Returns the validation flags of a block, which are absent from the blocks of an orderer
+func validationFlags(block *common.Block) txflags.ValidationFlags {
+	metadata := block.GetMetadata().GetMetadata()
+	if len(metadata) <= int(common.BlockMetadataIndex_TRANSACTIONS_FILTER) {
+		return nil
+	}
+	return txflags.ValidationFlags(metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+}
+
+func deepMarshalJSON(msg proto.Message) (json.RawMessage, error) {
+	buf := &bytes.Buffer{}
+	if err := protolator.DeepMarshalJSON(buf, msg); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func writeRecord(w io.Writer, record interface{}) error {
+	b, err := json.MarshalIndent(record, "", "\t")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(b, '\n'))
+	return err
+}
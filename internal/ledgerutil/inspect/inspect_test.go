@@ -0,0 +1,182 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package inspect
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/internal/pkg/txflags"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	sampleFileSystemDir = "../testdata/sample_prod/"
+	marblesTx5          = "ff86affc63812d2d7dda806a298ee8493f3b09c6a5941f64faff8d667609effb"
+)
+
+func TestPrintBlocks(t *testing.T) {
+	testCases := map[string]struct {
+		filter               func(f *Filter)
+		expectedBlocks       []uint64
+		expectedErr          string
+		expectedTxChaincodes []string
+	}{
+		"all": {
+			filter:         func(f *Filter) {},
+			expectedBlocks: []uint64{0, 1, 2, 3, 4, 5, 6, 7},
+		},
+		"range": {
+			filter:         func(f *Filter) { f.StartBlock, f.EndBlock = 2, 4 },
+			expectedBlocks: []uint64{2, 3, 4},
+		},
+		"range-beyond-height": {
+			filter:         func(f *Filter) { f.StartBlock = 6 },
+			expectedBlocks: []uint64{6, 7},
+		},
+		"chaincode": {
+			filter:               func(f *Filter) { f.Chaincode = "marbles" },
+			expectedBlocks:       []uint64{4, 5, 6, 7},
+			expectedTxChaincodes: []string{"marbles"},
+		},
+		"chaincode-and-range": {
+			filter:               func(f *Filter) { f.Chaincode, f.EndBlock = "_lifecycle", 2 },
+			expectedBlocks:       []uint64{1, 2},
+			expectedTxChaincodes: []string{"_lifecycle"},
+		},
+		"txid": {
+			filter:         func(f *Filter) { f.TxID = marblesTx5 },
+			expectedBlocks: []uint64{5},
+		},
+		"validation-code": {
+			filter: func(f *Filter) { f.ValidationCode = "MVCC_READ_CONFLICT" },
+		},
+		"unknown-validation-code": {
+			filter:      func(f *Filter) { f.ValidationCode = "SOMETIMES_VALID" },
+			expectedErr: "unknown validation code SOMETIMES_VALID",
+		},
+		"invalid-range": {
+			filter:      func(f *Filter) { f.StartBlock, f.EndBlock = 5, 4 },
+			expectedErr: "start block [5] is greater than end block [4]",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			filter := NewFilter()
+			testCase.filter(filter)
+			buf := &bytes.Buffer{}
+			count, err := PrintBlocks(sampleFileSystemDir, "mychannel", filter, buf)
+			if testCase.expectedErr != "" {
+				require.EqualError(t, err, testCase.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, len(testCase.expectedBlocks), count)
+
+			records := decodeRecords(t, buf, func() interface{} { return &blockRecord{} })
+			require.Len(t, records, len(testCase.expectedBlocks))
+			for i, r := range records {
+				block := r.(*blockRecord)
+				require.Equal(t, testCase.expectedBlocks[i], block.Number)
+				require.NotEmpty(t, block.Header)
+				require.NotEmpty(t, block.Metadata)
+				require.NotEmpty(t, block.Transactions)
+				for _, tx := range block.Transactions {
+					require.Equal(t, block.Number, tx.BlockNum)
+					require.Equal(t, "VALID", tx.ValidationCode)
+					require.NotEmpty(t, tx.Envelope)
+					require.Empty(t, tx.DecodeError)
+					if testCase.expectedTxChaincodes != nil {
+						require.Equal(t, testCase.expectedTxChaincodes, tx.Chaincodes)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestPrintTx(t *testing.T) {
+	buf := &bytes.Buffer{}
+	count, err := PrintTx(sampleFileSystemDir, "mychannel", marblesTx5, buf)
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+
+	records := decodeRecords(t, buf, func() interface{} { return &txRecord{} })
+	require.Len(t, records, 1)
+	tx := records[0].(*txRecord)
+	require.Equal(t, uint64(5), tx.BlockNum)
+	require.Equal(t, 0, tx.TxNum)
+	require.Equal(t, marblesTx5, tx.TxID)
+	require.Equal(t, "ENDORSER_TRANSACTION", tx.Type)
+	require.Equal(t, "VALID", tx.ValidationCode)
+	require.Equal(t, []string{"marbles"}, tx.Chaincodes)
+
+	// the read-write sets and the endorsements are decoded
+	envelope := string(tx.Envelope)
+	require.Contains(t, envelope, `"ns_rwset"`)
+	require.Contains(t, envelope, `"endorsements"`)
+	require.Contains(t, envelope, `"namespace": "marbles"`)
+
+	buf.Reset()
+	count, err = PrintTx(sampleFileSystemDir, "mychannel", "unknown", buf)
+	require.NoError(t, err)
+	require.Equal(t, 0, count)
+	require.Empty(t, buf.String())
+}
+
+func TestOrdererBlockStore(t *testing.T) {
+	// the block files of an orderer are located in <ledger location>/chains/<channel>
+	location := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(location, "chains", "mychannel"), 0o755))
+	blockfile, err := os.ReadFile(filepath.Join(sampleFileSystemDir, "ledgersData", "chains", "chains", "mychannel", "blockfile_000000"))
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(location, "chains", "mychannel", "blockfile_000000"), blockfile, 0o644))
+
+	filter := NewFilter()
+	filter.StartBlock = 7
+	buf := &bytes.Buffer{}
+	count, err := PrintBlocks(location, "mychannel", filter, buf)
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+
+	_, err = PrintBlocks(location, "otherchannel", filter, buf)
+	require.EqualError(t, err, "ledger [otherchannel] does not exist under ["+location+"]")
+
+	emptyDir := t.TempDir()
+	_, err = PrintTx(emptyDir, "mychannel", marblesTx5, buf)
+	require.EqualError(t, err, "no block store found in "+emptyDir+". Aborting inspection")
+}
+
+func TestTxRecordUndecodable(t *testing.T) {
+	flags := txflags.NewWithValues(1, peer.TxValidationCode_BAD_PAYLOAD)
+	tx := newTxRecord(3, 0, []byte("garbage"), flags)
+	require.Equal(t, "BAD_PAYLOAD", tx.ValidationCode)
+	require.NotEmpty(t, tx.DecodeError)
+	tx.decodeEnvelope()
+	require.Empty(t, tx.Envelope)
+
+	// the blocks of an orderer carry no validation flags
+	tx = newTxRecord(3, 0, []byte("garbage"), nil)
+	require.Empty(t, tx.ValidationCode)
+}
+
+func decodeRecords(t *testing.T, r io.Reader, newRecord func() interface{}) []interface{} {
+	var records []interface{}
+	decoder := json.NewDecoder(r)
+	for decoder.More() {
+		record := newRecord()
+		require.NoError(t, decoder.Decode(record))
+		records = append(records, record)
+	}
+	return records
+}
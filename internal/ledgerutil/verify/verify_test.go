@@ -12,7 +12,10 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
 	"github.com/hyperledger/fabric/internal/ledgerutil/jsonrw"
 	"github.com/stretchr/testify/require"
 )
@@ -99,3 +102,48 @@ func TestVerify(t *testing.T) {
 		})
 	}
 }
+
+func TestVerifyCompressedLedger(t *testing.T) {
+	// Copy the blocks of the sample ledger to a block store compressing the blocks
+	sampleDir := t.TempDir()
+	require.NoError(t, testutil.CopyDir(SampleGoodLedgerDir, sampleDir, false))
+	sampleProvider, err := getBlockStoreProvider(sampleDir)
+	require.NoError(t, err)
+	defer sampleProvider.Close()
+	sampleStore, err := sampleProvider.Open("mychannel")
+	require.NoError(t, err)
+	info, err := sampleStore.GetBlockchainInfo()
+	require.NoError(t, err)
+
+	fsDir := t.TempDir()
+	provider, err := blkstorage.NewProvider(
+		blkstorage.NewConfWithCompression(kvledger.BlockStorePath(filepath.Join(fsDir, "ledgersData")), 0, blkstorage.CompressionSnappy),
+		&blkstorage.IndexConfig{AttrsToIndex: []blkstorage.IndexableAttr{
+			blkstorage.IndexableAttrBlockNum,
+			blkstorage.IndexableAttrBlockHash,
+			blkstorage.IndexableAttrTxID,
+			blkstorage.IndexableAttrBlockNumTranNum,
+		}},
+		&disabled.Provider{},
+	)
+	require.NoError(t, err)
+	store, err := provider.Open("mychannel")
+	require.NoError(t, err)
+	for i := uint64(0); i < info.Height; i++ {
+		block, err := sampleStore.RetrieveBlockByNumber(i)
+		require.NoError(t, err)
+		require.NoError(t, store.AddBlock(block))
+	}
+	provider.Close()
+
+	outputDir := t.TempDir()
+	anyError, err := VerifyLedger(fsDir, outputDir)
+	require.NoError(t, err)
+	require.True(t, anyError)
+
+	actualResult, err := jsonrw.OutputFileToString(VerificationResultFile, outputDir)
+	require.NoError(t, err)
+	expectedResult, err := jsonrw.OutputFileToString("correct_blocks.json", SampleResultDir)
+	require.NoError(t, err)
+	require.Equal(t, expectedResult, actualResult)
+}
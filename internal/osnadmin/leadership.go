@@ -0,0 +1,43 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package osnadmin
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hyperledger/fabric/orderer/common/types"
+)
+
+// Reports the Raft state of an OSN in a channel it is a Raft consenter of.
+func RaftStatus(osnURL, channelID string, caCertPool *x509.CertPool, tlsClientCert tls.Certificate) (*http.Response, error) {
+	url := fmt.Sprintf("%s/participation/v1/channels/%s/raft-status", osnURL, channelID)
+
+	return httpGet(url, caCertPool, tlsClientCert)
+}
+
+// Transfers the leadership of a channel from the OSN to another consenter of the channel.
+// A zero consenterID lets the OSN pick the consenter.
+func TransferLeadership(osnURL, channelID string, consenterID uint64, caCertPool *x509.CertPool, tlsClientCert tls.Certificate) (*http.Response, error) {
+	url := fmt.Sprintf("%s/participation/v1/channels/%s/leadership-transfer", osnURL, channelID)
+	body, err := json.Marshal(&types.LeadershipTransfer{ConsenterID: consenterID})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	return httpDo(req, caCertPool, tlsClientCert)
+}
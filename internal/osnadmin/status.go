@@ -0,0 +1,28 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package osnadmin
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+)
+
+// Fetches the latest config block of a channel an OSN is a member of.
+func ConfigBlock(osnURL, channelID string, caCertPool *x509.CertPool, tlsClientCert tls.Certificate) (*http.Response, error) {
+	url := fmt.Sprintf("%s/participation/v1/channels/%s/config-block", osnURL, channelID)
+
+	return httpGet(url, caCertPool, tlsClientCert)
+}
+
+// Reports the consenters and catch-up progress of a channel an OSN is a member of.
+func ClusterStatus(osnURL, channelID string, caCertPool *x509.CertPool, tlsClientCert tls.Certificate) (*http.Response, error) {
+	url := fmt.Sprintf("%s/participation/v1/channels/%s/cluster-status", osnURL, channelID)
+
+	return httpGet(url, caCertPool, tlsClientCert)
+}
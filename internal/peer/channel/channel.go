@@ -36,7 +36,9 @@ var (
 	genesisBlockPath string
 
 	// joinbysnapshot related variables
-	snapshotPath string
+	snapshotPath  string
+	snapshotPeer  string
+	snapshotBlock uint64
 
 	// create related variables
 	channelID     string
@@ -82,6 +84,8 @@ func resetFlags() {
 
 	flags.StringVarP(&genesisBlockPath, "blockpath", "b", common.UndefinedParamValue, "Path to file containing genesis block")
 	flags.StringVarP(&snapshotPath, "snapshotpath", "", common.UndefinedParamValue, "Path to the snapshot directory")
+	flags.StringVarP(&snapshotPeer, "snapshotpeer", "", common.UndefinedParamValue, "Address of a peer of the organization from which the snapshot is fetched, instead of using --snapshotpath")
+	flags.Uint64VarP(&snapshotBlock, "snapshotblock", "", 0, "Block number of the snapshot fetched from --snapshotpeer. The most recent completed snapshot is fetched when it is 0")
 	flags.StringVarP(&channelID, "channelID", "c", common.UndefinedParamValue, "In case of a newChain command, the channel ID to create. It must be all lower case, less than 250 characters long and match the regular expression: [a-z][a-z0-9.-]*")
 	flags.StringVarP(&channelTxFile, "file", "f", "", "Configuration transaction file generated by a tool such as configtxgen for submitting to orderer")
 	flags.StringVarP(&outputBlock, "outputBlock", "", common.UndefinedParamValue, `The path to write the genesis block for the channel. (default ./<channelID>.block)`)
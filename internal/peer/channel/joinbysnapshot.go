@@ -8,6 +8,7 @@ package channel
 
 import (
 	"errors"
+	"strconv"
 
 	pb "github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/core/scc/cscc"
@@ -20,13 +21,18 @@ func joinBySnapshotCmd(cf *ChannelCmdFactory) *cobra.Command {
 	joinbysnapshotCmd := &cobra.Command{
 		Use:   "joinbysnapshot",
 		Short: "Joins the peer to a channel by the specified snapshot",
-		Long:  "Joins the peer to a channel by the specified snapshot",
+		Long: "Joins the peer to a channel by the specified snapshot. The snapshot is either read from " +
+			"the --snapshotpath directory on the peer's filesystem, or fetched by the peer from another " +
+			"peer of its organization at --snapshotpeer.",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return joinBySnapshot(cmd, args, cf)
 		},
 	}
 	flagList := []string{
 		"snapshotpath",
+		"snapshotpeer",
+		"snapshotblock",
+		"channelID",
 	}
 	attachFlags(joinbysnapshotCmd, flagList)
 
@@ -34,9 +40,15 @@ func joinBySnapshotCmd(cf *ChannelCmdFactory) *cobra.Command {
 }
 
 func joinBySnapshot(cmd *cobra.Command, args []string, cf *ChannelCmdFactory) error {
-	if snapshotPath == common.UndefinedParamValue {
+	if snapshotPath == common.UndefinedParamValue && snapshotPeer == common.UndefinedParamValue {
 		return errors.New("the required parameter 'snapshotpath' is empty. Rerun the command with --snapshotpath flag")
 	}
+	if snapshotPath != common.UndefinedParamValue && snapshotPeer != common.UndefinedParamValue {
+		return errors.New("only one of the parameters 'snapshotpath' and 'snapshotpeer' may be specified")
+	}
+	if snapshotPeer != common.UndefinedParamValue && channelID == common.UndefinedParamValue {
+		return errors.New("the required parameter 'channelID' is empty. Rerun the command with -c flag")
+	}
 
 	// Parsing of the command line is done so silence cmd usage
 	cmd.SilenceUsage = true
@@ -49,10 +61,20 @@ func joinBySnapshot(cmd *cobra.Command, args []string, cf *ChannelCmdFactory) er
 		}
 	}
 
+	input := &pb.ChaincodeInput{Args: [][]byte{[]byte(cscc.JoinChainBySnapshot), []byte(snapshotPath)}}
+	if snapshotPeer != common.UndefinedParamValue {
+		input.Args = [][]byte{
+			[]byte(cscc.JoinChainBySnapshotFromPeer),
+			[]byte(snapshotPeer),
+			[]byte(channelID),
+			[]byte(strconv.FormatUint(snapshotBlock, 10)),
+		}
+	}
+
 	spec := &pb.ChaincodeSpec{
 		Type:        pb.ChaincodeSpec_Type(pb.ChaincodeSpec_Type_value["GOLANG"]),
 		ChaincodeId: &pb.ChaincodeID{Name: "cscc"},
-		Input:       &pb.ChaincodeInput{Args: [][]byte{[]byte(cscc.JoinChainBySnapshot), []byte(snapshotPath)}},
+		Input:       input,
 	}
 
 	if err = executeJoin(cf, spec); err != nil {
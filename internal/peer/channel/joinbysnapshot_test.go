@@ -7,13 +7,16 @@ SPDX-License-Identifier: Apache-2.0
 package channel
 
 import (
+	"context"
 	"testing"
 	"time"
 
 	pb "github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/internal/peer/common"
+	"github.com/hyperledger/fabric/protoutil"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
 )
 
 func TestJoinBySnapshot(t *testing.T) {
@@ -42,6 +45,22 @@ func TestJoinBySnapshot(t *testing.T) {
 	cmd.SetArgs([]string{"--snapshotpath", "path_to_snapshot_directory"})
 	require.NoError(t, cmd.Execute())
 
+	// successful test with a snapshot fetched from a peer
+	capturingClient := &capturingEndorserClient{EndorserClient: mockEndorserClient}
+	mockCF.EndorserClient = capturingClient
+	resetFlags()
+	cmd = joinBySnapshotCmd(mockCF)
+	AddFlags(cmd)
+	cmd.SetArgs([]string{"--snapshotpeer", "peer0.org1.example.com:7051", "-c", "mychannel", "--snapshotblock", "1000"})
+	require.NoError(t, cmd.Execute())
+	require.Equal(t, [][]byte{
+		[]byte("JoinChainBySnapshotFromPeer"),
+		[]byte("peer0.org1.example.com:7051"),
+		[]byte("mychannel"),
+		[]byte("1000"),
+	}, capturingClient.args(t))
+	mockCF.EndorserClient = mockEndorserClient
+
 	// error due to missing snapshotpath
 	resetFlags()
 	cmd = joinBySnapshotCmd(mockCF)
@@ -49,6 +68,20 @@ func TestJoinBySnapshot(t *testing.T) {
 	cmd.SetArgs([]string{})
 	require.EqualError(t, cmd.Execute(), "the required parameter 'snapshotpath' is empty. Rerun the command with --snapshotpath flag")
 
+	// error due to both snapshotpath and snapshotpeer
+	resetFlags()
+	cmd = joinBySnapshotCmd(mockCF)
+	AddFlags(cmd)
+	cmd.SetArgs([]string{"--snapshotpath", "snapshot_path", "--snapshotpeer", "peer0.org1.example.com:7051", "-c", "mychannel"})
+	require.EqualError(t, cmd.Execute(), "only one of the parameters 'snapshotpath' and 'snapshotpeer' may be specified")
+
+	// error due to snapshotpeer without channelID
+	resetFlags()
+	cmd = joinBySnapshotCmd(mockCF)
+	AddFlags(cmd)
+	cmd.SetArgs([]string{"--snapshotpeer", "peer0.org1.example.com:7051"})
+	require.EqualError(t, cmd.Execute(), "the required parameter 'channelID' is empty. Rerun the command with -c flag")
+
 	// error due to EndoserClient returning bad response
 	mockResponse.Response = &pb.Response{Status: 500}
 	resetFlags()
@@ -60,6 +93,14 @@ func TestJoinBySnapshot(t *testing.T) {
 	require.EqualError(t, err, "proposal failed (err: bad proposal response 500: )")
 	require.IsType(t, ProposalFailedErr(err.Error()), err, "expected error type of ProposalFailedErr")
 
+	// error due to EndoserClient returning bad response for a snapshot fetched from a peer
+	resetFlags()
+	cmd = joinBySnapshotCmd(mockCF)
+	AddFlags(cmd)
+	cmd.SetArgs([]string{"--snapshotpeer", "peer0.org1.example.com:7051", "-c", "mychannel"})
+	err = cmd.Execute()
+	require.EqualError(t, err, "proposal failed (err: bad proposal response 500: )")
+
 	// error due to connection failure to endorser client
 	viper.Set("peer.client.connTimeout", 10*time.Millisecond)
 	resetFlags()
@@ -70,3 +111,23 @@ func TestJoinBySnapshot(t *testing.T) {
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "endorser client failed to connect to")
 }
+
+type capturingEndorserClient struct {
+	pb.EndorserClient
+	signedProposal *pb.SignedProposal
+}
+
+func (c *capturingEndorserClient) ProcessProposal(ctx context.Context, in *pb.SignedProposal, opts ...grpc.CallOption) (*pb.ProposalResponse, error) {
+	c.signedProposal = in
+	return c.EndorserClient.ProcessProposal(ctx, in, opts...)
+}
+
+func (c *capturingEndorserClient) args(t *testing.T) [][]byte {
+	proposal, err := protoutil.UnmarshalProposal(c.signedProposal.ProposalBytes)
+	require.NoError(t, err)
+	payload, err := protoutil.UnmarshalChaincodeProposalPayload(proposal.Payload)
+	require.NoError(t, err)
+	cis, err := protoutil.UnmarshalChaincodeInvocationSpec(payload.Input)
+	require.NoError(t, err)
+	return cis.ChaincodeSpec.Input.Args
+}
@@ -135,6 +135,55 @@ func TestSetBCCSPKeystorePath(t *testing.T) {
 	viper.Reset()
 }
 
+func TestBCCSPSWKeystorePasswordEnvVars(t *testing.T) {
+	t.Setenv("CORE_PEER_BCCSP_SW_FILEKEYSTORE_PASSWORD", "secret")
+	t.Setenv("CORE_PEER_BCCSP_SW_FILEKEYSTORE_KDF", "SCRYPT")
+
+	viper.Reset()
+	defer viper.Reset()
+	viper.SetConfigName(common.CmdRoot)
+	viper.SetEnvPrefix(common.CmdRoot)
+	configtest.AddDevConfigPath(nil)
+	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	viper.AutomaticEnv()
+	require.NoError(t, viper.ReadInConfig())
+
+	bccspConfig := factory.GetDefaultOpts()
+	err := common.InitBCCSPConfig(bccspConfig)
+	require.NoError(t, err)
+
+	require.NotNil(t, bccspConfig.SW.FileKeystore)
+	require.Equal(t, "secret", bccspConfig.SW.FileKeystore.Password)
+	require.Equal(t, "SCRYPT", bccspConfig.SW.FileKeystore.KDF)
+	require.Empty(t, bccspConfig.SW.FileKeystore.PasswordFile)
+}
+
+func TestBCCSPKMSEnvVars(t *testing.T) {
+	t.Setenv("CORE_PEER_BCCSP_DEFAULT", "KMS")
+	t.Setenv("CORE_PEER_BCCSP_KMS_ADDRESS", "kms:7070")
+	t.Setenv("CORE_PEER_BCCSP_KMS_TLS_ENABLED", "true")
+
+	viper.Reset()
+	defer viper.Reset()
+	viper.SetConfigName(common.CmdRoot)
+	viper.SetEnvPrefix(common.CmdRoot)
+	configtest.AddDevConfigPath(nil)
+	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	viper.AutomaticEnv()
+	require.NoError(t, viper.ReadInConfig())
+
+	bccspConfig := factory.GetDefaultOpts()
+	err := common.InitBCCSPConfig(bccspConfig)
+	require.NoError(t, err)
+
+	require.Equal(t, "KMS", bccspConfig.Default)
+	require.NotNil(t, bccspConfig.KMS)
+	require.Equal(t, "kms:7070", bccspConfig.KMS.Address)
+	require.Equal(t, 256, bccspConfig.KMS.Security)
+	require.Equal(t, 10*time.Second, bccspConfig.KMS.Timeout)
+	require.True(t, bccspConfig.KMS.TLS.Enabled)
+}
+
 func TestCheckLogLevel(t *testing.T) {
 	type args struct {
 		level string
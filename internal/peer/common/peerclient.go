@@ -13,6 +13,7 @@ import (
 	"time"
 
 	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/core/ledger/snapshotgrpc/snapshotext"
 	"github.com/hyperledger/fabric/internal/pkg/comm"
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
@@ -191,6 +192,15 @@ func (pc *PeerClient) SnapshotClient() (pb.SnapshotClient, error) {
 	return pb.NewSnapshotClient(conn), nil
 }
 
+// SnapshotExtensionsClient returns a client for the SnapshotExtensions service.
+func (pc *PeerClient) SnapshotExtensionsClient() (snapshotext.SnapshotExtensionsClient, error) {
+	conn, err := pc.CommonClient.clientConfig.Dial(pc.address)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "snapshot extensions client failed to connect to %s", pc.address)
+	}
+	return snapshotext.NewSnapshotExtensionsClient(conn), nil
+}
+
 // GetSnapshotClient returns a new snapshot client. If both the address and
 // tlsRootCertFile are not provided, the target values for the client are taken
 // from the configuration settings for "peer.address" and
@@ -203,6 +213,16 @@ func GetSnapshotClient(address, tlsRootCertFile string) (pb.SnapshotClient, erro
 	return peerClient.SnapshotClient()
 }
 
+// GetSnapshotExtensionsClient returns a new client for the SnapshotExtensions
+// service. The target values for the client are taken as for GetSnapshotClient.
+func GetSnapshotExtensionsClient(address, tlsRootCertFile string) (snapshotext.SnapshotExtensionsClient, error) {
+	peerClient, err := newPeerClient(address, tlsRootCertFile)
+	if err != nil {
+		return nil, err
+	}
+	return peerClient.SnapshotExtensionsClient()
+}
+
 func newPeerClient(address, tlsRootCertFile string) (*PeerClient, error) {
 	if address != "" {
 		return NewPeerClientForAddress(address, tlsRootCertFile)
@@ -21,6 +21,7 @@ const (
 	approveFuncName              = "ApproveChaincodeDefinitionForMyOrg"
 	commitFuncName               = "CommitChaincodeDefinition"
 	checkCommitReadinessFuncName = "CheckCommitReadiness"
+	uninstallFuncName            = "UninstallChaincode"
 )
 
 var logger = flogging.MustGetLogger("cli.lifecycle.chaincode")
@@ -36,6 +37,7 @@ func Cmd(cryptoProvider bccsp.BCCSP) *cobra.Command {
 	chaincodeCmd.AddCommand(PackageCmd(nil))
 	chaincodeCmd.AddCommand(CalculatePackageIDCmd(nil))
 	chaincodeCmd.AddCommand(InstallCmd(nil, cryptoProvider))
+	chaincodeCmd.AddCommand(UninstallCmd(nil, cryptoProvider))
 	chaincodeCmd.AddCommand(QueryInstalledCmd(nil, cryptoProvider))
 	chaincodeCmd.AddCommand(GetInstalledPackageCmd(nil, cryptoProvider))
 	chaincodeCmd.AddCommand(ApproveForMyOrgCmd(nil, cryptoProvider))
@@ -71,12 +73,13 @@ var (
 	initRequired          bool
 	output                string
 	outputDirectory       string
+	force                 bool
 )
 
 var chaincodeCmd = &cobra.Command{
 	Use:   "chaincode",
-	Short: "Perform chaincode operations: package|install|queryinstalled|getinstalledpackage|calculatepackageid|approveformyorg|queryapproved|checkcommitreadiness|commit|querycommitted",
-	Long:  "Perform chaincode operations: package|install|queryinstalled|getinstalledpackage|calculatepackageid|approveformyorg|queryapproved|checkcommitreadiness|commit|querycommitted",
+	Short: "Perform chaincode operations: package|install|uninstall|queryinstalled|getinstalledpackage|calculatepackageid|approveformyorg|queryapproved|checkcommitreadiness|commit|querycommitted",
+	Long:  "Perform chaincode operations: package|install|uninstall|queryinstalled|getinstalledpackage|calculatepackageid|approveformyorg|queryapproved|checkcommitreadiness|commit|querycommitted",
 	PersistentPreRun: func(cmd *cobra.Command, args []string) {
 		common.InitCmd(cmd, args)
 		common.SetOrdererEnv(cmd, args)
@@ -120,6 +123,7 @@ func ResetFlags() {
 	flags.BoolVarP(&initRequired, "init-required", "", false, "Whether the chaincode requires invoking 'init'")
 	flags.StringVarP(&output, "output", "O", "", "The output format for query results. Default is human-readable plain-text. json is currently the only supported format.")
 	flags.StringVarP(&outputDirectory, "output-directory", "", "", "The output directory to use when writing a chaincode install package to disk. Default is the current working directory.")
+	flags.BoolVarP(&force, "force", "", false, "Uninstall the chaincode install package even when it is referenced by a chaincode definition")
 }
 
 func attachFlags(cmd *cobra.Command, names []string) {
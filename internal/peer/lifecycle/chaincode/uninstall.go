@@ -0,0 +1,187 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/core/chaincode/lifecycle/lifecycleext"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// Uninstaller holds the dependencies needed to uninstall
+// a chaincode package from a peer.
+type Uninstaller struct {
+	Command        *cobra.Command
+	Input          *UninstallInput
+	EndorserClient EndorserClient
+	Signer         Signer
+}
+
+// UninstallInput holds the input parameters for uninstalling
+// a chaincode package from a peer.
+type UninstallInput struct {
+	PackageID string
+	Force     bool
+}
+
+// Validate checks that the required uninstall parameters
+// are provided.
+func (u *UninstallInput) Validate() error {
+	if u.PackageID == "" {
+		return errors.New("The required parameter 'package-id' is empty. Rerun the command with --package-id flag")
+	}
+
+	return nil
+}
+
+// UninstallCmd returns the cobra command for chaincode uninstall.
+func UninstallCmd(u *Uninstaller, cryptoProvider bccsp.BCCSP) *cobra.Command {
+	chaincodeUninstallCmd := &cobra.Command{
+		Use:   "uninstall",
+		Short: "Uninstall a chaincode.",
+		Long: "Uninstall a chaincode package from a peer, removing the chaincode images " +
+			"and external builder output built for it. The package is not removed while " +
+			"it is referenced by a chaincode definition approved by the peer's " +
+			"organization, whether committed or not, unless --force is specified.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if u == nil {
+				ccInput := &ClientConnectionsInput{
+					CommandName:           cmd.Name(),
+					EndorserRequired:      true,
+					PeerAddresses:         peerAddresses,
+					TLSRootCertFiles:      tlsRootCertFiles,
+					ConnectionProfilePath: connectionProfilePath,
+					TargetPeer:            targetPeer,
+					TLSEnabled:            viper.GetBool("peer.tls.enabled"),
+				}
+
+				cc, err := NewClientConnections(ccInput, cryptoProvider)
+				if err != nil {
+					return err
+				}
+
+				uninstallInput := &UninstallInput{
+					PackageID: packageID,
+					Force:     force,
+				}
+
+				// uninstall is currently only supported for one peer so just use
+				// the first endorser client
+				u = &Uninstaller{
+					Command:        cmd,
+					EndorserClient: cc.EndorserClients[0],
+					Input:          uninstallInput,
+					Signer:         cc.Signer,
+				}
+			}
+			return u.Uninstall()
+		},
+	}
+
+	flagList := []string{
+		"peerAddresses",
+		"tlsRootCertFiles",
+		"connectionProfile",
+		"targetPeer",
+		"package-id",
+		"force",
+	}
+	attachFlags(chaincodeUninstallCmd, flagList)
+
+	return chaincodeUninstallCmd
+}
+
+// Uninstall uninstalls a chaincode package from a peer.
+func (u *Uninstaller) Uninstall() error {
+	if u.Command != nil {
+		// Parsing of the command line is done so silence cmd usage
+		u.Command.SilenceUsage = true
+	}
+
+	if err := u.Input.Validate(); err != nil {
+		return err
+	}
+
+	proposal, err := u.createProposal()
+	if err != nil {
+		return errors.WithMessage(err, "failed to create proposal")
+	}
+
+	signedProposal, err := signProposal(proposal, u.Signer)
+	if err != nil {
+		return errors.WithMessage(err, "failed to create signed proposal")
+	}
+
+	proposalResponse, err := u.EndorserClient.ProcessProposal(context.Background(), signedProposal)
+	if err != nil {
+		return errors.WithMessage(err, "failed to endorse proposal")
+	}
+
+	if proposalResponse == nil {
+		return errors.New("received nil proposal response")
+	}
+
+	if proposalResponse.Response == nil {
+		return errors.New("received proposal response with nil response")
+	}
+
+	if proposalResponse.Response.Status != int32(cb.Status_SUCCESS) {
+		return errors.Errorf("proposal failed with status: %d - %s", proposalResponse.Response.Status, proposalResponse.Response.Message)
+	}
+
+	result := &lifecycleext.UninstallChaincodeResult{}
+	err = proto.Unmarshal(proposalResponse.Response.Payload, result)
+	if err != nil {
+		return errors.Wrap(err, "failed to unmarshal proposal response's response payload")
+	}
+	logger.Infof("Uninstalled chaincode code package with identifier: %s, label: %s", result.PackageId, result.Label)
+
+	return nil
+}
+
+func (u *Uninstaller) createProposal() (*pb.Proposal, error) {
+	args := &lifecycleext.UninstallChaincodeArgs{
+		PackageId: u.Input.PackageID,
+		Force:     u.Input.Force,
+	}
+
+	argsBytes, err := proto.Marshal(args)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal args")
+	}
+
+	ccInput := &pb.ChaincodeInput{
+		Args: [][]byte{[]byte(uninstallFuncName), argsBytes},
+	}
+
+	cis := &pb.ChaincodeInvocationSpec{
+		ChaincodeSpec: &pb.ChaincodeSpec{
+			ChaincodeId: &pb.ChaincodeID{Name: lifecycleName},
+			Input:       ccInput,
+		},
+	}
+
+	signerSerialized, err := u.Signer.Serialize()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to serialize identity")
+	}
+
+	proposal, _, err := protoutil.CreateProposalFromCIS(cb.HeaderType_ENDORSER_TRANSACTION, "", cis, signerSerialized)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to create ChaincodeInvocationSpec proposal")
+	}
+
+	return proposal, nil
+}
@@ -0,0 +1,221 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode_test
+
+import (
+	"github.com/golang/protobuf/proto"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/bccsp/sw"
+	"github.com/hyperledger/fabric/core/chaincode/lifecycle/lifecycleext"
+	"github.com/hyperledger/fabric/internal/peer/lifecycle/chaincode"
+	"github.com/hyperledger/fabric/internal/peer/lifecycle/chaincode/mock"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Uninstall", func() {
+	Describe("Uninstaller", func() {
+		var (
+			mockProposalResponse *pb.ProposalResponse
+			mockEndorserClient   *mock.EndorserClient
+			mockSigner           *mock.Signer
+			input                *chaincode.UninstallInput
+			uninstaller          *chaincode.Uninstaller
+		)
+
+		BeforeEach(func() {
+			mockEndorserClient = &mock.EndorserClient{}
+			mockProposalResponse = &pb.ProposalResponse{
+				Response: &pb.Response{
+					Status: 200,
+					Payload: protoutil.MarshalOrPanic(&lifecycleext.UninstallChaincodeResult{
+						PackageId: "pkg-id",
+						Label:     "pkg-label",
+					}),
+				},
+			}
+			mockEndorserClient.ProcessProposalReturns(mockProposalResponse, nil)
+
+			input = &chaincode.UninstallInput{
+				PackageID: "pkg-id",
+				Force:     true,
+			}
+
+			mockSigner = &mock.Signer{}
+
+			uninstaller = &chaincode.Uninstaller{
+				Input:          input,
+				EndorserClient: mockEndorserClient,
+				Signer:         mockSigner,
+			}
+		})
+
+		It("uninstalls the chaincode package", func() {
+			err := uninstaller.Uninstall()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(mockEndorserClient.ProcessProposalCallCount()).To(Equal(1))
+			_, signedProposal, _ := mockEndorserClient.ProcessProposalArgsForCall(0)
+			proposal, err := protoutil.UnmarshalProposal(signedProposal.ProposalBytes)
+			Expect(err).NotTo(HaveOccurred())
+			payload, err := protoutil.UnmarshalChaincodeProposalPayload(proposal.Payload)
+			Expect(err).NotTo(HaveOccurred())
+			cis, err := protoutil.UnmarshalChaincodeInvocationSpec(payload.Input)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cis.ChaincodeSpec.ChaincodeId.Name).To(Equal("_lifecycle"))
+			args := cis.ChaincodeSpec.Input.Args
+			Expect(args).To(HaveLen(2))
+			Expect(string(args[0])).To(Equal("UninstallChaincode"))
+			uninstallArgs := &lifecycleext.UninstallChaincodeArgs{}
+			err = proto.Unmarshal(args[1], uninstallArgs)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(proto.Equal(uninstallArgs, &lifecycleext.UninstallChaincodeArgs{
+				PackageId: "pkg-id",
+				Force:     true,
+			})).To(BeTrue())
+		})
+
+		Context("when the package id is not specified", func() {
+			BeforeEach(func() {
+				input.PackageID = ""
+			})
+
+			It("returns an error", func() {
+				err := uninstaller.Uninstall()
+				Expect(err).To(MatchError("The required parameter 'package-id' is empty. Rerun the command with --package-id flag"))
+			})
+		})
+
+		Context("when the signer cannot be serialized", func() {
+			BeforeEach(func() {
+				mockSigner.SerializeReturns(nil, errors.New("cafe"))
+			})
+
+			It("returns an error", func() {
+				err := uninstaller.Uninstall()
+				Expect(err).To(MatchError("failed to create proposal: failed to serialize identity: cafe"))
+			})
+		})
+
+		Context("when the signer fails to sign the proposal", func() {
+			BeforeEach(func() {
+				mockSigner.SignReturns(nil, errors.New("tea"))
+			})
+
+			It("returns an error", func() {
+				err := uninstaller.Uninstall()
+				Expect(err).To(MatchError("failed to create signed proposal: tea"))
+			})
+		})
+
+		Context("when the endorser fails to endorse the proposal", func() {
+			BeforeEach(func() {
+				mockEndorserClient.ProcessProposalReturns(nil, errors.New("latte"))
+			})
+
+			It("returns an error", func() {
+				err := uninstaller.Uninstall()
+				Expect(err).To(MatchError("failed to endorse proposal: latte"))
+			})
+		})
+
+		Context("when the endorser returns a nil proposal response", func() {
+			BeforeEach(func() {
+				mockEndorserClient.ProcessProposalReturns(nil, nil)
+			})
+
+			It("returns an error", func() {
+				err := uninstaller.Uninstall()
+				Expect(err).To(MatchError("received nil proposal response"))
+			})
+		})
+
+		Context("when the endorser returns a proposal response with a nil response", func() {
+			BeforeEach(func() {
+				mockProposalResponse.Response = nil
+			})
+
+			It("returns an error", func() {
+				err := uninstaller.Uninstall()
+				Expect(err).To(MatchError("received proposal response with nil response"))
+			})
+		})
+
+		Context("when the endorser returns a non-success status", func() {
+			BeforeEach(func() {
+				mockProposalResponse.Response = &pb.Response{
+					Status:  500,
+					Message: "capuccino",
+				}
+			})
+
+			It("returns an error", func() {
+				err := uninstaller.Uninstall()
+				Expect(err).To(MatchError("proposal failed with status: 500 - capuccino"))
+			})
+		})
+
+		Context("when the payload contains bytes that aren't an UninstallChaincodeResult", func() {
+			BeforeEach(func() {
+				mockProposalResponse.Response = &pb.Response{
+					Payload: []byte("badpayloadbadpayload"),
+					Status:  200,
+				}
+			})
+
+			It("returns an error", func() {
+				err := uninstaller.Uninstall()
+				Expect(err).To(MatchError(ContainSubstring("failed to unmarshal proposal response's response payload")))
+			})
+		})
+	})
+
+	Describe("UninstallCmd", func() {
+		var uninstallCmd *cobra.Command
+
+		BeforeEach(func() {
+			cryptoProvider, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewDummyKeyStore())
+			Expect(err).To(BeNil())
+			uninstallCmd = chaincode.UninstallCmd(nil, cryptoProvider)
+			uninstallCmd.SilenceErrors = true
+			uninstallCmd.SilenceUsage = true
+			uninstallCmd.SetArgs([]string{
+				"--package-id=test-package",
+				"--force",
+				"--peerAddresses=test1",
+				"--tlsRootCertFiles=tls1",
+			})
+		})
+
+		AfterEach(func() {
+			chaincode.ResetFlags()
+		})
+
+		It("sets up the uninstaller and attempts to uninstall the chaincode package", func() {
+			err := uninstallCmd.Execute()
+			Expect(err).To(MatchError(ContainSubstring("failed to retrieve endorser client for uninstall")))
+		})
+
+		Context("when more than one peer address is provided", func() {
+			BeforeEach(func() {
+				uninstallCmd.SetArgs([]string{
+					"--peerAddresses=test3",
+					"--peerAddresses=test4",
+				})
+			})
+
+			It("returns an error", func() {
+				err := uninstallCmd.Execute()
+				Expect(err).To(MatchError(ContainSubstring("failed to validate peer connection parameters")))
+			})
+		})
+	})
+})
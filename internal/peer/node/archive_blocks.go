@@ -0,0 +1,54 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/hyperledger/fabric/internal/peer/common"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var archiveDir string
+
+func archiveBlocksCmd() *cobra.Command {
+	nodeArchiveBlocksCmd.ResetFlags()
+	flags := nodeArchiveBlocksCmd.Flags()
+	flags.StringVarP(&channelID, "channelID", "c", common.UndefinedParamValue, "Channel whose blocks are archived.")
+	flags.Uint64VarP(&blockNumber, "blockNumber", "b", 0, "Block number below which the blocks are archived.")
+	flags.StringVarP(&archiveDir, "archiveDir", "d", "", "Directory to which the block files are moved. Defaults to ledger.blockchain.archiveDir; the block files are deleted if neither is set.")
+
+	return nodeArchiveBlocksCmd
+}
+
+var nodeArchiveBlocksCmd = &cobra.Command{
+	Use:   "archive-blocks",
+	Short: "Archives the old blocks of a channel.",
+	Long: "Archives the block files of a channel that contain only blocks below the specified block number," +
+		" by moving them to the archive directory or deleting them. When the command is executed, the peer must be offline." +
+		" The channel must have a completed snapshot at the specified block number or above." +
+		" The archived blocks can no longer be retrieved from the peer." +
+		" A running peer can archive the blocks of a channel via the /ledger/v1/archive endpoint of the operations service.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if channelID == common.UndefinedParamValue {
+			return errors.New("Must supply channel ID")
+		}
+
+		config := ledgerConfig()
+		if archiveDir == "" {
+			archiveDir = config.BlockStoreConfig.ArchiveDir
+		}
+		firstAvailableBlockNum, err := kvledger.ArchiveBlocks(config, channelID, blockNumber, archiveDir)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "First available block of channel %s: %d\n", channelID, firstAvailableBlockNum)
+		return nil
+	},
+}
@@ -0,0 +1,34 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchiveBlocksCmd(t *testing.T) {
+	t.Run("when the channelID is not supplied", func(t *testing.T) {
+		cmd := archiveBlocksCmd()
+		cmd.SetArgs([]string{})
+		err := cmd.Execute()
+		require.EqualError(t, err, "Must supply channel ID")
+	})
+
+	t.Run("when the channel has no snapshot", func(t *testing.T) {
+		testPath := t.TempDir()
+		viper.Set("peer.fileSystemPath", testPath)
+		defer viper.Reset()
+
+		cmd := archiveBlocksCmd()
+		cmd.SetArgs([]string{"-c", "ch1", "-b", "10", "-d", t.TempDir()})
+		err := cmd.Execute()
+		require.EqualError(t, err, "cannot archive blocks of ledger [ch1], no completed snapshot found")
+	})
+}
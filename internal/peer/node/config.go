@@ -8,10 +8,12 @@ package node
 
 import (
 	"path/filepath"
+	"strings"
 	"time"
 
 	coreconfig "github.com/hyperledger/fabric/core/config"
 	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/spf13/cast"
 	"github.com/spf13/viper"
 )
 
@@ -68,11 +70,28 @@ func ledgerConfig() *ledger.Config {
 		HistoryDBConfig: &ledger.HistoryDBConfig{
 			Enabled: viper.GetBool("ledger.history.enableHistoryDatabase"),
 		},
+		BlockStoreConfig: &ledger.BlockStoreConfig{
+			Compression: viper.GetString("ledger.blockchain.compression"),
+			ArchiveDir:  coreconfig.GetPath("ledger.blockchain.archiveDir"),
+		},
 		SnapshotsConfig: &ledger.SnapshotsConfig{
-			RootDir: snapshotsRootDir,
+			RootDir:  snapshotsRootDir,
+			Schedule: snapshotSchedule(viper.GetStringMap("ledger.snapshots.schedule")),
 		},
 	}
 
+	if channelSchedules := viper.GetStringMap("ledger.snapshots.channelSchedules"); len(channelSchedules) != 0 {
+		conf.SnapshotsConfig.ChannelSchedules = map[string]*ledger.SnapshotSchedule{}
+		for channelID, values := range channelSchedules {
+			schedule := snapshotSchedule(cast.ToStringMap(values))
+			if schedule == nil {
+				// an empty channel schedule disables the periodic snapshots of the channel
+				schedule = &ledger.SnapshotSchedule{}
+			}
+			conf.SnapshotsConfig.ChannelSchedules[channelID] = schedule
+		}
+	}
+
 	if conf.StateDBConfig.StateDatabase == ledger.CouchDB {
 		conf.StateDBConfig.CouchDB = &ledger.CouchDBConfig{
 			Address:               viper.GetString("ledger.state.couchDBConfig.couchDBAddress"),
@@ -90,3 +109,23 @@ func ledgerConfig() *ledger.Config {
 	}
 	return conf
 }
+
+// snapshotSchedule returns the periodic snapshot schedule configured by the given values,
+// or nil if none of its values is set
+func snapshotSchedule(values map[string]interface{}) *ledger.SnapshotSchedule {
+	schedule := &ledger.SnapshotSchedule{}
+	for key, value := range values {
+		switch strings.ToLower(key) {
+		case "blockinterval":
+			schedule.BlockInterval = cast.ToUint64(value)
+		case "timeinterval":
+			schedule.TimeInterval = cast.ToDuration(value)
+		case "retain":
+			schedule.Retain = cast.ToUint(value)
+		}
+	}
+	if schedule.BlockInterval == 0 && schedule.TimeInterval == 0 && schedule.Retain == 0 {
+		return nil
+	}
+	return schedule
+}
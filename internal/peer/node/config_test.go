@@ -44,6 +44,9 @@ func TestLedgerConfig(t *testing.T) {
 				HistoryDBConfig: &ledger.HistoryDBConfig{
 					Enabled: false,
 				},
+				BlockStoreConfig: &ledger.BlockStoreConfig{
+					Compression: "",
+				},
 				SnapshotsConfig: &ledger.SnapshotsConfig{
 					RootDir: "/peerfs/snapshots",
 				},
@@ -91,6 +94,9 @@ func TestLedgerConfig(t *testing.T) {
 				HistoryDBConfig: &ledger.HistoryDBConfig{
 					Enabled: false,
 				},
+				BlockStoreConfig: &ledger.BlockStoreConfig{
+					Compression: "",
+				},
 				SnapshotsConfig: &ledger.SnapshotsConfig{
 					RootDir: "/peerfs/snapshots",
 				},
@@ -117,6 +123,8 @@ func TestLedgerConfig(t *testing.T) {
 				"ledger.pvtdataStore.purgedKeyAuditLogging":               false,
 				"ledger.pvtdataStore.deprioritizedDataReconcilerInterval": "180m",
 				"ledger.history.enableHistoryDatabase":                    true,
+				"ledger.blockchain.compression":                           "snappy",
+				"ledger.blockchain.archiveDir":                            "/peerfs/archivedBlocks",
 				"ledger.snapshots.rootDir":                                "/peerfs/customLocationForsnapshots",
 			},
 			expected: &ledger.Config{
@@ -147,6 +155,10 @@ func TestLedgerConfig(t *testing.T) {
 				HistoryDBConfig: &ledger.HistoryDBConfig{
 					Enabled: true,
 				},
+				BlockStoreConfig: &ledger.BlockStoreConfig{
+					Compression: "snappy",
+					ArchiveDir:  "/peerfs/archivedBlocks",
+				},
 				SnapshotsConfig: &ledger.SnapshotsConfig{
 					RootDir: "/peerfs/customLocationForsnapshots",
 				},
@@ -165,3 +177,39 @@ func TestLedgerConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestLedgerConfigSnapshotSchedules(t *testing.T) {
+	defer viper.Reset()
+	viper.Set("peer.fileSystemPath", "/peerfs")
+	viper.Set("ledger.snapshots.schedule", map[string]interface{}{
+		"blockInterval": 1000,
+		"timeInterval":  "24h",
+		"retain":        3,
+	})
+	viper.Set("ledger.snapshots.channelSchedules", map[string]interface{}{
+		"channel1": map[string]interface{}{
+			"blockInterval": 50,
+		},
+		"channel2": map[string]interface{}{},
+	})
+
+	conf := ledgerConfig()
+	require.Equal(t,
+		&ledger.SnapshotsConfig{
+			RootDir: "/peerfs/snapshots",
+			Schedule: &ledger.SnapshotSchedule{
+				BlockInterval: 1000,
+				TimeInterval:  24 * time.Hour,
+				Retain:        3,
+			},
+			ChannelSchedules: map[string]*ledger.SnapshotSchedule{
+				"channel1": {BlockInterval: 50},
+				"channel2": {},
+			},
+		},
+		conf.SnapshotsConfig,
+	)
+	require.Equal(t, &ledger.SnapshotSchedule{BlockInterval: 50}, conf.SnapshotsConfig.ScheduleForChannel("channel1"))
+	require.Equal(t, &ledger.SnapshotSchedule{}, conf.SnapshotsConfig.ScheduleForChannel("channel2"))
+	require.Equal(t, conf.SnapshotsConfig.Schedule, conf.SnapshotsConfig.ScheduleForChannel("channel3"))
+}
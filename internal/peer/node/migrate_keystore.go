@@ -0,0 +1,139 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hyperledger/fabric/bccsp/factory"
+	coreconfig "github.com/hyperledger/fabric/core/config"
+	"github.com/hyperledger/fabric/internal/peer/common"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/mitchellh/mapstructure"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func migrateKeystoreCmd() *cobra.Command {
+	var from, to, output string
+	var skis []string
+
+	cmd := &cobra.Command{
+		Use:   "migrate-keystore",
+		Short: "Copies private keys to a different BCCSP.",
+		Long: "Copies the private keys of a file based SW key store to the BCCSP configured in the file passed with --to," +
+			" typically a PKCS11 token. The source is the BCCSP configured in the file passed with --from, or the BCCSP of the" +
+			" peer when not specified. Both files hold a BCCSP section with the same structure as peer.BCCSP in core.yaml." +
+			" Each key is verified with a sign/verify round trip through the target BCCSP.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if to == "" {
+				return errors.New("the target BCCSP configuration must be provided with --to")
+			}
+			cmd.SilenceUsage = true
+
+			source := &factory.FactoryOpts{}
+			if from == "" {
+				if err := common.InitBCCSPConfig(source); err != nil {
+					return err
+				}
+				// As for the local MSP, the key store defaults to the keystore folder
+				keystoreDir := filepath.Join(coreconfig.GetPath("peer.mspConfigPath"), "keystore")
+				source = msp.SetupBCCSPKeystoreConfig(source, keystoreDir)
+			} else if err := loadBCCSPConfig(from, source); err != nil {
+				return err
+			}
+			target := &factory.FactoryOpts{}
+			if err := loadBCCSPConfig(to, target); err != nil {
+				return err
+			}
+
+			report, err := factory.MigrateKeys(source, target, skis...)
+			if err != nil {
+				return err
+			}
+			printMigrationReport(cmd.OutOrStdout(), report)
+
+			if output != "" {
+				raw, err := json.MarshalIndent(report, "", "  ")
+				if err != nil {
+					return errors.Wrap(err, "failed marshaling report")
+				}
+				if err := os.WriteFile(output, raw, 0o644); err != nil {
+					return errors.Wrap(err, "failed writing report")
+				}
+			}
+
+			if failed := report.Failed(); failed > 0 {
+				return errors.Errorf("%d of %d keys failed migration", failed, len(report.Keys))
+			}
+			return nil
+		},
+	}
+	flags := cmd.Flags()
+	flags.StringVar(&from, "from", "", "Path of the file holding the source BCCSP configuration.")
+	flags.StringVar(&to, "to", "", "Path of the file holding the target BCCSP configuration.")
+	flags.StringSliceVar(&skis, "ski", nil, "Hex encoded SKI of a key to migrate. All keys are migrated when not specified.")
+	flags.StringVarP(&output, "output", "o", "", "Path of the file the JSON report is written to.")
+
+	return cmd
+}
+
+// loadBCCSPConfig decodes the BCCSP section of the YAML file at path.
+// Relative paths are resolved against the directory of the file.
+func loadBCCSPConfig(path string, opts *factory.FactoryOpts) error {
+	v := viper.New()
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil {
+		return errors.Wrapf(err, "failed reading BCCSP configuration %s", path)
+	}
+	subv := v.Sub("BCCSP")
+	if subv == nil {
+		return errors.Errorf("no BCCSP configuration found in %s", path)
+	}
+
+	decodeOpts := viper.DecodeHook(mapstructure.ComposeDecodeHookFunc(
+		mapstructure.StringToTimeDurationHookFunc(),
+		mapstructure.StringToSliceHookFunc(","),
+		factory.StringToKeyIds(),
+	))
+	if err := subv.Unmarshal(opts, decodeOpts); err != nil {
+		return errors.WithMessagef(err, "could not decode BCCSP configuration %s", path)
+	}
+
+	if opts.SW != nil && opts.SW.FileKeystore != nil {
+		// The key store path is configured as KeyStore, as in core.yaml
+		if opts.SW.FileKeystore.KeyStorePath == "" {
+			opts.SW.FileKeystore.KeyStorePath = subv.GetString("SW.FileKeyStore.KeyStore")
+		}
+		base := filepath.Dir(path)
+		if opts.SW.FileKeystore.KeyStorePath != "" {
+			coreconfig.TranslatePathInPlace(base, &opts.SW.FileKeystore.KeyStorePath)
+		}
+		if opts.SW.FileKeystore.PasswordFile != "" {
+			coreconfig.TranslatePathInPlace(base, &opts.SW.FileKeystore.PasswordFile)
+		}
+	}
+
+	return nil
+}
+
+func printMigrationReport(w io.Writer, report *factory.KeyMigrationReport) {
+	fmt.Fprintf(w, "Migrated keys from %s to %s:\n", report.Source, report.Target)
+	for _, k := range report.Keys {
+		line := []string{k.SKI, k.Algorithm, k.Status}
+		if k.Error != "" {
+			line = append(line, k.Error)
+		}
+		fmt.Fprintf(w, "  %s\n", strings.Join(line, "\t"))
+	}
+}
@@ -0,0 +1,101 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/factory"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrateKeystoreCmd(t *testing.T) {
+	dir := t.TempDir()
+	sourceConfig := filepath.Join(dir, "source.yaml")
+	err := os.WriteFile(sourceConfig, []byte(`
+BCCSP:
+  Default: SW
+  SW:
+    Hash: SHA2
+    Security: 256
+    FileKeyStore:
+      KeyStore: source
+`), 0o600)
+	require.NoError(t, err)
+	targetConfig := filepath.Join(dir, "target.yaml")
+	err = os.WriteFile(targetConfig, []byte(`
+BCCSP:
+  Default: SW
+  SW:
+    Hash: SHA2
+    Security: 256
+    FileKeyStore:
+      KeyStore: target
+      PasswordFile: password
+`), 0o600)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "password"), []byte("passwd"), 0o600))
+
+	source := &factory.FactoryOpts{}
+	require.NoError(t, loadBCCSPConfig(sourceConfig, source))
+	require.Equal(t, filepath.Join(dir, "source"), source.SW.FileKeystore.KeyStorePath)
+	csp, err := factory.GetBCCSPFromOpts(source)
+	require.NoError(t, err)
+	k, err := csp.KeyGen(&bccsp.ECDSAP256KeyGenOpts{})
+	require.NoError(t, err)
+	ski := hex.EncodeToString(k.SKI())
+
+	t.Run("Success", func(t *testing.T) {
+		output := filepath.Join(dir, "report.json")
+		cmd := migrateKeystoreCmd()
+		buf := &bytes.Buffer{}
+		cmd.SetOut(buf)
+		cmd.SetArgs([]string{"--from", sourceConfig, "--to", targetConfig, "-o", output})
+		require.NoError(t, cmd.Execute())
+		require.Contains(t, buf.String(), ski+"\tECDSA\tmigrated")
+
+		raw, err := os.ReadFile(output)
+		require.NoError(t, err)
+		report := &factory.KeyMigrationReport{}
+		require.NoError(t, json.Unmarshal(raw, report))
+		require.Equal(t, []factory.KeyMigrationResult{{SKI: ski, Algorithm: bccsp.ECDSA, Status: factory.KeyMigrated}}, report.Keys)
+
+		_, err = os.Stat(filepath.Join(dir, "target", ski+"_sk"))
+		require.NoError(t, err)
+	})
+
+	t.Run("MissingKey", func(t *testing.T) {
+		cmd := migrateKeystoreCmd()
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetArgs([]string{"--from", sourceConfig, "--to", targetConfig, "--ski", "0102"})
+		require.EqualError(t, cmd.Execute(), "1 of 1 keys failed migration")
+	})
+
+	t.Run("MissingTarget", func(t *testing.T) {
+		cmd := migrateKeystoreCmd()
+		cmd.SetArgs([]string{"--from", sourceConfig})
+		require.EqualError(t, cmd.Execute(), "the target BCCSP configuration must be provided with --to")
+	})
+
+	t.Run("InvalidConfig", func(t *testing.T) {
+		cmd := migrateKeystoreCmd()
+		cmd.SetArgs([]string{"--from", sourceConfig, "--to", filepath.Join(dir, "missing.yaml")})
+		require.ErrorContains(t, cmd.Execute(), "failed reading BCCSP configuration")
+
+		noBCCSP := filepath.Join(dir, "empty.yaml")
+		require.NoError(t, os.WriteFile(noBCCSP, []byte("peer: {}\n"), 0o600))
+		cmd = migrateKeystoreCmd()
+		cmd.SetArgs([]string{"--from", sourceConfig, "--to", noBCCSP})
+		require.EqualError(t, cmd.Execute(), "no BCCSP configuration found in "+noBCCSP)
+	})
+}
@@ -11,6 +11,20 @@ import (
 )
 
 type PeerLedger struct {
+	ArchiveBlocksStub        func(uint64, string) (uint64, error)
+	archiveBlocksMutex       sync.RWMutex
+	archiveBlocksArgsForCall []struct {
+		arg1 uint64
+		arg2 string
+	}
+	archiveBlocksReturns struct {
+		result1 uint64
+		result2 error
+	}
+	archiveBlocksReturnsOnCall map[int]struct {
+		result1 uint64
+		result2 error
+	}
 	CancelSnapshotRequestStub        func(uint64) error
 	cancelSnapshotRequestMutex       sync.RWMutex
 	cancelSnapshotRequestArgsForCall []struct {
@@ -271,6 +285,18 @@ type PeerLedger struct {
 		result1 []uint64
 		result2 error
 	}
+	SnapshotScheduleStub        func() (*ledger.SnapshotScheduleInfo, error)
+	snapshotScheduleMutex       sync.RWMutex
+	snapshotScheduleArgsForCall []struct {
+	}
+	snapshotScheduleReturns struct {
+		result1 *ledger.SnapshotScheduleInfo
+		result2 error
+	}
+	snapshotScheduleReturnsOnCall map[int]struct {
+		result1 *ledger.SnapshotScheduleInfo
+		result2 error
+	}
 	SubmitSnapshotRequestStub        func(uint64) error
 	submitSnapshotRequestMutex       sync.RWMutex
 	submitSnapshotRequestArgsForCall []struct {
@@ -299,6 +325,70 @@ type PeerLedger struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *PeerLedger) ArchiveBlocks(arg1 uint64, arg2 string) (uint64, error) {
+	fake.archiveBlocksMutex.Lock()
+	ret, specificReturn := fake.archiveBlocksReturnsOnCall[len(fake.archiveBlocksArgsForCall)]
+	fake.archiveBlocksArgsForCall = append(fake.archiveBlocksArgsForCall, struct {
+		arg1 uint64
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("ArchiveBlocks", []interface{}{arg1, arg2})
+	fake.archiveBlocksMutex.Unlock()
+	if fake.ArchiveBlocksStub != nil {
+		return fake.ArchiveBlocksStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.archiveBlocksReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *PeerLedger) ArchiveBlocksCallCount() int {
+	fake.archiveBlocksMutex.RLock()
+	defer fake.archiveBlocksMutex.RUnlock()
+	return len(fake.archiveBlocksArgsForCall)
+}
+
+func (fake *PeerLedger) ArchiveBlocksCalls(stub func(uint64, string) (uint64, error)) {
+	fake.archiveBlocksMutex.Lock()
+	defer fake.archiveBlocksMutex.Unlock()
+	fake.ArchiveBlocksStub = stub
+}
+
+func (fake *PeerLedger) ArchiveBlocksArgsForCall(i int) (uint64, string) {
+	fake.archiveBlocksMutex.RLock()
+	defer fake.archiveBlocksMutex.RUnlock()
+	argsForCall := fake.archiveBlocksArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *PeerLedger) ArchiveBlocksReturns(result1 uint64, result2 error) {
+	fake.archiveBlocksMutex.Lock()
+	defer fake.archiveBlocksMutex.Unlock()
+	fake.ArchiveBlocksStub = nil
+	fake.archiveBlocksReturns = struct {
+		result1 uint64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PeerLedger) ArchiveBlocksReturnsOnCall(i int, result1 uint64, result2 error) {
+	fake.archiveBlocksMutex.Lock()
+	defer fake.archiveBlocksMutex.Unlock()
+	fake.ArchiveBlocksStub = nil
+	if fake.archiveBlocksReturnsOnCall == nil {
+		fake.archiveBlocksReturnsOnCall = make(map[int]struct {
+			result1 uint64
+			result2 error
+		})
+	}
+	fake.archiveBlocksReturnsOnCall[i] = struct {
+		result1 uint64
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *PeerLedger) CancelSnapshotRequest(arg1 uint64) error {
 	fake.cancelSnapshotRequestMutex.Lock()
 	ret, specificReturn := fake.cancelSnapshotRequestReturnsOnCall[len(fake.cancelSnapshotRequestArgsForCall)]
@@ -1545,6 +1635,61 @@ func (fake *PeerLedger) PendingSnapshotRequestsReturnsOnCall(i int, result1 []ui
 	}{result1, result2}
 }
 
+func (fake *PeerLedger) SnapshotSchedule() (*ledger.SnapshotScheduleInfo, error) {
+	fake.snapshotScheduleMutex.Lock()
+	ret, specificReturn := fake.snapshotScheduleReturnsOnCall[len(fake.snapshotScheduleArgsForCall)]
+	fake.snapshotScheduleArgsForCall = append(fake.snapshotScheduleArgsForCall, struct {
+	}{})
+	fake.recordInvocation("SnapshotSchedule", []interface{}{})
+	fake.snapshotScheduleMutex.Unlock()
+	if fake.SnapshotScheduleStub != nil {
+		return fake.SnapshotScheduleStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.snapshotScheduleReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *PeerLedger) SnapshotScheduleCallCount() int {
+	fake.snapshotScheduleMutex.RLock()
+	defer fake.snapshotScheduleMutex.RUnlock()
+	return len(fake.snapshotScheduleArgsForCall)
+}
+
+func (fake *PeerLedger) SnapshotScheduleCalls(stub func() (*ledger.SnapshotScheduleInfo, error)) {
+	fake.snapshotScheduleMutex.Lock()
+	defer fake.snapshotScheduleMutex.Unlock()
+	fake.SnapshotScheduleStub = stub
+}
+
+func (fake *PeerLedger) SnapshotScheduleReturns(result1 *ledger.SnapshotScheduleInfo, result2 error) {
+	fake.snapshotScheduleMutex.Lock()
+	defer fake.snapshotScheduleMutex.Unlock()
+	fake.SnapshotScheduleStub = nil
+	fake.snapshotScheduleReturns = struct {
+		result1 *ledger.SnapshotScheduleInfo
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PeerLedger) SnapshotScheduleReturnsOnCall(i int, result1 *ledger.SnapshotScheduleInfo, result2 error) {
+	fake.snapshotScheduleMutex.Lock()
+	defer fake.snapshotScheduleMutex.Unlock()
+	fake.SnapshotScheduleStub = nil
+	if fake.snapshotScheduleReturnsOnCall == nil {
+		fake.snapshotScheduleReturnsOnCall = make(map[int]struct {
+			result1 *ledger.SnapshotScheduleInfo
+			result2 error
+		})
+	}
+	fake.snapshotScheduleReturnsOnCall[i] = struct {
+		result1 *ledger.SnapshotScheduleInfo
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *PeerLedger) SubmitSnapshotRequest(arg1 uint64) error {
 	fake.submitSnapshotRequestMutex.Lock()
 	ret, specificReturn := fake.submitSnapshotRequestReturnsOnCall[len(fake.submitSnapshotRequestArgsForCall)]
@@ -1671,6 +1816,8 @@ func (fake *PeerLedger) TxIDExistsReturnsOnCall(i int, result1 bool, result2 err
 func (fake *PeerLedger) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.archiveBlocksMutex.RLock()
+	defer fake.archiveBlocksMutex.RUnlock()
 	fake.cancelSnapshotRequestMutex.RLock()
 	defer fake.cancelSnapshotRequestMutex.RUnlock()
 	fake.closeMutex.RLock()
@@ -1713,6 +1860,8 @@ func (fake *PeerLedger) Invocations() map[string][][]interface{} {
 	defer fake.newTxSimulatorMutex.RUnlock()
 	fake.pendingSnapshotRequestsMutex.RLock()
 	defer fake.pendingSnapshotRequestsMutex.RUnlock()
+	fake.snapshotScheduleMutex.RLock()
+	defer fake.snapshotScheduleMutex.RUnlock()
 	fake.submitSnapshotRequestMutex.RLock()
 	defer fake.submitSnapshotRequestMutex.RUnlock()
 	fake.txIDExistsMutex.RLock()
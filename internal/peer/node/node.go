@@ -15,7 +15,7 @@ import (
 
 const (
 	nodeFuncName = "node"
-	nodeCmdDes   = "Operate a peer node: start|reset|rollback|pause|resume|rebuild-dbs|unjoin|upgrade-dbs."
+	nodeCmdDes   = "Operate a peer node: start|reset|rollback|pause|resume|rebuild-dbs|unjoin|upgrade-dbs|migrate-keystore|archive-blocks."
 )
 
 var logger = flogging.MustGetLogger("nodeCmd")
@@ -30,6 +30,8 @@ func Cmd() *cobra.Command {
 	nodeCmd.AddCommand(rebuildDBsCmd())
 	nodeCmd.AddCommand(unjoinCmd())
 	nodeCmd.AddCommand(upgradeDBsCmd())
+	nodeCmd.AddCommand(migrateKeystoreCmd())
+	nodeCmd.AddCommand(archiveBlocksCmd())
 	return nodeCmd
 }
 
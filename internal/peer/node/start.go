@@ -69,6 +69,7 @@ import (
 	"github.com/hyperledger/fabric/core/ledger/kvledger"
 	"github.com/hyperledger/fabric/core/ledger/ledgermgmt"
 	"github.com/hyperledger/fabric/core/ledger/snapshotgrpc"
+	"github.com/hyperledger/fabric/core/ledger/snapshotgrpc/snapshotext"
 	"github.com/hyperledger/fabric/core/operations"
 	"github.com/hyperledger/fabric/core/peer"
 	"github.com/hyperledger/fabric/core/policy"
@@ -93,6 +94,7 @@ import (
 	"github.com/hyperledger/fabric/internal/peer/version"
 	"github.com/hyperledger/fabric/internal/pkg/comm"
 	"github.com/hyperledger/fabric/internal/pkg/gateway"
+	"github.com/hyperledger/fabric/internal/pkg/gateway/gatewayext"
 	"github.com/hyperledger/fabric/msp"
 	"github.com/hyperledger/fabric/msp/mgmt"
 	"github.com/hyperledger/fabric/protoutil"
@@ -154,12 +156,20 @@ func (e externalVMAdapter) Build(
 	return i, err
 }
 
+func (e externalVMAdapter) Purge(ccid string) error {
+	return e.detector.Purge(ccid)
+}
+
 type disabledDockerBuilder struct{}
 
 func (disabledDockerBuilder) Build(string, *persistence.ChaincodePackageMetadata, io.Reader) (container.Instance, error) {
 	return nil, errors.New("docker build is disabled")
 }
 
+func (disabledDockerBuilder) Purge(string) error {
+	return nil
+}
+
 type endorserChannelAdapter struct {
 	peer *peer.Peer
 }
@@ -174,6 +184,26 @@ func (e endorserChannelAdapter) Channel(channelID string) *endorser.Channel {
 	return nil
 }
 
+type channelLedgersAdapter struct {
+	peer *peer.Peer
+}
+
+func (c channelLedgersAdapter) ChannelIDs() []string {
+	var channelIDs []string
+	for _, channelInfo := range c.peer.GetChannelsInfo() {
+		channelIDs = append(channelIDs, channelInfo.ChannelId)
+	}
+	return channelIDs
+}
+
+func (c channelLedgersAdapter) NewQueryExecutor(channelID string) (ledger.QueryExecutor, error) {
+	l := c.peer.GetLedger(channelID)
+	if l == nil {
+		return nil, errors.Errorf("channel '%s' not found", channelID)
+	}
+	return l.NewQueryExecutor()
+}
+
 type custodianLauncherAdapter struct {
 	launcher      chaincode.Launcher
 	streamHandler extcc.StreamHandler
@@ -227,6 +257,23 @@ func serve(args []string) error {
 		return err
 	}
 
+	// the revocation status of identities is checked against OCSP responders
+	// only by the local MSP and the ACL checks of the peer, as the outcome
+	// depends on when the check happens and must not affect block validation
+	var ocspChecker *msp.OCSPChecker
+	if coreConfig.OCSPEnabled {
+		ocspChecker = msp.NewOCSPChecker(msp.OCSPConfig{
+			ResponderURL: coreConfig.OCSPResponderURL,
+			CacheTTL:     coreConfig.OCSPCacheTTL,
+			Timeout:      coreConfig.OCSPTimeout,
+			FailOpen:     coreConfig.OCSPFailOpen,
+		})
+		err = msp.EnableOCSP(mgmt.GetLocalMSP(factory.GetDefault()), ocspChecker)
+		if err != nil {
+			return errors.WithMessage(err, "failed to enable OCSP on the local MSP")
+		}
+	}
+
 	platformRegistry := platforms.NewRegistry(platforms.SupportedPlatforms...)
 
 	opsSystem := newOperationsSystem(coreConfig)
@@ -356,6 +403,15 @@ func serve(args []string) error {
 		aclmgmt.ResourceGetter(peerInstance.GetStableChannelConfig),
 		policyChecker,
 	)
+	if ocspChecker != nil {
+		aclProvider = aclmgmt.NewOCSPACLProvider(aclProvider, ocspChecker, func(channelID string) msp.IdentityDeserializer {
+			channel := peerInstance.Channel(channelID)
+			if channel == nil {
+				return nil
+			}
+			return channel.MSPManager()
+		})
+	}
 
 	// TODO, unfortunately, the lifecycle initialization is very unclean at the
 	// moment. This is because ccprovider.SetChaincodePath only works after
@@ -442,6 +498,7 @@ func serve(args []string) error {
 		cb.HeaderType_CONFIG: &peer.ConfigTxProcessor{},
 	}
 
+	ledgerConf := ledgerConfig()
 	peerInstance.LedgerMgr = ledgermgmt.NewLedgerMgr(
 		&ledgermgmt.Initializer{
 			CustomTxProcessors:              txProcessors,
@@ -451,11 +508,16 @@ func serve(args []string) error {
 			MetricsProvider:                 metricsProvider,
 			HealthCheckRegistry:             opsSystem,
 			StateListeners:                  []ledger.StateListener{lifecycleCache},
-			Config:                          ledgerConfig(),
+			Config:                          ledgerConf,
 			HashProvider:                    factory.GetDefault(),
 			EbMetadataProvider:              ebMetadataProvider,
 		},
 	)
+	opsSystem.RegisterHandler(
+		"/ledger/v1/archive",
+		peer.NewArchiveBlocksHandler(peerInstance, ledgerConf.BlockStoreConfig.ArchiveDir),
+		coreConfig.OperationsTLSEnabled,
+	)
 
 	peerServer, err := comm.NewGRPCServer(listenAddr, serverConfig)
 	if err != nil {
@@ -647,6 +709,8 @@ func serve(args []string) error {
 		InstalledChaincodesLister: lifecycleCache,
 		ChaincodeBuilder:          containerRouter,
 		BuildRegistry:             buildRegistry,
+		ChannelLedgers:            channelLedgersAdapter{peer: peerInstance},
+		OrgMSPID:                  mspID,
 	}
 
 	lifecycleSCC := &lifecycle.SCC{
@@ -706,6 +770,21 @@ func serve(args []string) error {
 		ccSupSrv = authenticator.Wrap(ccSupSrv)
 	}
 
+	// snapshots are fetched from the peers of the organization, whose TLS certificates
+	// are expected to be issued by the TLS root CA of this peer
+	snapshotFetcherSecOpts := deliverServiceConfig.SecOpts
+	snapshotFetcherSecOpts.ServerRootCAs = serverConfig.SecOpts.ServerRootCAs
+	snapshotFetcher := &snapshotgrpc.Fetcher{
+		Dialer: comm.ClientConfig{
+			DialTimeout: deliverServiceConfig.ConnectionTimeout,
+			KaOpts:      deliverServiceConfig.KeepaliveOptions,
+			SecOpts:     snapshotFetcherSecOpts,
+		},
+		Signer:              signingIdentity,
+		HashProvider:        factory.GetDefault(),
+		FetchedSnapshotsDir: kvledger.FetchedSnapshotsPath(ledgerConf.SnapshotsConfig.RootDir),
+	}
+
 	csccInst := cscc.New(
 		aclProvider,
 		lifecycleValidatorCommitter,
@@ -713,6 +792,7 @@ func serve(args []string) error {
 		lifecycleValidatorCommitter,
 		peerInstance,
 		factory.GetDefault(),
+		snapshotFetcher,
 	)
 	qsccInst := scc.SelfDescribingSysCC(qscc.New(aclProvider, peerInstance))
 
@@ -847,8 +927,10 @@ func serve(args []string) error {
 				coreConfig.LocalMSPID,
 				coreConfig.GatewayOptions,
 				builtinSCCs,
+				metricsProvider,
 			)
 			gatewayprotos.RegisterGatewayServer(peerServer.Server(), gatewayServer)
+			gatewayext.RegisterGatewayExtensionsServer(peerServer.Server(), gatewayServer)
 		} else {
 			logger.Warning("Discovery service must be enabled for embedded gateway")
 		}
@@ -914,8 +996,13 @@ func serve(args []string) error {
 	pb.RegisterEndorserServer(peerServer.Server(), auth)
 
 	// register the snapshot server
-	snapshotSvc := &snapshotgrpc.SnapshotService{LedgerGetter: peerInstance, ACLProvider: aclProvider}
+	snapshotSvc := &snapshotgrpc.SnapshotService{
+		LedgerGetter:     peerInstance,
+		ACLProvider:      aclProvider,
+		SnapshotsRootDir: ledgerConf.SnapshotsConfig.RootDir,
+	}
 	pb.RegisterSnapshotServer(peerServer.Server(), snapshotSvc)
+	snapshotext.RegisterSnapshotExtensionsServer(peerServer.Server(), snapshotSvc)
 
 	go func() {
 		var grpcErr error
@@ -22,7 +22,7 @@ func TestCancelRequestCmd(t *testing.T) {
 	mockSnapshotClient := &mock.SnapshotClient{}
 	mockSnapshotClient.CancelReturns(&empty.Empty{}, nil)
 	buffer := gbytes.NewBuffer()
-	mockClient := &client{mockSnapshotClient, mockSigner, buffer}
+	mockClient := &client{mockSnapshotClient, mockSigner, buffer, nil}
 
 	resetFlags()
 	cmd := cancelRequestCmd(mockClient, nil)
@@ -12,6 +12,7 @@ import (
 
 	pb "github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/core/ledger/snapshotgrpc/snapshotext"
 	"github.com/hyperledger/fabric/internal/peer/common"
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
@@ -19,9 +20,10 @@ import (
 
 // client holds client side dependency for the snapshot commands
 type client struct {
-	snapshotClient pb.SnapshotClient
-	signer         common.Signer
-	writer         io.Writer
+	snapshotClient    pb.SnapshotClient
+	signer            common.Signer
+	writer            io.Writer
+	snapshotExtClient snapshotext.SnapshotExtensionsClient
 }
 
 // newClient creates a client instance
@@ -35,15 +37,21 @@ func newClient(cryptoProvider bccsp.BCCSP) (*client, error) {
 		return nil, errors.WithMessagef(err, "failed to retrieve snapshot client")
 	}
 
+	snapshotExtClient, err := common.GetSnapshotExtensionsClient(peerAddress, tlsRootCertFile)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to retrieve snapshot extensions client")
+	}
+
 	signer, err := common.GetDefaultSigner()
 	if err != nil {
 		return nil, errors.WithMessage(err, "failed to retrieve default signer")
 	}
 
 	return &client{
-		signer:         signer,
-		snapshotClient: snapshotClient,
-		writer:         os.Stdout,
+		signer:            signer,
+		snapshotClient:    snapshotClient,
+		writer:            os.Stdout,
+		snapshotExtClient: snapshotExtClient,
 	}, nil
 }
 
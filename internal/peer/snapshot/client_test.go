@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/core/ledger/snapshotgrpc/snapshotext"
 	"github.com/hyperledger/fabric/internal/peer/common"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/require"
@@ -21,6 +22,12 @@ type snapshotClient interface {
 	pb.SnapshotClient
 }
 
+//go:generate counterfeiter -o mock/snapshot_extensions_client.go -fake-name SnapshotExtensionsClient . snapshotExtensionsClient
+
+type snapshotExtensionsClient interface {
+	snapshotext.SnapshotExtensionsClient
+}
+
 //go:generate counterfeiter -o mock/signer.go -fake-name Signer . signer
 
 type signer interface {
@@ -9,11 +9,17 @@ package snapshot
 import (
 	"context"
 	"fmt"
+	"io"
+	"strings"
+	"time"
 
 	pb "github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/core/ledger/snapshotgrpc/snapshotext"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // listPendingCmd returns the cobra command for snapshot listpending command
@@ -21,7 +27,7 @@ func listPendingCmd(cl *client, cryptoProvider bccsp.BCCSP) *cobra.Command {
 	snapshotGenerateRequestCmd := &cobra.Command{
 		Use:   "listpending",
 		Short: "List pending requests for snapshots.",
-		Long:  "List pending requests for snapshots.",
+		Long:  "List pending requests for snapshots, including the requests added by the periodic snapshot schedule of the channel, and the schedule itself.",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return listPending(cmd, cl, cryptoProvider)
 		},
@@ -73,9 +79,44 @@ func listPending(cmd *cobra.Command, cl *client, cryptoProvider bccsp.BCCSP) err
 	}
 
 	fmt.Fprintf(cl.writer, "Successfully got pending snapshot requests: %v\n", resp.BlockNumbers)
+
+	schedule, err := cl.snapshotExtClient.QuerySchedule(context.Background(), signedRequest)
+	if status.Code(err) == codes.Unimplemented {
+		// the peer predates the periodic snapshots
+		return nil
+	}
+	if err != nil {
+		return errors.WithMessage(err, "failed to query snapshot schedule")
+	}
+	printSchedule(cl.writer, schedule)
 	return nil
 }
 
+// printSchedule prints the periodic snapshot schedule, if any, and the next snapshots scheduled by it
+func printSchedule(w io.Writer, schedule *snapshotext.QueryScheduleResponse) {
+	var intervals []string
+	if schedule.BlockInterval != 0 {
+		intervals = append(intervals, fmt.Sprintf("every %d blocks", schedule.BlockInterval))
+	}
+	if schedule.TimeInterval != nil {
+		intervals = append(intervals, fmt.Sprintf("every %s", schedule.TimeInterval.AsDuration()))
+	}
+	if schedule.Retain != 0 {
+		intervals = append(intervals, fmt.Sprintf("retaining the last %d scheduled snapshots", schedule.Retain))
+	}
+	if len(intervals) == 0 {
+		return
+	}
+
+	fmt.Fprintf(w, "Snapshot schedule: %s\n", strings.Join(intervals, ", "))
+	if schedule.NextBlockNumber != 0 {
+		fmt.Fprintf(w, "Next snapshot scheduled by block interval: block %d\n", schedule.NextBlockNumber)
+	}
+	if schedule.NextTime != nil {
+		fmt.Fprintf(w, "Next snapshot scheduled by time interval: first block committed after %s\n", schedule.NextTime.AsTime().UTC().Format(time.RFC3339))
+	}
+}
+
 func validateListPending() error {
 	if channelID == "" {
 		return errors.New("the required parameter 'channelID' is empty. Rerun the command with -c flag")
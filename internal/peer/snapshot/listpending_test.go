@@ -9,11 +9,17 @@ package snapshot
 import (
 	"fmt"
 	"testing"
+	"time"
 
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/core/ledger/snapshotgrpc/snapshotext"
 	"github.com/hyperledger/fabric/internal/peer/snapshot/mock"
 	"github.com/onsi/gomega/gbytes"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestListPendingCmd(t *testing.T) {
@@ -21,8 +27,10 @@ func TestListPendingCmd(t *testing.T) {
 	mockSigner.SignReturns([]byte("snapshot-request-signature"), nil)
 	mockSnapshotClient := &mock.SnapshotClient{}
 	mockSnapshotClient.QueryPendingsReturns(&pb.QueryPendingSnapshotsResponse{BlockNumbers: []uint64{100, 200}}, nil)
+	mockSnapshotExtClient := &mock.SnapshotExtensionsClient{}
+	mockSnapshotExtClient.QueryScheduleReturns(&snapshotext.QueryScheduleResponse{}, nil)
 	buffer := gbytes.NewBuffer()
-	mockClient := &client{mockSnapshotClient, mockSigner, buffer}
+	mockClient := &client{mockSnapshotClient, mockSigner, buffer, mockSnapshotExtClient}
 
 	resetFlags()
 	cmd := listPendingCmd(mockClient, nil)
@@ -31,7 +39,37 @@ func TestListPendingCmd(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, []byte("Successfully got pending snapshot requests: [100 200]\n"), buffer.Contents())
 
+	// the schedule is printed when the channel has one
+	nextTime := time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC)
+	mockSnapshotExtClient.QueryScheduleReturns(&snapshotext.QueryScheduleResponse{
+		BlockInterval:   1000,
+		TimeInterval:    ptypes.DurationProto(24 * time.Hour),
+		Retain:          3,
+		NextBlockNumber: 2000,
+		NextTime:        &timestamp.Timestamp{Seconds: nextTime.Unix()},
+	}, nil)
+	buffer = gbytes.NewBuffer()
+	mockClient.writer = buffer
+	require.NoError(t, cmd.Execute())
+	require.Equal(t,
+		"Successfully got pending snapshot requests: [100 200]\n"+
+			"Snapshot schedule: every 1000 blocks, every 24h0m0s, retaining the last 3 scheduled snapshots\n"+
+			"Next snapshot scheduled by block interval: block 2000\n"+
+			"Next snapshot scheduled by time interval: first block committed after 2026-10-18T10:00:00Z\n",
+		string(buffer.Contents()),
+	)
+
+	// peers without the snapshot extensions only list the pending requests
+	mockSnapshotExtClient.QueryScheduleReturns(nil, status.Error(codes.Unimplemented, "unknown service"))
+	buffer = gbytes.NewBuffer()
+	mockClient.writer = buffer
+	require.NoError(t, cmd.Execute())
+	require.Equal(t, []byte("Successfully got pending snapshot requests: [100 200]\n"), buffer.Contents())
+
 	// error tests
+	mockSnapshotExtClient.QueryScheduleReturns(nil, fmt.Errorf("fake-queryschedule-error"))
+	require.EqualError(t, cmd.Execute(), "failed to query snapshot schedule: fake-queryschedule-error")
+
 	mockSnapshotClient.QueryPendingsReturns(nil, fmt.Errorf("fake-querypendings-error"))
 	require.EqualError(t, cmd.Execute(), "failed to list pending requests: fake-querypendings-error")
 
@@ -8,6 +8,7 @@ package gateway
 
 import (
 	"context"
+	"io"
 	"testing"
 	"time"
 
@@ -20,14 +21,17 @@ import (
 	ab "github.com/hyperledger/fabric-protos-go/orderer"
 	"github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/configtx"
 	"github.com/hyperledger/fabric/common/crypto/tlsgen"
 	"github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
 	"github.com/hyperledger/fabric/gossip/api"
 	"github.com/hyperledger/fabric/gossip/common"
 	gdiscovery "github.com/hyperledger/fabric/gossip/discovery"
 	"github.com/hyperledger/fabric/internal/pkg/comm"
 	"github.com/hyperledger/fabric/internal/pkg/gateway/commit"
 	"github.com/hyperledger/fabric/internal/pkg/gateway/config"
+	gx "github.com/hyperledger/fabric/internal/pkg/gateway/gatewayext"
 	ledgermocks "github.com/hyperledger/fabric/internal/pkg/gateway/ledger/mocks"
 	"github.com/hyperledger/fabric/internal/pkg/gateway/mocks"
 	idmocks "github.com/hyperledger/fabric/internal/pkg/identity/mocks"
@@ -70,6 +74,14 @@ type commitFinder interface {
 
 //go:generate counterfeiter -o mocks/chaincodeeventsserver.go --fake-name ChaincodeEventsServer github.com/hyperledger/fabric-protos-go/gateway.Gateway_ChaincodeEventsServer
 
+//go:generate counterfeiter -o mocks/submitandwaitserver.go --fake-name SubmitAndWaitServer github.com/hyperledger/fabric/internal/pkg/gateway/gatewayext.GatewayExtensions_SubmitAndWaitServer
+
+//go:generate counterfeiter -o mocks/blockeventsserver.go --fake-name BlockEventsServer github.com/hyperledger/fabric/internal/pkg/gateway/gatewayext.GatewayExtensions_BlockEventsServer
+
+//go:generate counterfeiter -o mocks/filteredblockeventsserver.go --fake-name FilteredBlockEventsServer github.com/hyperledger/fabric/internal/pkg/gateway/gatewayext.GatewayExtensions_FilteredBlockEventsServer
+
+//go:generate counterfeiter -o mocks/blockandprivatedataeventsserver.go --fake-name BlockAndPrivateDataEventsServer github.com/hyperledger/fabric/internal/pkg/gateway/gatewayext.GatewayExtensions_BlockAndPrivateDataEventsServer
+
 //go:generate counterfeiter -o mocks/aclchecker.go --fake-name ACLChecker . aclChecker
 type aclChecker interface {
 	ACLChecker
@@ -98,6 +110,12 @@ type ccChannel interface {
 	channelconfig.Channel
 }
 
+//go:generate counterfeiter -o mocks/configtxvalidator.go --fake-name ConfigtxValidator . configtxValidator
+
+type configtxValidator interface {
+	configtx.Validator
+}
+
 //go:generate counterfeiter -o mocks/channel_capabilities.go --fake-name ChannelCapabilities . ccChannelCapabilities
 
 type ccChannelCapabilities interface {
@@ -184,10 +202,16 @@ type preparedTest struct {
 	dialer         *mocks.Dialer
 	finder         *mocks.CommitFinder
 	eventsServer   *mocks.ChaincodeEventsServer
+	submitServer   *mocks.SubmitAndWaitServer
+	blockServer    *mocks.BlockEventsServer
+	filteredServer *mocks.FilteredBlockEventsServer
+	pvtDataServer  *mocks.BlockAndPrivateDataEventsServer
+	signer         *idmocks.SignerSerializer
 	policy         *mocks.ACLChecker
 	ledgerProvider *ledgermocks.Provider
 	ledger         *ledgermocks.Ledger
 	blockIterator  *mocks.ResultsIterator
+	configtx       *mocks.ConfigtxValidator
 	logLevel       string
 	logFields      []string
 }
@@ -261,6 +285,7 @@ func TestNilArgs(t *testing.T) {
 		nil,
 		nil,
 		nil,
+		NewMetrics(&disabled.Provider{}),
 	)
 	ctx := context.Background()
 
@@ -361,6 +386,28 @@ func TestNilArgs(t *testing.T) {
 
 	err = server.ChaincodeEvents(&pb.SignedChaincodeEventsRequest{}, &mocks.ChaincodeEventsServer{})
 	require.ErrorIs(t, err, status.Error(codes.InvalidArgument, "a chaincode events request is required"))
+
+	submitServer := &mocks.SubmitAndWaitServer{}
+	submitServer.RecvReturns(nil, io.EOF)
+	err = server.SubmitAndWait(submitServer)
+	require.ErrorIs(t, err, status.Error(codes.InvalidArgument, "a submit and wait request is required"))
+
+	submitServer.RecvReturns(&gx.SubmitAndWaitRequest{}, nil)
+	err = server.SubmitAndWait(submitServer)
+	require.ErrorIs(t, err, status.Error(codes.InvalidArgument, "the proposed transaction must contain a signed proposal"))
+
+	submitServer.RecvReturns(&gx.SubmitAndWaitRequest{ProposedTransaction: &peer.SignedProposal{ProposalBytes: []byte("proposal")}}, nil)
+	err = server.SubmitAndWait(submitServer)
+	require.ErrorIs(t, err, status.Error(codes.InvalidArgument, "a prepared transaction is required"))
+
+	err = server.BlockEvents(nil, &mocks.BlockEventsServer{})
+	require.ErrorIs(t, err, status.Error(codes.InvalidArgument, "a block events request is required"))
+
+	err = server.FilteredBlockEvents(&gx.SignedBlockEventsRequest{}, &mocks.FilteredBlockEventsServer{})
+	require.ErrorIs(t, err, status.Error(codes.InvalidArgument, "a block events request is required"))
+
+	err = server.BlockAndPrivateDataEvents(&gx.SignedBlockEventsRequest{}, &mocks.BlockAndPrivateDataEventsServer{})
+	require.ErrorIs(t, err, status.Error(codes.InvalidArgument, "a block events request is required"))
 }
 
 func prepareTest(t *testing.T, tt *testDef) *preparedTest {
@@ -484,6 +531,7 @@ func prepareTest(t *testing.T, tt *testDef) *preparedTest {
 		Enabled:            true,
 		EndorsementTimeout: endorsementTimeout,
 		BroadcastTimeout:   broadcastTimeout,
+		MaxSubmitAttempts:  3,
 	}
 
 	member := gdiscovery.NetworkMember{
@@ -491,6 +539,8 @@ func prepareTest(t *testing.T, tt *testDef) *preparedTest {
 		Endpoint: "localhost:7051",
 	}
 
+	mockConfigtx := &mocks.ConfigtxValidator{}
+
 	getChannelConfig := func(channel string) channelconfig.Resources {
 		cap := &mocks.ChannelCapabilities{}
 		cap.ConsensusTypeBFTReturns(tt.isBFT)
@@ -498,10 +548,11 @@ func prepareTest(t *testing.T, tt *testDef) *preparedTest {
 		c.CapabilitiesReturns(cap)
 		res := &mocks.Resources{}
 		res.ChannelConfigReturns(c)
+		res.ConfigtxValidatorReturns(mockConfigtx)
 		return res
 	}
 
-	server := newServer(localEndorser, disc, mockFinder, mockPolicy, mockLedgerProvider, member, "msp1", &comm.SecureOptions{}, options, nil, tt.ordererEndpointOverrides, getChannelConfig)
+	server := newServer(localEndorser, disc, mockFinder, mockPolicy, mockLedgerProvider, member, "msp1", &comm.SecureOptions{}, options, nil, tt.ordererEndpointOverrides, getChannelConfig, NewMetrics(&disabled.Provider{}))
 
 	dialer := &mocks.Dialer{}
 	dialer.Returns(nil, nil)
@@ -518,10 +569,16 @@ func prepareTest(t *testing.T, tt *testDef) *preparedTest {
 		dialer:         dialer,
 		finder:         mockFinder,
 		eventsServer:   &mocks.ChaincodeEventsServer{},
+		submitServer:   &mocks.SubmitAndWaitServer{},
+		blockServer:    &mocks.BlockEventsServer{},
+		filteredServer: &mocks.FilteredBlockEventsServer{},
+		pvtDataServer:  &mocks.BlockAndPrivateDataEventsServer{},
+		signer:         mockSigner,
 		policy:         mockPolicy,
 		ledgerProvider: mockLedgerProvider,
 		ledger:         mockLedger,
 		blockIterator:  mockBlockIterator,
+		configtx:       mockConfigtx,
 	}
 	if tt.postSetup != nil {
 		tt.postSetup(t, pt)
@@ -0,0 +1,190 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gateway
+
+import (
+	"io"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset"
+	peerproto "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/common/crypto"
+	"github.com/hyperledger/fabric/core/aclmgmt/resources"
+	"github.com/hyperledger/fabric/core/peer"
+	"github.com/hyperledger/fabric/internal/pkg/gateway/event"
+	gx "github.com/hyperledger/fabric/internal/pkg/gateway/gatewayext"
+	"github.com/hyperledger/fabric/internal/pkg/gateway/ledger"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// privateDataFilter returns the private data of a block, by transaction sequence in the block, that the identity of the
+// signed data is eligible to read.
+type privateDataFilter func(block *common.Block, channelID string, retriever peer.PrivateDataRetriever, signedData *protoutil.SignedData) (map[uint64]*rwset.TxPvtReadWriteSet, error)
+
+// blockSender sends a block read from the ledger of the channel to the client.
+type blockSender func(block *common.Block, channel string, ledger ledger.Ledger, signedData *protoutil.SignedData) error
+
+// BlockEvents supplies a stream of the blocks committed to the requested channel, ordered by ascending block number.
+// The client must satisfy the event/Block ACL of the channel, as for the Deliver service of the peer.
+func (gs *Server) BlockEvents(signedRequest *gx.SignedBlockEventsRequest, stream gx.GatewayExtensions_BlockEventsServer) error {
+	return gs.blockEvents(signedRequest, resources.Event_Block, func(block *common.Block, _ string, _ ledger.Ledger, _ *protoutil.SignedData) error {
+		return stream.Send(&gx.BlockEventsResponse{Block: block})
+	})
+}
+
+// FilteredBlockEvents supplies a stream of the filtered blocks committed to the requested channel, ordered by ascending
+// block number. The client must satisfy the event/FilteredBlock ACL of the channel, as for the DeliverFiltered service
+// of the peer.
+func (gs *Server) FilteredBlockEvents(signedRequest *gx.SignedBlockEventsRequest, stream gx.GatewayExtensions_FilteredBlockEventsServer) error {
+	return gs.blockEvents(signedRequest, resources.Event_FilteredBlock, func(block *common.Block, _ string, _ ledger.Ledger, _ *protoutil.SignedData) error {
+		filteredBlock, err := peer.FilteredBlock(block)
+		if err != nil {
+			return status.Errorf(codes.Aborted, "failed to filter block %d: %s", block.GetHeader().GetNumber(), err)
+		}
+		return stream.Send(&gx.FilteredBlockEventsResponse{FilteredBlock: filteredBlock})
+	})
+}
+
+// BlockAndPrivateDataEvents supplies a stream of the blocks committed to the requested channel, ordered by ascending
+// block number, with the private data of the collections that the client is eligible to read. The client must satisfy
+// the event/Block ACL of the channel, as for the DeliverWithPrivateData service of the peer.
+func (gs *Server) BlockAndPrivateDataEvents(signedRequest *gx.SignedBlockEventsRequest, stream gx.GatewayExtensions_BlockAndPrivateDataEventsServer) error {
+	return gs.blockEvents(signedRequest, resources.Event_Block, func(block *common.Block, channel string, ledger ledger.Ledger, signedData *protoutil.SignedData) error {
+		privateData, err := gs.privateDataFilter(block, channel, ledger, signedData)
+		if err != nil {
+			return status.Errorf(codes.Aborted, "failed to get private data of block %d: %s", block.GetHeader().GetNumber(), err)
+		}
+		return stream.Send(&gx.BlockAndPrivateDataEventsResponse{
+			BlockAndPrivateData: &peerproto.BlockAndPrivateData{
+				Block:          block,
+				PrivateDataMap: privateData,
+			},
+		})
+	})
+}
+
+func (gs *Server) blockEvents(signedRequest *gx.SignedBlockEventsRequest, resource string, send blockSender) error {
+	if len(signedRequest.GetRequest()) == 0 {
+		return status.Error(codes.InvalidArgument, "a block events request is required")
+	}
+
+	request := &gx.BlockEventsRequest{}
+	if err := proto.Unmarshal(signedRequest.GetRequest(), request); err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid block events request: %v", err)
+	}
+
+	channel := request.GetChannelId()
+	signedData := &protoutil.SignedData{
+		Data:      signedRequest.GetRequest(),
+		Identity:  request.GetIdentity(),
+		Signature: signedRequest.GetSignature(),
+	}
+	access := &blockEventsAccess{
+		policy:     gs.policy,
+		resource:   resource,
+		channel:    channel,
+		signedData: signedData,
+		sequence:   gs.configSequence,
+		expiresAt:  crypto.ExpiresAt(request.GetIdentity()),
+	}
+	if err := access.check(); err != nil {
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	ledger, err := gs.ledgerProvider.Ledger(channel)
+	if err != nil {
+		return status.Error(codes.NotFound, err.Error())
+	}
+
+	startBlock, err := blockEventsStartBlock(ledger, request)
+	if err != nil {
+		return err
+	}
+
+	ledgerIter, err := ledger.GetBlocksIterator(startBlock)
+	if err != nil {
+		return status.Error(codes.Aborted, err.Error())
+	}
+
+	blockIter := event.NewBlockIterator(ledgerIter)
+	defer blockIter.Close()
+
+	for {
+		block, err := blockIter.Next()
+		if err != nil {
+			return status.Error(codes.Aborted, err.Error())
+		}
+
+		if err := access.check(); err != nil {
+			return status.Error(codes.PermissionDenied, err.Error())
+		}
+
+		if err := send(block.Block(), channel, ledger, signedData); err != nil {
+			if err == io.EOF {
+				// Stream closed by the client
+				return status.Error(codes.Canceled, err.Error())
+			}
+			return err
+		}
+	}
+}
+
+// configSequence returns the sequence of the current config of the channel, or zero if the channel does not exist.
+func (gs *Server) configSequence(channel string) uint64 {
+	resources := gs.getChannelConfig(channel)
+	if resources == nil {
+		return 0
+	}
+	return resources.ConfigtxValidator().Sequence()
+}
+
+func blockEventsStartBlock(ledger ledger.Ledger, request *gx.BlockEventsRequest) (uint64, error) {
+	if checkpoint := request.GetCheckpoint(); checkpoint != nil {
+		return checkpoint.GetBlockNumber() + 1, nil
+	}
+
+	return startBlockFromLedgerPosition(ledger, request.GetStartPosition())
+}
+
+// blockEventsAccess controls the access of a client to the blocks of a channel in the same way as the
+// SessionAccessControl of the deliver service: the ACL is checked again only when the channel config changes, and the
+// access ends when the identity of the client expires.
+type blockEventsAccess struct {
+	policy     ACLChecker
+	resource   string
+	channel    string
+	signedData *protoutil.SignedData
+	sequence   func(channel string) uint64
+	expiresAt  time.Time
+
+	checked      bool
+	lastSequence uint64
+}
+
+func (a *blockEventsAccess) check() error {
+	if !a.expiresAt.IsZero() && time.Now().After(a.expiresAt) {
+		return errors.Errorf("client identity expired %v before", time.Since(a.expiresAt))
+	}
+
+	sequence := a.sequence(a.channel)
+	if a.checked && sequence == a.lastSequence {
+		return nil
+	}
+
+	if err := a.policy.CheckACL(a.resource, a.channel, a.signedData); err != nil {
+		return err
+	}
+
+	a.checked = true
+	a.lastSequence = sequence
+	return nil
+}
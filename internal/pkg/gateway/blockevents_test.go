@@ -0,0 +1,456 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gateway
+
+import (
+	"io"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	cp "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset"
+	ab "github.com/hyperledger/fabric-protos-go/orderer"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/core/aclmgmt/resources"
+	corepeer "github.com/hyperledger/fabric/core/peer"
+	gx "github.com/hyperledger/fabric/internal/pkg/gateway/gatewayext"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func newBlockEventsBlock(number uint64, transactionID string) *cp.Block {
+	transaction := &cp.Envelope{
+		Payload: protoutil.MarshalOrPanic(&cp.Payload{
+			Header: &cp.Header{
+				ChannelHeader: protoutil.MarshalOrPanic(&cp.ChannelHeader{
+					Type:      int32(cp.HeaderType_ENDORSER_TRANSACTION),
+					ChannelId: testChannel,
+					TxId:      transactionID,
+				}),
+			},
+			Data: protoutil.MarshalOrPanic(&peer.Transaction{
+				Actions: []*peer.TransactionAction{
+					{
+						Payload: protoutil.MarshalOrPanic(&peer.ChaincodeActionPayload{
+							Action: &peer.ChaincodeEndorsedAction{
+								ProposalResponsePayload: protoutil.MarshalOrPanic(&peer.ProposalResponsePayload{
+									Extension: protoutil.MarshalOrPanic(&peer.ChaincodeAction{
+										Events: protoutil.MarshalOrPanic(&peer.ChaincodeEvent{
+											ChaincodeId: testChaincode,
+											TxId:        transactionID,
+											EventName:   "EVENT_NAME",
+											Payload:     []byte("PAYLOAD"),
+										}),
+									}),
+								}),
+							},
+						}),
+					},
+				},
+			}),
+		}),
+	}
+
+	metadata := make([][]byte, 5)
+	metadata[cp.BlockMetadataIndex_TRANSACTIONS_FILTER] = []byte{byte(peer.TxValidationCode_VALID)}
+
+	return &cp.Block{
+		Header: &cp.BlockHeader{
+			Number: number,
+		},
+		Metadata: &cp.BlockMetadata{
+			Metadata: metadata,
+		},
+		Data: &cp.BlockData{
+			Data: [][]byte{protoutil.MarshalOrPanic(transaction)},
+		},
+	}
+}
+
+func newSignedBlockEventsRequest(t *testing.T, tt *testDef, checkpoint *gx.BlockCheckpoint) *gx.SignedBlockEventsRequest {
+	request := &gx.BlockEventsRequest{
+		ChannelId:     testChannel,
+		Identity:      tt.identity,
+		StartPosition: tt.startPosition,
+		Checkpoint:    checkpoint,
+	}
+	requestBytes, err := proto.Marshal(request)
+	require.NoError(t, err)
+
+	return &gx.SignedBlockEventsRequest{
+		Request:   requestBytes,
+		Signature: []byte{},
+	}
+}
+
+func TestBlockEvents(t *testing.T) {
+	block100 := newBlockEventsBlock(100, "TX_100")
+	block101 := newBlockEventsBlock(101, "TX_101")
+
+	type blockEventsTest struct {
+		testDef
+		checkpoint *gx.BlockCheckpoint
+	}
+
+	tests := []blockEventsTest{
+		{
+			testDef: testDef{
+				name:      "error reading blocks",
+				eventErr:  errors.New("BLOCK_ERROR"),
+				errCode:   codes.Aborted,
+				errString: "BLOCK_ERROR",
+			},
+		},
+		{
+			testDef: testDef{
+				name:   "returns blocks",
+				blocks: []*cp.Block{block100, block101},
+				expectedResponses: []proto.Message{
+					&gx.BlockEventsResponse{Block: block100},
+					&gx.BlockEventsResponse{Block: block101},
+				},
+			},
+		},
+		{
+			testDef: testDef{
+				name: "passes channel name to ledger provider",
+				postTest: func(t *testing.T, test *preparedTest) {
+					require.Equal(t, 1, test.ledgerProvider.LedgerCallCount())
+					require.Equal(t, testChannel, test.ledgerProvider.LedgerArgsForCall(0))
+				},
+			},
+		},
+		{
+			testDef: testDef{
+				name:      "returns error obtaining ledger",
+				errCode:   codes.NotFound,
+				errString: "LEDGER_PROVIDER_ERROR",
+				postSetup: func(t *testing.T, test *preparedTest) {
+					test.ledgerProvider.LedgerReturns(nil, errors.New("LEDGER_PROVIDER_ERROR"))
+				},
+			},
+		},
+		{
+			testDef: testDef{
+				name: "defaults to next commit if start position not specified",
+				postSetup: func(t *testing.T, test *preparedTest) {
+					test.ledger.GetBlockchainInfoReturns(&cp.BlockchainInfo{Height: 101}, nil)
+				},
+				postTest: func(t *testing.T, test *preparedTest) {
+					require.Equal(t, 1, test.ledger.GetBlocksIteratorCallCount())
+					require.EqualValues(t, 101, test.ledger.GetBlocksIteratorArgsForCall(0))
+				},
+			},
+		},
+		{
+			testDef: testDef{
+				name: "uses specified start block",
+				startPosition: &ab.SeekPosition{
+					Type: &ab.SeekPosition_Specified{
+						Specified: &ab.SeekSpecified{
+							Number: 99,
+						},
+					},
+				},
+				postTest: func(t *testing.T, test *preparedTest) {
+					require.Equal(t, 1, test.ledger.GetBlocksIteratorCallCount())
+					require.EqualValues(t, 99, test.ledger.GetBlocksIteratorArgsForCall(0))
+				},
+			},
+		},
+		{
+			testDef: testDef{
+				name: "resumes after checkpoint instead of start block",
+				startPosition: &ab.SeekPosition{
+					Type: &ab.SeekPosition_Specified{
+						Specified: &ab.SeekSpecified{
+							Number: 99,
+						},
+					},
+				},
+				postTest: func(t *testing.T, test *preparedTest) {
+					require.Equal(t, 1, test.ledger.GetBlocksIteratorCallCount())
+					require.EqualValues(t, 151, test.ledger.GetBlocksIteratorArgsForCall(0))
+				},
+			},
+			checkpoint: &gx.BlockCheckpoint{BlockNumber: 150},
+		},
+		{
+			testDef: testDef{
+				name: "resumes after checkpoint of block zero",
+				postTest: func(t *testing.T, test *preparedTest) {
+					require.Equal(t, 1, test.ledger.GetBlocksIteratorCallCount())
+					require.EqualValues(t, 1, test.ledger.GetBlocksIteratorArgsForCall(0))
+				},
+			},
+			checkpoint: &gx.BlockCheckpoint{},
+		},
+		{
+			testDef: testDef{
+				name: "returns error for unsupported start position type",
+				startPosition: &ab.SeekPosition{
+					Type: &ab.SeekPosition_Oldest{
+						Oldest: &ab.SeekOldest{},
+					},
+				},
+				errCode:   codes.InvalidArgument,
+				errString: "invalid start position type: *orderer.SeekPosition_Oldest",
+			},
+		},
+		{
+			testDef: testDef{
+				name:      "returns error obtaining ledger iterator",
+				errCode:   codes.Aborted,
+				errString: "LEDGER_ITERATOR_ERROR",
+				postSetup: func(t *testing.T, test *preparedTest) {
+					test.ledger.GetBlocksIteratorReturns(nil, errors.New("LEDGER_ITERATOR_ERROR"))
+				},
+			},
+		},
+		{
+			testDef: testDef{
+				name:    "returns canceled status error when client closes stream",
+				blocks:  []*cp.Block{block100},
+				errCode: codes.Canceled,
+				postSetup: func(t *testing.T, test *preparedTest) {
+					test.blockServer.SendReturns(io.EOF)
+				},
+			},
+		},
+		{
+			testDef: testDef{
+				name:      "returns status error from send to client",
+				blocks:    []*cp.Block{block100},
+				errCode:   codes.Aborted,
+				errString: "SEND_ERROR",
+				postSetup: func(t *testing.T, test *preparedTest) {
+					test.blockServer.SendReturns(status.Error(codes.Aborted, "SEND_ERROR"))
+				},
+			},
+		},
+		{
+			testDef: testDef{
+				name:      "failed policy or signature check",
+				policyErr: errors.New("POLICY_ERROR"),
+				errCode:   codes.PermissionDenied,
+				errString: "POLICY_ERROR",
+				postTest: func(t *testing.T, test *preparedTest) {
+					require.Equal(t, 0, test.ledgerProvider.LedgerCallCount())
+				},
+			},
+		},
+		{
+			testDef: testDef{
+				name:     "passes block resource, channel name and identity to policy checker",
+				identity: []byte("IDENTITY"),
+				postTest: func(t *testing.T, test *preparedTest) {
+					require.Equal(t, 1, test.policy.CheckACLCallCount())
+					resource, channelName, data := test.policy.CheckACLArgsForCall(0)
+					require.Equal(t, resources.Event_Block, resource)
+					require.Equal(t, testChannel, channelName)
+					require.IsType(t, &protoutil.SignedData{}, data)
+					require.Equal(t, []byte("IDENTITY"), data.(*protoutil.SignedData).Identity)
+				},
+			},
+		},
+		{
+			testDef: testDef{
+				name:   "does not check policy again while channel config is unchanged",
+				blocks: []*cp.Block{block100, block101},
+				postTest: func(t *testing.T, test *preparedTest) {
+					require.Equal(t, 2, test.blockServer.SendCallCount())
+					require.Equal(t, 1, test.policy.CheckACLCallCount())
+				},
+			},
+		},
+		{
+			testDef: testDef{
+				name:   "checks policy again when channel config changes",
+				blocks: []*cp.Block{block100, block101},
+				postSetup: func(t *testing.T, test *preparedTest) {
+					test.configtx.SequenceReturns(1)
+					test.configtx.SequenceReturnsOnCall(2, 2)
+				},
+				postTest: func(t *testing.T, test *preparedTest) {
+					require.Equal(t, 2, test.blockServer.SendCallCount())
+					require.Equal(t, 2, test.policy.CheckACLCallCount())
+				},
+			},
+		},
+		{
+			testDef: testDef{
+				name:      "stops when policy check fails after channel config changes",
+				blocks:    []*cp.Block{block100, block101},
+				errCode:   codes.PermissionDenied,
+				errString: "POLICY_ERROR",
+				postSetup: func(t *testing.T, test *preparedTest) {
+					test.configtx.SequenceReturns(1)
+					test.configtx.SequenceReturnsOnCall(2, 2)
+					test.policy.CheckACLReturnsOnCall(1, errors.New("POLICY_ERROR"))
+				},
+				postTest: func(t *testing.T, test *preparedTest) {
+					require.Equal(t, 1, test.blockServer.SendCallCount())
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test := prepareTest(t, &tt.testDef)
+
+			err := test.server.BlockEvents(newSignedBlockEventsRequest(t, &tt.testDef, tt.checkpoint), test.blockServer)
+
+			if checkError(t, &tt.testDef, err) {
+				if tt.postTest != nil {
+					tt.postTest(t, test)
+				}
+				return
+			}
+
+			for i, expectedResponse := range tt.expectedResponses {
+				actualResponse := test.blockServer.SendArgsForCall(i)
+				require.True(t, proto.Equal(expectedResponse, actualResponse), "response[%d] mismatch: %v", i, actualResponse)
+			}
+
+			if tt.postTest != nil {
+				tt.postTest(t, test)
+			}
+		})
+	}
+}
+
+func TestFilteredBlockEvents(t *testing.T) {
+	block := newBlockEventsBlock(100, "TX_100")
+
+	t.Run("returns filtered blocks", func(t *testing.T) {
+		tt := &testDef{
+			blocks: []*cp.Block{block},
+		}
+		test := prepareTest(t, tt)
+
+		err := test.server.FilteredBlockEvents(newSignedBlockEventsRequest(t, tt, nil), test.filteredServer)
+		require.ErrorContains(t, err, "NO_MORE_BLOCKS")
+
+		expected, err := corepeer.FilteredBlock(block)
+		require.NoError(t, err)
+		require.Equal(t, "TX_100", expected.GetFilteredTransactions()[0].GetTxid())
+
+		require.Equal(t, 1, test.filteredServer.SendCallCount())
+		actual := test.filteredServer.SendArgsForCall(0)
+		require.True(t, proto.Equal(&gx.FilteredBlockEventsResponse{FilteredBlock: expected}, actual), "response mismatch: %v", actual)
+	})
+
+	t.Run("passes filtered block resource to policy checker", func(t *testing.T) {
+		tt := &testDef{}
+		test := prepareTest(t, tt)
+
+		test.server.FilteredBlockEvents(newSignedBlockEventsRequest(t, tt, nil), test.filteredServer)
+
+		require.Equal(t, 1, test.policy.CheckACLCallCount())
+		resource, _, _ := test.policy.CheckACLArgsForCall(0)
+		require.Equal(t, resources.Event_FilteredBlock, resource)
+	})
+
+	t.Run("returns error for block that cannot be filtered", func(t *testing.T) {
+		tt := &testDef{
+			blocks: []*cp.Block{
+				{
+					Header:   &cp.BlockHeader{Number: 100},
+					Metadata: &cp.BlockMetadata{Metadata: make([][]byte, 5)},
+					Data:     &cp.BlockData{Data: [][]byte{protoutil.MarshalOrPanic(&cp.Envelope{Payload: []byte("garbage")})}},
+				},
+			},
+		}
+		test := prepareTest(t, tt)
+
+		err := test.server.FilteredBlockEvents(newSignedBlockEventsRequest(t, tt, nil), test.filteredServer)
+
+		require.Equal(t, codes.Aborted, status.Code(err))
+		require.ErrorContains(t, err, "failed to filter block 100")
+		require.Equal(t, 0, test.filteredServer.SendCallCount())
+	})
+}
+
+func TestBlockAndPrivateDataEvents(t *testing.T) {
+	block := newBlockEventsBlock(100, "TX_100")
+	privateData := map[uint64]*rwset.TxPvtReadWriteSet{
+		0: {
+			DataModel: rwset.TxReadWriteSet_KV,
+			NsPvtRwset: []*rwset.NsPvtReadWriteSet{
+				{
+					Namespace: testChaincode,
+					CollectionPvtRwset: []*rwset.CollectionPvtReadWriteSet{
+						{CollectionName: "COLLECTION", Rwset: []byte("RWSET")},
+					},
+				},
+			},
+		},
+	}
+
+	t.Run("returns blocks with eligible private data", func(t *testing.T) {
+		tt := &testDef{
+			identity: []byte("IDENTITY"),
+			blocks:   []*cp.Block{block},
+		}
+		test := prepareTest(t, tt)
+
+		var filterArgs []interface{}
+		test.server.privateDataFilter = func(block *cp.Block, channelID string, retriever corepeer.PrivateDataRetriever, signedData *protoutil.SignedData) (map[uint64]*rwset.TxPvtReadWriteSet, error) {
+			filterArgs = []interface{}{block, channelID, retriever, signedData}
+			return privateData, nil
+		}
+
+		err := test.server.BlockAndPrivateDataEvents(newSignedBlockEventsRequest(t, tt, nil), test.pvtDataServer)
+		require.ErrorContains(t, err, "NO_MORE_BLOCKS")
+
+		require.Len(t, filterArgs, 4)
+		require.Equal(t, block, filterArgs[0])
+		require.Equal(t, testChannel, filterArgs[1])
+		require.Equal(t, test.ledger, filterArgs[2])
+		require.Equal(t, []byte("IDENTITY"), filterArgs[3].(*protoutil.SignedData).Identity)
+
+		require.Equal(t, 1, test.pvtDataServer.SendCallCount())
+		expected := &gx.BlockAndPrivateDataEventsResponse{
+			BlockAndPrivateData: &peer.BlockAndPrivateData{
+				Block:          block,
+				PrivateDataMap: privateData,
+			},
+		}
+		actual := test.pvtDataServer.SendArgsForCall(0)
+		require.True(t, proto.Equal(expected, actual), "response mismatch: %v", actual)
+	})
+
+	t.Run("passes block resource to policy checker", func(t *testing.T) {
+		tt := &testDef{}
+		test := prepareTest(t, tt)
+
+		test.server.BlockAndPrivateDataEvents(newSignedBlockEventsRequest(t, tt, nil), test.pvtDataServer)
+
+		require.Equal(t, 1, test.policy.CheckACLCallCount())
+		resource, _, _ := test.policy.CheckACLArgsForCall(0)
+		require.Equal(t, resources.Event_Block, resource)
+	})
+
+	t.Run("returns error getting private data", func(t *testing.T) {
+		tt := &testDef{
+			blocks: []*cp.Block{block},
+		}
+		test := prepareTest(t, tt)
+		test.server.privateDataFilter = func(*cp.Block, string, corepeer.PrivateDataRetriever, *protoutil.SignedData) (map[uint64]*rwset.TxPvtReadWriteSet, error) {
+			return nil, errors.New("PRIVATE_DATA_ERROR")
+		}
+
+		err := test.server.BlockAndPrivateDataEvents(newSignedBlockEventsRequest(t, tt, nil), test.pvtDataServer)
+
+		require.Equal(t, codes.Aborted, status.Code(err))
+		require.ErrorContains(t, err, "failed to get private data of block 100: PRIVATE_DATA_ERROR")
+		require.Equal(t, 0, test.pvtDataServer.SendCallCount())
+	})
+}
@@ -0,0 +1,107 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package commit
+
+import (
+	"sync"
+
+	"github.com/hyperledger/fabric/core/ledger"
+)
+
+type blockNumberListenerSet map[*blockNumberListener]struct{}
+
+type blockNumberNotifier struct {
+	lock      sync.Mutex
+	listeners blockNumberListenerSet
+	closed    bool
+}
+
+func newBlockNumberNotifier() *blockNumberNotifier {
+	return &blockNumberNotifier{
+		listeners: make(blockNumberListenerSet),
+	}
+}
+
+func (notifier *blockNumberNotifier) ReceiveBlock(blockEvent *ledger.CommitNotification) {
+	notifier.lock.Lock()
+	defer notifier.lock.Unlock()
+
+	for listener := range notifier.listeners {
+		if listener.isDone() {
+			notifier.removeListener(listener)
+			continue
+		}
+		listener.receive(blockEvent.BlockNumber)
+	}
+}
+
+func (notifier *blockNumberNotifier) registerListener(done <-chan struct{}) <-chan uint64 {
+	notifyChannel := make(chan uint64, 1) // Only the number of the last committed block is kept for a slow listener
+
+	notifier.lock.Lock()
+	defer notifier.lock.Unlock()
+
+	if notifier.closed {
+		close(notifyChannel)
+	} else {
+		listener := &blockNumberListener{
+			done:          done,
+			notifyChannel: notifyChannel,
+		}
+		notifier.listeners[listener] = struct{}{}
+	}
+
+	return notifyChannel
+}
+
+func (notifier *blockNumberNotifier) removeListener(listener *blockNumberListener) {
+	listener.close()
+	delete(notifier.listeners, listener)
+}
+
+func (notifier *blockNumberNotifier) Close() {
+	notifier.lock.Lock()
+	defer notifier.lock.Unlock()
+
+	for listener := range notifier.listeners {
+		listener.close()
+	}
+
+	notifier.listeners = nil
+	notifier.closed = true
+}
+
+type blockNumberListener struct {
+	done          <-chan struct{}
+	notifyChannel chan uint64
+}
+
+func (listener *blockNumberListener) isDone() bool {
+	select {
+	case <-listener.done:
+		return true
+	default:
+		return false
+	}
+}
+
+func (listener *blockNumberListener) close() {
+	close(listener.notifyChannel)
+}
+
+// receive replaces any block number not yet consumed by the listener, so that the notifier never blocks.
+func (listener *blockNumberListener) receive(blockNumber uint64) {
+	select {
+	case listener.notifyChannel <- blockNumber:
+	default:
+		select {
+		case <-listener.notifyChannel:
+		default:
+		}
+		listener.notifyChannel <- blockNumber
+	}
+}
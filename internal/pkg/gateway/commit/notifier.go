@@ -13,8 +13,9 @@ import (
 )
 
 type notifiers struct {
-	block  *blockNotifier
-	status *statusNotifier
+	block       *blockNotifier
+	status      *statusNotifier
+	blockNumber *blockNumberNotifier
 }
 
 // Notifier provides notification of transaction commits.
@@ -46,6 +47,19 @@ func (n *Notifier) notifyStatus(done <-chan struct{}, channelName string, transa
 	return notifyChannel, nil
 }
 
+// NotifyBlocks notifies the caller of the number of the blocks committed on the named channel after registering for
+// notifications. A caller that is slow to receive is only notified of the last block committed. The returned channel is
+// closed when the notifications stop, which may happen if the ledger is closed.
+func (n *Notifier) NotifyBlocks(done <-chan struct{}, channelName string) (<-chan uint64, error) {
+	notifiers, err := n.notifiersForChannel(channelName)
+	if err != nil {
+		return nil, err
+	}
+
+	notifyChannel := notifiers.blockNumber.registerListener(done)
+	return notifyChannel, nil
+}
+
 // close the notifier. This closes all notification channels obtained from this notifier. Behavior is undefined after
 // closing and the notifier should not be used.
 func (n *Notifier) close() {
@@ -74,10 +88,12 @@ func (n *Notifier) notifiersForChannel(channelName string) (*notifiers, error) {
 	}
 
 	statusNotifier := newStatusNotifier()
-	blockNotifier := newBlockNotifier(n.cancel, commitChannel, statusNotifier)
+	blockNumberNotifier := newBlockNumberNotifier()
+	blockNotifier := newBlockNotifier(n.cancel, commitChannel, statusNotifier, blockNumberNotifier)
 	result = &notifiers{
-		block:  blockNotifier,
-		status: statusNotifier,
+		block:       blockNotifier,
+		status:      statusNotifier,
+		blockNumber: blockNumberNotifier,
 	}
 	n.notifiersByChannel[channelName] = result
 
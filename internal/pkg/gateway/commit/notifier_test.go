@@ -8,6 +8,7 @@ package commit
 
 import (
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric-protos-go/peer"
@@ -410,6 +411,84 @@ func TestNotifier(t *testing.T) {
 		})
 	})
 
+	t.Run("NotifyBlocks", func(t *testing.T) {
+		t.Run("returns error from notification supplier", func(t *testing.T) {
+			provider, ledger := newLedgerMocks()
+			ledger.CommitNotificationsChannelReturns(nil, errors.New("MY_ERROR"))
+			notifier := NewNotifier(provider)
+			defer notifier.close()
+
+			_, err := notifier.NotifyBlocks(nil, "CHANNEL_NAME")
+
+			require.ErrorContains(t, err, "MY_ERROR")
+		})
+
+		t.Run("delivers block numbers in order", func(t *testing.T) {
+			commitSend := make(chan *ledger.CommitNotification)
+			notifier := newTestNotifier(commitSend)
+			defer notifier.close()
+
+			blockReceive, err := notifier.NotifyBlocks(nil, "CHANNEL_NAME")
+			require.NoError(t, err)
+
+			commitSend <- &ledger.CommitNotification{BlockNumber: 1}
+			require.EqualValues(t, 1, <-blockReceive)
+			commitSend <- &ledger.CommitNotification{BlockNumber: 2}
+			require.EqualValues(t, 2, <-blockReceive)
+		})
+
+		t.Run("delivers last block number to slow listener", func(t *testing.T) {
+			commitSend := make(chan *ledger.CommitNotification)
+			notifier := newTestNotifier(commitSend)
+			defer notifier.close()
+
+			blockReceive, err := notifier.NotifyBlocks(nil, "CHANNEL_NAME")
+			require.NoError(t, err)
+
+			commitSend <- &ledger.CommitNotification{BlockNumber: 1}
+			commitSend <- &ledger.CommitNotification{BlockNumber: 2}
+			commitSend <- &ledger.CommitNotification{BlockNumber: 3} // the notifier is not blocked by the listener
+			require.Eventually(t, func() bool {
+				select {
+				case blockNumber := <-blockReceive:
+					return blockNumber == 3
+				default:
+					return false
+				}
+			}, time.Second, 10*time.Millisecond)
+		})
+
+		t.Run("stops notification when done channel closed", func(t *testing.T) {
+			commitSend := make(chan *ledger.CommitNotification, 1)
+			notifier := newTestNotifier(commitSend)
+			defer notifier.close()
+
+			done := make(chan struct{})
+			blockReceive, err := notifier.NotifyBlocks(done, "CHANNEL_NAME")
+			require.NoError(t, err)
+
+			close(done)
+			commitSend <- &ledger.CommitNotification{BlockNumber: 1}
+			_, ok := <-blockReceive
+
+			require.False(t, ok, "Expected notification channel to be closed but receive was successful")
+		})
+
+		t.Run("stops notification if supplier stops", func(t *testing.T) {
+			commitSend := make(chan *ledger.CommitNotification, 1)
+			notifier := newTestNotifier(commitSend)
+			defer notifier.close()
+
+			blockReceive, err := notifier.NotifyBlocks(nil, "CHANNEL_NAME")
+			require.NoError(t, err)
+
+			close(commitSend)
+			_, ok := <-blockReceive
+
+			require.False(t, ok, "Expected notification channel to be closed but receive was successful")
+		})
+	})
+
 	t.Run("Close", func(t *testing.T) {
 		t.Run("stops all listeners", func(t *testing.T) {
 			commitSend := make(chan *ledger.CommitNotification)
@@ -22,13 +22,33 @@ type Options struct {
 	BroadcastTimeout time.Duration
 	// DialTimeout is used to specify the maximum time to wait for connecting to external peers and orderer nodes.
 	DialTimeout time.Duration
+	// MaxSubmitAttempts is used to specify the maximum number of times a transaction invalidated by a read conflict
+	// is submitted by SubmitAndWait, whatever the number of attempts allowed by the client.
+	MaxSubmitAttempts uint32
+	// EvaluateCacheEnabled is used to enable the caching of the results of Evaluate requests, which are shared by
+	// identical requests from the same client at the same ledger height.
+	EvaluateCacheEnabled bool
+	// EvaluateCacheMaxEntries is used to specify the maximum number of results cached for each channel.
+	EvaluateCacheMaxEntries int
+	// EndorserSelection is used to specify how the endorsers of an organization are ranked: "height" (by decreasing
+	// ledger height), "latency" (by increasing latency, load and failures of recent proposals, among those at the
+	// highest ledger height) or "roundrobin".
+	EndorserSelection string
+	// EndorserStatsWindow is used to specify the approximate number of recent proposals over which the latency and
+	// failures of each endorser are averaged.
+	EndorserStatsWindow int
 }
 
 var defaultOptions = Options{
-	Enabled:            true,
-	EndorsementTimeout: 10 * time.Second,
-	BroadcastTimeout:   10 * time.Second,
-	DialTimeout:        30 * time.Second,
+	Enabled:                 true,
+	EndorsementTimeout:      10 * time.Second,
+	BroadcastTimeout:        10 * time.Second,
+	DialTimeout:             30 * time.Second,
+	MaxSubmitAttempts:       3,
+	EvaluateCacheEnabled:    false,
+	EvaluateCacheMaxEntries: 1000,
+	EndorserSelection:       "height",
+	EndorserStatsWindow:     20,
 }
 
 // DefaultOptions gets the default Gateway configuration Options
@@ -46,6 +66,21 @@ func GetOptions(v *viper.Viper) Options {
 	if v.IsSet("peer.gateway.dialTimeout") {
 		options.DialTimeout = v.GetDuration("peer.gateway.dialTimeout")
 	}
+	if v.IsSet("peer.gateway.maxSubmitAttempts") {
+		options.MaxSubmitAttempts = v.GetUint32("peer.gateway.maxSubmitAttempts")
+	}
+	if v.IsSet("peer.gateway.evaluateCache.enabled") {
+		options.EvaluateCacheEnabled = v.GetBool("peer.gateway.evaluateCache.enabled")
+	}
+	if v.IsSet("peer.gateway.evaluateCache.maxEntries") {
+		options.EvaluateCacheMaxEntries = v.GetInt("peer.gateway.evaluateCache.maxEntries")
+	}
+	if v.IsSet("peer.gateway.endorserSelection.strategy") {
+		options.EndorserSelection = v.GetString("peer.gateway.endorserSelection.strategy")
+	}
+	if v.IsSet("peer.gateway.endorserSelection.statsWindow") {
+		options.EndorserStatsWindow = v.GetInt("peer.gateway.endorserSelection.statsWindow")
+	}
 
 	return options
 }
@@ -22,6 +22,13 @@ peer:
     endorsementTimeout: 30s
     broadcastTimeout: 20s
     dialTimeout: 2m
+    maxSubmitAttempts: 5
+    evaluateCache:
+      enabled: true
+      maxEntries: 50
+    endorserSelection:
+      strategy: latency
+      statsWindow: 100
 `)
 
 var testConfigOff = []byte(`
@@ -43,10 +50,15 @@ func TestOverriddenOptions(t *testing.T) {
 	options := GetOptions(v)
 
 	expectedOptions := Options{
-		Enabled:            true,
-		EndorsementTimeout: 30 * time.Second,
-		BroadcastTimeout:   20 * time.Second,
-		DialTimeout:        2 * time.Minute,
+		Enabled:                 true,
+		EndorsementTimeout:      30 * time.Second,
+		BroadcastTimeout:        20 * time.Second,
+		DialTimeout:             2 * time.Minute,
+		MaxSubmitAttempts:       5,
+		EvaluateCacheEnabled:    true,
+		EvaluateCacheMaxEntries: 50,
+		EndorserSelection:       "latency",
+		EndorserStatsWindow:     100,
 	}
 	require.Equal(t, expectedOptions, options)
 }
@@ -58,10 +70,14 @@ func TestDisabledGatewayOption(t *testing.T) {
 	options := GetOptions(v)
 
 	expectedOptions := Options{
-		Enabled:            false,
-		EndorsementTimeout: 10 * time.Second,
-		BroadcastTimeout:   10 * time.Second,
-		DialTimeout:        30 * time.Second,
+		Enabled:                 false,
+		EndorsementTimeout:      10 * time.Second,
+		BroadcastTimeout:        10 * time.Second,
+		DialTimeout:             30 * time.Second,
+		MaxSubmitAttempts:       3,
+		EvaluateCacheMaxEntries: 1000,
+		EndorserSelection:       "height",
+		EndorserStatsWindow:     20,
 	}
 	require.Equal(t, expectedOptions, options)
 }
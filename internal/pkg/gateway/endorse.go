@@ -11,6 +11,7 @@ import (
 	"fmt"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
 	gp "github.com/hyperledger/fabric-protos-go/gateway"
 	"github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/common/flogging"
@@ -25,7 +26,17 @@ func (gs *Server) Endorse(ctx context.Context, request *gp.EndorseRequest) (*gp.
 	if request == nil {
 		return nil, status.Error(codes.InvalidArgument, "an endorse request is required")
 	}
-	signedProposal := request.GetProposedTransaction()
+	preparedTransaction, err := gs.endorse(ctx, request.GetProposedTransaction(), request.GetEndorsingOrganizations(), request.GetTransactionId())
+	if err != nil {
+		return nil, err
+	}
+
+	return &gp.EndorseResponse{PreparedTransaction: preparedTransaction}, nil
+}
+
+// endorse collects the endorsements of the signed proposal, from the peers of the endorsing organizations if any are
+// given, and returns the unsigned transaction envelope.
+func (gs *Server) endorse(ctx context.Context, signedProposal *peer.SignedProposal, endorsingOrgs []string, transactionID string) (*common.Envelope, error) {
 	if len(signedProposal.GetProposalBytes()) == 0 {
 		return nil, status.Error(codes.InvalidArgument, "the proposed transaction must contain a signed proposal")
 	}
@@ -54,13 +65,13 @@ func (gs *Server) Endorse(ctx context.Context, request *gp.EndorseRequest) (*gp.
 	chaincodeID := spec.GetChaincodeSpec().GetChaincodeId().GetName()
 	hasTransientData := len(payload.GetTransientMap()) > 0
 
-	logger := gs.logger.With("channel", channel, "chaincode", chaincodeID, "txID", request.GetTransactionId())
+	logger := gs.logger.With("channel", channel, "chaincode", chaincodeID, "txID", transactionID)
 
 	var plan *plan
 	var action *peer.ChaincodeEndorsedAction
-	if len(request.GetEndorsingOrganizations()) > 0 {
+	if len(endorsingOrgs) > 0 {
 		// The client is specifying the endorsing orgs and taking responsibility for ensuring it meets the signature policy
-		plan, err = gs.registry.planForOrgs(channel, chaincodeID, request.GetEndorsingOrganizations())
+		plan, err = gs.registry.planForOrgs(channel, chaincodeID, endorsingOrgs)
 		if err != nil {
 			return nil, status.Error(codes.Unavailable, err.Error())
 		}
@@ -115,7 +126,7 @@ func (gs *Server) Endorse(ctx context.Context, request *gp.EndorseRequest) (*gp.
 		return nil, status.Errorf(codes.Aborted, "failed to assemble transaction: %s", err)
 	}
 
-	return &gp.EndorseResponse{PreparedTransaction: preparedTransaction}, nil
+	return preparedTransaction, nil
 }
 
 type ppResponse struct {
@@ -134,7 +145,10 @@ func (gs *Server) processProposal(ctx context.Context, plan *plan, endorser *end
 		logger.Debugw("Sending to endorser:", "MSPID", endorser.mspid, "endpoint", endorser.address)
 		ctx, cancel := context.WithTimeout(ctx, gs.options.EndorsementTimeout) // timeout of individual endorsement
 		defer cancel()
+		processed := gs.registry.stats.start(endorser)
 		response, err := endorser.client.ProcessProposal(ctx, signedProposal)
+		_, _, failed, _ := responseStatus(response, err)
+		processed(failed)
 		done <- &ppResponse{response: response, err: err}
 	}()
 	select {
@@ -202,8 +216,10 @@ func (gs *Server) planFromFirstEndorser(ctx context.Context, channel string, cha
 
 			ctx, cancel := context.WithTimeout(ctx, gs.options.EndorsementTimeout)
 			defer cancel()
+			processed := gs.registry.stats.start(firstEndorser)
 			firstResponse, err = firstEndorser.client.ProcessProposal(ctx, signedProposal)
-			code, message, _, remove := responseStatus(firstResponse, err)
+			code, message, failed, remove := responseStatus(firstResponse, err)
+			processed(failed)
 
 			if code != codes.OK {
 				logger.Warnw("Endorse call to endorser failed", "endorserAddress", firstEndorser.address, "endorserMspid", firstEndorser.mspid, "error", message)
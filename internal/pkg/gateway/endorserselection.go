@@ -0,0 +1,210 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gateway
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// HeightFirst ranks the endorsers by decreasing ledger height, preferring the gateway peer for equal heights.
+	HeightFirst = "height"
+	// LatencyFirst ranks the endorsers at the highest ledger height by increasing cost, estimated from their latency,
+	// load and failures, ahead of the endorsers that are behind.
+	LatencyFirst = "latency"
+	// RoundRobin rotates the endorsers at the highest ledger height from one selection of a group to the next.
+	RoundRobin = "roundrobin"
+)
+
+// endorserSorter ranks the endorsers of a group or an organization, from the most to the least preferred. The host
+// is the address of the gateway peer, if it may be preferred.
+type endorserSorter func(endorsers []*endorserState, host string)
+
+func newEndorserSorter(strategy string, stats *endorserStats) (endorserSorter, error) {
+	switch strategy {
+	case HeightFirst, "":
+		return sortByHeight, nil
+	case LatencyFirst:
+		return stats.sortByCost, nil
+	case RoundRobin:
+		return (&roundRobin{}).sort, nil
+	default:
+		return nil, errors.Errorf("unknown endorser selection strategy: %s", strategy)
+	}
+}
+
+func sortByHeight(endorsers []*endorserState, host string) {
+	sort.Slice(endorsers, sorter(endorsers, host))
+}
+
+type roundRobin struct {
+	lock sync.Mutex
+	next map[string]uint64 // endorser group -> count of selections
+}
+
+// sort orders the endorsers by decreasing ledger height, then by address, and rotates those at the highest ledger
+// height so that each selection from the same group of endorsers starts with the next of them. Endorsers that are
+// behind are never moved in front of those at the highest ledger height.
+func (rr *roundRobin) sort(endorsers []*endorserState, _ string) {
+	if len(endorsers) == 0 {
+		return
+	}
+	sort.Slice(endorsers, func(i, j int) bool {
+		if endorsers[i].height != endorsers[j].height {
+			return endorsers[i].height > endorsers[j].height
+		}
+		return endorsers[i].endorser.address < endorsers[j].endorser.address
+	})
+	highest := countAtHighestHeight(endorsers)
+	offset := int(rr.advance(groupKey(endorsers)) % uint64(highest))
+	rotated := append(append([]*endorserState{}, endorsers[offset:highest]...), endorsers[:offset]...)
+	copy(endorsers, rotated)
+}
+
+// countAtHighestHeight returns the count of the endorsers at the ledger height of the first one, which must be the
+// highest.
+func countAtHighestHeight(endorsers []*endorserState) int {
+	highest := 1
+	for highest < len(endorsers) && endorsers[highest].height == endorsers[0].height {
+		highest++
+	}
+	return highest
+}
+
+// advance returns the count of the previous selections from the group, and counts a new one.
+func (rr *roundRobin) advance(group string) uint64 {
+	rr.lock.Lock()
+	defer rr.lock.Unlock()
+
+	if rr.next == nil {
+		rr.next = map[string]uint64{}
+	}
+	count := rr.next[group]
+	rr.next[group] = count + 1
+	return count
+}
+
+// groupKey identifies a group of endorsers by their sorted addresses.
+func groupKey(endorsers []*endorserState) string {
+	addresses := make([]string, 0, len(endorsers))
+	for _, e := range endorsers {
+		addresses = append(addresses, e.endorser.address)
+	}
+	sort.Strings(addresses)
+	return strings.Join(addresses, ",")
+}
+
+type endorserScore struct {
+	latency     float64 // seconds, smoothed
+	failureRate float64 // smoothed
+	samples     int
+	inFlight    int
+}
+
+// endorserStats keeps a rolling score of the proposals processed by each endorser. The latency and the failure rate
+// are exponentially weighted moving averages over about the given number of recent proposals.
+type endorserStats struct {
+	weight  float64
+	metrics *Metrics
+
+	lock   sync.Mutex
+	scores map[string]*endorserScore // endorser pkiid -> score
+}
+
+func newEndorserStats(window int, metrics *Metrics) *endorserStats {
+	if window < 1 {
+		window = 1
+	}
+	return &endorserStats{
+		weight:  2 / float64(window+1),
+		metrics: metrics,
+		scores:  map[string]*endorserScore{},
+	}
+}
+
+// start records a proposal sent to the endorser. The returned function must be called once the proposal is
+// processed, to record its outcome. Failed is true if the endorser failed to process the proposal, rather than the
+// chaincode or the client being at fault.
+func (s *endorserStats) start(e *endorser) (done func(failed bool)) {
+	begin := time.Now()
+
+	s.lock.Lock()
+	score := s.score(e)
+	score.inFlight++
+	s.lock.Unlock()
+
+	return func(failed bool) {
+		latency := time.Since(begin).Seconds()
+		s.metrics.EndorserLatency.With("mspid", e.mspid, "endpoint", e.address).Observe(latency)
+		failure := 0.0
+		if failed {
+			failure = 1
+			s.metrics.EndorserFailures.With("mspid", e.mspid, "endpoint", e.address).Add(1)
+		}
+
+		s.lock.Lock()
+		defer s.lock.Unlock()
+
+		score.inFlight--
+		score.samples++
+		if score.samples == 1 {
+			score.latency = latency
+			score.failureRate = failure
+			return
+		}
+		score.latency += s.weight * (latency - score.latency)
+		score.failureRate += s.weight * (failure - score.failureRate)
+	}
+}
+
+// score returns the score of the endorser, which is added if not found. The caller must hold the lock.
+func (s *endorserStats) score(e *endorser) *endorserScore {
+	key := e.pkiid.String()
+	score, ok := s.scores[key]
+	if !ok {
+		score = &endorserScore{}
+		s.scores[key] = score
+	}
+	return score
+}
+
+// cost estimates the time the endorser will take to process a proposal: its latency, scaled up by the proposals in
+// flight and by its failure rate. An endorser that has not processed any proposal yet has no cost, so that it is tried.
+func (s *endorserStats) cost(e *endorser) float64 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	score, ok := s.scores[e.pkiid.String()]
+	if !ok || score.samples == 0 {
+		return 0
+	}
+	return score.latency * float64(1+score.inFlight) / math.Max(1-score.failureRate, 0.01)
+}
+
+// sortByCost orders the endorsers by decreasing ledger height, preferring the host for equal heights, then orders
+// those at the highest ledger height by increasing cost. Endorsers that are behind are never moved in front of those
+// at the highest ledger height, however fast they are, as they would endorse against stale state.
+func (s *endorserStats) sortByCost(endorsers []*endorserState, host string) {
+	if len(endorsers) == 0 {
+		return
+	}
+	sort.Slice(endorsers, sorter(endorsers, host))
+	upToDate := endorsers[:countAtHighestHeight(endorsers)]
+	costs := make(map[*endorser]float64, len(upToDate))
+	for _, e := range upToDate {
+		costs[e.endorser] = s.cost(e.endorser)
+	}
+	sort.SliceStable(upToDate, func(i, j int) bool {
+		return costs[upToDate[i].endorser] < costs[upToDate[j].endorser]
+	})
+}
@@ -0,0 +1,226 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gateway
+
+import (
+	"testing"
+
+	pb "github.com/hyperledger/fabric-protos-go/gateway"
+	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
+	gossipcommon "github.com/hyperledger/fabric/gossip/common"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestEndorserStats(window int) (*endorserStats, *metricsfakes.Histogram, *metricsfakes.Counter) {
+	latency := &metricsfakes.Histogram{}
+	latency.WithReturns(latency)
+	failures := &metricsfakes.Counter{}
+	failures.WithReturns(failures)
+
+	return newEndorserStats(window, &Metrics{EndorserLatency: latency, EndorserFailures: failures}), latency, failures
+}
+
+func endorserStates(endorsers ...*endorser) []*endorserState {
+	var states []*endorserState
+	for _, e := range endorsers {
+		states = append(states, &endorserState{endorser: e, height: 5})
+	}
+	return states
+}
+
+func endorserAddresses(states []*endorserState) []string {
+	var addresses []string
+	for _, s := range states {
+		addresses = append(addresses, s.endorser.address)
+	}
+	return addresses
+}
+
+func TestEndorserStats(t *testing.T) {
+	t.Run("endorser without proposals has no cost", func(t *testing.T) {
+		stats, _, _ := newTestEndorserStats(20)
+		require.Zero(t, stats.cost(peer1Mock))
+
+		stats.start(peer1Mock)
+		require.Zero(t, stats.cost(peer1Mock), "proposal in flight")
+	})
+
+	t.Run("first proposal sets latency", func(t *testing.T) {
+		stats, latency, failures := newTestEndorserStats(20)
+
+		stats.start(peer1Mock)(false)
+
+		score := stats.scores[peer1Mock.pkiid.String()]
+		require.Equal(t, 1, score.samples)
+		require.Zero(t, score.inFlight)
+		require.Zero(t, score.failureRate)
+		require.Positive(t, score.latency)
+		require.Equal(t, score.latency, stats.cost(peer1Mock))
+
+		require.Equal(t, 1, latency.ObserveCallCount())
+		require.Equal(t, []string{"mspid", "msp1", "endpoint", "peer1:8051"}, latency.WithArgsForCall(0))
+		require.Equal(t, 0, failures.AddCallCount())
+	})
+
+	t.Run("averages outcomes over the window", func(t *testing.T) {
+		stats, _, failures := newTestEndorserStats(3) // weight 0.5
+
+		stats.start(peer1Mock)(true)
+		stats.start(peer1Mock)(false)
+
+		score := stats.scores[peer1Mock.pkiid.String()]
+		require.Equal(t, 0.5, score.failureRate)
+		require.Equal(t, 1, failures.AddCallCount())
+		require.Equal(t, []string{"mspid", "msp1", "endpoint", "peer1:8051"}, failures.WithArgsForCall(0))
+	})
+
+	t.Run("failures and load increase cost", func(t *testing.T) {
+		stats, _, _ := newTestEndorserStats(20)
+		stats.scores[peer1Mock.pkiid.String()] = &endorserScore{latency: 0.1, samples: 1}
+		require.InDelta(t, 0.1, stats.cost(peer1Mock), 1e-9)
+
+		stats.scores[peer1Mock.pkiid.String()].inFlight = 1
+		require.InDelta(t, 0.2, stats.cost(peer1Mock), 1e-9)
+
+		stats.scores[peer1Mock.pkiid.String()].failureRate = 0.5
+		require.InDelta(t, 0.4, stats.cost(peer1Mock), 1e-9)
+
+		stats.scores[peer1Mock.pkiid.String()].failureRate = 1
+		require.InDelta(t, 20, stats.cost(peer1Mock), 1e-9)
+	})
+}
+
+func TestEndorserSorters(t *testing.T) {
+	peer3Mock := &endorser{endpointConfig: &endpointConfig{pkiid: []byte("3"), address: "peer3:10051", mspid: "msp1"}}
+
+	t.Run("unknown strategy", func(t *testing.T) {
+		_, err := newEndorserSorter("fastest", nil)
+		require.EqualError(t, err, "unknown endorser selection strategy: fastest")
+	})
+
+	t.Run("height first", func(t *testing.T) {
+		sortEndorsers, err := newEndorserSorter(HeightFirst, nil)
+		require.NoError(t, err)
+
+		states := endorserStates(peer1Mock, localhostMock, peer3Mock)
+		states[2].height = 6
+		sortEndorsers(states, localhostMock.address)
+
+		require.Equal(t, []string{"peer3:10051", "localhost:7051", "peer1:8051"}, endorserAddresses(states))
+	})
+
+	t.Run("latency first", func(t *testing.T) {
+		stats, _, _ := newTestEndorserStats(20)
+		stats.scores[peer1Mock.pkiid.String()] = &endorserScore{latency: 0.1, samples: 1}
+		stats.scores[localhostMock.pkiid.String()] = &endorserScore{latency: 0.5, samples: 1}
+		stats.scores[peer3Mock.pkiid.String()] = &endorserScore{latency: 0.1, samples: 1}
+		sortEndorsers, err := newEndorserSorter(LatencyFirst, stats)
+		require.NoError(t, err)
+
+		states := endorserStates(localhostMock, peer1Mock, peer3Mock)
+		sortEndorsers(states, localhostMock.address)
+
+		require.Equal(t, []string{"peer1:8051", "peer3:10051", "localhost:7051"}, endorserAddresses(states))
+	})
+
+	t.Run("latency first ranks endorsers that are behind last", func(t *testing.T) {
+		stats, _, _ := newTestEndorserStats(20)
+		stats.scores[peer1Mock.pkiid.String()] = &endorserScore{latency: 0.01, samples: 1}
+		stats.scores[localhostMock.pkiid.String()] = &endorserScore{latency: 0.5, samples: 1}
+		stats.scores[peer3Mock.pkiid.String()] = &endorserScore{latency: 0.1, samples: 1}
+		sortEndorsers, err := newEndorserSorter(LatencyFirst, stats)
+		require.NoError(t, err)
+
+		states := endorserStates(peer1Mock, localhostMock, peer3Mock)
+		states[0].height = 2
+		sortEndorsers(states, localhostMock.address)
+
+		require.Equal(t, []string{"peer3:10051", "localhost:7051", "peer1:8051"}, endorserAddresses(states))
+
+		sortEndorsers(nil, "")
+	})
+
+	t.Run("latency first tries endorsers without proposals", func(t *testing.T) {
+		stats, _, _ := newTestEndorserStats(20)
+		stats.scores[localhostMock.pkiid.String()] = &endorserScore{latency: 0.01, samples: 1}
+		sortEndorsers, err := newEndorserSorter(LatencyFirst, stats)
+		require.NoError(t, err)
+
+		states := endorserStates(localhostMock, peer1Mock)
+		sortEndorsers(states, localhostMock.address)
+
+		require.Equal(t, []string{"peer1:8051", "localhost:7051"}, endorserAddresses(states))
+	})
+
+	t.Run("round robin", func(t *testing.T) {
+		sortEndorsers, err := newEndorserSorter(RoundRobin, nil)
+		require.NoError(t, err)
+
+		var first []string
+		for i := 0; i < 4; i++ {
+			states := endorserStates(peer3Mock, peer1Mock, localhostMock)
+			sortEndorsers(states, localhostMock.address)
+			first = append(first, states[0].endorser.address)
+		}
+
+		require.Equal(t, []string{"localhost:7051", "peer1:8051", "peer3:10051", "localhost:7051"}, first)
+
+		sortEndorsers(nil, "")
+	})
+
+	t.Run("round robin rotates each group on its own", func(t *testing.T) {
+		sortEndorsers, err := newEndorserSorter(RoundRobin, nil)
+		require.NoError(t, err)
+
+		var first []string
+		for i := 0; i < 2; i++ {
+			group1 := endorserStates(peer1Mock, localhostMock)
+			sortEndorsers(group1, localhostMock.address)
+			group2 := endorserStates(peer3Mock, peer2Mock)
+			sortEndorsers(group2, localhostMock.address)
+			first = append(first, group1[0].endorser.address, group2[0].endorser.address)
+		}
+
+		require.Equal(t, []string{"localhost:7051", "peer2:9051", "peer1:8051", "peer3:10051"}, first)
+	})
+
+	t.Run("round robin rotates the endorsers at the highest ledger height", func(t *testing.T) {
+		sortEndorsers, err := newEndorserSorter(RoundRobin, nil)
+		require.NoError(t, err)
+
+		for i := 0; i < 4; i++ {
+			states := endorserStates(peer3Mock, peer1Mock, localhostMock)
+			states[0].height = 4
+			sortEndorsers(states, localhostMock.address)
+			require.Equal(t, "peer3:10051", states[2].endorser.address, "selection %d", i)
+		}
+	})
+}
+
+func TestEvaluateLatencyFirst(t *testing.T) {
+	tt := &testDef{
+		members: []networkMember{
+			{"id1", "localhost:7051", "msp1", 5},
+			{"id2", "peer1:8051", "msp1", 5},
+		},
+		localLedgerHeight: 5,
+	}
+	test := prepareTest(t, tt)
+
+	stats := test.server.registry.stats
+	stats.scores[gossipcommon.PKIidType("id1").String()] = &endorserScore{latency: 0.01, samples: 1}
+	stats.scores[gossipcommon.PKIidType("id2").String()] = &endorserScore{latency: 1, samples: 1}
+	test.server.registry.sortEndorsers = stats.sortByCost
+
+	_, err := test.server.Evaluate(test.ctx, &pb.EvaluateRequest{ProposedTransaction: test.signedProposal})
+	require.NoError(t, err)
+
+	checkEndorsers(t, []string{"localhost:7051"}, test)
+
+	score := stats.scores[gossipcommon.PKIidType("id1").String()]
+	require.Equal(t, 2, score.samples, "outcome recorded")
+}
@@ -30,7 +30,19 @@ func (gs *Server) Evaluate(ctx context.Context, request *gp.EvaluateRequest) (*g
 		return nil, status.Errorf(codes.InvalidArgument, "failed to unpack transaction proposal: %s", err)
 	}
 
-	err = gs.registry.connectChannelPeers(channel, false)
+	// results that depend on transient data, or on the peers of specific organizations, are not cached
+	if gs.evaluateCache != nil && !hasTransientData && len(request.GetTargetOrganizations()) == 0 {
+		if height, ok := gs.registry.localLedgerHeight(channel); ok {
+			return gs.evaluateCached(ctx, request, channel, chaincodeID, height)
+		}
+	}
+
+	return gs.evaluate(ctx, request, channel, chaincodeID, hasTransientData)
+}
+
+func (gs *Server) evaluate(ctx context.Context, request *gp.EvaluateRequest, channel string, chaincodeID string, hasTransientData bool) (*gp.EvaluateResponse, error) {
+	signedProposal := request.GetProposedTransaction()
+	err := gs.registry.connectChannelPeers(channel, false)
 	if err != nil {
 		return nil, status.Errorf(codes.Unavailable, "%s", err)
 	}
@@ -61,8 +73,10 @@ func (gs *Server) Evaluate(ctx context.Context, request *gp.EvaluateRequest) (*g
 			defer close(done)
 			ctx, cancel := context.WithTimeout(ctx, gs.options.EndorsementTimeout)
 			defer cancel()
+			processed := gs.registry.stats.start(endorser)
 			pr, err := endorser.client.ProcessProposal(ctx, signedProposal)
 			code, message, retry, remove := responseStatus(pr, err)
+			processed(retry)
 			if code == codes.OK {
 				response = pr.Response
 				// Prefer result from proposal response as Response.Payload is not required to be transaction result
@@ -0,0 +1,239 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gateway
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"hash"
+	"sync"
+
+	gp "github.com/hyperledger/fabric-protos-go/gateway"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/core/aclmgmt/resources"
+	"github.com/hyperledger/fabric/protoutil"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// commitNotifier provides notification of the blocks committed on a channel.
+//
+//go:generate counterfeiter -o mocks/commitnotifier.go --fake-name CommitNotifier . commitNotifier
+type commitNotifier interface {
+	NotifyBlocks(done <-chan struct{}, channelName string) (<-chan uint64, error)
+}
+
+type evaluateKey [sha256.Size]byte
+
+type evaluateResult struct {
+	height   uint64
+	done     chan struct{}
+	response *gp.EvaluateResponse
+	err      error
+}
+
+// evaluateCache holds the results of Evaluate requests for each channel, until a block is committed to the channel.
+// The result of a request in flight is shared by the identical requests received meanwhile.
+type evaluateCache struct {
+	notifier   commitNotifier
+	maxEntries int
+	metrics    *Metrics
+
+	lock     sync.Mutex
+	channels map[string]map[evaluateKey]*evaluateResult
+}
+
+func newEvaluateCache(notifier commitNotifier, maxEntries int, metrics *Metrics) *evaluateCache {
+	return &evaluateCache{
+		notifier:   notifier,
+		maxEntries: maxEntries,
+		metrics:    metrics,
+		channels:   map[string]map[evaluateKey]*evaluateResult{},
+	}
+}
+
+// evaluateCached answers the Evaluate request from the cache of results. The endorsers are not invoked for the requests
+// answered from the cache, so the channel ACL that they would apply to the proposal is checked by the gateway.
+func (gs *Server) evaluateCached(ctx context.Context, request *gp.EvaluateRequest, channel string, chaincodeID string, height uint64) (*gp.EvaluateResponse, error) {
+	signedProposal := request.GetProposedTransaction()
+	if err := gs.policy.CheckACL(resources.Peer_Propose, channel, signedProposal); err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	key, err := evaluateCacheKey(signedProposal, height)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to unpack transaction proposal: %s", err)
+	}
+
+	return gs.evaluateCache.evaluate(ctx, channel, chaincodeID, key, height, func() (*gp.EvaluateResponse, error) {
+		return gs.evaluate(ctx, request, channel, chaincodeID, false)
+	})
+}
+
+// evaluate returns the cached result identified by the key. Otherwise the result is obtained from the evaluate function,
+// which is invoked once for the identical requests in flight. Errors are not cached, and the requests that waited for
+// a request that failed, maybe because of its own timeout, invoke the evaluate function themselves.
+func (c *evaluateCache) evaluate(ctx context.Context, channel string, chaincodeID string, key evaluateKey, height uint64, evaluate func() (*gp.EvaluateResponse, error)) (*gp.EvaluateResponse, error) {
+	result, found, err := c.result(channel, key, height)
+	if err != nil {
+		logger.Warnw("Failed to cache the result of Evaluate as commits cannot be notified", "channel", channel, "err", err)
+		return evaluate()
+	}
+
+	if !found {
+		c.metrics.EvaluateCacheMisses.With("channel", channel, "chaincode", chaincodeID).Add(1)
+		result.response, result.err = evaluate()
+		if result.err != nil {
+			c.remove(channel, key, result)
+		}
+		close(result.done)
+		return result.response, result.err
+	}
+
+	select {
+	case <-result.done:
+		if result.err == nil {
+			c.metrics.EvaluateCacheHits.With("channel", channel, "chaincode", chaincodeID).Add(1)
+			return result.response, nil
+		}
+	default:
+		c.metrics.EvaluateCacheCoalesced.With("channel", channel, "chaincode", chaincodeID).Add(1)
+		select {
+		case <-result.done:
+			if result.err == nil {
+				return result.response, nil
+			}
+		case <-ctx.Done():
+			return nil, newRpcError(codes.DeadlineExceeded, "evaluate timeout expired")
+		}
+	}
+
+	c.metrics.EvaluateCacheMisses.With("channel", channel, "chaincode", chaincodeID).Add(1)
+	return evaluate()
+}
+
+// result returns the result identified by the key, if found. Otherwise a new result is added to the cache, which must
+// be completed by the caller.
+func (c *evaluateCache) result(channel string, key evaluateKey, height uint64) (result *evaluateResult, found bool, err error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	results, err := c.channelResults(channel)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if result, ok := results[key]; ok {
+		return result, true, nil
+	}
+
+	if len(results) >= c.maxEntries {
+		for k := range results {
+			delete(results, k)
+			break
+		}
+	}
+
+	result = &evaluateResult{
+		height: height,
+		done:   make(chan struct{}),
+	}
+	results[key] = result
+
+	return result, false, nil
+}
+
+// channelResults returns the results cached for the channel. The caller must hold the lock.
+func (c *evaluateCache) channelResults(channel string) (map[evaluateKey]*evaluateResult, error) {
+	if results, ok := c.channels[channel]; ok {
+		return results, nil
+	}
+
+	blockNumbers, err := c.notifier.NotifyBlocks(nil, channel)
+	if err != nil {
+		return nil, err
+	}
+
+	results := map[evaluateKey]*evaluateResult{}
+	c.channels[channel] = results
+	go c.invalidate(channel, results, blockNumbers)
+
+	return results, nil
+}
+
+// invalidate removes the results obtained at a ledger height that is exceeded by the committed blocks.
+func (c *evaluateCache) invalidate(channel string, results map[evaluateKey]*evaluateResult, blockNumbers <-chan uint64) {
+	for blockNumber := range blockNumbers {
+		c.lock.Lock()
+		for key, result := range results {
+			if result.height <= blockNumber {
+				delete(results, key)
+			}
+		}
+		c.lock.Unlock()
+	}
+
+	// The notifications stopped, maybe because the ledger was closed. The cache of the channel is dropped so that the
+	// notifications are registered again if the channel is used later.
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	delete(c.channels, channel)
+}
+
+func (c *evaluateCache) remove(channel string, key evaluateKey, result *evaluateResult) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if results := c.channels[channel]; results[key] == result {
+		delete(results, key)
+	}
+}
+
+// evaluateCacheKey identifies the result of a proposal at a ledger height by its chaincode, its input and its creator,
+// as the chaincode may return a result that depends on the identity of the client.
+func evaluateCacheKey(signedProposal *peer.SignedProposal, height uint64) (evaluateKey, error) {
+	proposal, err := protoutil.UnmarshalProposal(signedProposal.GetProposalBytes())
+	if err != nil {
+		return evaluateKey{}, err
+	}
+	header, err := protoutil.UnmarshalHeader(proposal.GetHeader())
+	if err != nil {
+		return evaluateKey{}, err
+	}
+	signatureHeader, err := protoutil.UnmarshalSignatureHeader(header.GetSignatureHeader())
+	if err != nil {
+		return evaluateKey{}, err
+	}
+	payload, err := protoutil.UnmarshalChaincodeProposalPayload(proposal.GetPayload())
+	if err != nil {
+		return evaluateKey{}, err
+	}
+	spec, err := protoutil.UnmarshalChaincodeInvocationSpec(payload.GetInput())
+	if err != nil {
+		return evaluateKey{}, err
+	}
+
+	h := sha256.New()
+	writeKeyField(h, signatureHeader.GetCreator())
+	writeKeyField(h, []byte(spec.GetChaincodeSpec().GetChaincodeId().GetName()))
+	args := spec.GetChaincodeSpec().GetInput().GetArgs()
+	binary.Write(h, binary.BigEndian, uint64(len(args)))
+	for _, arg := range args {
+		writeKeyField(h, arg)
+	}
+	binary.Write(h, binary.BigEndian, height)
+
+	var key evaluateKey
+	copy(key[:], h.Sum(nil))
+	return key, nil
+}
+
+func writeKeyField(h hash.Hash, field []byte) {
+	binary.Write(h, binary.BigEndian, uint64(len(field)))
+	h.Write(field)
+}
@@ -0,0 +1,284 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gateway
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cp "github.com/hyperledger/fabric-protos-go/common"
+	pb "github.com/hyperledger/fabric-protos-go/gateway"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
+	"github.com/hyperledger/fabric/internal/pkg/gateway/mocks"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type preparedCacheTest struct {
+	*preparedTest
+	blockNumbers chan uint64
+	notifier     *mocks.CommitNotifier
+	hits         *metricsfakes.Counter
+	misses       *metricsfakes.Counter
+	coalesced    *metricsfakes.Counter
+}
+
+func prepareCacheTest(t *testing.T, tt *testDef) *preparedCacheTest {
+	tt.members = []networkMember{
+		{"id1", "localhost:7051", "msp1", 5},
+	}
+	tt.localLedgerHeight = 5
+	test := &preparedCacheTest{
+		preparedTest: prepareTest(t, tt),
+		blockNumbers: make(chan uint64),
+		notifier:     &mocks.CommitNotifier{},
+		hits:         &metricsfakes.Counter{},
+		misses:       &metricsfakes.Counter{},
+		coalesced:    &metricsfakes.Counter{},
+	}
+	test.notifier.NotifyBlocksReturns(test.blockNumbers, nil)
+	for _, counter := range []*metricsfakes.Counter{test.hits, test.misses, test.coalesced} {
+		counter.WithReturns(counter)
+	}
+	metrics := &Metrics{
+		EvaluateCacheHits:      test.hits,
+		EvaluateCacheMisses:    test.misses,
+		EvaluateCacheCoalesced: test.coalesced,
+	}
+	test.server.evaluateCache = newEvaluateCache(test.notifier, 10, metrics)
+	return test
+}
+
+func (test *preparedCacheTest) evaluate(t *testing.T, signedProposal *peer.SignedProposal) *pb.EvaluateResponse {
+	response, err := test.server.Evaluate(test.ctx, &pb.EvaluateRequest{ProposedTransaction: signedProposal})
+	require.NoError(t, err)
+	require.Equal(t, []byte("mock_response"), response.Result.Payload)
+	return response
+}
+
+func (test *preparedCacheTest) signedProposal(t *testing.T, transient map[string][]byte, args ...[]byte) *peer.SignedProposal {
+	signedProposal, err := protoutil.GetSignedProposal(createProposal(t, testChannel, testChaincode, transient, args...), test.signer)
+	require.NoError(t, err)
+	return signedProposal
+}
+
+func TestEvaluateCache(t *testing.T) {
+	t.Run("identical requests answered from cache", func(t *testing.T) {
+		test := prepareCacheTest(t, &testDef{})
+
+		test.evaluate(t, test.signedProposal(t, nil, []byte("query"), []byte("arg")))
+		test.evaluate(t, test.signedProposal(t, nil, []byte("query"), []byte("arg")))
+
+		require.Equal(t, 1, test.localEndorser.ProcessProposalCallCount())
+		require.Equal(t, 1, test.misses.AddCallCount())
+		require.Equal(t, 1, test.hits.AddCallCount())
+		require.Equal(t, []string{"channel", testChannel, "chaincode", testChaincode}, test.hits.WithArgsForCall(0))
+	})
+
+	t.Run("requests with different arguments evaluated separately", func(t *testing.T) {
+		test := prepareCacheTest(t, &testDef{})
+
+		test.evaluate(t, test.signedProposal(t, nil, []byte("query"), []byte("arg1")))
+		test.evaluate(t, test.signedProposal(t, nil, []byte("query"), []byte("arg2")))
+		test.evaluate(t, test.signedProposal(t, nil, []byte("query"), []byte("arg1"), []byte("arg2")))
+
+		require.Equal(t, 3, test.localEndorser.ProcessProposalCallCount())
+		require.Equal(t, 0, test.hits.AddCallCount())
+	})
+
+	t.Run("requests from different clients evaluated separately", func(t *testing.T) {
+		test := prepareCacheTest(t, &testDef{})
+
+		test.evaluate(t, test.signedProposal(t, nil, []byte("query")))
+		proposal, _, err := protoutil.CreateChaincodeProposalWithTransient(
+			cp.HeaderType_ENDORSER_TRANSACTION,
+			testChannel,
+			&peer.ChaincodeInvocationSpec{
+				ChaincodeSpec: &peer.ChaincodeSpec{
+					Type:        peer.ChaincodeSpec_NODE,
+					ChaincodeId: &peer.ChaincodeID{Name: testChaincode},
+					Input:       &peer.ChaincodeInput{Args: [][]byte{[]byte("query")}},
+				},
+			},
+			[]byte("ANOTHER_CLIENT"),
+			nil,
+		)
+		require.NoError(t, err)
+		signedProposal, err := protoutil.GetSignedProposal(proposal, test.signer)
+		require.NoError(t, err)
+		test.evaluate(t, signedProposal)
+
+		require.Equal(t, 2, test.localEndorser.ProcessProposalCallCount())
+	})
+
+	t.Run("requests at a new ledger height evaluated again", func(t *testing.T) {
+		test := prepareCacheTest(t, &testDef{})
+
+		test.evaluate(t, test.signedProposal(t, nil, []byte("query")))
+		test.ledger.GetBlockchainInfoReturns(&cp.BlockchainInfo{Height: 6}, nil)
+		test.evaluate(t, test.signedProposal(t, nil, []byte("query")))
+
+		require.Equal(t, 2, test.localEndorser.ProcessProposalCallCount())
+	})
+
+	t.Run("results removed when block committed", func(t *testing.T) {
+		test := prepareCacheTest(t, &testDef{})
+
+		test.evaluate(t, test.signedProposal(t, nil, []byte("query")))
+		require.Len(t, test.server.evaluateCache.channels[testChannel], 1)
+
+		test.blockNumbers <- 5 // first block beyond the ledger height of the result
+		test.blockNumbers <- 6 // ensures that the previous notification has been processed
+		test.server.evaluateCache.lock.Lock()
+		defer test.server.evaluateCache.lock.Unlock()
+		require.Empty(t, test.server.evaluateCache.channels[testChannel])
+	})
+
+	t.Run("cache of channel dropped when notifications stop", func(t *testing.T) {
+		test := prepareCacheTest(t, &testDef{})
+
+		test.evaluate(t, test.signedProposal(t, nil, []byte("query")))
+		close(test.blockNumbers)
+
+		require.Eventually(t, func() bool {
+			test.server.evaluateCache.lock.Lock()
+			defer test.server.evaluateCache.lock.Unlock()
+			_, ok := test.server.evaluateCache.channels[testChannel]
+			return !ok
+		}, time.Second, 10*time.Millisecond)
+		test.notifier.NotifyBlocksReturns(make(chan uint64), nil)
+
+		test.evaluate(t, test.signedProposal(t, nil, []byte("query")))
+		require.Equal(t, 2, test.localEndorser.ProcessProposalCallCount())
+		require.Equal(t, 2, test.notifier.NotifyBlocksCallCount())
+	})
+
+	t.Run("requests evaluated without cache when commits cannot be notified", func(t *testing.T) {
+		test := prepareCacheTest(t, &testDef{})
+		test.notifier.NotifyBlocksReturns(nil, errors.New("NOTIFIER_ERROR"))
+
+		test.evaluate(t, test.signedProposal(t, nil, []byte("query")))
+		test.evaluate(t, test.signedProposal(t, nil, []byte("query")))
+
+		require.Equal(t, 2, test.localEndorser.ProcessProposalCallCount())
+	})
+
+	t.Run("requests with transient data not cached", func(t *testing.T) {
+		test := prepareCacheTest(t, &testDef{})
+		transient := map[string][]byte{"key": []byte("value")}
+
+		test.evaluate(t, test.signedProposal(t, transient, []byte("query")))
+		test.evaluate(t, test.signedProposal(t, transient, []byte("query")))
+
+		require.Equal(t, 2, test.localEndorser.ProcessProposalCallCount())
+		require.Equal(t, 0, test.notifier.NotifyBlocksCallCount())
+	})
+
+	t.Run("requests for target organizations not cached", func(t *testing.T) {
+		test := prepareCacheTest(t, &testDef{})
+		request := &pb.EvaluateRequest{ProposedTransaction: test.signedProposal(t, nil, []byte("query")), TargetOrganizations: []string{"msp1"}}
+
+		_, err := test.server.Evaluate(test.ctx, request)
+		require.NoError(t, err)
+		_, err = test.server.Evaluate(test.ctx, request)
+		require.NoError(t, err)
+
+		require.Equal(t, 2, test.localEndorser.ProcessProposalCallCount())
+	})
+
+	t.Run("ACL checked for requests answered from cache", func(t *testing.T) {
+		test := prepareCacheTest(t, &testDef{})
+		signedProposal := test.signedProposal(t, nil, []byte("query"))
+
+		test.evaluate(t, signedProposal)
+		test.policy.CheckACLReturns(errors.New("POLICY_ERROR"))
+		_, err := test.server.Evaluate(test.ctx, &pb.EvaluateRequest{ProposedTransaction: signedProposal})
+
+		require.ErrorIs(t, err, status.Error(codes.PermissionDenied, "POLICY_ERROR"))
+		require.Equal(t, 2, test.policy.CheckACLCallCount())
+		resource, channel, data := test.policy.CheckACLArgsForCall(1)
+		require.Equal(t, "peer/Propose", resource)
+		require.Equal(t, testChannel, channel)
+		require.Equal(t, signedProposal, data)
+	})
+
+	t.Run("errors not cached", func(t *testing.T) {
+		test := prepareCacheTest(t, &testDef{})
+		test.localEndorser.ProcessProposalReturnsOnCall(0, createErrorResponse(t, 500, "ENDORSER_ERROR", nil), nil)
+
+		_, err := test.server.Evaluate(test.ctx, &pb.EvaluateRequest{ProposedTransaction: test.signedProposal(t, nil, []byte("query"))})
+		require.Equal(t, codes.Aborted, status.Code(err))
+		test.evaluate(t, test.signedProposal(t, nil, []byte("query")))
+
+		require.Equal(t, 2, test.localEndorser.ProcessProposalCallCount())
+		require.Empty(t, test.hits.AddCallCount())
+	})
+
+	t.Run("identical requests in flight coalesced", func(t *testing.T) {
+		test := prepareCacheTest(t, &testDef{})
+		release := make(chan struct{})
+		proposalResponse := createProposalResponse(t, localhostMock.address, "mock_response", 200, "")
+		test.localEndorser.ProcessProposalCalls(func(ctx context.Context, proposal *peer.SignedProposal, opts ...grpc.CallOption) (*peer.ProposalResponse, error) {
+			<-release
+			return proposalResponse, nil
+		})
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			test.evaluate(t, test.signedProposal(t, nil, []byte("query")))
+		}()
+		require.Eventually(t, func() bool { return test.localEndorser.ProcessProposalCallCount() == 1 }, time.Second, 10*time.Millisecond)
+
+		go func() {
+			require.Eventually(t, func() bool { return test.coalesced.AddCallCount() == 1 }, time.Second, 10*time.Millisecond)
+			close(release)
+		}()
+		test.evaluate(t, test.signedProposal(t, nil, []byte("query")))
+		<-done
+
+		require.Equal(t, 1, test.localEndorser.ProcessProposalCallCount())
+		require.Equal(t, 1, test.misses.AddCallCount())
+		require.Equal(t, 0, test.hits.AddCallCount())
+	})
+
+	t.Run("coalesced request times out", func(t *testing.T) {
+		test := prepareCacheTest(t, &testDef{})
+		release := make(chan struct{})
+		defer close(release)
+		test.localEndorser.ProcessProposalCalls(func(ctx context.Context, proposal *peer.SignedProposal, opts ...grpc.CallOption) (*peer.ProposalResponse, error) {
+			<-release
+			return nil, errors.New("RELEASED")
+		})
+
+		go test.server.Evaluate(test.ctx, &pb.EvaluateRequest{ProposedTransaction: test.signedProposal(t, nil, []byte("query"))})
+		require.Eventually(t, func() bool { return test.localEndorser.ProcessProposalCallCount() == 1 }, time.Second, 10*time.Millisecond)
+
+		ctx, cancel := context.WithCancel(test.ctx)
+		cancel()
+		_, err := test.server.Evaluate(ctx, &pb.EvaluateRequest{ProposedTransaction: test.signedProposal(t, nil, []byte("query"))})
+
+		require.ErrorIs(t, err, status.Error(codes.DeadlineExceeded, "evaluate timeout expired"))
+	})
+
+	t.Run("entries evicted when cache is full", func(t *testing.T) {
+		test := prepareCacheTest(t, &testDef{})
+		test.server.evaluateCache.maxEntries = 2
+
+		test.evaluate(t, test.signedProposal(t, nil, []byte("query1")))
+		test.evaluate(t, test.signedProposal(t, nil, []byte("query2")))
+		test.evaluate(t, test.signedProposal(t, nil, []byte("query3")))
+
+		require.Len(t, test.server.evaluateCache.channels[testChannel], 2)
+	})
+}
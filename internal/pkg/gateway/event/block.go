@@ -26,6 +26,11 @@ func (b *Block) Number() uint64 {
 	return b.block.GetHeader().GetNumber()
 }
 
+// Block returns the committed block.
+func (b *Block) Block() *common.Block {
+	return b.block
+}
+
 func (b *Block) Transactions() ([]*Transaction, error) {
 	var err error
 
@@ -109,6 +109,7 @@ func TestIterators(t *testing.T) {
 	assertExpectedBlock := func(t *testing.T, block *event.Block) {
 		require.NotNil(t, block, "block")
 		require.EqualValues(t, blockProto.GetHeader().GetNumber(), block.Number(), "block.Number()")
+		require.Equal(t, blockProto, block.Block(), "block.Block()")
 
 		transactions, err := block.Transactions()
 		require.NoError(t, err, "Transactions()")
@@ -11,6 +11,7 @@ import (
 	peerproto "github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/metrics"
 	"github.com/hyperledger/fabric/core/peer"
 	"github.com/hyperledger/fabric/core/scc"
 	gdiscovery "github.com/hyperledger/fabric/gossip/discovery"
@@ -26,13 +27,15 @@ var logger = flogging.MustGetLogger("gateway")
 
 // Server represents the GRPC server for the Gateway.
 type Server struct {
-	registry         *registry
-	commitFinder     CommitFinder
-	policy           ACLChecker
-	options          config.Options
-	logger           *flogging.FabricLogger
-	ledgerProvider   ledger.Provider
-	getChannelConfig channelConfigGetter
+	registry          *registry
+	commitFinder      CommitFinder
+	policy            ACLChecker
+	options           config.Options
+	logger            *flogging.FabricLogger
+	ledgerProvider    ledger.Provider
+	getChannelConfig  channelConfigGetter
+	evaluateCache     *evaluateCache
+	privateDataFilter privateDataFilter
 }
 
 type EndorserServerAdapter struct {
@@ -63,11 +66,13 @@ func CreateServer(
 	localMSPID string,
 	options config.Options,
 	systemChaincodes scc.BuiltinSCCs,
+	metricsProvider metrics.Provider,
 ) *Server {
 	adapter := &ledger.PeerAdapter{
 		Peer: peerInstance,
 	}
 	notifier := commit.NewNotifier(adapter)
+	metrics := NewMetrics(metricsProvider)
 
 	server := newServer(
 		&EndorserServerAdapter{
@@ -84,8 +89,13 @@ func CreateServer(
 		systemChaincodes,
 		peerInstance.OrdererEndpointOverrides,
 		peerInstance.GetChannelConfig,
+		metrics,
 	)
 
+	if options.EvaluateCacheEnabled {
+		server.evaluateCache = newEvaluateCache(notifier, options.EvaluateCacheMaxEntries, metrics)
+	}
+
 	peerInstance.AddConfigCallbacks(server.registry.configUpdate)
 
 	return server
@@ -103,7 +113,15 @@ func newServer(localEndorser peerproto.EndorserClient,
 	systemChaincodes scc.BuiltinSCCs,
 	ordererEndpointOverrides map[string]*orderers.Endpoint,
 	getChannelConfig channelConfigGetter,
+	metrics *Metrics,
 ) *Server {
+	stats := newEndorserStats(options.EndorserStatsWindow, metrics)
+	sortEndorsers, err := newEndorserSorter(options.EndorserSelection, stats)
+	if err != nil {
+		logger.Warnw("Ranking endorsers by ledger height", "err", err)
+		sortEndorsers = sortByHeight
+	}
+
 	return &Server{
 		registry: &registry{
 			localEndorser: &endorser{
@@ -122,12 +140,15 @@ func newServer(localEndorser peerproto.EndorserClient,
 			channelInitialized: map[string]bool{},
 			systemChaincodes:   systemChaincodes,
 			localProvider:      ledgerProvider,
+			stats:              stats,
+			sortEndorsers:      sortEndorsers,
 		},
-		commitFinder:     finder,
-		policy:           policy,
-		options:          options,
-		logger:           logger,
-		ledgerProvider:   ledgerProvider,
-		getChannelConfig: getChannelConfig,
+		commitFinder:      finder,
+		policy:            policy,
+		options:           options,
+		logger:            logger,
+		ledgerProvider:    ledgerProvider,
+		getChannelConfig:  getChannelConfig,
+		privateDataFilter: peer.EligiblePrivateData,
 	}
 }
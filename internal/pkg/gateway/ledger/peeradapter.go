@@ -21,6 +21,8 @@ type Ledger interface {
 	GetBlockByTxID(txID string) (*common.Block, error)
 	GetBlockchainInfo() (*common.BlockchainInfo, error)
 	GetBlocksIterator(startBlockNumber uint64) (ledger.ResultsIterator, error)
+	GetConfigHistoryRetriever() (peerledger.ConfigHistoryRetriever, error)
+	GetPvtDataByNum(blockNum uint64, filter peerledger.PvtNsCollFilter) ([]*peerledger.TxPvtData, error)
 	GetTxValidationCodeByTxID(txID string) (peerproto.TxValidationCode, uint64, error)
 }
 
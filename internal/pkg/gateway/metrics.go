@@ -0,0 +1,69 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gateway
+
+import "github.com/hyperledger/fabric/common/metrics"
+
+var (
+	evaluateCacheHitsCounterOpts = metrics.CounterOpts{
+		Namespace:    "gateway",
+		Name:         "evaluate_cache_hits",
+		Help:         "The number of Evaluate requests answered from the cache of results.",
+		LabelNames:   []string{"channel", "chaincode"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{chaincode}",
+	}
+
+	evaluateCacheMissesCounterOpts = metrics.CounterOpts{
+		Namespace:    "gateway",
+		Name:         "evaluate_cache_misses",
+		Help:         "The number of Evaluate requests sent to an endorser as their result was not cached.",
+		LabelNames:   []string{"channel", "chaincode"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{chaincode}",
+	}
+
+	evaluateCacheCoalescedCounterOpts = metrics.CounterOpts{
+		Namespace:    "gateway",
+		Name:         "evaluate_cache_coalesced",
+		Help:         "The number of Evaluate requests that waited for the result of an identical request in flight.",
+		LabelNames:   []string{"channel", "chaincode"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{chaincode}",
+	}
+
+	endorserLatencyHistogramOpts = metrics.HistogramOpts{
+		Namespace:    "gateway",
+		Name:         "endorser_latency",
+		Help:         "The time taken by an endorser to process a proposal sent by the gateway.",
+		LabelNames:   []string{"mspid", "endpoint"},
+		StatsdFormat: "%{#fqname}.%{mspid}.%{endpoint}",
+	}
+
+	endorserFailuresCounterOpts = metrics.CounterOpts{
+		Namespace:    "gateway",
+		Name:         "endorser_failures",
+		Help:         "The number of proposals sent by the gateway that an endorser failed to process.",
+		LabelNames:   []string{"mspid", "endpoint"},
+		StatsdFormat: "%{#fqname}.%{mspid}.%{endpoint}",
+	}
+)
+
+type Metrics struct {
+	EvaluateCacheHits      metrics.Counter
+	EvaluateCacheMisses    metrics.Counter
+	EvaluateCacheCoalesced metrics.Counter
+	EndorserLatency        metrics.Histogram
+	EndorserFailures       metrics.Counter
+}
+
+func NewMetrics(p metrics.Provider) *Metrics {
+	return &Metrics{
+		EvaluateCacheHits:      p.NewCounter(evaluateCacheHitsCounterOpts),
+		EvaluateCacheMisses:    p.NewCounter(evaluateCacheMissesCounterOpts),
+		EvaluateCacheCoalesced: p.NewCounter(evaluateCacheCoalescedCounterOpts),
+		EndorserLatency:        p.NewHistogram(endorserLatencyHistogramOpts),
+		EndorserFailures:       p.NewCounter(endorserFailuresCounterOpts),
+	}
+}
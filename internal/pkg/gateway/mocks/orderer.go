@@ -8,9 +8,20 @@ import (
 	"github.com/hyperledger/fabric-protos-go/common"
 	"github.com/hyperledger/fabric-protos-go/orderer"
 	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/orderer/common/ordererext"
 )
 
 type Orderer struct {
+	AdaptiveBatchSizeStub        func() *ordererext.AdaptiveBatchSize
+	adaptiveBatchSizeMutex       sync.RWMutex
+	adaptiveBatchSizeArgsForCall []struct {
+	}
+	adaptiveBatchSizeReturns struct {
+		result1 *ordererext.AdaptiveBatchSize
+	}
+	adaptiveBatchSizeReturnsOnCall map[int]struct {
+		result1 *ordererext.AdaptiveBatchSize
+	}
 	BatchSizeStub        func() *orderer.BatchSize
 	batchSizeMutex       sync.RWMutex
 	batchSizeArgsForCall []struct {
@@ -105,6 +116,58 @@ type Orderer struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *Orderer) AdaptiveBatchSize() *ordererext.AdaptiveBatchSize {
+	fake.adaptiveBatchSizeMutex.Lock()
+	ret, specificReturn := fake.adaptiveBatchSizeReturnsOnCall[len(fake.adaptiveBatchSizeArgsForCall)]
+	fake.adaptiveBatchSizeArgsForCall = append(fake.adaptiveBatchSizeArgsForCall, struct {
+	}{})
+	fake.recordInvocation("AdaptiveBatchSize", []interface{}{})
+	fake.adaptiveBatchSizeMutex.Unlock()
+	if fake.AdaptiveBatchSizeStub != nil {
+		return fake.AdaptiveBatchSizeStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.adaptiveBatchSizeReturns
+	return fakeReturns.result1
+}
+
+func (fake *Orderer) AdaptiveBatchSizeCallCount() int {
+	fake.adaptiveBatchSizeMutex.RLock()
+	defer fake.adaptiveBatchSizeMutex.RUnlock()
+	return len(fake.adaptiveBatchSizeArgsForCall)
+}
+
+func (fake *Orderer) AdaptiveBatchSizeCalls(stub func() *ordererext.AdaptiveBatchSize) {
+	fake.adaptiveBatchSizeMutex.Lock()
+	defer fake.adaptiveBatchSizeMutex.Unlock()
+	fake.AdaptiveBatchSizeStub = stub
+}
+
+func (fake *Orderer) AdaptiveBatchSizeReturns(result1 *ordererext.AdaptiveBatchSize) {
+	fake.adaptiveBatchSizeMutex.Lock()
+	defer fake.adaptiveBatchSizeMutex.Unlock()
+	fake.AdaptiveBatchSizeStub = nil
+	fake.adaptiveBatchSizeReturns = struct {
+		result1 *ordererext.AdaptiveBatchSize
+	}{result1}
+}
+
+func (fake *Orderer) AdaptiveBatchSizeReturnsOnCall(i int, result1 *ordererext.AdaptiveBatchSize) {
+	fake.adaptiveBatchSizeMutex.Lock()
+	defer fake.adaptiveBatchSizeMutex.Unlock()
+	fake.AdaptiveBatchSizeStub = nil
+	if fake.adaptiveBatchSizeReturnsOnCall == nil {
+		fake.adaptiveBatchSizeReturnsOnCall = make(map[int]struct {
+			result1 *ordererext.AdaptiveBatchSize
+		})
+	}
+	fake.adaptiveBatchSizeReturnsOnCall[i] = struct {
+		result1 *ordererext.AdaptiveBatchSize
+	}{result1}
+}
+
 func (fake *Orderer) BatchSize() *orderer.BatchSize {
 	fake.batchSizeMutex.Lock()
 	ret, specificReturn := fake.batchSizeReturnsOnCall[len(fake.batchSizeArgsForCall)]
@@ -585,6 +648,8 @@ func (fake *Orderer) OrganizationsReturnsOnCall(i int, result1 map[string]channe
 func (fake *Orderer) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.adaptiveBatchSizeMutex.RLock()
+	defer fake.adaptiveBatchSizeMutex.RUnlock()
 	fake.batchSizeMutex.RLock()
 	defer fake.batchSizeMutex.RUnlock()
 	fake.batchTimeoutMutex.RLock()
@@ -9,7 +9,6 @@ package gateway
 import (
 	"fmt"
 	"math/rand"
-	"sort"
 	"strings"
 	"sync"
 
@@ -46,6 +45,8 @@ type registry struct {
 	channelOrderers    sync.Map // channel (string) -> orderer addresses (endpointConfig)
 	systemChaincodes   scc.BuiltinSCCs
 	localProvider      ledger.Provider
+	stats              *endorserStats
+	sortEndorsers      endorserSorter
 }
 
 type endorserState struct {
@@ -102,8 +103,8 @@ func (reg *registry) endorsementPlan(channel string, interest *peer.ChaincodeInt
 			}
 			groupPeers = append(groupPeers, &endorserState{peer: peer, endorser: endorser, height: height})
 		}
-		// sort by decreasing height
-		sort.Slice(groupPeers, sorter(groupPeers, reg.localEndorser.address))
+		// rank by the endorser selection strategy
+		reg.sortEndorsers(groupPeers, reg.localEndorser.address)
 
 		if len(groupPeers) > 0 {
 			var endorsers []*endorser
@@ -193,9 +194,9 @@ func (reg *registry) endorsersByOrg(channel string, chaincode string) map[string
 		}
 	}
 
-	// sort by decreasing height in each org
+	// rank the endorsers of each org by the endorser selection strategy
 	for _, es := range endorsersByOrg {
-		sort.Slice(es, sorter(es, reg.localEndorser.address))
+		reg.sortEndorsers(es, reg.localEndorser.address)
 	}
 
 	return endorsersByOrg
@@ -261,8 +262,8 @@ func (reg *registry) evaluator(channel string, chaincode string, targetOrgs []st
 			}
 		}
 	}
-	// sort all the 'other orgs' endorsers by decreasing block height
-	sort.Slice(otherOrgEndorsers, sorter(otherOrgEndorsers, ""))
+	// rank all the 'other orgs' endorsers by the endorser selection strategy
+	reg.sortEndorsers(otherOrgEndorsers, "")
 
 	var allEndorsers []*endorser
 	for _, e := range append(localOrgEndorsers, otherOrgEndorsers...) {
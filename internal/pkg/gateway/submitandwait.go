@@ -0,0 +1,337 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gateway
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+	gp "github.com/hyperledger/fabric-protos-go/gateway"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/core/aclmgmt/resources"
+	gx "github.com/hyperledger/fabric/internal/pkg/gateway/gatewayext"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SubmitAndWait will submit the prepared transaction to the ordering service and wait for its commit. If the
+// transaction is invalidated by a read conflict and the client has signed a re-endorsement policy, the next of the
+// proposals signed by the client is endorsed as a new transaction, which is sent to the client and submitted once the
+// client has sent it back signed, until it commits or the attempts allowed by both the policy and the
+// MaxSubmitAttempts option are exhausted. The status of each stage of each attempt is sent to the client.
+func (gs *Server) SubmitAndWait(stream gx.GatewayExtensions_SubmitAndWaitServer) error {
+	request, err := stream.Recv()
+	if err != nil && err != io.EOF {
+		return err
+	}
+	if request == nil {
+		return status.Error(codes.InvalidArgument, "a submit and wait request is required")
+	}
+	if len(request.GetProposedTransaction().GetProposalBytes()) == 0 {
+		return status.Error(codes.InvalidArgument, "the proposed transaction must contain a signed proposal")
+	}
+	txn := request.GetPreparedTransaction()
+	if txn == nil {
+		return status.Error(codes.InvalidArgument, "a prepared transaction is required")
+	}
+	if len(txn.Signature) == 0 {
+		return status.Error(codes.InvalidArgument, "prepared transaction must be signed")
+	}
+
+	creator, err := checkPreparedTransaction(request)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid submit and wait request: %s", err)
+	}
+
+	channel := request.GetChannelId()
+	if err := gs.policy.CheckACL(resources.Gateway_SubmitAndWait, channel, request.GetProposedTransaction()); err != nil {
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+	if err := gs.policy.CheckACL(resources.Gateway_SubmitAndWait, channel, txn); err != nil {
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	maxAttempts, err := gs.submitAttempts(request.GetReEndorsementPolicy(), channel, request.GetTransactionId(), creator)
+	if err != nil {
+		return err
+	}
+
+	ctx := stream.Context()
+	txID := request.GetTransactionId()
+	proposals := request.GetReEndorsementProposals()
+	for attempt := uint32(1); ; attempt++ {
+		if attempt > 1 {
+			if int(attempt-1) > len(proposals) {
+				return status.Errorf(codes.FailedPrecondition, "no proposal signed by the client is left to endorse transaction %s again", request.GetTransactionId())
+			}
+			txn, txID, err = gs.reEndorse(stream, request, proposals[attempt-2], attempt)
+			if err != nil {
+				return err
+			}
+			gs.logger.Debugw("Endorsed transaction again", "channel", channel, "txID", txID, "originalTxID", request.GetTransactionId(), "attempt", attempt)
+		}
+
+		if _, err := gs.Submit(ctx, &gp.SubmitRequest{TransactionId: txID, ChannelId: channel, PreparedTransaction: txn}); err != nil {
+			return err
+		}
+		if err := sendStatus(stream, &gx.SubmitAndWaitStatus{Attempt: attempt, TransactionId: txID, Stage: gx.SubmitAndWaitStatus_SUBMITTED}); err != nil {
+			return err
+		}
+
+		txStatus, err := gs.commitFinder.TransactionStatus(ctx, channel, txID)
+		if err != nil {
+			return toRpcError(err, codes.Aborted)
+		}
+		committed := &gx.SubmitAndWaitStatus{
+			Attempt:       attempt,
+			TransactionId: txID,
+			Stage:         gx.SubmitAndWaitStatus_COMMITTED,
+			Result:        txStatus.Code,
+			BlockNumber:   txStatus.BlockNumber,
+		}
+		if err := sendStatus(stream, committed); err != nil {
+			return err
+		}
+
+		if !isReadConflict(txStatus.Code) {
+			return nil
+		}
+		logger := gs.logger.With("channel", channel, "txID", txID, "attempt", attempt, "code", txStatus.Code)
+		if attempt >= maxAttempts {
+			logger.Infow("Transaction invalidated by a read conflict, no more submit attempts allowed", "maxAttempts", maxAttempts)
+			return nil
+		}
+		logger.Debugw("Transaction invalidated by a read conflict, endorsing it again")
+	}
+}
+
+// checkPreparedTransaction verifies that the prepared transaction is the one endorsed from the proposed transaction,
+// and returns the serialized identity of its creator.
+func checkPreparedTransaction(request *gx.SubmitAndWaitRequest) ([]byte, error) {
+	proposal, err := protoutil.UnmarshalProposal(request.GetProposedTransaction().GetProposalBytes())
+	if err != nil {
+		return nil, err
+	}
+	proposalHeader, err := protoutil.UnmarshalHeader(proposal.GetHeader())
+	if err != nil {
+		return nil, err
+	}
+	proposalChannelHeader, proposalSignatureHeader, err := unmarshalHeaders(proposalHeader)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := protoutil.UnmarshalPayload(request.GetPreparedTransaction().GetPayload())
+	if err != nil {
+		return nil, err
+	}
+	txnChannelHeader, txnSignatureHeader, err := unmarshalHeaders(payload.GetHeader())
+	if err != nil {
+		return nil, err
+	}
+
+	if proposalChannelHeader.GetChannelId() != request.GetChannelId() || proposalChannelHeader.GetTxId() != request.GetTransactionId() {
+		return nil, errors.Errorf("proposed transaction is not transaction %s on channel %s", request.GetTransactionId(), request.GetChannelId())
+	}
+	if txnChannelHeader.GetChannelId() != request.GetChannelId() || txnChannelHeader.GetTxId() != request.GetTransactionId() {
+		return nil, errors.Errorf("prepared transaction is not transaction %s on channel %s", request.GetTransactionId(), request.GetChannelId())
+	}
+	if !bytes.Equal(proposalSignatureHeader.GetCreator(), txnSignatureHeader.GetCreator()) {
+		return nil, errors.New("the creators of the proposed and prepared transactions differ")
+	}
+
+	proposalHash, err := protoutil.GetProposalHash1(proposalHeader, proposal.GetPayload())
+	if err != nil {
+		return nil, err
+	}
+	endorsedHash, err := endorsedProposalHash(payload)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(proposalHash, endorsedHash) {
+		return nil, errors.New("the prepared transaction was not endorsed from the proposed transaction")
+	}
+
+	return proposalSignatureHeader.GetCreator(), nil
+}
+
+// endorsedProposalHash returns the hash of the proposal that the endorsers of the transaction responded to.
+func endorsedProposalHash(payload *common.Payload) ([]byte, error) {
+	tx, err := protoutil.UnmarshalTransaction(payload.GetData())
+	if err != nil {
+		return nil, err
+	}
+	if len(tx.GetActions()) != 1 {
+		return nil, errors.Errorf("prepared transaction must contain a single action, found %d", len(tx.GetActions()))
+	}
+	actionPayload, err := protoutil.UnmarshalChaincodeActionPayload(tx.GetActions()[0].GetPayload())
+	if err != nil {
+		return nil, err
+	}
+	responsePayload, err := protoutil.UnmarshalProposalResponsePayload(actionPayload.GetAction().GetProposalResponsePayload())
+	if err != nil {
+		return nil, err
+	}
+	return responsePayload.GetProposalHash(), nil
+}
+
+// checkReEndorsementProposal verifies that the proposal is a new transaction with the same channel, creator and
+// chaincode invocation as the proposed transaction of the request, and returns its transaction ID.
+func checkReEndorsementProposal(request *gx.SubmitAndWaitRequest, signedProposal *peer.SignedProposal) (string, error) {
+	// the proposed transaction was parsed while the request was checked
+	original, _ := protoutil.UnmarshalProposal(request.GetProposedTransaction().GetProposalBytes())
+	originalHeader, _ := protoutil.UnmarshalHeader(original.GetHeader())
+	_, originalSignatureHeader, _ := unmarshalHeaders(originalHeader)
+	originalPayload, err := protoutil.UnmarshalChaincodeProposalPayload(original.GetPayload())
+	if err != nil {
+		return "", err
+	}
+
+	proposal, err := protoutil.UnmarshalProposal(signedProposal.GetProposalBytes())
+	if err != nil {
+		return "", err
+	}
+	header, err := protoutil.UnmarshalHeader(proposal.GetHeader())
+	if err != nil {
+		return "", err
+	}
+	channelHeader, signatureHeader, err := unmarshalHeaders(header)
+	if err != nil {
+		return "", err
+	}
+	payload, err := protoutil.UnmarshalChaincodeProposalPayload(proposal.GetPayload())
+	if err != nil {
+		return "", err
+	}
+
+	if channelHeader.GetChannelId() != request.GetChannelId() || channelHeader.GetTxId() == request.GetTransactionId() {
+		return "", errors.Errorf("proposal is not a new transaction on channel %s", request.GetChannelId())
+	}
+	if !bytes.Equal(signatureHeader.GetCreator(), originalSignatureHeader.GetCreator()) {
+		return "", errors.New("proposal must be created by the creator of the proposed transaction")
+	}
+	if !bytes.Equal(payload.GetInput(), originalPayload.GetInput()) {
+		return "", errors.New("proposal must invoke the chaincode with the input of the proposed transaction")
+	}
+
+	return channelHeader.GetTxId(), nil
+}
+
+func unmarshalHeaders(header *common.Header) (*common.ChannelHeader, *common.SignatureHeader, error) {
+	channelHeader, err := protoutil.UnmarshalChannelHeader(header.GetChannelHeader())
+	if err != nil {
+		return nil, nil, err
+	}
+	signatureHeader, err := protoutil.UnmarshalSignatureHeader(header.GetSignatureHeader())
+	if err != nil {
+		return nil, nil, err
+	}
+	return channelHeader, signatureHeader, nil
+}
+
+// submitAttempts returns the number of times the transaction may be submitted, after verifying that the
+// re-endorsement policy, if any, is bound to the transaction and signed by its creator.
+func (gs *Server) submitAttempts(signedPolicy *gx.SignedReEndorsementPolicy, channel string, txID string, creator []byte) (uint32, error) {
+	if signedPolicy == nil {
+		return 1, nil
+	}
+
+	policy := &gx.ReEndorsementPolicy{}
+	if err := proto.Unmarshal(signedPolicy.GetPolicy(), policy); err != nil {
+		return 0, status.Errorf(codes.InvalidArgument, "invalid re-endorsement policy: %v", err)
+	}
+	if policy.GetChannelId() != channel || policy.GetTransactionId() != txID {
+		return 0, status.Errorf(codes.InvalidArgument, "the re-endorsement policy is not bound to transaction %s on channel %s", txID, channel)
+	}
+	if !bytes.Equal(policy.GetIdentity(), creator) {
+		return 0, status.Error(codes.InvalidArgument, "the re-endorsement policy must be signed by the creator of the transaction")
+	}
+
+	signedData := &protoutil.SignedData{
+		Data:      signedPolicy.GetPolicy(),
+		Identity:  policy.GetIdentity(),
+		Signature: signedPolicy.GetSignature(),
+	}
+	if err := gs.policy.CheckACL(resources.Gateway_SubmitAndWait, channel, signedData); err != nil {
+		return 0, status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	attempts := policy.GetMaxAttempts()
+	if attempts > gs.options.MaxSubmitAttempts {
+		attempts = gs.options.MaxSubmitAttempts
+	}
+	if attempts == 0 {
+		attempts = 1
+	}
+	return attempts, nil
+}
+
+// reEndorse endorses the transaction again with the given proposal signed by the client, sends the resulting
+// transaction to the client, and returns it once the client has sent it back signed, along with its ID.
+func (gs *Server) reEndorse(stream gx.GatewayExtensions_SubmitAndWaitServer, request *gx.SubmitAndWaitRequest, signedProposal *peer.SignedProposal, attempt uint32) (*common.Envelope, string, error) {
+	channel := request.GetChannelId()
+	txID, err := checkReEndorsementProposal(request, signedProposal)
+	if err != nil {
+		return nil, "", status.Errorf(codes.InvalidArgument, "invalid re-endorsement proposal: %s", err)
+	}
+	if err := gs.policy.CheckACL(resources.Gateway_SubmitAndWait, channel, signedProposal); err != nil {
+		return nil, "", status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	txn, err := gs.endorse(stream.Context(), signedProposal, request.GetEndorsingOrganizations(), txID)
+	if err != nil {
+		return nil, "", err
+	}
+	endorsed := &gx.SubmitAndWaitStatus{
+		Attempt:             attempt,
+		TransactionId:       txID,
+		Stage:               gx.SubmitAndWaitStatus_ENDORSED,
+		PreparedTransaction: txn,
+	}
+	if err := sendStatus(stream, endorsed); err != nil {
+		return nil, "", err
+	}
+
+	signed, err := stream.Recv()
+	if err == io.EOF {
+		return nil, "", status.Errorf(codes.Canceled, "the client closed the stream before signing transaction %s", txID)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	signedTxn := signed.GetPreparedTransaction()
+	if signed.GetChannelId() != channel || signed.GetTransactionId() != txID || !bytes.Equal(signedTxn.GetPayload(), txn.GetPayload()) {
+		return nil, "", status.Errorf(codes.InvalidArgument, "the signed transaction is not transaction %s endorsed again", txID)
+	}
+	if len(signedTxn.GetSignature()) == 0 {
+		return nil, "", status.Error(codes.InvalidArgument, "prepared transaction must be signed")
+	}
+	if err := gs.policy.CheckACL(resources.Gateway_SubmitAndWait, channel, signedTxn); err != nil {
+		return nil, "", status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	return signedTxn, txID, nil
+}
+
+func isReadConflict(code peer.TxValidationCode) bool {
+	return code == peer.TxValidationCode_MVCC_READ_CONFLICT || code == peer.TxValidationCode_PHANTOM_READ_CONFLICT
+}
+
+func sendStatus(stream gx.GatewayExtensions_SubmitAndWaitServer, response *gx.SubmitAndWaitStatus) error {
+	if err := stream.Send(response); err != nil {
+		if err == io.EOF {
+			// Stream closed by the client
+			return status.Error(codes.Canceled, err.Error())
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,463 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gateway
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	cp "github.com/hyperledger/fabric-protos-go/common"
+	pb "github.com/hyperledger/fabric-protos-go/gateway"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/internal/pkg/gateway/commit"
+	gx "github.com/hyperledger/fabric/internal/pkg/gateway/gatewayext"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestSubmitAndWait(t *testing.T) {
+	var (
+		valid        = &commit.Status{Code: peer.TxValidationCode_VALID, BlockNumber: 101}
+		readConflict = &commit.Status{Code: peer.TxValidationCode_MVCC_READ_CONFLICT, BlockNumber: 101}
+	)
+
+	submitted := func(attempt uint32) *gx.SubmitAndWaitStatus {
+		return &gx.SubmitAndWaitStatus{Attempt: attempt, Stage: gx.SubmitAndWaitStatus_SUBMITTED}
+	}
+	endorsed := func(attempt uint32) *gx.SubmitAndWaitStatus {
+		return &gx.SubmitAndWaitStatus{Attempt: attempt, Stage: gx.SubmitAndWaitStatus_ENDORSED}
+	}
+	committed := func(attempt uint32, status *commit.Status) *gx.SubmitAndWaitStatus {
+		return &gx.SubmitAndWaitStatus{Attempt: attempt, Stage: gx.SubmitAndWaitStatus_COMMITTED, Result: status.Code, BlockNumber: status.BlockNumber}
+	}
+
+	type submitAndWaitTest struct {
+		testDef
+		maxAttempts      uint32 // no re-endorsement policy when zero
+		proposals        int    // number of re-endorsement proposals, maxAttempts-1 when zero
+		proposalArgs     [][]byte
+		policy           func(policy *gx.ReEndorsementPolicy)
+		sign             func(request *gx.SubmitAndWaitRequest) (*gx.SubmitAndWaitRequest, error)
+		finderStatuses   []*commit.Status
+		expectedStatuses []*gx.SubmitAndWaitStatus
+	}
+
+	tests := []submitAndWaitTest{
+		{
+			testDef:          testDef{name: "committed without re-endorsement policy"},
+			finderStatuses:   []*commit.Status{valid},
+			expectedStatuses: []*gx.SubmitAndWaitStatus{submitted(1), committed(1, valid)},
+		},
+		{
+			testDef:          testDef{name: "read conflict without re-endorsement policy"},
+			finderStatuses:   []*commit.Status{readConflict},
+			expectedStatuses: []*gx.SubmitAndWaitStatus{submitted(1), committed(1, readConflict)},
+		},
+		{
+			testDef:          testDef{name: "committed at first attempt with re-endorsement policy"},
+			maxAttempts:      3,
+			finderStatuses:   []*commit.Status{valid},
+			expectedStatuses: []*gx.SubmitAndWaitStatus{submitted(1), committed(1, valid)},
+		},
+		{
+			testDef: testDef{
+				name: "re-endorsed after read conflict",
+				postTest: func(t *testing.T, test *preparedTest) {
+					require.Equal(t, 2, test.localEndorser.ProcessProposalCallCount())
+					_, signedProposal, _ := test.localEndorser.ProcessProposalArgsForCall(1)
+					proposal, err := protoutil.UnmarshalProposal(signedProposal.ProposalBytes)
+					require.NoError(t, err)
+					payload, err := protoutil.UnmarshalChaincodeProposalPayload(proposal.Payload)
+					require.NoError(t, err)
+					require.Equal(t, map[string][]byte{"key": []byte("value")}, payload.TransientMap)
+
+					endorsedStatus := test.submitServer.SendArgsForCall(2)
+					require.NotNil(t, endorsedStatus.PreparedTransaction)
+					require.Empty(t, endorsedStatus.PreparedTransaction.Signature)
+					require.Equal(t, transactionID(t, signedProposal), endorsedStatus.TransactionId)
+					require.Equal(t, 2, test.submitServer.RecvCallCount())
+				},
+				transientData: map[string][]byte{"key": []byte("value")},
+			},
+			maxAttempts:      3,
+			finderStatuses:   []*commit.Status{readConflict, valid},
+			expectedStatuses: []*gx.SubmitAndWaitStatus{submitted(1), committed(1, readConflict), endorsed(2), submitted(2), committed(2, valid)},
+		},
+		{
+			testDef: testDef{name: "re-endorsed after phantom read conflict"},
+			finderStatuses: []*commit.Status{
+				{Code: peer.TxValidationCode_PHANTOM_READ_CONFLICT, BlockNumber: 101},
+				valid,
+			},
+			maxAttempts: 3,
+			expectedStatuses: []*gx.SubmitAndWaitStatus{
+				submitted(1),
+				committed(1, &commit.Status{Code: peer.TxValidationCode_PHANTOM_READ_CONFLICT, BlockNumber: 101}),
+				endorsed(2),
+				submitted(2),
+				committed(2, valid),
+			},
+		},
+		{
+			testDef:          testDef{name: "attempts limited by re-endorsement policy"},
+			maxAttempts:      2,
+			finderStatuses:   []*commit.Status{readConflict, readConflict, readConflict},
+			expectedStatuses: []*gx.SubmitAndWaitStatus{submitted(1), committed(1, readConflict), endorsed(2), submitted(2), committed(2, readConflict)},
+		},
+		{
+			testDef:        testDef{name: "attempts limited by gateway option"},
+			maxAttempts:    10,
+			finderStatuses: []*commit.Status{readConflict, readConflict, readConflict, readConflict},
+			expectedStatuses: []*gx.SubmitAndWaitStatus{
+				submitted(1), committed(1, readConflict),
+				endorsed(2), submitted(2), committed(2, readConflict),
+				endorsed(3), submitted(3), committed(3, readConflict),
+			},
+		},
+		{
+			testDef: testDef{
+				name:      "no re-endorsement proposal left",
+				errCode:   codes.FailedPrecondition,
+				errString: "no proposal signed by the client is left to endorse transaction",
+			},
+			maxAttempts:    3,
+			proposals:      1,
+			finderStatuses: []*commit.Status{readConflict, readConflict},
+		},
+		{
+			testDef: testDef{
+				name:      "re-endorsement proposal with another chaincode input",
+				errCode:   codes.InvalidArgument,
+				errString: "invalid re-endorsement proposal: proposal must invoke the chaincode with the input of the proposed transaction",
+			},
+			maxAttempts:    3,
+			proposalArgs:   [][]byte{[]byte("ANOTHER_ARG")},
+			finderStatuses: []*commit.Status{readConflict},
+		},
+		{
+			testDef: testDef{
+				name: "failed signature check of re-endorsement proposal",
+				postSetup: func(t *testing.T, test *preparedTest) {
+					test.policy.CheckACLReturnsOnCall(3, errors.New("SIGNATURE_ERROR"))
+				},
+				errCode:   codes.PermissionDenied,
+				errString: "SIGNATURE_ERROR",
+			},
+			maxAttempts:    3,
+			finderStatuses: []*commit.Status{readConflict},
+		},
+		{
+			testDef: testDef{
+				name: "failed signature check of re-endorsed transaction",
+				postSetup: func(t *testing.T, test *preparedTest) {
+					test.policy.CheckACLReturnsOnCall(4, errors.New("SIGNATURE_ERROR"))
+				},
+				errCode:   codes.PermissionDenied,
+				errString: "SIGNATURE_ERROR",
+			},
+			maxAttempts:    3,
+			finderStatuses: []*commit.Status{readConflict},
+		},
+		{
+			testDef: testDef{
+				name:      "client sends back another transaction",
+				errCode:   codes.InvalidArgument,
+				errString: "the signed transaction is not transaction",
+			},
+			maxAttempts:    3,
+			finderStatuses: []*commit.Status{readConflict},
+			sign: func(request *gx.SubmitAndWaitRequest) (*gx.SubmitAndWaitRequest, error) {
+				request.PreparedTransaction.Payload = []byte("ANOTHER_PAYLOAD")
+				return request, nil
+			},
+		},
+		{
+			testDef: testDef{
+				name:      "client sends back an unsigned transaction",
+				errCode:   codes.InvalidArgument,
+				errString: "prepared transaction must be signed",
+			},
+			maxAttempts:    3,
+			finderStatuses: []*commit.Status{readConflict},
+			sign: func(request *gx.SubmitAndWaitRequest) (*gx.SubmitAndWaitRequest, error) {
+				request.PreparedTransaction.Signature = nil
+				return request, nil
+			},
+		},
+		{
+			testDef: testDef{
+				name:      "client closes the stream before signing the transaction",
+				errCode:   codes.Canceled,
+				errString: "the client closed the stream before signing transaction",
+			},
+			maxAttempts:    3,
+			finderStatuses: []*commit.Status{readConflict},
+			sign: func(request *gx.SubmitAndWaitRequest) (*gx.SubmitAndWaitRequest, error) {
+				return nil, io.EOF
+			},
+		},
+		{
+			testDef: testDef{
+				name:      "failed policy check of proposed transaction",
+				policyErr: errors.New("POLICY_ERROR"),
+				errCode:   codes.PermissionDenied,
+				errString: "POLICY_ERROR",
+			},
+			maxAttempts: 3,
+		},
+		{
+			testDef: testDef{
+				name: "failed policy check of prepared transaction",
+				postSetup: func(t *testing.T, test *preparedTest) {
+					test.policy.CheckACLReturnsOnCall(1, errors.New("POLICY_ERROR"))
+				},
+				errCode:   codes.PermissionDenied,
+				errString: "POLICY_ERROR",
+			},
+			maxAttempts: 3,
+		},
+		{
+			testDef: testDef{
+				name: "failed signature check of re-endorsement policy",
+				postSetup: func(t *testing.T, test *preparedTest) {
+					test.policy.CheckACLReturnsOnCall(2, errors.New("SIGNATURE_ERROR"))
+				},
+				errCode:   codes.PermissionDenied,
+				errString: "SIGNATURE_ERROR",
+			},
+			maxAttempts: 3,
+		},
+		{
+			testDef: testDef{
+				name: "re-endorsement policy checked against creator",
+				postSetup: func(t *testing.T, test *preparedTest) {
+					test.policy.CheckACLCalls(func(policyName string, channelName string, data interface{}) error {
+						require.Equal(t, "gateway/SubmitAndWait", policyName)
+						require.Equal(t, testChannel, channelName)
+						return nil
+					})
+				},
+			},
+			maxAttempts:      3,
+			finderStatuses:   []*commit.Status{valid},
+			expectedStatuses: []*gx.SubmitAndWaitStatus{submitted(1), committed(1, valid)},
+		},
+		{
+			testDef: testDef{
+				name:      "re-endorsement policy bound to another transaction",
+				errCode:   codes.InvalidArgument,
+				errString: "the re-endorsement policy is not bound to transaction",
+			},
+			maxAttempts: 3,
+			policy: func(policy *gx.ReEndorsementPolicy) {
+				policy.TransactionId = "ANOTHER_TX_ID"
+			},
+		},
+		{
+			testDef: testDef{
+				name:      "re-endorsement policy signed by another identity",
+				errCode:   codes.InvalidArgument,
+				errString: "the re-endorsement policy must be signed by the creator of the transaction",
+			},
+			maxAttempts: 3,
+			policy: func(policy *gx.ReEndorsementPolicy) {
+				policy.Identity = []byte("ANOTHER_IDENTITY")
+			},
+		},
+		{
+			testDef: testDef{
+				name:      "error finding transaction status",
+				finderErr: errors.New("FINDER_ERROR"),
+				errCode:   codes.Aborted,
+				errString: "FINDER_ERROR",
+			},
+		},
+		{
+			testDef: testDef{
+				name: "stream closed by the client",
+				postSetup: func(t *testing.T, test *preparedTest) {
+					test.submitServer.SendReturns(io.EOF)
+				},
+				errCode: codes.Canceled,
+			},
+			finderStatuses: []*commit.Status{valid},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.plan = endorsementPlan{
+				"g1": {{endorser: localhostMock}},
+			}
+			test := prepareTest(t, &tt.testDef)
+			endorseWithProposalHash(t, test)
+			test.submitServer.ContextReturns(test.ctx)
+			for i, finderStatus := range tt.finderStatuses {
+				test.finder.TransactionStatusReturnsOnCall(i, finderStatus, nil)
+			}
+
+			endorseResponse, err := test.server.Endorse(test.ctx, &pb.EndorseRequest{ProposedTransaction: test.signedProposal})
+			require.NoError(t, err)
+			preparedTx := endorseResponse.GetPreparedTransaction()
+			preparedTx.Signature = []byte("mysignature")
+
+			txID := transactionID(t, test.signedProposal)
+			request := &gx.SubmitAndWaitRequest{
+				TransactionId:       txID,
+				ChannelId:           testChannel,
+				ProposedTransaction: test.signedProposal,
+				PreparedTransaction: preparedTx,
+			}
+			if tt.maxAttempts > 0 {
+				policy := &gx.ReEndorsementPolicy{
+					ChannelId:     testChannel,
+					TransactionId: txID,
+					Identity:      []byte{}, // creator of the test proposal
+					MaxAttempts:   tt.maxAttempts,
+				}
+				if tt.policy != nil {
+					tt.policy(policy)
+				}
+				request.ReEndorsementPolicy = &gx.SignedReEndorsementPolicy{
+					Policy:    marshal(policy, t),
+					Signature: []byte("policy_signature"),
+				}
+
+				proposals := tt.proposals
+				if proposals == 0 {
+					proposals = int(tt.maxAttempts) - 1
+				}
+				for i := 0; i < proposals; i++ {
+					proposal := createProposal(t, testChannel, testChaincode, tt.transientData, tt.proposalArgs...)
+					signedProposal, err := protoutil.GetSignedProposal(proposal, test.signer)
+					require.NoError(t, err)
+					request.ReEndorsementProposals = append(request.ReEndorsementProposals, signedProposal)
+				}
+			}
+
+			test.submitServer.RecvCalls(func() (*gx.SubmitAndWaitRequest, error) {
+				if test.submitServer.RecvCallCount() == 1 {
+					return request, nil
+				}
+				// the client signs the transaction sent in the last status
+				endorsedStatus := test.submitServer.SendArgsForCall(test.submitServer.SendCallCount() - 1)
+				signed := &gx.SubmitAndWaitRequest{
+					TransactionId:       endorsedStatus.TransactionId,
+					ChannelId:           testChannel,
+					PreparedTransaction: proto.Clone(endorsedStatus.PreparedTransaction).(*cp.Envelope),
+				}
+				signed.PreparedTransaction.Signature = []byte("mysignature")
+				if tt.sign != nil {
+					return tt.sign(signed)
+				}
+				return signed, nil
+			})
+
+			err = test.server.SubmitAndWait(test.submitServer)
+
+			if checkError(t, &tt.testDef, err) {
+				return
+			}
+			require.NoError(t, err)
+
+			require.Equal(t, len(tt.expectedStatuses), test.submitServer.SendCallCount(), "count of statuses")
+			for i, expectedStatus := range tt.expectedStatuses {
+				actualStatus := test.submitServer.SendArgsForCall(i)
+				if actualStatus.Attempt == 1 {
+					require.Equal(t, txID, actualStatus.TransactionId, "status[%d] transaction ID", i)
+				} else {
+					require.NotEqual(t, txID, actualStatus.TransactionId, "status[%d] transaction ID", i)
+				}
+				actualStatus = proto.Clone(actualStatus).(*gx.SubmitAndWaitStatus)
+				actualStatus.TransactionId = ""
+				actualStatus.PreparedTransaction = nil
+				require.True(t, proto.Equal(expectedStatus, actualStatus), "status[%d] mismatch: %v", i, actualStatus)
+			}
+
+			if tt.postTest != nil {
+				tt.postTest(t, test)
+			}
+		})
+	}
+}
+
+func TestSubmitAndWaitMismatchedTransaction(t *testing.T) {
+	test := prepareTest(t, &testDef{
+		plan: endorsementPlan{
+			"g1": {{endorser: localhostMock}},
+		},
+	})
+	endorseWithProposalHash(t, test)
+	endorseResponse, err := test.server.Endorse(test.ctx, &pb.EndorseRequest{ProposedTransaction: test.signedProposal})
+	require.NoError(t, err)
+	preparedTx := endorseResponse.GetPreparedTransaction()
+	preparedTx.Signature = []byte("mysignature")
+
+	otherProposal, err := protoutil.GetSignedProposal(createProposal(t, testChannel, testChaincode, nil), test.signer)
+	require.NoError(t, err)
+
+	request := &gx.SubmitAndWaitRequest{
+		TransactionId:       transactionID(t, otherProposal),
+		ChannelId:           testChannel,
+		ProposedTransaction: otherProposal,
+		PreparedTransaction: preparedTx,
+	}
+	test.submitServer.RecvReturns(request, nil)
+	err = test.server.SubmitAndWait(test.submitServer)
+	require.ErrorIs(t, err, status.Errorf(codes.InvalidArgument, "invalid submit and wait request: prepared transaction is not transaction %s on channel %s", request.TransactionId, testChannel))
+
+	// same transaction ID, with another chaincode input than the one endorsed
+	proposal, err := protoutil.UnmarshalProposal(test.signedProposal.ProposalBytes)
+	require.NoError(t, err)
+	proposal.Payload = createProposal(t, testChannel, testChaincode, nil, []byte("ANOTHER_ARG")).Payload
+	request.ProposedTransaction, err = protoutil.GetSignedProposal(proposal, test.signer)
+	require.NoError(t, err)
+	request.TransactionId = transactionID(t, test.signedProposal)
+	err = test.server.SubmitAndWait(test.submitServer)
+	require.ErrorIs(t, err, status.Error(codes.InvalidArgument, "invalid submit and wait request: the prepared transaction was not endorsed from the proposed transaction"))
+
+	request.PreparedTransaction = &cp.Envelope{Payload: []byte("garbage"), Signature: []byte("mysignature")}
+	err = test.server.SubmitAndWait(test.submitServer)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	require.Zero(t, test.submitServer.SendCallCount())
+	require.Zero(t, test.finder.TransactionStatusCallCount())
+}
+
+// endorseWithProposalHash makes the local endorser respond with the hash of the proposal it endorses.
+func endorseWithProposalHash(t *testing.T, test *preparedTest) {
+	test.localEndorser.ProcessProposalCalls(func(ctx context.Context, signedProposal *peer.SignedProposal, opts ...grpc.CallOption) (*peer.ProposalResponse, error) {
+		proposal, err := protoutil.UnmarshalProposal(signedProposal.ProposalBytes)
+		require.NoError(t, err)
+		header, err := protoutil.UnmarshalHeader(proposal.Header)
+		require.NoError(t, err)
+		proposalHash, err := protoutil.GetProposalHash1(header, proposal.Payload)
+		require.NoError(t, err)
+
+		response := createProposalResponse(t, localhostMock.address, "mock_response", 200, "")
+		payload, err := protoutil.UnmarshalProposalResponsePayload(response.Payload)
+		require.NoError(t, err)
+		payload.ProposalHash = proposalHash
+		response.Payload = marshal(payload, t)
+		return response, nil
+	})
+}
+
+func transactionID(t *testing.T, signedProposal *peer.SignedProposal) string {
+	proposal, err := protoutil.UnmarshalProposal(signedProposal.ProposalBytes)
+	require.NoError(t, err)
+	header, err := protoutil.UnmarshalHeader(proposal.Header)
+	require.NoError(t, err)
+	channelHeader, err := protoutil.UnmarshalChannelHeader(header.ChannelHeader)
+	require.NoError(t, err)
+	return channelHeader.TxId
+}
@@ -7,6 +7,9 @@ SPDX-License-Identifier: Apache-2.0
 package cache
 
 import (
+	"crypto/x509"
+	"time"
+
 	pmsp "github.com/hyperledger/fabric-protos-go/msp"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/msp"
@@ -49,6 +52,19 @@ type cachedMSP struct {
 	satisfiesPrincipalCache *secondChanceCache
 }
 
+// revocationChecker is implemented by MSPs that may check the revocation
+// status of identities online, in which case the outcomes of validating
+// identities must expire
+type revocationChecker interface {
+	OCSPCacheTTL() (time.Duration, bool)
+}
+
+// expiringValue is a cached value which expires
+type expiringValue struct {
+	value   interface{}
+	expires time.Time
+}
+
 type cachedIdentity struct {
 	msp.Identity
 	cache *cachedMSP
@@ -62,6 +78,14 @@ func (id *cachedIdentity) Validate() error {
 	return id.cache.Validate(id.Identity)
 }
 
+func (id *cachedIdentity) OCSPCertificates() (*x509.Certificate, *x509.Certificate, error) {
+	ocspID, ok := id.Identity.(msp.OCSPIdentity)
+	if !ok {
+		return nil, nil, nil
+	}
+	return ocspID.OCSPCertificates()
+}
+
 func (c *cachedMSP) DeserializeIdentity(serializedIdentity []byte) (msp.Identity, error) {
 	id, ok := c.deserializeIdentityCache.get(string(serializedIdentity))
 	if ok {
@@ -87,11 +111,19 @@ func (c *cachedMSP) Setup(config *pmsp.MSPConfig) error {
 	return c.MSP.Setup(config)
 }
 
+func (c *cachedMSP) EnableOCSP(checker *msp.OCSPChecker) error {
+	if err := msp.EnableOCSP(c.MSP, checker); err != nil {
+		return err
+	}
+	c.cleanCache()
+	return nil
+}
+
 func (c *cachedMSP) Validate(id msp.Identity) error {
 	identifier := id.GetIdentifier()
 	key := identifier.Mspid + ":" + identifier.Id
 
-	_, ok := c.validateIdentityCache.get(key)
+	_, ok := c.get(c.validateIdentityCache, key)
 	if ok {
 		// cache only stores if the identity is valid.
 		return nil
@@ -99,7 +131,7 @@ func (c *cachedMSP) Validate(id msp.Identity) error {
 
 	err := c.MSP.Validate(id)
 	if err == nil {
-		c.validateIdentityCache.add(key, true)
+		c.add(c.validateIdentityCache, key, true)
 	}
 
 	return err
@@ -111,7 +143,7 @@ func (c *cachedMSP) SatisfiesPrincipal(id msp.Identity, principal *pmsp.MSPPrinc
 	principalKey := string(principal.PrincipalClassification) + string(principal.Principal)
 	key := identityKey + principalKey
 
-	v, ok := c.satisfiesPrincipalCache.get(key)
+	v, ok := c.get(c.satisfiesPrincipalCache, key)
 	if ok {
 		if v == nil {
 			return nil
@@ -122,10 +154,43 @@ func (c *cachedMSP) SatisfiesPrincipal(id msp.Identity, principal *pmsp.MSPPrinc
 
 	err := c.MSP.SatisfiesPrincipal(id, principal)
 
-	c.satisfiesPrincipalCache.add(key, err)
+	c.add(c.satisfiesPrincipalCache, key, err)
 	return err
 }
 
+// get returns the value cached under the key, unless it has expired.
+func (c *cachedMSP) get(cache *secondChanceCache, key string) (interface{}, bool) {
+	v, ok := cache.get(key)
+	if !ok {
+		return nil, false
+	}
+
+	if ev, isExpiring := v.(*expiringValue); isExpiring {
+		if !time.Now().Before(ev.expires) {
+			return nil, false
+		}
+		return ev.value, true
+	}
+
+	return v, true
+}
+
+// add caches the outcome of validating an identity. If the wrapped MSP checks
+// the revocation status of identities online, the outcome expires along with
+// the revocation status it depends on.
+func (c *cachedMSP) add(cache *secondChanceCache, key string, value interface{}) {
+	if rc, ok := c.MSP.(revocationChecker); ok {
+		if ttl, enabled := rc.OCSPCacheTTL(); enabled {
+			if ttl <= 0 {
+				return
+			}
+			value = &expiringValue{value: value, expires: time.Now().Add(ttl)}
+		}
+	}
+
+	cache.add(key, value)
+}
+
 func (c *cachedMSP) cleanCache() {
 	c.deserializeIdentityCache = newSecondChanceCache(deserializeIdentityCacheSize)
 	c.satisfiesPrincipalCache = newSecondChanceCache(satisfiesPrincipalCacheSize)
@@ -9,6 +9,7 @@ package cache
 import (
 	"sync"
 	"testing"
+	"time"
 
 	msp2 "github.com/hyperledger/fabric-protos-go/msp"
 	"github.com/hyperledger/fabric/msp"
@@ -307,3 +308,84 @@ func TestSatisfiesPrincipal(t *testing.T) {
 	require.NotNil(t, v)
 	require.Contains(t, "Invalid", v.(error).Error())
 }
+
+// revocationCheckingMSP is an MSP which checks the revocation status of
+// identities online
+type revocationCheckingMSP struct {
+	*mocks.MockMSP
+	ttl time.Duration
+}
+
+func (m *revocationCheckingMSP) OCSPCacheTTL() (time.Duration, bool) {
+	return m.ttl, true
+}
+
+func TestValidateExpiresWithRevocationStatus(t *testing.T) {
+	mockMSP := &revocationCheckingMSP{MockMSP: &mocks.MockMSP{}, ttl: time.Minute}
+	i, err := New(mockMSP)
+	require.NoError(t, err)
+
+	mockIdentity := &mocks.MockIdentity{ID: "Alice"}
+	mockIdentity.On("GetIdentifier").Return(&msp.IdentityIdentifier{Mspid: "MSP", Id: "Alice"})
+	mockMSP.On("Validate", mockIdentity).Return(nil)
+	principal := &msp2.MSPPrincipal{PrincipalClassification: msp2.MSPPrincipal_ROLE, Principal: []byte{1, 2, 3}}
+	mockMSP.On("SatisfiesPrincipal", mockIdentity, principal).Return(nil)
+
+	require.NoError(t, i.Validate(mockIdentity))
+	require.NoError(t, i.SatisfiesPrincipal(mockIdentity, principal))
+	require.NoError(t, i.Validate(mockIdentity))
+	require.NoError(t, i.SatisfiesPrincipal(mockIdentity, principal))
+	mockMSP.AssertNumberOfCalls(t, "Validate", 1)
+	mockMSP.AssertNumberOfCalls(t, "SatisfiesPrincipal", 1)
+
+	// expire the cached outcomes
+	for _, cache := range []*secondChanceCache{i.(*cachedMSP).validateIdentityCache, i.(*cachedMSP).satisfiesPrincipalCache} {
+		for _, item := range cache.table {
+			item.value.(*expiringValue).expires = time.Now().Add(-time.Second)
+		}
+	}
+
+	require.NoError(t, i.Validate(mockIdentity))
+	require.NoError(t, i.SatisfiesPrincipal(mockIdentity, principal))
+	mockMSP.AssertNumberOfCalls(t, "Validate", 2)
+	mockMSP.AssertNumberOfCalls(t, "SatisfiesPrincipal", 2)
+
+	// outcomes are not cached when the revocation status is not
+	mockMSP.ttl = 0
+	i, err = New(mockMSP)
+	require.NoError(t, err)
+	require.NoError(t, i.Validate(mockIdentity))
+	require.NoError(t, i.Validate(mockIdentity))
+	mockMSP.AssertNumberOfCalls(t, "Validate", 4)
+	require.Equal(t, 0, i.(*cachedMSP).validateIdentityCache.len())
+}
+
+func (m *revocationCheckingMSP) EnableOCSP(checker *msp.OCSPChecker) error {
+	m.ttl = checker.CacheTTL()
+	return nil
+}
+
+func TestEnableOCSP(t *testing.T) {
+	mockMSP := &mocks.MockMSP{}
+	mockMSP.On("GetType").Return(msp.IDEMIX)
+	i, err := New(mockMSP)
+	require.NoError(t, err)
+	err = msp.EnableOCSP(i, msp.NewOCSPChecker(msp.OCSPConfig{}))
+	require.EqualError(t, err, "MSP of type idemix does not support OCSP")
+
+	revocationMSP := &revocationCheckingMSP{MockMSP: &mocks.MockMSP{}, ttl: time.Hour}
+	i, err = New(revocationMSP)
+	require.NoError(t, err)
+
+	mockIdentity := &mocks.MockIdentity{ID: "Alice"}
+	mockIdentity.On("GetIdentifier").Return(&msp.IdentityIdentifier{Mspid: "MSP", Id: "Alice"})
+	revocationMSP.On("Validate", mockIdentity).Return(nil)
+	require.NoError(t, i.Validate(mockIdentity))
+	require.Equal(t, 1, i.(*cachedMSP).validateIdentityCache.len())
+
+	// the outcomes cached before OCSP is enabled are discarded
+	err = msp.EnableOCSP(i, msp.NewOCSPChecker(msp.OCSPConfig{CacheTTL: time.Minute}))
+	require.NoError(t, err)
+	require.Equal(t, time.Minute, revocationMSP.ttl)
+	require.Equal(t, 0, i.(*cachedMSP).validateIdentityCache.len())
+}
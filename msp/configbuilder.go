@@ -144,9 +144,11 @@ func SetupBCCSPKeystoreConfig(bccspConfig *factory.FactoryOpts, keystoreDir stri
 		}
 
 		// Only override the KeyStorePath if it was left empty
-		if bccspConfig.SW.FileKeystore == nil ||
-			bccspConfig.SW.FileKeystore.KeyStorePath == "" {
-			bccspConfig.SW.FileKeystore = &factory.FileKeystoreOpts{KeyStorePath: keystoreDir}
+		if bccspConfig.SW.FileKeystore == nil {
+			bccspConfig.SW.FileKeystore = &factory.FileKeystoreOpts{}
+		}
+		if bccspConfig.SW.FileKeystore.KeyStorePath == "" {
+			bccspConfig.SW.FileKeystore.KeyStorePath = keystoreDir
 		}
 	}
 
@@ -65,6 +65,17 @@ func TestSetupBCCSPKeystoreConfig(t *testing.T) {
 	rtnConfig = SetupBCCSPKeystoreConfig(bccspConfig, keystoreDir)
 	require.Equal(t, rtnConfig.SW.FileKeystore.KeyStorePath, keystoreDir)
 
+	// Case 2-5 : the key store password is preserved
+	bccspConfig.SW = &factory.SwOpts{
+		Hash:         "SHA2",
+		Security:     256,
+		FileKeystore: &factory.FileKeystoreOpts{Password: "secret", KDF: "SCRYPT"},
+	}
+	rtnConfig = SetupBCCSPKeystoreConfig(bccspConfig, keystoreDir)
+	require.Equal(t, rtnConfig.SW.FileKeystore.KeyStorePath, keystoreDir)
+	require.Equal(t, rtnConfig.SW.FileKeystore.Password, "secret")
+	require.Equal(t, rtnConfig.SW.FileKeystore.KDF, "SCRYPT")
+
 	// Case 3 : Check with 'PKCS11' as default provider
 	// Case 3-1 : without SwOpts
 	bccspConfig.Default = "PKCS11"
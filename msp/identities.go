@@ -50,6 +50,11 @@ type identity struct {
 	// validationErr contains the validation error for this
 	// instance. It can be read if validated is true
 	validationErr error
+
+	// issuer is the certificate of the CA that issued the
+	// certificate of this instance. It is set when the
+	// instance has been validated successfully
+	issuer *x509.Certificate
 }
 
 func newIdentity(cert *x509.Certificate, pk bccsp.Key, msp *bccspmsp) (Identity, error) {
@@ -109,6 +114,15 @@ func (id *identity) Validate() error {
 	return id.msp.Validate(id)
 }
 
+// OCSPCertificates returns the certificate of this instance and the
+// certificate of the CA that issued it, validating the instance if needed
+func (id *identity) OCSPCertificates() (cert, issuer *x509.Certificate, err error) {
+	if err := id.msp.validateIdentityAgainstTrustRoots(id); err != nil {
+		return nil, nil, err
+	}
+	return id.cert, id.issuer, nil
+}
+
 type OUIDs []*OUIdentifier
 
 func (o OUIDs) String() string {
@@ -171,14 +185,9 @@ func (id *identity) Verify(msg []byte, sig []byte) error {
 	// mspIdentityLogger.Infof("Verifying signature")
 
 	// Compute Hash
-	hashOpt, err := id.getHashOpt(id.msp.cryptoConfig.SignatureHashFamily)
-	if err != nil {
-		return errors.WithMessage(err, "failed getting hash function options")
-	}
-
-	digest, err := id.msp.bccsp.Hash(msg, hashOpt)
+	digest, err := id.getDigest(msg)
 	if err != nil {
-		return errors.WithMessage(err, "failed computing digest")
+		return err
 	}
 
 	if mspIdentityLogger.IsEnabledFor(zapcore.DebugLevel) {
@@ -226,6 +235,27 @@ func (id *identity) getHashOpt(hashFamily string) (bccsp.HashOpts, error) {
 	return nil, errors.Errorf("hash family not recognized [%s]", hashFamily)
 }
 
+// getDigest returns the value that gets signed or verified on behalf of this
+// identity. Ed25519 signs the message itself, while all other signature
+// schemes sign the hash of the message computed with the MSP's hash family.
+func (id *identity) getDigest(msg []byte) ([]byte, error) {
+	if id.cert.PublicKeyAlgorithm == x509.Ed25519 {
+		return msg, nil
+	}
+
+	hashOpt, err := id.getHashOpt(id.msp.cryptoConfig.SignatureHashFamily)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed getting hash function options")
+	}
+
+	digest, err := id.msp.bccsp.Hash(msg, hashOpt)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed computing digest")
+	}
+
+	return digest, nil
+}
+
 type signingidentity struct {
 	// we embed everything from a base identity
 	identity
@@ -256,14 +286,9 @@ func (id *signingidentity) Sign(msg []byte) ([]byte, error) {
 	// mspIdentityLogger.Infof("Signing message")
 
 	// Compute Hash
-	hashOpt, err := id.getHashOpt(id.msp.cryptoConfig.SignatureHashFamily)
+	digest, err := id.getDigest(msg)
 	if err != nil {
-		return nil, errors.WithMessage(err, "failed getting hash function options")
-	}
-
-	digest, err := id.msp.bccsp.Hash(msg, hashOpt)
-	if err != nil {
-		return nil, errors.WithMessage(err, "failed computing digest")
+		return nil, err
 	}
 
 	if len(msg) < 32 {
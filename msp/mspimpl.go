@@ -15,6 +15,7 @@ import (
 	"encoding/pem"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	m "github.com/hyperledger/fabric-protos-go/msp"
@@ -104,6 +105,10 @@ type bccspmsp struct {
 	// These are the OUIdentifiers of the clients, peers, admins and orderers.
 	// They are used to tell apart these entities
 	clientOU, peerOU, adminOU, ordererOU *OUIdentifier
+
+	// ocsp checks the revocation status of identities against OCSP
+	// responders; nil if OCSP checking is disabled
+	ocsp *OCSPChecker
 }
 
 // newBccspMsp returns an MSP instance backed up by a BCCSP
@@ -230,9 +235,13 @@ func (msp *bccspmsp) getSigningIdentityFromConf(sidInfo *m.SigningIdentityInfo)
 		if pemKey == nil {
 			return nil, errors.Errorf("%s: wrong PEM encoding", sidInfo.PrivateSigner.KeyIdentifier)
 		}
-		privKey, err = msp.bccsp.KeyImport(pemKey.Bytes, &bccsp.ECDSAPrivateKeyImportOpts{Temporary: true})
+		var importOpts bccsp.KeyImportOpts = &bccsp.ECDSAPrivateKeyImportOpts{Temporary: true}
+		if idPub.(*identity).cert.PublicKeyAlgorithm == x509.Ed25519 {
+			importOpts = &bccsp.ED25519PrivateKeyImportOpts{Temporary: true}
+		}
+		privKey, err = msp.bccsp.KeyImport(pemKey.Bytes, importOpts)
 		if err != nil {
-			return nil, errors.WithMessage(err, "getIdentityFromBytes error: Failed to import EC private key")
+			return nil, errors.WithMessagef(err, "getIdentityFromBytes error: Failed to import %s private key", importOpts.Algorithm())
 		}
 	}
 
@@ -305,6 +314,22 @@ func (msp *bccspmsp) GetDefaultSigningIdentity() (SigningIdentity, error) {
 	return msp.signer, nil
 }
 
+// EnableOCSP makes this MSP check the revocation status of the identities
+// it validates with the given checker
+func (msp *bccspmsp) EnableOCSP(checker *OCSPChecker) error {
+	msp.ocsp = checker
+	return nil
+}
+
+// OCSPCacheTTL returns whether this MSP checks the revocation status of
+// identities against OCSP responders and, if so, how long it caches it
+func (msp *bccspmsp) OCSPCacheTTL() (time.Duration, bool) {
+	if msp.ocsp == nil {
+		return 0, false
+	}
+	return msp.ocsp.CacheTTL(), true
+}
+
 // Validate attempts to determine whether
 // the supplied identity is valid according
 // to this MSP's roots of trust; it returns
@@ -19,6 +19,26 @@ import (
 )
 
 func (msp *bccspmsp) validateIdentity(id *identity) error {
+	err := msp.validateIdentityAgainstTrustRoots(id)
+	if err != nil {
+		return err
+	}
+
+	// the revocation status of an identity may change at any time, hence
+	// it is checked on every validation; the OCSP checker caches it
+	if msp.ocsp != nil {
+		err = msp.ocsp.Check(id.cert, id.issuer)
+		if err != nil {
+			err = errors.WithMessage(err, "could not validate identity's revocation status")
+			mspLogger.Warnf("Could not validate identity: %s (certificate subject=%s issuer=%s serialnumber=%d)", err, id.cert.Subject, id.cert.Issuer, id.cert.SerialNumber)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (msp *bccspmsp) validateIdentityAgainstTrustRoots(id *identity) error {
 	id.validationMutex.Lock()
 	defer id.validationMutex.Unlock()
 
@@ -50,6 +70,8 @@ func (msp *bccspmsp) validateIdentity(id *identity) error {
 		return id.validationErr
 	}
 
+	id.issuer = validationChain[1]
+
 	return nil
 }
 
@@ -0,0 +1,310 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package msp
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/ocsp"
+)
+
+const (
+	// defaultOCSPTimeout is the timeout of requests to OCSP responders
+	// when none is configured
+	defaultOCSPTimeout = 5 * time.Second
+
+	// ocspCacheSize is the maximum number of revocation statuses
+	// cached by an OCSPChecker
+	ocspCacheSize = 1000
+
+	// ocspClockSkew is the clock skew tolerated between OCSP
+	// responders and this node
+	ocspClockSkew = time.Minute
+
+	// maxOCSPResponseSize bounds the size of the responses read
+	// from OCSP responders
+	maxOCSPResponseSize = 1 << 20
+)
+
+// OCSPConfig configures the checking of the revocation status of X.509
+// identities against OCSP (RFC 6960) responders. The revocation status is
+// checked in addition to the CRLs distributed in the MSP configuration.
+type OCSPConfig struct {
+	// ResponderURL is the URL of the OCSP responder that is queried for
+	// all certificates. If empty, the OCSP servers listed in the authority
+	// information access extension of each certificate are queried.
+	ResponderURL string
+
+	// CacheTTL is how long the revocation status of a certificate is
+	// cached. The status is never cached beyond the next update time of
+	// the OCSP response it was obtained from, and the failures to obtain
+	// it from the responders are never cached. Zero disables caching.
+	CacheTTL time.Duration
+
+	// Timeout is the timeout of requests to the OCSP responders. It
+	// defaults to 5 seconds.
+	Timeout time.Duration
+
+	// FailOpen determines whether a certificate whose revocation status
+	// cannot be determined, e.g. because no OCSP responder is reachable
+	// or the responder does not know the certificate, is considered valid.
+	// If false, such a certificate is considered invalid.
+	FailOpen bool
+}
+
+// OCSPChecker checks the revocation status of certificates against OCSP
+// responders and caches the outcomes.
+type OCSPChecker struct {
+	config OCSPConfig
+	client *http.Client
+	now    func() time.Time
+
+	mutex   sync.Mutex
+	entries map[string]*ocspCacheEntry
+}
+
+type ocspCacheEntry struct {
+	// err is the outcome of the check, nil if the certificate is valid
+	err     error
+	expires time.Time
+}
+
+// NewOCSPChecker returns an OCSPChecker configured with the given config.
+func NewOCSPChecker(config OCSPConfig) *OCSPChecker {
+	if config.Timeout == 0 {
+		config.Timeout = defaultOCSPTimeout
+	}
+
+	return &OCSPChecker{
+		config:  config,
+		client:  &http.Client{Timeout: config.Timeout},
+		now:     time.Now,
+		entries: map[string]*ocspCacheEntry{},
+	}
+}
+
+// OCSPEnabler is implemented by the MSPs which can check the revocation
+// status of the identities they validate against OCSP responders.
+type OCSPEnabler interface {
+	// EnableOCSP makes the MSP check the revocation status of the
+	// identities it validates with the given checker.
+	EnableOCSP(checker *OCSPChecker) error
+}
+
+// EnableOCSP makes the MSP check the revocation status of the identities it
+// validates against OCSP responders. As the revocation status of an identity
+// depends on the time and on the responders reached, OCSP must only be enabled
+// on MSPs which are not used to validate transactions, such as the local MSP.
+func EnableOCSP(m MSP, checker *OCSPChecker) error {
+	enabler, ok := m.(OCSPEnabler)
+	if !ok {
+		return errors.Errorf("MSP of type %s does not support OCSP", ProviderTypeToString(m.GetType()))
+	}
+	return enabler.EnableOCSP(checker)
+}
+
+// OCSPIdentity is implemented by the identities whose revocation status can
+// be checked against OCSP responders.
+type OCSPIdentity interface {
+	// OCSPCertificates returns the certificate of the identity and the
+	// certificate of its issuer, or nil certificates if the identity
+	// has no X.509 certificate.
+	OCSPCertificates() (cert, issuer *x509.Certificate, err error)
+}
+
+// CacheTTL returns how long the checker caches the revocation status of
+// certificates.
+func (c *OCSPChecker) CacheTTL() time.Duration {
+	return c.config.CacheTTL
+}
+
+// CheckIdentity checks the revocation status of the certificate of the
+// identity against the OCSP responders. Identities without an X.509
+// certificate are considered valid.
+func (c *OCSPChecker) CheckIdentity(id Identity) error {
+	ocspID, ok := id.(OCSPIdentity)
+	if !ok {
+		return nil
+	}
+	cert, issuer, err := ocspID.OCSPCertificates()
+	if err != nil {
+		return err
+	}
+	if cert == nil || issuer == nil {
+		return nil
+	}
+	return c.Check(cert, issuer)
+}
+
+// Check checks the revocation status of the certificate, issued by the given
+// issuer, against the OCSP responders. It returns an error if the certificate
+// has been revoked, or if its revocation status cannot be determined and the
+// checker does not fail open.
+func (c *OCSPChecker) Check(cert, issuer *x509.Certificate) error {
+	key := ocspCacheKey(cert, issuer)
+	if entry, ok := c.cached(key); ok {
+		return entry.err
+	}
+
+	now := c.now()
+	expires := now.Add(c.config.CacheTTL)
+
+	resp, responder, err := c.query(cert, issuer)
+	if err != nil {
+		// the responders may be reachable again on the next check, hence
+		// only the statuses of valid responses are cached
+		return c.indeterminate(cert, err)
+	}
+
+	switch {
+	case resp.Status == ocsp.Good:
+		err = nil
+	case resp.Status == ocsp.Revoked:
+		err = errors.Errorf("The certificate has been revoked according to OCSP responder %s", responder)
+	default:
+		err = c.indeterminate(cert, errors.Errorf("OCSP responder %s does not know the certificate", responder))
+	}
+
+	if !resp.NextUpdate.IsZero() && resp.NextUpdate.Before(expires) {
+		expires = resp.NextUpdate
+	}
+	if expires.After(now) {
+		c.add(key, &ocspCacheEntry{err: err, expires: expires})
+	}
+
+	return err
+}
+
+// indeterminate returns the outcome of a check for a certificate whose
+// revocation status could not be determined.
+func (c *OCSPChecker) indeterminate(cert *x509.Certificate, err error) error {
+	err = errors.WithMessage(err, "could not determine the revocation status of the certificate")
+	if c.config.FailOpen {
+		mspLogger.Warningf("Considering certificate (subject=%s issuer=%s serialnumber=%d) valid: %s", cert.Subject, cert.Issuer, cert.SerialNumber, err)
+		return nil
+	}
+	return err
+}
+
+// query requests the revocation status of the certificate from the OCSP
+// responders, and returns the first valid response along with the responder
+// that sent it.
+func (c *OCSPChecker) query(cert, issuer *x509.Certificate) (*ocsp.Response, string, error) {
+	responders := cert.OCSPServer
+	if c.config.ResponderURL != "" {
+		responders = []string{c.config.ResponderURL}
+	}
+	if len(responders) == 0 {
+		return nil, "", errors.New("no OCSP responder is known for the certificate")
+	}
+
+	req, err := ocsp.CreateRequest(cert, issuer, nil)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "failed creating OCSP request")
+	}
+
+	var lastErr error
+	for _, responder := range responders {
+		resp, err := c.queryResponder(responder, req, cert, issuer)
+		if err != nil {
+			mspLogger.Debugf("Failed querying OCSP responder %s: %s", responder, err)
+			lastErr = err
+			continue
+		}
+		return resp, responder, nil
+	}
+
+	return nil, "", lastErr
+}
+
+func (c *OCSPChecker) queryResponder(responder string, req []byte, cert, issuer *x509.Certificate) (*ocsp.Response, error) {
+	httpResp, err := c.client.Post(responder, "application/ocsp-request", bytes.NewReader(req))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed querying OCSP responder %s", responder)
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("OCSP responder %s returned status %s", responder, httpResp.Status)
+	}
+
+	raw, err := io.ReadAll(io.LimitReader(httpResp.Body, maxOCSPResponseSize))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed reading response of OCSP responder %s", responder)
+	}
+
+	resp, err := ocsp.ParseResponseForCert(raw, cert, issuer)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid response from OCSP responder %s", responder)
+	}
+
+	now := c.now()
+	if resp.ThisUpdate.After(now.Add(ocspClockSkew)) {
+		return nil, errors.Errorf("response from OCSP responder %s is not valid before %s", responder, resp.ThisUpdate)
+	}
+	if !resp.NextUpdate.IsZero() && resp.NextUpdate.Before(now) {
+		return nil, errors.Errorf("response from OCSP responder %s expired at %s", responder, resp.NextUpdate)
+	}
+
+	return resp, nil
+}
+
+func (c *OCSPChecker) cached(key string) (*ocspCacheEntry, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !c.now().Before(entry.expires) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return entry, true
+}
+
+func (c *OCSPChecker) add(key string, entry *ocspCacheEntry) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if len(c.entries) >= ocspCacheSize {
+		now := c.now()
+		for k, e := range c.entries {
+			if !now.Before(e.expires) {
+				delete(c.entries, k)
+			}
+		}
+	}
+	// if no entry has expired, evict an arbitrary one
+	for k := range c.entries {
+		if len(c.entries) < ocspCacheSize {
+			break
+		}
+		delete(c.entries, k)
+	}
+
+	c.entries[key] = entry
+}
+
+// ocspCacheKey identifies a certificate by its issuer and serial number,
+// as OCSP does.
+func ocspCacheKey(cert, issuer *x509.Certificate) string {
+	h := sha256.New()
+	h.Write(issuer.RawSubject)
+	h.Write(issuer.RawSubjectPublicKeyInfo)
+	h.Write(cert.SerialNumber.Bytes())
+	return hex.EncodeToString(h.Sum(nil))
+}
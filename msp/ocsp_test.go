@@ -0,0 +1,458 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package msp
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/sw"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ocsp"
+)
+
+type ocspTestCA struct {
+	cert    *x509.Certificate
+	certPEM []byte
+	key     *ecdsa.PrivateKey
+}
+
+func newOCSPTestCA(t *testing.T) *ocspTestCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	ski := sha256.Sum256(elliptic.Marshal(key.Curve, key.X, key.Y))
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "ca.org1.example.com", Organization: []string{"Org1"}},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		SubjectKeyId:          ski[:],
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return &ocspTestCA{
+		cert:    cert,
+		certPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		key:     key,
+	}
+}
+
+func (ca *ocspTestCA) issue(t *testing.T, serial int64, ocspServers ...string) *x509.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:   big.NewInt(serial),
+		Subject:        pkix.Name{CommonName: "user", Organization: []string{"Org1"}},
+		NotBefore:      time.Now().Add(-time.Hour),
+		NotAfter:       time.Now().Add(24 * time.Hour),
+		KeyUsage:       x509.KeyUsageDigitalSignature,
+		AuthorityKeyId: ca.cert.SubjectKeyId,
+		OCSPServer:     ocspServers,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return cert
+}
+
+// ocspTestResponder is an in-process OCSP responder which answers for the
+// certificates issued by a test CA.
+type ocspTestResponder struct {
+	*httptest.Server
+	ca *ocspTestCA
+
+	mutex      sync.Mutex
+	statuses   map[int64]int
+	nextUpdate time.Duration
+	requests   int
+	// unavailable makes the responder fail all requests
+	unavailable bool
+}
+
+func newOCSPTestResponder(t *testing.T, ca *ocspTestCA) *ocspTestResponder {
+	r := &ocspTestResponder{
+		ca:         ca,
+		statuses:   map[int64]int{},
+		nextUpdate: time.Hour,
+	}
+	r.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		r.mutex.Lock()
+		defer r.mutex.Unlock()
+		r.requests++
+		if r.unavailable {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		raw, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		ocspReq, err := ocsp.ParseRequest(raw)
+		require.NoError(t, err)
+
+		status, ok := r.statuses[ocspReq.SerialNumber.Int64()]
+		if !ok {
+			status = ocsp.Unknown
+		}
+		now := time.Now()
+		template := ocsp.Response{
+			Status:       status,
+			SerialNumber: ocspReq.SerialNumber,
+			ThisUpdate:   now.Add(-time.Minute),
+			NextUpdate:   now.Add(r.nextUpdate),
+			RevokedAt:    now.Add(-time.Minute),
+		}
+		resp, err := ocsp.CreateResponse(ca.cert, ca.cert, template, ca.key)
+		require.NoError(t, err)
+
+		w.Header().Set("Content-Type", "application/ocsp-response")
+		w.Write(resp)
+	}))
+	t.Cleanup(r.Close)
+
+	return r
+}
+
+func (r *ocspTestResponder) setStatus(serial int64, status int) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.statuses[serial] = status
+}
+
+func (r *ocspTestResponder) setUnavailable(unavailable bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.unavailable = unavailable
+}
+
+func (r *ocspTestResponder) requestCount() int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.requests
+}
+
+func TestOCSPChecker(t *testing.T) {
+	ca := newOCSPTestCA(t)
+	responder := newOCSPTestResponder(t, ca)
+	responder.setStatus(2, ocsp.Good)
+	responder.setStatus(3, ocsp.Revoked)
+
+	t.Run("Good", func(t *testing.T) {
+		checker := NewOCSPChecker(OCSPConfig{})
+		require.NoError(t, checker.Check(ca.issue(t, 2, responder.URL), ca.cert))
+	})
+
+	t.Run("Revoked", func(t *testing.T) {
+		checker := NewOCSPChecker(OCSPConfig{FailOpen: true})
+		err := checker.Check(ca.issue(t, 3, responder.URL), ca.cert)
+		require.EqualError(t, err, "The certificate has been revoked according to OCSP responder "+responder.URL)
+	})
+
+	t.Run("Unknown", func(t *testing.T) {
+		cert := ca.issue(t, 4, responder.URL)
+
+		checker := NewOCSPChecker(OCSPConfig{})
+		err := checker.Check(cert, ca.cert)
+		require.EqualError(t, err, "could not determine the revocation status of the certificate: OCSP responder "+responder.URL+" does not know the certificate")
+
+		checker = NewOCSPChecker(OCSPConfig{FailOpen: true})
+		require.NoError(t, checker.Check(cert, ca.cert))
+	})
+
+	t.Run("ResponderUnavailable", func(t *testing.T) {
+		unavailable := httptest.NewServer(http.NotFoundHandler())
+		unavailable.Close()
+		cert := ca.issue(t, 2, unavailable.URL)
+
+		checker := NewOCSPChecker(OCSPConfig{})
+		err := checker.Check(cert, ca.cert)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed querying OCSP responder "+unavailable.URL)
+		require.Contains(t, err.Error(), "could not determine the revocation status of the certificate")
+
+		checker = NewOCSPChecker(OCSPConfig{FailOpen: true})
+		require.NoError(t, checker.Check(cert, ca.cert))
+	})
+
+	t.Run("FallbackResponder", func(t *testing.T) {
+		unavailable := httptest.NewServer(http.NotFoundHandler())
+		defer unavailable.Close()
+
+		checker := NewOCSPChecker(OCSPConfig{})
+		err := checker.Check(ca.issue(t, 3, unavailable.URL, responder.URL), ca.cert)
+		require.EqualError(t, err, "The certificate has been revoked according to OCSP responder "+responder.URL)
+	})
+
+	t.Run("NoResponder", func(t *testing.T) {
+		checker := NewOCSPChecker(OCSPConfig{})
+		err := checker.Check(ca.issue(t, 2), ca.cert)
+		require.EqualError(t, err, "could not determine the revocation status of the certificate: no OCSP responder is known for the certificate")
+	})
+
+	t.Run("ConfiguredResponder", func(t *testing.T) {
+		checker := NewOCSPChecker(OCSPConfig{ResponderURL: responder.URL})
+		err := checker.Check(ca.issue(t, 3, "http://localhost:0/ocsp"), ca.cert)
+		require.EqualError(t, err, "The certificate has been revoked according to OCSP responder "+responder.URL)
+	})
+
+	t.Run("InvalidResponse", func(t *testing.T) {
+		otherCA := newOCSPTestCA(t)
+		otherResponder := newOCSPTestResponder(t, otherCA)
+		otherResponder.setStatus(2, ocsp.Good)
+
+		checker := NewOCSPChecker(OCSPConfig{})
+		err := checker.Check(ca.issue(t, 2, otherResponder.URL), ca.cert)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid response from OCSP responder "+otherResponder.URL)
+	})
+
+	t.Run("ExpiredResponse", func(t *testing.T) {
+		checker := NewOCSPChecker(OCSPConfig{})
+		checker.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+		err := checker.Check(ca.issue(t, 2, responder.URL), ca.cert)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "response from OCSP responder "+responder.URL+" expired at")
+	})
+}
+
+func TestOCSPCheckerCache(t *testing.T) {
+	ca := newOCSPTestCA(t)
+	responder := newOCSPTestResponder(t, ca)
+	responder.setStatus(2, ocsp.Good)
+	cert := ca.issue(t, 2, responder.URL)
+
+	now := time.Now()
+	checker := NewOCSPChecker(OCSPConfig{CacheTTL: 10 * time.Minute})
+	checker.now = func() time.Time { return now }
+
+	require.NoError(t, checker.Check(cert, ca.cert))
+	require.Equal(t, 1, responder.requestCount())
+
+	// the status is cached
+	responder.setStatus(2, ocsp.Revoked)
+	require.NoError(t, checker.Check(cert, ca.cert))
+	require.Equal(t, 1, responder.requestCount())
+
+	// until the cache TTL elapses
+	now = now.Add(10 * time.Minute)
+	require.Error(t, checker.Check(cert, ca.cert))
+	require.Equal(t, 2, responder.requestCount())
+
+	// negative outcomes are cached as well
+	require.Error(t, checker.Check(cert, ca.cert))
+	require.Equal(t, 2, responder.requestCount())
+
+	t.Run("NextUpdate", func(t *testing.T) {
+		responder.mutex.Lock()
+		responder.nextUpdate = time.Minute
+		responder.mutex.Unlock()
+		cert := ca.issue(t, 5, responder.URL)
+		responder.setStatus(5, ocsp.Good)
+
+		now := time.Now()
+		checker := NewOCSPChecker(OCSPConfig{CacheTTL: 10 * time.Minute})
+		checker.now = func() time.Time { return now }
+
+		requests := responder.requestCount()
+		require.NoError(t, checker.Check(cert, ca.cert))
+		require.Equal(t, requests+1, responder.requestCount())
+
+		_, ok := checker.cached(ocspCacheKey(cert, ca.cert))
+		require.True(t, ok)
+
+		// the status is not cached beyond the next update of the response
+		now = now.Add(2 * time.Minute)
+		_, ok = checker.cached(ocspCacheKey(cert, ca.cert))
+		require.False(t, ok)
+	})
+
+	t.Run("ResponderRecovers", func(t *testing.T) {
+		cert := ca.issue(t, 6, responder.URL)
+		responder.setStatus(6, ocsp.Good)
+		responder.setUnavailable(true)
+		defer responder.setUnavailable(false)
+
+		checker := NewOCSPChecker(OCSPConfig{CacheTTL: 10 * time.Minute})
+
+		requests := responder.requestCount()
+		err := checker.Check(cert, ca.cert)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "OCSP responder "+responder.URL+" returned status 503 Service Unavailable")
+		require.Equal(t, requests+1, responder.requestCount())
+
+		// the failure is not cached, the responder is queried again
+		responder.setUnavailable(false)
+		require.NoError(t, checker.Check(cert, ca.cert))
+		require.Equal(t, requests+2, responder.requestCount())
+
+		// while the status it returned is
+		require.NoError(t, checker.Check(cert, ca.cert))
+		require.Equal(t, requests+2, responder.requestCount())
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		checker := NewOCSPChecker(OCSPConfig{})
+
+		requests := responder.requestCount()
+		require.Error(t, checker.Check(cert, ca.cert))
+		require.Error(t, checker.Check(cert, ca.cert))
+		require.Equal(t, requests+2, responder.requestCount())
+	})
+
+	t.Run("Bounded", func(t *testing.T) {
+		checker := NewOCSPChecker(OCSPConfig{CacheTTL: time.Minute})
+		for i := 0; i < ocspCacheSize+10; i++ {
+			checker.add(string(rune(i)), &ocspCacheEntry{expires: time.Now().Add(time.Minute)})
+		}
+		require.Len(t, checker.entries, ocspCacheSize)
+	})
+}
+
+func TestMSPOCSPValidation(t *testing.T) {
+	ca := newOCSPTestCA(t)
+	responder := newOCSPTestResponder(t, ca)
+	responder.setStatus(2, ocsp.Good)
+	responder.setStatus(3, ocsp.Revoked)
+
+	// the admin has no revocation status, as OCSP is enabled once the MSP is set up
+	admin := ca.issue(t, 100, responder.URL)
+	setupMSP := func(t *testing.T) MSP {
+		cryptoProvider, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewDummyKeyStore())
+		require.NoError(t, err)
+		thisMSP, err := newBccspMsp(MSPv1_4_3, cryptoProvider)
+		require.NoError(t, err)
+
+		conf, err := proto.Marshal(&msp.FabricMSPConfig{
+			Name:      "Org1MSP",
+			RootCerts: [][]byte{ca.certPEM},
+			Admins:    [][]byte{pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: admin.Raw})},
+			CryptoConfig: &msp.FabricCryptoConfig{
+				SignatureHashFamily:            bccsp.SHA2,
+				IdentityIdentifierHashFunction: bccsp.SHA256,
+			},
+		})
+		require.NoError(t, err)
+		err = thisMSP.Setup(&msp.MSPConfig{Type: int32(FABRIC), Config: conf})
+		require.NoError(t, err)
+
+		return thisMSP
+	}
+
+	deserialize := func(t *testing.T, thisMSP MSP, cert *x509.Certificate) Identity {
+		serialized, err := proto.Marshal(&msp.SerializedIdentity{
+			Mspid:   "Org1MSP",
+			IdBytes: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}),
+		})
+		require.NoError(t, err)
+		id, err := thisMSP.DeserializeIdentity(serialized)
+		require.NoError(t, err)
+		return id
+	}
+
+	good := ca.issue(t, 2, responder.URL)
+	revoked := ca.issue(t, 3, responder.URL)
+
+	t.Run("Disabled", func(t *testing.T) {
+		thisMSP := setupMSP(t)
+		require.NoError(t, thisMSP.Validate(deserialize(t, thisMSP, good)))
+		require.NoError(t, thisMSP.Validate(deserialize(t, thisMSP, revoked)))
+
+		_, enabled := thisMSP.(*bccspmsp).OCSPCacheTTL()
+		require.False(t, enabled)
+	})
+
+	t.Run("Enabled", func(t *testing.T) {
+		thisMSP := setupMSP(t)
+		err := EnableOCSP(thisMSP, NewOCSPChecker(OCSPConfig{CacheTTL: time.Minute}))
+		require.NoError(t, err)
+
+		require.NoError(t, thisMSP.Validate(deserialize(t, thisMSP, good)))
+
+		id := deserialize(t, thisMSP, revoked)
+		err = thisMSP.Validate(id)
+		require.EqualError(t, err, "could not validate identity's revocation status: The certificate has been revoked according to OCSP responder "+responder.URL)
+		require.EqualError(t, id.Validate(), err.Error())
+
+		ttl, enabled := thisMSP.(*bccspmsp).OCSPCacheTTL()
+		require.True(t, enabled)
+		require.Equal(t, time.Minute, ttl)
+	})
+}
+
+func TestOCSPCheckerCheckIdentity(t *testing.T) {
+	ca := newOCSPTestCA(t)
+	responder := newOCSPTestResponder(t, ca)
+	responder.setStatus(2, ocsp.Good)
+	responder.setStatus(3, ocsp.Revoked)
+
+	admin := ca.issue(t, 100, responder.URL)
+	cryptoProvider, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewDummyKeyStore())
+	require.NoError(t, err)
+	thisMSP, err := newBccspMsp(MSPv1_4_3, cryptoProvider)
+	require.NoError(t, err)
+	conf, err := proto.Marshal(&msp.FabricMSPConfig{
+		Name:      "Org1MSP",
+		RootCerts: [][]byte{ca.certPEM},
+		Admins:    [][]byte{pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: admin.Raw})},
+		CryptoConfig: &msp.FabricCryptoConfig{
+			SignatureHashFamily:            bccsp.SHA2,
+			IdentityIdentifierHashFunction: bccsp.SHA256,
+		},
+	})
+	require.NoError(t, err)
+	err = thisMSP.Setup(&msp.MSPConfig{Type: int32(FABRIC), Config: conf})
+	require.NoError(t, err)
+
+	deserialize := func(cert *x509.Certificate) Identity {
+		serialized, err := proto.Marshal(&msp.SerializedIdentity{
+			Mspid:   "Org1MSP",
+			IdBytes: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}),
+		})
+		require.NoError(t, err)
+		id, err := thisMSP.DeserializeIdentity(serialized)
+		require.NoError(t, err)
+		return id
+	}
+
+	checker := NewOCSPChecker(OCSPConfig{})
+
+	err = checker.CheckIdentity(deserialize(ca.issue(t, 2, responder.URL)))
+	require.NoError(t, err)
+
+	err = checker.CheckIdentity(deserialize(ca.issue(t, 3, responder.URL)))
+	require.EqualError(t, err, "The certificate has been revoked according to OCSP responder "+responder.URL)
+
+	// the MSP itself does not check the revocation status unless enabled
+	require.NoError(t, thisMSP.Validate(deserialize(ca.issue(t, 3, responder.URL))))
+
+	// identities without an X.509 certificate have no revocation status
+	require.NoError(t, checker.CheckIdentity(struct{ Identity }{}))
+}
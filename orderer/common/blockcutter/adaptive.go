@@ -0,0 +1,111 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package blockcutter
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric/orderer/common/ordererext"
+)
+
+const (
+	// ewmaWeight is the weight of the latest sample in the moving averages of the
+	// interval between messages and of the commit latency
+	ewmaWeight = 0.2
+
+	// maxPendingCuts bounds the count of cut batches whose block commit is awaited.
+	// The batches cut by a leader that loses its leadership are never committed,
+	// so the oldest cut times are dropped past this count.
+	maxPendingCuts = 64
+)
+
+// CommitObserver is implemented by the Receivers that adapt the size of the batches to the latency of block commits
+type CommitObserver interface {
+	// BlockCommitted should be invoked as the blocks made of the batches cut by the Receiver are committed
+	BlockCommitted()
+}
+
+// adaptiveTarget computes the target message count of the batches from the moving averages of the
+// ingress rate of messages and of the latency from a batch being cut to its block being committed.
+// The batch is expected to be filled within the target latency less the commit latency.
+type adaptiveTarget struct {
+	mutex sync.Mutex
+
+	lastArrival     time.Time
+	arrivalInterval *float64 // in seconds, nil until two messages have arrived
+	commitLatency   *float64 // in seconds, nil until a block has been committed
+	cutTimes        []time.Time
+}
+
+func (a *adaptiveTarget) messageArrived(now time.Time) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if !a.lastArrival.IsZero() {
+		a.arrivalInterval = movingAverage(a.arrivalInterval, now.Sub(a.lastArrival).Seconds())
+	}
+	a.lastArrival = now
+}
+
+func (a *adaptiveTarget) batchCut(now time.Time) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if len(a.cutTimes) == maxPendingCuts {
+		a.cutTimes = a.cutTimes[1:]
+	}
+	a.cutTimes = append(a.cutTimes, now)
+}
+
+func (a *adaptiveTarget) blockCommitted(now time.Time) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if len(a.cutTimes) == 0 {
+		// the block was cut by another orderer
+		return
+	}
+	a.commitLatency = movingAverage(a.commitLatency, now.Sub(a.cutTimes[0]).Seconds())
+	a.cutTimes = a.cutTimes[1:]
+}
+
+// target returns the message count of the next batch, within the bounds of the config.
+// Until the ingress rate is known, the batches are cut at the lower bound.
+func (a *adaptiveTarget) target(config *ordererext.AdaptiveBatchSize) uint32 {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	// the target latency is validated along with the channel config
+	targetLatency, _ := time.ParseDuration(config.TargetLatency)
+	fillBudget := targetLatency.Seconds()
+	if a.commitLatency != nil {
+		fillBudget -= *a.commitLatency
+	}
+	if a.arrivalInterval == nil || fillBudget <= 0 {
+		return config.MinMessageCount
+	}
+
+	// a zero interval, as measured between the messages of a burst, yields an infinite count
+	count := math.Floor(fillBudget / *a.arrivalInterval)
+	switch {
+	case count < float64(config.MinMessageCount):
+		return config.MinMessageCount
+	case count > float64(config.MaxMessageCount):
+		return config.MaxMessageCount
+	default:
+		return uint32(count)
+	}
+}
+
+func movingAverage(average *float64, sample float64) *float64 {
+	if average != nil {
+		sample = ewmaWeight*sample + (1-ewmaWeight)*(*average)
+	}
+	return &sample
+}
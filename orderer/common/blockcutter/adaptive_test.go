@@ -0,0 +1,88 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package blockcutter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/orderer/common/ordererext"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdaptiveTarget(t *testing.T) {
+	config := &ordererext.AdaptiveBatchSize{
+		MinMessageCount: 10,
+		MaxMessageCount: 500,
+		TargetLatency:   "1s",
+	}
+	start := time.Unix(1000, 0)
+
+	t.Run("unknown ingress rate", func(t *testing.T) {
+		a := &adaptiveTarget{}
+		require.Equal(t, uint32(10), a.target(config))
+		a.messageArrived(start)
+		require.Equal(t, uint32(10), a.target(config))
+	})
+
+	t.Run("steady ingress rate", func(t *testing.T) {
+		a := &adaptiveTarget{}
+		for i := 0; i < 100; i++ {
+			a.messageArrived(start.Add(time.Duration(i) * 5 * time.Millisecond))
+		}
+		// 200 messages per second during the target latency of 1s
+		require.Equal(t, uint32(200), a.target(config))
+
+		// a commit latency of 400ms leaves 600ms to fill the batch
+		a.batchCut(start)
+		a.blockCommitted(start.Add(400 * time.Millisecond))
+		require.Equal(t, uint32(120), a.target(config))
+	})
+
+	t.Run("bounds", func(t *testing.T) {
+		a := &adaptiveTarget{}
+		a.messageArrived(start)
+		a.messageArrived(start)
+		require.Equal(t, uint32(500), a.target(config), "burst of messages")
+
+		a = &adaptiveTarget{}
+		a.messageArrived(start)
+		a.messageArrived(start.Add(time.Second))
+		require.Equal(t, uint32(10), a.target(config), "idle channel")
+
+		a = &adaptiveTarget{}
+		a.messageArrived(start)
+		a.messageArrived(start.Add(time.Millisecond))
+		a.batchCut(start)
+		a.blockCommitted(start.Add(2 * time.Second))
+		require.Equal(t, uint32(10), a.target(config), "commit latency above the target latency")
+	})
+
+	t.Run("moving averages", func(t *testing.T) {
+		a := &adaptiveTarget{}
+		a.messageArrived(start)
+		a.messageArrived(start.Add(10 * time.Millisecond))
+		a.messageArrived(start.Add(20 * time.Millisecond))
+		a.messageArrived(start.Add(70 * time.Millisecond))
+		// 0.2*50ms + 0.8*10ms
+		require.InDelta(t, 0.018, *a.arrivalInterval, 1e-9)
+	})
+
+	t.Run("pending cuts", func(t *testing.T) {
+		a := &adaptiveTarget{}
+		a.blockCommitted(start)
+		require.Nil(t, a.commitLatency, "block cut by another orderer")
+
+		for i := 0; i < maxPendingCuts+1; i++ {
+			a.batchCut(start.Add(time.Duration(i) * time.Second))
+		}
+		require.Len(t, a.cutTimes, maxPendingCuts)
+		a.blockCommitted(start.Add(time.Duration(maxPendingCuts) * time.Second))
+		require.InDelta(t, float64(maxPendingCuts-1), *a.commitLatency, 1e-9)
+		require.Len(t, a.cutTimes, maxPendingCuts-1)
+	})
+}
@@ -12,6 +12,7 @@ import (
 	cb "github.com/hyperledger/fabric-protos-go/common"
 	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/orderer/common/ordererext"
 )
 
 var logger = flogging.MustGetLogger("orderer.common.blockcutter")
@@ -35,6 +36,8 @@ type receiver struct {
 	sharedConfigFetcher   OrdererConfigFetcher
 	pendingBatch          []*cb.Envelope
 	pendingBatchSizeBytes uint32
+	targetMessageCount    uint32
+	adaptiveTarget        adaptiveTarget
 
 	PendingBatchStartTime time.Time
 	ChannelID             string
@@ -59,7 +62,8 @@ func NewReceiverImpl(channelID string, sharedConfigFetcher OrdererConfigFetcher,
 //   - no batch is cut and there are messages pending
 //
 // messageBatches length: 1, pending: false
-//   - the message count reaches BatchSize.MaxMessageCount
+//   - the message count reaches BatchSize.MaxMessageCount, or the target message count when the
+//     AdaptiveBatchSize is set in the channel config
 //
 // messageBatches length: 1, pending: true
 //   - the current message will cause the pending batch size in bytes to exceed BatchSize.PreferredMaxBytes.
@@ -72,17 +76,22 @@ func NewReceiverImpl(channelID string, sharedConfigFetcher OrdererConfigFetcher,
 //
 // Note that messageBatches can not be greater than 2.
 func (r *receiver) Ordered(msg *cb.Envelope) (messageBatches [][]*cb.Envelope, pending bool) {
-	if len(r.pendingBatch) == 0 {
-		// We are beginning a new batch, mark the time
-		r.PendingBatchStartTime = time.Now()
-	}
-
 	ordererConfig, ok := r.sharedConfigFetcher.OrdererConfig()
 	if !ok {
 		logger.Panicf("Could not retrieve orderer config to query batch parameters, block cutting is not possible")
 	}
 
 	batchSize := ordererConfig.BatchSize()
+	adaptiveBatchSize := ordererConfig.AdaptiveBatchSize()
+	if adaptiveBatchSize != nil {
+		r.adaptiveTarget.messageArrived(time.Now())
+	}
+
+	if len(r.pendingBatch) == 0 {
+		// We are beginning a new batch, mark the time
+		r.PendingBatchStartTime = time.Now()
+		r.setTargetMessageCount(batchSize.MaxMessageCount, adaptiveBatchSize)
+	}
 
 	messageSizeBytes := messageSizeBytes(msg)
 	if messageSizeBytes > batchSize.PreferredMaxBytes {
@@ -99,6 +108,7 @@ func (r *receiver) Ordered(msg *cb.Envelope) (messageBatches [][]*cb.Envelope, p
 
 		// Record that this batch took no time to fill
 		r.Metrics.BlockFillDuration.With("channel", r.ChannelID).Observe(0)
+		r.adaptiveTarget.batchCut(time.Now())
 
 		return
 	}
@@ -110,6 +120,7 @@ func (r *receiver) Ordered(msg *cb.Envelope) (messageBatches [][]*cb.Envelope, p
 		logger.Debugf("Pending batch would overflow if current message is added, cutting batch now.")
 		messageBatch := r.Cut()
 		r.PendingBatchStartTime = time.Now()
+		r.setTargetMessageCount(batchSize.MaxMessageCount, adaptiveBatchSize)
 		messageBatches = append(messageBatches, messageBatch)
 	}
 
@@ -118,7 +129,7 @@ func (r *receiver) Ordered(msg *cb.Envelope) (messageBatches [][]*cb.Envelope, p
 	r.pendingBatchSizeBytes += messageSizeBytes
 	pending = true
 
-	if uint32(len(r.pendingBatch)) >= batchSize.MaxMessageCount {
+	if uint32(len(r.pendingBatch)) >= r.targetMessageCount {
 		logger.Debugf("Batch size met, cutting batch")
 		messageBatch := r.Cut()
 		messageBatches = append(messageBatches, messageBatch)
@@ -132,6 +143,7 @@ func (r *receiver) Ordered(msg *cb.Envelope) (messageBatches [][]*cb.Envelope, p
 func (r *receiver) Cut() []*cb.Envelope {
 	if r.pendingBatch != nil {
 		r.Metrics.BlockFillDuration.With("channel", r.ChannelID).Observe(time.Since(r.PendingBatchStartTime).Seconds())
+		r.adaptiveTarget.batchCut(time.Now())
 	}
 	r.PendingBatchStartTime = time.Time{}
 	batch := r.pendingBatch
@@ -140,6 +152,23 @@ func (r *receiver) Cut() []*cb.Envelope {
 	return batch
 }
 
+// BlockCommitted records the latency from the oldest batch cut by the receiver to the commit of its block,
+// which bounds the size of the batches when they are cut adaptively
+func (r *receiver) BlockCommitted() {
+	r.adaptiveTarget.blockCommitted(time.Now())
+}
+
+// Sets the message count at which the pending batch is cut, either the static BatchSize.MaxMessageCount
+// or, when the AdaptiveBatchSize is set, the count adjusted to the recent load
+func (r *receiver) setTargetMessageCount(maxMessageCount uint32, adaptiveBatchSize *ordererext.AdaptiveBatchSize) {
+	r.targetMessageCount = maxMessageCount
+	if adaptiveBatchSize != nil {
+		r.targetMessageCount = r.adaptiveTarget.target(adaptiveBatchSize)
+		logger.Debugf("Target message count of the batch adjusted to %d", r.targetMessageCount)
+	}
+	r.Metrics.TargetBatchSize.With("channel", r.ChannelID).Set(float64(r.targetMessageCount))
+}
+
 func messageSizeBytes(message *cb.Envelope) uint32 {
 	return uint32(len(message.Payload) + len(message.Signature))
 }
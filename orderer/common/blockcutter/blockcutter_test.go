@@ -14,6 +14,7 @@ import (
 	ab "github.com/hyperledger/fabric-protos-go/orderer"
 	"github.com/hyperledger/fabric/orderer/common/blockcutter"
 	"github.com/hyperledger/fabric/orderer/common/blockcutter/mock"
+	"github.com/hyperledger/fabric/orderer/common/ordererext"
 )
 
 var _ = Describe("Blockcutter", func() {
@@ -24,6 +25,7 @@ var _ = Describe("Blockcutter", func() {
 
 		metrics               *blockcutter.Metrics
 		fakeBlockFillDuration *mock.MetricsHistogram
+		fakeTargetBatchSize   *mock.MetricsGauge
 	)
 
 	BeforeEach(func() {
@@ -33,8 +35,11 @@ var _ = Describe("Blockcutter", func() {
 
 		fakeBlockFillDuration = &mock.MetricsHistogram{}
 		fakeBlockFillDuration.WithReturns(fakeBlockFillDuration)
+		fakeTargetBatchSize = &mock.MetricsGauge{}
+		fakeTargetBatchSize.WithReturns(fakeTargetBatchSize)
 		metrics = &blockcutter.Metrics{
 			BlockFillDuration: fakeBlockFillDuration,
+			TargetBatchSize:   fakeTargetBatchSize,
 		}
 
 		bc = blockcutter.NewReceiverImpl("mychannel", fakeConfigFetcher, metrics)
@@ -74,6 +79,57 @@ var _ = Describe("Blockcutter", func() {
 				Expect(fakeBlockFillDuration.ObserveArgsForCall(0)).To(BeNumerically("<", 1))
 				Expect(fakeBlockFillDuration.WithCallCount()).To(Equal(1))
 				Expect(fakeBlockFillDuration.WithArgsForCall(0)).To(Equal([]string{"channel", "mychannel"}))
+
+				Expect(fakeTargetBatchSize.SetCallCount()).To(Equal(1))
+				Expect(fakeTargetBatchSize.SetArgsForCall(0)).To(Equal(float64(2)))
+				Expect(fakeTargetBatchSize.WithArgsForCall(0)).To(Equal([]string{"channel", "mychannel"}))
+			})
+		})
+
+		Context("when the adaptive batch size is set", func() {
+			BeforeEach(func() {
+				fakeConfig.BatchSizeReturns(&ab.BatchSize{
+					MaxMessageCount:   10,
+					PreferredMaxBytes: 1000,
+				})
+				fakeConfig.AdaptiveBatchSizeReturns(&ordererext.AdaptiveBatchSize{
+					MinMessageCount: 2,
+					MaxMessageCount: 5,
+					TargetLatency:   "1h",
+				})
+			})
+
+			It("cuts the first batch at the min message count and the next batches according to the ingress rate", func() {
+				batches, pending := bc.Ordered(message)
+				Expect(batches).To(BeEmpty())
+				Expect(pending).To(BeTrue())
+				batches, pending = bc.Ordered(message)
+				Expect(len(batches)).To(Equal(1))
+				Expect(len(batches[0])).To(Equal(2))
+				Expect(pending).To(BeFalse())
+
+				for i := 0; i < 4; i++ {
+					batches, pending = bc.Ordered(message)
+					Expect(batches).To(BeEmpty())
+					Expect(pending).To(BeTrue())
+				}
+				batches, pending = bc.Ordered(message)
+				Expect(len(batches)).To(Equal(1))
+				Expect(len(batches[0])).To(Equal(5))
+				Expect(pending).To(BeFalse())
+
+				Expect(fakeTargetBatchSize.SetCallCount()).To(Equal(2))
+				Expect(fakeTargetBatchSize.SetArgsForCall(0)).To(Equal(float64(2)))
+				Expect(fakeTargetBatchSize.SetArgsForCall(1)).To(Equal(float64(5)))
+			})
+
+			It("observes the commits of blocks", func() {
+				bc.Ordered(message)
+				bc.Ordered(message)
+
+				observer, ok := bc.(blockcutter.CommitObserver)
+				Expect(ok).To(BeTrue())
+				observer.BlockCommitted()
 			})
 		})
 
@@ -8,20 +8,32 @@ package blockcutter
 
 import "github.com/hyperledger/fabric/common/metrics"
 
-var blockFillDuration = metrics.HistogramOpts{
-	Namespace:    "blockcutter",
-	Name:         "block_fill_duration",
-	Help:         "The time from first transaction enqueing to the block being cut in seconds.",
-	LabelNames:   []string{"channel"},
-	StatsdFormat: "%{#fqname}.%{channel}",
-}
+var (
+	blockFillDuration = metrics.HistogramOpts{
+		Namespace:    "blockcutter",
+		Name:         "block_fill_duration",
+		Help:         "The time from first transaction enqueing to the block being cut in seconds.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+
+	targetBatchSize = metrics.GaugeOpts{
+		Namespace:    "blockcutter",
+		Name:         "target_batch_size",
+		Help:         "The message count at which the current batch is cut, adjusted to the load when the batches are cut adaptively.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+)
 
 type Metrics struct {
 	BlockFillDuration metrics.Histogram
+	TargetBatchSize   metrics.Gauge
 }
 
 func NewMetrics(p metrics.Provider) *Metrics {
 	return &Metrics{
 		BlockFillDuration: p.NewHistogram(blockFillDuration),
+		TargetBatchSize:   p.NewGauge(targetBatchSize),
 	}
 }
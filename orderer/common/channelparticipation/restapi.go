@@ -30,6 +30,12 @@ const (
 	URLBaseV1Channels      = URLBaseV1 + "channels"
 	FormDataConfigBlockKey = "config-block"
 
+	// URLConfigBlock, URLClusterStatus, URLRaftStatus and URLLeadershipTransfer are appended to the channel URL.
+	URLConfigBlock        = "config-block"
+	URLClusterStatus      = "cluster-status"
+	URLRaftStatus         = "raft-status"
+	URLLeadershipTransfer = "leadership-transfer"
+
 	channelIDKey        = "channelID"
 	urlWithChannelIDKey = URLBaseV1Channels + "/{" + channelIDKey + "}"
 )
@@ -52,6 +58,21 @@ type ChannelManagement interface {
 
 	// RemoveChannel instructs the orderer to remove a channel.
 	RemoveChannel(channelID string) error
+
+	// ConfigBlock returns the last config block of a channel.
+	ConfigBlock(channelID string) (*cb.Block, error)
+
+	// ClusterStatus provides the status of the orderer in the cluster of a channel.
+	// The URL field is empty, and is to be completed by the caller.
+	ClusterStatus(channelID string) (types.ClusterStatus, error)
+
+	// RaftStatus provides the Raft state of the orderer in a channel it is a Raft consenter of.
+	// The URL field is empty, and is to be completed by the caller.
+	RaftStatus(channelID string) (types.RaftStatus, error)
+
+	// TransferLeadership instructs the orderer to transfer the leadership of a channel it leads to the consenter with
+	// the given ID, or to a consenter of its choice if the ID is zero.
+	TransferLeadership(channelID string, to uint64) error
 }
 
 // HTTPHandler handles all the HTTP requests to the channel participation API.
@@ -116,6 +137,133 @@ func NewHTTPHandler(config localconfig.ChannelParticipation, registrar ChannelMa
 	handler.router.HandleFunc(urlWithChannelIDKey, handler.serveRemove).Methods(http.MethodDelete)
 	handler.router.HandleFunc(urlWithChannelIDKey, handler.serveNotAllowed)
 
+	// swagger:operation GET /v1/participation/channels/{channelID}/config-block channels getConfigBlock
+	// ---
+	// summary: Returns the last config block of a channel an Ordering Service Node (OSN) has joined.
+	// parameters:
+	// - name: channelID
+	//   in: path
+	//   description: Channel ID
+	//   required: true
+	//   type: string
+	// responses:
+	//    '200':
+	//       description: Successfully retrieved the config block.
+	//       schema:
+	//         "$ref": "#/definitions/configBlock"
+	//       headers:
+	//        Content-Type:
+	//          description: The media type of the resource
+	//          type: string
+	//        Cache-Control:
+	//         description: The directives for caching responses
+	//         type: string
+	//    '404':
+	//      description: The channel does not exist, or has no config block yet.
+	//    '409':
+	//      description: The channel is pending removal.
+
+	handler.router.HandleFunc(urlWithChannelIDKey+"/"+URLConfigBlock, handler.serveConfigBlock).Methods(http.MethodGet)
+	handler.router.HandleFunc(urlWithChannelIDKey+"/"+URLConfigBlock, handler.serveReadOnlyNotAllowed)
+
+	// swagger:operation GET /v1/participation/channels/{channelID}/cluster-status channels getClusterStatus
+	// ---
+	// summary: Returns the status of an Ordering Service Node (OSN) in the cluster of a channel.
+	// description: The status includes the consenters set of the channel, the leader as last known by the OSN, and
+	//   the progress of the OSN in pulling blocks from the cluster when it is following or onboarding.
+	// parameters:
+	// - name: channelID
+	//   in: path
+	//   description: Channel ID
+	//   required: true
+	//   type: string
+	// responses:
+	//    '200':
+	//       description: Successfully retrieved the cluster status.
+	//       schema:
+	//         "$ref": "#/definitions/clusterStatus"
+	//       headers:
+	//        Content-Type:
+	//          description: The media type of the resource
+	//          type: string
+	//        Cache-Control:
+	//         description: The directives for caching responses
+	//         type: string
+	//    '404':
+	//      description: The channel does not exist.
+	//    '409':
+	//      description: The channel is pending removal.
+
+	handler.router.HandleFunc(urlWithChannelIDKey+"/"+URLClusterStatus, handler.serveClusterStatus).Methods(http.MethodGet)
+	handler.router.HandleFunc(urlWithChannelIDKey+"/"+URLClusterStatus, handler.serveReadOnlyNotAllowed)
+
+	// swagger:operation GET /v1/participation/channels/{channelID}/raft-status channels getRaftStatus
+	// ---
+	// summary: Returns the Raft state of an Ordering Service Node (OSN) in a channel it is a Raft consenter of.
+	// description: The state includes the Raft term, the leader, the commit index and, when the OSN is the leader,
+	//   the replication progress of each follower.
+	// parameters:
+	// - name: channelID
+	//   in: path
+	//   description: Channel ID
+	//   required: true
+	//   type: string
+	// responses:
+	//    '200':
+	//       description: Successfully retrieved the Raft status.
+	//       schema:
+	//         "$ref": "#/definitions/raftStatus"
+	//       headers:
+	//        Content-Type:
+	//          description: The media type of the resource
+	//          type: string
+	//        Cache-Control:
+	//         description: The directives for caching responses
+	//         type: string
+	//    '404':
+	//      description: The channel does not exist.
+	//    '409':
+	//      description: The channel is pending removal, or the OSN is not a Raft consenter of the channel.
+
+	handler.router.HandleFunc(urlWithChannelIDKey+"/"+URLRaftStatus, handler.serveRaftStatus).Methods(http.MethodGet)
+	handler.router.HandleFunc(urlWithChannelIDKey+"/"+URLRaftStatus, handler.serveReadOnlyNotAllowed)
+
+	// swagger:operation POST /v1/participation/channels/{channelID}/leadership-transfer channels transferLeadership
+	// ---
+	// summary: Transfers the leadership of a channel from an Ordering Service Node (OSN) to another consenter.
+	// description: The request must be sent to the leader of the channel. If the consenter ID is zero, the leader
+	//   picks a recently active follower. The request returns once the leadership is transferred.
+	// parameters:
+	// - name: channelID
+	//   in: path
+	//   description: Channel ID
+	//   required: true
+	//   type: string
+	// - name: leadershipTransfer
+	//   in: body
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/leadershipTransfer"
+	// responses:
+	//    '204':
+	//      description: Successfully transferred the leadership.
+	//    '400':
+	//      description: Bad request, or the consenter does not exist.
+	//    '404':
+	//      description: The channel does not exist.
+	//    '409':
+	//      description: The channel is pending removal, the OSN is not a Raft consenter of the channel, the OSN
+	//        is not the leader of the channel, or another leadership transfer is in progress.
+	//    '500':
+	//      description: The leadership transfer failed.
+	// consumes:
+	//   - application/json
+
+	handler.router.HandleFunc(urlWithChannelIDKey+"/"+URLLeadershipTransfer, handler.serveLeadershipTransfer).Methods(http.MethodPost).HeadersRegexp(
+		"Content-Type", "application/json*")
+	handler.router.HandleFunc(urlWithChannelIDKey+"/"+URLLeadershipTransfer, handler.serveBadContentType).Methods(http.MethodPost)
+	handler.router.HandleFunc(urlWithChannelIDKey+"/"+URLLeadershipTransfer, handler.servePostOnlyNotAllowed)
+
 	// swagger:operation GET /v1/participation/channels channels listChannels
 	// ---
 	// summary: Returns the complete list of channels an Ordering Service Node (OSN) has joined.
@@ -229,6 +377,99 @@ func (h *HTTPHandler) serveListOne(resp http.ResponseWriter, req *http.Request)
 	h.sendResponseOK(resp, infoFull)
 }
 
+// Get the last config block of a channel
+func (h *HTTPHandler) serveConfigBlock(resp http.ResponseWriter, req *http.Request) {
+	_, err := negotiateContentType(req) // Only application/json responses for now
+	if err != nil {
+		h.sendResponseJsonError(resp, http.StatusNotAcceptable, err)
+		return
+	}
+
+	channelID, err := h.extractChannelID(req, resp)
+	if err != nil {
+		return
+	}
+
+	block, err := h.registrar.ConfigBlock(channelID)
+	if err != nil {
+		h.sendChannelStatusError(err, resp)
+		return
+	}
+
+	blockBytes, err := proto.Marshal(block)
+	if err != nil {
+		h.sendResponseJsonError(resp, http.StatusInternalServerError, errors.Wrap(err, "cannot marshal config block"))
+		return
+	}
+
+	resp.Header().Set("Cache-Control", "no-store")
+	h.sendResponseOK(resp, &types.ConfigBlock{
+		Name:   channelID,
+		Number: block.GetHeader().GetNumber(),
+		Block:  blockBytes,
+	})
+}
+
+// Get the status of the orderer in the cluster of a channel
+func (h *HTTPHandler) serveClusterStatus(resp http.ResponseWriter, req *http.Request) {
+	_, err := negotiateContentType(req) // Only application/json responses for now
+	if err != nil {
+		h.sendResponseJsonError(resp, http.StatusNotAcceptable, err)
+		return
+	}
+
+	channelID, err := h.extractChannelID(req, resp)
+	if err != nil {
+		return
+	}
+
+	status, err := h.registrar.ClusterStatus(channelID)
+	if err != nil {
+		h.sendChannelStatusError(err, resp)
+		return
+	}
+	status.URL = path.Join(URLBaseV1Channels, status.Name, URLClusterStatus)
+
+	resp.Header().Set("Cache-Control", "no-store")
+	h.sendResponseOK(resp, status)
+}
+
+// Get the Raft state of the orderer in a channel
+func (h *HTTPHandler) serveRaftStatus(resp http.ResponseWriter, req *http.Request) {
+	_, err := negotiateContentType(req) // Only application/json responses for now
+	if err != nil {
+		h.sendResponseJsonError(resp, http.StatusNotAcceptable, err)
+		return
+	}
+
+	channelID, err := h.extractChannelID(req, resp)
+	if err != nil {
+		return
+	}
+
+	status, err := h.registrar.RaftStatus(channelID)
+	if err != nil {
+		h.sendChannelStatusError(err, resp)
+		return
+	}
+	status.URL = path.Join(URLBaseV1Channels, status.Name, URLRaftStatus)
+
+	resp.Header().Set("Cache-Control", "no-store")
+	h.sendResponseOK(resp, status)
+}
+
+func (h *HTTPHandler) sendChannelStatusError(err error, resp http.ResponseWriter) {
+	h.logger.Debugf("Failed to get channel status: %s", err)
+	switch err {
+	case types.ErrChannelNotExist, types.ErrChannelNoConfigBlock:
+		h.sendResponseJsonError(resp, http.StatusNotFound, err)
+	case types.ErrChannelPendingRemoval, types.ErrNotRaftConsenter:
+		h.sendResponseJsonError(resp, http.StatusConflict, err)
+	default:
+		h.sendResponseJsonError(resp, http.StatusInternalServerError, err)
+	}
+}
+
 func (h *HTTPHandler) redirectBaseV1(resp http.ResponseWriter, req *http.Request) {
 	http.Redirect(resp, req, URLBaseV1Channels, http.StatusFound)
 }
@@ -390,6 +631,49 @@ func (h *HTTPHandler) serveRemove(resp http.ResponseWriter, req *http.Request) {
 	}
 }
 
+// Transfer the leadership of a channel.
+// Expect application/json.
+func (h *HTTPHandler) serveLeadershipTransfer(resp http.ResponseWriter, req *http.Request) {
+	_, err := negotiateContentType(req) // Only application/json responses for now
+	if err != nil {
+		h.sendResponseJsonError(resp, http.StatusNotAcceptable, err)
+		return
+	}
+
+	channelID, err := h.extractChannelID(req, resp)
+	if err != nil {
+		return
+	}
+
+	transfer := &types.LeadershipTransfer{}
+	decoder := json.NewDecoder(http.MaxBytesReader(resp, req.Body, int64(h.config.MaxRequestBodySize)))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(transfer); err != nil {
+		h.sendResponseJsonError(resp, http.StatusBadRequest, errors.Wrap(err, "cannot decode leadership transfer from request body"))
+		return
+	}
+
+	err = h.registrar.TransferLeadership(channelID, transfer.ConsenterID)
+	if err == nil {
+		h.logger.Debugf("Successfully transferred the leadership of channel: %s", channelID)
+		resp.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	h.logger.Debugf("Failed to transfer the leadership of channel: %s, err: %s", channelID, err)
+
+	switch err {
+	case types.ErrChannelNotExist:
+		h.sendResponseJsonError(resp, http.StatusNotFound, errors.WithMessage(err, "cannot transfer leadership"))
+	case types.ErrConsenterNotExist:
+		h.sendResponseJsonError(resp, http.StatusBadRequest, errors.WithMessage(err, "cannot transfer leadership"))
+	case types.ErrChannelPendingRemoval, types.ErrNotRaftConsenter, types.ErrNotLeader, types.ErrLeadershipTransferInProgress:
+		h.sendResponseJsonError(resp, http.StatusConflict, errors.WithMessage(err, "cannot transfer leadership"))
+	default:
+		h.sendResponseJsonError(resp, http.StatusInternalServerError, errors.WithMessage(err, "cannot transfer leadership"))
+	}
+}
+
 func (h *HTTPHandler) serveBadContentType(resp http.ResponseWriter, req *http.Request) {
 	err := errors.Errorf("unsupported Content-Type: %s", req.Header.Values("Content-Type"))
 	h.sendResponseJsonError(resp, http.StatusBadRequest, err)
@@ -406,6 +690,16 @@ func (h *HTTPHandler) serveNotAllowed(resp http.ResponseWriter, req *http.Reques
 	h.sendResponseNotAllowed(resp, err, http.MethodGet, http.MethodPost)
 }
 
+func (h *HTTPHandler) serveReadOnlyNotAllowed(resp http.ResponseWriter, req *http.Request) {
+	err := errors.Errorf("invalid request method: %s", req.Method)
+	h.sendResponseNotAllowed(resp, err, http.MethodGet)
+}
+
+func (h *HTTPHandler) servePostOnlyNotAllowed(resp http.ResponseWriter, req *http.Request) {
+	err := errors.Errorf("invalid request method: %s", req.Method)
+	h.sendResponseNotAllowed(resp, err, http.MethodPost)
+}
+
 func negotiateContentType(req *http.Request) (string, error) {
 	acceptReq := req.Header.Get("Accept")
 	if len(acceptReq) == 0 {
@@ -15,6 +15,7 @@ import (
 	"net/http/httptest"
 	"os"
 	"path"
+	"strings"
 	"testing"
 
 	"github.com/hyperledger/fabric-protos-go/common"
@@ -61,6 +62,41 @@ func TestHTTPHandler_ServeHTTP_InvalidMethods(t *testing.T) {
 		}
 	})
 
+	t.Run("on /channels/ch-id/config-block and /channels/ch-id/cluster-status", func(t *testing.T) {
+		invalidMethodsExt := append(invalidMethods, http.MethodPost, http.MethodDelete)
+		for _, resource := range []string{channelparticipation.URLConfigBlock, channelparticipation.URLClusterStatus} {
+			for _, method := range invalidMethodsExt {
+				resp := httptest.NewRecorder()
+				req := httptest.NewRequest(method, path.Join(channelparticipation.URLBaseV1Channels, "ch-id", resource), nil)
+				h.ServeHTTP(resp, req)
+				checkErrorResponse(t, http.StatusMethodNotAllowed, fmt.Sprintf("invalid request method: %s", method), resp)
+				require.Equal(t, "GET", resp.Result().Header.Get("Allow"), "%s %s", method, resource)
+			}
+		}
+	})
+
+	t.Run("on /channels/ch-id/raft-status", func(t *testing.T) {
+		invalidMethodsExt := append(invalidMethods, http.MethodPost, http.MethodDelete)
+		for _, method := range invalidMethodsExt {
+			resp := httptest.NewRecorder()
+			req := httptest.NewRequest(method, path.Join(channelparticipation.URLBaseV1Channels, "ch-id", channelparticipation.URLRaftStatus), nil)
+			h.ServeHTTP(resp, req)
+			checkErrorResponse(t, http.StatusMethodNotAllowed, fmt.Sprintf("invalid request method: %s", method), resp)
+			require.Equal(t, "GET", resp.Result().Header.Get("Allow"), "%s", method)
+		}
+	})
+
+	t.Run("on /channels/ch-id/leadership-transfer", func(t *testing.T) {
+		invalidMethodsExt := append(invalidMethods, http.MethodGet, http.MethodDelete)
+		for _, method := range invalidMethodsExt {
+			resp := httptest.NewRecorder()
+			req := httptest.NewRequest(method, path.Join(channelparticipation.URLBaseV1Channels, "ch-id", channelparticipation.URLLeadershipTransfer), nil)
+			h.ServeHTTP(resp, req)
+			checkErrorResponse(t, http.StatusMethodNotAllowed, fmt.Sprintf("invalid request method: %s", method), resp)
+			require.Equal(t, "POST", resp.Result().Header.Get("Allow"), "%s", method)
+		}
+	})
+
 	t.Run("on /channels", func(t *testing.T) {
 		invalidMethodsExt := append(invalidMethods, http.MethodDelete)
 		for _, method := range invalidMethodsExt {
@@ -239,6 +275,237 @@ func TestHTTPHandler_ServeHTTP_ListSingle(t *testing.T) {
 	})
 }
 
+func TestHTTPHandler_ServeHTTP_ConfigBlock(t *testing.T) {
+	config := localconfig.ChannelParticipation{Enabled: true}
+	fakeManager, h := setup(config, t)
+	require.NotNilf(t, h, "cannot create handler")
+
+	t.Run("config block exists", func(t *testing.T) {
+		block := protoutil.NewBlock(4, []byte("previous"))
+		fakeManager.ConfigBlockReturns(block, nil)
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, channelparticipation.URLBaseV1Channels+"/app-channel/config-block", nil)
+		h.ServeHTTP(resp, req)
+		require.Equal(t, http.StatusOK, resp.Result().StatusCode)
+		require.Equal(t, "application/json", resp.Result().Header.Get("Content-Type"))
+		require.Equal(t, "no-store", resp.Result().Header.Get("Cache-Control"))
+		require.Equal(t, "app-channel", fakeManager.ConfigBlockArgsForCall(0))
+
+		configBlockResp := types.ConfigBlock{}
+		err := json.Unmarshal(resp.Body.Bytes(), &configBlockResp)
+		require.NoError(t, err, "cannot be unmarshaled")
+		require.Equal(t, types.ConfigBlock{
+			Name:   "app-channel",
+			Number: 4,
+			Block:  protoutil.MarshalOrPanic(block),
+		}, configBlockResp)
+	})
+
+	for _, testCase := range []struct {
+		name         string
+		err          error
+		expectedCode int
+	}{
+		{name: "channel does not exist", err: types.ErrChannelNotExist, expectedCode: http.StatusNotFound},
+		{name: "no config block", err: types.ErrChannelNoConfigBlock, expectedCode: http.StatusNotFound},
+		{name: "channel pending removal", err: types.ErrChannelPendingRemoval, expectedCode: http.StatusConflict},
+		{name: "ledger error", err: errors.New("ledger error"), expectedCode: http.StatusInternalServerError},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			fakeManager.ConfigBlockReturns(nil, testCase.err)
+			resp := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, channelparticipation.URLBaseV1Channels+"/app-channel/config-block", nil)
+			h.ServeHTTP(resp, req)
+			checkErrorResponse(t, testCase.expectedCode, testCase.err.Error(), resp)
+		})
+	}
+
+	t.Run("bad channel ID", func(t *testing.T) {
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, channelparticipation.URLBaseV1Channels+"/Bad-Channel/config-block", nil)
+		h.ServeHTTP(resp, req)
+		checkErrorResponse(t, http.StatusBadRequest, "invalid channel ID: 'Bad-Channel' contains illegal characters", resp)
+	})
+}
+
+func TestHTTPHandler_ServeHTTP_ClusterStatus(t *testing.T) {
+	config := localconfig.ChannelParticipation{Enabled: true}
+	fakeManager, h := setup(config, t)
+	require.NotNilf(t, h, "cannot create handler")
+
+	t.Run("channel exists", func(t *testing.T) {
+		joinBlockNumber := uint64(10)
+		fakeManager.ClusterStatusReturns(types.ClusterStatus{
+			Name:              "app-channel",
+			ConsensusRelation: "follower",
+			Status:            "onboarding",
+			Height:            3,
+			Consenters: []types.ConsenterInfo{
+				{ID: 1, Host: "orderer1", Port: 7050, Leader: true},
+			},
+			CatchUp: &types.CatchUpProgress{JoinBlockNumber: &joinBlockNumber, StartHeight: 1, TargetHeight: 11},
+		}, nil)
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, channelparticipation.URLBaseV1Channels+"/app-channel/cluster-status", nil)
+		h.ServeHTTP(resp, req)
+		require.Equal(t, http.StatusOK, resp.Result().StatusCode)
+		require.Equal(t, "application/json", resp.Result().Header.Get("Content-Type"))
+		require.Equal(t, "no-store", resp.Result().Header.Get("Cache-Control"))
+
+		statusResp := types.ClusterStatus{}
+		err := json.Unmarshal(resp.Body.Bytes(), &statusResp)
+		require.NoError(t, err, "cannot be unmarshaled")
+		require.Equal(t, types.ClusterStatus{
+			Name:              "app-channel",
+			URL:               channelparticipation.URLBaseV1Channels + "/app-channel/cluster-status",
+			ConsensusRelation: "follower",
+			Status:            "onboarding",
+			Height:            3,
+			Consenters: []types.ConsenterInfo{
+				{ID: 1, Host: "orderer1", Port: 7050, Leader: true},
+			},
+			CatchUp: &types.CatchUpProgress{JoinBlockNumber: &joinBlockNumber, StartHeight: 1, TargetHeight: 11},
+		}, statusResp)
+	})
+
+	t.Run("channel does not exist", func(t *testing.T) {
+		fakeManager.ClusterStatusReturns(types.ClusterStatus{}, types.ErrChannelNotExist)
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, channelparticipation.URLBaseV1Channels+"/app-channel/cluster-status", nil)
+		h.ServeHTTP(resp, req)
+		checkErrorResponse(t, http.StatusNotFound, "channel does not exist", resp)
+	})
+
+	t.Run("bad Accept header", func(t *testing.T) {
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, channelparticipation.URLBaseV1Channels+"/app-channel/cluster-status", nil)
+		req.Header.Set("Accept", "text/html")
+		h.ServeHTTP(resp, req)
+		checkErrorResponse(t, http.StatusNotAcceptable, "response Content-Type is application/json only", resp)
+	})
+}
+
+func TestHTTPHandler_ServeHTTP_RaftStatus(t *testing.T) {
+	config := localconfig.ChannelParticipation{Enabled: true}
+	fakeManager, h := setup(config, t)
+	require.NotNilf(t, h, "cannot create handler")
+
+	t.Run("raft consenter", func(t *testing.T) {
+		fakeManager.RaftStatusReturns(types.RaftStatus{
+			Name:         "app-channel",
+			ID:           1,
+			State:        "StateLeader",
+			Term:         2,
+			Leader:       1,
+			CommitIndex:  7,
+			AppliedIndex: 7,
+			Followers: []types.RaftFollowerProgress{
+				{ID: 2, MatchIndex: 7, NextIndex: 8, State: "StateReplicate", RecentActive: true},
+			},
+		}, nil)
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, channelparticipation.URLBaseV1Channels+"/app-channel/raft-status", nil)
+		h.ServeHTTP(resp, req)
+		require.Equal(t, http.StatusOK, resp.Result().StatusCode)
+		require.Equal(t, "application/json", resp.Result().Header.Get("Content-Type"))
+		require.Equal(t, "no-store", resp.Result().Header.Get("Cache-Control"))
+		require.Equal(t, "app-channel", fakeManager.RaftStatusArgsForCall(0))
+
+		statusResp := types.RaftStatus{}
+		err := json.Unmarshal(resp.Body.Bytes(), &statusResp)
+		require.NoError(t, err, "cannot be unmarshaled")
+		require.Equal(t, types.RaftStatus{
+			Name:         "app-channel",
+			URL:          channelparticipation.URLBaseV1Channels + "/app-channel/raft-status",
+			ID:           1,
+			State:        "StateLeader",
+			Term:         2,
+			Leader:       1,
+			CommitIndex:  7,
+			AppliedIndex: 7,
+			Followers: []types.RaftFollowerProgress{
+				{ID: 2, MatchIndex: 7, NextIndex: 8, State: "StateReplicate", RecentActive: true},
+			},
+		}, statusResp)
+	})
+
+	for _, testCase := range []struct {
+		name         string
+		err          error
+		expectedCode int
+	}{
+		{name: "channel does not exist", err: types.ErrChannelNotExist, expectedCode: http.StatusNotFound},
+		{name: "not a raft consenter", err: types.ErrNotRaftConsenter, expectedCode: http.StatusConflict},
+		{name: "chain is stopped", err: errors.New("chain is stopped"), expectedCode: http.StatusInternalServerError},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			fakeManager.RaftStatusReturns(types.RaftStatus{}, testCase.err)
+			resp := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, channelparticipation.URLBaseV1Channels+"/app-channel/raft-status", nil)
+			h.ServeHTTP(resp, req)
+			checkErrorResponse(t, testCase.expectedCode, testCase.err.Error(), resp)
+		})
+	}
+}
+
+func TestHTTPHandler_ServeHTTP_LeadershipTransfer(t *testing.T) {
+	config := localconfig.ChannelParticipation{Enabled: true, MaxRequestBodySize: 1024}
+	fakeManager, h := setup(config, t)
+	require.NotNilf(t, h, "cannot create handler")
+
+	newRequest := func(body string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, channelparticipation.URLBaseV1Channels+"/app-channel/leadership-transfer", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		return req
+	}
+
+	t.Run("transferred", func(t *testing.T) {
+		fakeManager.TransferLeadershipReturns(nil)
+		resp := httptest.NewRecorder()
+		h.ServeHTTP(resp, newRequest(`{"consenterID":3}`))
+		require.Equal(t, http.StatusNoContent, resp.Result().StatusCode)
+		require.Equal(t, 1, fakeManager.TransferLeadershipCallCount())
+		channelID, to := fakeManager.TransferLeadershipArgsForCall(0)
+		require.Equal(t, "app-channel", channelID)
+		require.Equal(t, uint64(3), to)
+	})
+
+	t.Run("bad body", func(t *testing.T) {
+		resp := httptest.NewRecorder()
+		h.ServeHTTP(resp, newRequest(`{"consenter":3}`))
+		checkErrorResponse(t, http.StatusBadRequest, "cannot decode leadership transfer from request body: json: unknown field \"consenter\"", resp)
+	})
+
+	t.Run("bad content type", func(t *testing.T) {
+		resp := httptest.NewRecorder()
+		req := newRequest(`{"consenterID":3}`)
+		req.Header.Set("Content-Type", "text/plain")
+		h.ServeHTTP(resp, req)
+		checkErrorResponse(t, http.StatusBadRequest, "unsupported Content-Type: [text/plain]", resp)
+	})
+
+	for _, testCase := range []struct {
+		name         string
+		err          error
+		expectedCode int
+	}{
+		{name: "channel does not exist", err: types.ErrChannelNotExist, expectedCode: http.StatusNotFound},
+		{name: "consenter does not exist", err: types.ErrConsenterNotExist, expectedCode: http.StatusBadRequest},
+		{name: "pending removal", err: types.ErrChannelPendingRemoval, expectedCode: http.StatusConflict},
+		{name: "not a raft consenter", err: types.ErrNotRaftConsenter, expectedCode: http.StatusConflict},
+		{name: "not the leader", err: types.ErrNotLeader, expectedCode: http.StatusConflict},
+		{name: "transfer in progress", err: types.ErrLeadershipTransferInProgress, expectedCode: http.StatusConflict},
+		{name: "timed out", err: errors.New("leadership transfer timed out"), expectedCode: http.StatusInternalServerError},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			fakeManager.TransferLeadershipReturns(testCase.err)
+			resp := httptest.NewRecorder()
+			h.ServeHTTP(resp, newRequest(`{"consenterID":3}`))
+			checkErrorResponse(t, testCase.expectedCode, "cannot transfer leadership: "+testCase.err.Error(), resp)
+		})
+	}
+}
+
 func TestHTTPHandler_ServeHTTP_Join(t *testing.T) {
 	config := localconfig.ChannelParticipation{
 		Enabled:            true,
@@ -167,7 +167,7 @@ func (s *ClusterService) VerifyAuthRequest(stream orderer.ClusterNodeService_Ste
 		return nil, errors.Errorf("node id mismatch")
 	}
 
-	err = VerifySignature(fromIdentity, SHA256Digest(msg), authReq.Signature)
+	err = VerifySignature(fromIdentity, msg, authReq.Signature)
 	if err != nil {
 		return nil, errors.Wrap(err, "signature mismatch")
 	}
@@ -10,6 +10,7 @@ import (
 	"bytes"
 	"context"
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/sha256"
 	"crypto/tls"
 	"crypto/x509"
@@ -834,7 +835,10 @@ func GetTLSSessionBinding(ctx context.Context, bindingPayload []byte) ([]byte, e
 	return tlsBinding, nil
 }
 
-func VerifySignature(identity, msgHash, signature []byte) error {
+// VerifySignature verifies that signature was produced over msg by the key
+// certified by identity. ECDSA signatures are verified against the SHA256
+// digest of msg, Ed25519 signatures against msg itself.
+func VerifySignature(identity, msg, signature []byte) error {
 	block, _ := pem.Decode(identity)
 	if block == nil {
 		return errors.New("pem decoding failed")
@@ -845,13 +849,16 @@ func VerifySignature(identity, msgHash, signature []byte) error {
 		return errors.Wrap(err, "key extraction failed")
 	}
 
-	pubKey, isECDSA := cert.PublicKey.(*ecdsa.PublicKey)
-	if !isECDSA {
+	var validSignature bool
+	switch pubKey := cert.PublicKey.(type) {
+	case *ecdsa.PublicKey:
+		validSignature = ecdsa.VerifyASN1(pubKey, SHA256Digest(msg), signature)
+	case ed25519.PublicKey:
+		validSignature = ed25519.Verify(pubKey, msg, signature)
+	default:
 		return errors.New("not valid public key")
 	}
 
-	validSignature := ecdsa.VerifyASN1(pubKey, msgHash, signature)
-
 	if !validSignature {
 		return errors.New("signature invalid")
 	}
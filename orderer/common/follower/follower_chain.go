@@ -93,13 +93,14 @@ type ChannelParticipationMetricsReporter interface {
 // i.e. the follower is performing onboarding for an etcdraft.Chain. Otherwise, the follower return clusterRelation
 // "follower".
 type Chain struct {
-	mutex             sync.Mutex    // Protects the start/stop flags & channels, consensusRelation & status. All the rest are immutable or accessed only by the go-routine.
+	mutex             sync.Mutex    // Protects the start/stop flags & channels, consensusRelation, status & targetHeight. All the rest are immutable or accessed only by the go-routine.
 	started           bool          // Start once.
 	stopped           bool          // Stop once.
 	stopChan          chan struct{} // A 'closer' signals the go-routine to stop by closing this channel.
 	doneChan          chan struct{} // The go-routine signals the 'closer' that it is done by closing this channel.
 	consensusRelation types.ConsensusRelation
 	status            types.Status
+	targetHeight      uint64 // The height the go-routine is pulling blocks up to, zero until it is known.
 
 	ledgerResources  LedgerResources            // ledger & config resources
 	clusterConsenter consensus.ClusterConsenter // detects whether a block indicates channel membership
@@ -249,6 +250,30 @@ func (c *Chain) StatusReport() (types.ConsensusRelation, types.Status) {
 	return c.consensusRelation, c.status
 }
 
+// CatchUpProgress returns the progress of the follower in pulling blocks from the cluster.
+func (c *Chain) CatchUpProgress() types.CatchUpProgress {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	progress := types.CatchUpProgress{
+		StartHeight:  c.firstHeight,
+		TargetHeight: c.targetHeight,
+	}
+	if c.joinBlock != nil {
+		joinBlockNumber := c.joinBlock.Header.Number
+		progress.JoinBlockNumber = &joinBlockNumber
+	}
+
+	return progress
+}
+
+func (c *Chain) setTargetHeight(targetHeight uint64) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.targetHeight = targetHeight
+}
+
 func (c *Chain) setStatus(status types.Status) {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
@@ -463,6 +488,8 @@ func (c *Chain) pullAfterJoin() error {
 // On internal pull errors it employs exponential back-off and retries.
 // When parameter updateEndpoints is true, the block-puller's endpoints are updated with every incoming config.
 func (c *Chain) pullUntilLatestWithRetry(latestNetworkHeight uint64, updateEndpoints bool) error {
+	c.setTargetHeight(latestNetworkHeight)
+
 	retryInterval := c.options.PullRetryMinInterval
 	for {
 		numPulled, errPull := c.pullUntilTarget(latestNetworkHeight, updateEndpoints)
@@ -119,6 +119,9 @@ func TestFollowerNewChain(t *testing.T) {
 		consensusRelation, status := chain.StatusReport()
 		require.Equal(t, types.ConsensusRelationFollower, consensusRelation)
 		require.Equal(t, types.StatusOnBoarding, status)
+
+		joinBlockNumber := uint64(10)
+		require.Equal(t, types.CatchUpProgress{JoinBlockNumber: &joinBlockNumber}, chain.CatchUpProgress())
 	})
 
 	t.Run("with join block, in channel, empty ledger", func(t *testing.T) {
@@ -159,6 +162,8 @@ func TestFollowerNewChain(t *testing.T) {
 		consensusRelation, status := chain.StatusReport()
 		require.Equal(t, types.ConsensusRelationFollower, consensusRelation)
 		require.True(t, status == types.StatusActive)
+
+		require.Equal(t, types.CatchUpProgress{StartHeight: 5}, chain.CatchUpProgress())
 	})
 
 	t.Run("can not find config block in chain", func(t *testing.T) {
@@ -263,6 +268,7 @@ func TestFollowerPullUpToJoin(t *testing.T) {
 			require.Equal(t, remoteBlockchain.Block(i).Header, localBlockchain.Block(i).Header, "failed block i=%d", i)
 		}
 		require.Equal(t, 1, mockChainCreator.SwitchFollowerToChainCallCount())
+		require.Equal(t, types.CatchUpProgress{JoinBlockNumber: &joinNum, StartHeight: joinNum / 2, TargetHeight: joinNum + 1}, chain.CatchUpProgress())
 
 		require.Equal(t, 3, mockChannelParticipationMetricsReporter.ReportConsensusRelationAndStatusMetricsCallCount())
 		channel, relation, status = mockChannelParticipationMetricsReporter.ReportConsensusRelationAndStatusMetricsArgsForCall(2)
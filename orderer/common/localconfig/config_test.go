@@ -115,6 +115,20 @@ func TestEnvInnerVar(t *testing.T) {
 	require.Equal(t, config.General.Keepalive.ServerTimeout, v2, "Environmental override of inner config test 2 did not work")
 }
 
+func TestBCCSPKeystorePasswordEnv(t *testing.T) {
+	t.Setenv("ORDERER_GENERAL_BCCSP_SW_FILEKEYSTORE_PASSWORD", "secret")
+	t.Setenv("ORDERER_GENERAL_BCCSP_SW_FILEKEYSTORE_PASSWORDFILE", "/etc/hyperledger/password")
+	configtest.SetDevFabricConfigPath(t)
+
+	cc := &configCache{}
+	config, err := cc.load()
+	require.NoError(t, err)
+
+	require.NotNil(t, config.General.BCCSP.SW.FileKeystore)
+	require.Equal(t, "secret", config.General.BCCSP.SW.FileKeystore.Password)
+	require.Equal(t, "/etc/hyperledger/password", config.General.BCCSP.SW.FileKeystore.PasswordFile)
+}
+
 func TestAdminTLSConfig(t *testing.T) {
 	testCases := []struct {
 		name        string
@@ -123,6 +123,15 @@ func (cs *ChainSupport) BlockCutter() blockcutter.Receiver {
 	return cs.cutter
 }
 
+// WriteBlock passes through to the BlockWriter, and reports the commit of the block to the
+// blockcutter.Receiver when it adapts the size of the batches to the commit latency.
+func (cs *ChainSupport) WriteBlock(block *cb.Block, encodedMetadataValue []byte) {
+	cs.BlockWriter.WriteBlock(block, encodedMetadataValue)
+	if observer, ok := cs.cutter.(blockcutter.CommitObserver); ok {
+		observer.BlockCommitted()
+	}
+}
+
 // Validate passes through to the underlying configtx.Validator
 func (cs *ChainSupport) Validate(configEnv *cb.ConfigEnvelope) error {
 	return cs.ConfigtxValidator().Validate(configEnv)
@@ -11,10 +11,12 @@ package multichannel
 
 import (
 	"path/filepath"
+	"sort"
 	"sync"
 
 	"github.com/golang/protobuf/proto"
 	cb "github.com/hyperledger/fabric-protos-go/common"
+	etcdraftproto "github.com/hyperledger/fabric-protos-go/orderer/etcdraft"
 	"github.com/hyperledger/fabric/bccsp"
 	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/configtx"
@@ -615,6 +617,208 @@ func (r *Registrar) ChannelInfo(channelID string) (types.ChannelInfo, error) {
 	return types.ChannelInfo{}, types.ErrChannelNotExist
 }
 
+// ConfigBlock returns the last config block of a channel.
+func (r *Registrar) ConfigBlock(channelID string) (*cb.Block, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	reader, err := r.channelReader(channelID)
+	if err != nil {
+		return nil, err
+	}
+
+	return lastConfigBlock(reader)
+}
+
+// ClusterStatus provides the status of the orderer in the cluster of a channel: its consensus relation and status,
+// the consenters set of the channel and, for a follower, its progress in pulling blocks from the cluster.
+// The URL field is empty, and is to be completed by the caller.
+func (r *Registrar) ClusterStatus(channelID string) (types.ClusterStatus, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	reader, err := r.channelReader(channelID)
+	if err != nil {
+		return types.ClusterStatus{}, err
+	}
+
+	status := types.ClusterStatus{Name: channelID, Height: reader.Height()}
+
+	if c, ok := r.chains[channelID]; ok {
+		status.ConsensusRelation, status.Status = c.StatusReport()
+		if reporter, ok := c.Chain.(consensus.ConsenterStatusReporter); ok {
+			status.Consenters = reporter.ConsenterStatus()
+			return status, nil
+		}
+	}
+
+	if f, ok := r.followers[channelID]; ok {
+		status.ConsensusRelation, status.Status = f.StatusReport()
+		catchUp := f.CatchUpProgress()
+		status.CatchUp = &catchUp
+	}
+
+	if reader.Height() == 0 {
+		// An onboarding follower that has not pulled any block yet
+		return status, nil
+	}
+
+	configBlock, err := lastConfigBlock(reader)
+	if err != nil {
+		return types.ClusterStatus{}, err
+	}
+	status.Consenters, err = r.consentersFromConfigBlock(configBlock)
+	if err != nil {
+		return types.ClusterStatus{}, errors.WithMessagef(err, "failed to read the consenters of channel %s", channelID)
+	}
+
+	return status, nil
+}
+
+// RaftStatus returns the Raft state of the orderer in a channel it is a Raft consenter of.
+func (r *Registrar) RaftStatus(channelID string) (types.RaftStatus, error) {
+	chain, err := r.consenterChain(channelID)
+	if err != nil {
+		return types.RaftStatus{}, err
+	}
+
+	reporter, ok := chain.(consensus.RaftStatusReporter)
+	if !ok {
+		return types.RaftStatus{}, types.ErrNotRaftConsenter
+	}
+
+	status, err := reporter.RaftStatus()
+	if err != nil {
+		return types.RaftStatus{}, err
+	}
+	status.Name = channelID
+
+	return status, nil
+}
+
+// TransferLeadership instructs the orderer to transfer the leadership of a channel it leads to the consenter with
+// the given ID, or to a consenter of its choice if the ID is zero.
+func (r *Registrar) TransferLeadership(channelID string, to uint64) error {
+	chain, err := r.consenterChain(channelID)
+	if err != nil {
+		return err
+	}
+
+	transferrer, ok := chain.(consensus.LeadershipTransferrer)
+	if !ok {
+		return types.ErrNotRaftConsenter
+	}
+
+	// The transfer blocks until the leadership changes, so it must not hold the registrar lock.
+	return transferrer.TransferLeadership(to)
+}
+
+// consenterChain returns the consensus chain of a channel the orderer is a consenter of.
+func (r *Registrar) consenterChain(channelID string) (consensus.Chain, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	if c, ok := r.chains[channelID]; ok {
+		return c.Chain, nil
+	}
+
+	if _, ok := r.followers[channelID]; ok {
+		return nil, types.ErrNotRaftConsenter
+	}
+
+	if _, ok := r.pendingRemoval[channelID]; ok {
+		return nil, types.ErrChannelPendingRemoval
+	}
+
+	return nil, types.ErrChannelNotExist
+}
+
+// channelReader returns the ledger of a channel the orderer is a consenter or follower of.
+func (r *Registrar) channelReader(channelID string) (blockledger.Reader, error) {
+	if c, ok := r.chains[channelID]; ok {
+		return c, nil
+	}
+
+	if _, ok := r.followers[channelID]; ok {
+		return r.ledgerFactory.GetOrCreate(channelID)
+	}
+
+	if _, ok := r.pendingRemoval[channelID]; ok {
+		return nil, types.ErrChannelPendingRemoval
+	}
+
+	return nil, types.ErrChannelNotExist
+}
+
+// consentersFromConfigBlock reads the consenters set from a config block, ordered by ID.
+func (r *Registrar) consentersFromConfigBlock(configBlock *cb.Block) ([]types.ConsenterInfo, error) {
+	env, err := protoutil.ExtractEnvelope(configBlock, 0)
+	if err != nil {
+		return nil, err
+	}
+	bundle, err := channelconfig.NewBundleFromEnvelope(env, r.bccsp)
+	if err != nil {
+		return nil, err
+	}
+	ordererConfig, ok := bundle.OrdererConfig()
+	if !ok {
+		return nil, errors.New("no orderer config")
+	}
+
+	var consenters []types.ConsenterInfo
+
+	if ordererConfig.ConsensusType() == "etcdraft" {
+		configMetadata := &etcdraftproto.ConfigMetadata{}
+		if err := proto.Unmarshal(ordererConfig.ConsensusMetadata(), configMetadata); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal etcdraft config metadata")
+		}
+		consenterMetadata, err := protoutil.GetConsenterMetadataFromBlock(configBlock)
+		if err != nil {
+			return nil, err
+		}
+		blockMetadata, err := etcdraft.ReadBlockMetadata(consenterMetadata, configMetadata)
+		if err != nil {
+			return nil, err
+		}
+		if len(blockMetadata.ConsenterIds) != len(configMetadata.Consenters) {
+			return nil, errors.Errorf("%d consenter IDs for %d consenters", len(blockMetadata.ConsenterIds), len(configMetadata.Consenters))
+		}
+		for id, consenter := range etcdraft.CreateConsentersMap(blockMetadata, configMetadata) {
+			consenters = append(consenters, types.ConsenterInfo{ID: id, Host: consenter.Host, Port: consenter.Port})
+		}
+	} else {
+		for _, consenter := range ordererConfig.Consenters() {
+			consenters = append(consenters, types.ConsenterInfo{ID: uint64(consenter.Id), Host: consenter.Host, Port: consenter.Port})
+		}
+	}
+
+	sort.Slice(consenters, func(i, j int) bool {
+		return consenters[i].ID < consenters[j].ID
+	})
+
+	return consenters, nil
+}
+
+// lastConfigBlock retrieves the last configuration block from the given ledger.
+func lastConfigBlock(reader blockledger.Reader) (*cb.Block, error) {
+	if reader.Height() == 0 {
+		return nil, types.ErrChannelNoConfigBlock
+	}
+	lastBlock, err := blockledger.GetBlockByNumber(reader, reader.Height()-1)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to retrieve block %d", reader.Height()-1)
+	}
+	index, err := protoutil.GetLastConfigIndexFromBlock(lastBlock)
+	if err != nil {
+		return nil, errors.WithMessage(err, "chain did not have appropriately encoded last config in its latest block")
+	}
+	configBlock, err := blockledger.GetBlockByNumber(reader, index)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to retrieve config block %d", index)
+	}
+	return configBlock, nil
+}
+
 // JoinChannel instructs the orderer to create a channel and join it with the provided config block.
 // The URL field is empty, and is to be completed by the caller.
 func (r *Registrar) JoinChannel(channelID string, configBlock *cb.Block) (info types.ChannelInfo, err error) {
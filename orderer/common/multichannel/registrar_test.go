@@ -833,6 +833,54 @@ func TestRegistrar_JoinChannel(t *testing.T) {
 			_, err = os.Stat(joinBlockPath)
 			require.True(t, os.IsNotExist(err))
 			checkMetrics(t, fakeFields, []string{"channel", "my-raft-channel"}, 1, 1, 1)
+
+			// ConfigBlock() and ClusterStatus() are working fine
+			configBlock, err := registrar.ConfigBlock("my-raft-channel")
+			require.NoError(t, err)
+			require.True(t, proto.Equal(genesisBlockAppRaft, configBlock))
+			var consenters []types.ConsenterInfo
+			for i, c := range confAppRaft.Orderer.EtcdRaft.Consenters {
+				consenters = append(consenters, types.ConsenterInfo{ID: uint64(i + 1), Host: c.Host, Port: c.Port})
+			}
+			require.Len(t, consenters, 3)
+			status, err := registrar.ClusterStatus("my-raft-channel")
+			require.NoError(t, err)
+			require.Equal(t, types.ClusterStatus{Name: "my-raft-channel", ConsensusRelation: "consenter", Status: "active", Height: 0x1, Consenters: consenters}, status)
+
+			// The consenters are reported by the chain when it tracks them
+			consenters[1].Leader = true
+			registrar.GetChain("my-raft-channel").Chain = &mockChainConsenterStatus{
+				mockChainCluster: registrar.GetChain("my-raft-channel").Chain.(*mockChainCluster),
+				consenters:       consenters,
+			}
+			status, err = registrar.ClusterStatus("my-raft-channel")
+			require.NoError(t, err)
+			require.Equal(t, types.ClusterStatus{Name: "my-raft-channel", ConsensusRelation: "consenter", Status: "active", Height: 0x1, Consenters: consenters}, status)
+
+			// The Raft status and leadership transfer are served by Raft chains only
+			_, err = registrar.RaftStatus("my-raft-channel")
+			require.Equal(t, types.ErrNotRaftConsenter, err)
+			require.Equal(t, types.ErrNotRaftConsenter, registrar.TransferLeadership("my-raft-channel", 2))
+
+			raftChain := &mockChainRaft{
+				mockChainCluster: registrar.GetChain("my-raft-channel").Chain.(*mockChainConsenterStatus).mockChainCluster,
+				raftStatus:       types.RaftStatus{ID: 1, State: "StateLeader", Term: 2, Leader: 1, CommitIndex: 5, AppliedIndex: 5},
+				transferErr:      types.ErrNotLeader,
+			}
+			registrar.GetChain("my-raft-channel").Chain = raftChain
+			raftStatus, err := registrar.RaftStatus("my-raft-channel")
+			require.NoError(t, err)
+			require.Equal(t, types.RaftStatus{Name: "my-raft-channel", ID: 1, State: "StateLeader", Term: 2, Leader: 1, CommitIndex: 5, AppliedIndex: 5}, raftStatus)
+			require.Equal(t, types.ErrNotLeader, registrar.TransferLeadership("my-raft-channel", 2))
+			require.Equal(t, []uint64{2}, raftChain.transferredTo)
+
+			_, err = registrar.ConfigBlock("not-a-channel")
+			require.Equal(t, types.ErrChannelNotExist, err)
+			_, err = registrar.ClusterStatus("not-a-channel")
+			require.Equal(t, types.ErrChannelNotExist, err)
+			_, err = registrar.RaftStatus("not-a-channel")
+			require.Equal(t, types.ErrChannelNotExist, err)
+			require.Equal(t, types.ErrChannelNotExist, registrar.TransferLeadership("not-a-channel", 2))
 		})
 	})
 
@@ -902,6 +950,23 @@ func TestRegistrar_JoinChannel(t *testing.T) {
 		require.NotNil(t, fChain)
 		fChain.Halt()
 
+		// ConfigBlock() and ClusterStatus() are working fine before the first block is pulled
+		_, err = registrar.ConfigBlock("my-raft-channel")
+		require.Equal(t, types.ErrChannelNoConfigBlock, err)
+		status, err := registrar.ClusterStatus("my-raft-channel")
+		require.NoError(t, err)
+		require.Equal(t, "follower", string(status.ConsensusRelation))
+		require.Equal(t, "onboarding", string(status.Status))
+		require.Empty(t, status.Consenters)
+		require.NotNil(t, status.CatchUp)
+		require.Equal(t, uint64(10), *status.CatchUp.JoinBlockNumber)
+		require.Equal(t, uint64(0), status.CatchUp.StartHeight)
+
+		// A follower has no Raft state
+		_, err = registrar.RaftStatus("my-raft-channel")
+		require.Equal(t, types.ErrNotRaftConsenter, err)
+		require.Equal(t, types.ErrNotRaftConsenter, registrar.TransferLeadership("my-raft-channel", 1))
+
 		checkMetrics(t, fakeFields, []string{"channel", "my-raft-channel"}, 2, 2, 1)
 	})
 
@@ -31,6 +31,31 @@ func (c *mockChainCluster) StatusReport() (types.ConsensusRelation, types.Status
 	return types.ConsensusRelationConsenter, types.StatusActive
 }
 
+type mockChainConsenterStatus struct {
+	*mockChainCluster
+	consenters []types.ConsenterInfo
+}
+
+func (c *mockChainConsenterStatus) ConsenterStatus() []types.ConsenterInfo {
+	return c.consenters
+}
+
+type mockChainRaft struct {
+	*mockChainCluster
+	raftStatus    types.RaftStatus
+	transferErr   error
+	transferredTo []uint64
+}
+
+func (c *mockChainRaft) RaftStatus() (types.RaftStatus, error) {
+	return c.raftStatus, nil
+}
+
+func (c *mockChainRaft) TransferLeadership(to uint64) error {
+	c.transferredTo = append(c.transferredTo, to)
+	return c.transferErr
+}
+
 type mockChain struct {
 	queue    chan *cb.Envelope
 	cutter   blockcutter.Receiver
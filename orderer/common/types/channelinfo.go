@@ -85,3 +85,105 @@ type ChannelInfo struct {
 	// Current block height.
 	Height uint64 `json:"height"`
 }
+
+// ConfigBlock carries the response to an HTTP request to fetch the last config block of a channel.
+// This is marshaled into the body of the HTTP response.
+// swagger:model configBlock
+type ConfigBlock struct {
+	// The channel name.
+	Name string `json:"name"`
+	// The number of the last config block.
+	Number uint64 `json:"number"`
+	// The last config block, marshaled as a protobuf common.Block and encoded in base64.
+	Block []byte `json:"block,omitempty"`
+}
+
+// ClusterStatus carries the response to an HTTP request for the status of the orderer in the cluster of a channel.
+// This is marshaled into the body of the HTTP response.
+// swagger:model clusterStatus
+type ClusterStatus struct {
+	// The channel name.
+	Name string `json:"name"`
+	// The channel relative URL (no Host:Port, only path), e.g.: "/participation/v1/channels/my-channel".
+	URL string `json:"url"`
+	// Whether the orderer is a “consenter”, ”follower”, or "config-tracker" of
+	// the cluster for this channel.
+	// For non cluster consensus types (solo) it is "other".
+	ConsensusRelation ConsensusRelation `json:"consensusRelation"`
+	// Whether the orderer is ”onboarding”, ”active”, or "inactive", for this channel.
+	Status Status `json:"status"`
+	// Current block height.
+	Height uint64 `json:"height"`
+	// The consenters set of the channel, ordered by ID, empty if the orderer has no config block of the channel yet.
+	Consenters []ConsenterInfo `json:"consenters"`
+	// The progress of the orderer in pulling blocks from the cluster, nil unless the orderer is following the
+	// cluster or onboarding.
+	CatchUp *CatchUpProgress `json:"catchUp"`
+}
+
+// ConsenterInfo carries the info of a single consenter of a channel.
+type ConsenterInfo struct {
+	// The ID of the consenter in the consensus protocol (e.g. the Raft node ID), zero if unknown.
+	ID uint64 `json:"id"`
+	// The host of the cluster endpoint of the consenter.
+	Host string `json:"host"`
+	// The port of the cluster endpoint of the consenter.
+	Port uint32 `json:"port"`
+	// Whether the consenter is the leader of the cluster, as last known by this orderer.
+	Leader bool `json:"leader"`
+}
+
+// CatchUpProgress carries the progress of a follower in pulling blocks from the cluster.
+type CatchUpProgress struct {
+	// The number of the join-block, if the orderer joined the channel with a join-block.
+	JoinBlockNumber *uint64 `json:"joinBlockNumber,omitempty"`
+	// The block height of the orderer when it started following the cluster.
+	StartHeight uint64 `json:"startHeight"`
+	// The block height the orderer is pulling blocks up to, zero until it learns the height of the cluster.
+	TargetHeight uint64 `json:"targetHeight"`
+}
+
+// RaftStatus carries the response to an HTTP request for the Raft status of the orderer in a channel.
+// This is marshaled into the body of the HTTP response.
+// swagger:model raftStatus
+type RaftStatus struct {
+	// The channel name.
+	Name string `json:"name"`
+	// The channel relative URL (no Host:Port, only path), e.g.: "/participation/v1/channels/my-channel".
+	URL string `json:"url"`
+	// The Raft ID of the orderer.
+	ID uint64 `json:"id"`
+	// The Raft role of the orderer: "StateFollower", "StateCandidate", "StateLeader" or "StatePreCandidate".
+	State string `json:"state"`
+	// The current Raft term.
+	Term uint64 `json:"term"`
+	// The Raft ID of the leader, zero if there is no leader.
+	Leader uint64 `json:"leader"`
+	// The index of the last Raft entry known to be committed.
+	CommitIndex uint64 `json:"commitIndex"`
+	// The index of the last Raft entry applied by the orderer.
+	AppliedIndex uint64 `json:"appliedIndex"`
+	// The replication progress of the followers, ordered by ID. Only the leader tracks it, it is empty otherwise.
+	Followers []RaftFollowerProgress `json:"followers"`
+}
+
+// RaftFollowerProgress carries the replication progress of a single follower, as tracked by the leader.
+type RaftFollowerProgress struct {
+	// The Raft ID of the follower.
+	ID uint64 `json:"id"`
+	// The index of the last Raft entry known to be replicated to the follower.
+	MatchIndex uint64 `json:"matchIndex"`
+	// The index of the next Raft entry to send to the follower.
+	NextIndex uint64 `json:"nextIndex"`
+	// The replication state of the follower: "StateProbe", "StateReplicate" or "StateSnapshot".
+	State string `json:"state"`
+	// Whether the follower was recently active.
+	RecentActive bool `json:"recentActive"`
+}
+
+// LeadershipTransfer carries the body of an HTTP request to transfer the leadership of a channel.
+// swagger:model leadershipTransfer
+type LeadershipTransfer struct {
+	// The Raft ID of the consenter to transfer the leadership to; zero lets the leader pick a recently active follower.
+	ConsenterID uint64 `json:"consenterID"`
+}
@@ -89,3 +89,93 @@ func TestChannelInfo(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, info.Height, info2.Height)
 }
+
+func TestConfigBlock(t *testing.T) {
+	configBlock := types.ConfigBlock{
+		Name:   "a",
+		Number: 3,
+		Block:  []byte("block"),
+	}
+
+	buff, err := json.Marshal(configBlock)
+	require.NoError(t, err)
+	require.Equal(t, `{"name":"a","number":3,"block":"YmxvY2s="}`, string(buff))
+
+	var configBlock2 types.ConfigBlock
+	err = json.Unmarshal(buff, &configBlock2)
+	require.NoError(t, err)
+	require.Equal(t, configBlock, configBlock2)
+
+	configBlock.Block = nil
+	buff, err = json.Marshal(configBlock)
+	require.NoError(t, err)
+	require.Equal(t, `{"name":"a","number":3}`, string(buff))
+}
+
+func TestClusterStatus(t *testing.T) {
+	status := types.ClusterStatus{
+		Name:              "a",
+		URL:               "/api/channels/a/cluster-status",
+		ConsensusRelation: types.ConsensusRelationConsenter,
+		Status:            types.StatusActive,
+		Height:            5,
+		Consenters: []types.ConsenterInfo{
+			{ID: 1, Host: "orderer1", Port: 7050, Leader: true},
+			{ID: 2, Host: "orderer2", Port: 7050},
+		},
+	}
+
+	buff, err := json.Marshal(status)
+	require.NoError(t, err)
+	require.Equal(t, `{"name":"a","url":"/api/channels/a/cluster-status","consensusRelation":"consenter","status":"active","height":5,`+
+		`"consenters":[{"id":1,"host":"orderer1","port":7050,"leader":true},{"id":2,"host":"orderer2","port":7050,"leader":false}],"catchUp":null}`, string(buff))
+
+	joinBlockNumber := uint64(10)
+	status.ConsensusRelation = types.ConsensusRelationFollower
+	status.Status = types.StatusOnBoarding
+	status.CatchUp = &types.CatchUpProgress{JoinBlockNumber: &joinBlockNumber, StartHeight: 2, TargetHeight: 11}
+	status.Consenters = nil
+
+	buff, err = json.Marshal(status)
+	require.NoError(t, err)
+	require.Equal(t, `{"name":"a","url":"/api/channels/a/cluster-status","consensusRelation":"follower","status":"onboarding","height":5,`+
+		`"consenters":null,"catchUp":{"joinBlockNumber":10,"startHeight":2,"targetHeight":11}}`, string(buff))
+
+	var status2 types.ClusterStatus
+	err = json.Unmarshal(buff, &status2)
+	require.NoError(t, err)
+	require.Equal(t, status, status2)
+}
+
+func TestRaftStatus(t *testing.T) {
+	status := types.RaftStatus{
+		Name:         "a",
+		URL:          "/api/channels/a/raft-status",
+		ID:           1,
+		State:        "StateLeader",
+		Term:         3,
+		Leader:       1,
+		CommitIndex:  12,
+		AppliedIndex: 11,
+		Followers: []types.RaftFollowerProgress{
+			{ID: 2, MatchIndex: 12, NextIndex: 13, State: "StateReplicate", RecentActive: true},
+		},
+	}
+
+	buff, err := json.Marshal(status)
+	require.NoError(t, err)
+	require.Equal(t, `{"name":"a","url":"/api/channels/a/raft-status","id":1,"state":"StateLeader","term":3,"leader":1,`+
+		`"commitIndex":12,"appliedIndex":11,"followers":[{"id":2,"matchIndex":12,"nextIndex":13,"state":"StateReplicate","recentActive":true}]}`, string(buff))
+
+	var status2 types.RaftStatus
+	err = json.Unmarshal(buff, &status2)
+	require.NoError(t, err)
+	require.Equal(t, status, status2)
+}
+
+func TestLeadershipTransfer(t *testing.T) {
+	var transfer types.LeadershipTransfer
+	err := json.Unmarshal([]byte(`{"consenterID":2}`), &transfer)
+	require.NoError(t, err)
+	require.Equal(t, types.LeadershipTransfer{ConsenterID: 2}, transfer)
+}
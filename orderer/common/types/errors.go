@@ -32,3 +32,21 @@ var ErrChannelPendingRemoval = errors.New("channel pending removal")
 
 // ErrChannelRemovalFailure is returned when a removal attempt failure has been recorded.
 var ErrChannelRemovalFailure = errors.New("channel removal failure")
+
+// ErrChannelNoConfigBlock is returned when trying to get the config of a channel whose ledger has no block yet.
+var ErrChannelNoConfigBlock = errors.New("channel has no config block")
+
+// ErrNotRaftConsenter is returned when trying to get the Raft status of, or transfer the leadership of, a channel the
+// orderer is not a Raft consenter of.
+var ErrNotRaftConsenter = errors.New("orderer is not a Raft consenter of the channel")
+
+// ErrNotLeader is returned when trying to transfer the leadership of a channel from an orderer that is not the leader.
+var ErrNotLeader = errors.New("orderer is not the leader of the channel")
+
+// ErrConsenterNotExist is returned when trying to transfer the leadership of a channel to a consenter that is not in
+// the consenters set of the channel.
+var ErrConsenterNotExist = errors.New("consenter does not exist")
+
+// ErrLeadershipTransferInProgress is returned when trying to transfer the leadership of a channel while another
+// leadership transfer of the channel is in progress.
+var ErrLeadershipTransferInProgress = errors.New("a leadership transfer is already in progress")
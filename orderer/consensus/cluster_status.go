@@ -30,3 +30,28 @@ type StaticStatusReporter struct {
 func (s StaticStatusReporter) StatusReport() (types.ConsensusRelation, types.Status) {
 	return s.ConsensusRelation, s.Status
 }
+
+// ConsenterStatusReporter is implemented by cluster-type Chain implementations that track the consenters set of the
+// channel and its leader. This information is used to generate the types.ClusterStatus in response to a
+// "cluster status" request on a particular channel.
+//
+// The consenters set of chains that do not implement this interface is read from the last config block of the channel.
+type ConsenterStatusReporter interface {
+	// ConsenterStatus provides the consenters set of the channel, ordered by ID.
+	ConsenterStatus() []types.ConsenterInfo
+}
+
+// RaftStatusReporter is implemented by Raft-based Chain implementations. It allows the node to report its Raft state
+// for the channel, in response to a "Raft status" request on a particular channel.
+type RaftStatusReporter interface {
+	// RaftStatus provides the Raft state of the node, and, on the leader, the replication progress of the followers.
+	RaftStatus() (types.RaftStatus, error)
+}
+
+// LeadershipTransferrer is implemented by leader-based Chain implementations that allow an admin to move the
+// leadership of the channel to another consenter, e.g. before taking the leader down for maintenance.
+type LeadershipTransferrer interface {
+	// TransferLeadership transfers the leadership to the consenter with the given ID, or to a consenter picked by the
+	// leader if the ID is zero. Blocks until the leadership is transferred or the transfer fails.
+	TransferLeadership(to uint64) error
+}
@@ -10,6 +10,7 @@ import (
 	"context"
 	"encoding/pem"
 	"fmt"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -1520,6 +1521,87 @@ func (c *Chain) StatusReport() (types.ConsensusRelation, types.Status) {
 	return c.consensusRelation, c.status
 }
 
+// ConsenterStatus returns the consenters set of the channel, ordered by Raft ID, marking the last known leader.
+func (c *Chain) ConsenterStatus() []types.ConsenterInfo {
+	leader := atomic.LoadUint64(&c.lastKnownLeader)
+
+	c.raftMetadataLock.RLock()
+	defer c.raftMetadataLock.RUnlock()
+
+	consenters := make([]types.ConsenterInfo, 0, len(c.opts.Consenters))
+	for raftID, consenter := range c.opts.Consenters {
+		consenters = append(consenters, types.ConsenterInfo{
+			ID:     raftID,
+			Host:   consenter.Host,
+			Port:   consenter.Port,
+			Leader: raftID == leader,
+		})
+	}
+	sort.Slice(consenters, func(i, j int) bool {
+		return consenters[i].ID < consenters[j].ID
+	})
+
+	return consenters
+}
+
+// RaftStatus returns the Raft state of the node, along with the replication progress of the followers, ordered by
+// Raft ID. The progress of the followers is tracked only by the leader.
+func (c *Chain) RaftStatus() (types.RaftStatus, error) {
+	if err := c.isRunning(); err != nil {
+		return types.RaftStatus{}, err
+	}
+
+	status := c.Node.Status()
+	raftStatus := types.RaftStatus{
+		ID:           status.ID,
+		State:        status.RaftState.String(),
+		Term:         status.Term,
+		Leader:       status.Lead,
+		CommitIndex:  status.Commit,
+		AppliedIndex: status.Applied,
+		Followers:    []types.RaftFollowerProgress{},
+	}
+	for id, pr := range status.Progress {
+		if id == status.ID {
+			continue
+		}
+		raftStatus.Followers = append(raftStatus.Followers, types.RaftFollowerProgress{
+			ID:           id,
+			MatchIndex:   pr.Match,
+			NextIndex:    pr.Next,
+			State:        pr.State.String(),
+			RecentActive: pr.RecentActive,
+		})
+	}
+	sort.Slice(raftStatus.Followers, func(i, j int) bool {
+		return raftStatus.Followers[i].ID < raftStatus.Followers[j].ID
+	})
+
+	return raftStatus, nil
+}
+
+// TransferLeadership transfers the leadership of the channel to the consenter with the given Raft ID. If the ID is
+// zero, the leader picks a follower that is recently active. It must be called on the leader, and it blocks until
+// the leadership is transferred or when a timeout expires. It fails if another leadership transfer is in progress.
+func (c *Chain) TransferLeadership(to uint64) error {
+	if err := c.isRunning(); err != nil {
+		return err
+	}
+
+	if to != raft.None {
+		c.raftMetadataLock.RLock()
+		_, exists := c.opts.Consenters[to]
+		c.raftMetadataLock.RUnlock()
+
+		if !exists {
+			return types.ErrConsenterNotExist
+		}
+	}
+
+	c.logger.Infof("Leadership transfer to %d requested by admin", to)
+	return c.Node.tryTransferLeadership(to)
+}
+
 func (c *Chain) suspectEviction() bool {
 	if c.isRunning() != nil {
 		return false
@@ -290,6 +290,25 @@ var _ = Describe("Chain", func() {
 		})
 
 		Context("when no Raft leader is elected", func() {
+			It("reports the consenters without a leader", func() {
+				Expect(chain.ConsenterStatus()).To(Equal([]orderer_types.ConsenterInfo{
+					{ID: 1, Host: consenters[1].Host, Port: consenters[1].Port},
+				}))
+			})
+
+			It("reports the Raft status without a leader", func() {
+				status, err := chain.RaftStatus()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(status.ID).To(Equal(uint64(1)))
+				Expect(status.Leader).To(Equal(uint64(0)))
+				Expect(status.State).To(Equal("StateFollower"))
+				Expect(status.Followers).To(BeEmpty())
+			})
+
+			It("fails to transfer leadership", func() {
+				Expect(chain.TransferLeadership(0)).To(MatchError(etcdraft.ErrNoLeader))
+			})
+
 			It("fails to order envelope", func() {
 				err := chain.Order(env, 0)
 				Expect(err).To(MatchError("no Raft leader"))
@@ -316,6 +335,43 @@ var _ = Describe("Chain", func() {
 				campaign(chain, observeC)
 			})
 
+			It("reports the consenters with the leader", func() {
+				Expect(chain.ConsenterStatus()).To(Equal([]orderer_types.ConsenterInfo{
+					{ID: 1, Host: consenters[1].Host, Port: consenters[1].Port, Leader: true},
+				}))
+			})
+
+			It("reports the Raft status with the leader", func() {
+				status, err := chain.RaftStatus()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(status.ID).To(Equal(uint64(1)))
+				Expect(status.Leader).To(Equal(uint64(1)))
+				Expect(status.State).To(Equal("StateLeader"))
+				Expect(status.Term).NotTo(BeZero())
+				Expect(status.CommitIndex).NotTo(BeZero())
+				Expect(status.Followers).To(BeEmpty())
+			})
+
+			It("fails to transfer leadership to an unknown consenter", func() {
+				Expect(chain.TransferLeadership(2)).To(MatchError(orderer_types.ErrConsenterNotExist))
+			})
+
+			It("keeps the leadership when asked to transfer it to itself", func() {
+				Expect(chain.TransferLeadership(1)).To(Succeed())
+				Consistently(observeC).ShouldNot(Receive())
+			})
+
+			It("fails to transfer leadership without followers", func() {
+				Expect(chain.TransferLeadership(0)).To(MatchError(etcdraft.ErrNoAvailableLeaderCandidate))
+			})
+
+			It("fails to report the Raft status and transfer leadership if chain is halted", func() {
+				chain.Halt()
+				_, err := chain.RaftStatus()
+				Expect(err).To(MatchError("chain is stopped"))
+				Expect(chain.TransferLeadership(0)).To(MatchError("chain is stopped"))
+			})
+
 			It("updates metrics upon leader election", func() {
 				Expect(fakeFields.fakeIsLeader.SetCallCount()).To(Equal(2))
 				Expect(fakeFields.fakeIsLeader.SetArgsForCall(1)).To(Equal(float64(1)))
@@ -2411,6 +2467,62 @@ var _ = Describe("Chain", func() {
 				Expect(c3.fakeFields.fakeIsLeader.SetArgsForCall(0)).Should(Equal(float64(0)))
 			})
 
+			It("reports the replication progress of the followers on the leader only", func() {
+				Eventually(func() []orderer_types.RaftFollowerProgress {
+					status, err := c1.RaftStatus()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(status.Leader).To(Equal(uint64(1)))
+					Expect(status.State).To(Equal("StateLeader"))
+					for i := range status.Followers {
+						Expect(status.Followers[i].MatchIndex).To(Equal(status.CommitIndex))
+						status.Followers[i].MatchIndex = 0
+						status.Followers[i].NextIndex = 0
+					}
+					return status.Followers
+				}, LongEventualTimeout).Should(Equal([]orderer_types.RaftFollowerProgress{
+					{ID: 2, State: "StateReplicate", RecentActive: true},
+					{ID: 3, State: "StateReplicate", RecentActive: true},
+				}))
+
+				status, err := c2.RaftStatus()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(status.ID).To(Equal(uint64(2)))
+				Expect(status.Leader).To(Equal(uint64(1)))
+				Expect(status.State).To(Equal("StateFollower"))
+				Expect(status.Followers).To(BeEmpty())
+			})
+
+			It("transfers leadership to the requested consenter", func() {
+				By("requesting the transfer on a follower")
+				Expect(c2.TransferLeadership(3)).To(MatchError(orderer_types.ErrNotLeader))
+
+				By("requesting the transfer on the leader")
+				Eventually(func() error {
+					return c1.TransferLeadership(3)
+				}, LongEventualTimeout).Should(Succeed())
+
+				Eventually(c3.observe, LongEventualTimeout).Should(Receive(StateEqual(3, raft.StateLeader)))
+				Eventually(c1.observe, LongEventualTimeout).Should(Receive(StateEqual(3, raft.StateFollower)))
+
+				By("ordering on the new leader")
+				c3.cutter.SetCutNext(true)
+				Expect(c3.Order(env, 0)).To(Succeed())
+				network.exec(func(c *chain) {
+					Eventually(c.support.WriteBlockCallCount, LongEventualTimeout).Should(Equal(1))
+				})
+			})
+
+			It("transfers leadership to a follower picked by the leader", func() {
+				Eventually(func() error {
+					return c1.TransferLeadership(0)
+				}, LongEventualTimeout).Should(Succeed())
+
+				Eventually(c1.observe, LongEventualTimeout).Should(Receive(BeFollower()))
+				status, err := c1.RaftStatus()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(status.Leader).To(Or(Equal(uint64(2)), Equal(uint64(3))))
+			})
+
 			It("orders envelope on leader", func() {
 				By("instructed to cut next block")
 				c1.cutter.SetCutNext(true)
@@ -6,6 +6,16 @@ import (
 )
 
 type OrdererCapabilities struct {
+	AdaptiveBatchSizeStub        func() bool
+	adaptiveBatchSizeMutex       sync.RWMutex
+	adaptiveBatchSizeArgsForCall []struct {
+	}
+	adaptiveBatchSizeReturns struct {
+		result1 bool
+	}
+	adaptiveBatchSizeReturnsOnCall map[int]struct {
+		result1 bool
+	}
 	ConsensusTypeMigrationStub        func() bool
 	consensusTypeMigrationMutex       sync.RWMutex
 	consensusTypeMigrationArgsForCall []struct {
@@ -70,6 +80,59 @@ type OrdererCapabilities struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *OrdererCapabilities) AdaptiveBatchSize() bool {
+	fake.adaptiveBatchSizeMutex.Lock()
+	ret, specificReturn := fake.adaptiveBatchSizeReturnsOnCall[len(fake.adaptiveBatchSizeArgsForCall)]
+	fake.adaptiveBatchSizeArgsForCall = append(fake.adaptiveBatchSizeArgsForCall, struct {
+	}{})
+	stub := fake.AdaptiveBatchSizeStub
+	fakeReturns := fake.adaptiveBatchSizeReturns
+	fake.recordInvocation("AdaptiveBatchSize", []interface{}{})
+	fake.adaptiveBatchSizeMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *OrdererCapabilities) AdaptiveBatchSizeCallCount() int {
+	fake.adaptiveBatchSizeMutex.RLock()
+	defer fake.adaptiveBatchSizeMutex.RUnlock()
+	return len(fake.adaptiveBatchSizeArgsForCall)
+}
+
+func (fake *OrdererCapabilities) AdaptiveBatchSizeCalls(stub func() bool) {
+	fake.adaptiveBatchSizeMutex.Lock()
+	defer fake.adaptiveBatchSizeMutex.Unlock()
+	fake.AdaptiveBatchSizeStub = stub
+}
+
+func (fake *OrdererCapabilities) AdaptiveBatchSizeReturns(result1 bool) {
+	fake.adaptiveBatchSizeMutex.Lock()
+	defer fake.adaptiveBatchSizeMutex.Unlock()
+	fake.AdaptiveBatchSizeStub = nil
+	fake.adaptiveBatchSizeReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *OrdererCapabilities) AdaptiveBatchSizeReturnsOnCall(i int, result1 bool) {
+	fake.adaptiveBatchSizeMutex.Lock()
+	defer fake.adaptiveBatchSizeMutex.Unlock()
+	fake.AdaptiveBatchSizeStub = nil
+	if fake.adaptiveBatchSizeReturnsOnCall == nil {
+		fake.adaptiveBatchSizeReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.adaptiveBatchSizeReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *OrdererCapabilities) ConsensusTypeMigration() bool {
 	fake.consensusTypeMigrationMutex.Lock()
 	ret, specificReturn := fake.consensusTypeMigrationReturnsOnCall[len(fake.consensusTypeMigrationArgsForCall)]
@@ -391,6 +454,8 @@ func (fake *OrdererCapabilities) UseChannelCreationPolicyAsAdminsReturnsOnCall(i
 func (fake *OrdererCapabilities) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.adaptiveBatchSizeMutex.RLock()
+	defer fake.adaptiveBatchSizeMutex.RUnlock()
 	fake.consensusTypeMigrationMutex.RLock()
 	defer fake.consensusTypeMigrationMutex.RUnlock()
 	fake.expirationCheckMutex.RLock()
@@ -18,6 +18,7 @@ import (
 	"github.com/hyperledger/fabric-protos-go/orderer"
 	"github.com/hyperledger/fabric-protos-go/orderer/etcdraft"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/orderer/common/types"
 	"github.com/hyperledger/fabric/protoutil"
 	"github.com/pkg/errors"
 	"go.etcd.io/etcd/raft/v3"
@@ -54,6 +55,7 @@ type node struct {
 
 	metadata *etcdraft.BlockMetadata
 
+	transferLock             sync.Mutex
 	leaderChangeSubscription atomic.Value
 
 	raft.Node
@@ -247,6 +249,36 @@ func (n *node) abdicateLeadership() error {
 		n.logger.Infof("abdicateLeader took %v", time.Since(start))
 	}()
 
+	// Abdication waits for a leadership transfer in progress, after which this node may no longer be the leader
+	n.transferLock.Lock()
+	defer n.transferLock.Unlock()
+
+	err := n.transferLeadership(raft.None)
+	if err == types.ErrNotLeader {
+		n.logger.Warn("Leader has changed since asked to transfer leadership")
+		return nil
+	}
+
+	return err
+}
+
+// tryTransferLeadership attempts to transfer leadership to the given transferee, unless another leadership transfer
+// is in progress, in which case it returns types.ErrLeadershipTransferInProgress right away.
+func (n *node) tryTransferLeadership(transferee uint64) error {
+	if !n.transferLock.TryLock() {
+		return types.ErrLeadershipTransferInProgress
+	}
+	defer n.transferLock.Unlock()
+
+	return n.transferLeadership(transferee)
+}
+
+// transferLeadership attempts to transfer leadership to the given transferee. If the transferee is raft.None,
+// it picks a node that is recently active instead.
+// Blocks until leadership transfer happens or when a timeout expires.
+// Returns error upon failure. The caller must hold the transferLock, so that only one leadership transfer at a time
+// reads the status of the node and is subscribed to leader changes.
+func (n *node) transferLeadership(transferee uint64) error {
 	status := n.Status()
 
 	if status.Lead == raft.None {
@@ -255,32 +287,47 @@ func (n *node) abdicateLeadership() error {
 	}
 
 	if status.Lead != n.config.ID {
-		n.logger.Warn("Leader has changed since asked to transfer leadership")
+		return types.ErrNotLeader
+	}
+
+	if transferee == status.ID {
+		n.logger.Infof("Leadership is already held by %d", transferee)
 		return nil
 	}
 
-	// register to leader changes
-	notifyC, unsubscribe := n.subscribeToLeaderChange()
-	defer unsubscribe()
+	if transferee == raft.None {
+		for id, pr := range status.Progress {
+			if id == status.ID {
+				continue // skip self
+			}
+
+			if pr.RecentActive && !pr.IsPaused() {
+				transferee = id
+				break
+			}
 
-	var transferee uint64
-	for id, pr := range status.Progress {
-		if id == status.ID {
-			continue // skip self
+			n.logger.Debugf("Node %d is not qualified as transferee because it's either paused or not active", id)
 		}
 
-		if pr.RecentActive && !pr.IsPaused() {
-			transferee = id
-			break
+		if transferee == raft.None {
+			n.logger.Errorf("No follower is qualified as transferee, abort leader transfer")
+			return ErrNoAvailableLeaderCandidate
+		}
+	} else {
+		pr, exists := status.Progress[transferee]
+		if !exists {
+			return types.ErrConsenterNotExist
 		}
 
-		n.logger.Debugf("Node %d is not qualified as transferee because it's either paused or not active", id)
+		if !pr.RecentActive || pr.IsPaused() {
+			n.logger.Errorf("Node %d is not qualified as transferee because it's either paused or not active, abort leader transfer", transferee)
+			return errors.WithMessagef(ErrNoAvailableLeaderCandidate, "node %d is either paused or not active", transferee)
+		}
 	}
 
-	if transferee == raft.None {
-		n.logger.Errorf("No follower is qualified as transferee, abort leader transfer")
-		return ErrNoAvailableLeaderCandidate
-	}
+	// register to leader changes
+	notifyC, unsubscribe := n.subscribeToLeaderChange()
+	defer unsubscribe()
 
 	n.logger.Infof("Transferring leadership to %d", transferee)
 
@@ -0,0 +1,22 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package etcdraft
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/orderer/common/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTryTransferLeadershipInProgress(t *testing.T) {
+	n := &node{}
+	n.transferLock.Lock()
+	defer n.transferLock.Unlock()
+
+	require.Equal(t, types.ErrLeadershipTransferInProgress, n.tryTransferLeadership(2))
+}
@@ -85,7 +85,7 @@ generateOrCheck \
         docs/wrappers/peer_chaincode_postscript.md \
         "${commands[@]}"
 
-commands=("peer lifecycle" "peer lifecycle chaincode" "peer lifecycle chaincode package" "peer lifecycle chaincode install" "peer lifecycle chaincode queryinstalled" "peer lifecycle chaincode getinstalledpackage" "peer lifecycle chaincode calculatepackageid" "peer lifecycle chaincode approveformyorg" "peer lifecycle chaincode queryapproved" "peer lifecycle chaincode checkcommitreadiness" "peer lifecycle chaincode commit" "peer lifecycle chaincode querycommitted")
+commands=("peer lifecycle" "peer lifecycle chaincode" "peer lifecycle chaincode package" "peer lifecycle chaincode install" "peer lifecycle chaincode uninstall" "peer lifecycle chaincode queryinstalled" "peer lifecycle chaincode getinstalledpackage" "peer lifecycle chaincode calculatepackageid" "peer lifecycle chaincode approveformyorg" "peer lifecycle chaincode queryapproved" "peer lifecycle chaincode checkcommitreadiness" "peer lifecycle chaincode commit" "peer lifecycle chaincode querycommitted")
 generateOrCheck \
         docs/source/commands/peerlifecycle.md \
         docs/wrappers/peer_lifecycle_chaincode_preamble.md \
@@ -120,28 +120,28 @@ generateOrCheck \
         docs/wrappers/configtxgen_postscript.md \
         "${commands[@]}"
 
-commands=("cryptogen help" "cryptogen generate" "cryptogen showtemplate" "cryptogen extend" "cryptogen version")
+commands=("cryptogen help" "cryptogen generate" "cryptogen showtemplate" "cryptogen extend" "cryptogen revoke" "cryptogen version")
 generateOrCheck \
         docs/source/commands/cryptogen.md \
         docs/wrappers/cryptogen_preamble.md \
         docs/wrappers/cryptogen_postscript.md \
         "${commands[@]}"
 
-commands=("configtxlator start" "configtxlator proto_encode" "configtxlator proto_decode" "configtxlator compute_update" "configtxlator version")
+commands=("configtxlator start" "configtxlator proto_encode" "configtxlator proto_decode" "configtxlator compute_update" "configtxlator add_org" "configtxlator remove_org" "configtxlator set_batch_timeout" "configtxlator set_batch_size" "configtxlator update_consenter" "configtxlator diff" "configtxlator policy eval" "configtxlator version")
 generateOrCheck \
         docs/source/commands/configtxlator.md \
         docs/wrappers/configtxlator_preamble.md \
         docs/wrappers/configtxlator_postscript.md \
         "${commands[@]}"
 
-commands=("osnadmin channel" "osnadmin channel join" "osnadmin channel list" "osnadmin channel remove")
+commands=("osnadmin channel" "osnadmin channel join" "osnadmin channel list" "osnadmin channel remove" "osnadmin channel config-block" "osnadmin channel cluster-status" "osnadmin channel raft-status" "osnadmin channel transfer-leadership")
 generateOrCheck \
         docs/source/commands/osnadminchannel.md \
         docs/wrappers/osnadmin_channel_preamble.md \
         docs/wrappers/osnadmin_channel_postscript.md \
         "${commands[@]}"
 
-commands=("ledgerutil compare" "ledgerutil identifytxs" "ledgerutil verify")
+commands=("ledgerutil compare" "ledgerutil identifytxs" "ledgerutil verify" "ledgerutil export" "ledgerutil blocks" "ledgerutil tx")
 generateOrCheck \
         docs/source/commands/ledgerutil.md \
         docs/wrappers/ledgerutil_preamble.md \